@@ -0,0 +1,195 @@
+package incus
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/ioprogress"
+)
+
+// DefaultBulkParallelism is the number of operations run at the same time by a bulk run, unless specified.
+const DefaultBulkParallelism = 4
+
+// BulkArgs holds the options of a bulk run.
+type BulkArgs struct {
+	// Maximum number of operations running at the same time (DefaultBulkParallelism if 0)
+	Parallelism int
+
+	// Channel receiving the progress of the operations, which must be consumed until it's closed once
+	// the bulk run completes (ignored if nil)
+	Progress chan<- BulkProgress
+}
+
+// BulkProgress is a progress update of the operation on one of the instances of a bulk run.
+type BulkProgress struct {
+	// Name of the instance.
+	Name string
+
+	// Progress of the operation.
+	Progress ioprogress.ProgressData
+
+	// Done is set once the operation completed, with Err set if it failed.
+	Done bool
+	Err  error
+}
+
+// BulkError aggregates the errors of the operations of a bulk run.
+type BulkError struct {
+	// Errors of the failed operations by instance name.
+	Errors map[string]error
+}
+
+// Error returns the errors sorted by instance name.
+func (e *BulkError) Error() string {
+	names := make([]string, 0, len(e.Errors))
+	for name := range e.Errors {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	messages := make([]string, 0, len(names))
+	for _, name := range names {
+		messages = append(messages, fmt.Sprintf("%s: %v", name, e.Errors[name]))
+	}
+
+	return fmt.Sprintf("Failed on %d instance(s): %s", len(names), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors, so that errors.Is and errors.As apply to them.
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// bulkProgress sends the progress updates of a bulk run, dropping the ones received after its completion.
+type bulkProgress struct {
+	ch     chan<- BulkProgress
+	mu     sync.Mutex
+	closed bool
+}
+
+// send sends a progress update, unless the bulk run completed.
+func (p *bulkProgress) send(progress BulkProgress) {
+	if p.ch == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.closed {
+		p.ch <- progress
+	}
+}
+
+// close closes the channel once the bulk run completed.
+func (p *bulkProgress) close() {
+	if p.ch == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	close(p.ch)
+}
+
+// RunBulk runs the operation returned by the function for each of the instances and waits for all of them,
+// running at most Parallelism of them at the same time. A failing operation doesn't stop the others, their
+// errors being returned together as a *BulkError.
+func RunBulk(names []string, args *BulkArgs, run func(name string) (Operation, error)) error {
+	if args == nil {
+		args = &BulkArgs{}
+	}
+
+	parallelism := args.Parallelism
+	if parallelism <= 0 {
+		parallelism = DefaultBulkParallelism
+	}
+
+	progress := &bulkProgress{ch: args.Progress}
+	defer progress.close()
+
+	errs := map[string]error{}
+	errsLock := sync.Mutex{}
+
+	slots := make(chan struct{}, parallelism)
+	wg := sync.WaitGroup{}
+	for _, name := range names {
+		wg.Add(1)
+		slots <- struct{}{}
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			err := runBulkOperation(name, progress, run)
+			if err != nil {
+				errsLock.Lock()
+				errs[name] = err
+				errsLock.Unlock()
+			}
+
+			progress.send(BulkProgress{Name: name, Done: true, Err: err})
+		}()
+	}
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return &BulkError{Errors: errs}
+	}
+
+	return nil
+}
+
+// runBulkOperation runs and waits for the operation on one of the instances of a bulk run.
+func runBulkOperation(name string, progress *bulkProgress, run func(name string) (Operation, error)) error {
+	op, err := run(name)
+	if err != nil {
+		return err
+	}
+
+	// The progress is best effort, some operations not supporting handlers.
+	if progress.ch != nil {
+		_, _ = op.AddHandler(OperationProgressHandler(func(data ioprogress.ProgressData) {
+			progress.send(BulkProgress{Name: name, Progress: data})
+		}))
+	}
+
+	return op.Wait()
+}
+
+// BulkUpdateInstanceState changes the state of the instances (start, stop, ...) as a bulk run.
+func BulkUpdateInstanceState(server InstanceServer, names []string, state api.InstanceStatePut, args *BulkArgs) error {
+	return RunBulk(names, args, func(name string) (Operation, error) {
+		return server.UpdateInstanceState(name, state, "")
+	})
+}
+
+// BulkUpdateInstances updates the instances as a bulk run, each instance being changed by the function
+// and updated only if it wasn't modified in the meantime.
+func BulkUpdateInstances(server InstanceServer, names []string, update func(instance *api.Instance) error, args *BulkArgs) error {
+	return RunBulk(names, args, func(name string) (Operation, error) {
+		instance, ETag, err := server.GetInstance(name)
+		if err != nil {
+			return nil, err
+		}
+
+		err = update(instance)
+		if err != nil {
+			return nil, err
+		}
+
+		return server.UpdateInstance(name, instance.Writable(), ETag)
+	})
+}
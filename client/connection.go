@@ -38,6 +38,9 @@ type ConnectionArgs struct {
 	// User agent string
 	UserAgent string
 
+	// Identity to run the requests as (only allowed for server administrators)
+	Impersonate string
+
 	// Authentication type
 	AuthType string
 
@@ -59,12 +62,28 @@ type ConnectionArgs struct {
 	// OpenID Connect tokens
 	OIDCTokens *oidc.Tokens[*oidc.IDTokenClaims]
 
+	// Cache loading the OpenID Connect tokens (unless OIDCTokens is set) and saving them once renewed
+	OIDCTokenCache OIDCTokenCache
+
+	// Handler presenting the verification URL and code of the OpenID Connect device authorization flow
+	// to the user (printed and opened in a browser if nil)
+	OIDCDeviceAuthorizationHandler func(authorization OIDCDeviceAuthorization) error
+
 	// Skip automatic GetServer request upon connection
 	SkipGetServer bool
 
 	// Caching support for image servers
 	CachePath   string
 	CacheExpiry time.Duration
+
+	// Retry policy of the requests failing with a transient error (disabled if nil)
+	Retry *RetryPolicy
+
+	// Number of GET responses to keep and revalidate with their ETag instead of transferring them again (disabled if 0)
+	ResponseCacheSize int
+
+	// SSH jump host to tunnel the connections through (direct connections if nil)
+	SSHJump *SSHJump
 }
 
 // ConnectIncus lets you connect to a remote Incus daemon over HTTPs.
@@ -121,10 +140,13 @@ func ConnectIncusHTTPWithContext(ctx context.Context, args *ConnectionArgs, clie
 		httpBaseURL:        *httpBaseURL,
 		httpProtocol:       "custom",
 		httpUserAgent:      args.UserAgent,
+		httpImpersonate:    args.Impersonate,
 		ctxConnected:       ctxConnected,
 		ctxConnectedCancel: ctxConnectedCancel,
 		eventConns:         make(map[string]*websocket.Conn),
 		eventListeners:     make(map[string][]*EventListener),
+		retryPolicy:        args.Retry,
+		responseCache:      newResponseCache(args.ResponseCacheSize),
 	}
 
 	// Setup the HTTP client
@@ -148,7 +170,8 @@ func ConnectIncusHTTPWithContext(ctx context.Context, args *ConnectionArgs, clie
 //
 // If the path argument is empty, then $INCUS_SOCKET will be used, if
 // unset $INCUS_DIR/unix.socket will be used and if that one isn't set
-// either, then the path will default to /var/lib/incus/unix.socket or /run/incus/unix.socket.
+// either, then the path will default to /var/lib/incus/unix.socket or /run/incus/unix.socket,
+// falling back to the socket of a rootless daemon in $XDG_DATA_HOME/incus.
 func ConnectIncusUnix(path string, args *ConnectionArgs) (InstanceServer, error) {
 	return ConnectIncusUnixWithContext(context.Background(), path, args)
 }
@@ -157,7 +180,8 @@ func ConnectIncusUnix(path string, args *ConnectionArgs) (InstanceServer, error)
 //
 // If the path argument is empty, then $INCUS_SOCKET will be used, if
 // unset $INCUS_DIR/unix.socket will be used and if that one isn't set
-// either, then the path will default to /var/lib/incus/unix.socket or /run/incus/unix.socket.
+// either, then the path will default to /var/lib/incus/unix.socket or /run/incus/unix.socket,
+// falling back to the socket of a rootless daemon in $XDG_DATA_HOME/incus.
 func ConnectIncusUnixWithContext(ctx context.Context, path string, args *ConnectionArgs) (InstanceServer, error) {
 	logger.Debug("Connecting to a local Incus over a Unix socket")
 
@@ -185,6 +209,12 @@ func ConnectIncusUnixWithContext(ctx context.Context, path string, args *Connect
 					incusDir = "/run/incus"
 				} else {
 					incusDir = "/var/lib/incus"
+
+					// Fallback to a rootless daemon of the current user.
+					rootlessDir, err := util.RootlessDir()
+					if err == nil && !util.PathExists(filepath.Join(incusDir, "unix.socket")) && util.PathExists(filepath.Join(rootlessDir, "unix.socket")) {
+						incusDir = rootlessDir
+					}
 				}
 			}
 
@@ -208,10 +238,13 @@ func ConnectIncusUnixWithContext(ctx context.Context, path string, args *Connect
 		httpUnixPath:       path,
 		httpProtocol:       "unix",
 		httpUserAgent:      args.UserAgent,
+		httpImpersonate:    args.Impersonate,
 		ctxConnected:       ctxConnected,
 		ctxConnectedCancel: ctxConnectedCancel,
 		eventConns:         make(map[string]*websocket.Conn),
 		eventListeners:     make(map[string][]*EventListener),
+		retryPolicy:        args.Retry,
+		responseCache:      newResponseCache(args.ResponseCacheSize),
 		project:            projectName,
 	}
 
@@ -237,6 +270,110 @@ func ConnectIncusUnixWithContext(ctx context.Context, path string, args *Connect
 	return &server, nil
 }
 
+// ConnectIncusSSH lets you connect to the local Unix socket of a remote Incus daemon over SSH.
+//
+// The URI is in the form ssh://[user@]host[:port][/path/to/unix.socket], the socket path defaulting
+// to /var/lib/incus/unix.socket. The SSH connection uses the default keys and known hosts of the user
+// (see NewSSHJump), unless a pre-configured one is provided through SSHJump.
+func ConnectIncusSSH(uri string, args *ConnectionArgs) (InstanceServer, error) {
+	return ConnectIncusSSHWithContext(context.Background(), uri, args)
+}
+
+// ConnectIncusSSHWithContext lets you connect to the local Unix socket of a remote Incus daemon over SSH with context.Context.
+//
+// The URI is in the form ssh://[user@]host[:port][/path/to/unix.socket], the socket path defaulting
+// to /var/lib/incus/unix.socket. The SSH connection uses the default keys and known hosts of the user
+// (see NewSSHJump), unless a pre-configured one is provided through SSHJump.
+//
+// Disconnect closes the SSH connection, unless it was provided through SSHJump.
+func ConnectIncusSSHWithContext(ctx context.Context, uri string, args *ConnectionArgs) (InstanceServer, error) {
+	logger.Debug("Connecting to a remote Incus over SSH", logger.Ctx{"url": uri})
+
+	// Use empty args if not specified
+	if args == nil {
+		args = &ConnectionArgs{}
+	}
+
+	sshURL, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if sshURL.Scheme != "ssh" || sshURL.Host == "" {
+		return nil, fmt.Errorf("Invalid SSH URL %q", uri)
+	}
+
+	path := sshURL.Path
+	if path == "" || path == "/" {
+		path = "/var/lib/incus/unix.socket"
+	}
+
+	// The SSH connection is only owned by the client (and closed on Disconnect) when it sets it up.
+	var ownedJump *SSHJump
+
+	jump := args.SSHJump
+	if jump == nil {
+		address := sshURL.Host
+		if sshURL.User != nil {
+			address = sshURL.User.Username() + "@" + address
+		}
+
+		jump, err = NewSSHJump(address, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		ownedJump = jump
+	}
+
+	httpBaseURL, err := url.Parse("http://unix.socket")
+	if err != nil {
+		return nil, err
+	}
+
+	ctxConnected, ctxConnectedCancel := context.WithCancel(context.Background())
+
+	// Initialize the client struct
+	server := ProtocolIncus{
+		ctx:                ctx,
+		httpBaseURL:        *httpBaseURL,
+		httpSSHTarget:      sshURL.Host + path, // Identifies the remote socket when comparing connections.
+		httpProtocol:       "ssh",
+		httpUserAgent:      args.UserAgent,
+		httpImpersonate:    args.Impersonate,
+		ctxConnected:       ctxConnected,
+		ctxConnectedCancel: ctxConnectedCancel,
+		eventConns:         make(map[string]*websocket.Conn),
+		eventListeners:     make(map[string][]*EventListener),
+		retryPolicy:        args.Retry,
+		responseCache:      newResponseCache(args.ResponseCacheSize),
+		sshJump:            ownedJump,
+	}
+
+	// Setup the HTTP client
+	httpClient, err := sshHTTPClient(args, jump, path)
+	if err != nil {
+		server.Disconnect()
+		return nil, err
+	}
+
+	server.http = httpClient
+
+	// Test the connection and seed the server information
+	if !args.SkipGetServer {
+		serverStatus, _, err := server.GetServer()
+		if err != nil {
+			server.Disconnect()
+			return nil, err
+		}
+
+		// Record the server certificate
+		server.httpCertificate = serverStatus.Environment.Certificate
+	}
+
+	return &server, nil
+}
+
 // ConnectPublicIncus lets you connect to a remote public Incus daemon over HTTPs.
 //
 // Unless the remote server is trusted by the system CA, the remote certificate must be provided (TLSServerCert).
@@ -283,6 +420,13 @@ func ConnectSimpleStreams(uri string, args *ConnectionArgs) (ImageServer, error)
 		return nil, err
 	}
 
+	if args.SSHJump != nil {
+		err = setSSHJump(httpClient, args.SSHJump)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	server.http = httpClient
 
 	// Get simplestreams client
@@ -345,6 +489,13 @@ func ConnectOCI(uri string, args *ConnectionArgs) (ImageServer, error) {
 		return nil, err
 	}
 
+	if args.SSHJump != nil {
+		err = setSSHJump(httpClient, args.SSHJump)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	server.http = httpClient
 
 	return &server, nil
@@ -371,10 +522,13 @@ func httpsIncus(ctx context.Context, requestURL string, args *ConnectionArgs) (I
 		httpBaseURL:        *httpBaseURL,
 		httpProtocol:       "https",
 		httpUserAgent:      args.UserAgent,
+		httpImpersonate:    args.Impersonate,
 		ctxConnected:       ctxConnected,
 		ctxConnectedCancel: ctxConnectedCancel,
 		eventConns:         make(map[string]*websocket.Conn),
 		eventListeners:     make(map[string][]*EventListener),
+		retryPolicy:        args.Retry,
+		responseCache:      newResponseCache(args.ResponseCacheSize),
 	}
 
 	if slices.Contains([]string{api.AuthenticationMethodOIDC}, args.AuthType) {
@@ -387,13 +541,23 @@ func httpsIncus(ctx context.Context, requestURL string, args *ConnectionArgs) (I
 		return nil, err
 	}
 
+	if args.SSHJump != nil {
+		err = setSSHJump(httpClient, args.SSHJump)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if args.CookieJar != nil {
 		httpClient.Jar = args.CookieJar
 	}
 
 	server.http = httpClient
 	if args.AuthType == api.AuthenticationMethodOIDC {
-		server.setupOIDCClient(args.OIDCTokens)
+		err = server.setupOIDCClient(args.OIDCTokens, args.OIDCTokenCache, args.OIDCDeviceAuthorizationHandler)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Test the connection and seed the server information
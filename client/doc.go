@@ -154,4 +154,134 @@
 //	if err != nil {
 //	  return err
 //	}
+//
+// # Example - cancellation
+//
+// This stops an instance, giving up on waiting after a minute. The context of the client
+// applies to its requests, websockets and operation waits, and carries the
+// tracing span of the caller, if any, to the server.
+//
+//	// Connect to Incus over the Unix socket
+//	c, err := incus.ConnectIncusUnix("", nil)
+//	if err != nil {
+//	  return err
+//	}
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//	defer cancel()
+//
+//	// Get Incus to stop the instance (background operation)
+//	op, err := c.WithContext(ctx).UpdateInstanceState("my-container", api.InstanceStatePut{Action: "stop"}, "")
+//	if err != nil {
+//	  return err
+//	}
+//
+//	// Wait for the operation to complete, or the timeout
+//	err = op.Wait()
+//	if err != nil {
+//	  return err
+//	}
+//
+// # OpenID Connect
+//
+// When connecting with the OIDC authentication type, the client goes through the device
+// authorization flow once its tokens can't be refreshed anymore, the user entering a code from
+// any device with a browser. Headless consumers, like services or CI jobs, can present the code
+// their own way with OIDCDeviceAuthorizationHandler and keep the tokens between runs with an
+// OIDCTokenCache.
+//
+//	args := &incus.ConnectionArgs{
+//	  AuthType:       api.AuthenticationMethodOIDC,
+//	  OIDCTokenCache: incus.NewOIDCFileTokenCache("/var/lib/my-service/oidc-tokens.json"),
+//	  OIDCDeviceAuthorizationHandler: func(authorization incus.OIDCDeviceAuthorization) error {
+//	    log.Printf("Authenticate at %s with code %s", authorization.VerificationURI, authorization.UserCode)
+//	    return nil
+//	  },
+//	}
+//
+// # Events
+//
+// The events of the servers are received through an EventListener. Along with the raw events of
+// AddHandler, it decodes the lifecycle, logging and operation events for the handlers added with
+// AddLifecycleHandler, AddLoggingHandler and AddOperationHandler, which only get the events passing
+// their filter.
+//
+//	listener, err := c.GetEvents()
+//	if err != nil {
+//	  return err
+//	}
+//
+//	filter := incus.LifecycleEventFilter{Sources: []string{"/1.0/instances"}}
+//	_, err = listener.AddLifecycleHandler(filter, func(event api.Event, lifecycle api.EventLifecycle) {
+//	  fmt.Printf("%s: %s\n", lifecycle.Source, lifecycle.Action)
+//	})
+//	if err != nil {
+//	  return err
+//	}
+//
+// # Progress
+//
+// The transfers done by the client, like image downloads, file pushes and backup exports, report
+// their progress to the ProgressHandler functions of their arguments. They get the stage of the
+// transfer, the bytes transferred, the total size if known and the transfer rate, along with a text
+// to display. The progress of the transfers done by the servers, like instance copies and migrations,
+// is reported the same way by passing an OperationProgressHandler to the AddHandler function of
+// their operation.
+//
+//	op, err := c.CopyInstance(source, instance, nil)
+//	if err != nil {
+//	  return err
+//	}
+//
+//	_, err = op.AddHandler(incus.OperationProgressHandler(func(progress ioprogress.ProgressData) {
+//	  fmt.Printf("%s: %d/%d bytes (%d bytes/s)\n", progress.Stage, progress.TransferredBytes, progress.TotalBytes, progress.Speed)
+//	}))
+//	if err != nil {
+//	  return err
+//	}
+//
+// # Bulk operations
+//
+// RunBulk runs an operation on many instances concurrently, with a bounded parallelism, reporting
+// the progress of all of them on a single channel and their errors together as a *BulkError.
+// BulkUpdateInstanceState and BulkUpdateInstances cover the common cases.
+//
+//	progress := make(chan incus.BulkProgress)
+//	go func() {
+//	  for update := range progress {
+//	    if update.Done {
+//	      fmt.Printf("%s: done (%v)\n", update.Name, update.Err)
+//	    }
+//	  }
+//	}()
+//
+//	err := incus.BulkUpdateInstanceState(c, []string{"c1", "c2", "c3"}, api.InstanceStatePut{Action: "start"}, &incus.BulkArgs{Parallelism: 2, Progress: progress})
+//	if err != nil {
+//	  return err
+//	}
+//
+// # Terminals
+//
+// Interactive exec and console sessions can be attached to any terminal with a TerminalControl,
+// which sends the window size and signals to the session. NewWebsocketTerminal bridges a
+// websocket, like one from a web frontend, to the session: binary messages carry the data and
+// text messages carry the JSON control messages.
+//
+//	control := incus.NewTerminalControl()
+//	terminal := incus.NewWebsocketTerminal(browserConn, control)
+//
+//	args := control.ExecArgs(terminal)
+//	op, err := c.ExecInstance("c1", api.InstanceExecPost{Command: []string{"bash"}, Interactive: true, WaitForWS: true}, args)
+//	if err != nil {
+//	  return err
+//	}
+//
+//	err = op.Wait()
+//	<-args.DataDone
+//
+// # Testing
+//
+// The code using an InstanceServer can be tested against the in-memory server of the
+// client/fake package, which handles instances, profiles, operations and events without
+// a running Incus server.
 package incus
@@ -2,10 +2,14 @@ package incus
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
 )
 
 // The EventListener struct is used to interact with an Incus event stream.
@@ -27,6 +31,32 @@ type EventTarget struct {
 	types    []string
 }
 
+// NewEventListener returns an event listener which isn't attached to a server connection, along with
+// the function delivering events to its handlers.
+//
+// This is meant for the implementations of InstanceServer that don't talk to a server, like fakes,
+// the handlers being called synchronously by the returned function.
+func NewEventListener(ctx context.Context) (*EventListener, func(event api.Event)) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	listener := &EventListener{
+		ctx:       ctx,
+		ctxCancel: cancel,
+	}
+
+	send := func(event api.Event) {
+		if listener.ctx.Err() != nil {
+			return
+		}
+
+		for _, function := range listener.handlers(event.Type) {
+			function(event)
+		}
+	}
+
+	return listener, send
+}
+
 // AddHandler adds a function to be called whenever an event is received.
 func (e *EventListener) AddHandler(types []string, function func(api.Event)) (*EventTarget, error) {
 	if function == nil {
@@ -72,8 +102,32 @@ func (e *EventListener) RemoveHandler(target *EventTarget) error {
 	return fmt.Errorf("Couldn't find this function and event types combination")
 }
 
+// handlers returns the functions of the handlers of the given type of event.
+func (e *EventListener) handlers(eventType string) []func(api.Event) {
+	e.targetsLock.Lock()
+	defer e.targetsLock.Unlock()
+
+	functions := []func(api.Event){}
+	for _, target := range e.targets {
+		if target.types != nil && !slices.Contains(target.types, eventType) {
+			continue
+		}
+
+		functions = append(functions, target.function)
+	}
+
+	return functions
+}
+
 // Disconnect must be used once done listening for events.
 func (e *EventListener) Disconnect() {
+	// Listeners not attached to a connection only need to be turned off.
+	if e.r == nil {
+		e.err = nil
+		e.ctxCancel()
+		return
+	}
+
 	// Handle locking
 	e.r.eventListenersLock.Lock()
 	defer e.r.eventListenersLock.Unlock()
@@ -107,3 +161,158 @@ func (e *EventListener) Wait() error {
 func (e *EventListener) IsActive() bool {
 	return e.ctx.Err() == nil
 }
+
+// EventFilter is the filter common to the typed event handlers, an empty field matching all events.
+type EventFilter struct {
+	// Projects the events must belong to.
+	Projects []string
+
+	// Locations (cluster members) the events must come from.
+	Locations []string
+}
+
+// match returns whether the event passes the filter.
+func (f EventFilter) match(event api.Event) bool {
+	if len(f.Projects) > 0 && !slices.Contains(f.Projects, event.Project) {
+		return false
+	}
+
+	if len(f.Locations) > 0 && !slices.Contains(f.Locations, event.Location) {
+		return false
+	}
+
+	return true
+}
+
+// LifecycleEventFilter is the filter of AddLifecycleHandler.
+type LifecycleEventFilter struct {
+	EventFilter
+
+	// Actions the events must be about, like api.EventLifecycleInstanceStarted.
+	Actions []string
+
+	// Sources the events must be about, either exactly or as a parent path (like "/1.0/instances").
+	Sources []string
+}
+
+// LoggingEventFilter is the filter of AddLoggingHandler.
+type LoggingEventFilter struct {
+	EventFilter
+
+	// Levels the messages must have, like "warning" or "error".
+	Levels []string
+}
+
+// OperationEventFilter is the filter of AddOperationHandler.
+type OperationEventFilter struct {
+	EventFilter
+
+	// Classes the operations must have, like api.OperationClassTask.
+	Classes []string
+
+	// Status codes the operations must have, like api.Success.
+	StatusCodes []api.StatusCode
+}
+
+// AddLifecycleHandler adds a function to be called with the decoded lifecycle events passing the filter.
+func (e *EventListener) AddLifecycleHandler(filter LifecycleEventFilter, function func(event api.Event, lifecycle api.EventLifecycle)) (*EventTarget, error) {
+	if function == nil {
+		return nil, fmt.Errorf("A valid function must be provided")
+	}
+
+	return e.AddHandler([]string{api.EventTypeLifecycle}, func(event api.Event) {
+		if !filter.match(event) {
+			return
+		}
+
+		lifecycle := api.EventLifecycle{}
+		if !decodeEvent(event, &lifecycle) {
+			return
+		}
+
+		if len(filter.Actions) > 0 && !slices.Contains(filter.Actions, lifecycle.Action) {
+			return
+		}
+
+		if len(filter.Sources) > 0 && !slices.ContainsFunc(filter.Sources, func(source string) bool {
+			return matchSource(source, lifecycle.Source)
+		}) {
+			return
+		}
+
+		function(event, lifecycle)
+	})
+}
+
+// AddLoggingHandler adds a function to be called with the decoded logging events passing the filter.
+func (e *EventListener) AddLoggingHandler(filter LoggingEventFilter, function func(event api.Event, logging api.EventLogging)) (*EventTarget, error) {
+	if function == nil {
+		return nil, fmt.Errorf("A valid function must be provided")
+	}
+
+	return e.AddHandler([]string{api.EventTypeLogging}, func(event api.Event) {
+		if !filter.match(event) {
+			return
+		}
+
+		logging := api.EventLogging{}
+		if !decodeEvent(event, &logging) {
+			return
+		}
+
+		if len(filter.Levels) > 0 && !slices.Contains(filter.Levels, logging.Level) {
+			return
+		}
+
+		function(event, logging)
+	})
+}
+
+// AddOperationHandler adds a function to be called with the decoded operation events passing the filter.
+func (e *EventListener) AddOperationHandler(filter OperationEventFilter, function func(event api.Event, op api.Operation)) (*EventTarget, error) {
+	if function == nil {
+		return nil, fmt.Errorf("A valid function must be provided")
+	}
+
+	return e.AddHandler([]string{api.EventTypeOperation}, func(event api.Event) {
+		if !filter.match(event) {
+			return
+		}
+
+		op := api.Operation{}
+		if !decodeEvent(event, &op) {
+			return
+		}
+
+		if len(filter.Classes) > 0 && !slices.Contains(filter.Classes, op.Class) {
+			return
+		}
+
+		if len(filter.StatusCodes) > 0 && !slices.Contains(filter.StatusCodes, op.StatusCode) {
+			return
+		}
+
+		function(event, op)
+	})
+}
+
+// decodeEvent decodes the metadata of the event, skipping the events which can't be decoded.
+func decodeEvent(event api.Event, target any) bool {
+	err := json.Unmarshal(event.Metadata, target)
+	if err != nil {
+		logger.Debug("Skipping event with invalid metadata", logger.Ctx{"type": event.Type, "err": err})
+		return false
+	}
+
+	return true
+}
+
+// matchSource returns whether the source of a lifecycle event is the given one or one of its children.
+func matchSource(filter string, source string) bool {
+	filter = strings.TrimSuffix(filter, "/")
+
+	// Ignore the query string (project, target) of the source.
+	path, _, _ := strings.Cut(source, "?")
+
+	return path == filter || strings.HasPrefix(path, filter+"/")
+}
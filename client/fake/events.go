@@ -0,0 +1,118 @@
+package fake
+
+import (
+	"encoding/json"
+	"time"
+
+	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// eventListener is an event listener of the fake server along with the function sending it events.
+type eventListener struct {
+	listener *incus.EventListener
+	send     func(event api.Event)
+
+	// project is the project whose events are sent to the listener (empty for all projects).
+	project string
+}
+
+// GetEvents returns a listener to the events of the project.
+func (s *Server) GetEvents() (*incus.EventListener, error) {
+	return s.addEventListener(s.project), nil
+}
+
+// GetEventsAllProjects returns a listener to the events of all projects.
+func (s *Server) GetEventsAllProjects() (*incus.EventListener, error) {
+	return s.addEventListener(""), nil
+}
+
+// SendEvent sends the event to the event listeners of its project.
+func (s *Server) SendEvent(event api.Event) error {
+	if event.Project == "" {
+		event.Project = s.project
+	}
+
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	s.sendEvents([]api.Event{event})
+
+	return nil
+}
+
+// addEventListener registers a new event listener, disconnected along with the context of the server.
+func (s *Server) addEventListener(project string) *incus.EventListener {
+	listener, send := incus.NewEventListener(s.ctx)
+
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	s.state.listeners = append(s.state.listeners, &eventListener{listener: listener, send: send, project: project})
+
+	return listener
+}
+
+// apply runs a change to the state of the server, then sends the events it returned unless it failed.
+func (s *Server) apply(change func() ([]api.Event, error)) error {
+	s.state.mu.Lock()
+	events, err := change()
+	s.state.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	s.sendEvents(events)
+
+	return nil
+}
+
+// sendEvents sends the events to the active listeners. The state must not be locked, so that handlers can use the server.
+func (s *Server) sendEvents(events []api.Event) {
+	s.state.mu.Lock()
+
+	listeners := make([]*eventListener, 0, len(s.state.listeners))
+	for _, listener := range s.state.listeners {
+		if listener.listener.IsActive() {
+			listeners = append(listeners, listener)
+		}
+	}
+
+	s.state.listeners = listeners
+	s.state.mu.Unlock()
+
+	for _, event := range events {
+		for _, listener := range listeners {
+			if listener.project != "" && listener.project != event.Project {
+				continue
+			}
+
+			listener.send(event)
+		}
+	}
+}
+
+// newEvent returns an event of the project of the server with the given metadata.
+func (s *Server) newEvent(eventType string, metadata any) api.Event {
+	data, _ := json.Marshal(metadata)
+
+	return api.Event{
+		Type:      eventType,
+		Timestamp: time.Now(),
+		Metadata:  data,
+		Location:  s.location(),
+		Project:   s.project,
+	}
+}
+
+// lifecycleEvent returns a lifecycle event for the given action on a resource of the project.
+func (s *Server) lifecycleEvent(action string, source string, ctx map[string]any) api.Event {
+	return s.newEvent(api.EventTypeLifecycle, api.EventLifecycle{
+		Action:  action,
+		Source:  source,
+		Context: ctx,
+		Project: s.project,
+	})
+}
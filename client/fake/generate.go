@@ -0,0 +1,264 @@
+//go:build ignore
+
+// This program generates the methods of the fake server which aren't implemented, so that it keeps
+// satisfying the InstanceServer interface as the interface grows.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	clientImport = "github.com/lxc/incus/v6/client"
+	output       = "unimplemented.go"
+)
+
+func main() {
+	err := run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	fset := token.NewFileSet()
+
+	iface, err := clientInterface(fset, "InstanceServer")
+	if err != nil {
+		return err
+	}
+
+	implemented, err := implementedMethods(fset)
+	if err != nil {
+		return err
+	}
+
+	// Generate the missing methods, recording the imports they need.
+	imports := map[string]string{clientImport: "incus"}
+	qualifier := func(pkg *types.Package) string {
+		if pkg.Path() == clientImport {
+			return "incus"
+		}
+
+		imports[pkg.Path()] = pkg.Name()
+
+		return pkg.Name()
+	}
+
+	body := &bytes.Buffer{}
+	for i := range iface.NumMethods() {
+		method := iface.Method(i)
+		if implemented[method.Name()] {
+			continue
+		}
+
+		signature, ok := method.Type().(*types.Signature)
+		if !ok {
+			return fmt.Errorf("Unexpected type of method %q", method.Name())
+		}
+
+		writeMethod(body, method.Name(), signature, qualifier)
+	}
+
+	out := &bytes.Buffer{}
+	fmt.Fprintf(out, "// Code generated by generate.go; DO NOT EDIT.\n\npackage fake\n\n")
+	writeImports(out, imports)
+	fmt.Fprintf(out, "%s", body.String())
+
+	source, err := format.Source(out.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(output, source, 0o644)
+}
+
+// clientInterface type checks the client package and returns the given interface.
+func clientInterface(fset *token.FileSet, name string) (*types.Interface, error) {
+	pkg, err := build.Default.ImportDir("..", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []*ast.File{}
+	for _, path := range pkg.GoFiles {
+		file, err := parser.ParseFile(fset, filepath.Join("..", path), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, file)
+	}
+
+	config := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+
+	client, err := config.Check(clientImport, fset, files, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	object := client.Scope().Lookup(name)
+	if object == nil {
+		return nil, fmt.Errorf("Unknown type %q", name)
+	}
+
+	iface, ok := object.Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil, fmt.Errorf("Type %q isn't an interface", name)
+	}
+
+	return iface, nil
+}
+
+// implementedMethods returns the methods of the fake server defined in the package.
+func implementedMethods(fset *token.FileSet) (map[string]bool, error) {
+	paths, err := filepath.Glob("*.go")
+	if err != nil {
+		return nil, err
+	}
+
+	methods := map[string]bool{}
+	for _, path := range paths {
+		if slices.Contains([]string{"generate.go", output}, path) || strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 {
+				continue
+			}
+
+			star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+			if !ok {
+				continue
+			}
+
+			ident, ok := star.X.(*ast.Ident)
+			if ok && ident.Name == "Server" {
+				methods[fn.Name.Name] = true
+			}
+		}
+	}
+
+	return methods, nil
+}
+
+// writeImports writes the import block, grouped like the rest of the tree: standard library, third party, then Incus.
+func writeImports(w *bytes.Buffer, imports map[string]string) {
+	groups := make([][]string, 3)
+	for path := range imports {
+		group := 1
+		if !strings.Contains(strings.Split(path, "/")[0], ".") {
+			group = 0
+		} else if strings.HasPrefix(path, "github.com/lxc/incus/") {
+			group = 2
+		}
+
+		groups[group] = append(groups[group], path)
+	}
+
+	fmt.Fprintf(w, "import (\n")
+
+	for _, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+
+		sort.Strings(group)
+
+		for _, path := range group {
+			if filepath.Base(path) != imports[path] {
+				fmt.Fprintf(w, "\t%s %s\n", imports[path], strconv.Quote(path))
+				continue
+			}
+
+			fmt.Fprintf(w, "\t%s\n", strconv.Quote(path))
+		}
+
+		fmt.Fprintf(w, "\n")
+	}
+
+	fmt.Fprintf(w, ")\n")
+}
+
+// writeMethod writes a method of the fake server returning an error wrapping ErrNotImplemented.
+func writeMethod(w *bytes.Buffer, name string, signature *types.Signature, qualifier types.Qualifier) {
+	params := []string{}
+	for i := range signature.Params().Len() {
+		typ := signature.Params().At(i).Type()
+
+		slice, ok := typ.(*types.Slice)
+		if ok && signature.Variadic() && i == signature.Params().Len()-1 {
+			params = append(params, "_ ..."+types.TypeString(slice.Elem(), qualifier))
+			continue
+		}
+
+		params = append(params, "_ "+types.TypeString(typ, qualifier))
+	}
+
+	results := []string{}
+	values := []string{}
+	hasError := false
+	for i := range signature.Results().Len() {
+		typ := signature.Results().At(i).Type()
+		results = append(results, types.TypeString(typ, qualifier))
+
+		if !hasError && types.Identical(typ, types.Universe.Lookup("error").Type()) {
+			hasError = true
+			values = append(values, fmt.Sprintf("notImplemented(%q)", name))
+			continue
+		}
+
+		values = append(values, zeroValue(typ, qualifier))
+	}
+
+	fmt.Fprintf(w, "\n// %s isn't implemented by the fake server.\n", name)
+	fmt.Fprintf(w, "func (s *Server) %s(%s) (%s) {\n", name, strings.Join(params, ", "), strings.Join(results, ", "))
+
+	if !hasError {
+		fmt.Fprintf(w, "\tpanic(notImplemented(%q))\n}\n", name)
+		return
+	}
+
+	fmt.Fprintf(w, "\treturn %s\n}\n", strings.Join(values, ", "))
+}
+
+// zeroValue returns the zero value of the type.
+func zeroValue(typ types.Type, qualifier types.Qualifier) string {
+	switch underlying := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case underlying.Info()&types.IsString != 0:
+			return `""`
+		case underlying.Info()&types.IsBoolean != 0:
+			return "false"
+		case underlying.Info()&types.IsNumeric != 0:
+			return "0"
+		}
+	case *types.Struct:
+		return types.TypeString(typ, qualifier) + "{}"
+	}
+
+	return "nil"
+}
@@ -0,0 +1,410 @@
+package fake
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// instanceURL returns the URL of the instance in the project of the server.
+func (s *Server) instanceURL(name string) string {
+	return api.NewURL().Path(version.APIVersion, "instances", name).Project(s.project).String()
+}
+
+// instanceETag returns the ETag of the instance, computed from the same fields as the server.
+func instanceETag(instance *api.Instance) string {
+	return etag([]any{instance.Architecture, instance.Config, instance.Devices, instance.Ephemeral, instance.Profiles})
+}
+
+// expandInstance returns a copy of the instance with its configuration expanded from its profiles.
+// The state must be locked.
+func (s *Server) expandInstance(project string, instance *api.Instance) api.Instance {
+	expanded := clone(*instance)
+	expanded.ExpandedConfig = map[string]string{}
+	expanded.ExpandedDevices = map[string]map[string]string{}
+
+	for _, name := range instance.Profiles {
+		profile, ok := s.state.profiles[project][name]
+		if !ok {
+			continue
+		}
+
+		maps.Copy(expanded.ExpandedConfig, profile.Config)
+		maps.Copy(expanded.ExpandedDevices, clone(profile.Devices))
+	}
+
+	maps.Copy(expanded.ExpandedConfig, expanded.Config)
+	maps.Copy(expanded.ExpandedDevices, clone(expanded.Devices))
+
+	return expanded
+}
+
+// instanceState returns the state of the instance.
+func instanceState(instance *api.Instance) *api.InstanceState {
+	return &api.InstanceState{
+		Status:     instance.Status,
+		StatusCode: instance.StatusCode,
+		Disk:       map[string]api.InstanceStateDisk{},
+		Network:    map[string]api.InstanceStateNetwork{},
+	}
+}
+
+// listInstances returns the expanded instances of the given type in the project, or in all projects if empty.
+func (s *Server) listInstances(project string, instanceType api.InstanceType) []api.Instance {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	if project != "" {
+		s.projectInstances()
+	}
+
+	instances := []api.Instance{}
+	for instanceProject, projectInstances := range s.state.instances {
+		if project != "" && instanceProject != project {
+			continue
+		}
+
+		for _, instance := range projectInstances {
+			if instanceType != api.InstanceTypeAny && instance.Type != string(instanceType) {
+				continue
+			}
+
+			instances = append(instances, s.expandInstance(instanceProject, instance))
+		}
+	}
+
+	sort.Slice(instances, func(i int, j int) bool {
+		if instances[i].Project != instances[j].Project {
+			return instances[i].Project < instances[j].Project
+		}
+
+		return instances[i].Name < instances[j].Name
+	})
+
+	return instances
+}
+
+// fullInstances returns the instances along with their state.
+func fullInstances(instances []api.Instance) []api.InstanceFull {
+	full := make([]api.InstanceFull, 0, len(instances))
+	for _, instance := range instances {
+		full = append(full, api.InstanceFull{
+			Instance:  instance,
+			Backups:   []api.InstanceBackup{},
+			State:     instanceState(&instance),
+			Snapshots: []api.InstanceSnapshot{},
+		})
+	}
+
+	return full
+}
+
+// GetInstanceNames returns the names of the instances of the given type in the project.
+func (s *Server) GetInstanceNames(instanceType api.InstanceType) ([]string, error) {
+	names := []string{}
+	for _, instance := range s.listInstances(s.project, instanceType) {
+		names = append(names, instance.Name)
+	}
+
+	return names, nil
+}
+
+// GetInstanceNamesAllProjects returns the names of the instances of the given type, per project.
+func (s *Server) GetInstanceNamesAllProjects(instanceType api.InstanceType) (map[string][]string, error) {
+	names := map[string][]string{}
+	for _, instance := range s.listInstances("", instanceType) {
+		names[instance.Project] = append(names[instance.Project], instance.Name)
+	}
+
+	return names, nil
+}
+
+// GetInstances returns the instances of the given type in the project.
+func (s *Server) GetInstances(instanceType api.InstanceType) ([]api.Instance, error) {
+	return s.listInstances(s.project, instanceType), nil
+}
+
+// GetInstancesAllProjects returns the instances of the given type in all projects.
+func (s *Server) GetInstancesAllProjects(instanceType api.InstanceType) ([]api.Instance, error) {
+	return s.listInstances("", instanceType), nil
+}
+
+// GetInstancesFull returns the instances of the given type in the project, along with their state.
+func (s *Server) GetInstancesFull(instanceType api.InstanceType) ([]api.InstanceFull, error) {
+	return fullInstances(s.listInstances(s.project, instanceType)), nil
+}
+
+// GetInstancesFullAllProjects returns the instances of the given type in all projects, along with their state.
+func (s *Server) GetInstancesFullAllProjects(instanceType api.InstanceType) ([]api.InstanceFull, error) {
+	return fullInstances(s.listInstances("", instanceType)), nil
+}
+
+// GetInstance returns the instance with the given name.
+func (s *Server) GetInstance(name string) (*api.Instance, string, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	instance, ok := s.projectInstances()[name]
+	if !ok {
+		return nil, "", api.StatusErrorf(http.StatusNotFound, "Instance not found")
+	}
+
+	expanded := s.expandInstance(s.project, instance)
+
+	return &expanded, instanceETag(instance), nil
+}
+
+// GetInstanceFull returns the instance with the given name, along with its state.
+func (s *Server) GetInstanceFull(name string) (*api.InstanceFull, string, error) {
+	instance, ETag, err := s.GetInstance(name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &fullInstances([]api.Instance{*instance})[0], ETag, nil
+}
+
+// checkProfiles returns an error if one of the profiles doesn't exist in the project. The state must be locked.
+func (s *Server) checkProfiles(profiles []string) error {
+	for _, name := range profiles {
+		_, ok := s.projectProfiles()[name]
+		if !ok {
+			return api.StatusErrorf(http.StatusBadRequest, "Requested profile %q doesn't exist", name)
+		}
+	}
+
+	return nil
+}
+
+// instanceOperation runs a change to the instances of the project, recording its operation on the given instance.
+func (s *Server) instanceOperation(description string, name string, change func(instances map[string]*api.Instance) ([]api.Event, error)) (incus.Operation, error) {
+	var op *operation
+
+	err := s.apply(func() ([]api.Event, error) {
+		events, err := change(s.projectInstances())
+		if err != nil {
+			return nil, err
+		}
+
+		var event api.Event
+		op, event = s.newOperation(description, map[string][]string{"instances": {s.instanceURL(name)}})
+
+		return append(events, event), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+// CreateInstance creates a stopped instance, started right away if requested. The source of the instance is ignored.
+func (s *Server) CreateInstance(instance api.InstancesPost) (incus.Operation, error) {
+	if instance.Name == "" {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Instance name is required")
+	}
+
+	if instance.Type == api.InstanceTypeAny {
+		instance.Type = api.InstanceTypeContainer
+	}
+
+	if instance.Profiles == nil {
+		instance.Profiles = []string{api.ProjectDefaultName}
+	}
+
+	return s.instanceOperation("Creating instance", instance.Name, func(instances map[string]*api.Instance) ([]api.Event, error) {
+		_, ok := instances[instance.Name]
+		if ok {
+			return nil, api.StatusErrorf(http.StatusConflict, "Instance %q already exists", instance.Name)
+		}
+
+		err := s.checkProfiles(instance.Profiles)
+		if err != nil {
+			return nil, err
+		}
+
+		record := &api.Instance{
+			InstancePut: clone(instance.InstancePut),
+			CreatedAt:   time.Now(),
+			Name:        instance.Name,
+			Status:      api.Stopped.String(),
+			StatusCode:  api.Stopped,
+			Location:    s.location(),
+			Type:        string(instance.Type),
+			Project:     s.project,
+		}
+
+		if record.Architecture == "" {
+			record.Architecture = s.state.server.Environment.Architectures[0]
+		}
+
+		if record.Config == nil {
+			record.Config = map[string]string{}
+		}
+
+		if record.Devices == nil {
+			record.Devices = map[string]map[string]string{}
+		}
+
+		instances[instance.Name] = record
+
+		url := s.instanceURL(instance.Name)
+		events := []api.Event{s.lifecycleEvent(api.EventLifecycleInstanceCreated, url, map[string]any{"type": record.Type})}
+
+		if instance.Start {
+			record.Status = api.Running.String()
+			record.StatusCode = api.Running
+			record.LastUsedAt = time.Now()
+			events = append(events, s.lifecycleEvent(api.EventLifecycleInstanceStarted, url, nil))
+		}
+
+		return events, nil
+	})
+}
+
+// UpdateInstance updates the configuration of the instance. Restoring snapshots isn't implemented.
+func (s *Server) UpdateInstance(name string, instance api.InstancePut, ETag string) (incus.Operation, error) {
+	if instance.Restore != "" {
+		return nil, notImplemented("UpdateInstance with restore")
+	}
+
+	return s.instanceOperation("Updating instance", name, func(instances map[string]*api.Instance) ([]api.Event, error) {
+		record, ok := instances[name]
+		if !ok {
+			return nil, api.StatusErrorf(http.StatusNotFound, "Instance not found")
+		}
+
+		err := checkETag(ETag, instanceETag(record))
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.checkProfiles(instance.Profiles)
+		if err != nil {
+			return nil, err
+		}
+
+		if instance.Architecture == "" {
+			instance.Architecture = record.Architecture
+		}
+
+		if instance.Config == nil {
+			instance.Config = map[string]string{}
+		}
+
+		if instance.Devices == nil {
+			instance.Devices = map[string]map[string]string{}
+		}
+
+		record.InstancePut = clone(instance)
+
+		return []api.Event{s.lifecycleEvent(api.EventLifecycleInstanceUpdated, s.instanceURL(name), nil)}, nil
+	})
+}
+
+// RenameInstance renames the stopped instance. Migrations aren't implemented.
+func (s *Server) RenameInstance(name string, instance api.InstancePost) (incus.Operation, error) {
+	if instance.Migration {
+		return nil, notImplemented("RenameInstance with migration")
+	}
+
+	return s.instanceOperation("Renaming instance", name, func(instances map[string]*api.Instance) ([]api.Event, error) {
+		record, ok := instances[name]
+		if !ok {
+			return nil, api.StatusErrorf(http.StatusNotFound, "Instance not found")
+		}
+
+		_, ok = instances[instance.Name]
+		if ok {
+			return nil, api.StatusErrorf(http.StatusConflict, "Name %q already in use", instance.Name)
+		}
+
+		if record.StatusCode != api.Stopped {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Renaming of running instance not allowed")
+		}
+
+		delete(instances, name)
+		record.Name = instance.Name
+		instances[instance.Name] = record
+
+		return []api.Event{s.lifecycleEvent(api.EventLifecycleInstanceRenamed, s.instanceURL(instance.Name), map[string]any{"old_name": name})}, nil
+	})
+}
+
+// DeleteInstance deletes the stopped instance.
+func (s *Server) DeleteInstance(name string) (incus.Operation, error) {
+	return s.instanceOperation("Deleting instance", name, func(instances map[string]*api.Instance) ([]api.Event, error) {
+		record, ok := instances[name]
+		if !ok {
+			return nil, api.StatusErrorf(http.StatusNotFound, "Instance not found")
+		}
+
+		if record.StatusCode != api.Stopped {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Instance is running")
+		}
+
+		delete(instances, name)
+
+		return []api.Event{s.lifecycleEvent(api.EventLifecycleInstanceDeleted, s.instanceURL(name), nil)}, nil
+	})
+}
+
+// GetInstanceState returns the state of the instance, only reporting its status.
+func (s *Server) GetInstanceState(name string) (*api.InstanceState, string, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	record, ok := s.projectInstances()[name]
+	if !ok {
+		return nil, "", api.StatusErrorf(http.StatusNotFound, "Instance not found")
+	}
+
+	return instanceState(record), "", nil
+}
+
+// instanceStateTransitions maps the state actions to the statuses they apply to, the resulting status and their lifecycle action.
+var instanceStateTransitions = map[string]struct {
+	from   []api.StatusCode
+	to     api.StatusCode
+	action string
+}{
+	"start":    {from: []api.StatusCode{api.Stopped}, to: api.Running, action: api.EventLifecycleInstanceStarted},
+	"stop":     {from: []api.StatusCode{api.Running, api.Frozen}, to: api.Stopped, action: api.EventLifecycleInstanceStopped},
+	"restart":  {from: []api.StatusCode{api.Running}, to: api.Running, action: api.EventLifecycleInstanceRestarted},
+	"freeze":   {from: []api.StatusCode{api.Running}, to: api.Frozen, action: api.EventLifecycleInstancePaused},
+	"unfreeze": {from: []api.StatusCode{api.Frozen}, to: api.Running, action: api.EventLifecycleInstanceResumed},
+}
+
+// UpdateInstanceState starts, stops, restarts, freezes or unfreezes the instance.
+func (s *Server) UpdateInstanceState(name string, state api.InstanceStatePut, ETag string) (incus.Operation, error) {
+	transition, ok := instanceStateTransitions[state.Action]
+	if !ok {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Unknown action %s", state.Action)
+	}
+
+	return s.instanceOperation("Updating instance state", name, func(instances map[string]*api.Instance) ([]api.Event, error) {
+		record, ok := instances[name]
+		if !ok {
+			return nil, api.StatusErrorf(http.StatusNotFound, "Instance not found")
+		}
+
+		if !slices.Contains(transition.from, record.StatusCode) {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Instance is %s", record.Status)
+		}
+
+		record.Status = transition.to.String()
+		record.StatusCode = transition.to
+
+		if transition.to == api.Running {
+			record.LastUsedAt = time.Now()
+		}
+
+		return []api.Event{s.lifecycleEvent(transition.action, s.instanceURL(name), nil)}, nil
+	})
+}
@@ -0,0 +1,188 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+
+	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// operationRecord is an operation of the fake server along with its project.
+type operationRecord struct {
+	operation api.Operation
+	project   string
+}
+
+// operation is the Operation returned by the fake server, always completed.
+type operation struct {
+	api.Operation
+
+	server *Server
+}
+
+// AddHandler does nothing, the operation being already completed.
+func (op *operation) AddHandler(function func(api.Operation)) (*incus.EventTarget, error) {
+	return nil, nil
+}
+
+// Cancel fails, the operation being already completed.
+func (op *operation) Cancel() error {
+	return op.server.DeleteOperation(op.ID)
+}
+
+// Get returns the operation.
+func (op *operation) Get() api.Operation {
+	return op.Operation
+}
+
+// GetWebsocket isn't implemented by the fake operations.
+func (op *operation) GetWebsocket(secret string) (*websocket.Conn, error) {
+	return nil, notImplemented("GetWebsocket")
+}
+
+// RemoveHandler does nothing, the operation having no handlers.
+func (op *operation) RemoveHandler(target *incus.EventTarget) error {
+	return nil
+}
+
+// Refresh updates the operation from the state of the server.
+func (op *operation) Refresh() error {
+	newOp, _, err := op.server.GetOperation(op.ID)
+	if err != nil {
+		return err
+	}
+
+	op.Operation = *newOp
+
+	return nil
+}
+
+// Wait returns the error of the operation, if any.
+func (op *operation) Wait() error {
+	return op.WaitContext(context.Background())
+}
+
+// WaitContext returns the error of the operation, if any.
+func (op *operation) WaitContext(ctx context.Context) error {
+	if op.Err != "" {
+		return errors.New(op.Err)
+	}
+
+	return nil
+}
+
+// newOperation records a successful operation of the project on the given resources, and returns it along
+// with its event. The state must be locked.
+func (s *Server) newOperation(description string, resources map[string][]string) (*operation, api.Event) {
+	now := time.Now()
+
+	op := api.Operation{
+		ID:          uuid.New().String(),
+		Class:       api.OperationClassTask,
+		Description: description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Status:      api.Success.String(),
+		StatusCode:  api.Success,
+		Resources:   resources,
+		Metadata:    map[string]any{},
+		Location:    s.location(),
+	}
+
+	s.state.operations[op.ID] = &operationRecord{operation: op, project: s.project}
+
+	return &operation{Operation: clone(op), server: s}, s.newEvent(api.EventTypeOperation, op)
+}
+
+// projectOperations returns the operations of the project, or of all projects if empty, sorted by creation date.
+// The state must be locked.
+func (s *Server) projectOperations(project string) []api.Operation {
+	operations := []api.Operation{}
+	for _, record := range s.state.operations {
+		if project != "" && record.project != project {
+			continue
+		}
+
+		operations = append(operations, clone(record.operation))
+	}
+
+	sort.Slice(operations, func(i int, j int) bool {
+		return operations[i].CreatedAt.Before(operations[j].CreatedAt)
+	})
+
+	return operations
+}
+
+// GetOperationUUIDs returns the UUIDs of the operations of the project.
+func (s *Server) GetOperationUUIDs() ([]string, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	uuids := []string{}
+	for _, op := range s.projectOperations(s.project) {
+		uuids = append(uuids, op.ID)
+	}
+
+	return uuids, nil
+}
+
+// GetOperations returns the operations of the project.
+func (s *Server) GetOperations() ([]api.Operation, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	return s.projectOperations(s.project), nil
+}
+
+// GetOperationsAllProjects returns the operations of all projects.
+func (s *Server) GetOperationsAllProjects() ([]api.Operation, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	return s.projectOperations(""), nil
+}
+
+// GetOperation returns the operation with the given ID.
+func (s *Server) GetOperation(operationID string) (*api.Operation, string, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	record, ok := s.state.operations[operationID]
+	if !ok {
+		return nil, "", api.StatusErrorf(http.StatusNotFound, "Operation not found")
+	}
+
+	op := clone(record.operation)
+
+	return &op, "", nil
+}
+
+// GetOperationWait returns the operation with the given ID, which is always completed.
+func (s *Server) GetOperationWait(operationID string, timeout int) (*api.Operation, string, error) {
+	return s.GetOperation(operationID)
+}
+
+// GetOperationWaitSecret returns the operation with the given ID, which is always completed.
+func (s *Server) GetOperationWaitSecret(operationID string, secret string, timeout int) (*api.Operation, string, error) {
+	return s.GetOperation(operationID)
+}
+
+// DeleteOperation fails as cancelling the operation would, the operations being already completed.
+func (s *Server) DeleteOperation(operationID string) error {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	_, ok := s.state.operations[operationID]
+	if !ok {
+		return api.StatusErrorf(http.StatusNotFound, "Operation not found")
+	}
+
+	return api.StatusErrorf(http.StatusBadRequest, "Only running operations can be cancelled")
+}
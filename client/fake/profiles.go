@@ -0,0 +1,224 @@
+package fake
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// profileURL returns the URL of the profile in the project of the server.
+func (s *Server) profileURL(name string) string {
+	return api.NewURL().Path(version.APIVersion, "profiles", name).Project(s.project).String()
+}
+
+// profileETag returns the ETag of the profile, computed from the same fields as the server.
+func profileETag(profile *api.Profile) string {
+	return etag([]any{profile.Config, profile.Description, profile.Devices, profile.Extends})
+}
+
+// profileUsedBy returns the URLs of the instances using the profile. The state must be locked.
+func (s *Server) profileUsedBy(project string, name string) []string {
+	usedBy := []string{}
+	for _, instance := range s.state.instances[project] {
+		if !slices.Contains(instance.Profiles, name) {
+			continue
+		}
+
+		usedBy = append(usedBy, api.NewURL().Path(version.APIVersion, "instances", instance.Name).Project(project).String())
+	}
+
+	sort.Strings(usedBy)
+
+	return usedBy
+}
+
+// listProfiles returns the profiles of the project, or of all projects if empty.
+func (s *Server) listProfiles(project string) []api.Profile {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	if project != "" {
+		s.projectProfiles()
+	}
+
+	profiles := []api.Profile{}
+	for profileProject, projectProfiles := range s.state.profiles {
+		if project != "" && profileProject != project {
+			continue
+		}
+
+		for _, profile := range projectProfiles {
+			entry := clone(*profile)
+			entry.UsedBy = s.profileUsedBy(profileProject, profile.Name)
+			profiles = append(profiles, entry)
+		}
+	}
+
+	sort.Slice(profiles, func(i int, j int) bool {
+		if profiles[i].Project != profiles[j].Project {
+			return profiles[i].Project < profiles[j].Project
+		}
+
+		return profiles[i].Name < profiles[j].Name
+	})
+
+	return profiles
+}
+
+// GetProfileNames returns the names of the profiles of the project.
+func (s *Server) GetProfileNames() ([]string, error) {
+	names := []string{}
+	for _, profile := range s.listProfiles(s.project) {
+		names = append(names, profile.Name)
+	}
+
+	return names, nil
+}
+
+// GetProfiles returns the profiles of the project.
+func (s *Server) GetProfiles() ([]api.Profile, error) {
+	return s.listProfiles(s.project), nil
+}
+
+// GetProfilesAllProjects returns the profiles of all projects.
+func (s *Server) GetProfilesAllProjects() ([]api.Profile, error) {
+	return s.listProfiles(""), nil
+}
+
+// GetProfile returns the profile with the given name.
+func (s *Server) GetProfile(name string) (*api.Profile, string, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	profile, ok := s.projectProfiles()[name]
+	if !ok {
+		return nil, "", api.StatusErrorf(http.StatusNotFound, "Profile not found")
+	}
+
+	entry := clone(*profile)
+	entry.UsedBy = s.profileUsedBy(s.project, name)
+
+	return &entry, profileETag(profile), nil
+}
+
+// CreateProfile creates a profile in the project.
+func (s *Server) CreateProfile(profile api.ProfilesPost) error {
+	if profile.Name == "" {
+		return api.StatusErrorf(http.StatusBadRequest, "No name provided")
+	}
+
+	return s.apply(func() ([]api.Event, error) {
+		profiles := s.projectProfiles()
+
+		_, ok := profiles[profile.Name]
+		if ok {
+			return nil, api.StatusErrorf(http.StatusConflict, "Profile %q already exists", profile.Name)
+		}
+
+		record := &api.Profile{
+			ProfilePut: clone(profile.ProfilePut),
+			Name:       profile.Name,
+			Project:    s.project,
+		}
+
+		if record.Config == nil {
+			record.Config = map[string]string{}
+		}
+
+		if record.Devices == nil {
+			record.Devices = map[string]map[string]string{}
+		}
+
+		profiles[profile.Name] = record
+
+		return []api.Event{s.lifecycleEvent(api.EventLifecycleProfileCreated, s.profileURL(profile.Name), nil)}, nil
+	})
+}
+
+// UpdateProfile updates the profile, which applies to the instances using it.
+func (s *Server) UpdateProfile(name string, profile api.ProfilePut, ETag string) error {
+	return s.apply(func() ([]api.Event, error) {
+		record, ok := s.projectProfiles()[name]
+		if !ok {
+			return nil, api.StatusErrorf(http.StatusNotFound, "Profile not found")
+		}
+
+		err := checkETag(ETag, profileETag(record))
+		if err != nil {
+			return nil, err
+		}
+
+		if profile.Config == nil {
+			profile.Config = map[string]string{}
+		}
+
+		if profile.Devices == nil {
+			profile.Devices = map[string]map[string]string{}
+		}
+
+		record.ProfilePut = clone(profile)
+
+		return []api.Event{s.lifecycleEvent(api.EventLifecycleProfileUpdated, s.profileURL(name), nil)}, nil
+	})
+}
+
+// RenameProfile renames the profile, along with the references of the instances using it.
+func (s *Server) RenameProfile(name string, profile api.ProfilePost) error {
+	if name == api.ProjectDefaultName {
+		return api.StatusErrorf(http.StatusForbidden, `The "default" profile cannot be renamed`)
+	}
+
+	return s.apply(func() ([]api.Event, error) {
+		profiles := s.projectProfiles()
+
+		record, ok := profiles[name]
+		if !ok {
+			return nil, api.StatusErrorf(http.StatusNotFound, "Profile not found")
+		}
+
+		_, ok = profiles[profile.Name]
+		if ok {
+			return nil, api.StatusErrorf(http.StatusConflict, "Name %q already in use", profile.Name)
+		}
+
+		delete(profiles, name)
+		record.Name = profile.Name
+		profiles[profile.Name] = record
+
+		for _, instance := range s.projectInstances() {
+			index := slices.Index(instance.Profiles, name)
+			if index >= 0 {
+				instance.Profiles[index] = profile.Name
+			}
+		}
+
+		return []api.Event{s.lifecycleEvent(api.EventLifecycleProfileRenamed, s.profileURL(profile.Name), map[string]any{"old_name": name})}, nil
+	})
+}
+
+// DeleteProfile deletes the profile, unless instances use it.
+func (s *Server) DeleteProfile(name string) error {
+	if name == api.ProjectDefaultName {
+		return api.StatusErrorf(http.StatusForbidden, `The "default" profile cannot be deleted`)
+	}
+
+	return s.apply(func() ([]api.Event, error) {
+		profiles := s.projectProfiles()
+
+		_, ok := profiles[name]
+		if !ok {
+			return nil, api.StatusErrorf(http.StatusNotFound, "Profile not found")
+		}
+
+		if len(s.profileUsedBy(s.project, name)) > 0 {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Profile is currently in use")
+		}
+
+		delete(profiles, name)
+
+		return []api.Event{s.lifecycleEvent(api.EventLifecycleProfileDeleted, s.profileURL(name), nil)}, nil
+	})
+}
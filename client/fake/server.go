@@ -0,0 +1,243 @@
+// Package fake provides an in-memory implementation of the InstanceServer interface of the client,
+// so that the tools built on top of the client can be tested without an Incus server.
+//
+// The fake server keeps track of instances, profiles and operations per project, the projects being
+// created with their default profile on first use, and sends the matching lifecycle and operation
+// events to its event listeners. Operations complete before being returned.
+//
+// The functions which aren't implemented return an error wrapping ErrNotImplemented.
+//
+// Example:
+//
+//	server := fake.NewServer()
+//
+//	op, err := server.CreateInstance(api.InstancesPost{Name: "c1", Type: api.InstanceTypeContainer})
+//	if err != nil {
+//		return err
+//	}
+//
+//	err = op.Wait()
+//	if err != nil {
+//		return err
+//	}
+package fake
+
+//go:generate go run generate.go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// ErrNotImplemented is wrapped by the errors of the functions which aren't implemented by the fake server.
+var ErrNotImplemented = errors.New("Not implemented by the fake server")
+
+// notImplemented returns the error of a function which isn't implemented.
+func notImplemented(name string) error {
+	return fmt.Errorf("%s: %w", name, ErrNotImplemented)
+}
+
+// Make sure the fake server implements the whole interface.
+var _ incus.InstanceServer = (*Server)(nil)
+
+// state is the in-memory state shared by a fake server and its project, target and context variants.
+type state struct {
+	mu sync.Mutex
+
+	server     api.Server
+	instances  map[string]map[string]*api.Instance
+	profiles   map[string]map[string]*api.Profile
+	operations map[string]*operationRecord
+	listeners  []*eventListener
+}
+
+// Server is a fake InstanceServer keeping its state in memory.
+type Server struct {
+	state *state
+
+	ctx     context.Context
+	project string
+	target  string
+}
+
+// NewServer returns a fake server only having the default project and profile.
+func NewServer() *Server {
+	s := &state{
+		instances:  map[string]map[string]*api.Instance{},
+		profiles:   map[string]map[string]*api.Profile{},
+		operations: map[string]*operationRecord{},
+	}
+
+	s.server = api.Server{
+		ServerUntrusted: api.ServerUntrusted{
+			ServerPut: api.ServerPut{
+				Config: map[string]string{},
+			},
+			APIExtensions: slices.Clone(version.APIExtensions),
+			APIStatus:     "stable",
+			APIVersion:    version.APIVersion,
+			Auth:          "trusted",
+			AuthMethods:   []string{api.AuthenticationMethodTLS},
+		},
+		Environment: api.ServerEnvironment{
+			Architectures:   []string{"x86_64"},
+			Server:          "incus",
+			ServerName:      "fake",
+			ServerVersion:   version.Version,
+			ServerEventMode: "full-mesh",
+		},
+	}
+
+	return &Server{
+		state:   s,
+		ctx:     context.Background(),
+		project: api.ProjectDefaultName,
+	}
+}
+
+// projectInstances returns the instances of the project, creating the project on first use.
+// The state must be locked.
+func (s *Server) projectInstances() map[string]*api.Instance {
+	s.projectProfiles()
+
+	return s.state.instances[s.project]
+}
+
+// projectProfiles returns the profiles of the project, creating the project on first use.
+// The state must be locked.
+func (s *Server) projectProfiles() map[string]*api.Profile {
+	_, ok := s.state.profiles[s.project]
+	if !ok {
+		s.state.instances[s.project] = map[string]*api.Instance{}
+		s.state.profiles[s.project] = map[string]*api.Profile{
+			api.ProjectDefaultName: {
+				ProfilePut: api.ProfilePut{
+					Config:      map[string]string{},
+					Description: "Default Incus profile",
+					Devices:     map[string]map[string]string{},
+				},
+				Name:    api.ProjectDefaultName,
+				Project: s.project,
+			},
+		}
+	}
+
+	return s.state.profiles[s.project]
+}
+
+// location returns the name of the member the server runs on, or the target of the server.
+func (s *Server) location() string {
+	if s.target != "" {
+		return s.target
+	}
+
+	return s.state.server.Environment.ServerName
+}
+
+// GetConnectionInfo returns the project and target of the fake server.
+func (s *Server) GetConnectionInfo() (*incus.ConnectionInfo, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	return &incus.ConnectionInfo{
+		Protocol: "incus",
+		Project:  s.project,
+		Target:   s.location(),
+	}, nil
+}
+
+// Disconnect does nothing, the fake server not having a connection.
+func (s *Server) Disconnect() {
+}
+
+// GetServer returns the server information.
+func (s *Server) GetServer() (*api.Server, string, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	server := clone(s.state.server)
+	server.Environment.Project = s.project
+
+	return &server, etag(server.ServerPut), nil
+}
+
+// UpdateServer updates the server configuration.
+func (s *Server) UpdateServer(server api.ServerPut, ETag string) error {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	err := checkETag(ETag, etag(s.state.server.ServerPut))
+	if err != nil {
+		return err
+	}
+
+	if server.Config == nil {
+		server.Config = map[string]string{}
+	}
+
+	s.state.server.ServerPut = clone(server)
+
+	return nil
+}
+
+// HasExtension returns whether the server supports the API extension, all of them by default.
+func (s *Server) HasExtension(extension string) bool {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	return slices.Contains(s.state.server.APIExtensions, extension)
+}
+
+// SetExtensions replaces the API extensions supported by the fake server, to test the handling of older servers.
+func (s *Server) SetExtensions(extensions []string) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	s.state.server.APIExtensions = slices.Clone(extensions)
+}
+
+// RequireAuthenticated does nothing, the fake server always trusting its clients.
+func (s *Server) RequireAuthenticated(authenticated bool) {
+}
+
+// IsClustered returns false, the fake server being standalone.
+func (s *Server) IsClustered() bool {
+	return false
+}
+
+// UseTarget returns a fake server sharing the same state, creating its instances on the given member.
+func (s *Server) UseTarget(name string) incus.InstanceServer {
+	return &Server{
+		state:   s.state,
+		ctx:     s.ctx,
+		project: s.project,
+		target:  name,
+	}
+}
+
+// UseProject returns a fake server sharing the same state, working on the given project.
+func (s *Server) UseProject(name string) incus.InstanceServer {
+	return &Server{
+		state:   s.state,
+		ctx:     s.ctx,
+		project: name,
+		target:  s.target,
+	}
+}
+
+// WithContext returns a fake server sharing the same state, whose event listeners are disconnected with the context.
+func (s *Server) WithContext(ctx context.Context) incus.InstanceServer {
+	return &Server{
+		state:   s.state,
+		ctx:     ctx,
+		project: s.project,
+		target:  s.target,
+	}
+}
@@ -0,0 +1,288 @@
+package fake_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/client/fake"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// wait waits for the operation, failing the test if it fails.
+func wait(t *testing.T, op incus.Operation, err error) {
+	t.Helper()
+
+	require.NoError(t, err)
+	require.NoError(t, op.Wait())
+}
+
+// Instances go through their lifecycle, with their configuration expanded from their profiles.
+func TestServer_Instances(t *testing.T) {
+	server := fake.NewServer()
+
+	err := server.UpdateProfile("default", api.ProfilePut{Config: map[string]string{"limits.cpu": "2"}}, "")
+	require.NoError(t, err)
+
+	op, err := server.CreateInstance(api.InstancesPost{Name: "c1", Start: true, InstancePut: api.InstancePut{Config: map[string]string{"limits.memory": "1GiB"}}})
+	wait(t, op, err)
+
+	_, err = server.CreateInstance(api.InstancesPost{Name: "c1"})
+	assert.True(t, api.StatusErrorCheck(err, http.StatusConflict))
+
+	instance, ETag, err := server.GetInstance("c1")
+	require.NoError(t, err)
+	assert.Equal(t, "Running", instance.Status)
+	assert.Equal(t, "container", instance.Type)
+	assert.Equal(t, []string{"default"}, instance.Profiles)
+	assert.Equal(t, map[string]string{"limits.cpu": "2", "limits.memory": "1GiB"}, instance.ExpandedConfig)
+
+	_, err = server.DeleteInstance("c1")
+	assert.True(t, api.StatusErrorCheck(err, http.StatusBadRequest))
+
+	op, err = server.UpdateInstanceState("c1", api.InstanceStatePut{Action: "stop"}, "")
+	wait(t, op, err)
+
+	instance.Config["limits.memory"] = "2GiB"
+	op, err = server.UpdateInstance("c1", instance.Writable(), ETag)
+	wait(t, op, err)
+
+	_, err = server.UpdateInstance("c1", instance.Writable(), ETag)
+	assert.True(t, api.StatusErrorCheck(err, http.StatusPreconditionFailed))
+
+	op, err = server.RenameInstance("c1", api.InstancePost{Name: "c2"})
+	wait(t, op, err)
+
+	names, err := server.GetInstanceNames(api.InstanceTypeAny)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c2"}, names)
+
+	state, _, err := server.GetInstanceState("c2")
+	require.NoError(t, err)
+	assert.Equal(t, api.Stopped, state.StatusCode)
+
+	op, err = server.DeleteInstance("c2")
+	wait(t, op, err)
+
+	_, _, err = server.GetInstance("c2")
+	assert.True(t, api.StatusErrorCheck(err, http.StatusNotFound))
+}
+
+// Projects are isolated from each other while sharing the state of the server.
+func TestServer_Projects(t *testing.T) {
+	server := fake.NewServer()
+	project := server.UseProject("foo")
+
+	op, err := project.CreateInstance(api.InstancesPost{Name: "c1"})
+	wait(t, op, err)
+
+	names, err := server.GetInstanceNames(api.InstanceTypeAny)
+	require.NoError(t, err)
+	assert.Empty(t, names)
+
+	allNames, err := server.GetInstanceNamesAllProjects(api.InstanceTypeAny)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"foo": {"c1"}}, allNames)
+
+	info, err := project.GetConnectionInfo()
+	require.NoError(t, err)
+	assert.Equal(t, "foo", info.Project)
+}
+
+// Profiles used by instances can't be deleted, and renaming them updates the instances.
+func TestServer_Profiles(t *testing.T) {
+	server := fake.NewServer()
+
+	require.NoError(t, server.CreateProfile(api.ProfilesPost{Name: "p1"}))
+
+	err := server.CreateProfile(api.ProfilesPost{Name: "p1"})
+	assert.True(t, api.StatusErrorCheck(err, http.StatusConflict))
+
+	_, err = server.CreateInstance(api.InstancesPost{Name: "c1", InstancePut: api.InstancePut{Profiles: []string{"missing"}}})
+	assert.True(t, api.StatusErrorCheck(err, http.StatusBadRequest))
+
+	op, err := server.CreateInstance(api.InstancesPost{Name: "c1", InstancePut: api.InstancePut{Profiles: []string{"default", "p1"}}})
+	wait(t, op, err)
+
+	profile, _, err := server.GetProfile("p1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/1.0/instances/c1"}, profile.UsedBy)
+
+	err = server.DeleteProfile("p1")
+	assert.True(t, api.StatusErrorCheck(err, http.StatusBadRequest))
+
+	require.NoError(t, server.RenameProfile("p1", api.ProfilePost{Name: "p2"}))
+
+	instance, _, err := server.GetInstance("c1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default", "p2"}, instance.Profiles)
+
+	op, err = server.DeleteInstance("c1")
+	wait(t, op, err)
+
+	require.NoError(t, server.DeleteProfile("p2"))
+
+	names, err := server.GetProfileNames()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default"}, names)
+}
+
+// Changes are recorded as operations and sent as events to the listeners of their project.
+func TestServer_Events(t *testing.T) {
+	server := fake.NewServer()
+
+	listener, err := server.GetEvents()
+	require.NoError(t, err)
+
+	otherListener, err := server.UseProject("foo").GetEvents()
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	actions := []string{}
+
+	_, err = listener.AddHandler([]string{api.EventTypeLifecycle}, func(event api.Event) {
+		lifecycle := api.EventLifecycle{}
+		require.NoError(t, json.Unmarshal(event.Metadata, &lifecycle))
+
+		mu.Lock()
+		actions = append(actions, lifecycle.Action)
+		mu.Unlock()
+	})
+	require.NoError(t, err)
+
+	_, err = otherListener.AddHandler(nil, func(event api.Event) {
+		t.Errorf("Unexpected event in other project: %v", event)
+	})
+	require.NoError(t, err)
+
+	op, err := server.CreateInstance(api.InstancesPost{Name: "c1", Start: true})
+	wait(t, op, err)
+
+	mu.Lock()
+	assert.Equal(t, []string{api.EventLifecycleInstanceCreated, api.EventLifecycleInstanceStarted}, actions)
+	mu.Unlock()
+
+	uuids, err := server.GetOperationUUIDs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{op.Get().ID}, uuids)
+
+	listener.Disconnect()
+	assert.False(t, listener.IsActive())
+	require.NoError(t, listener.Wait())
+}
+
+// Typed event handlers only get the decoded events passing their filter.
+func TestServer_TypedEvents(t *testing.T) {
+	server := fake.NewServer()
+
+	listener, err := server.GetEvents()
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	sources := []string{}
+	operations := 0
+
+	filter := incus.LifecycleEventFilter{
+		Actions: []string{api.EventLifecycleInstanceCreated, api.EventLifecycleProfileCreated},
+		Sources: []string{"/1.0/instances"},
+	}
+
+	_, err = listener.AddLifecycleHandler(filter, func(_ api.Event, lifecycle api.EventLifecycle) {
+		mu.Lock()
+		sources = append(sources, lifecycle.Source)
+		mu.Unlock()
+	})
+	require.NoError(t, err)
+
+	_, err = listener.AddOperationHandler(incus.OperationEventFilter{StatusCodes: []api.StatusCode{api.Success}}, func(_ api.Event, _ api.Operation) {
+		mu.Lock()
+		operations++
+		mu.Unlock()
+	})
+	require.NoError(t, err)
+
+	_, err = listener.AddLifecycleHandler(incus.LifecycleEventFilter{EventFilter: incus.EventFilter{Projects: []string{"foo"}}}, func(event api.Event, _ api.EventLifecycle) {
+		t.Errorf("Unexpected event in other project: %v", event)
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, server.CreateProfile(api.ProfilesPost{Name: "p1"}))
+
+	op, err := server.CreateInstance(api.InstancesPost{Name: "c1", Start: true})
+	wait(t, op, err)
+
+	mu.Lock()
+	assert.Equal(t, []string{"/1.0/instances/c1"}, sources)
+	assert.Equal(t, 1, operations)
+	mu.Unlock()
+}
+
+// Bulk runs apply to all the instances, reporting the failures together.
+func TestServer_Bulk(t *testing.T) {
+	server := fake.NewServer()
+
+	names := []string{"c1", "c2", "c3"}
+	for _, name := range names {
+		op, err := server.CreateInstance(api.InstancesPost{Name: name})
+		wait(t, op, err)
+	}
+
+	progress := make(chan incus.BulkProgress)
+	done := []string{}
+	finished := make(chan struct{})
+
+	go func() {
+		for update := range progress {
+			if update.Done {
+				done = append(done, update.Name)
+			}
+		}
+
+		close(finished)
+	}()
+
+	err := incus.BulkUpdateInstanceState(server, names, api.InstanceStatePut{Action: "start"}, &incus.BulkArgs{Parallelism: 2, Progress: progress})
+	require.NoError(t, err)
+
+	<-finished
+	assert.ElementsMatch(t, names, done)
+
+	for _, name := range names {
+		state, _, err := server.GetInstanceState(name)
+		require.NoError(t, err)
+		assert.Equal(t, api.Running, state.StatusCode)
+	}
+
+	err = incus.BulkUpdateInstances(server, append(names, "missing"), func(instance *api.Instance) error {
+		instance.Config["user.bulk"] = "true"
+		return nil
+	}, nil)
+
+	bulkErr := &incus.BulkError{}
+	require.True(t, errors.As(err, &bulkErr))
+	assert.Len(t, bulkErr.Errors, 1)
+	assert.True(t, api.StatusErrorCheck(bulkErr.Errors["missing"], http.StatusNotFound))
+
+	instance, _, err := server.GetInstance("c2")
+	require.NoError(t, err)
+	assert.Equal(t, "true", instance.Config["user.bulk"])
+}
+
+// The functions which aren't implemented return ErrNotImplemented.
+func TestServer_NotImplemented(t *testing.T) {
+	server := fake.NewServer()
+
+	_, err := server.GetImages()
+	assert.True(t, errors.Is(err, fake.ErrNotImplemented))
+
+	assert.True(t, server.HasExtension("instances"))
+
+	server.SetExtensions(nil)
+	assert.False(t, server.HasExtension("instances"))
+}
@@ -0,0 +1,1460 @@
+// Code generated by generate.go; DO NOT EDIT.
+
+package fake
+
+import (
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/sftp"
+
+	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// ApplyServerPreseed isn't implemented by the fake server.
+func (s *Server) ApplyServerPreseed(_ api.InitPreseed) error {
+	return notImplemented("ApplyServerPreseed")
+}
+
+// CheckInstanceUpdate isn't implemented by the fake server.
+func (s *Server) CheckInstanceUpdate(_ string, _ api.InstancePut) (*api.InstanceUpdateCheck, error) {
+	return nil, notImplemented("CheckInstanceUpdate")
+}
+
+// ConsoleInstance isn't implemented by the fake server.
+func (s *Server) ConsoleInstance(_ string, _ api.InstanceConsolePost, _ *incus.InstanceConsoleArgs) (incus.Operation, error) {
+	return nil, notImplemented("ConsoleInstance")
+}
+
+// ConsoleInstanceDynamic isn't implemented by the fake server.
+func (s *Server) ConsoleInstanceDynamic(_ string, _ api.InstanceConsolePost, _ *incus.InstanceConsoleArgs) (incus.Operation, func(io.ReadWriteCloser) error, error) {
+	return nil, nil, notImplemented("ConsoleInstanceDynamic")
+}
+
+// CopyImage isn't implemented by the fake server.
+func (s *Server) CopyImage(_ incus.ImageServer, _ api.Image, _ *incus.ImageCopyArgs) (incus.RemoteOperation, error) {
+	return nil, notImplemented("CopyImage")
+}
+
+// CopyInstance isn't implemented by the fake server.
+func (s *Server) CopyInstance(_ incus.InstanceServer, _ api.Instance, _ *incus.InstanceCopyArgs) (incus.RemoteOperation, error) {
+	return nil, notImplemented("CopyInstance")
+}
+
+// CopyInstanceSnapshot isn't implemented by the fake server.
+func (s *Server) CopyInstanceSnapshot(_ incus.InstanceServer, _ string, _ api.InstanceSnapshot, _ *incus.InstanceSnapshotCopyArgs) (incus.RemoteOperation, error) {
+	return nil, notImplemented("CopyInstanceSnapshot")
+}
+
+// CopyStoragePoolVolume isn't implemented by the fake server.
+func (s *Server) CopyStoragePoolVolume(_ string, _ incus.InstanceServer, _ string, _ api.StorageVolume, _ *incus.StoragePoolVolumeCopyArgs) (incus.RemoteOperation, error) {
+	return nil, notImplemented("CopyStoragePoolVolume")
+}
+
+// CreateCertificate isn't implemented by the fake server.
+func (s *Server) CreateCertificate(_ api.CertificatesPost) error {
+	return notImplemented("CreateCertificate")
+}
+
+// CreateCertificateToken isn't implemented by the fake server.
+func (s *Server) CreateCertificateToken(_ api.CertificatesPost) (incus.Operation, error) {
+	return nil, notImplemented("CreateCertificateToken")
+}
+
+// CreateClusterGroup isn't implemented by the fake server.
+func (s *Server) CreateClusterGroup(_ api.ClusterGroupsPost) error {
+	return notImplemented("CreateClusterGroup")
+}
+
+// CreateClusterMember isn't implemented by the fake server.
+func (s *Server) CreateClusterMember(_ api.ClusterMembersPost) (incus.Operation, error) {
+	return nil, notImplemented("CreateClusterMember")
+}
+
+// CreateImage isn't implemented by the fake server.
+func (s *Server) CreateImage(_ api.ImagesPost, _ *incus.ImageCreateArgs) (incus.Operation, error) {
+	return nil, notImplemented("CreateImage")
+}
+
+// CreateImageAlias isn't implemented by the fake server.
+func (s *Server) CreateImageAlias(_ api.ImageAliasesPost) error {
+	return notImplemented("CreateImageAlias")
+}
+
+// CreateImageSecret isn't implemented by the fake server.
+func (s *Server) CreateImageSecret(_ string) (incus.Operation, error) {
+	return nil, notImplemented("CreateImageSecret")
+}
+
+// CreateInstanceBackup isn't implemented by the fake server.
+func (s *Server) CreateInstanceBackup(_ string, _ api.InstanceBackupsPost) (incus.Operation, error) {
+	return nil, notImplemented("CreateInstanceBackup")
+}
+
+// CreateInstanceFile isn't implemented by the fake server.
+func (s *Server) CreateInstanceFile(_ string, _ string, _ incus.InstanceFileArgs) error {
+	return notImplemented("CreateInstanceFile")
+}
+
+// CreateInstanceFromBackup isn't implemented by the fake server.
+func (s *Server) CreateInstanceFromBackup(_ incus.InstanceBackupArgs) (incus.Operation, error) {
+	return nil, notImplemented("CreateInstanceFromBackup")
+}
+
+// CreateInstanceFromImage isn't implemented by the fake server.
+func (s *Server) CreateInstanceFromImage(_ incus.ImageServer, _ api.Image, _ api.InstancesPost) (incus.RemoteOperation, error) {
+	return nil, notImplemented("CreateInstanceFromImage")
+}
+
+// CreateInstanceSnapshot isn't implemented by the fake server.
+func (s *Server) CreateInstanceSnapshot(_ string, _ api.InstanceSnapshotsPost) (incus.Operation, error) {
+	return nil, notImplemented("CreateInstanceSnapshot")
+}
+
+// CreateInstanceTemplateFile isn't implemented by the fake server.
+func (s *Server) CreateInstanceTemplateFile(_ string, _ string, _ io.ReadSeeker) error {
+	return notImplemented("CreateInstanceTemplateFile")
+}
+
+// CreateNetwork isn't implemented by the fake server.
+func (s *Server) CreateNetwork(_ api.NetworksPost) error {
+	return notImplemented("CreateNetwork")
+}
+
+// CreateNetworkACL isn't implemented by the fake server.
+func (s *Server) CreateNetworkACL(_ api.NetworkACLsPost) error {
+	return notImplemented("CreateNetworkACL")
+}
+
+// CreateNetworkAddressSet isn't implemented by the fake server.
+func (s *Server) CreateNetworkAddressSet(_ api.NetworkAddressSetsPost) error {
+	return notImplemented("CreateNetworkAddressSet")
+}
+
+// CreateNetworkForward isn't implemented by the fake server.
+func (s *Server) CreateNetworkForward(_ string, _ api.NetworkForwardsPost) error {
+	return notImplemented("CreateNetworkForward")
+}
+
+// CreateNetworkIntegration isn't implemented by the fake server.
+func (s *Server) CreateNetworkIntegration(_ api.NetworkIntegrationsPost) error {
+	return notImplemented("CreateNetworkIntegration")
+}
+
+// CreateNetworkLoadBalancer isn't implemented by the fake server.
+func (s *Server) CreateNetworkLoadBalancer(_ string, _ api.NetworkLoadBalancersPost) error {
+	return notImplemented("CreateNetworkLoadBalancer")
+}
+
+// CreateNetworkPeer isn't implemented by the fake server.
+func (s *Server) CreateNetworkPeer(_ string, _ api.NetworkPeersPost) error {
+	return notImplemented("CreateNetworkPeer")
+}
+
+// CreateNetworkZone isn't implemented by the fake server.
+func (s *Server) CreateNetworkZone(_ api.NetworkZonesPost) error {
+	return notImplemented("CreateNetworkZone")
+}
+
+// CreateNetworkZoneRecord isn't implemented by the fake server.
+func (s *Server) CreateNetworkZoneRecord(_ string, _ api.NetworkZoneRecordsPost) error {
+	return notImplemented("CreateNetworkZoneRecord")
+}
+
+// CreateProject isn't implemented by the fake server.
+func (s *Server) CreateProject(_ api.ProjectsPost) error {
+	return notImplemented("CreateProject")
+}
+
+// CreateScheduledTask isn't implemented by the fake server.
+func (s *Server) CreateScheduledTask(_ api.ScheduledTasksPost) error {
+	return notImplemented("CreateScheduledTask")
+}
+
+// CreateSessionBlock isn't implemented by the fake server.
+func (s *Server) CreateSessionBlock(_ api.SessionBlock) error {
+	return notImplemented("CreateSessionBlock")
+}
+
+// CreateStoragePool isn't implemented by the fake server.
+func (s *Server) CreateStoragePool(_ api.StoragePoolsPost) error {
+	return notImplemented("CreateStoragePool")
+}
+
+// CreateStoragePoolBucket isn't implemented by the fake server.
+func (s *Server) CreateStoragePoolBucket(_ string, _ api.StorageBucketsPost) (*api.StorageBucketKey, error) {
+	return nil, notImplemented("CreateStoragePoolBucket")
+}
+
+// CreateStoragePoolBucketBackup isn't implemented by the fake server.
+func (s *Server) CreateStoragePoolBucketBackup(_ string, _ string, _ api.StorageBucketBackupsPost) (incus.Operation, error) {
+	return nil, notImplemented("CreateStoragePoolBucketBackup")
+}
+
+// CreateStoragePoolBucketFromBackup isn't implemented by the fake server.
+func (s *Server) CreateStoragePoolBucketFromBackup(_ string, _ incus.StoragePoolBucketBackupArgs) (incus.Operation, error) {
+	return nil, notImplemented("CreateStoragePoolBucketFromBackup")
+}
+
+// CreateStoragePoolBucketKey isn't implemented by the fake server.
+func (s *Server) CreateStoragePoolBucketKey(_ string, _ string, _ api.StorageBucketKeysPost) (*api.StorageBucketKey, error) {
+	return nil, notImplemented("CreateStoragePoolBucketKey")
+}
+
+// CreateStoragePoolVolume isn't implemented by the fake server.
+func (s *Server) CreateStoragePoolVolume(_ string, _ api.StorageVolumesPost) error {
+	return notImplemented("CreateStoragePoolVolume")
+}
+
+// CreateStoragePoolVolumeFromBackup isn't implemented by the fake server.
+func (s *Server) CreateStoragePoolVolumeFromBackup(_ string, _ incus.StorageVolumeBackupArgs) (incus.Operation, error) {
+	return nil, notImplemented("CreateStoragePoolVolumeFromBackup")
+}
+
+// CreateStoragePoolVolumeFromISO isn't implemented by the fake server.
+func (s *Server) CreateStoragePoolVolumeFromISO(_ string, _ incus.StorageVolumeBackupArgs) (incus.Operation, error) {
+	return nil, notImplemented("CreateStoragePoolVolumeFromISO")
+}
+
+// CreateStoragePoolVolumeFromMigration isn't implemented by the fake server.
+func (s *Server) CreateStoragePoolVolumeFromMigration(_ string, _ api.StorageVolumesPost) (incus.Operation, error) {
+	return nil, notImplemented("CreateStoragePoolVolumeFromMigration")
+}
+
+// CreateStoragePoolVolumeSnapshot isn't implemented by the fake server.
+func (s *Server) CreateStoragePoolVolumeSnapshot(_ string, _ string, _ string, _ api.StorageVolumeSnapshotsPost) (incus.Operation, error) {
+	return nil, notImplemented("CreateStoragePoolVolumeSnapshot")
+}
+
+// CreateStorageVolumeBackup isn't implemented by the fake server.
+func (s *Server) CreateStorageVolumeBackup(_ string, _ string, _ api.StorageVolumeBackupsPost) (incus.Operation, error) {
+	return nil, notImplemented("CreateStorageVolumeBackup")
+}
+
+// DeleteCertificate isn't implemented by the fake server.
+func (s *Server) DeleteCertificate(_ string) error {
+	return notImplemented("DeleteCertificate")
+}
+
+// DeleteClusterGroup isn't implemented by the fake server.
+func (s *Server) DeleteClusterGroup(_ string) error {
+	return notImplemented("DeleteClusterGroup")
+}
+
+// DeleteClusterMember isn't implemented by the fake server.
+func (s *Server) DeleteClusterMember(_ string, _ bool) error {
+	return notImplemented("DeleteClusterMember")
+}
+
+// DeleteImage isn't implemented by the fake server.
+func (s *Server) DeleteImage(_ string) (incus.Operation, error) {
+	return nil, notImplemented("DeleteImage")
+}
+
+// DeleteImageAlias isn't implemented by the fake server.
+func (s *Server) DeleteImageAlias(_ string) error {
+	return notImplemented("DeleteImageAlias")
+}
+
+// DeleteInstanceBackup isn't implemented by the fake server.
+func (s *Server) DeleteInstanceBackup(_ string, _ string) (incus.Operation, error) {
+	return nil, notImplemented("DeleteInstanceBackup")
+}
+
+// DeleteInstanceConsoleLog isn't implemented by the fake server.
+func (s *Server) DeleteInstanceConsoleLog(_ string, _ *incus.InstanceConsoleLogArgs) error {
+	return notImplemented("DeleteInstanceConsoleLog")
+}
+
+// DeleteInstanceFile isn't implemented by the fake server.
+func (s *Server) DeleteInstanceFile(_ string, _ string) error {
+	return notImplemented("DeleteInstanceFile")
+}
+
+// DeleteInstanceLogfile isn't implemented by the fake server.
+func (s *Server) DeleteInstanceLogfile(_ string, _ string) error {
+	return notImplemented("DeleteInstanceLogfile")
+}
+
+// DeleteInstanceSnapshot isn't implemented by the fake server.
+func (s *Server) DeleteInstanceSnapshot(_ string, _ string) (incus.Operation, error) {
+	return nil, notImplemented("DeleteInstanceSnapshot")
+}
+
+// DeleteInstanceTemplateFile isn't implemented by the fake server.
+func (s *Server) DeleteInstanceTemplateFile(_ string, _ string) error {
+	return notImplemented("DeleteInstanceTemplateFile")
+}
+
+// DeleteNetwork isn't implemented by the fake server.
+func (s *Server) DeleteNetwork(_ string) error {
+	return notImplemented("DeleteNetwork")
+}
+
+// DeleteNetworkACL isn't implemented by the fake server.
+func (s *Server) DeleteNetworkACL(_ string) error {
+	return notImplemented("DeleteNetworkACL")
+}
+
+// DeleteNetworkAddressSet isn't implemented by the fake server.
+func (s *Server) DeleteNetworkAddressSet(_ string) error {
+	return notImplemented("DeleteNetworkAddressSet")
+}
+
+// DeleteNetworkForward isn't implemented by the fake server.
+func (s *Server) DeleteNetworkForward(_ string, _ string) error {
+	return notImplemented("DeleteNetworkForward")
+}
+
+// DeleteNetworkIntegration isn't implemented by the fake server.
+func (s *Server) DeleteNetworkIntegration(_ string) error {
+	return notImplemented("DeleteNetworkIntegration")
+}
+
+// DeleteNetworkLoadBalancer isn't implemented by the fake server.
+func (s *Server) DeleteNetworkLoadBalancer(_ string, _ string) error {
+	return notImplemented("DeleteNetworkLoadBalancer")
+}
+
+// DeleteNetworkPeer isn't implemented by the fake server.
+func (s *Server) DeleteNetworkPeer(_ string, _ string) error {
+	return notImplemented("DeleteNetworkPeer")
+}
+
+// DeleteNetworkZone isn't implemented by the fake server.
+func (s *Server) DeleteNetworkZone(_ string) error {
+	return notImplemented("DeleteNetworkZone")
+}
+
+// DeleteNetworkZoneRecord isn't implemented by the fake server.
+func (s *Server) DeleteNetworkZoneRecord(_ string, _ string) error {
+	return notImplemented("DeleteNetworkZoneRecord")
+}
+
+// DeleteProject isn't implemented by the fake server.
+func (s *Server) DeleteProject(_ string) error {
+	return notImplemented("DeleteProject")
+}
+
+// DeleteProjectForce isn't implemented by the fake server.
+func (s *Server) DeleteProjectForce(_ string) error {
+	return notImplemented("DeleteProjectForce")
+}
+
+// DeleteScheduledTask isn't implemented by the fake server.
+func (s *Server) DeleteScheduledTask(_ string) error {
+	return notImplemented("DeleteScheduledTask")
+}
+
+// DeleteSession isn't implemented by the fake server.
+func (s *Server) DeleteSession(_ string) error {
+	return notImplemented("DeleteSession")
+}
+
+// DeleteSessionBlock isn't implemented by the fake server.
+func (s *Server) DeleteSessionBlock(_ string) error {
+	return notImplemented("DeleteSessionBlock")
+}
+
+// DeleteStoragePool isn't implemented by the fake server.
+func (s *Server) DeleteStoragePool(_ string) error {
+	return notImplemented("DeleteStoragePool")
+}
+
+// DeleteStoragePoolBucket isn't implemented by the fake server.
+func (s *Server) DeleteStoragePoolBucket(_ string, _ string) error {
+	return notImplemented("DeleteStoragePoolBucket")
+}
+
+// DeleteStoragePoolBucketBackup isn't implemented by the fake server.
+func (s *Server) DeleteStoragePoolBucketBackup(_ string, _ string, _ string) (incus.Operation, error) {
+	return nil, notImplemented("DeleteStoragePoolBucketBackup")
+}
+
+// DeleteStoragePoolBucketKey isn't implemented by the fake server.
+func (s *Server) DeleteStoragePoolBucketKey(_ string, _ string, _ string) error {
+	return notImplemented("DeleteStoragePoolBucketKey")
+}
+
+// DeleteStoragePoolVolume isn't implemented by the fake server.
+func (s *Server) DeleteStoragePoolVolume(_ string, _ string, _ string) error {
+	return notImplemented("DeleteStoragePoolVolume")
+}
+
+// DeleteStoragePoolVolumeSnapshot isn't implemented by the fake server.
+func (s *Server) DeleteStoragePoolVolumeSnapshot(_ string, _ string, _ string, _ string) (incus.Operation, error) {
+	return nil, notImplemented("DeleteStoragePoolVolumeSnapshot")
+}
+
+// DeleteStorageVolumeBackup isn't implemented by the fake server.
+func (s *Server) DeleteStorageVolumeBackup(_ string, _ string, _ string) (incus.Operation, error) {
+	return nil, notImplemented("DeleteStorageVolumeBackup")
+}
+
+// DeleteWarning isn't implemented by the fake server.
+func (s *Server) DeleteWarning(_ string) error {
+	return notImplemented("DeleteWarning")
+}
+
+// DoHTTP isn't implemented by the fake server.
+func (s *Server) DoHTTP(_ *http.Request) (*http.Response, error) {
+	return nil, notImplemented("DoHTTP")
+}
+
+// EnrollInstanceUEFISecureBootKeys isn't implemented by the fake server.
+func (s *Server) EnrollInstanceUEFISecureBootKeys(_ string, _ api.InstanceUEFISecureBootKeysPost) error {
+	return notImplemented("EnrollInstanceUEFISecureBootKeys")
+}
+
+// ExecInstance isn't implemented by the fake server.
+func (s *Server) ExecInstance(_ string, _ api.InstanceExecPost, _ *incus.InstanceExecArgs) (incus.Operation, error) {
+	return nil, notImplemented("ExecInstance")
+}
+
+// ExportImage isn't implemented by the fake server.
+func (s *Server) ExportImage(_ string, _ api.ImageExportPost) (incus.Operation, error) {
+	return nil, notImplemented("ExportImage")
+}
+
+// GetCertificate isn't implemented by the fake server.
+func (s *Server) GetCertificate(_ string) (*api.Certificate, string, error) {
+	return nil, "", notImplemented("GetCertificate")
+}
+
+// GetCertificateFingerprints isn't implemented by the fake server.
+func (s *Server) GetCertificateFingerprints() ([]string, error) {
+	return nil, notImplemented("GetCertificateFingerprints")
+}
+
+// GetCertificates isn't implemented by the fake server.
+func (s *Server) GetCertificates() ([]api.Certificate, error) {
+	return nil, notImplemented("GetCertificates")
+}
+
+// GetCertificatesWithFilter isn't implemented by the fake server.
+func (s *Server) GetCertificatesWithFilter(_ []string) ([]api.Certificate, error) {
+	return nil, notImplemented("GetCertificatesWithFilter")
+}
+
+// GetCluster isn't implemented by the fake server.
+func (s *Server) GetCluster() (*api.Cluster, string, error) {
+	return nil, "", notImplemented("GetCluster")
+}
+
+// GetClusterGroup isn't implemented by the fake server.
+func (s *Server) GetClusterGroup(_ string) (*api.ClusterGroup, string, error) {
+	return nil, "", notImplemented("GetClusterGroup")
+}
+
+// GetClusterGroupNames isn't implemented by the fake server.
+func (s *Server) GetClusterGroupNames() ([]string, error) {
+	return nil, notImplemented("GetClusterGroupNames")
+}
+
+// GetClusterGroups isn't implemented by the fake server.
+func (s *Server) GetClusterGroups() ([]api.ClusterGroup, error) {
+	return nil, notImplemented("GetClusterGroups")
+}
+
+// GetClusterMember isn't implemented by the fake server.
+func (s *Server) GetClusterMember(_ string) (*api.ClusterMember, string, error) {
+	return nil, "", notImplemented("GetClusterMember")
+}
+
+// GetClusterMemberNames isn't implemented by the fake server.
+func (s *Server) GetClusterMemberNames() ([]string, error) {
+	return nil, notImplemented("GetClusterMemberNames")
+}
+
+// GetClusterMemberState isn't implemented by the fake server.
+func (s *Server) GetClusterMemberState(_ string) (*api.ClusterMemberState, string, error) {
+	return nil, "", notImplemented("GetClusterMemberState")
+}
+
+// GetClusterMembers isn't implemented by the fake server.
+func (s *Server) GetClusterMembers() ([]api.ClusterMember, error) {
+	return nil, notImplemented("GetClusterMembers")
+}
+
+// GetClusterMembersWithFilter isn't implemented by the fake server.
+func (s *Server) GetClusterMembersWithFilter(_ []string) ([]api.ClusterMember, error) {
+	return nil, notImplemented("GetClusterMembersWithFilter")
+}
+
+// GetHTTPClient isn't implemented by the fake server.
+func (s *Server) GetHTTPClient() (*http.Client, error) {
+	return nil, notImplemented("GetHTTPClient")
+}
+
+// GetImage isn't implemented by the fake server.
+func (s *Server) GetImage(_ string) (*api.Image, string, error) {
+	return nil, "", notImplemented("GetImage")
+}
+
+// GetImageAlias isn't implemented by the fake server.
+func (s *Server) GetImageAlias(_ string) (*api.ImageAliasesEntry, string, error) {
+	return nil, "", notImplemented("GetImageAlias")
+}
+
+// GetImageAliasArchitectures isn't implemented by the fake server.
+func (s *Server) GetImageAliasArchitectures(_ string, _ string) (map[string]*api.ImageAliasesEntry, error) {
+	return nil, notImplemented("GetImageAliasArchitectures")
+}
+
+// GetImageAliasNames isn't implemented by the fake server.
+func (s *Server) GetImageAliasNames() ([]string, error) {
+	return nil, notImplemented("GetImageAliasNames")
+}
+
+// GetImageAliasType isn't implemented by the fake server.
+func (s *Server) GetImageAliasType(_ string, _ string) (*api.ImageAliasesEntry, string, error) {
+	return nil, "", notImplemented("GetImageAliasType")
+}
+
+// GetImageAliases isn't implemented by the fake server.
+func (s *Server) GetImageAliases() ([]api.ImageAliasesEntry, error) {
+	return nil, notImplemented("GetImageAliases")
+}
+
+// GetImageFile isn't implemented by the fake server.
+func (s *Server) GetImageFile(_ string, _ incus.ImageFileRequest) (*incus.ImageFileResponse, error) {
+	return nil, notImplemented("GetImageFile")
+}
+
+// GetImageFingerprints isn't implemented by the fake server.
+func (s *Server) GetImageFingerprints() ([]string, error) {
+	return nil, notImplemented("GetImageFingerprints")
+}
+
+// GetImageSecret isn't implemented by the fake server.
+func (s *Server) GetImageSecret(_ string) (string, error) {
+	return "", notImplemented("GetImageSecret")
+}
+
+// GetImages isn't implemented by the fake server.
+func (s *Server) GetImages() ([]api.Image, error) {
+	return nil, notImplemented("GetImages")
+}
+
+// GetImagesAllProjects isn't implemented by the fake server.
+func (s *Server) GetImagesAllProjects() ([]api.Image, error) {
+	return nil, notImplemented("GetImagesAllProjects")
+}
+
+// GetImagesAllProjectsWithFilter isn't implemented by the fake server.
+func (s *Server) GetImagesAllProjectsWithFilter(_ []string) ([]api.Image, error) {
+	return nil, notImplemented("GetImagesAllProjectsWithFilter")
+}
+
+// GetImagesWithFilter isn't implemented by the fake server.
+func (s *Server) GetImagesWithFilter(_ []string) ([]api.Image, error) {
+	return nil, notImplemented("GetImagesWithFilter")
+}
+
+// GetInstanceAccess isn't implemented by the fake server.
+func (s *Server) GetInstanceAccess(_ string) (api.Access, error) {
+	return nil, notImplemented("GetInstanceAccess")
+}
+
+// GetInstanceBackup isn't implemented by the fake server.
+func (s *Server) GetInstanceBackup(_ string, _ string) (*api.InstanceBackup, string, error) {
+	return nil, "", notImplemented("GetInstanceBackup")
+}
+
+// GetInstanceBackupFile isn't implemented by the fake server.
+func (s *Server) GetInstanceBackupFile(_ string, _ string, _ *incus.BackupFileRequest) (*incus.BackupFileResponse, error) {
+	return nil, notImplemented("GetInstanceBackupFile")
+}
+
+// GetInstanceBackupNames isn't implemented by the fake server.
+func (s *Server) GetInstanceBackupNames(_ string) ([]string, error) {
+	return nil, notImplemented("GetInstanceBackupNames")
+}
+
+// GetInstanceBackups isn't implemented by the fake server.
+func (s *Server) GetInstanceBackups(_ string) ([]api.InstanceBackup, error) {
+	return nil, notImplemented("GetInstanceBackups")
+}
+
+// GetInstanceConsoleLog isn't implemented by the fake server.
+func (s *Server) GetInstanceConsoleLog(_ string, _ *incus.InstanceConsoleLogArgs) (io.ReadCloser, error) {
+	return nil, notImplemented("GetInstanceConsoleLog")
+}
+
+// GetInstanceDebugMemory isn't implemented by the fake server.
+func (s *Server) GetInstanceDebugMemory(_ string, _ string) (io.ReadCloser, error) {
+	return nil, notImplemented("GetInstanceDebugMemory")
+}
+
+// GetInstanceFile isn't implemented by the fake server.
+func (s *Server) GetInstanceFile(_ string, _ string) (io.ReadCloser, *incus.InstanceFileResponse, error) {
+	return nil, nil, notImplemented("GetInstanceFile")
+}
+
+// GetInstanceFileSFTP isn't implemented by the fake server.
+func (s *Server) GetInstanceFileSFTP(_ string) (*sftp.Client, error) {
+	return nil, notImplemented("GetInstanceFileSFTP")
+}
+
+// GetInstanceFileSFTPConn isn't implemented by the fake server.
+func (s *Server) GetInstanceFileSFTPConn(_ string) (net.Conn, error) {
+	return nil, notImplemented("GetInstanceFileSFTPConn")
+}
+
+// GetInstanceLogfile isn't implemented by the fake server.
+func (s *Server) GetInstanceLogfile(_ string, _ string) (io.ReadCloser, error) {
+	return nil, notImplemented("GetInstanceLogfile")
+}
+
+// GetInstanceLogfiles isn't implemented by the fake server.
+func (s *Server) GetInstanceLogfiles(_ string) ([]string, error) {
+	return nil, notImplemented("GetInstanceLogfiles")
+}
+
+// GetInstanceMetadata isn't implemented by the fake server.
+func (s *Server) GetInstanceMetadata(_ string) (*api.ImageMetadata, string, error) {
+	return nil, "", notImplemented("GetInstanceMetadata")
+}
+
+// GetInstanceSnapshot isn't implemented by the fake server.
+func (s *Server) GetInstanceSnapshot(_ string, _ string) (*api.InstanceSnapshot, string, error) {
+	return nil, "", notImplemented("GetInstanceSnapshot")
+}
+
+// GetInstanceSnapshotNames isn't implemented by the fake server.
+func (s *Server) GetInstanceSnapshotNames(_ string) ([]string, error) {
+	return nil, notImplemented("GetInstanceSnapshotNames")
+}
+
+// GetInstanceSnapshots isn't implemented by the fake server.
+func (s *Server) GetInstanceSnapshots(_ string) ([]api.InstanceSnapshot, error) {
+	return nil, notImplemented("GetInstanceSnapshots")
+}
+
+// GetInstanceTemplateFile isn't implemented by the fake server.
+func (s *Server) GetInstanceTemplateFile(_ string, _ string) (io.ReadCloser, error) {
+	return nil, notImplemented("GetInstanceTemplateFile")
+}
+
+// GetInstanceTemplateFiles isn't implemented by the fake server.
+func (s *Server) GetInstanceTemplateFiles(_ string) ([]string, error) {
+	return nil, notImplemented("GetInstanceTemplateFiles")
+}
+
+// GetInstanceUEFI isn't implemented by the fake server.
+func (s *Server) GetInstanceUEFI(_ string) (*api.InstanceUEFI, string, error) {
+	return nil, "", notImplemented("GetInstanceUEFI")
+}
+
+// GetInstanceUEFINVRAM isn't implemented by the fake server.
+func (s *Server) GetInstanceUEFINVRAM(_ string) (io.ReadCloser, error) {
+	return nil, notImplemented("GetInstanceUEFINVRAM")
+}
+
+// GetInstanceWithOrigins isn't implemented by the fake server.
+func (s *Server) GetInstanceWithOrigins(_ string) (*api.Instance, string, error) {
+	return nil, "", notImplemented("GetInstanceWithOrigins")
+}
+
+// GetInstancesAllProjectsWithFilter isn't implemented by the fake server.
+func (s *Server) GetInstancesAllProjectsWithFilter(_ api.InstanceType, _ []string) ([]api.Instance, error) {
+	return nil, notImplemented("GetInstancesAllProjectsWithFilter")
+}
+
+// GetInstancesFullAllProjectsWithFilter isn't implemented by the fake server.
+func (s *Server) GetInstancesFullAllProjectsWithFilter(_ api.InstanceType, _ []string) ([]api.InstanceFull, error) {
+	return nil, notImplemented("GetInstancesFullAllProjectsWithFilter")
+}
+
+// GetInstancesFullWithFilter isn't implemented by the fake server.
+func (s *Server) GetInstancesFullWithFilter(_ api.InstanceType, _ []string) ([]api.InstanceFull, error) {
+	return nil, notImplemented("GetInstancesFullWithFilter")
+}
+
+// GetInstancesWithFilter isn't implemented by the fake server.
+func (s *Server) GetInstancesWithFilter(_ api.InstanceType, _ []string) ([]api.Instance, error) {
+	return nil, notImplemented("GetInstancesWithFilter")
+}
+
+// GetMetadataConfiguration isn't implemented by the fake server.
+func (s *Server) GetMetadataConfiguration() (*api.MetadataConfiguration, error) {
+	return nil, notImplemented("GetMetadataConfiguration")
+}
+
+// GetMetrics isn't implemented by the fake server.
+func (s *Server) GetMetrics() (string, error) {
+	return "", notImplemented("GetMetrics")
+}
+
+// GetNetwork isn't implemented by the fake server.
+func (s *Server) GetNetwork(_ string) (*api.Network, string, error) {
+	return nil, "", notImplemented("GetNetwork")
+}
+
+// GetNetworkACL isn't implemented by the fake server.
+func (s *Server) GetNetworkACL(_ string) (*api.NetworkACL, string, error) {
+	return nil, "", notImplemented("GetNetworkACL")
+}
+
+// GetNetworkACLLogfile isn't implemented by the fake server.
+func (s *Server) GetNetworkACLLogfile(_ string) (io.ReadCloser, error) {
+	return nil, notImplemented("GetNetworkACLLogfile")
+}
+
+// GetNetworkACLNames isn't implemented by the fake server.
+func (s *Server) GetNetworkACLNames() ([]string, error) {
+	return nil, notImplemented("GetNetworkACLNames")
+}
+
+// GetNetworkACLs isn't implemented by the fake server.
+func (s *Server) GetNetworkACLs() ([]api.NetworkACL, error) {
+	return nil, notImplemented("GetNetworkACLs")
+}
+
+// GetNetworkACLsAllProjects isn't implemented by the fake server.
+func (s *Server) GetNetworkACLsAllProjects() ([]api.NetworkACL, error) {
+	return nil, notImplemented("GetNetworkACLsAllProjects")
+}
+
+// GetNetworkAddressSet isn't implemented by the fake server.
+func (s *Server) GetNetworkAddressSet(_ string) (*api.NetworkAddressSet, string, error) {
+	return nil, "", notImplemented("GetNetworkAddressSet")
+}
+
+// GetNetworkAddressSetNames isn't implemented by the fake server.
+func (s *Server) GetNetworkAddressSetNames() ([]string, error) {
+	return nil, notImplemented("GetNetworkAddressSetNames")
+}
+
+// GetNetworkAddressSets isn't implemented by the fake server.
+func (s *Server) GetNetworkAddressSets() ([]api.NetworkAddressSet, error) {
+	return nil, notImplemented("GetNetworkAddressSets")
+}
+
+// GetNetworkAddressSetsAllProjects isn't implemented by the fake server.
+func (s *Server) GetNetworkAddressSetsAllProjects() ([]api.NetworkAddressSet, error) {
+	return nil, notImplemented("GetNetworkAddressSetsAllProjects")
+}
+
+// GetNetworkAllocations isn't implemented by the fake server.
+func (s *Server) GetNetworkAllocations() ([]api.NetworkAllocations, error) {
+	return nil, notImplemented("GetNetworkAllocations")
+}
+
+// GetNetworkAllocationsAllProjects isn't implemented by the fake server.
+func (s *Server) GetNetworkAllocationsAllProjects() ([]api.NetworkAllocations, error) {
+	return nil, notImplemented("GetNetworkAllocationsAllProjects")
+}
+
+// GetNetworkForward isn't implemented by the fake server.
+func (s *Server) GetNetworkForward(_ string, _ string) (*api.NetworkForward, string, error) {
+	return nil, "", notImplemented("GetNetworkForward")
+}
+
+// GetNetworkForwardAddresses isn't implemented by the fake server.
+func (s *Server) GetNetworkForwardAddresses(_ string) ([]string, error) {
+	return nil, notImplemented("GetNetworkForwardAddresses")
+}
+
+// GetNetworkForwards isn't implemented by the fake server.
+func (s *Server) GetNetworkForwards(_ string) ([]api.NetworkForward, error) {
+	return nil, notImplemented("GetNetworkForwards")
+}
+
+// GetNetworkIntegration isn't implemented by the fake server.
+func (s *Server) GetNetworkIntegration(_ string) (*api.NetworkIntegration, string, error) {
+	return nil, "", notImplemented("GetNetworkIntegration")
+}
+
+// GetNetworkIntegrationNames isn't implemented by the fake server.
+func (s *Server) GetNetworkIntegrationNames() ([]string, error) {
+	return nil, notImplemented("GetNetworkIntegrationNames")
+}
+
+// GetNetworkIntegrations isn't implemented by the fake server.
+func (s *Server) GetNetworkIntegrations() ([]api.NetworkIntegration, error) {
+	return nil, notImplemented("GetNetworkIntegrations")
+}
+
+// GetNetworkLeases isn't implemented by the fake server.
+func (s *Server) GetNetworkLeases(_ string) ([]api.NetworkLease, error) {
+	return nil, notImplemented("GetNetworkLeases")
+}
+
+// GetNetworkLoadBalancer isn't implemented by the fake server.
+func (s *Server) GetNetworkLoadBalancer(_ string, _ string) (*api.NetworkLoadBalancer, string, error) {
+	return nil, "", notImplemented("GetNetworkLoadBalancer")
+}
+
+// GetNetworkLoadBalancerAddresses isn't implemented by the fake server.
+func (s *Server) GetNetworkLoadBalancerAddresses(_ string) ([]string, error) {
+	return nil, notImplemented("GetNetworkLoadBalancerAddresses")
+}
+
+// GetNetworkLoadBalancerState isn't implemented by the fake server.
+func (s *Server) GetNetworkLoadBalancerState(_ string, _ string) (*api.NetworkLoadBalancerState, error) {
+	return nil, notImplemented("GetNetworkLoadBalancerState")
+}
+
+// GetNetworkLoadBalancers isn't implemented by the fake server.
+func (s *Server) GetNetworkLoadBalancers(_ string) ([]api.NetworkLoadBalancer, error) {
+	return nil, notImplemented("GetNetworkLoadBalancers")
+}
+
+// GetNetworkNames isn't implemented by the fake server.
+func (s *Server) GetNetworkNames() ([]string, error) {
+	return nil, notImplemented("GetNetworkNames")
+}
+
+// GetNetworkPeer isn't implemented by the fake server.
+func (s *Server) GetNetworkPeer(_ string, _ string) (*api.NetworkPeer, string, error) {
+	return nil, "", notImplemented("GetNetworkPeer")
+}
+
+// GetNetworkPeerNames isn't implemented by the fake server.
+func (s *Server) GetNetworkPeerNames(_ string) ([]string, error) {
+	return nil, notImplemented("GetNetworkPeerNames")
+}
+
+// GetNetworkPeers isn't implemented by the fake server.
+func (s *Server) GetNetworkPeers(_ string) ([]api.NetworkPeer, error) {
+	return nil, notImplemented("GetNetworkPeers")
+}
+
+// GetNetworkState isn't implemented by the fake server.
+func (s *Server) GetNetworkState(_ string) (*api.NetworkState, error) {
+	return nil, notImplemented("GetNetworkState")
+}
+
+// GetNetworkZone isn't implemented by the fake server.
+func (s *Server) GetNetworkZone(_ string) (*api.NetworkZone, string, error) {
+	return nil, "", notImplemented("GetNetworkZone")
+}
+
+// GetNetworkZoneNames isn't implemented by the fake server.
+func (s *Server) GetNetworkZoneNames() ([]string, error) {
+	return nil, notImplemented("GetNetworkZoneNames")
+}
+
+// GetNetworkZoneRecord isn't implemented by the fake server.
+func (s *Server) GetNetworkZoneRecord(_ string, _ string) (*api.NetworkZoneRecord, string, error) {
+	return nil, "", notImplemented("GetNetworkZoneRecord")
+}
+
+// GetNetworkZoneRecordNames isn't implemented by the fake server.
+func (s *Server) GetNetworkZoneRecordNames(_ string) ([]string, error) {
+	return nil, notImplemented("GetNetworkZoneRecordNames")
+}
+
+// GetNetworkZoneRecords isn't implemented by the fake server.
+func (s *Server) GetNetworkZoneRecords(_ string) ([]api.NetworkZoneRecord, error) {
+	return nil, notImplemented("GetNetworkZoneRecords")
+}
+
+// GetNetworkZones isn't implemented by the fake server.
+func (s *Server) GetNetworkZones() ([]api.NetworkZone, error) {
+	return nil, notImplemented("GetNetworkZones")
+}
+
+// GetNetworkZonesAllProjects isn't implemented by the fake server.
+func (s *Server) GetNetworkZonesAllProjects() ([]api.NetworkZone, error) {
+	return nil, notImplemented("GetNetworkZonesAllProjects")
+}
+
+// GetNetworks isn't implemented by the fake server.
+func (s *Server) GetNetworks() ([]api.Network, error) {
+	return nil, notImplemented("GetNetworks")
+}
+
+// GetNetworksAllProjects isn't implemented by the fake server.
+func (s *Server) GetNetworksAllProjects() ([]api.Network, error) {
+	return nil, notImplemented("GetNetworksAllProjects")
+}
+
+// GetNetworksAllProjectsWithFilter isn't implemented by the fake server.
+func (s *Server) GetNetworksAllProjectsWithFilter(_ []string) ([]api.Network, error) {
+	return nil, notImplemented("GetNetworksAllProjectsWithFilter")
+}
+
+// GetNetworksWithFilter isn't implemented by the fake server.
+func (s *Server) GetNetworksWithFilter(_ []string) ([]api.Network, error) {
+	return nil, notImplemented("GetNetworksWithFilter")
+}
+
+// GetOperationWebsocket isn't implemented by the fake server.
+func (s *Server) GetOperationWebsocket(_ string, _ string) (*websocket.Conn, error) {
+	return nil, notImplemented("GetOperationWebsocket")
+}
+
+// GetOperationsWithFilter isn't implemented by the fake server.
+func (s *Server) GetOperationsWithFilter(_ []string) ([]api.Operation, error) {
+	return nil, notImplemented("GetOperationsWithFilter")
+}
+
+// GetPrivateImage isn't implemented by the fake server.
+func (s *Server) GetPrivateImage(_ string, _ string) (*api.Image, string, error) {
+	return nil, "", notImplemented("GetPrivateImage")
+}
+
+// GetPrivateImageFile isn't implemented by the fake server.
+func (s *Server) GetPrivateImageFile(_ string, _ string, _ incus.ImageFileRequest) (*incus.ImageFileResponse, error) {
+	return nil, notImplemented("GetPrivateImageFile")
+}
+
+// GetProfilesAllProjectsWithFilter isn't implemented by the fake server.
+func (s *Server) GetProfilesAllProjectsWithFilter(_ []string) ([]api.Profile, error) {
+	return nil, notImplemented("GetProfilesAllProjectsWithFilter")
+}
+
+// GetProfilesWithFilter isn't implemented by the fake server.
+func (s *Server) GetProfilesWithFilter(_ []string) ([]api.Profile, error) {
+	return nil, notImplemented("GetProfilesWithFilter")
+}
+
+// GetProject isn't implemented by the fake server.
+func (s *Server) GetProject(_ string) (*api.Project, string, error) {
+	return nil, "", notImplemented("GetProject")
+}
+
+// GetProjectAccess isn't implemented by the fake server.
+func (s *Server) GetProjectAccess(_ string) (api.Access, error) {
+	return nil, notImplemented("GetProjectAccess")
+}
+
+// GetProjectAudit isn't implemented by the fake server.
+func (s *Server) GetProjectAudit(_ string) ([]api.ProjectAuditViolation, error) {
+	return nil, notImplemented("GetProjectAudit")
+}
+
+// GetProjectNames isn't implemented by the fake server.
+func (s *Server) GetProjectNames() ([]string, error) {
+	return nil, notImplemented("GetProjectNames")
+}
+
+// GetProjectState isn't implemented by the fake server.
+func (s *Server) GetProjectState(_ string) (*api.ProjectState, error) {
+	return nil, notImplemented("GetProjectState")
+}
+
+// GetProjects isn't implemented by the fake server.
+func (s *Server) GetProjects() ([]api.Project, error) {
+	return nil, notImplemented("GetProjects")
+}
+
+// GetProjectsFull isn't implemented by the fake server.
+func (s *Server) GetProjectsFull() ([]api.ProjectFull, error) {
+	return nil, notImplemented("GetProjectsFull")
+}
+
+// GetProjectsWithFilter isn't implemented by the fake server.
+func (s *Server) GetProjectsWithFilter(_ []string) ([]api.Project, error) {
+	return nil, notImplemented("GetProjectsWithFilter")
+}
+
+// GetScheduledTask isn't implemented by the fake server.
+func (s *Server) GetScheduledTask(_ string) (*api.ScheduledTask, string, error) {
+	return nil, "", notImplemented("GetScheduledTask")
+}
+
+// GetScheduledTaskNames isn't implemented by the fake server.
+func (s *Server) GetScheduledTaskNames() ([]string, error) {
+	return nil, notImplemented("GetScheduledTaskNames")
+}
+
+// GetScheduledTasks isn't implemented by the fake server.
+func (s *Server) GetScheduledTasks() ([]api.ScheduledTask, error) {
+	return nil, notImplemented("GetScheduledTasks")
+}
+
+// GetServerLogs isn't implemented by the fake server.
+func (s *Server) GetServerLogs(_ *incus.GetServerLogsArgs) ([]api.ServerLogEntry, error) {
+	return nil, notImplemented("GetServerLogs")
+}
+
+// GetServerResources isn't implemented by the fake server.
+func (s *Server) GetServerResources() (*api.Resources, error) {
+	return nil, notImplemented("GetServerResources")
+}
+
+// GetSession isn't implemented by the fake server.
+func (s *Server) GetSession(_ string) (*api.Session, error) {
+	return nil, notImplemented("GetSession")
+}
+
+// GetSessionBlocks isn't implemented by the fake server.
+func (s *Server) GetSessionBlocks() ([]api.SessionBlock, error) {
+	return nil, notImplemented("GetSessionBlocks")
+}
+
+// GetSessionIDs isn't implemented by the fake server.
+func (s *Server) GetSessionIDs() ([]string, error) {
+	return nil, notImplemented("GetSessionIDs")
+}
+
+// GetSessions isn't implemented by the fake server.
+func (s *Server) GetSessions() ([]api.Session, error) {
+	return nil, notImplemented("GetSessions")
+}
+
+// GetStoragePool isn't implemented by the fake server.
+func (s *Server) GetStoragePool(_ string) (*api.StoragePool, string, error) {
+	return nil, "", notImplemented("GetStoragePool")
+}
+
+// GetStoragePoolBucket isn't implemented by the fake server.
+func (s *Server) GetStoragePoolBucket(_ string, _ string) (*api.StorageBucket, string, error) {
+	return nil, "", notImplemented("GetStoragePoolBucket")
+}
+
+// GetStoragePoolBucketBackupFile isn't implemented by the fake server.
+func (s *Server) GetStoragePoolBucketBackupFile(_ string, _ string, _ string, _ *incus.BackupFileRequest) (*incus.BackupFileResponse, error) {
+	return nil, notImplemented("GetStoragePoolBucketBackupFile")
+}
+
+// GetStoragePoolBucketKey isn't implemented by the fake server.
+func (s *Server) GetStoragePoolBucketKey(_ string, _ string, _ string) (*api.StorageBucketKey, string, error) {
+	return nil, "", notImplemented("GetStoragePoolBucketKey")
+}
+
+// GetStoragePoolBucketKeyNames isn't implemented by the fake server.
+func (s *Server) GetStoragePoolBucketKeyNames(_ string, _ string) ([]string, error) {
+	return nil, notImplemented("GetStoragePoolBucketKeyNames")
+}
+
+// GetStoragePoolBucketKeys isn't implemented by the fake server.
+func (s *Server) GetStoragePoolBucketKeys(_ string, _ string) ([]api.StorageBucketKey, error) {
+	return nil, notImplemented("GetStoragePoolBucketKeys")
+}
+
+// GetStoragePoolBucketNames isn't implemented by the fake server.
+func (s *Server) GetStoragePoolBucketNames(_ string) ([]string, error) {
+	return nil, notImplemented("GetStoragePoolBucketNames")
+}
+
+// GetStoragePoolBuckets isn't implemented by the fake server.
+func (s *Server) GetStoragePoolBuckets(_ string) ([]api.StorageBucket, error) {
+	return nil, notImplemented("GetStoragePoolBuckets")
+}
+
+// GetStoragePoolBucketsAllProjects isn't implemented by the fake server.
+func (s *Server) GetStoragePoolBucketsAllProjects(_ string) ([]api.StorageBucket, error) {
+	return nil, notImplemented("GetStoragePoolBucketsAllProjects")
+}
+
+// GetStoragePoolBucketsWithFilter isn't implemented by the fake server.
+func (s *Server) GetStoragePoolBucketsWithFilter(_ string, _ []string) ([]api.StorageBucket, error) {
+	return nil, notImplemented("GetStoragePoolBucketsWithFilter")
+}
+
+// GetStoragePoolBucketsWithFilterAllProjects isn't implemented by the fake server.
+func (s *Server) GetStoragePoolBucketsWithFilterAllProjects(_ string, _ []string) ([]api.StorageBucket, error) {
+	return nil, notImplemented("GetStoragePoolBucketsWithFilterAllProjects")
+}
+
+// GetStoragePoolNames isn't implemented by the fake server.
+func (s *Server) GetStoragePoolNames() ([]string, error) {
+	return nil, notImplemented("GetStoragePoolNames")
+}
+
+// GetStoragePoolResources isn't implemented by the fake server.
+func (s *Server) GetStoragePoolResources(_ string) (*api.ResourcesStoragePool, error) {
+	return nil, notImplemented("GetStoragePoolResources")
+}
+
+// GetStoragePoolVolume isn't implemented by the fake server.
+func (s *Server) GetStoragePoolVolume(_ string, _ string, _ string) (*api.StorageVolume, string, error) {
+	return nil, "", notImplemented("GetStoragePoolVolume")
+}
+
+// GetStoragePoolVolumeFileSFTP isn't implemented by the fake server.
+func (s *Server) GetStoragePoolVolumeFileSFTP(_ string, _ string, _ string) (*sftp.Client, error) {
+	return nil, notImplemented("GetStoragePoolVolumeFileSFTP")
+}
+
+// GetStoragePoolVolumeFileSFTPConn isn't implemented by the fake server.
+func (s *Server) GetStoragePoolVolumeFileSFTPConn(_ string, _ string, _ string) (net.Conn, error) {
+	return nil, notImplemented("GetStoragePoolVolumeFileSFTPConn")
+}
+
+// GetStoragePoolVolumeNames isn't implemented by the fake server.
+func (s *Server) GetStoragePoolVolumeNames(_ string) ([]string, error) {
+	return nil, notImplemented("GetStoragePoolVolumeNames")
+}
+
+// GetStoragePoolVolumeNamesAllProjects isn't implemented by the fake server.
+func (s *Server) GetStoragePoolVolumeNamesAllProjects(_ string) (map[string][]string, error) {
+	return nil, notImplemented("GetStoragePoolVolumeNamesAllProjects")
+}
+
+// GetStoragePoolVolumeSnapshot isn't implemented by the fake server.
+func (s *Server) GetStoragePoolVolumeSnapshot(_ string, _ string, _ string, _ string) (*api.StorageVolumeSnapshot, string, error) {
+	return nil, "", notImplemented("GetStoragePoolVolumeSnapshot")
+}
+
+// GetStoragePoolVolumeSnapshotNames isn't implemented by the fake server.
+func (s *Server) GetStoragePoolVolumeSnapshotNames(_ string, _ string, _ string) ([]string, error) {
+	return nil, notImplemented("GetStoragePoolVolumeSnapshotNames")
+}
+
+// GetStoragePoolVolumeSnapshots isn't implemented by the fake server.
+func (s *Server) GetStoragePoolVolumeSnapshots(_ string, _ string, _ string) ([]api.StorageVolumeSnapshot, error) {
+	return nil, notImplemented("GetStoragePoolVolumeSnapshots")
+}
+
+// GetStoragePoolVolumeState isn't implemented by the fake server.
+func (s *Server) GetStoragePoolVolumeState(_ string, _ string, _ string) (*api.StorageVolumeState, error) {
+	return nil, notImplemented("GetStoragePoolVolumeState")
+}
+
+// GetStoragePoolVolumes isn't implemented by the fake server.
+func (s *Server) GetStoragePoolVolumes(_ string) ([]api.StorageVolume, error) {
+	return nil, notImplemented("GetStoragePoolVolumes")
+}
+
+// GetStoragePoolVolumesAllProjects isn't implemented by the fake server.
+func (s *Server) GetStoragePoolVolumesAllProjects(_ string) ([]api.StorageVolume, error) {
+	return nil, notImplemented("GetStoragePoolVolumesAllProjects")
+}
+
+// GetStoragePoolVolumesWithFilter isn't implemented by the fake server.
+func (s *Server) GetStoragePoolVolumesWithFilter(_ string, _ []string) ([]api.StorageVolume, error) {
+	return nil, notImplemented("GetStoragePoolVolumesWithFilter")
+}
+
+// GetStoragePoolVolumesWithFilterAllProjects isn't implemented by the fake server.
+func (s *Server) GetStoragePoolVolumesWithFilterAllProjects(_ string, _ []string) ([]api.StorageVolume, error) {
+	return nil, notImplemented("GetStoragePoolVolumesWithFilterAllProjects")
+}
+
+// GetStoragePools isn't implemented by the fake server.
+func (s *Server) GetStoragePools() ([]api.StoragePool, error) {
+	return nil, notImplemented("GetStoragePools")
+}
+
+// GetStorageVolumeBackup isn't implemented by the fake server.
+func (s *Server) GetStorageVolumeBackup(_ string, _ string, _ string) (*api.StorageVolumeBackup, string, error) {
+	return nil, "", notImplemented("GetStorageVolumeBackup")
+}
+
+// GetStorageVolumeBackupFile isn't implemented by the fake server.
+func (s *Server) GetStorageVolumeBackupFile(_ string, _ string, _ string, _ *incus.BackupFileRequest) (*incus.BackupFileResponse, error) {
+	return nil, notImplemented("GetStorageVolumeBackupFile")
+}
+
+// GetStorageVolumeBackupNames isn't implemented by the fake server.
+func (s *Server) GetStorageVolumeBackupNames(_ string, _ string) ([]string, error) {
+	return nil, notImplemented("GetStorageVolumeBackupNames")
+}
+
+// GetStorageVolumeBackups isn't implemented by the fake server.
+func (s *Server) GetStorageVolumeBackups(_ string, _ string) ([]api.StorageVolumeBackup, error) {
+	return nil, notImplemented("GetStorageVolumeBackups")
+}
+
+// GetWarning isn't implemented by the fake server.
+func (s *Server) GetWarning(_ string) (*api.Warning, string, error) {
+	return nil, "", notImplemented("GetWarning")
+}
+
+// GetWarningUUIDs isn't implemented by the fake server.
+func (s *Server) GetWarningUUIDs() ([]string, error) {
+	return nil, notImplemented("GetWarningUUIDs")
+}
+
+// GetWarnings isn't implemented by the fake server.
+func (s *Server) GetWarnings() ([]api.Warning, error) {
+	return nil, notImplemented("GetWarnings")
+}
+
+// MigrateInstance isn't implemented by the fake server.
+func (s *Server) MigrateInstance(_ string, _ api.InstancePost) (incus.Operation, error) {
+	return nil, notImplemented("MigrateInstance")
+}
+
+// MigrateInstanceSnapshot isn't implemented by the fake server.
+func (s *Server) MigrateInstanceSnapshot(_ string, _ string, _ api.InstanceSnapshotPost) (incus.Operation, error) {
+	return nil, notImplemented("MigrateInstanceSnapshot")
+}
+
+// MigrateStoragePoolVolume isn't implemented by the fake server.
+func (s *Server) MigrateStoragePoolVolume(_ string, _ api.StorageVolumePost) (incus.Operation, error) {
+	return nil, notImplemented("MigrateStoragePoolVolume")
+}
+
+// MoveStoragePoolVolume isn't implemented by the fake server.
+func (s *Server) MoveStoragePoolVolume(_ string, _ incus.InstanceServer, _ string, _ api.StorageVolume, _ *incus.StoragePoolVolumeMoveArgs) (incus.RemoteOperation, error) {
+	return nil, notImplemented("MoveStoragePoolVolume")
+}
+
+// RawOperation isn't implemented by the fake server.
+func (s *Server) RawOperation(_ string, _ string, _ any, _ string) (incus.Operation, string, error) {
+	return nil, "", notImplemented("RawOperation")
+}
+
+// RawQuery isn't implemented by the fake server.
+func (s *Server) RawQuery(_ string, _ string, _ any, _ string) (*api.Response, string, error) {
+	return nil, "", notImplemented("RawQuery")
+}
+
+// RawWebsocket isn't implemented by the fake server.
+func (s *Server) RawWebsocket(_ string) (*websocket.Conn, error) {
+	return nil, notImplemented("RawWebsocket")
+}
+
+// RebuildInstance isn't implemented by the fake server.
+func (s *Server) RebuildInstance(_ string, _ api.InstanceRebuildPost) (incus.Operation, error) {
+	return nil, notImplemented("RebuildInstance")
+}
+
+// RebuildInstanceFromImage isn't implemented by the fake server.
+func (s *Server) RebuildInstanceFromImage(_ incus.ImageServer, _ api.Image, _ string, _ api.InstanceRebuildPost) (incus.RemoteOperation, error) {
+	return nil, notImplemented("RebuildInstanceFromImage")
+}
+
+// RefreshImage isn't implemented by the fake server.
+func (s *Server) RefreshImage(_ string) (incus.Operation, error) {
+	return nil, notImplemented("RefreshImage")
+}
+
+// RemapProject isn't implemented by the fake server.
+func (s *Server) RemapProject(_ string) (incus.Operation, error) {
+	return nil, notImplemented("RemapProject")
+}
+
+// RenameClusterGroup isn't implemented by the fake server.
+func (s *Server) RenameClusterGroup(_ string, _ api.ClusterGroupPost) error {
+	return notImplemented("RenameClusterGroup")
+}
+
+// RenameClusterMember isn't implemented by the fake server.
+func (s *Server) RenameClusterMember(_ string, _ api.ClusterMemberPost) error {
+	return notImplemented("RenameClusterMember")
+}
+
+// RenameImageAlias isn't implemented by the fake server.
+func (s *Server) RenameImageAlias(_ string, _ api.ImageAliasesEntryPost) error {
+	return notImplemented("RenameImageAlias")
+}
+
+// RenameInstanceBackup isn't implemented by the fake server.
+func (s *Server) RenameInstanceBackup(_ string, _ string, _ api.InstanceBackupPost) (incus.Operation, error) {
+	return nil, notImplemented("RenameInstanceBackup")
+}
+
+// RenameInstanceSnapshot isn't implemented by the fake server.
+func (s *Server) RenameInstanceSnapshot(_ string, _ string, _ api.InstanceSnapshotPost) (incus.Operation, error) {
+	return nil, notImplemented("RenameInstanceSnapshot")
+}
+
+// RenameNetwork isn't implemented by the fake server.
+func (s *Server) RenameNetwork(_ string, _ api.NetworkPost) error {
+	return notImplemented("RenameNetwork")
+}
+
+// RenameNetworkACL isn't implemented by the fake server.
+func (s *Server) RenameNetworkACL(_ string, _ api.NetworkACLPost) error {
+	return notImplemented("RenameNetworkACL")
+}
+
+// RenameNetworkAddressSet isn't implemented by the fake server.
+func (s *Server) RenameNetworkAddressSet(_ string, _ api.NetworkAddressSetPost) error {
+	return notImplemented("RenameNetworkAddressSet")
+}
+
+// RenameNetworkIntegration isn't implemented by the fake server.
+func (s *Server) RenameNetworkIntegration(_ string, _ api.NetworkIntegrationPost) error {
+	return notImplemented("RenameNetworkIntegration")
+}
+
+// RenameProject isn't implemented by the fake server.
+func (s *Server) RenameProject(_ string, _ api.ProjectPost) (incus.Operation, error) {
+	return nil, notImplemented("RenameProject")
+}
+
+// RenameStoragePoolVolume isn't implemented by the fake server.
+func (s *Server) RenameStoragePoolVolume(_ string, _ string, _ string, _ api.StorageVolumePost) error {
+	return notImplemented("RenameStoragePoolVolume")
+}
+
+// RenameStoragePoolVolumeSnapshot isn't implemented by the fake server.
+func (s *Server) RenameStoragePoolVolumeSnapshot(_ string, _ string, _ string, _ string, _ api.StorageVolumeSnapshotPost) (incus.Operation, error) {
+	return nil, notImplemented("RenameStoragePoolVolumeSnapshot")
+}
+
+// RenameStorageVolumeBackup isn't implemented by the fake server.
+func (s *Server) RenameStorageVolumeBackup(_ string, _ string, _ string, _ api.StorageVolumeBackupPost) (incus.Operation, error) {
+	return nil, notImplemented("RenameStorageVolumeBackup")
+}
+
+// RenewClusterCertificate isn't implemented by the fake server.
+func (s *Server) RenewClusterCertificate() (string, error) {
+	return "", notImplemented("RenewClusterCertificate")
+}
+
+// ResetInstanceUEFINVRAM isn't implemented by the fake server.
+func (s *Server) ResetInstanceUEFINVRAM(_ string) error {
+	return notImplemented("ResetInstanceUEFINVRAM")
+}
+
+// RunBatch isn't implemented by the fake server.
+func (s *Server) RunBatch(_ api.BatchPost) (incus.Operation, error) {
+	return nil, notImplemented("RunBatch")
+}
+
+// RunInstanceDebugQMP isn't implemented by the fake server.
+func (s *Server) RunInstanceDebugQMP(_ string, _ api.InstanceDebugQMPPost) (any, error) {
+	return nil, notImplemented("RunInstanceDebugQMP")
+}
+
+// UpdateCertificate isn't implemented by the fake server.
+func (s *Server) UpdateCertificate(_ string, _ api.CertificatePut, _ string) error {
+	return notImplemented("UpdateCertificate")
+}
+
+// UpdateCluster isn't implemented by the fake server.
+func (s *Server) UpdateCluster(_ api.ClusterPut, _ string) (incus.Operation, error) {
+	return nil, notImplemented("UpdateCluster")
+}
+
+// UpdateClusterCertificate isn't implemented by the fake server.
+func (s *Server) UpdateClusterCertificate(_ api.ClusterCertificatePut, _ string) error {
+	return notImplemented("UpdateClusterCertificate")
+}
+
+// UpdateClusterGroup isn't implemented by the fake server.
+func (s *Server) UpdateClusterGroup(_ string, _ api.ClusterGroupPut, _ string) error {
+	return notImplemented("UpdateClusterGroup")
+}
+
+// UpdateClusterMember isn't implemented by the fake server.
+func (s *Server) UpdateClusterMember(_ string, _ api.ClusterMemberPut, _ string) error {
+	return notImplemented("UpdateClusterMember")
+}
+
+// UpdateClusterMemberState isn't implemented by the fake server.
+func (s *Server) UpdateClusterMemberState(_ string, _ api.ClusterMemberStatePost) (incus.Operation, error) {
+	return nil, notImplemented("UpdateClusterMemberState")
+}
+
+// UpdateImage isn't implemented by the fake server.
+func (s *Server) UpdateImage(_ string, _ api.ImagePut, _ string) error {
+	return notImplemented("UpdateImage")
+}
+
+// UpdateImageAlias isn't implemented by the fake server.
+func (s *Server) UpdateImageAlias(_ string, _ api.ImageAliasesEntryPut, _ string) error {
+	return notImplemented("UpdateImageAlias")
+}
+
+// UpdateInstanceMetadata isn't implemented by the fake server.
+func (s *Server) UpdateInstanceMetadata(_ string, _ api.ImageMetadata, _ string) error {
+	return notImplemented("UpdateInstanceMetadata")
+}
+
+// UpdateInstanceSnapshot isn't implemented by the fake server.
+func (s *Server) UpdateInstanceSnapshot(_ string, _ string, _ api.InstanceSnapshotPut, _ string) (incus.Operation, error) {
+	return nil, notImplemented("UpdateInstanceSnapshot")
+}
+
+// UpdateInstanceUEFI isn't implemented by the fake server.
+func (s *Server) UpdateInstanceUEFI(_ string, _ api.InstanceUEFIPut, _ string) error {
+	return notImplemented("UpdateInstanceUEFI")
+}
+
+// UpdateInstanceUEFINVRAM isn't implemented by the fake server.
+func (s *Server) UpdateInstanceUEFINVRAM(_ string, _ io.ReadSeeker) error {
+	return notImplemented("UpdateInstanceUEFINVRAM")
+}
+
+// UpdateInstances isn't implemented by the fake server.
+func (s *Server) UpdateInstances(_ api.InstancesPut, _ string) (incus.Operation, error) {
+	return nil, notImplemented("UpdateInstances")
+}
+
+// UpdateNetwork isn't implemented by the fake server.
+func (s *Server) UpdateNetwork(_ string, _ api.NetworkPut, _ string) error {
+	return notImplemented("UpdateNetwork")
+}
+
+// UpdateNetworkACL isn't implemented by the fake server.
+func (s *Server) UpdateNetworkACL(_ string, _ api.NetworkACLPut, _ string) error {
+	return notImplemented("UpdateNetworkACL")
+}
+
+// UpdateNetworkAddressSet isn't implemented by the fake server.
+func (s *Server) UpdateNetworkAddressSet(_ string, _ api.NetworkAddressSetPut, _ string) error {
+	return notImplemented("UpdateNetworkAddressSet")
+}
+
+// UpdateNetworkForward isn't implemented by the fake server.
+func (s *Server) UpdateNetworkForward(_ string, _ string, _ api.NetworkForwardPut, _ string) error {
+	return notImplemented("UpdateNetworkForward")
+}
+
+// UpdateNetworkIntegration isn't implemented by the fake server.
+func (s *Server) UpdateNetworkIntegration(_ string, _ api.NetworkIntegrationPut, _ string) error {
+	return notImplemented("UpdateNetworkIntegration")
+}
+
+// UpdateNetworkLoadBalancer isn't implemented by the fake server.
+func (s *Server) UpdateNetworkLoadBalancer(_ string, _ string, _ api.NetworkLoadBalancerPut, _ string) error {
+	return notImplemented("UpdateNetworkLoadBalancer")
+}
+
+// UpdateNetworkPeer isn't implemented by the fake server.
+func (s *Server) UpdateNetworkPeer(_ string, _ string, _ api.NetworkPeerPut, _ string) error {
+	return notImplemented("UpdateNetworkPeer")
+}
+
+// UpdateNetworkZone isn't implemented by the fake server.
+func (s *Server) UpdateNetworkZone(_ string, _ api.NetworkZonePut, _ string) error {
+	return notImplemented("UpdateNetworkZone")
+}
+
+// UpdateNetworkZoneRecord isn't implemented by the fake server.
+func (s *Server) UpdateNetworkZoneRecord(_ string, _ string, _ api.NetworkZoneRecordPut, _ string) error {
+	return notImplemented("UpdateNetworkZoneRecord")
+}
+
+// UpdateProject isn't implemented by the fake server.
+func (s *Server) UpdateProject(_ string, _ api.ProjectPut, _ string) error {
+	return notImplemented("UpdateProject")
+}
+
+// UpdateScheduledTask isn't implemented by the fake server.
+func (s *Server) UpdateScheduledTask(_ string, _ api.ScheduledTaskPut, _ string) error {
+	return notImplemented("UpdateScheduledTask")
+}
+
+// UpdateStoragePool isn't implemented by the fake server.
+func (s *Server) UpdateStoragePool(_ string, _ api.StoragePoolPut, _ string) error {
+	return notImplemented("UpdateStoragePool")
+}
+
+// UpdateStoragePoolBucket isn't implemented by the fake server.
+func (s *Server) UpdateStoragePoolBucket(_ string, _ string, _ api.StorageBucketPut, _ string) error {
+	return notImplemented("UpdateStoragePoolBucket")
+}
+
+// UpdateStoragePoolBucketKey isn't implemented by the fake server.
+func (s *Server) UpdateStoragePoolBucketKey(_ string, _ string, _ string, _ api.StorageBucketKeyPut, _ string) error {
+	return notImplemented("UpdateStoragePoolBucketKey")
+}
+
+// UpdateStoragePoolVolume isn't implemented by the fake server.
+func (s *Server) UpdateStoragePoolVolume(_ string, _ string, _ string, _ api.StorageVolumePut, _ string) error {
+	return notImplemented("UpdateStoragePoolVolume")
+}
+
+// UpdateStoragePoolVolumeSnapshot isn't implemented by the fake server.
+func (s *Server) UpdateStoragePoolVolumeSnapshot(_ string, _ string, _ string, _ string, _ api.StorageVolumeSnapshotPut, _ string) error {
+	return notImplemented("UpdateStoragePoolVolumeSnapshot")
+}
+
+// UpdateWarning isn't implemented by the fake server.
+func (s *Server) UpdateWarning(_ string, _ api.WarningPut, _ string) error {
+	return notImplemented("UpdateWarning")
+}
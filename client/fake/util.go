@@ -0,0 +1,49 @@
+package fake
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// etag returns the ETag of the value, computed like the server does.
+func etag(value any) string {
+	hash256 := sha256.New()
+
+	err := json.NewEncoder(hash256).Encode(value)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%x", hash256.Sum(nil))
+}
+
+// checkETag returns an error if the ETag is set and doesn't match the current one.
+func checkETag(ETag string, current string) error {
+	if ETag == "" {
+		return nil
+	}
+
+	if ETag != current {
+		return api.StatusErrorf(http.StatusPreconditionFailed, "ETag doesn't match: %s vs %s", current, ETag)
+	}
+
+	return nil
+}
+
+// clone returns a deep copy of the value, so that the callers can't alter the state of the fake server.
+func clone[T any](value T) T {
+	var out T
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return out
+	}
+
+	_ = json.Unmarshal(data, &out)
+
+	return out
+}
@@ -0,0 +1,47 @@
+package incus
+
+import (
+	"sort"
+	"sync"
+)
+
+// FanOutResult holds the outcome of a request issued to one of the servers of a fan-out.
+type FanOutResult[T any] struct {
+	// Name of the server, as passed to FanOut.
+	Name string
+
+	// Value returned by the request.
+	Value T
+
+	// Error returned by the request, if any.
+	Err error
+}
+
+// FanOut issues a request to all the given servers in parallel and waits for all of them to complete.
+// The results are returned sorted by server name, each carrying its own error so that a failing
+// server doesn't hide the results of the others.
+func FanOut[S any, T any](servers map[string]S, request func(name string, server S) (T, error)) []FanOutResult[T] {
+	results := make([]FanOutResult[T], 0, len(servers))
+	resultsLock := sync.Mutex{}
+
+	wg := sync.WaitGroup{}
+	for name, server := range servers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			value, err := request(name, server)
+
+			resultsLock.Lock()
+			results = append(results, FanOutResult[T]{Name: name, Value: value, Err: err})
+			resultsLock.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	return results
+}
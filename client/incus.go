@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel/propagation"
 
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
@@ -41,15 +42,22 @@ type ProtocolIncus struct {
 	httpCertificate string
 	httpBaseURL     neturl.URL
 	httpUnixPath    string
+	httpSSHTarget   string
 	httpProtocol    string
 	httpUserAgent   string
+	httpImpersonate string
 
 	requireAuthenticated bool
 
 	clusterTarget string
 	project       string
 
-	oidcClient *oidcClient
+	oidcClient    *oidcClient
+	retryPolicy   *RetryPolicy
+	responseCache *responseCache
+
+	// sshJump is the SSH connection set up by ConnectIncusSSH, closed on Disconnect.
+	sshJump *SSHJump
 }
 
 // Disconnect gets rid of any background goroutines.
@@ -57,6 +65,10 @@ func (r *ProtocolIncus) Disconnect() {
 	if r.ctxConnected.Err() != nil {
 		r.ctxConnectedCancel()
 	}
+
+	if r.sshJump != nil {
+		_ = r.sshJump.Close()
+	}
 }
 
 // GetConnectionInfo returns the basic connection information used to interact with the server.
@@ -66,6 +78,7 @@ func (r *ProtocolIncus) GetConnectionInfo() (*ConnectionInfo, error) {
 	info.Protocol = "incus"
 	info.URL = r.httpBaseURL.String()
 	info.SocketPath = r.httpUnixPath
+	info.SSHTarget = r.httpSSHTarget
 
 	info.Project = r.project
 	if info.Project == "" {
@@ -143,10 +156,15 @@ func (r *ProtocolIncus) GetHTTPClient() (*http.Client, error) {
 	return r.http, nil
 }
 
-// DoHTTP performs a Request, using OIDC authentication if set.
+// DoHTTP performs a Request, using OIDC authentication if set and retrying it according to the retry policy if any.
 func (r *ProtocolIncus) DoHTTP(req *http.Request) (*http.Response, error) {
 	r.addClientHeaders(req)
 
+	return r.retryPolicy.do(req, r.doHTTP)
+}
+
+// doHTTP performs a single attempt of a Request.
+func (r *ProtocolIncus) doHTTP(req *http.Request) (*http.Response, error) {
 	if r.oidcClient != nil {
 		return r.oidcClient.do(req)
 	}
@@ -176,11 +194,12 @@ func (r *ProtocolIncus) DoWebsocket(dialer websocket.Dialer, uri string, req *ht
 		return r.oidcClient.dial(dialer, uri, req)
 	}
 
-	return dialer.Dial(uri, req.Header)
+	return dialer.DialContext(req.Context(), uri, req.Header)
 }
 
 // addClientHeaders sets headers from client settings.
 // User-Agent (if r.httpUserAgent is set).
+// X-Incus-impersonate (if r.httpImpersonate is set).
 // X-Incus-authenticated (if r.requireAuthenticated is set).
 // OIDC Authorization header (if r.oidcClient is set).
 func (r *ProtocolIncus) addClientHeaders(req *http.Request) {
@@ -188,6 +207,10 @@ func (r *ProtocolIncus) addClientHeaders(req *http.Request) {
 		req.Header.Set("User-Agent", r.httpUserAgent)
 	}
 
+	if r.httpImpersonate != "" {
+		req.Header.Set("X-Incus-impersonate", r.httpImpersonate)
+	}
+
 	if r.requireAuthenticated {
 		req.Header.Set("X-Incus-authenticated", "true")
 	}
@@ -195,6 +218,9 @@ func (r *ProtocolIncus) addClientHeaders(req *http.Request) {
 	if r.oidcClient != nil {
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", r.oidcClient.getAccessToken()))
 	}
+
+	// Propagate the tracing span of the request context, if any.
+	propagation.TraceContext{}.Inject(req.Context(), propagation.HeaderCarrier(req.Header))
 }
 
 // RequireAuthenticated sets whether we expect to be authenticated with the server.
@@ -315,6 +341,15 @@ func (r *ProtocolIncus) rawQuery(method string, url string, data any, ETag strin
 		req.Header.Set("If-Match", ETag)
 	}
 
+	// Revalidate the cached response, if any.
+	var cached *responseCacheEntry
+	if method == http.MethodGet && data == nil {
+		cached = r.responseCache.get(url)
+		if cached != nil {
+			req.Header.Set("If-None-Match", cached.tag)
+		}
+	}
+
 	// Send the request
 	resp, err := r.DoHTTP(req)
 	if err != nil {
@@ -323,7 +358,32 @@ func (r *ProtocolIncus) rawQuery(method string, url string, data any, ETag strin
 
 	defer func() { _ = resp.Body.Close() }()
 
-	return incusParseResponse(resp)
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		response := cached.response
+
+		return &response, cached.etag, nil
+	}
+
+	if method != http.MethodGet || data != nil || r.responseCache == nil {
+		return incusParseResponse(resp)
+	}
+
+	// Keep the body of the response, to revalidate it next time.
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	response, etag, err := incusParseResponse(resp)
+	if err == nil && etag != "" {
+		r.responseCache.put(url, etag, responseCacheTag(body), *response)
+	} else {
+		r.responseCache.delete(url)
+	}
+
+	return response, etag, err
 }
 
 // setURLQueryAttributes modifies the supplied URL's query string with the client's current target and project.
@@ -461,7 +521,7 @@ func (r *ProtocolIncus) rawWebsocket(url string) (*websocket.Conn, error) {
 
 	// Create temporary http.Request using the http url, not the ws one, so that we can add the client headers
 	// for the websocket request.
-	req := &http.Request{URL: &r.httpBaseURL, Header: http.Header{}}
+	req := (&http.Request{URL: &r.httpBaseURL, Header: http.Header{}}).WithContext(r.ctx)
 
 	// Establish the connection
 	conn, resp, err := r.DoWebsocket(dialer, url, req)
@@ -473,6 +533,9 @@ func (r *ProtocolIncus) rawWebsocket(url string) (*websocket.Conn, error) {
 		return nil, err
 	}
 
+	// Close the websocket when the context of the client is done.
+	context.AfterFunc(r.ctx, func() { _ = conn.Close() })
+
 	// Set TCP timeout options.
 	remoteTCP, _ := tcp.ExtractConn(conn.UnderlyingConn())
 	if remoteTCP != nil {
@@ -502,11 +565,32 @@ func (r *ProtocolIncus) websocket(path string) (*websocket.Conn, error) {
 	return r.rawWebsocket(url)
 }
 
-// WithContext returns a client that will add context.Context.
+// WithContext returns a client using the given context for its requests, websockets and operation waits.
+// Canceling the context aborts the in-flight requests and closes the websockets, and its tracing
+// span, if any, is propagated to the server.
 func (r *ProtocolIncus) WithContext(ctx context.Context) InstanceServer {
-	rr := r
-	rr.ctx = ctx
-	return rr
+	return &ProtocolIncus{
+		ctx:                  ctx,
+		ctxConnected:         r.ctxConnected,
+		ctxConnectedCancel:   r.ctxConnectedCancel,
+		server:               r.server,
+		http:                 r.http,
+		httpCertificate:      r.httpCertificate,
+		httpBaseURL:          r.httpBaseURL,
+		httpProtocol:         r.httpProtocol,
+		httpUserAgent:        r.httpUserAgent,
+		httpUnixPath:         r.httpUnixPath,
+		httpSSHTarget:        r.httpSSHTarget,
+		httpImpersonate:      r.httpImpersonate,
+		requireAuthenticated: r.requireAuthenticated,
+		project:              r.project,
+		clusterTarget:        r.clusterTarget,
+		eventConns:           make(map[string]*websocket.Conn),  // New context specific listener conns.
+		eventListeners:       make(map[string][]*EventListener), // New context specific listeners.
+		oidcClient:           r.oidcClient,
+		retryPolicy:          r.retryPolicy,
+		responseCache:        r.responseCache,
+	}
 }
 
 // getUnderlyingHTTPTransport returns the *http.Transport used by the http client. If the http
@@ -0,0 +1,22 @@
+package incus
+
+import (
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// RunBatch runs a list of API requests server-side as a single operation.
+// The metadata of the operation holds the result of each request under the "results" key.
+func (r *ProtocolIncus) RunBatch(batch api.BatchPost) (Operation, error) {
+	err := r.CheckExtension("batch")
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("POST", "/batch", batch, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
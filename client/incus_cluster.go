@@ -2,6 +2,7 @@ package incus
 
 import (
 	"fmt"
+	"net/url"
 
 	"github.com/lxc/incus/v6/shared/api"
 )
@@ -94,6 +95,27 @@ func (r *ProtocolIncus) GetClusterMembers() ([]api.ClusterMember, error) {
 	return members, nil
 }
 
+// GetClusterMembersWithFilter returns a filtered list of cluster members.
+func (r *ProtocolIncus) GetClusterMembersWithFilter(filters []string) ([]api.ClusterMember, error) {
+	err := r.CheckExtension("collection_filtering")
+	if err != nil {
+		return nil, err
+	}
+
+	members := []api.ClusterMember{}
+
+	v := url.Values{}
+	v.Set("recursion", "1")
+	v.Set("filter", parseFilters(filters))
+
+	_, err = r.queryStruct("GET", fmt.Sprintf("/cluster/members?%s", v.Encode()), nil, "", &members)
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
 // GetClusterMember returns information about the given member.
 func (r *ProtocolIncus) GetClusterMember(name string) (*api.ClusterMember, string, error) {
 	if !r.HasExtension("clustering") {
@@ -172,6 +194,22 @@ func (r *ProtocolIncus) UpdateClusterCertificate(certs api.ClusterCertificatePut
 	return nil
 }
 
+// RenewClusterCertificate replaces the cluster certificate with a newly issued one and returns it.
+func (r *ProtocolIncus) RenewClusterCertificate() (string, error) {
+	err := r.CheckExtension("acme_cluster_members")
+	if err != nil {
+		return "", err
+	}
+
+	certificate := ""
+	_, err = r.queryStruct("POST", "/cluster/certificate", nil, "", &certificate)
+	if err != nil {
+		return "", err
+	}
+
+	return certificate, nil
+}
+
 // GetClusterMemberState gets state information about a cluster member.
 func (r *ProtocolIncus) GetClusterMemberState(name string) (*api.ClusterMemberState, string, error) {
 	err := r.CheckExtension("cluster_member_state")
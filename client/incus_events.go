@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"slices"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -20,7 +19,8 @@ func (r *ProtocolIncus) getEvents(allProjects bool) (*EventListener, error) {
 	r.eventListenersLock.Lock()
 	defer r.eventListenersLock.Unlock()
 
-	ctx, cancel := context.WithCancel(context.Background())
+	// Stop the listener along with the context of the client.
+	ctx, cancel := context.WithCancel(r.ctx)
 
 	// Setup a new listener
 	listener := EventListener{
@@ -141,16 +141,9 @@ func (r *ProtocolIncus) getEvents(allProjects bool) (*EventListener, error) {
 			// Send the message to all handlers
 			r.eventListenersLock.Lock()
 			for _, listener := range r.eventListeners[listener.projectName] {
-				listener.targetsLock.Lock()
-				for _, target := range listener.targets {
-					if target.types != nil && !slices.Contains(target.types, event.Type) {
-						continue
-					}
-
-					go target.function(event)
+				for _, function := range listener.handlers(event.Type) {
+					go function(event)
 				}
-
-				listener.targetsLock.Unlock()
 			}
 
 			r.eventListenersLock.Unlock()
@@ -1,6 +1,7 @@
 package incus
 
 import (
+	"context"
 	"crypto/sha256"
 	"errors"
 	"fmt"
@@ -18,7 +19,6 @@ import (
 	"github.com/lxc/incus/v6/shared/cancel"
 	"github.com/lxc/incus/v6/shared/ioprogress"
 	localtls "github.com/lxc/incus/v6/shared/tls"
-	"github.com/lxc/incus/v6/shared/units"
 	"github.com/lxc/incus/v6/shared/util"
 )
 
@@ -188,7 +188,7 @@ func (r *ProtocolIncus) GetPrivateImageFile(fingerprint string, secret string, r
 		// Setup the HTTP client
 		devIncusHTTP, err := unixHTTPClient(nil, "/dev/incus/sock")
 		if err == nil {
-			resp, err := incusDownloadImage(fingerprint, unixURI, r.httpUserAgent, devIncusHTTP.Do, req)
+			resp, err := incusDownloadImage(r.ctx, fingerprint, unixURI, r.httpUserAgent, devIncusHTTP.Do, req)
 			if err == nil {
 				return resp, nil
 			}
@@ -211,15 +211,15 @@ func (r *ProtocolIncus) GetPrivateImageFile(fingerprint string, secret string, r
 	httpTransport.ResponseHeaderTimeout = 30 * time.Second
 	httpClient.Transport = httpTransport
 
-	return incusDownloadImage(fingerprint, uri, r.httpUserAgent, r.DoHTTP, req)
+	return incusDownloadImage(r.ctx, fingerprint, uri, r.httpUserAgent, r.DoHTTP, req)
 }
 
-func incusDownloadImage(fingerprint string, uri string, userAgent string, do func(*http.Request) (*http.Response, error), req ImageFileRequest) (*ImageFileResponse, error) {
+func incusDownloadImage(ctx context.Context, fingerprint string, uri string, userAgent string, do func(*http.Request) (*http.Response, error), req ImageFileRequest) (*ImageFileResponse, error) {
 	// Prepare the response
 	resp := ImageFileResponse{}
 
 	// Prepare the download request
-	request, err := http.NewRequest("GET", uri, nil)
+	request, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -258,24 +258,10 @@ func incusDownloadImage(fingerprint string, uri string, userAgent string, do fun
 	// Handle the data
 	body := response.Body
 	if req.ProgressHandler != nil {
-		reader := &ioprogress.ProgressReader{
+		body = &ioprogress.ProgressReader{
 			ReadCloser: response.Body,
-			Tracker: &ioprogress.ProgressTracker{
-				Length: response.ContentLength,
-			},
+			Tracker:    ioprogress.NewProgressTracker(ioprogress.StageImageDownload, response.ContentLength, req.ProgressHandler),
 		}
-
-		if response.ContentLength > 0 {
-			reader.Tracker.Handler = func(percent int64, speed int64) {
-				req.ProgressHandler(ioprogress.ProgressData{Text: fmt.Sprintf("%d%% (%s/s)", percent, units.GetByteSizeString(speed, 2))})
-			}
-		} else {
-			reader.Tracker.Handler = func(received int64, speed int64) {
-				req.ProgressHandler(ioprogress.ProgressData{Text: fmt.Sprintf("%s (%s/s)", units.GetByteSizeString(received, 2), units.GetByteSizeString(speed, 2))})
-			}
-		}
-
-		body = reader
 	}
 
 	// Hashing
@@ -528,11 +514,7 @@ func (r *ProtocolIncus) CreateImage(image api.ImagesPost, args *ImageCreateArgs)
 		if args.ProgressHandler != nil {
 			body = &ioprogress.ProgressReader{
 				ReadCloser: pr,
-				Tracker: &ioprogress.ProgressTracker{
-					Handler: func(received int64, speed int64) {
-						args.ProgressHandler(ioprogress.ProgressData{Text: fmt.Sprintf("%s (%s/s)", units.GetByteSizeString(received, 2), units.GetByteSizeString(speed, 2))})
-					},
-				},
+				Tracker:    ioprogress.NewProgressTracker(ioprogress.StageImageUpload, 0, args.ProgressHandler),
 			}
 		} else {
 			body = pr
@@ -547,7 +529,7 @@ func (r *ProtocolIncus) CreateImage(image api.ImagesPost, args *ImageCreateArgs)
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", reqURL, body)
+	req, err := http.NewRequestWithContext(r.ctx, "POST", reqURL, body)
 	if err != nil {
 		return nil, err
 	}
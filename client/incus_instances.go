@@ -21,7 +21,6 @@ import (
 	"github.com/lxc/incus/v6/shared/ioprogress"
 	"github.com/lxc/incus/v6/shared/tcp"
 	localtls "github.com/lxc/incus/v6/shared/tls"
-	"github.com/lxc/incus/v6/shared/units"
 	"github.com/lxc/incus/v6/shared/ws"
 )
 
@@ -509,6 +508,29 @@ func (r *ProtocolIncus) GetInstanceFull(name string) (*api.InstanceFull, string,
 	return &instance, etag, nil
 }
 
+// GetInstanceWithOrigins returns the instance entry for the provided name along with the origin of its
+// expanded configuration and devices.
+func (r *ProtocolIncus) GetInstanceWithOrigins(name string) (*api.Instance, string, error) {
+	if !r.HasExtension("instances_config_origin") {
+		return nil, "", fmt.Errorf("The server is missing the required \"instances_config_origin\" API extension")
+	}
+
+	instance := api.Instance{}
+
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Fetch the raw value
+	etag, err := r.queryStruct("GET", fmt.Sprintf("%s/%s?origin=1", path, url.PathEscape(name)), nil, "", &instance)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &instance, etag, nil
+}
+
 // CreateInstanceFromBackup is a convenience function to make it easier to
 // create a instance from a backup.
 func (r *ProtocolIncus) CreateInstanceFromBackup(args InstanceBackupArgs) (Operation, error) {
@@ -545,7 +567,7 @@ func (r *ProtocolIncus) CreateInstanceFromBackup(args InstanceBackupArgs) (Opera
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", reqURL, args.BackupFile)
+	req, err := http.NewRequestWithContext(r.ctx, "POST", reqURL, args.BackupFile)
 	if err != nil {
 		return nil, err
 	}
@@ -819,7 +841,7 @@ func (r *ProtocolIncus) CopyInstance(source InstanceServer, instance api.Instanc
 	}
 
 	// Optimization for the local copy case
-	if destInfo.URL == sourceInfo.URL && destInfo.SocketPath == sourceInfo.SocketPath && (!r.IsClustered() || instance.Location == r.clusterTarget || r.HasExtension("cluster_internal_copy")) {
+	if destInfo.URL == sourceInfo.URL && destInfo.SocketPath == sourceInfo.SocketPath && destInfo.SSHTarget == sourceInfo.SSHTarget && (!r.IsClustered() || instance.Location == r.clusterTarget || r.HasExtension("cluster_internal_copy")) {
 		// Project handling
 		if destInfo.Project != sourceInfo.Project {
 			if !r.HasExtension("container_copy_project") {
@@ -991,6 +1013,29 @@ func (r *ProtocolIncus) UpdateInstance(name string, instance api.InstancePut, ET
 	return op, nil
 }
 
+// CheckInstanceUpdate returns the effect updating the instance would have on the resources of its project,
+// without updating it.
+func (r *ProtocolIncus) CheckInstanceUpdate(name string, instance api.InstancePut) (*api.InstanceUpdateCheck, error) {
+	if !r.HasExtension("instances_limits_check") {
+		return nil, fmt.Errorf("The server is missing the required \"instances_limits_check\" API extension")
+	}
+
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	check := api.InstanceUpdateCheck{}
+
+	// Send the request
+	_, err = r.queryStruct("PUT", fmt.Sprintf("%s/%s?check=1", path, url.PathEscape(name)), instance, "", &check)
+	if err != nil {
+		return nil, err
+	}
+
+	return &check, nil
+}
+
 // RenameInstance requests that Incus renames the instance.
 func (r *ProtocolIncus) RenameInstance(name string, instance api.InstancePost) (Operation, error) {
 	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
@@ -1451,7 +1496,7 @@ func (r *ProtocolIncus) GetInstanceFile(instanceName string, filePath string) (i
 		return nil, nil, err
 	}
 
-	req, err := http.NewRequest("GET", requestURL, nil)
+	req, err := http.NewRequestWithContext(r.ctx, "GET", requestURL, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1543,7 +1588,27 @@ func (r *ProtocolIncus) CreateInstanceFile(instanceName string, filePath string,
 		return err
 	}
 
-	req, err := http.NewRequest("POST", requestURL, args.Content)
+	// Report the upload progress if requested.
+	content := func() io.Reader {
+		if args.ProgressHandler == nil || args.Content == nil {
+			return args.Content
+		}
+
+		var length int64
+
+		size, err := args.Content.Seek(0, io.SeekEnd)
+		if err == nil {
+			length = size
+			_, _ = args.Content.Seek(0, io.SeekStart)
+		}
+
+		return &ioprogress.ProgressReader{
+			Reader:  args.Content,
+			Tracker: ioprogress.NewProgressTracker(ioprogress.StageFilePush, length, args.ProgressHandler),
+		}
+	}
+
+	req, err := http.NewRequestWithContext(r.ctx, "POST", requestURL, content())
 	if err != nil {
 		return err
 	}
@@ -1554,7 +1619,7 @@ func (r *ProtocolIncus) CreateInstanceFile(instanceName string, filePath string,
 			return nil, err
 		}
 
-		return io.NopCloser(args.Content), nil
+		return io.NopCloser(content()), nil
 	}
 
 	// Set the various headers
@@ -1645,6 +1710,7 @@ func (r *ProtocolIncus) rawSFTPConn(apiURL *url.URL) (net.Conn, error) {
 		Host:       apiURL.Host,
 	}
 
+	req = req.WithContext(r.ctx)
 	req.Header["Upgrade"] = []string{"sftp"}
 	req.Header["Connection"] = []string{"Upgrade"}
 
@@ -1654,9 +1720,9 @@ func (r *ProtocolIncus) rawSFTPConn(apiURL *url.URL) (net.Conn, error) {
 	var conn net.Conn
 
 	if httpTransport.TLSClientConfig != nil {
-		conn, err = httpTransport.DialTLSContext(context.Background(), "tcp", apiURL.Host)
+		conn, err = httpTransport.DialTLSContext(r.ctx, "tcp", apiURL.Host)
 	} else {
-		conn, err = httpTransport.DialContext(context.Background(), "tcp", apiURL.Host)
+		conn, err = httpTransport.DialContext(r.ctx, "tcp", apiURL.Host)
 	}
 
 	if err != nil {
@@ -1692,6 +1758,9 @@ func (r *ProtocolIncus) rawSFTPConn(apiURL *url.URL) (net.Conn, error) {
 		return nil, fmt.Errorf("Missing or unexpected Upgrade header in response")
 	}
 
+	// Close the connection when the context of the client is done.
+	context.AfterFunc(r.ctx, func() { _ = conn.Close() })
+
 	return conn, err
 }
 
@@ -1873,7 +1942,7 @@ func (r *ProtocolIncus) CopyInstanceSnapshot(source InstanceServer, instanceName
 	}
 
 	// Optimization for the local copy case
-	if destInfo.URL == sourceInfo.URL && destInfo.SocketPath == sourceInfo.SocketPath && (!r.IsClustered() || instance.Location == r.clusterTarget || r.HasExtension("cluster_internal_copy")) {
+	if destInfo.URL == sourceInfo.URL && destInfo.SocketPath == sourceInfo.SocketPath && destInfo.SSHTarget == sourceInfo.SSHTarget && (!r.IsClustered() || instance.Location == r.clusterTarget || r.HasExtension("cluster_internal_copy")) {
 		// Project handling
 		if destInfo.Project != sourceInfo.Project {
 			if !r.HasExtension("container_copy_project") {
@@ -2267,7 +2336,7 @@ func (r *ProtocolIncus) GetInstanceLogfile(name string, filename string) (io.Rea
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", uri, nil)
+	req, err := http.NewRequestWithContext(r.ctx, "GET", uri, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -2327,7 +2396,7 @@ func (r *ProtocolIncus) getInstanceExecOutputLogFile(name string, filename strin
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", uri, nil)
+	req, err := http.NewRequestWithContext(r.ctx, "GET", uri, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -2452,7 +2521,7 @@ func (r *ProtocolIncus) GetInstanceTemplateFile(instanceName string, templateNam
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", uri, nil)
+	req, err := http.NewRequestWithContext(r.ctx, "GET", uri, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -2492,7 +2561,7 @@ func (r *ProtocolIncus) CreateInstanceTemplateFile(instanceName string, template
 		return err
 	}
 
-	req, err := http.NewRequest("POST", uri, content)
+	req, err := http.NewRequestWithContext(r.ctx, "POST", uri, content)
 	if err != nil {
 		return err
 	}
@@ -2747,7 +2816,7 @@ func (r *ProtocolIncus) GetInstanceConsoleLog(instanceName string, _ *InstanceCo
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", uri, nil)
+	req, err := http.NewRequestWithContext(r.ctx, "GET", uri, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -2933,7 +3002,7 @@ func (r *ProtocolIncus) GetInstanceBackupFile(instanceName string, name string,
 	}
 
 	// Prepare the download request
-	request, err := http.NewRequest("GET", uri, nil)
+	request, err := http.NewRequestWithContext(r.ctx, "GET", uri, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -2963,12 +3032,7 @@ func (r *ProtocolIncus) GetInstanceBackupFile(instanceName string, name string,
 	if req.ProgressHandler != nil {
 		body = &ioprogress.ProgressReader{
 			ReadCloser: response.Body,
-			Tracker: &ioprogress.ProgressTracker{
-				Length: response.ContentLength,
-				Handler: func(percent int64, speed int64) {
-					req.ProgressHandler(ioprogress.ProgressData{Text: fmt.Sprintf("%d%% (%s/s)", percent, units.GetByteSizeString(speed, 2))})
-				},
-			},
+			Tracker:    ioprogress.NewProgressTracker(ioprogress.StageBackupDownload, response.ContentLength, req.ProgressHandler),
 		}
 	}
 
@@ -3085,7 +3149,7 @@ func (r *ProtocolIncus) GetInstanceDebugMemory(name string, format string) (io.R
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", requestURL, nil)
+	req, err := http.NewRequestWithContext(r.ctx, "GET", requestURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -3106,3 +3170,200 @@ func (r *ProtocolIncus) GetInstanceDebugMemory(name string, format string) (io.R
 
 	return resp.Body, nil
 }
+
+// RunInstanceDebugQMP runs a QMP command on the monitor of a running virtual machine and returns its result.
+func (r *ProtocolIncus) RunInstanceDebugQMP(name string, command api.InstanceDebugQMPPost) (any, error) {
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeVM)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.CheckExtension("instance_debug_qmp")
+	if err != nil {
+		return nil, err
+	}
+
+	var result any
+
+	_, err = r.queryStruct("POST", fmt.Sprintf("%s/%s/debug/qmp", path, url.PathEscape(name)), command, "", &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetInstanceUEFI returns the UEFI boot configuration and variables of a virtual machine.
+func (r *ProtocolIncus) GetInstanceUEFI(name string) (*api.InstanceUEFI, string, error) {
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeVM)
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = r.CheckExtension("instance_uefi")
+	if err != nil {
+		return nil, "", err
+	}
+
+	uefi := api.InstanceUEFI{}
+
+	etag, err := r.queryStruct("GET", fmt.Sprintf("%s/%s/uefi", path, url.PathEscape(name)), nil, "", &uefi)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &uefi, etag, nil
+}
+
+// UpdateInstanceUEFI updates the UEFI boot configuration of a stopped virtual machine.
+func (r *ProtocolIncus) UpdateInstanceUEFI(name string, uefi api.InstanceUEFIPut, ETag string) error {
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeVM)
+	if err != nil {
+		return err
+	}
+
+	err = r.CheckExtension("instance_uefi")
+	if err != nil {
+		return err
+	}
+
+	_, _, err = r.query("PUT", fmt.Sprintf("%s/%s/uefi", path, url.PathEscape(name)), uefi, ETag)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetInstanceUEFINVRAM returns the raw UEFI variable store of a virtual machine.
+func (r *ProtocolIncus) GetInstanceUEFINVRAM(name string) (io.ReadCloser, error) {
+	path, v, err := r.instanceTypeToPath(api.InstanceTypeVM)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.CheckExtension("instance_uefi")
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare the HTTP request
+	requestURL := fmt.Sprintf("%s/1.0%s/%s/uefi/nvram?%s", r.httpBaseURL.String(), path, url.PathEscape(name), v.Encode())
+
+	requestURL, err = r.setQueryAttributes(requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(r.ctx, "GET", requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the request
+	resp, err := r.DoHTTP(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the return value for a cleaner error
+	if resp.StatusCode != http.StatusOK {
+		_, _, err := incusParseResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp.Body, nil
+}
+
+// UpdateInstanceUEFINVRAM replaces the raw UEFI variable store of a stopped virtual machine.
+func (r *ProtocolIncus) UpdateInstanceUEFINVRAM(name string, content io.ReadSeeker) error {
+	path, v, err := r.instanceTypeToPath(api.InstanceTypeVM)
+	if err != nil {
+		return err
+	}
+
+	err = r.CheckExtension("instance_uefi")
+	if err != nil {
+		return err
+	}
+
+	// Prepare the HTTP request
+	requestURL := fmt.Sprintf("%s/1.0%s/%s/uefi/nvram?%s", r.httpBaseURL.String(), path, url.PathEscape(name), v.Encode())
+
+	requestURL, err = r.setQueryAttributes(requestURL)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(r.ctx, "PUT", requestURL, content)
+	if err != nil {
+		return err
+	}
+
+	req.GetBody = func() (io.ReadCloser, error) {
+		_, err := content.Seek(0, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		return io.NopCloser(content), nil
+	}
+
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	// Send the request
+	resp, err := r.DoHTTP(req)
+	if err != nil {
+		return err
+	}
+
+	// Check the return value for a cleaner error
+	_, _, err = incusParseResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ResetInstanceUEFINVRAM resets the UEFI variables of a stopped virtual machine to the firmware defaults.
+func (r *ProtocolIncus) ResetInstanceUEFINVRAM(name string) error {
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeVM)
+	if err != nil {
+		return err
+	}
+
+	err = r.CheckExtension("instance_uefi")
+	if err != nil {
+		return err
+	}
+
+	_, _, err = r.query("DELETE", fmt.Sprintf("%s/%s/uefi/nvram", path, url.PathEscape(name)), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EnrollInstanceUEFISecureBootKeys enrolls certificates into one of the Secure Boot variables of a stopped virtual machine.
+func (r *ProtocolIncus) EnrollInstanceUEFISecureBootKeys(name string, keys api.InstanceUEFISecureBootKeysPost) error {
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeVM)
+	if err != nil {
+		return err
+	}
+
+	err = r.CheckExtension("instance_uefi_secureboot_keys")
+	if err != nil {
+		return err
+	}
+
+	_, _, err = r.query("POST", fmt.Sprintf("%s/%s/uefi/secureboot-keys", path, url.PathEscape(name)), keys, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -91,7 +91,7 @@ func (r *ProtocolIncus) GetNetworkACLLogfile(name string) (io.ReadCloser, error)
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", uri, nil)
+	req, err := http.NewRequestWithContext(r.ctx, "GET", uri, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -3,8 +3,11 @@ package incus
 import (
 	"context"
 	"crypto/rand"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 	"net/url"
 	"os"
@@ -19,21 +22,105 @@ import (
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 	"golang.org/x/oauth2"
 
+	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/util"
 )
 
 // ErrOIDCExpired is returned when the token is expired and we can't retry the request ourselves.
 var ErrOIDCExpired = fmt.Errorf("OIDC token expired, please re-try the request")
 
-// setupOIDCClient initializes the OIDC (OpenID Connect) client with given tokens if it hasn't been set up already.
+// OIDCTokenCache stores the OIDC tokens of a client between its runs, so that services and CI jobs only
+// go through the device authorization flow when the tokens can't be refreshed anymore.
+type OIDCTokenCache interface {
+	// Load returns the stored tokens, or nil if there are none.
+	Load() (*oidc.Tokens[*oidc.IDTokenClaims], error)
+
+	// Save stores the tokens, called whenever they are renewed.
+	Save(tokens *oidc.Tokens[*oidc.IDTokenClaims]) error
+}
+
+// OIDCDeviceAuthorization holds what the user needs to complete the OIDC device authorization flow
+// from any device with a browser.
+type OIDCDeviceAuthorization struct {
+	// URL where the user enters the code.
+	VerificationURI string
+
+	// URL including the code, if supported by the provider.
+	VerificationURIComplete string
+
+	// Code to enter.
+	UserCode string
+
+	// Time after which the code can't be used anymore.
+	Expiry time.Time
+}
+
+// fileTokenCache is an OIDCTokenCache storing the tokens as JSON in a file.
+type fileTokenCache struct {
+	path string
+}
+
+// NewOIDCFileTokenCache returns an OIDCTokenCache storing the tokens as JSON in the file at the given path,
+// readable only by its owner.
+func NewOIDCFileTokenCache(path string) OIDCTokenCache {
+	return &fileTokenCache{path: path}
+}
+
+// Load reads the tokens from the file, returning nil if it doesn't exist.
+func (c *fileTokenCache) Load() (*oidc.Tokens[*oidc.IDTokenClaims], error) {
+	content, err := os.ReadFile(c.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	tokens := &oidc.Tokens[*oidc.IDTokenClaims]{}
+
+	err = json.Unmarshal(content, tokens)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing OIDC tokens %q: %w", c.path, err)
+	}
+
+	return tokens, nil
+}
+
+// Save writes the tokens to the file.
+func (c *fileTokenCache) Save(tokens *oidc.Tokens[*oidc.IDTokenClaims]) error {
+	content, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.path, content, 0o600)
+}
+
+// setupOIDCClient initializes the OIDC (OpenID Connect) client with given tokens if it hasn't been set up already,
+// loading them from the cache if not provided.
 // It also assigns the protocol's http client to the oidcClient's httpClient.
-func (r *ProtocolIncus) setupOIDCClient(token *oidc.Tokens[*oidc.IDTokenClaims]) {
+func (r *ProtocolIncus) setupOIDCClient(tokens *oidc.Tokens[*oidc.IDTokenClaims], cache OIDCTokenCache, deviceHandler func(OIDCDeviceAuthorization) error) error {
 	if r.oidcClient != nil {
-		return
+		return nil
 	}
 
-	r.oidcClient = newOIDCClient(token)
+	if tokens == nil && cache != nil {
+		var err error
+
+		tokens, err = cache.Load()
+		if err != nil {
+			return fmt.Errorf("Failed loading OIDC tokens: %w", err)
+		}
+	}
+
+	r.oidcClient = newOIDCClient(tokens)
 	r.oidcClient.httpClient = r.http
+	r.oidcClient.ctx = r.ctx
+	r.oidcClient.cache = cache
+	r.oidcClient.deviceHandler = deviceHandler
+
+	return nil
 }
 
 // GetOIDCTokens returns the current OIDC tokens (if any) from the OIDC client.
@@ -84,15 +171,19 @@ var (
 )
 
 type oidcClient struct {
+	ctx           context.Context
 	httpClient    *http.Client
 	oidcTransport *oidcTransport
 	tokens        *oidc.Tokens[*oidc.IDTokenClaims]
+	cache         OIDCTokenCache
+	deviceHandler func(OIDCDeviceAuthorization) error
 }
 
 // oidcClient is a structure encapsulating an HTTP client, OIDC transport, and a token for OpenID Connect (OIDC) operations.
 // newOIDCClient constructs a new oidcClient, ensuring the token field is non-nil to prevent panics during authentication.
 func newOIDCClient(tokens *oidc.Tokens[*oidc.IDTokenClaims]) *oidcClient {
 	client := oidcClient{
+		ctx:           context.Background(),
 		tokens:        tokens,
 		httpClient:    &http.Client{},
 		oidcTransport: &oidcTransport{},
@@ -137,12 +228,9 @@ func (o *oidcClient) do(req *http.Request) (*http.Response, error) {
 	}
 
 	// Refresh the token.
-	err = o.refresh(issuer, clientID)
+	err = o.renew(issuer, clientID, audience)
 	if err != nil {
-		err = o.authenticate(issuer, clientID, audience)
-		if err != nil {
-			return nil, err
-		}
+		return nil, err
 	}
 
 	// If not dealing with something we can retry, return a clear error.
@@ -173,7 +261,7 @@ func (o *oidcClient) do(req *http.Request) (*http.Response, error) {
 
 // dial function executes a websocket request and handles OIDC authentication and refresh.
 func (o *oidcClient) dial(dialer websocket.Dialer, uri string, req *http.Request) (*websocket.Conn, *http.Response, error) {
-	conn, resp, err := dialer.Dial(uri, req.Header)
+	conn, resp, err := dialer.DialContext(req.Context(), uri, req.Header)
 	if err != nil && resp == nil {
 		return nil, nil, err
 	}
@@ -191,18 +279,35 @@ func (o *oidcClient) dial(dialer websocket.Dialer, uri string, req *http.Request
 		return nil, resp, err
 	}
 
-	err = o.refresh(issuer, clientID)
+	err = o.renew(issuer, clientID, audience)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	// Set the new access token in the header.
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", o.tokens.AccessToken))
+
+	return dialer.DialContext(req.Context(), uri, req.Header)
+}
+
+// renew refreshes the tokens, or authenticates again if they can't be refreshed, then saves them to the cache.
+func (o *oidcClient) renew(issuer string, clientID string, audience string) error {
+	err := o.refresh(issuer, clientID)
 	if err != nil {
 		err = o.authenticate(issuer, clientID, audience)
 		if err != nil {
-			return nil, resp, err
+			return err
 		}
 	}
 
-	// Set the new access token in the header.
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", o.tokens.AccessToken))
+	if o.cache != nil {
+		err = o.cache.Save(o.tokens)
+		if err != nil {
+			logger.Warn("Failed saving OIDC tokens", logger.Ctx{"err": err})
+		}
+	}
 
-	return dialer.Dial(uri, req.Header)
+	return nil
 }
 
 // getProvider initializes a new OpenID Connect Relying Party for a given issuer and clientID.
@@ -229,7 +334,7 @@ func (o *oidcClient) getProvider(issuer string, clientID string) (rp.RelyingPart
 		rp.WithHTTPClient(o.httpClient),
 	}
 
-	provider, err := rp.NewRelyingPartyOIDC(context.TODO(), issuer, clientID, "", "", oidcScopes, options...)
+	provider, err := rp.NewRelyingPartyOIDC(o.ctx, issuer, clientID, "", "", oidcScopes, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -249,7 +354,7 @@ func (o *oidcClient) refresh(issuer string, clientID string) error {
 		return errRefreshAccessToken
 	}
 
-	oauthTokens, err := rp.RefreshTokens[*oidc.IDTokenClaims](context.TODO(), provider, o.tokens.RefreshToken, "", "")
+	oauthTokens, err := rp.RefreshTokens[*oidc.IDTokenClaims](o.ctx, provider, o.tokens.RefreshToken, "", "")
 	if err != nil {
 		return errRefreshAccessToken
 	}
@@ -285,20 +390,49 @@ func (o *oidcClient) authenticate(issuer string, clientID string, audience strin
 
 	o.oidcTransport.deviceAuthorizationEndpoint = provider.GetDeviceAuthorizationEndpoint()
 
-	resp, err := rp.DeviceAuthorization(context.TODO(), oidcScopes, provider, nil)
+	resp, err := rp.DeviceAuthorization(o.ctx, oidcScopes, provider, nil)
 	if err != nil {
 		return err
 	}
 
-	u, _ := url.Parse(resp.VerificationURIComplete)
+	authorization := OIDCDeviceAuthorization{
+		VerificationURI:         resp.VerificationURI,
+		VerificationURIComplete: resp.VerificationURIComplete,
+		UserCode:                resp.UserCode,
+		Expiry:                  time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second),
+	}
+
+	ctx := o.ctx
+	if o.deviceHandler != nil {
+		err = o.deviceHandler(authorization)
+		if err != nil {
+			return err
+		}
+	} else {
+		verificationURI := resp.VerificationURIComplete
+		if verificationURI == "" {
+			verificationURI = resp.VerificationURI
+		}
+
+		u, _ := url.Parse(verificationURI)
+
+		fmt.Printf("URL: %s\n", u.String())
+		fmt.Printf("Code: %s\n\n", resp.UserCode)
 
-	fmt.Printf("URL: %s\n", u.String())
-	fmt.Printf("Code: %s\n\n", resp.UserCode)
+		_ = util.OpenBrowser(u.String())
 
-	_ = util.OpenBrowser(u.String())
+		// Let the user interrupt the wait.
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, os.Interrupt, syscall.SIGINT)
+		defer stop()
+	}
 
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGINT)
-	defer stop()
+	// Stop waiting once the code expired.
+	if resp.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, authorization.Expiry)
+		defer cancel()
+	}
 
 	token, err := rp.DeviceAccessToken(ctx, resp.DeviceCode, time.Duration(resp.Interval)*time.Second, provider)
 	if err != nil {
@@ -309,7 +443,7 @@ func (o *oidcClient) authenticate(issuer string, clientID string, audience strin
 		o.tokens.Token = &oauth2.Token{}
 	}
 
-	o.tokens.Expiry = time.Now().Add(time.Duration(token.ExpiresIn))
+	o.tokens.Expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
 	o.tokens.IDToken = token.IDToken
 	o.tokens.AccessToken = token.AccessToken
 	o.tokens.TokenType = token.TokenType
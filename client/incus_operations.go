@@ -42,6 +42,34 @@ func (r *ProtocolIncus) GetOperations() ([]api.Operation, error) {
 	return operations, nil
 }
 
+// GetOperationsWithFilter returns a filtered list of operations.
+func (r *ProtocolIncus) GetOperationsWithFilter(filters []string) ([]api.Operation, error) {
+	err := r.CheckExtension("collection_filtering")
+	if err != nil {
+		return nil, err
+	}
+
+	apiOperations := map[string][]api.Operation{}
+
+	v := url.Values{}
+	v.Set("recursion", "1")
+	v.Set("filter", parseFilters(filters))
+
+	// Fetch the raw value.
+	_, err = r.queryStruct("GET", fmt.Sprintf("/operations?%s", v.Encode()), nil, "", &apiOperations)
+	if err != nil {
+		return nil, err
+	}
+
+	// Turn it into a list of operations.
+	operations := []api.Operation{}
+	for _, v := range apiOperations {
+		operations = append(operations, v...)
+	}
+
+	return operations, nil
+}
+
 // GetOperationsAllProjects returns a list of operations from all projects.
 func (r *ProtocolIncus) GetOperationsAllProjects() ([]api.Operation, error) {
 	err := r.CheckExtension("operations_get_query_all_projects")
@@ -44,6 +44,23 @@ func (r *ProtocolIncus) GetProjects() ([]api.Project, error) {
 	return projects, nil
 }
 
+// GetProjectsFull returns a list of projects as ProjectFull structs, including their resource usage.
+func (r *ProtocolIncus) GetProjectsFull() ([]api.ProjectFull, error) {
+	if !r.HasExtension("projects_usage_report") {
+		return nil, fmt.Errorf("The server is missing the required \"projects_usage_report\" API extension")
+	}
+
+	projects := []api.ProjectFull{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", "/projects?recursion=2", nil, "", &projects)
+	if err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
 // GetProjectsWithFilter returns a filtered list of projects as Project structs.
 func (r *ProtocolIncus) GetProjectsWithFilter(filters []string) ([]api.Project, error) {
 	if !r.HasExtension("projects") {
@@ -98,6 +115,24 @@ func (r *ProtocolIncus) GetProjectState(name string) (*api.ProjectState, error)
 	return &projectState, nil
 }
 
+// GetProjectAudit returns the configuration of the instances and profiles of the project which violates its
+// current restrictions.
+func (r *ProtocolIncus) GetProjectAudit(name string) ([]api.ProjectAuditViolation, error) {
+	if !r.HasExtension("projects_audit") {
+		return nil, fmt.Errorf("The server is missing the required \"projects_audit\" API extension")
+	}
+
+	violations := []api.ProjectAuditViolation{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", fmt.Sprintf("/projects/%s/audit", url.PathEscape(name)), nil, "", &violations)
+	if err != nil {
+		return nil, err
+	}
+
+	return violations, nil
+}
+
 // GetProjectAccess returns an Access entry for the specified project.
 func (r *ProtocolIncus) GetProjectAccess(name string) (api.Access, error) {
 	access := api.Access{}
@@ -121,6 +156,10 @@ func (r *ProtocolIncus) CreateProject(project api.ProjectsPost) error {
 		return fmt.Errorf("The server is missing the required \"projects\" API extension")
 	}
 
+	if project.Source != "" && !r.HasExtension("projects_templates") {
+		return fmt.Errorf("The server is missing the required \"projects_templates\" API extension")
+	}
+
 	// Send the request
 	_, _, err := r.query("POST", "/projects", project, "")
 	if err != nil {
@@ -160,6 +199,21 @@ func (r *ProtocolIncus) RenameProject(name string, project api.ProjectPost) (Ope
 	return op, nil
 }
 
+// RemapProject allocates new idmaps to the stopped containers of the project and shifts their filesystem.
+func (r *ProtocolIncus) RemapProject(name string) (Operation, error) {
+	if !r.HasExtension("project_idmap_isolated") {
+		return nil, fmt.Errorf("The server is missing the required \"project_idmap_isolated\" API extension")
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("POST", fmt.Sprintf("/projects/%s/remap", url.PathEscape(name)), nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
 // DeleteProject deletes a project.
 func (r *ProtocolIncus) DeleteProject(name string) error {
 	if !r.HasExtension("projects") {
@@ -0,0 +1,111 @@
+package incus
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// GetScheduledTaskNames returns a list of scheduled task names.
+func (r *ProtocolIncus) GetScheduledTaskNames() ([]string, error) {
+	err := r.CheckExtension("scheduled_tasks")
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch the raw URL values.
+	urls := []string{}
+	baseURL := "/scheduled-tasks"
+	_, err = r.queryStruct("GET", baseURL, nil, "", &urls)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse it.
+	return urlsToResourceNames(baseURL, urls...)
+}
+
+// GetScheduledTasks returns a list of scheduled task structs.
+func (r *ProtocolIncus) GetScheduledTasks() ([]api.ScheduledTask, error) {
+	err := r.CheckExtension("scheduled_tasks")
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []api.ScheduledTask{}
+
+	// Fetch the raw value.
+	_, err = r.queryStruct("GET", "/scheduled-tasks?recursion=1", nil, "", &tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// GetScheduledTask returns a scheduled task entry for the provided name.
+func (r *ProtocolIncus) GetScheduledTask(name string) (*api.ScheduledTask, string, error) {
+	err := r.CheckExtension("scheduled_tasks")
+	if err != nil {
+		return nil, "", err
+	}
+
+	task := api.ScheduledTask{}
+
+	// Fetch the raw value.
+	etag, err := r.queryStruct("GET", fmt.Sprintf("/scheduled-tasks/%s", url.PathEscape(name)), nil, "", &task)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &task, etag, nil
+}
+
+// CreateScheduledTask defines a new scheduled task using the provided struct.
+func (r *ProtocolIncus) CreateScheduledTask(task api.ScheduledTasksPost) error {
+	err := r.CheckExtension("scheduled_tasks")
+	if err != nil {
+		return err
+	}
+
+	// Send the request.
+	_, _, err = r.query("POST", "/scheduled-tasks", task, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateScheduledTask updates the scheduled task to match the provided struct.
+func (r *ProtocolIncus) UpdateScheduledTask(name string, task api.ScheduledTaskPut, ETag string) error {
+	err := r.CheckExtension("scheduled_tasks")
+	if err != nil {
+		return err
+	}
+
+	// Send the request.
+	_, _, err = r.query("PUT", fmt.Sprintf("/scheduled-tasks/%s", url.PathEscape(name)), task, ETag)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteScheduledTask deletes an existing scheduled task.
+func (r *ProtocolIncus) DeleteScheduledTask(name string) error {
+	err := r.CheckExtension("scheduled_tasks")
+	if err != nil {
+		return err
+	}
+
+	// Send the request.
+	_, _, err = r.query("DELETE", fmt.Sprintf("/scheduled-tasks/%s", url.PathEscape(name)), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
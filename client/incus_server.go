@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"slices"
+	"time"
 
 	"github.com/gorilla/websocket"
 
@@ -99,6 +101,43 @@ func (r *ProtocolIncus) GetServerResources() (*api.Resources, error) {
 	return &resources, nil
 }
 
+// GetServerLogs returns the messages of the server log matching the given filter.
+func (r *ProtocolIncus) GetServerLogs(args *GetServerLogsArgs) ([]api.ServerLogEntry, error) {
+	if !r.HasExtension("server_logs") {
+		return nil, fmt.Errorf("The server is missing the required \"server_logs\" API extension")
+	}
+
+	values := url.Values{}
+
+	if args != nil {
+		if args.Level != "" {
+			values.Set("level", args.Level)
+		}
+
+		if !args.Since.IsZero() {
+			values.Set("since", args.Since.Format(time.RFC3339))
+		}
+
+		if !args.Until.IsZero() {
+			values.Set("until", args.Until.Format(time.RFC3339))
+		}
+	}
+
+	path := "/logs"
+	if len(values) > 0 {
+		path += "?" + values.Encode()
+	}
+
+	entries := []api.ServerLogEntry{}
+
+	_, err := r.queryStruct("GET", path, nil, "", &entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
 // UseProject returns a client that will use a specific project.
 func (r *ProtocolIncus) UseProject(name string) InstanceServer {
 	return &ProtocolIncus{
@@ -112,12 +151,16 @@ func (r *ProtocolIncus) UseProject(name string) InstanceServer {
 		httpProtocol:         r.httpProtocol,
 		httpUserAgent:        r.httpUserAgent,
 		httpUnixPath:         r.httpUnixPath,
+		httpSSHTarget:        r.httpSSHTarget,
 		requireAuthenticated: r.requireAuthenticated,
 		clusterTarget:        r.clusterTarget,
 		project:              name,
 		eventConns:           make(map[string]*websocket.Conn),  // New project specific listener conns.
 		eventListeners:       make(map[string][]*EventListener), // New project specific listeners.
 		oidcClient:           r.oidcClient,
+		retryPolicy:          r.retryPolicy,
+		responseCache:        r.responseCache,
+		sshJump:              r.sshJump,
 	}
 }
 
@@ -136,11 +179,15 @@ func (r *ProtocolIncus) UseTarget(name string) InstanceServer {
 		httpProtocol:         r.httpProtocol,
 		httpUserAgent:        r.httpUserAgent,
 		httpUnixPath:         r.httpUnixPath,
+		httpSSHTarget:        r.httpSSHTarget,
 		requireAuthenticated: r.requireAuthenticated,
 		project:              r.project,
 		eventConns:           make(map[string]*websocket.Conn),  // New target specific listener conns.
 		eventListeners:       make(map[string][]*EventListener), // New target specific listeners.
 		oidcClient:           r.oidcClient,
+		retryPolicy:          r.retryPolicy,
+		responseCache:        r.responseCache,
+		sshJump:              r.sshJump,
 		clusterTarget:        name,
 	}
 }
@@ -163,7 +210,7 @@ func (r *ProtocolIncus) GetMetrics() (string, error) {
 		return "", err
 	}
 
-	req, err := http.NewRequest("GET", requestURL, nil)
+	req, err := http.NewRequestWithContext(r.ctx, "GET", requestURL, nil)
 	if err != nil {
 		return "", err
 	}
@@ -568,6 +615,26 @@ func (r *ProtocolIncus) ApplyServerPreseed(config api.InitPreseed) error {
 				return fmt.Errorf("Failed to configure cluster: %w", err)
 			}
 		}
+
+		// Assign the requested roles.
+		if len(config.Cluster.Roles) > 0 {
+			member, etag, err := r.GetClusterMember(config.Cluster.ServerName)
+			if err != nil {
+				return fmt.Errorf("Failed to retrieve cluster member %q: %w", config.Cluster.ServerName, err)
+			}
+
+			memberPut := member.Writable()
+			for _, role := range config.Cluster.Roles {
+				if !slices.Contains(memberPut.Roles, role) {
+					memberPut.Roles = append(memberPut.Roles, role)
+				}
+			}
+
+			err = r.UpdateClusterMember(config.Cluster.ServerName, memberPut, etag)
+			if err != nil {
+				return fmt.Errorf("Failed to assign roles to cluster member %q: %w", config.Cluster.ServerName, err)
+			}
+		}
 	}
 
 	return nil
@@ -0,0 +1,121 @@
+package incus
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// Session handling functions
+
+// GetSessionIDs returns a list of active session IDs.
+func (r *ProtocolIncus) GetSessionIDs() ([]string, error) {
+	if !r.HasExtension("sessions") {
+		return nil, fmt.Errorf("The server is missing the required \"sessions\" API extension")
+	}
+
+	// Fetch the raw values.
+	urls := []string{}
+	baseURL := "/sessions"
+	_, err := r.queryStruct("GET", baseURL, nil, "", &urls)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse it.
+	return urlsToResourceNames(baseURL, urls...)
+}
+
+// GetSessions returns a list of active sessions.
+func (r *ProtocolIncus) GetSessions() ([]api.Session, error) {
+	if !r.HasExtension("sessions") {
+		return nil, fmt.Errorf("The server is missing the required \"sessions\" API extension")
+	}
+
+	sessions := []api.Session{}
+
+	_, err := r.queryStruct("GET", "/sessions?recursion=1", nil, "", &sessions)
+	if err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// GetSession returns the active session with the given ID.
+func (r *ProtocolIncus) GetSession(id string) (*api.Session, error) {
+	if !r.HasExtension("sessions") {
+		return nil, fmt.Errorf("The server is missing the required \"sessions\" API extension")
+	}
+
+	session := api.Session{}
+
+	_, err := r.queryStruct("GET", fmt.Sprintf("/sessions/%s", url.PathEscape(id)), nil, "", &session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// DeleteSession terminates the active session with the given ID.
+func (r *ProtocolIncus) DeleteSession(id string) error {
+	if !r.HasExtension("sessions") {
+		return fmt.Errorf("The server is missing the required \"sessions\" API extension")
+	}
+
+	// Send the request
+	_, _, err := r.query("DELETE", fmt.Sprintf("/sessions/%s", url.PathEscape(id)), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetSessionBlocks returns the identities which are temporarily blocked.
+func (r *ProtocolIncus) GetSessionBlocks() ([]api.SessionBlock, error) {
+	if !r.HasExtension("sessions") {
+		return nil, fmt.Errorf("The server is missing the required \"sessions\" API extension")
+	}
+
+	blocks := []api.SessionBlock{}
+
+	_, err := r.queryStruct("GET", "/session-blocks", nil, "", &blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+// CreateSessionBlock temporarily blocks an identity and terminates its active sessions.
+func (r *ProtocolIncus) CreateSessionBlock(block api.SessionBlock) error {
+	if !r.HasExtension("sessions") {
+		return fmt.Errorf("The server is missing the required \"sessions\" API extension")
+	}
+
+	// Send the request
+	_, _, err := r.query("POST", "/session-blocks", block, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteSessionBlock removes the block of an identity.
+func (r *ProtocolIncus) DeleteSessionBlock(username string) error {
+	if !r.HasExtension("sessions") {
+		return fmt.Errorf("The server is missing the required \"sessions\" API extension")
+	}
+
+	// Send the request
+	_, _, err := r.query("DELETE", fmt.Sprintf("/session-blocks/%s", url.PathEscape(username)), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -65,6 +65,10 @@ func (r *ProtocolIncus) UpdateWarning(UUID string, warning api.WarningPut, ETag
 		return fmt.Errorf("The server is missing the required \"warnings\" API extension")
 	}
 
+	if warning.AcknowledgedUntil != nil && !r.HasExtension("warnings_notifications") {
+		return fmt.Errorf("The server is missing the required \"warnings_notifications\" API extension")
+	}
+
 	// Send the request
 	_, _, err := r.query("PUT", fmt.Sprintf("/warnings/%s", url.PathEscape(UUID)), warning, ETag)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/pkg/sftp"
@@ -78,6 +79,7 @@ type InstanceServer interface {
 	GetMetrics() (metrics string, err error)
 	GetServer() (server *api.Server, ETag string, err error)
 	GetServerResources() (resources *api.Resources, err error)
+	GetServerLogs(args *GetServerLogsArgs) (entries []api.ServerLogEntry, err error)
 	UpdateServer(server api.ServerPut, ETag string) (err error)
 	ApplyServerPreseed(config api.InitPreseed) error
 	HasExtension(extension string) (exists bool)
@@ -85,6 +87,7 @@ type InstanceServer interface {
 	IsClustered() (clustered bool)
 	UseTarget(name string) (client InstanceServer)
 	UseProject(name string) (client InstanceServer)
+	WithContext(ctx context.Context) (client InstanceServer)
 
 	// Certificate functions
 	GetCertificateFingerprints() (fingerprints []string, err error)
@@ -109,10 +112,12 @@ type InstanceServer interface {
 	GetInstancesFullAllProjectsWithFilter(instanceType api.InstanceType, filters []string) (instances []api.InstanceFull, err error)
 	GetInstance(name string) (instance *api.Instance, ETag string, err error)
 	GetInstanceFull(name string) (instance *api.InstanceFull, ETag string, err error)
+	GetInstanceWithOrigins(name string) (instance *api.Instance, ETag string, err error)
 	CreateInstance(instance api.InstancesPost) (op Operation, err error)
 	CreateInstanceFromImage(source ImageServer, image api.Image, req api.InstancesPost) (op RemoteOperation, err error)
 	CopyInstance(source InstanceServer, instance api.Instance, args *InstanceCopyArgs) (op RemoteOperation, err error)
 	UpdateInstance(name string, instance api.InstancePut, ETag string) (op Operation, err error)
+	CheckInstanceUpdate(name string, instance api.InstancePut) (check *api.InstanceUpdateCheck, err error)
 	RenameInstance(name string, instance api.InstancePost) (op Operation, err error)
 	MigrateInstance(name string, instance api.InstancePost) (op Operation, err error)
 	DeleteInstance(name string) (op Operation, err error)
@@ -171,6 +176,14 @@ type InstanceServer interface {
 	DeleteInstanceTemplateFile(name string, templateName string) (err error)
 
 	GetInstanceDebugMemory(name string, format string) (rc io.ReadCloser, err error)
+	RunInstanceDebugQMP(name string, command api.InstanceDebugQMPPost) (result any, err error)
+
+	GetInstanceUEFI(name string) (uefi *api.InstanceUEFI, ETag string, err error)
+	UpdateInstanceUEFI(name string, uefi api.InstanceUEFIPut, ETag string) (err error)
+	GetInstanceUEFINVRAM(name string) (content io.ReadCloser, err error)
+	UpdateInstanceUEFINVRAM(name string, content io.ReadSeeker) (err error)
+	ResetInstanceUEFINVRAM(name string) (err error)
+	EnrollInstanceUEFISecureBootKeys(name string, keys api.InstanceUEFISecureBootKeysPost) (err error)
 
 	// Event handling functions
 	GetEvents() (listener *EventListener, err error)
@@ -284,6 +297,7 @@ type InstanceServer interface {
 	// Operation functions
 	GetOperationUUIDs() (uuids []string, err error)
 	GetOperations() (operations []api.Operation, err error)
+	GetOperationsWithFilter(filters []string) (operations []api.Operation, err error)
 	GetOperationsAllProjects() (operations []api.Operation, err error)
 	GetOperation(uuid string) (op *api.Operation, ETag string, err error)
 	GetOperationWait(uuid string, timeout int) (op *api.Operation, ETag string, err error)
@@ -306,16 +320,30 @@ type InstanceServer interface {
 	// Project functions
 	GetProjectNames() (names []string, err error)
 	GetProjects() (projects []api.Project, err error)
+	GetProjectsFull() (projects []api.ProjectFull, err error)
 	GetProjectsWithFilter(filters []string) (projects []api.Project, err error)
 	GetProject(name string) (project *api.Project, ETag string, err error)
 	GetProjectState(name string) (project *api.ProjectState, err error)
+	GetProjectAudit(name string) (violations []api.ProjectAuditViolation, err error)
 	GetProjectAccess(name string) (access api.Access, err error)
 	CreateProject(project api.ProjectsPost) (err error)
 	UpdateProject(name string, project api.ProjectPut, ETag string) (err error)
 	RenameProject(name string, project api.ProjectPost) (op Operation, err error)
+	RemapProject(name string) (op Operation, err error)
 	DeleteProject(name string) (err error)
 	DeleteProjectForce(name string) (err error)
 
+	// Scheduled task functions ("scheduled_tasks" API extension)
+	GetScheduledTaskNames() (names []string, err error)
+	GetScheduledTasks() (tasks []api.ScheduledTask, err error)
+	GetScheduledTask(name string) (task *api.ScheduledTask, ETag string, err error)
+	CreateScheduledTask(task api.ScheduledTasksPost) (err error)
+	UpdateScheduledTask(name string, task api.ScheduledTaskPut, ETag string) (err error)
+	DeleteScheduledTask(name string) (err error)
+
+	// Batch functions ("batch" API extension)
+	RunBatch(batch api.BatchPost) (op Operation, err error)
+
 	// Storage pool functions ("storage" API extension)
 	GetStoragePoolNames() (names []string, err error)
 	GetStoragePools() (pools []api.StoragePool, err error)
@@ -398,11 +426,13 @@ type InstanceServer interface {
 	DeleteClusterMember(name string, force bool) (err error)
 	GetClusterMemberNames() (names []string, err error)
 	GetClusterMembers() (members []api.ClusterMember, err error)
+	GetClusterMembersWithFilter(filters []string) (members []api.ClusterMember, err error)
 	GetClusterMember(name string) (member *api.ClusterMember, ETag string, err error)
 	UpdateClusterMember(name string, member api.ClusterMemberPut, ETag string) (err error)
 	RenameClusterMember(name string, member api.ClusterMemberPost) (err error)
 	CreateClusterMember(member api.ClusterMembersPost) (op Operation, err error)
 	UpdateClusterCertificate(certs api.ClusterCertificatePut, ETag string) (err error)
+	RenewClusterCertificate() (certificate string, err error)
 	GetClusterMemberState(name string) (*api.ClusterMemberState, string, error)
 	UpdateClusterMemberState(name string, state api.ClusterMemberStatePost) (op Operation, err error)
 	GetClusterGroups() ([]api.ClusterGroup, error)
@@ -420,6 +450,15 @@ type InstanceServer interface {
 	UpdateWarning(UUID string, warning api.WarningPut, ETag string) (err error)
 	DeleteWarning(UUID string) (err error)
 
+	// Session functions
+	GetSessionIDs() (ids []string, err error)
+	GetSessions() (sessions []api.Session, err error)
+	GetSession(id string) (session *api.Session, err error)
+	DeleteSession(id string) (err error)
+	GetSessionBlocks() (blocks []api.SessionBlock, err error)
+	CreateSessionBlock(block api.SessionBlock) (err error)
+	DeleteSessionBlock(username string) (err error)
+
 	// Internal functions (for internal use)
 	RawQuery(method string, path string, data any, queryETag string) (resp *api.Response, ETag string, err error)
 	RawWebsocket(path string) (conn *websocket.Conn, err error)
@@ -433,6 +472,7 @@ type ConnectionInfo struct {
 	Protocol    string
 	URL         string
 	SocketPath  string
+	SSHTarget   string
 	Project     string
 	Target      string
 }
@@ -455,6 +495,18 @@ type BackupFileResponse struct {
 	Size int64
 }
 
+// The GetServerLogsArgs struct is used to filter the messages of the server log.
+type GetServerLogsArgs struct {
+	// Least severe level of the messages
+	Level string
+
+	// Only return the messages logged since this time
+	Since time.Time
+
+	// Only return the messages logged until this time
+	Until time.Time
+}
+
 // The ImageCreateArgs struct is used for direct image upload.
 type ImageCreateArgs struct {
 	// Reader for the meta file
@@ -676,6 +728,9 @@ type InstanceFileArgs struct {
 
 	// File write mode (overwrite or append)
 	WriteMode string
+
+	// Progress handler (called with upload progress)
+	ProgressHandler func(progress ioprogress.ProgressData)
 }
 
 // The InstanceFileResponse struct is used as part of the response for a instance file download.
@@ -19,7 +19,6 @@ import (
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/osarch"
 	"github.com/lxc/incus/v6/shared/subprocess"
-	"github.com/lxc/incus/v6/shared/units"
 )
 
 type ociInfo struct {
@@ -180,7 +179,7 @@ func (r *ProtocolOCI) GetImageFile(fingerprint string, req ImageFileRequest) (*I
 
 	// Copy the image.
 	if req.ProgressHandler != nil {
-		req.ProgressHandler(ioprogress.ProgressData{Text: "Retrieving OCI image from registry"})
+		req.ProgressHandler(ioprogress.ProgressData{Text: "Retrieving OCI image from registry", Stage: ioprogress.StageImageDownload})
 	}
 
 	stdout, _, err := subprocess.RunCommandSplit(
@@ -200,7 +199,7 @@ func (r *ProtocolOCI) GetImageFile(fingerprint string, req ImageFileRequest) (*I
 
 	// Convert to something usable.
 	if req.ProgressHandler != nil {
-		req.ProgressHandler(ioprogress.ProgressData{Text: "Unpacking the OCI image"})
+		req.ProgressHandler(ioprogress.ProgressData{Text: "Unpacking the OCI image", Stage: ioprogress.StageImageUnpack})
 	}
 
 	stdout, err = subprocess.RunCommand(
@@ -216,7 +215,7 @@ func (r *ProtocolOCI) GetImageFile(fingerprint string, req ImageFileRequest) (*I
 
 	// Generate a metadata.yaml.
 	if req.ProgressHandler != nil {
-		req.ProgressHandler(ioprogress.ProgressData{Text: "Generating image metadata"})
+		req.ProgressHandler(ioprogress.ProgressData{Text: "Generating image metadata", Stage: ioprogress.StageImageUnpack})
 	}
 
 	metadata := api.ImageMetadata{
@@ -252,11 +251,10 @@ func (r *ProtocolOCI) GetImageFile(fingerprint string, req ImageFileRequest) (*I
 	if req.ProgressHandler != nil {
 		pipeRead = &ioprogress.ProgressReader{
 			ReadCloser: pipeRead,
-			Tracker: &ioprogress.ProgressTracker{
-				Handler: func(received int64, speed int64) {
-					req.ProgressHandler(ioprogress.ProgressData{Text: fmt.Sprintf("Generating metadata tarball: %s (%s/s)", units.GetByteSizeString(received, 2), units.GetByteSizeString(speed, 2))})
-				},
-			},
+			Tracker: ioprogress.NewProgressTracker(ioprogress.StageImageDownload, 0, func(data ioprogress.ProgressData) {
+				data.Text = fmt.Sprintf("Generating metadata tarball: %s", data.Text)
+				req.ProgressHandler(data)
+			}),
 		}
 	}
 
@@ -288,11 +286,10 @@ func (r *ProtocolOCI) GetImageFile(fingerprint string, req ImageFileRequest) (*I
 	if req.ProgressHandler != nil {
 		pipeRead = &ioprogress.ProgressReader{
 			ReadCloser: pipeRead,
-			Tracker: &ioprogress.ProgressTracker{
-				Handler: func(received int64, speed int64) {
-					req.ProgressHandler(ioprogress.ProgressData{Text: fmt.Sprintf("Generating rootfs tarball: %s (%s/s)", units.GetByteSizeString(received, 2), units.GetByteSizeString(speed, 2))})
-				},
-			},
+			Tracker: ioprogress.NewProgressTracker(ioprogress.StageImageDownload, 0, func(data ioprogress.ProgressData) {
+				data.Text = fmt.Sprintf("Generating rootfs tarball: %s", data.Text)
+				req.ProgressHandler(data)
+			}),
 		}
 	}
 
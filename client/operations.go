@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
 
 	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/ioprogress"
+	"github.com/lxc/incus/v6/shared/units"
 )
 
 // The Operation type represents an ongoing Incus operation (asynchronous processing).
@@ -113,9 +116,9 @@ func (op *operation) Refresh() error {
 	return nil
 }
 
-// Wait lets you wait until the operation reaches a final state.
+// Wait lets you wait until the operation reaches a final state, or the context of the client is done.
 func (op *operation) Wait() error {
-	return op.WaitContext(context.Background())
+	return op.WaitContext(op.r.ctx)
 }
 
 // WaitContext lets you wait until the operation reaches a final state with context.Context.
@@ -376,3 +379,41 @@ func (op *remoteOperation) Wait() error {
 
 	return op.err
 }
+
+// OperationProgressHandler returns an operation handler, to pass to AddHandler, reporting the progress of the
+// operation to the given progress handler. This allows following the transfers done by the servers, like
+// migrations and copies, the same way as those done by the client.
+func OperationProgressHandler(handler func(progress ioprogress.ProgressData)) func(api.Operation) {
+	return func(op api.Operation) {
+		if op.Progress == nil {
+			// Older servers only report the progress as text.
+			for key, value := range op.Metadata {
+				text, ok := value.(string)
+				if ok && strings.HasSuffix(key, "_progress") {
+					handler(ioprogress.ProgressData{Text: text, Stage: strings.TrimSuffix(key, "_progress")})
+					return
+				}
+			}
+
+			return
+		}
+
+		data := ioprogress.ProgressData{
+			Percentage:       int(op.Progress.Percent),
+			TransferredBytes: op.Progress.Processed,
+			Speed:            op.Progress.Speed,
+			Stage:            op.Progress.Stage,
+		}
+
+		text, ok := op.Metadata[op.Progress.Stage+"_progress"].(string)
+		if ok {
+			data.Text = text
+		} else if data.Percentage > 0 {
+			data.Text = fmt.Sprintf("%d%% (%s/s)", data.Percentage, units.GetByteSizeString(data.Speed, 2))
+		} else {
+			data.Text = fmt.Sprintf("%s (%s/s)", units.GetByteSizeString(data.TransferredBytes, 2), units.GetByteSizeString(data.Speed, 2))
+		}
+
+		handler(data)
+	}
+}
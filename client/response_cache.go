@@ -0,0 +1,116 @@
+package incus
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// responseCacheEntry is a GET response kept along with its ETag and the tag of its body.
+type responseCacheEntry struct {
+	url      string
+	etag     string
+	tag      string
+	response api.Response
+}
+
+// responseCache keeps the most recently used GET responses having an ETag, so that they can be revalidated
+// with a If-None-Match header instead of being transferred again when they haven't changed.
+//
+// As the ETag of the objects doesn't cover their state, the responses are revalidated with the weak tag of
+// their body, the server comparing it with the one of its new response.
+type responseCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+// newResponseCache returns a cache of the given number of responses, or nil if the size is 0.
+func newResponseCache(size int) *responseCache {
+	if size <= 0 {
+		return nil
+	}
+
+	return &responseCache{
+		size:    size,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// responseCacheTag returns the weak tag of a response body, the quoted hex SHA-256 hash of the body.
+func responseCacheTag(body []byte) string {
+	hash := sha256.Sum256(body)
+
+	return "W/\"" + hex.EncodeToString(hash[:]) + "\""
+}
+
+// get returns the cached response of the URL, if any.
+func (c *responseCache) get(url string) *responseCacheEntry {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[url]
+	if !ok {
+		return nil
+	}
+
+	c.order.MoveToFront(element)
+
+	entry, _ := element.Value.(*responseCacheEntry)
+
+	return entry
+}
+
+// put records the response of the URL, evicting the least recently used responses above the size of the cache.
+func (c *responseCache) put(url string, etag string, tag string, response api.Response) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &responseCacheEntry{url: url, etag: etag, tag: tag, response: response}
+
+	element, ok := c.entries[url]
+	if ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[url] = c.order.PushFront(entry)
+
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+
+		oldestEntry, _ := oldest.Value.(*responseCacheEntry)
+		delete(c.entries, oldestEntry.url)
+	}
+}
+
+// delete removes the cached response of the URL.
+func (c *responseCache) delete(url string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[url]
+	if ok {
+		c.order.Remove(element)
+		delete(c.entries, url)
+	}
+}
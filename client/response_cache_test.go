@@ -0,0 +1,61 @@
+package incus
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func TestResponseCacheDisabled(t *testing.T) {
+	cache := newResponseCache(0)
+	assert.Nil(t, cache)
+
+	// A disabled cache is a no-op.
+	cache.put("/1.0", `"etag"`, `W/"tag"`, api.Response{})
+	assert.Nil(t, cache.get("/1.0"))
+	cache.delete("/1.0")
+}
+
+func TestResponseCache(t *testing.T) {
+	cache := newResponseCache(2)
+
+	cache.put("/1.0/instances/c1", `"c1"`, `W/"t1"`, api.Response{Status: "c1"})
+	cache.put("/1.0/instances/c2", `"c2"`, `W/"t2"`, api.Response{Status: "c2"})
+
+	entry := cache.get("/1.0/instances/c1")
+	require.NotNil(t, entry)
+	assert.Equal(t, `"c1"`, entry.etag)
+	assert.Equal(t, `W/"t1"`, entry.tag)
+	assert.Equal(t, "c1", entry.response.Status)
+
+	// Replacing a response keeps a single entry.
+	cache.put("/1.0/instances/c1", `"c1"`, `W/"t1b"`, api.Response{Status: "c1b"})
+	assert.Equal(t, 2, cache.order.Len())
+
+	entry = cache.get("/1.0/instances/c1")
+	require.NotNil(t, entry)
+	assert.Equal(t, `W/"t1b"`, entry.tag)
+
+	// The least recently used response gets evicted.
+	cache.put("/1.0/instances/c3", `"c3"`, `W/"t3"`, api.Response{Status: "c3"})
+	assert.Nil(t, cache.get("/1.0/instances/c2"))
+	assert.NotNil(t, cache.get("/1.0/instances/c1"))
+	assert.NotNil(t, cache.get("/1.0/instances/c3"))
+
+	cache.delete("/1.0/instances/c1")
+	assert.Nil(t, cache.get("/1.0/instances/c1"))
+	assert.Len(t, cache.entries, 1)
+}
+
+func TestResponseCacheTag(t *testing.T) {
+	tag := responseCacheTag([]byte(`{"type":"sync"}`))
+
+	assert.Regexp(t, `^W/"[0-9a-f]{64}"$`, tag)
+	assert.Equal(t, tag, responseCacheTag([]byte(`{"type":"sync"}`)))
+	assert.NotEqual(t, tag, responseCacheTag([]byte(`{"type":"async"}`)))
+	assert.Equal(t, fmt.Sprintf("W/%q", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"), responseCacheTag(nil))
+}
@@ -0,0 +1,127 @@
+package incus
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// RetryPolicy controls the automatic retry of the requests failing with a transient error.
+//
+// The transient errors are refused and reset connections, and the HTTP 503 (Service Unavailable)
+// responses returned by the servers, for example while the cluster database elects a new leader.
+type RetryPolicy struct {
+	// Maximum number of retries of a request
+	MaxRetries int
+
+	// Delay before the first retry, doubled after each retry (defaults to 100ms)
+	InitialDelay time.Duration
+
+	// Maximum delay between two retries (defaults to 5s)
+	MaxDelay time.Duration
+
+	// Also retry the idempotent PUT and DELETE requests, on top of the safe GET, HEAD and OPTIONS ones
+	RetryIdempotent bool
+}
+
+// retryable returns whether the request can be retried by the policy.
+func (p *RetryPolicy) retryable(req *http.Request) bool {
+	if p == nil || p.MaxRetries <= 0 {
+		return false
+	}
+
+	// The request body must be rewindable to be sent again.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if slices.Contains([]string{http.MethodGet, http.MethodHead, http.MethodOptions}, req.Method) {
+		return true
+	}
+
+	return p.RetryIdempotent && slices.Contains([]string{http.MethodPut, http.MethodDelete}, req.Method)
+}
+
+// delay returns the delay before the given retry, starting at 0, honoring the Retry-After header of the response if any.
+func (p *RetryPolicy) delay(retry int, resp *http.Response) time.Duration {
+	initialDelay := p.InitialDelay
+	if initialDelay <= 0 {
+		initialDelay = 100 * time.Millisecond
+	}
+
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = 5 * time.Second
+	}
+
+	delay := initialDelay
+	for i := 0; i < retry && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	if resp != nil {
+		seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+		if err == nil && seconds >= 0 {
+			delay = time.Duration(seconds) * time.Second
+		}
+	}
+
+	return min(delay, maxDelay)
+}
+
+// isTransientError returns whether the request failed because of a transient network error.
+func isTransientError(err error) bool {
+	return errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// do performs the request with the given function, retrying it as long as it fails with a transient error.
+func (p *RetryPolicy) do(req *http.Request, do func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	if !p.retryable(req) {
+		return do(req)
+	}
+
+	for retry := 0; ; retry++ {
+		resp, err := do(req)
+		if retry >= p.MaxRetries || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		if err == nil && resp.StatusCode != http.StatusServiceUnavailable {
+			return resp, nil
+		}
+
+		if err != nil && !isTransientError(err) {
+			return resp, err
+		}
+
+		delay := p.delay(retry, resp)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		logger.Debug("Retrying request after transient failure", logger.Ctx{"method": req.Method, "url": req.URL.String(), "err": err, "delay": delay})
+
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+
+		// Reset the request body.
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			req.Body = body
+		}
+	}
+}
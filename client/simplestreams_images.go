@@ -83,7 +83,7 @@ func (r *ProtocolSimpleStreams) GetImageFile(fingerprint string, req ImageFileRe
 		// Setup the HTTP client
 		devIncusHTTP, err := unixHTTPClient(nil, "/dev/incus/sock")
 		if err == nil {
-			resp, err := incusDownloadImage(fingerprint, unixURI, r.httpUserAgent, devIncusHTTP.Do, req)
+			resp, err := incusDownloadImage(context.Background(), fingerprint, unixURI, r.httpUserAgent, devIncusHTTP.Do, req)
 			if err == nil {
 				return resp, nil
 			}
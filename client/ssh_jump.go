@@ -0,0 +1,277 @@
+package incus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// sshJumpKeepAlive is the interval of the keep-alive requests detecting a lost connection to the jump host.
+const sshJumpKeepAlive = 30 * time.Second
+
+// SSHJump tunnels connections through an SSH jump host (bastion).
+//
+// The SSH connection is established on first use, shared by all the connections tunneled through it,
+// and established again when lost.
+type SSHJump struct {
+	address string
+	config  *ssh.ClientConfig
+
+	mu     sync.Mutex
+	client *ssh.Client
+	closed bool
+}
+
+// NewSSHJump returns a new SSHJump for the jump host at the given address ("[user@]host[:port]").
+//
+// If no authentication method is provided, the keys of the SSH agent (SSH_AUTH_SOCK) and the
+// unencrypted default keys of the user (~/.ssh/id_*) are used. If no host key callback is provided,
+// the host key is checked against the known hosts of the user (~/.ssh/known_hosts).
+func NewSSHJump(address string, auth []ssh.AuthMethod, hostKeyCallback ssh.HostKeyCallback) (*SSHJump, error) {
+	username, hostPort, err := parseSSHAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(auth) == 0 {
+		auth = defaultSSHAuth()
+	}
+
+	if hostKeyCallback == nil {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+
+		hostKeyCallback, err = knownhosts.New(filepath.Join(home, ".ssh", "known_hosts"))
+		if err != nil {
+			return nil, fmt.Errorf("Failed loading the known SSH hosts: %w", err)
+		}
+	}
+
+	return &SSHJump{
+		address: hostPort,
+		config: &ssh.ClientConfig{
+			User:            username,
+			Auth:            auth,
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         10 * time.Second,
+		},
+	}, nil
+}
+
+// DialContext connects to the address through the jump host, reconnecting to it if needed.
+func (j *SSHJump) DialContext(ctx context.Context, network string, addr string) (net.Conn, error) {
+	client, err := j.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := client.DialContext(ctx, network, addr)
+	if err == nil {
+		return conn, nil
+	}
+
+	// Retry once with a new connection if the connection to the jump host was lost.
+	_, _, errAlive := client.SendRequest("keepalive@openssh.com", true, nil)
+	if errAlive == nil || ctx.Err() != nil || !j.reset(client) {
+		return nil, fmt.Errorf("Failed connecting to %q through SSH jump host %q: %w", addr, j.address, err)
+	}
+
+	client, err = j.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.DialContext(ctx, network, addr)
+}
+
+// Close closes the connection to the jump host, along with the connections tunneled through it.
+func (j *SSHJump) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.closed = true
+
+	if j.client == nil {
+		return nil
+	}
+
+	err := j.client.Close()
+	j.client = nil
+
+	return err
+}
+
+// getClient returns the connection to the jump host, establishing it if needed.
+func (j *SSHJump) getClient(ctx context.Context) (*ssh.Client, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.closed {
+		return nil, errors.New("The SSH jump host connection is closed")
+	}
+
+	if j.client != nil {
+		return j.client, nil
+	}
+
+	logger.Debug("Connecting to SSH jump host", logger.Ctx{"address": j.address, "user": j.config.User})
+
+	dialer := net.Dialer{Timeout: j.config.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", j.address)
+	if err != nil {
+		return nil, fmt.Errorf("Failed connecting to SSH jump host %q: %w", j.address, err)
+	}
+
+	// Bound the handshake by the context.
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(j.config.Timeout)
+	}
+
+	_ = conn.SetDeadline(deadline)
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, j.address, j.config)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("Failed connecting to SSH jump host %q: %w", j.address, err)
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+
+	client := ssh.NewClient(sshConn, chans, reqs)
+	j.client = client
+
+	go j.keepAlive(client)
+
+	return client, nil
+}
+
+// keepAlive regularly checks the connection to the jump host, dropping it once lost.
+func (j *SSHJump) keepAlive(client *ssh.Client) {
+	done := make(chan struct{})
+	go func() {
+		_ = client.Wait()
+		close(done)
+	}()
+
+	ticker := time.NewTicker(sshJumpKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			j.reset(client)
+			return
+		case <-ticker.C:
+			_, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+			if err != nil {
+				logger.Debug("Lost connection to SSH jump host", logger.Ctx{"address": j.address, "err": err})
+				j.reset(client)
+				return
+			}
+		}
+	}
+}
+
+// reset drops the connection to the jump host if it's still the current one, returning whether it was.
+func (j *SSHJump) reset(client *ssh.Client) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.client != client {
+		// Another connection was already established.
+		return !j.closed
+	}
+
+	_ = client.Close()
+	j.client = nil
+
+	return !j.closed
+}
+
+// parseSSHAddress splits an SSH address ("[user@]host[:port]") into the user and the host and port,
+// defaulting to the current user and port 22.
+func parseSSHAddress(address string) (string, string, error) {
+	username, host, found := strings.Cut(address, "@")
+	if !found {
+		host = username
+		username = ""
+	}
+
+	if host == "" {
+		return "", "", fmt.Errorf("Invalid SSH address %q", address)
+	}
+
+	if username == "" {
+		current, err := user.Current()
+		if err != nil {
+			return "", "", fmt.Errorf("Failed getting the current user: %w", err)
+		}
+
+		username = current.Username
+	}
+
+	_, _, err := net.SplitHostPort(host)
+	if err != nil {
+		host = net.JoinHostPort(strings.Trim(host, "[]"), "22")
+	}
+
+	return username, host, nil
+}
+
+// defaultSSHAuth returns the authentication methods of the SSH agent and of the default keys of the user.
+func defaultSSHAuth() []ssh.AuthMethod {
+	auth := []ssh.AuthMethod{}
+
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket != "" {
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			logger.Debug("Failed connecting to SSH agent", logger.Ctx{"socket": socket, "err": err})
+		} else {
+			auth = append(auth, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return auth
+	}
+
+	signers := []ssh.Signer{}
+	for _, name := range []string{"id_ed25519", "id_ecdsa", "id_rsa"} {
+		content, err := os.ReadFile(filepath.Join(home, ".ssh", name))
+		if err != nil {
+			continue
+		}
+
+		// Encrypted keys are expected to be loaded in the agent.
+		signer, err := ssh.ParsePrivateKey(content)
+		if err != nil {
+			continue
+		}
+
+		signers = append(signers, signer)
+	}
+
+	if len(signers) > 0 {
+		auth = append(auth, ssh.PublicKeys(signers...))
+	}
+
+	return auth
+}
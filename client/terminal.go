@@ -0,0 +1,297 @@
+package incus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// TerminalControl sends the control messages (window resize, signals) of an exec or console session.
+//
+// Its Handler is used as the control handler of the session, either directly or through ExecArgs and ConsoleArgs.
+type TerminalControl struct {
+	mu   sync.Mutex
+	conn *websocket.Conn
+
+	// Size requested before the control connection was established.
+	width  int
+	height int
+
+	// Channel closed to detach from a console session (nil for exec sessions).
+	disconnect chan bool
+	closed     bool
+}
+
+// NewTerminalControl returns a new TerminalControl, not yet connected to a session.
+func NewTerminalControl() *TerminalControl {
+	return &TerminalControl{}
+}
+
+// ExecArgs returns the arguments of an interactive exec session attached to the terminal.
+func (c *TerminalControl) ExecArgs(terminal io.ReadWriter) *InstanceExecArgs {
+	return &InstanceExecArgs{
+		Stdin:    terminal,
+		Stdout:   terminal,
+		Control:  c.Handler,
+		DataDone: make(chan bool),
+	}
+}
+
+// ConsoleArgs returns the arguments of a console session attached to the terminal.
+func (c *TerminalControl) ConsoleArgs(terminal io.ReadWriter) *InstanceConsoleArgs {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.disconnect == nil {
+		c.disconnect = make(chan bool)
+	}
+
+	rwc, ok := terminal.(io.ReadWriteCloser)
+	if !ok {
+		rwc = nopCloser{terminal}
+	}
+
+	return &InstanceConsoleArgs{
+		Terminal:          rwc,
+		Control:           c.Handler,
+		ConsoleDisconnect: c.disconnect,
+	}
+}
+
+// Handler is the control handler of the session, sending the size requested before it was connected.
+func (c *TerminalControl) Handler(conn *websocket.Conn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		closeConn(conn)
+		return
+	}
+
+	c.conn = conn
+
+	if c.width > 0 && c.height > 0 {
+		_ = c.send(resizeMessage(c.width, c.height))
+	}
+}
+
+// Resize sets the window size of the session.
+func (c *TerminalControl) Resize(width int, height int) error {
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("Invalid window size %dx%d", width, height)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.width = width
+	c.height = height
+
+	// The size is sent once the control connection is established.
+	if c.conn == nil {
+		return nil
+	}
+
+	return c.send(resizeMessage(width, height))
+}
+
+// Signal forwards the signal to the command of an exec session.
+func (c *TerminalControl) Signal(signal int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return errors.New("The control connection isn't established")
+	}
+
+	return c.send(api.InstanceExecControl{Command: "signal", Signal: signal})
+}
+
+// Close ends the session: the console is detached from, and the command of an exec session is killed.
+func (c *TerminalControl) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+
+	c.closed = true
+
+	if c.disconnect != nil {
+		close(c.disconnect)
+		return nil
+	}
+
+	if c.conn != nil {
+		closeConn(c.conn)
+	}
+
+	return nil
+}
+
+// send writes a control message. The lock must be held.
+func (c *TerminalControl) send(msg api.InstanceExecControl) error {
+	if c.closed {
+		return errors.New("The session is closed")
+	}
+
+	return c.conn.WriteJSON(msg)
+}
+
+// closeConn closes a control connection.
+func closeConn(conn *websocket.Conn) {
+	msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "Closing terminal")
+	// We don't care if this fails. This is just for convenience.
+	_ = conn.WriteMessage(websocket.CloseMessage, msg)
+	_ = conn.Close()
+}
+
+// resizeMessage returns the control message setting the window size.
+func resizeMessage(width int, height int) api.InstanceExecControl {
+	return api.InstanceExecControl{
+		Command: "window-resize",
+		Args: map[string]string{
+			"width":  strconv.Itoa(width),
+			"height": strconv.Itoa(height),
+		},
+	}
+}
+
+// nopCloser adds a no-op Close to a terminal which can't be closed.
+type nopCloser struct {
+	io.ReadWriter
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// NewWebsocketTerminal returns a terminal bridging a websocket, typically from a browser, to a session.
+//
+// Binary messages carry the data of the terminal, while text messages carry JSON control messages in the
+// format of api.InstanceExecControl ("window-resize" with "width" and "height" arguments, or "signal"),
+// which are forwarded to the session through the control. Invalid control messages are ignored.
+// Closing the websocket closes the session.
+func NewWebsocketTerminal(conn *websocket.Conn, control *TerminalControl) io.ReadWriteCloser {
+	return &websocketTerminal{conn: conn, control: control}
+}
+
+// websocketTerminal implements io.ReadWriteCloser on top of a caller-provided websocket.
+type websocketTerminal struct {
+	conn    *websocket.Conn
+	control *TerminalControl
+	reader  io.Reader
+	mur     sync.Mutex
+	muw     sync.Mutex
+}
+
+func (t *websocketTerminal) Read(p []byte) (int, error) {
+	t.mur.Lock()
+	defer t.mur.Unlock()
+
+	for {
+		if t.reader == nil {
+			mt, reader, err := t.conn.NextReader()
+			if err != nil {
+				// The websocket is gone, so is the session.
+				_ = t.control.Close()
+
+				if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+					return 0, io.EOF
+				}
+
+				return 0, err
+			}
+
+			if mt == websocket.TextMessage {
+				// An invalid control message doesn't end the session.
+				err = t.handleControl(reader)
+				if err != nil {
+					logger.Debug("Failed handling terminal control message", logger.Ctx{"err": err})
+				}
+
+				continue
+			}
+
+			t.reader = reader
+		}
+
+		n, err := t.reader.Read(p)
+		if err == io.EOF {
+			// At the end of the message, reset the reader.
+			t.reader = nil
+
+			if n == 0 {
+				continue
+			}
+
+			return n, nil
+		}
+
+		return n, err
+	}
+}
+
+// handleControl forwards a control message to the session.
+func (t *websocketTerminal) handleControl(reader io.Reader) error {
+	msg := api.InstanceExecControl{}
+
+	err := json.NewDecoder(reader).Decode(&msg)
+	if err != nil {
+		return fmt.Errorf("Failed decoding terminal control message: %w", err)
+	}
+
+	switch msg.Command {
+	case "window-resize":
+		width, err := strconv.Atoi(msg.Args["width"])
+		if err != nil {
+			return fmt.Errorf("Invalid window width %q: %w", msg.Args["width"], err)
+		}
+
+		height, err := strconv.Atoi(msg.Args["height"])
+		if err != nil {
+			return fmt.Errorf("Invalid window height %q: %w", msg.Args["height"], err)
+		}
+
+		return t.control.Resize(width, height)
+	case "signal":
+		return t.control.Signal(msg.Signal)
+	default:
+		return fmt.Errorf("Unknown terminal control command %q", msg.Command)
+	}
+}
+
+func (t *websocketTerminal) Write(p []byte) (int, error) {
+	t.muw.Lock()
+	defer t.muw.Unlock()
+
+	// Send the data as a binary message.
+	err := t.conn.WriteMessage(websocket.BinaryMessage, p)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Close closes the session and the websocket.
+func (t *websocketTerminal) Close() error {
+	_ = t.control.Close()
+
+	t.muw.Lock()
+	defer t.muw.Unlock()
+
+	msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	_ = t.conn.WriteMessage(websocket.CloseMessage, msg)
+
+	return t.conn.Close()
+}
@@ -42,8 +42,14 @@ func tlsHTTPClient(client *http.Client, tlsClientCert string, tlsClientKey strin
 
 	// Special TLS handling
 	transport.DialTLSContext = func(ctx context.Context, network string, addr string) (net.Conn, error) {
+		// Use the custom dialer if any (SSH jump host).
+		dial := localtls.RFC3493Dialer
+		if transport.DialContext != nil {
+			dial = transport.DialContext
+		}
+
 		tlsDial := func(network string, addr string, config *tls.Config, resetName bool) (net.Conn, error) {
-			conn, err := localtls.RFC3493Dialer(ctx, network, addr)
+			conn, err := dial(ctx, network, addr)
 			if err != nil {
 				return nil, err
 			}
@@ -110,6 +116,26 @@ func tlsHTTPClient(client *http.Client, tlsClientCert string, tlsClientKey strin
 	return client, nil
 }
 
+// setSSHJump tunnels the connections of the HTTP client through the SSH jump host.
+func setSSHJump(client *http.Client, jump *SSHJump) error {
+	var transport *http.Transport
+
+	switch t := client.Transport.(type) {
+	case *http.Transport:
+		transport = t
+	case HTTPTransporter:
+		transport = t.Transport()
+	default:
+		return fmt.Errorf("Unexpected http.Transport type, %T", t)
+	}
+
+	// Proxies don't apply to the connections tunneled through the jump host.
+	transport.Proxy = nil
+	transport.DialContext = jump.DialContext
+
+	return nil
+}
+
 // unixHTTPClient creates an HTTP client that communicates over a Unix socket.
 // It takes in the connection arguments and the Unix socket path as parameters.
 // The function sets up a Unix socket dialer, configures the HTTP transport, and returns the HTTP client with the specified configurations.
@@ -125,13 +151,27 @@ func unixHTTPClient(args *ConnectionArgs, path string) (*http.Client, error) {
 		return net.DialUnix("unix", nil, raddr)
 	}
 
+	return socketHTTPClient(args, unixDial)
+}
+
+// sshHTTPClient creates an HTTP client that communicates with the Unix socket of a remote host over SSH.
+func sshHTTPClient(args *ConnectionArgs, jump *SSHJump, path string) (*http.Client, error) {
+	sshDial := func(ctx context.Context, _ string, _ string) (net.Conn, error) {
+		return jump.DialContext(ctx, "unix", path)
+	}
+
+	return socketHTTPClient(args, sshDial)
+}
+
+// socketHTTPClient creates an HTTP client that sends all its requests over the connections of the dialer.
+func socketHTTPClient(args *ConnectionArgs, dial func(ctx context.Context, network string, addr string) (net.Conn, error)) (*http.Client, error) {
 	if args == nil {
 		args = &ConnectionArgs{}
 	}
 
 	// Define the http transport
 	transport := &http.Transport{
-		DialContext:           unixDial,
+		DialContext:           dial,
 		DisableKeepAlives:     true,
 		Proxy:                 args.Proxy,
 		ExpectContinueTimeout: time.Second * 30,
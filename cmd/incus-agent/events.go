@@ -113,7 +113,27 @@ func eventsPost(d *Daemon, r *http.Request) response.Response {
 }
 
 func eventsProcess(event api.Event) {
-	// We currently only need to react to device events.
+	// Bring hotplugged resources online.
+	if event.Type == "hotplug" {
+		type hotplugEvent struct {
+			Resource string `json:"resource"`
+		}
+
+		e := hotplugEvent{}
+		err := json.Unmarshal(event.Metadata, &e)
+		if err != nil {
+			return
+		}
+
+		err = osOnlineHotplugged(e.Resource)
+		if err != nil {
+			logger.Warnf("Failed bringing hotplugged %s online: %v", e.Resource, err)
+		}
+
+		return
+	}
+
+	// Otherwise we only need to react to device events.
 	if event.Type != "device" {
 		return
 	}
@@ -110,6 +110,9 @@ func (c *cmdAgent) Run(cmd *cobra.Command, args []string) error {
 
 	osReconfigureNetworkInterfaces()
 
+	// Record the CPUs and memory blocks present at startup.
+	osInitHotplug()
+
 	// Load the kernel driver.
 	err = osLoadModules()
 	if err != nil {
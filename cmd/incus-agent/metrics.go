@@ -51,6 +51,26 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 
 	out.ProcessesTotal = uint64(osGetProcessesState())
 
+	topProcesses, services := stateOptions(r)
+	for _, process := range osGetTopProcesses(topProcesses) {
+		out.Processes = append(out.Processes, metrics.ProcessMetrics{
+			PID:         process.PID,
+			Name:        process.Name,
+			CPUSeconds:  float64(process.CPUUsage) / 1000000000,
+			MemoryBytes: uint64(process.MemoryUsage),
+		})
+	}
+
+	if services {
+		servicesState := osGetServicesState()
+		if servicesState != nil {
+			out.Services = &metrics.ServicesMetrics{
+				Units:  uint64(servicesState.Units),
+				Failed: uint64(len(servicesState.Failed)),
+			}
+		}
+	}
+
 	cpuStats, err := osGetCPUMetrics(d)
 	if err != nil {
 		logger.Warn("Failed to get CPU metrics", logger.Ctx{"err": err})
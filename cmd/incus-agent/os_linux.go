@@ -5,6 +5,7 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
@@ -19,6 +20,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -620,6 +622,108 @@ func osGetProcessesState() int64 {
 	return int64(len(pids))
 }
 
+// osGetTopProcesses returns the given number of processes using the most CPU time since they started.
+func osGetTopProcesses(count int) []api.InstanceStateProcess {
+	if count <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		logger.Warn("Failed listing processes", logger.Ctx{"err": err})
+		return nil
+	}
+
+	pageSize := int64(os.Getpagesize())
+	processes := []api.InstanceStateProcess{}
+
+	for _, entry := range entries {
+		pid, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		content, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+		if err != nil {
+			// The process terminated in the meantime.
+			continue
+		}
+
+		// The name is between parentheses and may contain spaces, so the fields are counted from its end.
+		start := bytes.IndexByte(content, '(')
+		end := bytes.LastIndexByte(content, ')')
+		if start < 0 || end < start {
+			continue
+		}
+
+		fields := strings.Fields(string(content[end+1:]))
+		if len(fields) < 22 {
+			continue
+		}
+
+		utime, err := strconv.ParseInt(fields[11], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		stime, err := strconv.ParseInt(fields[12], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		rss, err := strconv.ParseInt(fields[21], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		// The CPU times are in clock ticks (100 per second).
+		processes = append(processes, api.InstanceStateProcess{
+			PID:         pid,
+			Name:        string(content[start+1 : end]),
+			CPUUsage:    (utime + stime) * 10000000,
+			MemoryUsage: rss * pageSize,
+		})
+	}
+
+	slices.SortFunc(processes, func(a api.InstanceStateProcess, b api.InstanceStateProcess) int {
+		return cmp.Compare(b.CPUUsage, a.CPUUsage)
+	})
+
+	return processes[:min(count, len(processes))]
+}
+
+// osGetServicesState returns the number of loaded systemd units and the names of the failed ones.
+func osGetServicesState() *api.InstanceStateServices {
+	_, err := exec.LookPath("systemctl")
+	if err != nil {
+		return nil
+	}
+
+	output, err := subprocess.RunCommand("systemctl", "list-units", "--all", "--plain", "--full", "--no-legend", "--no-pager")
+	if err != nil {
+		logger.Warn("Failed listing systemd units", logger.Ctx{"err": err})
+		return nil
+	}
+
+	services := &api.InstanceStateServices{Failed: []string{}}
+
+	for _, line := range strings.Split(output, "\n") {
+		// Each line holds the unit name followed by its load, active and sub states.
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+
+		services.Units++
+
+		if fields[2] == "failed" {
+			services.Failed = append(services.Failed, fields[0])
+		}
+	}
+
+	return services
+}
+
 func osGetOSState() *api.InstanceStateOSInfo {
 	osInfo := &api.InstanceStateOSInfo{}
 
@@ -656,6 +760,92 @@ func osGetOSState() *api.InstanceStateOSInfo {
 	return osInfo
 }
 
+// osHotplugResource describes where the CPUs or memory blocks of the VM are found and how to bring them online.
+type osHotplugResource struct {
+	pattern     string
+	stateFile   string
+	onlineValue string
+}
+
+var osHotplugResources = map[string]osHotplugResource{
+	"cpu":    {pattern: "/sys/devices/system/cpu/cpu[0-9]*", stateFile: "online", onlineValue: "1"},
+	"memory": {pattern: "/sys/devices/system/memory/memory[0-9]*", stateFile: "state", onlineValue: "online"},
+}
+
+// osHotplugKnown holds the CPUs and memory blocks already seen by the agent, indexed by resource.
+var osHotplugKnown = map[string][]string{}
+
+var osHotplugMu sync.Mutex
+
+// osInitHotplug records the CPUs and memory blocks present when the agent starts, so that only the ones
+// hotplugged later get brought online, the others being left in the state chosen by the guest.
+func osInitHotplug() {
+	osHotplugMu.Lock()
+	defer osHotplugMu.Unlock()
+
+	for resource, res := range osHotplugResources {
+		paths, err := filepath.Glob(res.pattern)
+		if err != nil {
+			logger.Warn("Failed listing the hotpluggable resources", logger.Ctx{"resource": resource, "err": err})
+			continue
+		}
+
+		osHotplugKnown[resource] = paths
+	}
+}
+
+// osOnlineHotplugged brings online the offline CPUs or memory blocks which showed up since the previous
+// hotplug, after they got hotplugged into the VM. As the guest kernel may take a moment to detect the new
+// resources, this runs a few times in a row.
+func osOnlineHotplugged(resource string) error {
+	res, ok := osHotplugResources[resource]
+	if !ok {
+		return fmt.Errorf("Unsupported hotplug resource %q", resource)
+	}
+
+	osHotplugMu.Lock()
+	defer osHotplugMu.Unlock()
+
+	for i := 0; i < 10; i++ {
+		time.Sleep(500 * time.Millisecond)
+
+		paths, err := filepath.Glob(res.pattern)
+		if err != nil {
+			return err
+		}
+
+		for _, path := range paths {
+			if slices.Contains(osHotplugKnown[resource], path) {
+				continue
+			}
+
+			osHotplugKnown[resource] = append(osHotplugKnown[resource], path)
+
+			statePath := filepath.Join(path, res.stateFile)
+
+			// The boot CPU may not have an online file.
+			content, err := os.ReadFile(statePath)
+			if err != nil {
+				continue
+			}
+
+			state := strings.TrimSpace(string(content))
+			if state != "0" && state != "offline" {
+				continue
+			}
+
+			err = os.WriteFile(statePath, []byte(res.onlineValue), 0o644)
+			if err != nil {
+				return fmt.Errorf("Failed to bring %q online: %w", path, err)
+			}
+
+			logger.Info("Brought hotplugged resource online", logger.Ctx{"path": path})
+		}
+	}
+
+	return nil
+}
+
 // osReconfigureNetworkInterfaces checks for the existence of files under NICConfigDir in the config share.
 // Each file is named <device>.json and contains the Device Name, NIC Name, MTU and MAC address.
 func osReconfigureNetworkInterfaces() {
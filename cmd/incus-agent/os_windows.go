@@ -96,6 +96,14 @@ func osGetProcessesState() int64 {
 	return int64(pidBytes / 4)
 }
 
+func osGetTopProcesses(count int) []api.InstanceStateProcess {
+	return nil
+}
+
+func osGetServicesState() *api.InstanceStateServices {
+	return nil
+}
+
 func osGetOSState() *api.InstanceStateOSInfo {
 	// Get Windows registry.
 	k, err := registry.OpenKey(registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, registry.QUERY_VALUE)
@@ -147,6 +155,15 @@ func osGetOSState() *api.InstanceStateOSInfo {
 	return osInfo
 }
 
+func osInitHotplug() {
+	// Windows brings hotplugged CPUs and memory online by itself.
+}
+
+func osOnlineHotplugged(resource string) error {
+	// Windows brings hotplugged CPUs and memory online by itself.
+	return nil
+}
+
 func osReconfigureNetworkInterfaces() {
 	// Agent assisted network reconfiguration isn't currently supported.
 	return
@@ -2,9 +2,11 @@ package main
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/lxc/incus/v6/internal/server/response"
 	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/util"
 )
 
 var stateCmd = APIEndpoint{
@@ -15,16 +17,32 @@ var stateCmd = APIEndpoint{
 	Put: APIEndpointAction{Handler: statePut},
 }
 
+// stateMaxProcesses is the maximum number of processes which can be reported.
+const stateMaxProcesses = 100
+
 func stateGet(d *Daemon, r *http.Request) response.Response {
-	return response.SyncResponse(true, renderState())
+	topProcesses, services := stateOptions(r)
+
+	return response.SyncResponse(true, renderState(topProcesses, services))
 }
 
 func statePut(d *Daemon, r *http.Request) response.Response {
 	return response.NotImplemented(nil)
 }
 
-func renderState() *api.InstanceState {
-	return &api.InstanceState{
+// stateOptions returns the optional information requested in the query string: the number of processes using the
+// most CPU time to report, and whether to report the state of the systemd units.
+func stateOptions(r *http.Request) (int, bool) {
+	topProcesses, err := strconv.Atoi(r.FormValue("processes"))
+	if err != nil || topProcesses < 0 {
+		topProcesses = 0
+	}
+
+	return min(topProcesses, stateMaxProcesses), util.IsTrue(r.FormValue("services"))
+}
+
+func renderState(topProcesses int, services bool) *api.InstanceState {
+	state := &api.InstanceState{
 		CPU:       osGetCPUState(),
 		Memory:    osGetMemoryState(),
 		Network:   osGetNetworkState(),
@@ -32,4 +50,14 @@ func renderState() *api.InstanceState {
 		Processes: osGetProcessesState(),
 		OSInfo:    osGetOSState(),
 	}
+
+	if topProcesses > 0 {
+		state.TopProcesses = osGetTopProcesses(topProcesses)
+	}
+
+	if services {
+		state.Services = osGetServicesState()
+	}
+
+	return state
 }
@@ -21,18 +21,42 @@ func (c *cmdAdmin) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Manage incus daemon`))
 
+	// audit
+	adminAuditCmd := cmdAdminAudit{global: c.global}
+	cmd.AddCommand(adminAuditCmd.Command())
+
 	// cluster
 	adminClusterCmd := cmdAdminCluster{global: c.global}
 	cmd.AddCommand(adminClusterCmd.Command())
 
+	// debug sub-command
+	adminDebugCmd := cmdAdminDebug{global: c.global}
+	cmd.AddCommand(adminDebugCmd.Command())
+
+	// doctor sub-command
+	adminDoctorCmd := cmdAdminDoctor{global: c.global}
+	cmd.AddCommand(adminDoctorCmd.Command())
+
 	// init
 	adminInitCmd := cmdAdminInit{global: c.global}
 	cmd.AddCommand(adminInitCmd.Command())
 
+	// log sub-command
+	adminLogCmd := cmdAdminLog{global: c.global}
+	cmd.AddCommand(adminLogCmd.Command())
+
+	// qmp sub-command
+	adminQMPCmd := cmdAdminQMP{global: c.global}
+	cmd.AddCommand(adminQMPCmd.Command())
+
 	// recover sub-command
 	adminRecoverCmd := cmdAdminRecover{global: c.global}
 	cmd.AddCommand(adminRecoverCmd.Command())
 
+	// reload sub-command
+	adminReloadCmd := cmdAdminReload{global: c.global}
+	cmd.AddCommand(adminReloadCmd.Command())
+
 	// shutdown sub-command
 	shutdownCmd := cmdAdminShutdown{global: c.global}
 	cmd.AddCommand(shutdownCmd.Command())
@@ -41,6 +65,10 @@ func (c *cmdAdmin) Command() *cobra.Command {
 	sqlCmd := cmdAdminSQL{global: c.global}
 	cmd.AddCommand(sqlCmd.Command())
 
+	// task sub-command
+	adminTaskCmd := cmdAdminTask{global: c.global}
+	cmd.AddCommand(adminTaskCmd.Command())
+
 	// waitready sub-command
 	adminWaitreadyCmd := cmdAdminWaitready{global: c.global}
 	cmd.AddCommand(adminWaitreadyCmd.Command())
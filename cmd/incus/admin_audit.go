@@ -0,0 +1,237 @@
+//go:build linux
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	incus "github.com/lxc/incus/v6/client"
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+type cmdAdminAudit struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAdminAudit) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("audit")
+	cmd.Short = i18n.G("Query the audit log of the local server")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Query the audit log of the local server
+
+The audit log records the authenticated API requests handled by the server
+when core.audit_log is enabled.`))
+
+	// List
+	adminAuditListCmd := cmdAdminAuditList{global: c.global}
+	cmd.AddCommand(adminAuditListCmd.Command())
+
+	// Verify
+	adminAuditVerifyCmd := cmdAdminAuditVerify{global: c.global}
+	cmd.AddCommand(adminAuditVerifyCmd.Command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, _ []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// List.
+type cmdAdminAuditList struct {
+	global *cmdGlobal
+
+	flagSince    string
+	flagUntil    string
+	flagUsername string
+	flagFormat   string
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAdminAuditList) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("list")
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = i18n.G("List the entries of the audit log")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`List the entries of the audit log
+
+The --since and --until flags take either a RFC3339 time or a duration
+relative to the current time.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus admin audit list --since 24h --username jdoe@example.com
+    List the requests made by the jdoe@example.com OIDC user during the last day.`))
+
+	cmd.Flags().StringVar(&c.flagSince, "since", "", i18n.G("Only list the requests handled since the given time")+"``")
+	cmd.Flags().StringVar(&c.flagUntil, "until", "", i18n.G("Only list the requests handled until the given time")+"``")
+	cmd.Flags().StringVar(&c.flagUsername, "username", "", i18n.G("Only list the requests of the given identity")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", c.global.defaultListFormat(), i18n.G(`Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`)+"``")
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		return cli.ValidateFlagFormatForListOutput(cmd.Flag("format").Value.String())
+	}
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// parseTimeOrDuration parses a RFC3339 time or a duration relative to the current time.
+func parseTimeOrDuration(value string) (time.Time, error) {
+	duration, err := time.ParseDuration(value)
+	if err == nil {
+		return time.Now().Add(-duration), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(i18n.G("Invalid time %q, expected a RFC3339 time or a duration"), value)
+	}
+
+	return t, nil
+}
+
+// Run runs the actual command logic.
+func (c *cmdAdminAuditList) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 0, 0)
+	if exit {
+		return err
+	}
+
+	v := url.Values{}
+
+	if c.flagSince != "" {
+		since, err := parseTimeOrDuration(c.flagSince)
+		if err != nil {
+			return err
+		}
+
+		v.Set("since", since.Format(time.RFC3339))
+	}
+
+	if c.flagUntil != "" {
+		until, err := parseTimeOrDuration(c.flagUntil)
+		if err != nil {
+			return err
+		}
+
+		v.Set("until", until.Format(time.RFC3339))
+	}
+
+	if c.flagUsername != "" {
+		v.Set("username", c.flagUsername)
+	}
+
+	// Connect to daemon
+	d, err := incus.ConnectIncusUnix("", &incus.ConnectionArgs{SkipGetServer: true})
+	if err != nil {
+		return err
+	}
+
+	response, _, err := d.RawQuery("GET", "/internal/audit?"+v.Encode(), nil, "")
+	if err != nil {
+		return err
+	}
+
+	entries := []api.AuditEntry{}
+	err = json.Unmarshal(response.Metadata, &entries)
+	if err != nil {
+		return err
+	}
+
+	data := [][]string{}
+	for _, entry := range entries {
+		data = append(data, []string{
+			entry.Timestamp.Local().Format(dateLayout),
+			entry.Username,
+			entry.Protocol,
+			entry.Address,
+			entry.Method,
+			entry.URL,
+			strconv.Itoa(entry.StatusCode),
+		})
+	}
+
+	header := []string{
+		i18n.G("TIMESTAMP"),
+		i18n.G("USERNAME"),
+		i18n.G("PROTOCOL"),
+		i18n.G("ADDRESS"),
+		i18n.G("METHOD"),
+		i18n.G("URL"),
+		i18n.G("STATUS"),
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, entries)
+}
+
+// Verify.
+type cmdAdminAuditVerify struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAdminAuditVerify) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("verify")
+	cmd.Short = i18n.G("Check the integrity of the audit log")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Check the integrity of the audit log
+
+Each entry of the audit log includes the hash of the previous one,
+so that modified or removed entries can be detected.`))
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdAdminAuditVerify) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 0, 0)
+	if exit {
+		return err
+	}
+
+	// Connect to daemon
+	d, err := incus.ConnectIncusUnix("", &incus.ConnectionArgs{SkipGetServer: true})
+	if err != nil {
+		return err
+	}
+
+	response, _, err := d.RawQuery("GET", "/internal/audit/verify", nil, "")
+	if err != nil {
+		return err
+	}
+
+	result := struct {
+		Entries int    `json:"entries"`
+		Error   string `json:"error"`
+	}{}
+
+	err = json.Unmarshal(response.Metadata, &result)
+	if err != nil {
+		return err
+	}
+
+	if result.Error != "" {
+		return errors.New(result.Error)
+	}
+
+	fmt.Printf(i18n.G("Verified %d audit log entries")+"\n", result.Entries)
+
+	return nil
+}
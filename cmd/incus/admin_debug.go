@@ -0,0 +1,263 @@
+//go:build linux
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	incus "github.com/lxc/incus/v6/client"
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+)
+
+type cmdAdminDebug struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAdminDebug) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("debug")
+	cmd.Short = i18n.G("Manage the debugging endpoints of the daemon")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Manage the debugging endpoints of the daemon
+
+The pprof endpoint serves the Go profiles and execution traces of the daemon.`))
+
+	// Disable
+	adminDebugDisableCmd := cmdAdminDebugDisable{global: c.global}
+	cmd.AddCommand(adminDebugDisableCmd.Command())
+
+	// Enable
+	adminDebugEnableCmd := cmdAdminDebugEnable{global: c.global}
+	cmd.AddCommand(adminDebugEnableCmd.Command())
+
+	// Show
+	adminDebugShowCmd := cmdAdminDebugShow{global: c.global}
+	cmd.AddCommand(adminDebugShowCmd.Command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, _ []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// adminDebugCheckEndpoint validates the name of the debugging endpoint.
+func adminDebugCheckEndpoint(name string) error {
+	if name != "pprof" {
+		return fmt.Errorf(i18n.G("Unknown debugging endpoint %q"), name)
+	}
+
+	return nil
+}
+
+// adminDebugPprofStatus returns the address of the pprof endpoint and when it gets disabled.
+func adminDebugPprofStatus(metadata json.RawMessage) (string, string, error) {
+	status := map[string]string{}
+
+	err := json.Unmarshal(metadata, &status)
+	if err != nil {
+		return "", "", fmt.Errorf(i18n.G("Failed to parse pprof status: %w"), err)
+	}
+
+	expires := status["expires_at"]
+	if expires != "" {
+		expiresAt, err := time.Parse(time.RFC3339, expires)
+		if err == nil {
+			expires = expiresAt.Local().Format(dateLayout)
+		}
+	}
+
+	return status["address"], expires, nil
+}
+
+// Disable.
+type cmdAdminDebugDisable struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAdminDebugDisable) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("disable", i18n.G("pprof"))
+	cmd.Short = i18n.G("Disable debugging endpoints")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Disable debugging endpoints
+
+The pprof endpoint goes back to the address of core.debug_address, if set.`))
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdAdminDebugDisable) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	err = adminDebugCheckEndpoint(args[0])
+	if err != nil {
+		return err
+	}
+
+	d, err := incus.ConnectIncusUnix("", &incus.ConnectionArgs{SkipGetServer: true})
+	if err != nil {
+		return err
+	}
+
+	_, _, err = d.RawQuery("DELETE", "/internal/debug/pprof", nil, "")
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed to disable pprof endpoint: %w"), err)
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Println(i18n.G("pprof endpoint disabled"))
+	}
+
+	return nil
+}
+
+// Enable.
+type cmdAdminDebugEnable struct {
+	global *cmdGlobal
+
+	flagListen  string
+	flagTimeout string
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAdminDebugEnable) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("enable", i18n.G("pprof"))
+	cmd.Short = i18n.G("Enable debugging endpoints")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Enable debugging endpoints
+
+The endpoint is enabled without restarting the daemon and gets disabled
+after the timeout. Enabling it again replaces the address and timeout.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus admin debug enable pprof --listen 127.0.0.1:6060 --timeout 30m
+    Serve the profiles on 127.0.0.1:6060 during 30 minutes.`))
+
+	cmd.Flags().StringVar(&c.flagListen, "listen", "127.0.0.1:6060", i18n.G("Address to listen on")+"``")
+	cmd.Flags().StringVar(&c.flagTimeout, "timeout", "1h", i18n.G("Duration after which the endpoint is disabled")+"``")
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdAdminDebugEnable) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	err = adminDebugCheckEndpoint(args[0])
+	if err != nil {
+		return err
+	}
+
+	if c.flagListen == "" {
+		return errors.New(i18n.G("Missing listen address"))
+	}
+
+	timeout, err := time.ParseDuration(c.flagTimeout)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Invalid duration %q: %w"), c.flagTimeout, err)
+	}
+
+	d, err := incus.ConnectIncusUnix("", &incus.ConnectionArgs{SkipGetServer: true})
+	if err != nil {
+		return err
+	}
+
+	v := url.Values{}
+	v.Set("address", c.flagListen)
+	v.Set("timeout", timeout.String())
+
+	response, _, err := d.RawQuery("PUT", fmt.Sprintf("/internal/debug/pprof?%s", v.Encode()), nil, "")
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed to enable pprof endpoint: %w"), err)
+	}
+
+	address, expires, err := adminDebugPprofStatus(response.Metadata)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("pprof endpoint enabled on %s until %s")+"\n", address, expires)
+	}
+
+	return nil
+}
+
+// Show.
+type cmdAdminDebugShow struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAdminDebugShow) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("show", i18n.G("pprof"))
+	cmd.Short = i18n.G("Show the status of debugging endpoints")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Show the status of debugging endpoints"))
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdAdminDebugShow) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	err = adminDebugCheckEndpoint(args[0])
+	if err != nil {
+		return err
+	}
+
+	d, err := incus.ConnectIncusUnix("", &incus.ConnectionArgs{SkipGetServer: true})
+	if err != nil {
+		return err
+	}
+
+	response, _, err := d.RawQuery("GET", "/internal/debug/pprof", nil, "")
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed to get pprof status: %w"), err)
+	}
+
+	address, expires, err := adminDebugPprofStatus(response.Metadata)
+	if err != nil {
+		return err
+	}
+
+	if address == "" {
+		fmt.Println(i18n.G("pprof endpoint disabled"))
+		return nil
+	}
+
+	if expires == "" {
+		fmt.Printf(i18n.G("pprof endpoint enabled on %s")+"\n", address)
+		return nil
+	}
+
+	fmt.Printf(i18n.G("pprof endpoint enabled on %s until %s")+"\n", address, expires)
+
+	return nil
+}
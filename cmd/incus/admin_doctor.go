@@ -0,0 +1,608 @@
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	incus "github.com/lxc/incus/v6/client"
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/internal/linux"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/subprocess"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// Statuses of the diagnostics checks.
+const (
+	doctorStatusOK      = "ok"
+	doctorStatusWarning = "warning"
+	doctorStatusError   = "error"
+	doctorStatusSkipped = "skipped"
+)
+
+// doctorMinKernel is the minimum supported kernel version.
+const doctorMinKernel = "5.15"
+
+// doctorCheck is the result of a diagnostics check.
+type doctorCheck struct {
+	Category string `json:"category" yaml:"category"`
+	Name     string `json:"name" yaml:"name"`
+	Status   string `json:"status" yaml:"status"`
+	Message  string `json:"message" yaml:"message"`
+	Fix      string `json:"fix,omitempty" yaml:"fix,omitempty"`
+}
+
+// doctorStorageTool is the command line tool needed by a storage driver.
+type doctorStorageTool struct {
+	driver  string
+	command string
+	args    []string
+}
+
+// doctorStorageTools are the tools of the storage drivers, along with the arguments printing their version.
+var doctorStorageTools = []doctorStorageTool{
+	{driver: "btrfs", command: "btrfs", args: []string{"--version"}},
+	{driver: "ceph", command: "rbd", args: []string{"--version"}},
+	{driver: "cephfs", command: "ceph", args: []string{"--version"}},
+	{driver: "lvm", command: "lvm", args: []string{"version"}},
+	{driver: "zfs", command: "zfs", args: []string{"version"}},
+}
+
+type cmdAdminDoctor struct {
+	global *cmdGlobal
+
+	flagFormat string
+
+	checks []doctorCheck
+	server incus.InstanceServer
+	info   *api.Server
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAdminDoctor) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("doctor")
+	cmd.Short = i18n.G("Diagnose common problems of the local server")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Diagnose common problems of the local server
+
+The kernel features, cgroup layout, subordinate ID maps, storage tools,
+firewall and networking prerequisites of the host are checked, as well as
+the health of the cluster when clustered. A fix is suggested for each
+problem found.
+
+Some checks rely on the daemon and are skipped if it can't be reached.
+The command fails if any check reports an error.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus admin doctor
+    Check the local server.
+
+incus admin doctor --format yaml
+    Check the local server, producing a YAML report.`))
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", c.global.defaultListFormat(), i18n.G(`Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`)+"``")
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		return cli.ValidateFlagFormatForListOutput(cmd.Flag("format").Value.String())
+	}
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdAdminDoctor) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 0, 0)
+	if exit {
+		return err
+	}
+
+	c.checkDaemon()
+	c.checkKernel()
+	c.checkCgroups()
+	c.checkIdmap()
+	c.checkStorage()
+	c.checkFirewall()
+	c.checkNetwork()
+	c.checkCluster()
+
+	data := [][]string{}
+	failed := 0
+	for _, check := range c.checks {
+		if check.Status == doctorStatusError {
+			failed++
+		}
+
+		data = append(data, []string{check.Category, check.Name, strings.ToUpper(check.Status), check.Message, check.Fix})
+	}
+
+	header := []string{i18n.G("CATEGORY"), i18n.G("CHECK"), i18n.G("STATUS"), i18n.G("MESSAGE"), i18n.G("SUGGESTED FIX")}
+
+	err = cli.RenderTable(os.Stdout, c.flagFormat, header, data, c.checks)
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf(i18n.G("%d check(s) failed"), failed)
+	}
+
+	return nil
+}
+
+// add records the result of a check.
+func (c *cmdAdminDoctor) add(category string, name string, status string, message string, fix string) {
+	c.checks = append(c.checks, doctorCheck{Category: category, Name: name, Status: status, Message: message, Fix: fix})
+}
+
+// checkDaemon connects to the local daemon, used by the checks relying on its view of the system.
+func (c *cmdAdminDoctor) checkDaemon() {
+	server, err := incus.ConnectIncusUnix("", nil)
+	if err == nil {
+		c.info, _, err = server.GetServer()
+	}
+
+	if err != nil {
+		c.add("daemon", i18n.G("Connection"), doctorStatusError, fmt.Sprintf(i18n.G("The daemon can't be reached: %v"), err), i18n.G("Check that the incus service is running and look at its log (journalctl -u incus)"))
+		return
+	}
+
+	c.server = server
+	c.add("daemon", i18n.G("Connection"), doctorStatusOK, fmt.Sprintf(i18n.G("Incus %s is running"), c.info.Environment.ServerVersion), "")
+}
+
+// checkKernel checks the kernel version and the kernel features needed by the instances.
+func (c *cmdAdminDoctor) checkKernel() {
+	// Version.
+	uname, err := linux.Uname()
+	if err != nil {
+		c.add("kernel", i18n.G("Version"), doctorStatusError, err.Error(), "")
+	} else {
+		current, err := version.Parse(uname.Release)
+		minimum, _ := version.NewDottedVersion(doctorMinKernel)
+
+		if err != nil {
+			c.add("kernel", i18n.G("Version"), doctorStatusWarning, fmt.Sprintf(i18n.G("Unknown kernel version %s"), uname.Release), "")
+		} else if current.Compare(minimum) < 0 {
+			c.add("kernel", i18n.G("Version"), doctorStatusError, fmt.Sprintf(i18n.G("Kernel %s is older than the minimum supported version %s"), uname.Release, doctorMinKernel), fmt.Sprintf(i18n.G("Upgrade to kernel %s or later"), doctorMinKernel))
+		} else {
+			c.add("kernel", i18n.G("Version"), doctorStatusOK, fmt.Sprintf(i18n.G("Kernel %s"), uname.Release), "")
+		}
+	}
+
+	// Namespaces.
+	missing := []string{}
+	for _, namespace := range []string{"pid", "net", "uts", "ipc", "mnt"} {
+		if !util.PathExists("/proc/self/ns/" + namespace) {
+			missing = append(missing, namespace)
+		}
+	}
+
+	if len(missing) > 0 {
+		c.add("kernel", i18n.G("Namespaces"), doctorStatusError, fmt.Sprintf(i18n.G("Missing namespaces: %s"), strings.Join(missing, ", ")), i18n.G("Use a kernel built with support for the missing namespaces"))
+	} else {
+		c.add("kernel", i18n.G("Namespaces"), doctorStatusOK, i18n.G("The pid, net, uts, ipc and mount namespaces are available"), "")
+	}
+
+	// User and cgroup namespaces, needed by unprivileged containers.
+	missing = []string{}
+	for _, namespace := range []string{"user", "cgroup"} {
+		if !util.PathExists("/proc/self/ns/" + namespace) {
+			missing = append(missing, namespace)
+		}
+	}
+
+	maxUserNamespaces, _ := os.ReadFile("/proc/sys/user/max_user_namespaces")
+	if len(missing) > 0 {
+		c.add("kernel", i18n.G("User namespaces"), doctorStatusWarning, fmt.Sprintf(i18n.G("Missing namespaces: %s, only privileged containers can run"), strings.Join(missing, ", ")), i18n.G("Use a kernel built with support for the missing namespaces"))
+	} else if strings.TrimSpace(string(maxUserNamespaces)) == "0" {
+		c.add("kernel", i18n.G("User namespaces"), doctorStatusWarning, i18n.G("User namespaces are disabled (user.max_user_namespaces is 0), only privileged containers can run"), i18n.G("Raise the limit with: sysctl user.max_user_namespaces=63000"))
+	} else {
+		c.add("kernel", i18n.G("User namespaces"), doctorStatusOK, i18n.G("The user and cgroup namespaces are available"), "")
+	}
+
+	// Seccomp.
+	status, _ := os.ReadFile("/proc/self/status")
+	if !strings.Contains(string(status), "\nSeccomp:") {
+		c.add("kernel", i18n.G("Seccomp"), doctorStatusError, i18n.G("Seccomp isn't supported by the kernel"), i18n.G("Use a kernel built with CONFIG_SECCOMP"))
+	} else {
+		c.add("kernel", i18n.G("Seccomp"), doctorStatusOK, i18n.G("Seccomp is supported"), "")
+	}
+
+	// Virtual machines.
+	if !util.PathExists("/dev/kvm") {
+		c.add("kernel", i18n.G("KVM"), doctorStatusWarning, i18n.G("/dev/kvm is missing, virtual machines can't run"), i18n.G("Enable virtualization in the firmware settings and load the kvm_intel or kvm_amd module"))
+	} else if !util.PathExists("/dev/vhost-vsock") {
+		c.add("kernel", i18n.G("KVM"), doctorStatusWarning, i18n.G("/dev/vhost-vsock is missing, the agent of the virtual machines can't be reached"), i18n.G("Load the vhost_vsock module (modprobe vhost_vsock)"))
+	} else {
+		c.add("kernel", i18n.G("KVM"), doctorStatusOK, i18n.G("Virtual machines are supported"), "")
+	}
+
+	// Optional features, as detected by the daemon.
+	if c.info == nil {
+		c.add("kernel", i18n.G("Features"), doctorStatusSkipped, i18n.G("The daemon can't be reached"), "")
+		return
+	}
+
+	unavailable := []string{}
+	for feature, value := range c.info.Environment.KernelFeatures {
+		if value != "true" {
+			unavailable = append(unavailable, feature)
+		}
+	}
+
+	sort.Strings(unavailable)
+
+	if len(unavailable) > 0 {
+		c.add("kernel", i18n.G("Features"), doctorStatusWarning, fmt.Sprintf(i18n.G("Unavailable kernel features: %s"), strings.Join(unavailable, ", ")), i18n.G("Upgrade the kernel to use the corresponding functionality"))
+	} else {
+		c.add("kernel", i18n.G("Features"), doctorStatusOK, i18n.G("All the optional kernel features are available"), "")
+	}
+}
+
+// checkCgroups checks the layout of the cgroup hierarchy and the available controllers.
+func (c *cmdAdminDoctor) checkCgroups() {
+	fix := i18n.G("Boot with systemd.unified_cgroup_hierarchy=1 to use the unified hierarchy (cgroup2)")
+
+	controllers, err := os.ReadFile("/sys/fs/cgroup/cgroup.controllers")
+	if err != nil {
+		if util.PathExists("/sys/fs/cgroup/unified") {
+			c.add("cgroups", i18n.G("Layout"), doctorStatusWarning, i18n.G("Hybrid cgroup hierarchy, some resource limits are unavailable"), fix)
+		} else if util.PathExists("/sys/fs/cgroup/memory") || util.PathExists("/sys/fs/cgroup/cpu") {
+			c.add("cgroups", i18n.G("Layout"), doctorStatusWarning, i18n.G("Legacy cgroup hierarchy (cgroup1), some resource limits are unavailable"), fix)
+		} else {
+			c.add("cgroups", i18n.G("Layout"), doctorStatusError, i18n.G("No cgroup hierarchy is mounted on /sys/fs/cgroup"), i18n.G("Mount the cgroup2 filesystem on /sys/fs/cgroup"))
+		}
+
+		return
+	}
+
+	c.add("cgroups", i18n.G("Layout"), doctorStatusOK, i18n.G("Unified cgroup hierarchy (cgroup2)"), "")
+
+	available := strings.Fields(string(controllers))
+	missing := []string{}
+	for _, controller := range []string{"cpu", "cpuset", "io", "memory", "pids"} {
+		if !slices.Contains(available, controller) {
+			missing = append(missing, controller)
+		}
+	}
+
+	if len(missing) > 0 {
+		c.add("cgroups", i18n.G("Controllers"), doctorStatusWarning, fmt.Sprintf(i18n.G("Missing controllers: %s, the corresponding limits are ignored"), strings.Join(missing, ", ")), i18n.G("Remove the cgroup_disable= options of the kernel command line"))
+	} else {
+		c.add("cgroups", i18n.G("Controllers"), doctorStatusOK, fmt.Sprintf(i18n.G("Available controllers: %s"), strings.Join(available, ", ")), "")
+	}
+}
+
+// checkIdmap checks that enough uids and gids are available for unprivileged containers,
+// looking for them the same way as the daemon does.
+func (c *cmdAdminDoctor) checkIdmap() {
+	_, errNewUIDMap := exec.LookPath("newuidmap")
+	_, errNewGIDMap := exec.LookPath("newgidmap")
+
+	if util.PathExists("/etc/subuid") && util.PathExists("/etc/subgid") && errNewUIDMap == nil && errNewGIDMap == nil {
+		fix := i18n.G("Allocate at least 65536 uids and gids to root in /etc/subuid and /etc/subgid, e.g. root:1000000:1000000000")
+
+		ranges := []string{}
+		for _, path := range []string{"/etc/subuid", "/etc/subgid"} {
+			found, err := doctorSubIDRanges(path)
+			if err != nil {
+				c.add("idmap", i18n.G("Subordinate IDs"), doctorStatusError, fmt.Sprintf(i18n.G("Failed reading %s: %v"), path, err), fix)
+				return
+			}
+
+			if len(found) == 0 {
+				c.add("idmap", i18n.G("Subordinate IDs"), doctorStatusError, fmt.Sprintf(i18n.G("No range of 65536 IDs is allocated to root in %s, only privileged containers can run"), path), fix)
+				return
+			}
+
+			ranges = append(ranges, fmt.Sprintf("%s: %s", path, strings.Join(found, ", ")))
+		}
+
+		c.add("idmap", i18n.G("Subordinate IDs"), doctorStatusOK, strings.Join(ranges, "; "), "")
+		return
+	}
+
+	// Without subordinate IDs, the daemon uses the map of its own process.
+	if !linux.RunningInUserNS() {
+		c.add("idmap", i18n.G("Subordinate IDs"), doctorStatusOK, i18n.G("No subordinate IDs configured, the whole ID range of the host is used"), "")
+		return
+	}
+
+	for _, path := range []string{"/proc/self/uid_map", "/proc/self/gid_map"} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			c.add("idmap", i18n.G("Subordinate IDs"), doctorStatusError, fmt.Sprintf(i18n.G("Failed reading %s: %v"), path, err), "")
+			return
+		}
+
+		usable := false
+		for _, line := range strings.Split(string(content), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 3 {
+				size, err := strconv.ParseInt(fields[2], 10, 64)
+				if err == nil && size >= 65536 {
+					usable = true
+				}
+			}
+		}
+
+		if !usable {
+			c.add("idmap", i18n.G("Subordinate IDs"), doctorStatusError, i18n.G("Running in a user namespace without 65536 uids and gids available, only privileged containers can run"), i18n.G("Allocate more uids and gids to the container running Incus"))
+			return
+		}
+	}
+
+	c.add("idmap", i18n.G("Subordinate IDs"), doctorStatusOK, i18n.G("Running in a user namespace with enough uids and gids available"), "")
+}
+
+// doctorSubIDRanges returns the ranges of at least 65536 IDs allocated to root in the subuid or subgid file.
+func doctorSubIDRanges(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := []string{}
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Split(strings.TrimSpace(line), ":")
+		if len(fields) != 3 || (fields[0] != "root" && fields[0] != "0") {
+			continue
+		}
+
+		size, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil || size < 65536 {
+			continue
+		}
+
+		ranges = append(ranges, fmt.Sprintf("%s:%s", fields[1], fields[2]))
+	}
+
+	return ranges, nil
+}
+
+// checkStorage checks the tools of the storage drivers, along with their versions.
+func (c *cmdAdminDoctor) checkStorage() {
+	// Drivers of the existing pools.
+	used := map[string][]string{}
+	if c.server != nil {
+		pools, err := c.server.GetStoragePools()
+		if err == nil {
+			for _, pool := range pools {
+				used[pool.Driver] = append(used[pool.Driver], pool.Name)
+			}
+		}
+	}
+
+	for _, tool := range doctorStorageTools {
+		name := fmt.Sprintf(i18n.G("%s tools"), tool.driver)
+
+		_, err := exec.LookPath(tool.command)
+		if err != nil {
+			pools := used[tool.driver]
+			if tool.driver == "lvm" {
+				pools = append(pools, used["lvmcluster"]...)
+			}
+
+			if len(pools) > 0 {
+				c.add("storage", name, doctorStatusError, fmt.Sprintf(i18n.G("%q isn't installed, but used by the storage pools: %s"), tool.command, strings.Join(pools, ", ")), fmt.Sprintf(i18n.G("Install the package providing %q and restart the daemon"), tool.command))
+			} else {
+				c.add("storage", name, doctorStatusSkipped, fmt.Sprintf(i18n.G("%q isn't installed"), tool.command), "")
+			}
+
+			continue
+		}
+
+		output, err := subprocess.RunCommand(tool.command, tool.args...)
+		if err != nil {
+			c.add("storage", name, doctorStatusWarning, fmt.Sprintf(i18n.G("Failed getting the version of %q: %v"), tool.command, err), "")
+			continue
+		}
+
+		firstLine, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+		c.add("storage", name, doctorStatusOK, strings.TrimSpace(firstLine), "")
+
+		// LVM thin pools also need the thin provisioning tools.
+		if tool.driver == "lvm" {
+			_, err := exec.LookPath("thin_check")
+			if err != nil {
+				c.add("storage", name, doctorStatusWarning, i18n.G("\"thin_check\" isn't installed, LVM thin pools can't be used"), i18n.G("Install the thin provisioning tools (thin-provisioning-tools)"))
+			}
+		}
+	}
+}
+
+// checkFirewall checks the firewall backend and the other firewalls which may block the traffic of the instances.
+func (c *cmdAdminDoctor) checkFirewall() {
+	seeDocs := i18n.G("See https://linuxcontainers.org/incus/docs/main/howto/network_bridge_firewalld/")
+
+	// Backend.
+	_, errNft := exec.LookPath("nft")
+	_, errIptables := exec.LookPath("iptables")
+
+	backend := ""
+	if c.info != nil {
+		backend = c.info.Environment.Firewall
+	}
+
+	if errNft != nil && errIptables != nil {
+		c.add("firewall", i18n.G("Backend"), doctorStatusWarning, i18n.G("Neither nft nor iptables is installed, the firewall rules of the managed networks can't be set"), i18n.G("Install nftables"))
+	} else if backend != "" {
+		c.add("firewall", i18n.G("Backend"), doctorStatusOK, fmt.Sprintf(i18n.G("Using %s"), backend), "")
+	} else {
+		c.add("firewall", i18n.G("Backend"), doctorStatusSkipped, i18n.G("The daemon can't be reached"), "")
+	}
+
+	// Rules of the legacy iptables backend are evaluated alongside the nftables ones.
+	if backend == "nftables" {
+		output, err := subprocess.RunCommand("iptables-legacy-save")
+		if err == nil && strings.Contains(output, "\n-A ") {
+			c.add("firewall", i18n.G("Backend conflicts"), doctorStatusWarning, i18n.G("Rules are set with the legacy iptables backend, they also apply to the traffic of the instances"), i18n.G("Migrate the legacy iptables rules to nftables (iptables-nft), or remove them"))
+		} else {
+			c.add("firewall", i18n.G("Backend conflicts"), doctorStatusOK, i18n.G("No rules set with the legacy iptables backend"), "")
+		}
+	}
+
+	// Docker sets the policy of the FORWARD chain to drop.
+	if util.PathExists("/sys/class/net/docker0") {
+		output, err := subprocess.RunCommand("iptables", "-S", "FORWARD")
+		if err == nil && strings.Contains(output, "-P FORWARD DROP") {
+			c.add("firewall", "Docker", doctorStatusWarning, i18n.G("Docker sets the FORWARD policy to drop, blocking the traffic forwarded for the instances"), i18n.G("Allow the traffic of the bridges in the DOCKER-USER chain (iptables -I DOCKER-USER -i <bridge> -j ACCEPT, and -o <bridge> with conntrack), or enable IPv4 forwarding before Docker starts.")+" "+seeDocs)
+		} else {
+			c.add("firewall", "Docker", doctorStatusOK, i18n.G("Docker is running, without blocking the forwarded traffic"), "")
+		}
+	}
+
+	// firewalld.
+	_, err := exec.LookPath("firewall-cmd")
+	if err == nil {
+		output, _ := subprocess.RunCommand("firewall-cmd", "--state")
+		if strings.TrimSpace(output) == "running" {
+			c.add("firewall", "firewalld", doctorStatusWarning, i18n.G("firewalld is running and may block the traffic of the bridges"), i18n.G("Add the bridges to the trusted zone (firewall-cmd --zone=trusted --change-interface=<bridge> --permanent).")+" "+seeDocs)
+		}
+	}
+
+	// UFW.
+	content, err := os.ReadFile("/etc/ufw/ufw.conf")
+	if err == nil && slices.Contains(strings.Split(string(content), "\n"), "ENABLED=yes") {
+		c.add("firewall", "UFW", doctorStatusWarning, i18n.G("UFW is enabled and may block the traffic of the bridges"), i18n.G("Allow the traffic of the bridges (ufw allow in on <bridge>, ufw route allow in on <bridge>, ufw route allow out on <bridge>).")+" "+seeDocs)
+	}
+}
+
+// checkNetwork checks the tools needed by the managed networks.
+func (c *cmdAdminDoctor) checkNetwork() {
+	bridges := []string{}
+	ovn := []string{}
+	ovnConfigured := false
+
+	if c.server != nil {
+		networks, err := c.server.GetNetworks()
+		if err == nil {
+			for _, network := range networks {
+				if !network.Managed {
+					continue
+				}
+
+				switch network.Type {
+				case "bridge":
+					bridges = append(bridges, network.Name)
+				case "ovn":
+					ovn = append(ovn, network.Name)
+				}
+			}
+		}
+
+		ovnConfigured = c.info.Config["network.ovn.northbound_connection"] != ""
+	}
+
+	// dnsmasq serves DHCP and DNS on the bridges.
+	_, err := exec.LookPath("dnsmasq")
+	if err != nil {
+		if len(bridges) > 0 {
+			c.add("network", "dnsmasq", doctorStatusError, fmt.Sprintf(i18n.G("dnsmasq isn't installed, DHCP and DNS aren't served on the bridges: %s"), strings.Join(bridges, ", ")), i18n.G("Install dnsmasq and restart the daemon"))
+		} else {
+			c.add("network", "dnsmasq", doctorStatusWarning, i18n.G("dnsmasq isn't installed, managed bridges can't be created"), i18n.G("Install dnsmasq"))
+		}
+	} else {
+		output, err := subprocess.RunCommand("dnsmasq", "--version")
+		firstLine, _, _ := strings.Cut(output, "  ")
+		if err != nil {
+			firstLine = i18n.G("dnsmasq is installed")
+		}
+
+		c.add("network", "dnsmasq", doctorStatusOK, strings.TrimSpace(firstLine), "")
+	}
+
+	// OVN.
+	if len(ovn) == 0 && !ovnConfigured {
+		c.add("network", "OVN", doctorStatusSkipped, i18n.G("OVN isn't used"), "")
+		return
+	}
+
+	missing := []string{}
+	for _, command := range []string{"ovn-nbctl", "ovn-sbctl", "ovs-vsctl"} {
+		_, err := exec.LookPath(command)
+		if err != nil {
+			missing = append(missing, command)
+		}
+	}
+
+	if len(missing) > 0 {
+		c.add("network", "OVN", doctorStatusError, fmt.Sprintf(i18n.G("Missing OVN tools: %s"), strings.Join(missing, ", ")), i18n.G("Install the OVN host and Open vSwitch packages"))
+		return
+	}
+
+	_, err = subprocess.RunCommand("ovs-vsctl", "show")
+	if err != nil {
+		c.add("network", "OVN", doctorStatusError, fmt.Sprintf(i18n.G("Open vSwitch isn't running: %v"), err), i18n.G("Start the Open vSwitch service"))
+		return
+	}
+
+	output, _ := subprocess.RunCommand("ovs-vsctl", "get", "open_vswitch", ".", "external_ids:ovn-remote")
+	if strings.TrimSpace(output) == "" {
+		c.add("network", "OVN", doctorStatusWarning, i18n.G("The local chassis isn't connected to the OVN southbound database"), i18n.G("Set external_ids:ovn-remote, ovn-encap-type and ovn-encap-ip with ovs-vsctl"))
+		return
+	}
+
+	c.add("network", "OVN", doctorStatusOK, i18n.G("The OVN tools are installed and Open vSwitch is running"), "")
+}
+
+// checkCluster checks the status of the cluster members and the database roles.
+func (c *cmdAdminDoctor) checkCluster() {
+	if c.info == nil {
+		c.add("cluster", i18n.G("Members"), doctorStatusSkipped, i18n.G("The daemon can't be reached"), "")
+		return
+	}
+
+	if !c.info.Environment.ServerClustered {
+		c.add("cluster", i18n.G("Members"), doctorStatusSkipped, i18n.G("The server isn't clustered"), "")
+		return
+	}
+
+	members, err := c.server.GetClusterMembers()
+	if err != nil {
+		c.add("cluster", i18n.G("Members"), doctorStatusError, fmt.Sprintf(i18n.G("Failed getting the cluster members: %v"), err), "")
+		return
+	}
+
+	voters := 0
+	online := 0
+	for _, member := range members {
+		if slices.Contains(member.Roles, "database") || slices.Contains(member.Roles, "database-leader") {
+			voters++
+		}
+
+		if member.Status == "Online" {
+			online++
+			continue
+		}
+
+		c.add("cluster", member.ServerName, doctorStatusError, fmt.Sprintf(i18n.G("Member is %s: %s"), strings.ToLower(member.Status), member.Message), i18n.G("Check the daemon of the member, or evacuate and remove it if it's gone for good (incus cluster remove --force)"))
+	}
+
+	status := doctorStatusOK
+	if online < len(members) {
+		status = doctorStatusWarning
+	}
+
+	c.add("cluster", i18n.G("Members"), status, fmt.Sprintf(i18n.G("%d of %d members online"), online, len(members)), "")
+
+	if len(members) >= 3 && voters < 3 {
+		c.add("cluster", i18n.G("Database"), doctorStatusWarning, fmt.Sprintf(i18n.G("Only %d voting database members, losing one may make the cluster unavailable"), voters), i18n.G("Bring the offline members back, or check cluster.max_voters"))
+	} else {
+		c.add("cluster", i18n.G("Database"), doctorStatusOK, fmt.Sprintf(i18n.G("%d voting database members"), voters), "")
+	}
+}
@@ -27,6 +27,7 @@ type cmdAdminInit struct {
 	flagAuto    bool
 	flagMinimal bool
 	flagPreseed bool
+	flagDryRun  bool
 	flagDump    bool
 
 	flagNetworkAddress  string
@@ -50,12 +51,14 @@ func (c *cmdAdminInit) Command() *cobra.Command {
               [--storage-create-device=DEVICE] [--storage-create-loop=SIZE]
               [--storage-pool=POOL]
   init --preseed [preseed.yaml]
+  init --preseed --dry-run [preseed.yaml]
   init --dump
 `
 	cmd.RunE = c.Run
 	cmd.Flags().BoolVar(&c.flagAuto, "auto", false, i18n.G("Automatic (non-interactive) mode"))
 	cmd.Flags().BoolVar(&c.flagMinimal, "minimal", false, i18n.G("Minimal configuration (non-interactive)"))
 	cmd.Flags().BoolVar(&c.flagPreseed, "preseed", false, i18n.G("Pre-seed mode, expects YAML config from stdin"))
+	cmd.Flags().BoolVar(&c.flagDryRun, "dry-run", false, i18n.G("Validate the pre-seed and show the changes it would make, without applying them"))
 	cmd.Flags().BoolVar(&c.flagDump, "dump", false, i18n.G("Dump YAML config to stdout"))
 
 	cmd.Flags().StringVar(&c.flagNetworkAddress, "network-address", "", i18n.G("Address to bind to (default: none)")+"``")
@@ -83,6 +86,10 @@ func (c *cmdAdminInit) Run(cmd *cobra.Command, args []string) error {
 		return errors.New(i18n.G("Can't use --minimal and --auto together"))
 	}
 
+	if c.flagDryRun && !c.flagPreseed {
+		return errors.New(i18n.G("Can't use --dry-run without --preseed"))
+	}
+
 	if !c.flagAuto && (c.flagNetworkAddress != "" || c.flagNetworkPort != -1 ||
 		c.flagStorageBackend != "" || c.flagStorageDevice != "" ||
 		c.flagStorageLoopSize != -1 || c.flagStoragePool != "") {
@@ -202,6 +209,11 @@ func (c *cmdAdminInit) Run(cmd *cobra.Command, args []string) error {
 		config.Server.Config["cluster.https_address"] = config.Server.Config["core.https_address"]
 	}
 
+	// Dry run mode
+	if c.flagDryRun {
+		return c.RunDryRun(d, server, config)
+	}
+
 	// Detect if the user has chosen to join a cluster using the new
 	// cluster join API format, and use the dedicated API if so.
 	if config.Cluster != nil && config.Cluster.ClusterAddress != "" && config.Cluster.ServerAddress != "" {
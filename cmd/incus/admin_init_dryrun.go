@@ -0,0 +1,591 @@
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+
+	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/internal/ports"
+	internalUtil "github.com/lxc/incus/v6/internal/util"
+	"github.com/lxc/incus/v6/shared/api"
+	localtls "github.com/lxc/incus/v6/shared/tls"
+)
+
+// Actions of the changes applying a preseed would make.
+const (
+	adminInitActionCreate = "create"
+	adminInitActionUpdate = "update"
+	adminInitActionJoin   = "join"
+	adminInitActionEnable = "enable"
+)
+
+// adminInitKeyChange is a key (configuration key, device key, description...) changed by a preseed.
+type adminInitKeyChange struct {
+	Key string `json:"key" yaml:"key"`
+	Old string `json:"old,omitempty" yaml:"old,omitempty"`
+	New string `json:"new" yaml:"new"`
+}
+
+// adminInitChange is a change to an entity that applying a preseed would make.
+type adminInitChange struct {
+	Entity  string               `json:"entity" yaml:"entity"`
+	Name    string               `json:"name,omitempty" yaml:"name,omitempty"`
+	Project string               `json:"project,omitempty" yaml:"project,omitempty"`
+	Pool    string               `json:"pool,omitempty" yaml:"pool,omitempty"`
+	Action  string               `json:"action" yaml:"action"`
+	Keys    []adminInitKeyChange `json:"keys,omitempty" yaml:"keys,omitempty"`
+}
+
+// adminInitDryRunReport is the result of the validation of a preseed.
+type adminInitDryRunReport struct {
+	Valid    bool              `json:"valid" yaml:"valid"`
+	Errors   []string          `json:"errors,omitempty" yaml:"errors,omitempty"`
+	Warnings []string          `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+	Changes  []adminInitChange `json:"changes" yaml:"changes"`
+}
+
+// adminInitDryRun validates a preseed against the current server, tracking the entities
+// which exist or would be created.
+type adminInitDryRun struct {
+	d      incus.InstanceServer
+	report adminInitDryRunReport
+
+	pools    []string
+	projects []string
+	networks map[string][]string
+}
+
+// RunDryRun validates the preseed against the current server and prints the changes applying it would make,
+// following the same merge rules as the preseed itself.
+func (c *cmdAdminInit) RunDryRun(d incus.InstanceServer, server *api.Server, config *api.InitPreseed) error {
+	dryRun := &adminInitDryRun{d: d, networks: map[string][]string{}}
+
+	err := dryRun.check(server, config)
+	if err != nil {
+		return err
+	}
+
+	dryRun.report.Valid = len(dryRun.report.Errors) == 0
+	if dryRun.report.Changes == nil {
+		dryRun.report.Changes = []adminInitChange{}
+	}
+
+	out, err := yaml.Marshal(dryRun.report)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed to render the preseed changes: %w"), err)
+	}
+
+	fmt.Printf("%s", out)
+
+	if !dryRun.report.Valid {
+		return fmt.Errorf(i18n.G("The preseed is invalid (%d error(s))"), len(dryRun.report.Errors))
+	}
+
+	return nil
+}
+
+// check goes through the preseed in the order it's applied in.
+func (r *adminInitDryRun) check(server *api.Server, config *api.InitPreseed) error {
+	var err error
+
+	// Joining an existing cluster ignores the rest of the preseed.
+	if config.Cluster != nil && config.Cluster.ClusterAddress != "" && config.Cluster.ServerAddress != "" {
+		r.report.Changes = append(r.report.Changes, adminInitChange{Entity: "cluster", Name: config.Cluster.ServerName, Action: adminInitActionJoin, Keys: []adminInitKeyChange{
+			{Key: "cluster_address", New: internalUtil.CanonicalNetworkAddress(config.Cluster.ClusterAddress, ports.HTTPSDefaultPort)},
+			{Key: "server_address", New: internalUtil.CanonicalNetworkAddress(config.Cluster.ServerAddress, ports.HTTPSDefaultPort)},
+		}})
+
+		return nil
+	}
+
+	r.pools, err = r.d.GetStoragePoolNames()
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed to retrieve list of storage pools: %w"), err)
+	}
+
+	r.projects, err = r.d.GetProjectNames()
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed to retrieve list of projects: %w"), err)
+	}
+
+	// The projects created by the preseed are usable by its other entities.
+	for _, project := range config.Server.Projects {
+		if !slices.Contains(r.projects, project.Name) {
+			r.projects = append(r.projects, project.Name)
+		}
+	}
+
+	r.checkServer(server, config.Server.Config)
+
+	for _, pool := range config.Server.StoragePools {
+		err := r.checkStoragePool(pool)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Networks in the default project are applied before the projects.
+	networks := []api.InitNetworksProjectPost{}
+	projectNetworks := []api.InitNetworksProjectPost{}
+	for _, network := range config.Server.Networks {
+		if network.Project == "" {
+			network.Project = api.ProjectDefaultName
+		}
+
+		if network.Project == api.ProjectDefaultName {
+			networks = append(networks, network)
+		} else {
+			projectNetworks = append(projectNetworks, network)
+		}
+	}
+
+	for _, network := range append(networks, projectNetworks...) {
+		err := r.checkNetwork(network)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, project := range config.Server.Projects {
+		err := r.checkProject(project)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, volume := range config.Server.StorageVolumes {
+		err := r.checkStorageVolume(volume)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, profile := range config.Server.Profiles {
+		err := r.checkProfile(profile)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, certificate := range config.Server.Certificates {
+		err := r.checkCertificate(certificate)
+		if err != nil {
+			return err
+		}
+	}
+
+	if config.Cluster != nil && config.Cluster.Enabled {
+		err := r.checkCluster(config.Cluster)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addError records a problem which would make applying the preseed fail.
+func (r *adminInitDryRun) addError(format string, args ...any) {
+	r.report.Errors = append(r.report.Errors, fmt.Sprintf(format, args...))
+}
+
+// addChange records a change, unless it doesn't change anything.
+func (r *adminInitDryRun) addChange(change adminInitChange) {
+	if change.Action == adminInitActionUpdate && len(change.Keys) == 0 {
+		return
+	}
+
+	r.report.Changes = append(r.report.Changes, change)
+}
+
+// checkProjectExists checks that the project exists or is created by the preseed.
+func (r *adminInitDryRun) checkProjectExists(project string, entity string, name string) bool {
+	if slices.Contains(r.projects, project) {
+		return true
+	}
+
+	r.addError(i18n.G("Project %q of %s %q doesn't exist"), project, entity, name)
+	return false
+}
+
+// checkServer checks the server configuration, warning about the keys unknown to the server.
+func (r *adminInitDryRun) checkServer(server *api.Server, config map[string]string) {
+	if len(config) == 0 {
+		return
+	}
+
+	// The metadata of the configuration keys is best effort, older servers don't provide it.
+	var known []string
+	metadata, err := r.d.GetMetadataConfiguration()
+	if err == nil {
+		for group := range metadata.Config["server"] {
+			keys, err := metadata.GetKeys("server", string(group))
+			if err != nil {
+				continue
+			}
+
+			for key := range keys {
+				known = append(known, key)
+			}
+		}
+	}
+
+	if known != nil {
+		for _, key := range sortedKeys(config) {
+			if !adminInitKnownServerKey(known, key) {
+				r.report.Warnings = append(r.report.Warnings, fmt.Sprintf(i18n.G("Unknown server configuration key %q"), key))
+			}
+		}
+	}
+
+	r.addChange(adminInitChange{Entity: "server", Action: adminInitActionUpdate, Keys: adminInitDiffConfig(nil, "config.", server.Config, config)})
+}
+
+// adminInitKnownServerKey checks whether the key is one of the known server configuration keys,
+// the "NAME" components of the known keys matching any name.
+func adminInitKnownServerKey(known []string, key string) bool {
+	if strings.HasPrefix(key, "user.") {
+		return true
+	}
+
+	fields := strings.Split(key, ".")
+	for _, knownKey := range known {
+		knownFields := strings.Split(knownKey, ".")
+		if len(knownFields) != len(fields) {
+			continue
+		}
+
+		match := true
+		for i := range fields {
+			if knownFields[i] != fields[i] && knownFields[i] != "NAME" {
+				match = false
+				break
+			}
+		}
+
+		if match {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkStoragePool checks a storage pool, which can't change driver.
+func (r *adminInitDryRun) checkStoragePool(target api.StoragePoolsPost) error {
+	if !slices.Contains(r.pools, target.Name) {
+		r.pools = append(r.pools, target.Name)
+
+		keys := []adminInitKeyChange{{Key: "driver", New: target.Driver}}
+		keys = adminInitDiffDescription(keys, "", target.Description)
+		keys = adminInitDiffConfig(keys, "config.", nil, target.Config)
+		r.addChange(adminInitChange{Entity: "storage_pool", Name: target.Name, Action: adminInitActionCreate, Keys: keys})
+
+		return nil
+	}
+
+	pool, _, err := r.d.GetStoragePool(target.Name)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed to retrieve current storage pool %q: %w"), target.Name, err)
+	}
+
+	if pool.Driver != target.Driver {
+		r.addError(i18n.G("Storage pool %q is of type %q instead of %q"), pool.Name, pool.Driver, target.Driver)
+		return nil
+	}
+
+	keys := adminInitDiffDescription(nil, pool.Description, target.Description)
+	keys = adminInitDiffConfig(keys, "config.", pool.Config, target.Config)
+	r.addChange(adminInitChange{Entity: "storage_pool", Name: target.Name, Action: adminInitActionUpdate, Keys: keys})
+
+	return nil
+}
+
+// projectNetworks returns the networks of the project, including the ones created by the preseed.
+func (r *adminInitDryRun) projectNetworks(project string) []string {
+	networks, ok := r.networks[project]
+	if ok {
+		return networks
+	}
+
+	// Projects created by the preseed don't have any network yet.
+	networks, err := r.d.UseProject(project).GetNetworkNames()
+	if err != nil {
+		networks = []string{}
+	}
+
+	r.networks[project] = networks
+
+	return networks
+}
+
+// checkNetwork checks a network, which can't change type.
+func (r *adminInitDryRun) checkNetwork(target api.InitNetworksProjectPost) error {
+	if !r.checkProjectExists(target.Project, i18n.G("network"), target.Name) {
+		return nil
+	}
+
+	network, _, err := r.d.UseProject(target.Project).GetNetwork(target.Name)
+	if err != nil {
+		if !api.StatusErrorCheck(err, http.StatusNotFound) {
+			return fmt.Errorf(i18n.G("Failed to retrieve current network %q in project %q: %w"), target.Name, target.Project, err)
+		}
+
+		r.networks[target.Project] = append(r.projectNetworks(target.Project), target.Name)
+
+		keys := []adminInitKeyChange{}
+		if target.Type != "" {
+			keys = append(keys, adminInitKeyChange{Key: "type", New: target.Type})
+		}
+
+		keys = adminInitDiffDescription(keys, "", target.Description)
+		keys = adminInitDiffConfig(keys, "config.", nil, target.Config)
+		r.addChange(adminInitChange{Entity: "network", Name: target.Name, Project: target.Project, Action: adminInitActionCreate, Keys: keys})
+
+		return nil
+	}
+
+	if target.Type != "" && network.Type != target.Type {
+		r.addError(i18n.G("Network %q in project %q is of type %q instead of %q"), target.Name, target.Project, network.Type, target.Type)
+		return nil
+	}
+
+	keys := adminInitDiffDescription(nil, network.Description, target.Description)
+	keys = adminInitDiffConfig(keys, "config.", network.Config, target.Config)
+	r.addChange(adminInitChange{Entity: "network", Name: target.Name, Project: target.Project, Action: adminInitActionUpdate, Keys: keys})
+
+	return nil
+}
+
+// checkProject checks a project.
+func (r *adminInitDryRun) checkProject(target api.ProjectsPost) error {
+	project, _, err := r.d.GetProject(target.Name)
+	if err != nil {
+		if !api.StatusErrorCheck(err, http.StatusNotFound) {
+			return fmt.Errorf(i18n.G("Failed to retrieve current project %q: %w"), target.Name, err)
+		}
+
+		keys := adminInitDiffDescription(nil, "", target.Description)
+		keys = adminInitDiffConfig(keys, "config.", nil, target.Config)
+		r.addChange(adminInitChange{Entity: "project", Name: target.Name, Action: adminInitActionCreate, Keys: keys})
+
+		return nil
+	}
+
+	keys := adminInitDiffDescription(nil, project.Description, target.Description)
+	keys = adminInitDiffConfig(keys, "config.", project.Config, target.Config)
+	r.addChange(adminInitChange{Entity: "project", Name: target.Name, Action: adminInitActionUpdate, Keys: keys})
+
+	return nil
+}
+
+// checkStorageVolume checks a storage volume, whose pool must exist.
+func (r *adminInitDryRun) checkStorageVolume(target api.InitStorageVolumesProjectPost) error {
+	if target.Project == "" {
+		target.Project = api.ProjectDefaultName
+	}
+
+	if target.Type == "" {
+		target.Type = "custom"
+	}
+
+	if !r.checkProjectExists(target.Project, i18n.G("storage volume"), target.Name) {
+		return nil
+	}
+
+	if !slices.Contains(r.pools, target.Pool) {
+		r.addError(i18n.G("Storage pool %q of storage volume %q doesn't exist"), target.Pool, target.Name)
+		return nil
+	}
+
+	volume, _, err := r.d.UseProject(target.Project).GetStoragePoolVolume(target.Pool, target.Type, target.Name)
+	if err != nil {
+		if !api.StatusErrorCheck(err, http.StatusNotFound) {
+			return fmt.Errorf(i18n.G("Failed to retrieve current storage volume %q in project %q: %w"), target.Name, target.Project, err)
+		}
+
+		keys := []adminInitKeyChange{{Key: "type", New: target.Type}}
+		keys = adminInitDiffDescription(keys, "", target.Description)
+		keys = adminInitDiffConfig(keys, "config.", nil, target.Config)
+		r.addChange(adminInitChange{Entity: "storage_volume", Name: target.Name, Project: target.Project, Pool: target.Pool, Action: adminInitActionCreate, Keys: keys})
+
+		return nil
+	}
+
+	keys := adminInitDiffDescription(nil, volume.Description, target.Description)
+	keys = adminInitDiffConfig(keys, "config.", volume.Config, target.Config)
+	r.addChange(adminInitChange{Entity: "storage_volume", Name: target.Name, Project: target.Project, Pool: target.Pool, Action: adminInitActionUpdate, Keys: keys})
+
+	return nil
+}
+
+// checkProfile checks a profile, whose devices must refer to existing storage pools and networks.
+func (r *adminInitDryRun) checkProfile(target api.InitProfileProjectPost) error {
+	if target.Project == "" {
+		target.Project = api.ProjectDefaultName
+	}
+
+	if !r.checkProjectExists(target.Project, i18n.G("profile"), target.Name) {
+		return nil
+	}
+
+	for _, name := range sortedKeys(target.Devices) {
+		device := target.Devices[name]
+
+		if device["pool"] != "" && !slices.Contains(r.pools, device["pool"]) {
+			r.addError(i18n.G("Storage pool %q of device %q of profile %q doesn't exist"), device["pool"], name, target.Name)
+		}
+
+		// Projects without their own networks use the ones of the default project.
+		network := device["network"]
+		if network != "" && !slices.Contains(r.projectNetworks(target.Project), network) && !slices.Contains(r.projectNetworks(api.ProjectDefaultName), network) {
+			r.addError(i18n.G("Network %q of device %q of profile %q doesn't exist"), network, name, target.Name)
+		}
+	}
+
+	profile, _, err := r.d.UseProject(target.Project).GetProfile(target.Name)
+	if err != nil {
+		if !api.StatusErrorCheck(err, http.StatusNotFound) {
+			return fmt.Errorf(i18n.G("Failed to retrieve current profile %q in project %q: %w"), target.Name, target.Project, err)
+		}
+
+		keys := adminInitDiffDescription(nil, "", target.Description)
+		keys = adminInitDiffConfig(keys, "config.", nil, target.Config)
+		keys = adminInitDiffDevices(keys, nil, target.Devices)
+		r.addChange(adminInitChange{Entity: "profile", Name: target.Name, Project: target.Project, Action: adminInitActionCreate, Keys: keys})
+
+		return nil
+	}
+
+	keys := adminInitDiffDescription(nil, profile.Description, target.Description)
+	keys = adminInitDiffConfig(keys, "config.", profile.Config, target.Config)
+	keys = adminInitDiffDevices(keys, profile.Devices, target.Devices)
+	r.addChange(adminInitChange{Entity: "profile", Name: target.Name, Project: target.Project, Action: adminInitActionUpdate, Keys: keys})
+
+	return nil
+}
+
+// checkCertificate checks a certificate, which is always added to the trust store.
+func (r *adminInitDryRun) checkCertificate(target api.CertificatesPost) error {
+	keys := []adminInitKeyChange{}
+	if target.Type != "" {
+		keys = append(keys, adminInitKeyChange{Key: "type", New: target.Type})
+	}
+
+	if target.Certificate != "" {
+		fingerprint, err := localtls.CertFingerprintStr(target.Certificate)
+		if err != nil {
+			r.addError(i18n.G("Invalid certificate %q: %v"), target.Name, err)
+			return nil
+		}
+
+		_, _, err = r.d.GetCertificate(fingerprint)
+		if err == nil {
+			r.addError(i18n.G("Certificate %q is already trusted"), target.Name)
+			return nil
+		}
+
+		if !api.StatusErrorCheck(err, http.StatusNotFound) {
+			return fmt.Errorf(i18n.G("Failed to retrieve certificate %q: %w"), target.Name, err)
+		}
+
+		keys = append(keys, adminInitKeyChange{Key: "fingerprint", New: fingerprint})
+	}
+
+	r.addChange(adminInitChange{Entity: "certificate", Name: target.Name, Action: adminInitActionCreate, Keys: keys})
+
+	return nil
+}
+
+// checkCluster checks the clustering of the server and the roles of its member.
+func (r *adminInitDryRun) checkCluster(target *api.InitClusterPreseed) error {
+	cluster, _, err := r.d.GetCluster()
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed to retrieve current cluster config: %w"), err)
+	}
+
+	if !cluster.Enabled {
+		r.addChange(adminInitChange{Entity: "cluster", Name: target.ServerName, Action: adminInitActionEnable})
+	}
+
+	if len(target.Roles) == 0 {
+		return nil
+	}
+
+	currentRoles := []string{}
+	if cluster.Enabled {
+		member, _, err := r.d.GetClusterMember(target.ServerName)
+		if err != nil {
+			if !api.StatusErrorCheck(err, http.StatusNotFound) {
+				return fmt.Errorf(i18n.G("Failed to retrieve cluster member %q: %w"), target.ServerName, err)
+			}
+
+			r.addError(i18n.G("Cluster member %q doesn't exist"), target.ServerName)
+			return nil
+		}
+
+		currentRoles = member.Roles
+	}
+
+	keys := []adminInitKeyChange{}
+	for _, role := range target.Roles {
+		if !slices.Contains(currentRoles, role) {
+			keys = append(keys, adminInitKeyChange{Key: "roles", New: role})
+		}
+	}
+
+	r.addChange(adminInitChange{Entity: "cluster_member", Name: target.ServerName, Action: adminInitActionUpdate, Keys: keys})
+
+	return nil
+}
+
+// adminInitDiffDescription appends the change of description, the description being kept if none is given.
+func adminInitDiffDescription(keys []adminInitKeyChange, current string, target string) []adminInitKeyChange {
+	if target == "" || target == current {
+		return keys
+	}
+
+	return append(keys, adminInitKeyChange{Key: "description", Old: current, New: target})
+}
+
+// adminInitDiffConfig appends the changes of the configuration keys, the keys missing from the target being kept.
+func adminInitDiffConfig(keys []adminInitKeyChange, prefix string, current map[string]string, target map[string]string) []adminInitKeyChange {
+	for _, key := range sortedKeys(target) {
+		if current[key] == target[key] {
+			continue
+		}
+
+		keys = append(keys, adminInitKeyChange{Key: prefix + key, Old: current[key], New: target[key]})
+	}
+
+	return keys
+}
+
+// adminInitDiffDevices appends the changes of the devices, the keys of the existing devices being merged.
+func adminInitDiffDevices(keys []adminInitKeyChange, current map[string]map[string]string, target map[string]map[string]string) []adminInitKeyChange {
+	for _, name := range sortedKeys(target) {
+		keys = adminInitDiffConfig(keys, "devices."+name+".", current[name], target[name])
+	}
+
+	return keys
+}
+
+// sortedKeys returns the keys of the map, sorted.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
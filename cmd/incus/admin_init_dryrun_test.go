@@ -0,0 +1,40 @@
+//go:build linux
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The changes follow the merge rules of the preseed, the keys and devices missing from it being kept.
+func TestAdminInitDiff(t *testing.T) {
+	keys := adminInitDiffDescription(nil, "Default", "")
+	keys = adminInitDiffConfig(keys, "config.", map[string]string{"limits.cpu": "2", "limits.memory": "1GiB"}, map[string]string{"limits.cpu": "4", "limits.memory": "1GiB", "user.foo": "bar"})
+	keys = adminInitDiffDevices(keys, map[string]map[string]string{
+		"root": {"type": "disk", "path": "/", "pool": "default"},
+		"eth0": {"type": "nic", "network": "incusbr0"},
+	}, map[string]map[string]string{
+		"root": {"pool": "fast"},
+		"gpu":  {"type": "gpu"},
+	})
+
+	assert.Equal(t, []adminInitKeyChange{
+		{Key: "config.limits.cpu", Old: "2", New: "4"},
+		{Key: "config.user.foo", New: "bar"},
+		{Key: "devices.gpu.type", New: "gpu"},
+		{Key: "devices.root.pool", Old: "default", New: "fast"},
+	}, keys)
+}
+
+// Server configuration keys match the known keys, with "NAME" matching any name.
+func TestAdminInitKnownServerKey(t *testing.T) {
+	known := []string{"core.https_address", "logging.NAME.target.address"}
+
+	assert.True(t, adminInitKnownServerKey(known, "core.https_address"))
+	assert.True(t, adminInitKnownServerKey(known, "logging.loki01.target.address"))
+	assert.True(t, adminInitKnownServerKey(known, "user.foo"))
+	assert.False(t, adminInitKnownServerKey(known, "core.https_adress"))
+	assert.False(t, adminInitKnownServerKey(known, "logging.loki01.target"))
+}
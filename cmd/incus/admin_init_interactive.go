@@ -262,6 +262,18 @@ func (c *cmdAdminInit) askClustering(config *api.InitPreseed, server *api.Server
 				return err
 			}
 
+			// Witnesses only take part in the database voting.
+			if client.HasExtension("clustering_witness") {
+				witness, err := c.global.asker.AskBool(i18n.G("Should this member be a witness, only taking part in database voting without hosting instances?")+" (yes/no) [default=no]: ", "no")
+				if err != nil {
+					return err
+				}
+
+				if witness {
+					config.Cluster.Roles = []string{"witness"}
+				}
+			}
+
 			// Get the list of required member config keys.
 			cluster, _, err := client.GetCluster()
 			if err != nil {
@@ -0,0 +1,172 @@
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	incus "github.com/lxc/incus/v6/client"
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+)
+
+type cmdAdminLog struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAdminLog) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("log")
+	cmd.Short = i18n.G("Query the log of the servers")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Query the log of the servers
+
+The servers keep the messages they log at the info level or more severe
+during the last 7 days.`))
+
+	// List
+	adminLogListCmd := cmdAdminLogList{global: c.global}
+	cmd.AddCommand(adminLogListCmd.Command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, _ []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// List.
+type cmdAdminLogList struct {
+	global *cmdGlobal
+
+	flagLevel  string
+	flagSince  string
+	flagUntil  string
+	flagMember string
+	flagFormat string
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAdminLogList) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("list", i18n.G("[<remote>:]"))
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = i18n.G("List the messages of the server log")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`List the messages of the server log
+
+On clusters, the messages of all the online members are listed unless
+a member is given.
+
+The --since and --until flags take either a RFC3339 time or a duration
+relative to the current time.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus admin log list --level warning --since 1h --member server02
+    List the warnings and errors logged by server02 during the last hour.`))
+
+	cmd.Flags().StringVarP(&c.flagLevel, "level", "l", "", i18n.G("Least severe level of the messages (error, warning or info)")+"``")
+	cmd.Flags().StringVar(&c.flagSince, "since", "", i18n.G("Only list the messages logged since the given time")+"``")
+	cmd.Flags().StringVar(&c.flagUntil, "until", "", i18n.G("Only list the messages logged until the given time")+"``")
+	cmd.Flags().StringVar(&c.flagMember, "member", "", i18n.G("Only list the messages of the given cluster member")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", c.global.defaultListFormat(), i18n.G(`Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`)+"``")
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		return cli.ValidateFlagFormatForListOutput(cmd.Flag("format").Value.String())
+	}
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(toComplete, false)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdAdminLogList) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	remote := ""
+	if len(args) > 0 {
+		remote = args[0]
+	}
+
+	remoteName, _, err := c.global.conf.ParseRemote(remote)
+	if err != nil {
+		return err
+	}
+
+	d, err := c.global.conf.GetInstanceServer(remoteName)
+	if err != nil {
+		return err
+	}
+
+	if c.flagMember != "" {
+		d = d.UseTarget(c.flagMember)
+	}
+
+	logArgs := incus.GetServerLogsArgs{Level: c.flagLevel}
+
+	if c.flagSince != "" {
+		logArgs.Since, err = parseTimeOrDuration(c.flagSince)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.flagUntil != "" {
+		logArgs.Until, err = parseTimeOrDuration(c.flagUntil)
+		if err != nil {
+			return err
+		}
+	}
+
+	entries, err := d.GetServerLogs(&logArgs)
+	if err != nil {
+		return err
+	}
+
+	clustered := d.IsClustered()
+
+	data := [][]string{}
+	for _, entry := range entries {
+		context := make([]string, 0, len(entry.Context))
+		for key, value := range entry.Context {
+			context = append(context, fmt.Sprintf("%s=%q", key, value))
+		}
+
+		slices.Sort(context)
+
+		row := []string{entry.Timestamp.Local().Format(dateLayout)}
+		if clustered {
+			row = append(row, entry.Location)
+		}
+
+		row = append(row, strings.ToUpper(entry.Level), entry.Message, strings.Join(context, " "))
+		data = append(data, row)
+	}
+
+	header := []string{i18n.G("TIMESTAMP")}
+	if clustered {
+		header = append(header, i18n.G("LOCATION"))
+	}
+
+	header = append(header, i18n.G("LEVEL"), i18n.G("MESSAGE"), i18n.G("CONTEXT"))
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, entries)
+}
@@ -0,0 +1,100 @@
+//go:build linux
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+type cmdAdminQMP struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAdminQMP) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("qmp", i18n.G("[<remote>:]<instance> <command> [<arguments>]"))
+	cmd.Short = i18n.G("Run a QMP command on a virtual machine")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(`Run a QMP command on a virtual machine
+
+  Runs a command on the QEMU monitor of a running virtual machine and prints
+  its JSON result. The optional arguments are provided as a JSON object.
+
+  Only read-only commands (such as "query-status" or "qom-get") are allowed
+  and every use is logged by the server.
+
+  This internal command is mostly useful for debugging and requires full
+  administrative access to the server.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus admin qmp v1 query-status
+    Show the run state of v1.
+
+incus admin qmp v1 qom-get '{"path": "/machine", "property": "type"}'
+    Show the machine type of v1.`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstances(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdAdminQMP) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 2, 3)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing instance name"))
+	}
+
+	req := api.InstanceDebugQMPPost{
+		Execute: args[1],
+	}
+
+	if len(args) > 2 {
+		err = json.Unmarshal([]byte(args[2]), &req.Arguments)
+		if err != nil {
+			return fmt.Errorf(i18n.G("Invalid QMP arguments: %w"), err)
+		}
+	}
+
+	result, err := resource.server.RunInstanceDebugQMP(resource.name, req)
+	if err != nil {
+		return err
+	}
+
+	out, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(out))
+
+	return nil
+}
@@ -0,0 +1,54 @@
+//go:build linux
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	incus "github.com/lxc/incus/v6/client"
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+)
+
+type cmdAdminReload struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAdminReload) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("reload")
+	cmd.Short = i18n.G("Tell the daemon to reload its configuration")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(`Tell the daemon to reload its configuration
+
+  This will tell the daemon to read its server configuration from the
+  database again and apply any change it missed, re-create the logging
+  targets, OIDC verifier and OpenFGA authorizer and check again whether
+  the UI and documentation directories are available.
+
+  Running instances and API connections are not affected.`))
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdAdminReload) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 0, 0)
+	if exit {
+		return err
+	}
+
+	d, err := incus.ConnectIncusUnix("", &incus.ConnectionArgs{SkipGetServer: true})
+	if err != nil {
+		return err
+	}
+
+	_, _, err = d.RawQuery("PUT", "/internal/reload", nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
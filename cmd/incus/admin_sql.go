@@ -9,6 +9,8 @@ import (
 	"io"
 	"os"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -21,7 +23,8 @@ import (
 type cmdAdminSQL struct {
 	global *cmdGlobal
 
-	flagFormat string
+	flagFormat  string
+	flagSlowLog bool
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -50,10 +53,16 @@ func (c *cmdAdminSQL) Command() *cobra.Command {
   If <query> is the special value ".schema", the command returns the SQL
   text schema of the given database.
 
+  With --slow-log, the command lists the queries of the global database
+  which took longer than the core.slow_query_threshold server
+  configuration key on the targeted cluster member, along with their
+  parameters and call site.
+
   This internal command is mostly useful for debugging and disaster
   recovery. The development team will occasionally provide hotfixes to users as a
   set of database queries to fix some data inconsistency.`))
 	cmd.RunE = c.Run
+	cmd.Flags().BoolVar(&c.flagSlowLog, "slow-log", false, i18n.G("List the slow queries of the global database"))
 	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", c.global.defaultListFormat(), i18n.G(`Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`)+"``")
 
 	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
@@ -65,6 +74,14 @@ func (c *cmdAdminSQL) Command() *cobra.Command {
 
 // Run runs the actual command logic.
 func (c *cmdAdminSQL) Run(cmd *cobra.Command, args []string) error {
+	if c.flagSlowLog {
+		if len(args) != 0 {
+			return errors.New(i18n.G("The --slow-log flag doesn't take any argument"))
+		}
+
+		return c.runSlowLog()
+	}
+
 	if len(args) != 2 {
 		_ = cmd.Help()
 
@@ -162,6 +179,35 @@ func (c *cmdAdminSQL) Run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runSlowLog lists the slow queries of the global database.
+func (c *cmdAdminSQL) runSlowLog() error {
+	d, err := incus.ConnectIncusUnix("", &incus.ConnectionArgs{SkipGetServer: true})
+	if err != nil {
+		return err
+	}
+
+	response, _, err := d.RawQuery("GET", "/internal/sql/slow", nil, "")
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed to request slow queries: %w"), err)
+	}
+
+	queries := []internalSQL.SQLSlowQuery{}
+	err = json.Unmarshal(response.Metadata, &queries)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed to parse slow queries response: %w"), err)
+	}
+
+	data := [][]string{}
+	for _, query := range queries {
+		duration := time.Duration(query.Duration * float64(time.Second)).Round(time.Millisecond)
+		data = append(data, []string{query.Timestamp.Local().Format(dateLayout), duration.String(), query.Caller, query.Query, strings.Join(query.Args, ", ")})
+	}
+
+	header := []string{i18n.G("TIMESTAMP"), i18n.G("DURATION"), i18n.G("CALLER"), i18n.G("QUERY"), i18n.G("ARGUMENTS")}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, queries)
+}
+
 func (c *cmdAdminSQL) sqlPrintSelectResult(result internalSQL.SQLResult) error {
 	data := [][]string{}
 	for _, row := range result.Rows {
@@ -0,0 +1,486 @@
+//go:build linux
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/termios"
+)
+
+type cmdAdminTask struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAdminTask) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("task")
+	cmd.Short = i18n.G("Manage scheduled tasks")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Manage scheduled tasks
+
+Scheduled tasks periodically snapshot, back up or run a command in the instances
+matching their filter, refresh the images or call a webhook.`))
+
+	// Create
+	adminTaskCreateCmd := cmdAdminTaskCreate{global: c.global}
+	cmd.AddCommand(adminTaskCreateCmd.Command())
+
+	// Delete
+	adminTaskDeleteCmd := cmdAdminTaskDelete{global: c.global}
+	cmd.AddCommand(adminTaskDeleteCmd.Command())
+
+	// Edit
+	adminTaskEditCmd := cmdAdminTaskEdit{global: c.global}
+	cmd.AddCommand(adminTaskEditCmd.Command())
+
+	// List
+	adminTaskListCmd := cmdAdminTaskList{global: c.global}
+	cmd.AddCommand(adminTaskListCmd.Command())
+
+	// Show
+	adminTaskShowCmd := cmdAdminTaskShow{global: c.global}
+	cmd.AddCommand(adminTaskShowCmd.Command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, _ []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// Create.
+type cmdAdminTaskCreate struct {
+	global *cmdGlobal
+
+	flagDescription string
+	flagSchedule    string
+	flagAction      string
+	flagFilter      string
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAdminTaskCreate) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("create", i18n.G("[<remote>:]<task> [key=value...]"))
+	cmd.Aliases = []string{"add"}
+	cmd.Short = i18n.G("Create scheduled tasks")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Create scheduled tasks
+
+The supported actions are snapshot, backup, image-refresh, exec and webhook.
+The filter is a comma separated list of project, name, type, status or
+config.<key> filters selecting the instances that the action applies to.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus admin task create nightly --schedule "0 3 * * *" --action snapshot --filter project=prod snapshot.expiry=7d
+    Snapshot the instances of the prod project every night, keeping the snapshots for a week.
+
+incus admin task create notify --schedule @hourly --action webhook webhook.url=https://example.com/hook
+    Call a webhook every hour.`))
+
+	cmd.Flags().StringVar(&c.flagDescription, "description", "", i18n.G("Task description")+"``")
+	cmd.Flags().StringVar(&c.flagSchedule, "schedule", "", i18n.G("Cron expression or alias (@hourly, @daily, ...) of the schedule")+"``")
+	cmd.Flags().StringVar(&c.flagAction, "action", "", i18n.G("Action to run (snapshot, backup, image-refresh, exec or webhook)")+"``")
+	cmd.Flags().StringVar(&c.flagFilter, "filter", "", i18n.G("Filter of the instances the action applies to")+"``")
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdAdminTaskCreate) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, -1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing task name"))
+	}
+
+	// If stdin isn't a terminal, read yaml from it.
+	var taskPut api.ScheduledTaskPut
+	if !termios.IsTerminal(getStdinFd()) {
+		contents, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		err = yaml.UnmarshalStrict(contents, &taskPut)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Create the scheduled task.
+	task := api.ScheduledTasksPost{
+		Name:             resource.name,
+		ScheduledTaskPut: taskPut,
+	}
+
+	if task.Config == nil {
+		task.Config = map[string]string{}
+	}
+
+	if c.flagDescription != "" {
+		task.Description = c.flagDescription
+	}
+
+	if c.flagSchedule != "" {
+		task.Schedule = c.flagSchedule
+	}
+
+	if c.flagAction != "" {
+		task.Action = c.flagAction
+	}
+
+	if c.flagFilter != "" {
+		task.Filter = c.flagFilter
+	}
+
+	for i := 1; i < len(args); i++ {
+		entry := strings.SplitN(args[i], "=", 2)
+		if len(entry) < 2 {
+			return fmt.Errorf(i18n.G("Bad key/value pair: %s"), args[i])
+		}
+
+		task.Config[entry[0]] = entry[1]
+	}
+
+	err = resource.server.CreateScheduledTask(task)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Scheduled task %s created")+"\n", resource.name)
+	}
+
+	return nil
+}
+
+// Delete.
+type cmdAdminTaskDelete struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAdminTaskDelete) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("delete", i18n.G("[<remote>:]<task>"))
+	cmd.Aliases = []string{"rm", "remove"}
+	cmd.Short = i18n.G("Delete scheduled tasks")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Delete scheduled tasks"))
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdAdminTaskDelete) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing task name"))
+	}
+
+	// Delete the scheduled task.
+	err = resource.server.DeleteScheduledTask(resource.name)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Scheduled task %s deleted")+"\n", resource.name)
+	}
+
+	return nil
+}
+
+// Edit.
+type cmdAdminTaskEdit struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAdminTaskEdit) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("edit", i18n.G("[<remote>:]<task>"))
+	cmd.Short = i18n.G("Edit scheduled tasks as YAML")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Edit scheduled tasks as YAML"))
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+func (c *cmdAdminTaskEdit) helpTemplate() string {
+	return i18n.G(
+		`### This is a YAML representation of the scheduled task.
+### Any line starting with a '# will be ignored.
+###
+### An example would look like:
+### name: nightly
+### description: Nightly snapshots of the production instances
+### schedule: 0 3 * * *
+### action: snapshot
+### filter: project=prod
+### config:
+###   snapshot.expiry: 7d
+###
+### Note that the name and runs are shown but cannot be changed`)
+}
+
+// Run runs the actual command logic.
+func (c *cmdAdminTaskEdit) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing task name"))
+	}
+
+	// If stdin isn't a terminal, read text from it
+	if !termios.IsTerminal(getStdinFd()) {
+		contents, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		newdata := api.ScheduledTask{}
+		err = yaml.UnmarshalStrict(contents, &newdata)
+		if err != nil {
+			return err
+		}
+
+		return resource.server.UpdateScheduledTask(resource.name, newdata.Writable(), "")
+	}
+
+	// Get the current config.
+	task, etag, err := resource.server.GetScheduledTask(resource.name)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(&task)
+	if err != nil {
+		return err
+	}
+
+	// Spawn the editor.
+	content, err := textEditor("", []byte(c.helpTemplate()+"\n\n"+string(data)))
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Parse the text received from the editor.
+		newdata := api.ScheduledTask{}
+		err = yaml.UnmarshalStrict(content, &newdata)
+		if err == nil {
+			err = resource.server.UpdateScheduledTask(resource.name, newdata.Writable(), etag)
+		}
+
+		// Respawn the editor.
+		if err != nil {
+			// Editing again won't help when another client modified the object in the meantime.
+			conflictErr := checkEditConflict(err)
+			if conflictErr != nil {
+				return conflictErr
+			}
+
+			fmt.Fprintf(os.Stderr, i18n.G("Config parsing error: %s")+"\n", err)
+			fmt.Println(i18n.G("Press enter to open the editor again or ctrl+c to abort change"))
+
+			_, err := os.Stdin.Read(make([]byte, 1))
+			if err != nil {
+				return err
+			}
+
+			content, err = textEditor("", content)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		break
+	}
+
+	return nil
+}
+
+// List.
+type cmdAdminTaskList struct {
+	global *cmdGlobal
+
+	flagFormat string
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAdminTaskList) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("list", i18n.G("[<remote>:]"))
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = i18n.G("List scheduled tasks")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("List scheduled tasks"))
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", c.global.defaultListFormat(), i18n.G(`Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`)+"``")
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		return cli.ValidateFlagFormatForListOutput(cmd.Flag("format").Value.String())
+	}
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdAdminTaskList) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	remote := ""
+	if len(args) > 0 {
+		remote = args[0]
+	}
+
+	resources, err := c.global.parseServers(remote)
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name != "" {
+		return errors.New(i18n.G("Filtering isn't supported yet"))
+	}
+
+	tasks, err := resource.server.GetScheduledTasks()
+	if err != nil {
+		return err
+	}
+
+	data := [][]string{}
+	for _, task := range tasks {
+		lastRun := ""
+		if len(task.Runs) > 0 {
+			lastRun = fmt.Sprintf("%s (%s)", task.Runs[0].Date.Local().Format(dateLayout), task.Runs[0].Status)
+		}
+
+		data = append(data, []string{task.Name, task.Schedule, task.Action, task.Filter, task.Description, lastRun})
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{
+		i18n.G("NAME"),
+		i18n.G("SCHEDULE"),
+		i18n.G("ACTION"),
+		i18n.G("FILTER"),
+		i18n.G("DESCRIPTION"),
+		i18n.G("LAST RUN"),
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, tasks)
+}
+
+// Show.
+type cmdAdminTaskShow struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAdminTaskShow) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("show", i18n.G("[<remote>:]<task>"))
+	cmd.Short = i18n.G("Show scheduled tasks and their run history")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Show scheduled tasks and their run history"))
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdAdminTaskShow) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing task name"))
+	}
+
+	task, _, err := resource.server.GetScheduledTask(resource.name)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(&task)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s", data)
+
+	return nil
+}
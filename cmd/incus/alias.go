@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 
+	"github.com/kballard/go-shellquote"
 	"github.com/spf13/cobra"
 
 	cli "github.com/lxc/incus/v6/internal/cmd"
@@ -61,10 +63,20 @@ func (c *cmdAliasAdd) Command() *cobra.Command {
 	cmd.Aliases = []string{"create"}
 	cmd.Short = i18n.G("Add new aliases")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
-		`Add new aliases`))
+		`Add new aliases
+
+The target can reference positional parameters ({0}, {1}, ...) and named parameters ({name}),
+given as "name=value" arguments, with an optional default value ({name:default}).
+Several commands can be run in sequence by separating them with "&&".`))
 	cmd.Example = cli.FormatSection("", i18n.G(
 		`incus alias add list "list -c ns46S"
-    Overwrite the "list" command to pass -c ns46S.`))
+    Overwrite the "list" command to pass -c ns46S.
+
+incus alias add redeploy "delete -f {0} && launch {1} {0}"
+    Add a "redeploy" alias, so that "incus redeploy c1 images:debian/12" deletes and launches c1 again.
+
+incus alias add cpus "config set {0} limits.cpu={count:1}"
+    Add a "cpus" alias, so that "incus cpus c1 count=4" sets limits.cpu to 4 (1 if not given).`))
 
 	cmd.RunE = c.Run
 
@@ -88,6 +100,12 @@ func (c *cmdAliasAdd) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf(i18n.G("Alias %s already exists"), args[0])
 	}
 
+	// Validate the target
+	err = validateAliasTarget(args[1])
+	if err != nil {
+		return err
+	}
+
 	// Add the new alias
 	conf.Aliases[args[0]] = args[1]
 
@@ -137,14 +155,14 @@ func (c *cmdAliasList) Run(cmd *cobra.Command, args []string) error {
 	// List the aliases
 	data := [][]string{}
 	for k, v := range conf.Aliases {
-		data = append(data, []string{k, v})
+		data = append(data, []string{k, v, aliasParamsColumn(v)})
 	}
 
 	// Apply default entries.
 	for k, v := range defaultAliases {
 		_, ok := conf.Aliases[k]
 		if !ok {
-			data = append(data, []string{k, v})
+			data = append(data, []string{k, v, aliasParamsColumn(v)})
 		}
 	}
 
@@ -153,11 +171,32 @@ func (c *cmdAliasList) Run(cmd *cobra.Command, args []string) error {
 	header := []string{
 		i18n.G("ALIAS"),
 		i18n.G("TARGET"),
+		i18n.G("PARAMETERS"),
 	}
 
 	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, conf.Aliases)
 }
 
+// aliasParamsColumn returns the parameters of an alias as shown in the list, optional ones being in brackets.
+func aliasParamsColumn(target string) string {
+	fields, err := shellquote.Split(target)
+	if err != nil {
+		return ""
+	}
+
+	params, err := parseAliasParams(fields)
+	if err != nil {
+		return ""
+	}
+
+	columns := make([]string, 0, len(params))
+	for _, param := range params {
+		columns = append(columns, param.String())
+	}
+
+	return strings.Join(columns, " ")
+}
+
 // Rename.
 type cmdAliasRename struct {
 	global *cmdGlobal
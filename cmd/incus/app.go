@@ -0,0 +1,641 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	incus "github.com/lxc/incus/v6/client"
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// appDefinition represents the YAML definition of an OCI application.
+type appDefinition struct {
+	Containers []appContainer `yaml:"containers"`
+}
+
+// appContainer represents a container of an OCI application.
+type appContainer struct {
+	Name        string                       `yaml:"name"`
+	Image       string                       `yaml:"image"`
+	Profiles    []string                     `yaml:"profiles"`
+	Environment map[string]string            `yaml:"environment"`
+	Config      map[string]string            `yaml:"config"`
+	Devices     map[string]map[string]string `yaml:"devices"`
+}
+
+type cmdApp struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdApp) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("app")
+	cmd.Short = i18n.G("Manage OCI applications")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Manage OCI applications
+
+An application is a group of OCI containers sharing the network namespace of
+its first container and managed together.`))
+
+	// Create
+	appCreateCmd := cmdAppCreate{global: c.global, app: c}
+	cmd.AddCommand(appCreateCmd.Command())
+
+	// Delete
+	appDeleteCmd := cmdAppDelete{global: c.global, app: c}
+	cmd.AddCommand(appDeleteCmd.Command())
+
+	// List
+	appListCmd := cmdAppList{global: c.global, app: c}
+	cmd.AddCommand(appListCmd.Command())
+
+	// Show
+	appShowCmd := cmdAppShow{global: c.global, app: c}
+	cmd.AddCommand(appShowCmd.Command())
+
+	// Start, stop and restart
+	for _, action := range []string{"start", "stop", "restart"} {
+		appActionCmd := cmdAppAction{global: c.global, app: c, action: action}
+		cmd.AddCommand(appActionCmd.Command())
+	}
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, _ []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// members returns the containers of an application, with the ones owning the network namespace first.
+func (c *cmdApp) members(d incus.InstanceServer, appName string) ([]api.Instance, error) {
+	instances, err := d.GetInstances(api.InstanceTypeContainer)
+	if err != nil {
+		return nil, err
+	}
+
+	members := []api.Instance{}
+	for _, inst := range instances {
+		if inst.Config["oci.application"] == appName {
+			members = append(members, inst)
+		}
+	}
+
+	sort.SliceStable(members, func(i, j int) bool {
+		iShared := members[i].Config["linux.network_namespace"] != ""
+		jShared := members[j].Config["linux.network_namespace"] != ""
+		if iShared != jShared {
+			return !iShared
+		}
+
+		return members[i].Name < members[j].Name
+	})
+
+	return members, nil
+}
+
+// changeState runs a state change action on the given instance and waits for it to complete.
+func (c *cmdApp) changeState(d incus.InstanceServer, name string, action string, force bool) error {
+	op, err := d.UpdateInstanceState(name, api.InstanceStatePut{Action: action, Timeout: -1, Force: force}, "")
+	if err != nil {
+		return err
+	}
+
+	return op.Wait()
+}
+
+// Create.
+type cmdAppCreate struct {
+	global *cmdGlobal
+	app    *cmdApp
+
+	flagNoStart bool
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAppCreate) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("create", i18n.G("[<remote>:]<app> <file>"))
+	cmd.Aliases = []string{"add"}
+	cmd.Short = i18n.G("Create an OCI application")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Create an OCI application from a YAML definition
+
+The definition lists the containers of the application. Each container is
+created as "<app>-<name>" from its image. The first container gets the network
+devices of its profiles, the other ones join its network namespace.
+
+If <file> is "-", the definition is read from standard input.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus app create blog blog.yaml
+    Create and start the "blog" application with blog.yaml containing:
+
+containers:
+- name: db
+  image: oci-docker:mariadb
+  environment:
+    MARIADB_ROOT_PASSWORD: secret
+- name: web
+  image: oci-docker:wordpress
+  environment:
+    WORDPRESS_DB_HOST: 127.0.0.1`))
+
+	cmd.Flags().BoolVar(&c.flagNoStart, "no-start", false, i18n.G("Don't start the application after creating it"))
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdAppCreate) Run(cmd *cobra.Command, args []string) error {
+	conf := c.global.conf
+
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing application name"))
+	}
+
+	// Read the definition.
+	var contents []byte
+	if args[1] == "-" {
+		contents, err = io.ReadAll(os.Stdin)
+	} else {
+		contents, err = os.ReadFile(args[1])
+	}
+
+	if err != nil {
+		return err
+	}
+
+	app := appDefinition{}
+	err = yaml.UnmarshalStrict(contents, &app)
+	if err != nil {
+		return err
+	}
+
+	if len(app.Containers) == 0 {
+		return errors.New(i18n.G("The application doesn't define any container"))
+	}
+
+	names := []string{}
+	for i, ct := range app.Containers {
+		if ct.Name == "" || ct.Image == "" {
+			return errors.New(i18n.G("All containers of the application require a name and an image"))
+		}
+
+		if slices.Contains(names, ct.Name) {
+			return fmt.Errorf(i18n.G("Duplicate container name %q"), ct.Name)
+		}
+
+		names = append(names, ct.Name)
+
+		if i > 0 {
+			for devName, dev := range ct.Devices {
+				if dev["type"] == "nic" {
+					return fmt.Errorf(i18n.G("Only the first container of the application can have network devices, found %q in %q"), devName, ct.Name)
+				}
+			}
+		}
+	}
+
+	members, err := c.app.members(resource.server, resource.name)
+	if err != nil {
+		return err
+	}
+
+	if len(members) > 0 {
+		return fmt.Errorf(i18n.G("Application %q already exists"), resource.name)
+	}
+
+	// Create the containers.
+	created := []string{}
+	leader := fmt.Sprintf("%s-%s", resource.name, app.Containers[0].Name)
+
+	for i, ct := range app.Containers {
+		instName := fmt.Sprintf("%s-%s", resource.name, ct.Name)
+
+		createCmd := cmdCreate{global: c.global, flagProfile: ct.Profiles}
+		createCmd.flagConfig = append(createCmd.flagConfig, fmt.Sprintf("oci.application=%s", resource.name))
+
+		if i > 0 {
+			createCmd.flagConfig = append(createCmd.flagConfig, fmt.Sprintf("linux.network_namespace=%s", leader), fmt.Sprintf("boot.depends_on=%s", leader))
+		}
+
+		for k, v := range ct.Environment {
+			createCmd.flagConfig = append(createCmd.flagConfig, fmt.Sprintf("environment.%s=%s", k, v))
+		}
+
+		for k, v := range ct.Config {
+			createCmd.flagConfig = append(createCmd.flagConfig, fmt.Sprintf("%s=%s", k, v))
+		}
+
+		d, _, err := createCmd.create(conf, []string{ct.Image, fmt.Sprintf("%s:%s", resource.remote, instName)}, false)
+		if err != nil {
+			return err
+		}
+
+		created = append(created, instName)
+
+		// Add the devices, masking the network devices inherited from profiles on shared containers.
+		inst, etag, err := d.GetInstance(instName)
+		if err != nil {
+			return err
+		}
+
+		for devName, dev := range ct.Devices {
+			inst.Devices[devName] = dev
+		}
+
+		if i > 0 {
+			for devName, dev := range inst.ExpandedDevices {
+				if dev["type"] == "nic" {
+					inst.Devices[devName] = map[string]string{"type": "none"}
+				}
+			}
+		}
+
+		if len(ct.Devices) > 0 || i > 0 {
+			op, err := d.UpdateInstance(instName, inst.Writable(), etag)
+			if err != nil {
+				return err
+			}
+
+			err = op.Wait()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if c.flagNoStart {
+		return nil
+	}
+
+	// Start the containers, the one owning the network namespace first.
+	for _, name := range created {
+		err = c.app.changeState(resource.server, name, "start", false)
+		if err != nil {
+			return fmt.Errorf(i18n.G("Failed starting %q: %w"), name, err)
+		}
+	}
+
+	return nil
+}
+
+// Delete.
+type cmdAppDelete struct {
+	global *cmdGlobal
+	app    *cmdApp
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAppDelete) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("delete", i18n.G("[<remote>:]<app>"))
+	cmd.Aliases = []string{"rm", "remove"}
+	cmd.Short = i18n.G("Delete an OCI application")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Stop and delete all the containers of an OCI application`))
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdAppDelete) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing application name"))
+	}
+
+	members, err := c.app.members(resource.server, resource.name)
+	if err != nil {
+		return err
+	}
+
+	if len(members) == 0 {
+		return fmt.Errorf(i18n.G("Application %q not found"), resource.name)
+	}
+
+	// Delete the containers sharing the network namespace first.
+	for _, inst := range slices.Backward(members) {
+		if inst.StatusCode != api.Stopped {
+			err = c.app.changeState(resource.server, inst.Name, "stop", true)
+			if err != nil {
+				return fmt.Errorf(i18n.G("Failed stopping %q: %w"), inst.Name, err)
+			}
+		}
+
+		op, err := resource.server.DeleteInstance(inst.Name)
+		if err != nil {
+			return err
+		}
+
+		err = op.Wait()
+		if err != nil {
+			return fmt.Errorf(i18n.G("Failed deleting %q: %w"), inst.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// List.
+type cmdAppList struct {
+	global *cmdGlobal
+	app    *cmdApp
+
+	flagFormat string
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAppList) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("list", i18n.G("[<remote>:]"))
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = i18n.G("List OCI applications")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`List OCI applications`))
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", c.global.defaultListFormat(), i18n.G(`Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`)+"``")
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		return cli.ValidateFlagFormatForListOutput(cmd.Flag("format").Value.String())
+	}
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdAppList) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	remote := ""
+	if len(args) > 0 {
+		remote = args[0]
+	}
+
+	resources, err := c.global.parseServers(remote)
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	instances, err := resource.server.GetInstances(api.InstanceTypeContainer)
+	if err != nil {
+		return err
+	}
+
+	// Group the containers by application.
+	apps := map[string][]api.Instance{}
+	for _, inst := range instances {
+		appName := inst.Config["oci.application"]
+		if appName != "" {
+			apps[appName] = append(apps[appName], inst)
+		}
+	}
+
+	data := [][]string{}
+	for appName, members := range apps {
+		running := 0
+		for _, inst := range members {
+			if inst.StatusCode == api.Running {
+				running++
+			}
+		}
+
+		state := "RUNNING"
+		if running == 0 {
+			state = "STOPPED"
+		} else if running < len(members) {
+			state = "DEGRADED"
+		}
+
+		data = append(data, []string{appName, fmt.Sprintf("%d", len(members)), state})
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{
+		i18n.G("NAME"),
+		i18n.G("CONTAINERS"),
+		i18n.G("STATE"),
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, apps)
+}
+
+// Show.
+type cmdAppShow struct {
+	global *cmdGlobal
+	app    *cmdApp
+
+	flagFormat string
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAppShow) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("show", i18n.G("[<remote>:]<app>"))
+	cmd.Short = i18n.G("Show the containers of an OCI application")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Show the containers of an OCI application`))
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", c.global.defaultListFormat(), i18n.G(`Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`)+"``")
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		return cli.ValidateFlagFormatForListOutput(cmd.Flag("format").Value.String())
+	}
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdAppShow) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing application name"))
+	}
+
+	members, err := c.app.members(resource.server, resource.name)
+	if err != nil {
+		return err
+	}
+
+	if len(members) == 0 {
+		return fmt.Errorf(i18n.G("Application %q not found"), resource.name)
+	}
+
+	data := [][]string{}
+	for _, inst := range members {
+		network := inst.Config["linux.network_namespace"]
+		if network == "" {
+			network = "-"
+		}
+
+		data = append(data, []string{inst.Name, strings.ToUpper(inst.Status), network})
+	}
+
+	header := []string{
+		i18n.G("NAME"),
+		i18n.G("STATE"),
+		i18n.G("NETWORK NAMESPACE"),
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, members)
+}
+
+// Start, stop and restart.
+type cmdAppAction struct {
+	global *cmdGlobal
+	app    *cmdApp
+	action string
+
+	flagForce bool
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdAppAction) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage(c.action, i18n.G("[<remote>:]<app>"))
+
+	switch c.action {
+	case "start":
+		cmd.Short = i18n.G("Start an OCI application")
+	case "stop":
+		cmd.Short = i18n.G("Stop an OCI application")
+	case "restart":
+		cmd.Short = i18n.G("Restart an OCI application")
+	}
+
+	cmd.Long = cli.FormatSection(i18n.G("Description"), cmd.Short+"\n\n"+i18n.G(
+		`The container owning the network namespace is started first and stopped last.`))
+
+	if c.action != "start" {
+		cmd.Flags().BoolVarP(&c.flagForce, "force", "f", false, i18n.G("Force the containers to stop"))
+	}
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdAppAction) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing application name"))
+	}
+
+	members, err := c.app.members(resource.server, resource.name)
+	if err != nil {
+		return err
+	}
+
+	if len(members) == 0 {
+		return fmt.Errorf(i18n.G("Application %q not found"), resource.name)
+	}
+
+	// Stop the containers sharing the network namespace first.
+	if c.action != "start" {
+		for _, inst := range slices.Backward(members) {
+			if inst.StatusCode == api.Stopped {
+				continue
+			}
+
+			err = c.app.changeState(resource.server, inst.Name, "stop", c.flagForce)
+			if err != nil {
+				return fmt.Errorf(i18n.G("Failed stopping %q: %w"), inst.Name, err)
+			}
+		}
+	}
+
+	// Start the container owning the network namespace first.
+	if c.action != "stop" {
+		for _, inst := range members {
+			if c.action == "start" && inst.StatusCode == api.Running {
+				continue
+			}
+
+			err = c.app.changeState(resource.server, inst.Name, "start", false)
+			if err != nil {
+				return fmt.Errorf(i18n.G("Failed starting %q: %w"), inst.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
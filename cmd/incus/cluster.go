@@ -93,6 +93,10 @@ func (c *cmdCluster) Command() *cobra.Command {
 	cmdClusterUpdateCertificate := cmdClusterUpdateCertificate{global: c.global, cluster: c}
 	cmd.AddCommand(cmdClusterUpdateCertificate.Command())
 
+	// Renew certificate
+	cmdClusterRenewCertificate := cmdClusterRenewCertificate{global: c.global, cluster: c}
+	cmd.AddCommand(cmdClusterRenewCertificate.Command())
+
 	// Evacuate cluster member
 	cmdClusterEvacuate := cmdClusterEvacuate{global: c.global, cluster: c}
 	cmd.AddCommand(cmdClusterEvacuate.Command())
@@ -101,6 +105,18 @@ func (c *cmdCluster) Command() *cobra.Command {
 	cmdClusterRestore := cmdClusterRestore{global: c.global, cluster: c}
 	cmd.AddCommand(cmdClusterRestore.Command())
 
+	// Drain cluster member
+	cmdClusterDrain := cmdClusterDrain{global: c.global, cluster: c}
+	cmd.AddCommand(cmdClusterDrain.Command())
+
+	// Uncordon cluster member
+	cmdClusterUncordon := cmdClusterUncordon{global: c.global, cluster: c}
+	cmd.AddCommand(cmdClusterUncordon.Command())
+
+	// Upgrade cluster members
+	cmdClusterUpgrade := cmdClusterUpgrade{global: c.global, cluster: c}
+	cmd.AddCommand(cmdClusterUpgrade.Command())
+
 	clusterGroupCmd := cmdClusterGroup{global: c.global, cluster: c}
 	cmd.AddCommand(clusterGroupCmd.Command())
 
@@ -985,6 +1001,12 @@ func (c *cmdClusterEdit) Run(cmd *cobra.Command, args []string) error {
 
 		// Respawn the editor
 		if err != nil {
+			// Editing again won't help when another client modified the object in the meantime.
+			conflictErr := checkEditConflict(err)
+			if conflictErr != nil {
+				return conflictErr
+			}
+
 			fmt.Fprintf(os.Stderr, i18n.G("Config parsing error: %s")+"\n", err)
 			fmt.Println(i18n.G("Press enter to open the editor again or ctrl+c to abort change"))
 
@@ -1458,11 +1480,96 @@ func (c *cmdClusterUpdateCertificate) Run(cmd *cobra.Command, args []string) err
 	return nil
 }
 
+// Renew Certificates.
+type cmdClusterRenewCertificate struct {
+	global  *cmdGlobal
+	cluster *cmdCluster
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdClusterRenewCertificate) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("renew-certificate", i18n.G("[<remote>:]"))
+	cmd.Aliases = []string{"renew-cert"}
+	cmd.Short = i18n.G("Renew cluster certificate")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Renew cluster certificate
+
+Replaces the cluster certificate and its key with newly issued ones, even if
+the current certificate is still valid. The certificate is issued through ACME
+if configured, or is self-signed otherwise.`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(toComplete, false)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdClusterRenewCertificate) Run(cmd *cobra.Command, args []string) error {
+	conf := c.global.conf
+
+	exit, err := c.global.checkArgs(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	remote := ""
+	if len(args) == 1 {
+		remote = args[0]
+	}
+
+	resources, err := c.global.parseServers(remote)
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	// Check if clustered.
+	cluster, _, err := resource.server.GetCluster()
+	if err != nil {
+		return err
+	}
+
+	if !cluster.Enabled {
+		return errors.New(i18n.G("Server isn't part of a cluster"))
+	}
+
+	cert, err := resource.server.RenewClusterCertificate()
+	if err != nil {
+		return err
+	}
+
+	certf := conf.ServerCertPath(resource.remote)
+	if util.PathExists(certf) {
+		err = os.WriteFile(certf, []byte(cert), 0o644)
+		if err != nil {
+			return fmt.Errorf(i18n.G("Could not write new remote certificate for remote '%s' with error: %v"), resource.remote, err)
+		}
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Println(i18n.G("Successfully renewed cluster certificates"))
+	}
+
+	return nil
+}
+
 type cmdClusterEvacuateAction struct {
 	global *cmdGlobal
 
 	flagAction string
 	flagForce  bool
+	flagFormat string
 }
 
 // Cluster member evacuation.
@@ -1524,6 +1631,73 @@ func (c *cmdClusterRestore) Command() *cobra.Command {
 	return cmd
 }
 
+// Cluster member drain.
+type cmdClusterDrain struct {
+	global  *cmdGlobal
+	cluster *cmdCluster
+	action  *cmdClusterEvacuateAction
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdClusterDrain) Command() *cobra.Command {
+	cmdAction := cmdClusterEvacuateAction{global: c.global}
+	c.action = &cmdAction
+
+	cmd := c.action.Command()
+	cmd.Use = usage("drain", i18n.G("[<remote>:]<member>"))
+	cmd.Short = i18n.G("Drain cluster member")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(`Drain cluster member
+
+The cluster member is marked as unschedulable, its instances are live-migrated
+when possible, moved or cleanly stopped according to their cluster.evacuate
+policy otherwise, and the resulting restore plan is shown.
+
+Use "incus cluster uncordon" to undo it.`))
+
+	cmd.Flags().StringVarP(&c.action.flagFormat, "format", "f", c.global.defaultListFormat(), i18n.G(`Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`)+"``")
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpClusterMembers(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Cluster member uncordon.
+type cmdClusterUncordon struct {
+	global  *cmdGlobal
+	cluster *cmdCluster
+	action  *cmdClusterEvacuateAction
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdClusterUncordon) Command() *cobra.Command {
+	cmdAction := cmdClusterEvacuateAction{global: c.global}
+	c.action = &cmdAction
+
+	cmd := c.action.Command()
+	cmd.Use = usage("uncordon", i18n.G("[<remote>:]<member>"))
+	cmd.Short = i18n.G("Uncordon cluster member")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(`Uncordon cluster member
+
+The cluster member is made schedulable again, the instances which were stopped
+are started and those which were moved are migrated back.`))
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpClusterMembers(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
 func (c *cmdClusterEvacuateAction) Command() *cobra.Command {
 	cmd := &cobra.Command{}
@@ -1564,8 +1738,17 @@ func (c *cmdClusterEvacuateAction) Run(cmd *cobra.Command, args []string) error
 		}
 	}
 
+	action := cmd.Name()
+	if action == "uncordon" {
+		action = "restore"
+	}
+
+	if action == "drain" && !resource.server.HasExtension("clustering_drain") {
+		return errors.New(i18n.G("The server doesn't support draining cluster members"))
+	}
+
 	state := api.ClusterMemberStatePost{
-		Action: cmd.Name(),
+		Action: action,
 		Mode:   c.flagAction,
 	}
 
@@ -1576,9 +1759,12 @@ func (c *cmdClusterEvacuateAction) Run(cmd *cobra.Command, args []string) error
 
 	var format string
 
-	if cmd.Name() == "restore" {
+	switch action {
+	case "restore":
 		format = i18n.G("Restoring cluster member: %s")
-	} else {
+	case "drain":
+		format = i18n.G("Draining cluster member: %s")
+	default:
 		format = i18n.G("Evacuating cluster member: %s")
 	}
 
@@ -1600,5 +1786,30 @@ func (c *cmdClusterEvacuateAction) Run(cmd *cobra.Command, args []string) error
 	}
 
 	progress.Done("")
-	return nil
+
+	if action != "drain" {
+		return nil
+	}
+
+	// Show the restore plan.
+	opAPI := op.Get()
+	plan, err := opAPI.ToClusterMemberRestorePlan()
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed to get the restore plan: %w"), err)
+	}
+
+	data := [][]string{}
+	for _, entry := range plan {
+		data = append(data, []string{entry.Project, entry.Instance, entry.Action, entry.Location, entry.Restore})
+	}
+
+	header := []string{
+		i18n.G("PROJECT"),
+		i18n.G("INSTANCE"),
+		i18n.G("ACTION"),
+		i18n.G("LOCATION"),
+		i18n.G("RESTORE"),
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, plan)
 }
@@ -0,0 +1,393 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	incus "github.com/lxc/incus/v6/client"
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// clusterUpgradeMember tracks the progress of the upgrade of a cluster member.
+type clusterUpgradeMember struct {
+	Name       string `json:"name" yaml:"name"`
+	URL        string `json:"url" yaml:"url"`
+	OldVersion string `json:"old_version" yaml:"old_version"`
+	NewVersion string `json:"new_version" yaml:"new_version"`
+	State      string `json:"state" yaml:"state"`
+}
+
+// Cluster upgrade.
+type cmdClusterUpgrade struct {
+	global  *cmdGlobal
+	cluster *cmdCluster
+
+	flagRolling bool
+	flagExec    string
+	flagTimeout int
+	flagForce   bool
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdClusterUpgrade) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("upgrade", i18n.G("[<remote>:]"))
+	cmd.Short = i18n.G("Upgrade cluster members")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(`Upgrade cluster members
+
+The upgrade of each member is performed by the command passed with --exec,
+which gets the name and URL of the member in the INCUS_MEMBER and
+INCUS_MEMBER_URL environment variables. Without it, you're asked to upgrade
+each member yourself.
+
+With --rolling, the members are upgraded one at a time: each member is drained,
+upgraded, waited for until it's back with a new version and restored before
+moving on to the next one. If any step fails, the upgrade stops and the state
+of all members is reported.`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus cluster upgrade --rolling --exec 'ssh "root@${INCUS_MEMBER}" apt-get install -y incus'`))
+
+	cmd.Flags().BoolVar(&c.flagRolling, "rolling", false, i18n.G("Upgrade the members one at a time, moving their instances away first"))
+	cmd.Flags().StringVar(&c.flagExec, "exec", "", i18n.G("Command upgrading a cluster member")+"``")
+	cmd.Flags().IntVar(&c.flagTimeout, "timeout", 600, i18n.G("Time in seconds to wait for a member to come back after its upgrade")+"``")
+	cmd.Flags().BoolVar(&c.flagForce, "force", false, i18n.G("Upgrade without user confirmation"))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(toComplete, false)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdClusterUpgrade) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	if !c.flagRolling && c.flagExec == "" {
+		return errors.New(i18n.G("Upgrading all members at once requires --exec"))
+	}
+
+	// Parse remote.
+	remote := ""
+	if len(args) == 1 {
+		remote = args[0]
+	}
+
+	resources, err := c.global.parseServers(remote)
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	// Check if clustered.
+	cluster, _, err := resource.server.GetCluster()
+	if err != nil {
+		return err
+	}
+
+	if !cluster.Enabled {
+		return errors.New(i18n.G("Server isn't part of a cluster"))
+	}
+
+	// Get the members and their current version.
+	clusterMembers, err := resource.server.GetClusterMembers()
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(clusterMembers, func(i, j int) bool { return clusterMembers[i].ServerName < clusterMembers[j].ServerName })
+
+	members := make([]*clusterUpgradeMember, 0, len(clusterMembers))
+	for _, clusterMember := range clusterMembers {
+		if clusterMember.Status != "Online" {
+			return fmt.Errorf(i18n.G("Cluster member %q isn't online: %s"), clusterMember.ServerName, clusterMember.Message)
+		}
+
+		version, err := c.memberVersion(resource.server, clusterMember.ServerName)
+		if err != nil {
+			return fmt.Errorf(i18n.G("Failed getting version of cluster member %q: %w"), clusterMember.ServerName, err)
+		}
+
+		members = append(members, &clusterUpgradeMember{
+			Name:       clusterMember.ServerName,
+			URL:        clusterMember.URL,
+			OldVersion: version,
+			State:      "pending",
+		})
+	}
+
+	if !c.flagForce {
+		upgrade, err := c.global.asker.AskBool(fmt.Sprintf(i18n.G("Are you sure you want to upgrade the %d cluster members? (yes/no) [default=no]: "), len(members)), "no")
+		if err != nil {
+			return err
+		}
+
+		if !upgrade {
+			return nil
+		}
+	}
+
+	if c.flagRolling {
+		err = c.upgradeRolling(resource.server, members)
+	} else {
+		err = c.upgradeAll(resource.server, members)
+	}
+
+	// Always report the state of the members.
+	reportErr := c.report(members)
+	if err != nil {
+		return err
+	}
+
+	return reportErr
+}
+
+// upgradeRolling upgrades the members one at a time, moving their instances away first.
+func (c *cmdClusterUpgrade) upgradeRolling(server incus.InstanceServer, members []*clusterUpgradeMember) error {
+	action := "evacuate"
+	if server.HasExtension("clustering_drain") {
+		action = "drain"
+	}
+
+	// Members whose new version needs the others to be upgraded first are restored at the end.
+	waiting := []*clusterUpgradeMember{}
+
+	for _, member := range members {
+		member.State = "draining"
+		err := c.memberState(server, member.Name, action, i18n.G("Draining cluster member %s: %%s"))
+		if err != nil {
+			member.State = "failed to drain"
+			return fmt.Errorf(i18n.G("Failed to drain cluster member %q: %w"), member.Name, err)
+		}
+
+		member.State = "upgrading"
+		err = c.upgradeMember(member)
+		if err != nil {
+			member.State = "failed to upgrade"
+			return fmt.Errorf(i18n.G("Failed to upgrade cluster member %q: %w"), member.Name, err)
+		}
+
+		member.State = "waiting"
+		back, err := c.waitMember(server, member, members)
+		if err != nil {
+			member.State = "didn't come back"
+			return err
+		}
+
+		if !back {
+			member.State = "waiting for the other members"
+			waiting = append(waiting, member)
+			continue
+		}
+
+		err = c.restoreMember(server, member)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Now that all the members were upgraded, the waiting ones come back.
+	for _, member := range waiting {
+		member.State = "waiting"
+		_, err := c.waitMember(server, member, nil)
+		if err != nil {
+			member.State = "didn't come back"
+			return err
+		}
+
+		err = c.restoreMember(server, member)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoreMember brings the instances back to an upgraded cluster member.
+func (c *cmdClusterUpgrade) restoreMember(server incus.InstanceServer, member *clusterUpgradeMember) error {
+	member.State = "restoring"
+	err := c.memberState(server, member.Name, "restore", i18n.G("Restoring cluster member %s: %%s"))
+	if err != nil {
+		member.State = "failed to restore"
+		return fmt.Errorf(i18n.G("Failed to restore cluster member %q: %w"), member.Name, err)
+	}
+
+	member.State = "upgraded"
+
+	return nil
+}
+
+// upgradeAll upgrades all the members before waiting for them to come back.
+func (c *cmdClusterUpgrade) upgradeAll(server incus.InstanceServer, members []*clusterUpgradeMember) error {
+	for _, member := range members {
+		member.State = "upgrading"
+		err := c.upgradeMember(member)
+		if err != nil {
+			member.State = "failed to upgrade"
+			return fmt.Errorf(i18n.G("Failed to upgrade cluster member %q: %w"), member.Name, err)
+		}
+
+		member.State = "waiting"
+	}
+
+	for _, member := range members {
+		_, err := c.waitMember(server, member, nil)
+		if err != nil {
+			member.State = "didn't come back"
+			return err
+		}
+
+		member.State = "upgraded"
+	}
+
+	return nil
+}
+
+// memberVersion returns the version of the server running on a cluster member.
+func (c *cmdClusterUpgrade) memberVersion(server incus.InstanceServer, name string) (string, error) {
+	info, _, err := server.UseTarget(name).GetServer()
+	if err != nil {
+		return "", err
+	}
+
+	return info.Environment.ServerVersion, nil
+}
+
+// memberState evacuates or restores a cluster member.
+func (c *cmdClusterUpgrade) memberState(server incus.InstanceServer, name string, action string, format string) error {
+	op, err := server.UpdateClusterMemberState(name, api.ClusterMemberStatePost{Action: action})
+	if err != nil {
+		return err
+	}
+
+	progress := cli.ProgressRenderer{
+		Format: fmt.Sprintf(format, name),
+		Quiet:  c.global.flagQuiet,
+	}
+
+	_, err = op.AddHandler(progress.UpdateOp)
+	if err != nil {
+		progress.Done("")
+		return err
+	}
+
+	err = op.Wait()
+	progress.Done("")
+	return err
+}
+
+// upgradeMember runs the upgrade command for a cluster member, or asks the user to upgrade it.
+func (c *cmdClusterUpgrade) upgradeMember(member *clusterUpgradeMember) error {
+	if c.flagExec == "" {
+		done, err := c.global.asker.AskBool(fmt.Sprintf(i18n.G("Upgrade cluster member %q now. Done? (yes/no) [default=yes]: "), member.Name), "yes")
+		if err != nil {
+			return err
+		}
+
+		if !done {
+			return errors.New(i18n.G("User aborted the upgrade"))
+		}
+
+		return nil
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Upgrading cluster member %s")+"\n", member.Name)
+	}
+
+	upgradeCmd := exec.Command("sh", "-c", c.flagExec)
+	upgradeCmd.Env = append(os.Environ(), "INCUS_MEMBER="+member.Name, "INCUS_MEMBER_URL="+member.URL)
+	upgradeCmd.Stdout = os.Stdout
+	upgradeCmd.Stderr = os.Stderr
+
+	return upgradeCmd.Run()
+}
+
+// waitMember waits for a cluster member to be back with a new version, returning true once it is.
+//
+// When the new version changes the database schema or API, the upgraded member only comes back once
+// all the members were upgraded, the members still to upgrade being reported as blocked in the meantime.
+// If one of the pending members is blocked, false is returned so that the upgrade moves on.
+func (c *cmdClusterUpgrade) waitMember(server incus.InstanceServer, member *clusterUpgradeMember, members []*clusterUpgradeMember) (bool, error) {
+	timeout := time.Duration(c.flagTimeout) * time.Second
+	deadline := time.Now().Add(timeout)
+
+	for time.Now().Before(deadline) {
+		version, err := c.memberVersion(server, member.Name)
+		if err == nil && version != member.OldVersion {
+			member.NewVersion = version
+			return true, nil
+		}
+
+		if c.pendingBlocked(server, members) {
+			return false, nil
+		}
+
+		time.Sleep(5 * time.Second)
+	}
+
+	return false, fmt.Errorf(i18n.G("Cluster member %q didn't come back with a new version within %s"), member.Name, timeout)
+}
+
+// pendingBlocked returns whether one of the members still to upgrade is blocked, waiting to be upgraded
+// to the newer version of another member.
+func (c *cmdClusterUpgrade) pendingBlocked(server incus.InstanceServer, members []*clusterUpgradeMember) bool {
+	if len(members) == 0 {
+		return false
+	}
+
+	clusterMembers, err := server.GetClusterMembers()
+	if err != nil {
+		return false
+	}
+
+	for _, clusterMember := range clusterMembers {
+		if clusterMember.Status != "Blocked" {
+			continue
+		}
+
+		for _, member := range members {
+			if member.Name == clusterMember.ServerName && member.State == "pending" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// report shows the state of the members.
+func (c *cmdClusterUpgrade) report(members []*clusterUpgradeMember) error {
+	data := [][]string{}
+	for _, member := range members {
+		data = append(data, []string{member.Name, member.OldVersion, member.NewVersion, member.State})
+	}
+
+	header := []string{
+		i18n.G("NAME"),
+		i18n.G("OLD VERSION"),
+		i18n.G("NEW VERSION"),
+		i18n.G("STATE"),
+	}
+
+	return cli.RenderTable(os.Stdout, cli.TableFormatTable, header, data, members)
+}
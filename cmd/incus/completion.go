@@ -10,6 +10,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	incus "github.com/lxc/incus/v6/client"
 	"github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/shared/api"
 )
@@ -214,22 +215,39 @@ func (g *cmdGlobal) cmpImages(toComplete string) ([]string, cobra.ShellCompDirec
 		remote = g.conf.DefaultRemote
 	}
 
-	remoteServer, _ := g.conf.GetImageServer(remote)
+	prefix := strings.TrimPrefix(toComplete, remote+":")
 
-	images, _ := remoteServer.GetImages()
+	aliases, _ := g.cmpCached(remote, "image-aliases", prefix, func() ([]string, error) {
+		remoteServer, err := g.conf.GetImageServer(remote)
+		if err != nil {
+			return nil, err
+		}
 
-	for _, image := range images {
-		for _, alias := range image.Aliases {
-			var name string
+		images, err := remoteServer.GetImages()
+		if err != nil {
+			return nil, err
+		}
 
-			if remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
-				name = alias.Name
-			} else {
-				name = fmt.Sprintf("%s:%s", remote, alias.Name)
+		names := []string{}
+		for _, image := range images {
+			for _, alias := range image.Aliases {
+				names = append(names, alias.Name)
 			}
+		}
 
-			results = append(results, name)
+		return names, nil
+	})
+
+	for _, alias := range aliases {
+		var name string
+
+		if remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
+			name = alias
+		} else {
+			name = fmt.Sprintf("%s:%s", remote, alias)
 		}
+
+		results = append(results, name)
 	}
 
 	if !strings.Contains(toComplete, ":") {
@@ -340,19 +358,15 @@ func (g *cmdGlobal) cmpInstances(toComplete string) ([]string, cobra.ShellCompDi
 	results := []string{}
 	cmpDirectives := cobra.ShellCompDirectiveNoFileComp
 
-	resources, _ := g.parseServers(toComplete)
-
-	if len(resources) > 0 {
-		resource := resources[0]
-
-		instances, _ := resource.server.GetInstanceNames(api.InstanceTypeAny)
+	remote, instances, err := g.cmpCachedNames(toComplete, "instances", cmpInstanceNames)
+	if err == nil {
 		for _, instName := range instances {
 			var name string
 
-			if resource.remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
+			if remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
 				name = instName
 			} else {
-				name = fmt.Sprintf("%s:%s", resource.remote, instName)
+				name = fmt.Sprintf("%s:%s", remote, instName)
 			}
 
 			if !strings.HasPrefix(name, toComplete) {
@@ -376,26 +390,29 @@ func (g *cmdGlobal) cmpInstancesAndSnapshots(toComplete string) ([]string, cobra
 	results := []string{}
 	cmpDirectives := cobra.ShellCompDirectiveNoFileComp
 
-	resources, _ := g.parseServers(toComplete)
+	_, resourceName, err := g.conf.ParseRemote(toComplete)
+	if err == nil && strings.Contains(resourceName, instance.SnapshotDelimiter) {
+		resources, _ := g.parseServers(toComplete)
 
-	if len(resources) > 0 {
-		resource := resources[0]
+		if len(resources) > 0 {
+			resource := resources[0]
 
-		if strings.Contains(resource.name, instance.SnapshotDelimiter) {
 			instName := strings.SplitN(resource.name, instance.SnapshotDelimiter, 2)[0]
 			snapshots, _ := resource.server.GetInstanceSnapshotNames(instName)
 			for _, snapshot := range snapshots {
 				results = append(results, fmt.Sprintf("%s/%s", instName, snapshot))
 			}
-		} else {
-			instances, _ := resource.server.GetInstanceNames(api.InstanceTypeAny)
+		}
+	} else if err == nil {
+		remote, instances, err := g.cmpCachedNames(toComplete, "instances", cmpInstanceNames)
+		if err == nil {
 			for _, instName := range instances {
 				var name string
 
-				if resource.remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
+				if remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
 					name = instName
 				} else {
-					name = fmt.Sprintf("%s:%s", resource.remote, instName)
+					name = fmt.Sprintf("%s:%s", remote, instName)
 				}
 
 				results = append(results, name)
@@ -415,20 +432,19 @@ func (g *cmdGlobal) cmpInstancesAndSnapshots(toComplete string) ([]string, cobra
 func (g *cmdGlobal) cmpInstanceNamesFromRemote(toComplete string) ([]string, cobra.ShellCompDirective) {
 	results := []string{}
 
-	resources, _ := g.parseServers(toComplete)
-
-	if len(resources) > 0 {
-		resource := resources[0]
-
-		containers, _ := resource.server.GetInstanceNames("container")
-		results = append(results, containers...)
-		vms, _ := resource.server.GetInstanceNames("virtual-machine")
-		results = append(results, vms...)
+	_, instances, err := g.cmpCachedNames(toComplete, "instances", cmpInstanceNames)
+	if err == nil {
+		results = append(results, instances...)
 	}
 
 	return results, cobra.ShellCompDirectiveNoFileComp
 }
 
+// cmpInstanceNames fetches the names of the instances for the completion cache.
+func cmpInstanceNames(server incus.InstanceServer) ([]string, error) {
+	return server.GetInstanceNames(api.InstanceTypeAny)
+}
+
 func (g *cmdGlobal) cmpNetworkACLConfigs(aclName string) ([]string, cobra.ShellCompDirective) {
 	// Parse remote
 	resources, err := g.parseServers(aclName)
@@ -672,23 +688,17 @@ func (g *cmdGlobal) cmpNetworks(toComplete string) ([]string, cobra.ShellCompDir
 	results := []string{}
 	cmpDirectives := cobra.ShellCompDirectiveNoFileComp
 
-	resources, _ := g.parseServers(toComplete)
-
-	if len(resources) > 0 {
-		resource := resources[0]
-
-		networks, err := resource.server.GetNetworkNames()
-		if err != nil {
-			return nil, cobra.ShellCompDirectiveError
-		}
-
+	remote, networks, err := g.cmpCachedNames(toComplete, "networks", func(server incus.InstanceServer) ([]string, error) {
+		return server.GetNetworkNames()
+	})
+	if err == nil {
 		for _, network := range networks {
 			var name string
 
-			if resource.remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
+			if remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
 				name = network
 			} else {
-				name = fmt.Sprintf("%s:%s", resource.remote, network)
+				name = fmt.Sprintf("%s:%s", remote, network)
 			}
 
 			results = append(results, name)
@@ -933,36 +943,32 @@ func (g *cmdGlobal) cmpProfileDeviceNames(instanceName string) ([]string, cobra.
 func (g *cmdGlobal) cmpProfileNamesFromRemote(toComplete string) ([]string, cobra.ShellCompDirective) {
 	results := []string{}
 
-	resources, _ := g.parseServers(toComplete)
-
-	if len(resources) > 0 {
-		resource := resources[0]
-
-		profiles, _ := resource.server.GetProfileNames()
+	_, profiles, err := g.cmpCachedNames(toComplete, "profiles", cmpProfileNames)
+	if err == nil {
 		results = append(results, profiles...)
 	}
 
 	return results, cobra.ShellCompDirectiveNoFileComp
 }
 
+// cmpProfileNames fetches the names of the profiles for the completion cache.
+func cmpProfileNames(server incus.InstanceServer) ([]string, error) {
+	return server.GetProfileNames()
+}
+
 func (g *cmdGlobal) cmpProfiles(toComplete string, includeRemotes bool) ([]string, cobra.ShellCompDirective) {
 	results := []string{}
 	cmpDirectives := cobra.ShellCompDirectiveNoFileComp
 
-	resources, _ := g.parseServers(toComplete)
-
-	if len(resources) > 0 {
-		resource := resources[0]
-
-		profiles, _ := resource.server.GetProfileNames()
-
+	remote, profiles, err := g.cmpCachedNames(toComplete, "profiles", cmpProfileNames)
+	if err == nil {
 		for _, profile := range profiles {
 			var name string
 
-			if resource.remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
+			if remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
 				name = profile
 			} else {
-				name = fmt.Sprintf("%s:%s", resource.remote, profile)
+				name = fmt.Sprintf("%s:%s", remote, profile)
 			}
 
 			results = append(results, name)
@@ -1068,6 +1074,16 @@ func (g *cmdGlobal) cmpRemoteNames() ([]string, cobra.ShellCompDirective) {
 	return results, cobra.ShellCompDirectiveNoFileComp
 }
 
+func (g *cmdGlobal) cmpRemoteGroupNames() ([]string, cobra.ShellCompDirective) {
+	results := []string{}
+
+	for groupName := range g.conf.RemoteGroups {
+		results = append(results, groupName)
+	}
+
+	return results, cobra.ShellCompDirectiveNoFileComp
+}
+
 func (g *cmdGlobal) cmpStoragePoolConfigs(poolName string) ([]string, cobra.ShellCompDirective) {
 	// Parse remote
 	resources, err := g.parseServers(poolName)
@@ -1131,20 +1147,17 @@ func (g *cmdGlobal) cmpStoragePoolWithVolume(toComplete string) ([]string, cobra
 func (g *cmdGlobal) cmpStoragePools(toComplete string) ([]string, cobra.ShellCompDirective) {
 	results := []string{}
 
-	resources, _ := g.parseServers(toComplete)
-
-	if len(resources) > 0 {
-		resource := resources[0]
-
-		storagePools, _ := resource.server.GetStoragePoolNames()
-
+	remote, storagePools, err := g.cmpCachedNames(toComplete, "storage-pools", func(server incus.InstanceServer) ([]string, error) {
+		return server.GetStoragePoolNames()
+	})
+	if err == nil {
 		for _, storage := range storagePools {
 			var name string
 
-			if resource.remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
+			if remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
 				name = storage
 			} else {
-				name = fmt.Sprintf("%s:%s", resource.remote, storage)
+				name = fmt.Sprintf("%s:%s", remote, storage)
 			}
 
 			results = append(results, name)
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// cmpCacheTTL is how long the names of the resources fetched for shell completion are cached,
+// so that completing several arguments doesn't query the remote on each key press.
+const cmpCacheTTL = 30 * time.Second
+
+// cmpCacheEntry is the content of a completion cache file.
+type cmpCacheEntry struct {
+	Names []string `json:"names"`
+}
+
+// cmpCachePath returns the path of the completion cache file of a kind of resources of the remote.
+func (g *cmdGlobal) cmpCachePath(remote string, kind string) string {
+	project := g.conf.ProjectOverride
+	if project == "" {
+		project = g.conf.Remotes[remote].Project
+	}
+
+	if project == "" {
+		project = api.ProjectDefaultName
+	}
+
+	name := fmt.Sprintf("%s_%s_%s.json", url.PathEscape(remote), url.PathEscape(project), kind)
+
+	return filepath.Join(g.conf.CacheDir, "completion", name)
+}
+
+// cmpCached returns the names of a kind of resources of the remote, from the completion cache if
+// fresh enough and holding a name with the prefix being completed, fetching them otherwise.
+func (g *cmdGlobal) cmpCached(remote string, kind string, prefix string, fetch func() ([]string, error)) ([]string, error) {
+	if g.conf.CacheDir == "" {
+		return fetch()
+	}
+
+	path := g.cmpCachePath(remote, kind)
+
+	info, err := os.Stat(path)
+	if err == nil && time.Since(info.ModTime()) < cmpCacheTTL {
+		content, err := os.ReadFile(path)
+		if err == nil {
+			entry := cmpCacheEntry{}
+
+			err = json.Unmarshal(content, &entry)
+			if err == nil {
+				// Resources created since the names were cached are missed otherwise.
+				for _, name := range entry.Names {
+					if strings.HasPrefix(name, prefix) {
+						return entry.Names, nil
+					}
+				}
+			}
+		}
+	}
+
+	names, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	// The cache is best effort.
+	content, err := json.Marshal(cmpCacheEntry{Names: names})
+	if err == nil && os.MkdirAll(filepath.Dir(path), 0o700) == nil {
+		tmpPath := path + ".tmp"
+
+		err = os.WriteFile(tmpPath, content, 0o600)
+		if err == nil {
+			_ = os.Rename(tmpPath, path)
+		}
+	}
+
+	return names, nil
+}
+
+// cmpCachedNames returns the names of a kind of resources of the remote through the completion cache,
+// connecting to the remote only if they need to be fetched.
+func (g *cmdGlobal) cmpCachedNames(toComplete string, kind string, fetch func(server incus.InstanceServer) ([]string, error)) (string, []string, error) {
+	remote, prefix, err := g.conf.ParseRemote(toComplete)
+	if err != nil {
+		return "", nil, err
+	}
+
+	names, err := g.cmpCached(remote, kind, prefix, func() ([]string, error) {
+		server, err := g.conf.GetInstanceServer(remote)
+		if err != nil {
+			return nil, err
+		}
+
+		return fetch(server)
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return remote, names, nil
+}
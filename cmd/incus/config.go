@@ -68,6 +68,10 @@ func (c *cmdConfig) Command() *cobra.Command {
 	configTemplateCmd := cmdConfigTemplate{global: c.global, config: c}
 	cmd.AddCommand(configTemplateCmd.Command())
 
+	// UEFI
+	configUEFICmd := cmdConfigUEFI{global: c.global, config: c}
+	cmd.AddCommand(configUEFICmd.Command())
+
 	// Trust
 	configTrustCmd := cmdConfigTrust{global: c.global, config: c}
 	cmd.AddCommand(configTrustCmd.Command())
@@ -274,6 +278,12 @@ func (c *cmdConfigEdit) Run(cmd *cobra.Command, args []string) error {
 
 			// Respawn the editor
 			if err != nil {
+				// Editing again won't help when another client modified the object in the meantime.
+				conflictErr := checkEditConflict(err)
+				if conflictErr != nil {
+					return conflictErr
+				}
+
 				fmt.Fprintf(os.Stderr, i18n.G("Config parsing error: %s")+"\n", err)
 				fmt.Println(i18n.G("Press enter to open the editor again or ctrl+c to abort change"))
 
@@ -349,6 +359,12 @@ func (c *cmdConfigEdit) Run(cmd *cobra.Command, args []string) error {
 
 		// Respawn the editor
 		if err != nil {
+			// Editing again won't help when another client modified the object in the meantime.
+			conflictErr := checkEditConflict(err)
+			if conflictErr != nil {
+				return conflictErr
+			}
+
 			fmt.Fprintf(os.Stderr, i18n.G("Config parsing error: %s")+"\n", err)
 			fmt.Println(i18n.G("Press enter to open the editor again or ctrl+c to abort change"))
 
@@ -516,6 +532,7 @@ type cmdConfigSet struct {
 	config *cmdConfig
 
 	flagIsProperty bool
+	flagCheck      bool
 }
 
 // Command creates a new Cobra command to set instance or server configuration keys and returns it.
@@ -537,6 +554,7 @@ incus config set core.https_address=[::]:8443
 
 	cmd.Flags().StringVar(&c.config.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
 	cmd.Flags().BoolVarP(&c.flagIsProperty, "property", "p", false, i18n.G("Set the key as an instance property"))
+	cmd.Flags().BoolVar(&c.flagCheck, "check", false, i18n.G("Only report the effect of the change on the project resources"))
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -689,6 +707,10 @@ func (c *cmdConfigSet) Run(cmd *cobra.Command, args []string) error {
 			}
 		}
 
+		if c.flagCheck {
+			return instanceUpdateCheck(resource.server, resource.name, writable)
+		}
+
 		op, err := resource.server.UpdateInstance(resource.name, writable, etag)
 		if err != nil {
 			return err
@@ -697,6 +719,11 @@ func (c *cmdConfigSet) Run(cmd *cobra.Command, args []string) error {
 		return op.Wait()
 	}
 
+	// Quick check.
+	if c.flagCheck {
+		return errors.New(i18n.G("--check can only be used with instances"))
+	}
+
 	// Targeting
 	if c.config.flagTarget != "" {
 		if !resource.server.IsClustered() {
@@ -742,6 +769,7 @@ type cmdConfigShow struct {
 	config *cmdConfig
 
 	flagExpanded bool
+	flagOrigin   bool
 }
 
 // Command sets up the "show" command, which displays instance or server configurations based on the provided arguments.
@@ -753,6 +781,7 @@ func (c *cmdConfigShow) Command() *cobra.Command {
 		`Show instance or server configurations`))
 
 	cmd.Flags().BoolVarP(&c.flagExpanded, "expanded", "e", false, i18n.G("Show the expanded configuration"))
+	cmd.Flags().BoolVar(&c.flagOrigin, "origin", false, i18n.G("Show where each expanded configuration key and device comes from"))
 	cmd.Flags().StringVar(&c.config.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
 	cmd.RunE = c.Run
 
@@ -788,6 +817,10 @@ func (c *cmdConfigShow) Run(cmd *cobra.Command, args []string) error {
 
 	resource := resources[0]
 
+	if c.flagOrigin && !c.flagExpanded {
+		return errors.New(i18n.G("--origin can only be used with --expanded"))
+	}
+
 	// Show configuration
 	var data []byte
 
@@ -830,6 +863,10 @@ func (c *cmdConfigShow) Run(cmd *cobra.Command, args []string) error {
 			// Snapshot
 			fields := strings.Split(resource.name, instance.SnapshotDelimiter)
 
+			if c.flagOrigin {
+				return errors.New(i18n.G("--origin cannot be used with snapshots"))
+			}
+
 			snap, _, err := resource.server.GetInstanceSnapshot(fields[0], fields[1])
 			if err != nil {
 				return err
@@ -840,6 +877,14 @@ func (c *cmdConfigShow) Run(cmd *cobra.Command, args []string) error {
 				brief.(*api.InstanceSnapshot).Config = snap.ExpandedConfig
 				brief.(*api.InstanceSnapshot).Devices = snap.ExpandedDevices
 			}
+		} else if c.flagOrigin {
+			// Instance with the origin of its configuration
+			inst, _, err := resource.server.GetInstanceWithOrigins(resource.name)
+			if err != nil {
+				return err
+			}
+
+			brief = c.instanceOrigins(inst)
 		} else {
 			// Instance
 			inst, _, err := resource.server.GetInstance(resource.name)
@@ -867,6 +912,48 @@ func (c *cmdConfigShow) Run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// instanceOrigins returns the expanded configuration of an instance along with where each key and device
+// comes from.
+func (c *cmdConfigShow) instanceOrigins(inst *api.Instance) any {
+	type instanceOrigins struct {
+		api.InstancePut `yaml:",inline"`
+
+		ConfigOrigins  map[string]string `yaml:"config_origins"`
+		DevicesOrigins map[string]string `yaml:"devices_origins"`
+	}
+
+	formatOrigin := func(origin api.InstanceConfigOrigin) string {
+		if origin.Type != "profile" {
+			return origin.Type
+		}
+
+		if origin.Project != "" && origin.Project != inst.Project {
+			return fmt.Sprintf(i18n.G("profile %s (project %s)"), origin.Profile, origin.Project)
+		}
+
+		return fmt.Sprintf(i18n.G("profile %s"), origin.Profile)
+	}
+
+	brief := instanceOrigins{
+		InstancePut:    inst.Writable(),
+		ConfigOrigins:  map[string]string{},
+		DevicesOrigins: map[string]string{},
+	}
+
+	brief.Config = inst.ExpandedConfig
+	brief.Devices = inst.ExpandedDevices
+
+	for key, origin := range inst.ExpandedConfigOrigins {
+		brief.ConfigOrigins[key] = formatOrigin(origin)
+	}
+
+	for name, origin := range inst.ExpandedDevicesOrigins {
+		brief.DevicesOrigins[name] = formatOrigin(origin)
+	}
+
+	return &brief
+}
+
 // Unset.
 type cmdConfigUnset struct {
 	global    *cmdGlobal
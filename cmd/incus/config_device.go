@@ -72,6 +72,8 @@ type cmdConfigDeviceAdd struct {
 	config       *cmdConfig
 	configDevice *cmdConfigDevice
 	profile      *cmdProfile
+
+	flagCheck bool
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -89,6 +91,8 @@ func (c *cmdConfigDeviceAdd) Command() *cobra.Command {
 
 incus config device add [<remote>:]instance1 <device-name> disk pool=some-pool source=some-volume path=/opt
     Will mount the some-volume volume on some-pool onto /opt in the instance.`))
+
+		cmd.Flags().BoolVar(&c.flagCheck, "check", false, i18n.G("Only report the effect of the change on the project resources"))
 	} else if c.profile != nil {
 		cmd.Use = usage("add", i18n.G("[<remote>:]<profile> <device> <type> [key=value...]"))
 		cmd.Example = cli.FormatSection("", i18n.G(
@@ -187,6 +191,10 @@ func (c *cmdConfigDeviceAdd) Run(cmd *cobra.Command, args []string) error {
 
 		inst.Devices[devname] = device
 
+		if c.flagCheck {
+			return instanceUpdateCheck(resource.server, resource.name, inst.Writable())
+		}
+
 		op, err := resource.server.UpdateInstance(resource.name, inst.Writable(), etag)
 		if err != nil {
 			return err
@@ -155,6 +155,12 @@ func (c *cmdConfigMetadataEdit) Run(cmd *cobra.Command, args []string) error {
 
 		// Respawn the editor
 		if err != nil {
+			// Editing again won't help when another client modified the object in the meantime.
+			conflictErr := checkEditConflict(err)
+			if conflictErr != nil {
+				return conflictErr
+			}
+
 			fmt.Fprintf(os.Stderr, i18n.G("Config parsing error: %s")+"\n", err)
 			fmt.Println(i18n.G("Press enter to open the editor again or ctrl+c to abort change"))
 
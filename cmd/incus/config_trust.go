@@ -81,8 +81,9 @@ type cmdConfigTrustAdd struct {
 	config      *cmdConfig
 	configTrust *cmdConfigTrust
 
-	flagProjects   string
-	flagRestricted bool
+	flagProjects       string
+	flagRestricted     bool
+	flagAllowedSources string
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -98,6 +99,7 @@ This will issue a trust token to be used by the client to add itself to the trus
 
 	cmd.Flags().BoolVar(&c.flagRestricted, "restricted", false, i18n.G("Restrict the certificate to one or more projects"))
 	cmd.Flags().StringVar(&c.flagProjects, "projects", "", i18n.G("List of projects to restrict the certificate to")+"``")
+	cmd.Flags().StringVar(&c.flagAllowedSources, "allowed-sources", "", i18n.G("List of networks (CIDR) the certificate may be used from")+"``")
 
 	cmd.RunE = c.Run
 
@@ -134,6 +136,14 @@ func (c *cmdConfigTrustAdd) Run(cmd *cobra.Command, args []string) error {
 		cert.Projects = strings.Split(c.flagProjects, ",")
 	}
 
+	if c.flagAllowedSources != "" {
+		if !resource.server.HasExtension("identity_allowed_sources") {
+			return errors.New(i18n.G("The server doesn't support restricting the source addresses of certificates"))
+		}
+
+		cert.AllowedSources = strings.Split(c.flagAllowedSources, ",")
+	}
+
 	// Create the token.
 	op, err := resource.server.CreateCertificateToken(cert)
 	if err != nil {
@@ -161,11 +171,12 @@ type cmdConfigTrustAddCertificate struct {
 	config      *cmdConfig
 	configTrust *cmdConfigTrust
 
-	flagProjects    string
-	flagRestricted  bool
-	flagName        string
-	flagType        string
-	flagDescription string
+	flagProjects       string
+	flagRestricted     bool
+	flagName           string
+	flagType           string
+	flagDescription    string
+	flagAllowedSources string
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -183,6 +194,7 @@ The following certificate types are supported:
 
 	cmd.Flags().BoolVar(&c.flagRestricted, "restricted", false, i18n.G("Restrict the certificate to one or more projects"))
 	cmd.Flags().StringVar(&c.flagProjects, "projects", "", i18n.G("List of projects to restrict the certificate to")+"``")
+	cmd.Flags().StringVar(&c.flagAllowedSources, "allowed-sources", "", i18n.G("List of networks (CIDR) the certificate may be used from")+"``")
 	cmd.Flags().StringVar(&c.flagName, "name", "", i18n.G("Alternative certificate name")+"``")
 	cmd.Flags().StringVar(&c.flagType, "type", "client", i18n.G("Type of certificate")+"``")
 	cmd.Flags().StringVar(&c.flagDescription, "description", "", i18n.G("Certificate description")+"``")
@@ -267,6 +279,14 @@ func (c *cmdConfigTrustAddCertificate) Run(cmd *cobra.Command, args []string) er
 		cert.Projects = strings.Split(c.flagProjects, ",")
 	}
 
+	if c.flagAllowedSources != "" {
+		if !resource.server.HasExtension("identity_allowed_sources") {
+			return errors.New(i18n.G("The server doesn't support restricting the source addresses of certificates"))
+		}
+
+		cert.AllowedSources = strings.Split(c.flagAllowedSources, ",")
+	}
+
 	return resource.server.CreateCertificate(cert)
 }
 
@@ -361,6 +381,12 @@ func (c *cmdConfigTrustEdit) Run(cmd *cobra.Command, args []string) error {
 
 		// Respawn the editor
 		if err != nil {
+			// Editing again won't help when another client modified the object in the meantime.
+			conflictErr := checkEditConflict(err)
+			if conflictErr != nil {
+				return conflictErr
+			}
+
 			fmt.Fprintf(os.Stderr, i18n.G("Config parsing error: %s")+"\n", err)
 			fmt.Println(i18n.G("Press enter to open the editor again or ctrl+c to abort change"))
 
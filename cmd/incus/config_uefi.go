@@ -0,0 +1,532 @@
+package main
+
+import (
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/termios"
+)
+
+type cmdConfigUEFI struct {
+	global *cmdGlobal
+	config *cmdConfig
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdConfigUEFI) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("uefi")
+	cmd.Short = i18n.G("Manage UEFI variables of virtual machines")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Manage UEFI variables of virtual machines
+
+Changes to the UEFI variables require the virtual machine to be stopped.`))
+
+	// Edit
+	configUEFIEditCmd := cmdConfigUEFIEdit{global: c.global, config: c.config, configUEFI: c}
+	cmd.AddCommand(configUEFIEditCmd.Command())
+
+	// Enroll
+	configUEFIEnrollCmd := cmdConfigUEFIEnroll{global: c.global, config: c.config, configUEFI: c}
+	cmd.AddCommand(configUEFIEnrollCmd.Command())
+
+	// Export
+	configUEFIExportCmd := cmdConfigUEFIExport{global: c.global, config: c.config, configUEFI: c}
+	cmd.AddCommand(configUEFIExportCmd.Command())
+
+	// Import
+	configUEFIImportCmd := cmdConfigUEFIImport{global: c.global, config: c.config, configUEFI: c}
+	cmd.AddCommand(configUEFIImportCmd.Command())
+
+	// Reset
+	configUEFIResetCmd := cmdConfigUEFIReset{global: c.global, config: c.config, configUEFI: c}
+	cmd.AddCommand(configUEFIResetCmd.Command())
+
+	// Show
+	configUEFIShowCmd := cmdConfigUEFIShow{global: c.global, config: c.config, configUEFI: c}
+	cmd.AddCommand(configUEFIShowCmd.Command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, _ []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// Edit.
+type cmdConfigUEFIEdit struct {
+	global     *cmdGlobal
+	config     *cmdConfig
+	configUEFI *cmdConfigUEFI
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdConfigUEFIEdit) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("edit", i18n.G("[<remote>:]<instance>"))
+	cmd.Short = i18n.G("Edit the UEFI boot order of a virtual machine")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Edit the UEFI boot order of a virtual machine`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstances(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdConfigUEFIEdit) helpTemplate() string {
+	return i18n.G(
+		`### This is a YAML representation of the UEFI boot configuration.
+### Any line starting with a '# will be ignored.
+###
+### The boot order lists the boot entries in the order they're attempted.
+### The next boot entry is only used for the next boot and is then cleared.
+###
+### A sample configuration looks like:
+###
+### boot_order:
+### - Boot0001
+### - Boot0000
+### boot_next: Boot0002`)
+}
+
+// Run runs the actual command logic.
+func (c *cmdConfigUEFIEdit) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing instance name"))
+	}
+
+	// Edit the boot configuration
+	if !termios.IsTerminal(getStdinFd()) {
+		uefi := api.InstanceUEFIPut{}
+		content, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		err = yaml.Unmarshal(content, &uefi)
+		if err != nil {
+			return err
+		}
+
+		return resource.server.UpdateInstanceUEFI(resource.name, uefi, "")
+	}
+
+	uefi, etag, err := resource.server.GetInstanceUEFI(resource.name)
+	if err != nil {
+		return err
+	}
+
+	origContent, err := yaml.Marshal(uefi.InstanceUEFIPut)
+	if err != nil {
+		return err
+	}
+
+	// Spawn the editor
+	content, err := textEditor("", []byte(c.helpTemplate()+"\n\n"+string(origContent)))
+	if err != nil {
+		return err
+	}
+
+	for {
+		newUEFI := api.InstanceUEFIPut{}
+		err = yaml.Unmarshal(content, &newUEFI)
+		if err == nil {
+			err = resource.server.UpdateInstanceUEFI(resource.name, newUEFI, etag)
+		}
+
+		// Respawn the editor
+		if err != nil {
+			// Editing again won't help when another client modified the object in the meantime.
+			conflictErr := checkEditConflict(err)
+			if conflictErr != nil {
+				return conflictErr
+			}
+
+			fmt.Fprintf(os.Stderr, i18n.G("Config parsing error: %s")+"\n", err)
+			fmt.Println(i18n.G("Press enter to open the editor again or ctrl+c to abort change"))
+
+			_, err := os.Stdin.Read(make([]byte, 1))
+			if err != nil {
+				return err
+			}
+
+			content, err = textEditor("", content)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		break
+	}
+
+	return nil
+}
+
+// Enroll.
+type cmdConfigUEFIEnroll struct {
+	global     *cmdGlobal
+	config     *cmdConfig
+	configUEFI *cmdConfigUEFI
+
+	flagAppend bool
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdConfigUEFIEnroll) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("enroll", i18n.G("[<remote>:]<instance> <PK|KEK|db|dbx> [<certificate>...]"))
+	cmd.Short = i18n.G("Enroll Secure Boot keys into a virtual machine")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Enroll Secure Boot keys into a virtual machine
+
+The certificates (PEM or DER encoded) replace the content of the Secure Boot variable,
+unless --append is passed. Passing no certificate removes all keys from the variable.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus config uefi enroll v1 db my-key.crt --append
+    Allow binaries signed with my-key.crt to boot in addition to the existing keys.`))
+
+	cmd.Flags().BoolVar(&c.flagAppend, "append", false, i18n.G("Keep the existing keys of the variable"))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstances(toComplete)
+		}
+
+		if len(args) == 1 {
+			return []string{"PK", "KEK", "db", "dbx"}, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdConfigUEFIEnroll) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 2, -1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing instance name"))
+	}
+
+	req := api.InstanceUEFISecureBootKeysPost{
+		Variable:     args[1],
+		Certificates: []string{},
+		Append:       c.flagAppend,
+	}
+
+	for _, path := range args[2:] {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		// Convert DER encoded certificates to PEM.
+		block, _ := pem.Decode(content)
+		if block == nil {
+			content = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: content})
+		}
+
+		req.Certificates = append(req.Certificates, string(content))
+	}
+
+	return resource.server.EnrollInstanceUEFISecureBootKeys(resource.name, req)
+}
+
+// Export.
+type cmdConfigUEFIExport struct {
+	global     *cmdGlobal
+	config     *cmdConfig
+	configUEFI *cmdConfigUEFI
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdConfigUEFIExport) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("export", i18n.G("[<remote>:]<instance> <target path>"))
+	cmd.Short = i18n.G("Export the UEFI variable store of a virtual machine")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Export the UEFI variable store of a virtual machine
+
+The raw firmware variable file is written to the target path, use "-" for stdout.`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstances(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdConfigUEFIExport) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing instance name"))
+	}
+
+	content, err := resource.server.GetInstanceUEFINVRAM(resource.name)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = content.Close() }()
+
+	if args[1] == "-" {
+		_, err = io.Copy(os.Stdout, content)
+		return err
+	}
+
+	target, err := os.Create(args[1])
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = target.Close() }()
+
+	_, err = io.Copy(target, content)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed writing UEFI variable store: %w"), err)
+	}
+
+	return target.Close()
+}
+
+// Import.
+type cmdConfigUEFIImport struct {
+	global     *cmdGlobal
+	config     *cmdConfig
+	configUEFI *cmdConfigUEFI
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdConfigUEFIImport) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("import", i18n.G("[<remote>:]<instance> <source path>"))
+	cmd.Short = i18n.G("Import the UEFI variable store of a virtual machine")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Import the UEFI variable store of a virtual machine
+
+The raw firmware variable file must match the firmware used by the virtual machine.`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstances(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdConfigUEFIImport) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing instance name"))
+	}
+
+	source, err := os.Open(args[1])
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = source.Close() }()
+
+	return resource.server.UpdateInstanceUEFINVRAM(resource.name, source)
+}
+
+// Reset.
+type cmdConfigUEFIReset struct {
+	global     *cmdGlobal
+	config     *cmdConfig
+	configUEFI *cmdConfigUEFI
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdConfigUEFIReset) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("reset", i18n.G("[<remote>:]<instance>"))
+	cmd.Short = i18n.G("Reset the UEFI variables of a virtual machine")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Reset the UEFI variables of a virtual machine to the firmware defaults`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstances(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdConfigUEFIReset) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing instance name"))
+	}
+
+	return resource.server.ResetInstanceUEFINVRAM(resource.name)
+}
+
+// Show.
+type cmdConfigUEFIShow struct {
+	global     *cmdGlobal
+	config     *cmdConfig
+	configUEFI *cmdConfigUEFI
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdConfigUEFIShow) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("show", i18n.G("[<remote>:]<instance>"))
+	cmd.Short = i18n.G("Show the UEFI variables of a virtual machine")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Show the UEFI boot entries and variables of a virtual machine`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstances(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdConfigUEFIShow) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing instance name"))
+	}
+
+	uefi, _, err := resource.server.GetInstanceUEFI(resource.name)
+	if err != nil {
+		return err
+	}
+
+	content, err := yaml.Marshal(uefi)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s", content)
+
+	return nil
+}
@@ -23,7 +23,6 @@ import (
 	"github.com/lxc/incus/v6/shared/ioprogress"
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/termios"
-	"github.com/lxc/incus/v6/shared/units"
 	"github.com/lxc/incus/v6/shared/util"
 )
 
@@ -261,14 +260,7 @@ func (c *cmdFileCreate) Run(cmd *cobra.Command, args []string) error {
 	if readCloser != nil {
 		fileArgs.Content = internalIO.NewReadSeeker(&ioprogress.ProgressReader{
 			ReadCloser: readCloser,
-			Tracker: &ioprogress.ProgressTracker{
-				Length: contentLength,
-				Handler: func(percent int64, speed int64) {
-					progress.UpdateProgress(ioprogress.ProgressData{
-						Text: fmt.Sprintf("%d%% (%s/s)", percent, units.GetByteSizeString(speed, 2)),
-					})
-				},
-			},
+			Tracker:    ioprogress.NewProgressTracker(ioprogress.StageFilePush, contentLength, progress.UpdateProgress),
 		}, fileArgs.Content)
 	}
 
@@ -639,19 +631,13 @@ func (c *cmdFilePull) Run(cmd *cobra.Command, args []string) error {
 
 		writer := &ioprogress.ProgressWriter{
 			WriteCloser: f,
-			Tracker: &ioprogress.ProgressTracker{
-				Handler: func(bytesReceived int64, speed int64) {
-					if targetPath == "-" {
-						return
-					}
+			Tracker: ioprogress.NewProgressTracker(ioprogress.StageFilePull, 0, func(data ioprogress.ProgressData) {
+				if targetPath == "-" {
+					return
+				}
 
-					progress.UpdateProgress(ioprogress.ProgressData{
-						Text: fmt.Sprintf("%s (%s/s)",
-							units.GetByteSizeString(bytesReceived, 2),
-							units.GetByteSizeString(speed, 2)),
-					})
-				},
-			},
+				progress.UpdateProgress(data)
+			}),
 		}
 
 		if targetIsLink {
@@ -936,14 +922,7 @@ func (c *cmdFilePush) Run(cmd *cobra.Command, args []string) error {
 
 		args.Content = internalIO.NewReadSeeker(&ioprogress.ProgressReader{
 			ReadCloser: f,
-			Tracker: &ioprogress.ProgressTracker{
-				Length: fstat.Size(),
-				Handler: func(percent int64, speed int64) {
-					progress.UpdateProgress(ioprogress.ProgressData{
-						Text: fmt.Sprintf("%d%% (%s/s)", percent, units.GetByteSizeString(speed, 2)),
-					})
-				},
-			},
+			Tracker:    ioprogress.NewProgressTracker(ioprogress.StageFilePush, fstat.Size(), progress.UpdateProgress),
 		}, f)
 
 		logger.Infof("Pushing %s to %s (%s)", f.Name(), fpath, args.Type)
@@ -1132,15 +1111,7 @@ func (c *cmdFile) recursivePullFile(sftpConn *sftp.Client, p string, targetDir s
 
 		writer := &ioprogress.ProgressWriter{
 			WriteCloser: dst,
-			Tracker: &ioprogress.ProgressTracker{
-				Handler: func(bytesReceived int64, speed int64) {
-					progress.UpdateProgress(ioprogress.ProgressData{
-						Text: fmt.Sprintf("%s (%s/s)",
-							units.GetByteSizeString(bytesReceived, 2),
-							units.GetByteSizeString(speed, 2)),
-					})
-				},
-			},
+			Tracker:     ioprogress.NewProgressTracker(ioprogress.StageFilePull, 0, progress.UpdateProgress),
 		}
 
 		for {
@@ -1263,15 +1234,7 @@ func (c *cmdFile) recursivePushFile(sftpConn *sftp.Client, source string, target
 
 			args.Content = internalIO.NewReadSeeker(&ioprogress.ProgressReader{
 				ReadCloser: readCloser,
-				Tracker: &ioprogress.ProgressTracker{
-					Length: contentLength,
-					Handler: func(percent int64, speed int64) {
-						progress.UpdateProgress(ioprogress.ProgressData{
-							Text: fmt.Sprintf("%d%% (%s/s)", percent,
-								units.GetByteSizeString(speed, 2)),
-						})
-					},
-				},
+				Tracker:    ioprogress.NewProgressTracker(ioprogress.StageFilePush, contentLength, progress.UpdateProgress),
 			}, args.Content)
 		}
 
@@ -471,6 +471,12 @@ func (c *cmdImageEdit) Run(cmd *cobra.Command, args []string) error {
 
 		// Respawn the editor
 		if err != nil {
+			// Editing again won't help when another client modified the object in the meantime.
+			conflictErr := checkEditConflict(err)
+			if conflictErr != nil {
+				return conflictErr
+			}
+
 			fmt.Fprintf(os.Stderr, i18n.G("Config parsing error: %s")+"\n", err)
 			fmt.Println(i18n.G("Press enter to open the editor again or ctrl+c to abort change"))
 
@@ -1352,11 +1358,6 @@ func (c *cmdImageList) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	remoteServer, err := c.global.conf.GetImageServer(remoteName)
-	if err != nil {
-		return err
-	}
-
 	// Process the filters
 	filters := []string{}
 	if name != "" {
@@ -1373,32 +1374,22 @@ func (c *cmdImageList) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	serverFilters, clientFilters := getServerSupportedFilters(filters, []string{}, false)
-	serverFilters = prepareImageServerFilters(serverFilters, api.Image{})
-
-	var allImages, images []api.Image
-	if c.flagAllProjects {
-		allImages, err = remoteServer.GetImagesAllProjectsWithFilter(serverFilters)
-		if err != nil {
-			allImages, err = remoteServer.GetImagesAllProjects()
-			if err != nil {
-				return err
-			}
+	if c.global.conf.IsRemoteGroup(remoteName) {
+		return c.listRemoteGroup(remoteName, filters, columns)
+	}
 
-			clientFilters = filters
-		}
-	} else {
-		allImages, err = remoteServer.GetImagesWithFilter(serverFilters)
-		if err != nil {
-			allImages, err = remoteServer.GetImages()
-			if err != nil {
-				return err
-			}
+	remoteServer, err := c.global.conf.GetImageServer(remoteName)
+	if err != nil {
+		return err
+	}
 
-			clientFilters = filters
-		}
+	allImages, clientFilters, err := c.getImages(remoteServer, filters)
+	if err != nil {
+		return err
 	}
 
+	var images []api.Image
+
 	data := [][]string{}
 	for _, image := range allImages {
 		if !c.imageShouldShow(clientFilters, &image) {
@@ -1430,6 +1421,101 @@ func (c *cmdImageList) Run(cmd *cobra.Command, args []string) error {
 	return cli.RenderTable(os.Stdout, c.flagFormat, headers, data, rawData)
 }
 
+// getImages returns the images of the server, along with the filters left to apply on the client side.
+func (c *cmdImageList) getImages(server incus.ImageServer, filters []string) ([]api.Image, []string, error) {
+	serverFilters, clientFilters := getServerSupportedFilters(filters, []string{}, false)
+	serverFilters = prepareImageServerFilters(serverFilters, api.Image{})
+
+	var images []api.Image
+	var err error
+	if c.flagAllProjects {
+		images, err = server.GetImagesAllProjectsWithFilter(serverFilters)
+		if err != nil {
+			images, err = server.GetImagesAllProjects()
+			if err != nil {
+				return nil, nil, err
+			}
+
+			clientFilters = filters
+		}
+	} else {
+		images, err = server.GetImagesWithFilter(serverFilters)
+		if err != nil {
+			images, err = server.GetImages()
+			if err != nil {
+				return nil, nil, err
+			}
+
+			clientFilters = filters
+		}
+	}
+
+	return images, clientFilters, nil
+}
+
+// remoteImage is an image annotated with the remote it comes from, as listed for remote groups.
+type remoteImage struct {
+	Remote string `json:"remote" yaml:"remote"`
+
+	api.Image `yaml:",inline"`
+}
+
+// listRemoteGroup lists the images of all the remotes of a remote group, prefixing each row with the remote name.
+func (c *cmdImageList) listRemoteGroup(group string, filters []string, columns []imageColumn) error {
+	servers, err := c.global.conf.GetImageServers(group)
+	if err != nil {
+		if len(servers) == 0 {
+			return err
+		}
+
+		fmt.Fprintln(os.Stderr, err)
+	}
+
+	type imagesResult struct {
+		images        []api.Image
+		clientFilters []string
+	}
+
+	results := incus.FanOut(servers, func(_ string, server incus.ImageServer) (imagesResult, error) {
+		images, clientFilters, err := c.getImages(server, filters)
+		return imagesResult{images: images, clientFilters: clientFilters}, err
+	})
+
+	data := [][]string{}
+	images := []remoteImage{}
+
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Fprintf(os.Stderr, i18n.G("Failed listing images on remote %q: %v")+"\n", result.Name, result.Err)
+			continue
+		}
+
+		for _, image := range result.Value.images {
+			if !c.imageShouldShow(result.Value.clientFilters, &image) {
+				continue
+			}
+
+			images = append(images, remoteImage{Remote: result.Name, Image: image})
+
+			row := []string{result.Name}
+			for _, column := range columns {
+				row = append(row, column.Data(image))
+			}
+
+			data = append(data, row)
+		}
+	}
+
+	sort.Sort(cli.StringList(data))
+
+	headers := []string{i18n.G("REMOTE")}
+	for _, column := range columns {
+		headers = append(headers, column.Name)
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, headers, data, images)
+}
+
 // Refresh.
 type cmdImageRefresh struct {
 	global *cmdGlobal
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"os"
 	"strings"
 
@@ -11,7 +10,6 @@ import (
 	cli "github.com/lxc/incus/v6/internal/cmd"
 	"github.com/lxc/incus/v6/internal/i18n"
 	"github.com/lxc/incus/v6/shared/ioprogress"
-	"github.com/lxc/incus/v6/shared/units"
 )
 
 type cmdImport struct {
@@ -99,12 +97,7 @@ func (c *cmdImport) Run(cmd *cobra.Command, args []string) error {
 	createArgs := incus.InstanceBackupArgs{
 		BackupFile: &ioprogress.ProgressReader{
 			ReadCloser: file,
-			Tracker: &ioprogress.ProgressTracker{
-				Length: fstat.Size(),
-				Handler: func(percent int64, speed int64) {
-					progress.UpdateProgress(ioprogress.ProgressData{Text: fmt.Sprintf("%d%% (%s/s)", percent, units.GetByteSizeString(speed, 2))})
-				},
-			},
+			Tracker:    ioprogress.NewProgressTracker(ioprogress.StageBackupUpload, fstat.Size(), progress.UpdateProgress),
 		},
 		PoolName: c.flagStorage,
 		Name:     instanceName,
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
@@ -371,6 +374,43 @@ func (c *cmdInfo) renderUSB(usb api.ResourcesUSBDevice, prefix string) {
 	}
 }
 
+func (c *cmdInfo) renderIOMMUGroups(devices []api.ResourcesPCIDevice) {
+	groups := map[uint64][]api.ResourcesPCIDevice{}
+	enabled := false
+	for _, pci := range devices {
+		groups[pci.IOMMUGroup] = append(groups[pci.IOMMUGroup], pci)
+
+		if pci.IOMMUGroup != 0 {
+			enabled = true
+		}
+	}
+
+	// Devices all report group 0 when the IOMMU is disabled.
+	if !enabled {
+		return
+	}
+
+	groupIDs := make([]uint64, 0, len(groups))
+	for groupID := range groups {
+		groupIDs = append(groupIDs, groupID)
+	}
+
+	sort.Slice(groupIDs, func(i, j int) bool { return groupIDs[i] < groupIDs[j] })
+
+	fmt.Printf("\n" + i18n.G("IOMMU groups:") + "\n")
+	for _, groupID := range groupIDs {
+		fmt.Printf("  "+i18n.G("Group %d:")+"\n", groupID)
+		for _, pci := range groups[groupID] {
+			driver := pci.Driver
+			if driver == "" {
+				driver = "-"
+			}
+
+			fmt.Printf("    - %s (%s) [%s]\n", pci.PCIAddress, pci.Product, driver)
+		}
+	}
+}
+
 func (c *cmdInfo) renderPCI(pci api.ResourcesPCIDevice, prefix string) {
 	fmt.Printf(prefix+i18n.G("Address: %v")+"\n", pci.PCIAddress)
 	fmt.Printf(prefix+i18n.G("Vendor: %v")+"\n", pci.Vendor)
@@ -603,6 +643,9 @@ func (c *cmdInfo) remoteInfo(d incus.InstanceServer) error {
 			}
 		}
 
+		// IOMMU groups
+		c.renderIOMMUGroups(resources.PCI.Devices)
+
 		return nil
 	}
 
@@ -652,7 +695,20 @@ func (c *cmdInfo) instanceInfo(d incus.InstanceServer, name string, showLog bool
 
 	fmt.Printf(i18n.G("Type: %s")+"\n", instType)
 
-	fmt.Printf(i18n.G("Architecture: %s")+"\n", inst.Architecture)
+	architecture := inst.Architecture
+	if d.HasExtension("instance_architecture_emulation") {
+		server := d
+		if inst.Location != "" && d.IsClustered() {
+			server = d.UseTarget(inst.Location)
+		}
+
+		serverStatus, _, err := server.GetServer()
+		if err == nil && slices.Contains(serverStatus.Environment.EmulatedArchitectures, inst.Architecture) {
+			architecture = fmt.Sprintf("%s (%s)", architecture, i18n.G("emulated"))
+		}
+	}
+
+	fmt.Printf(i18n.G("Architecture: %s")+"\n", architecture)
 
 	if inst.Location != "" && d.IsClustered() {
 		fmt.Printf(i18n.G("Location: %s")+"\n", inst.Location)
@@ -675,6 +731,30 @@ func (c *cmdInfo) instanceInfo(d incus.InstanceServer, name string, showLog bool
 			fmt.Printf(i18n.G("Started: %s")+"\n", inst.State.StartedAt.Local().Format(dateLayout))
 		}
 
+		startDuration, err := strconv.ParseInt(inst.Config["volatile.last_state.start_duration"], 10, 64)
+		if err == nil {
+			fmt.Printf(i18n.G("Start duration: %s")+"\n", (time.Duration(startDuration) * time.Millisecond).Round(100*time.Millisecond))
+		}
+
+		agentDuration, err := strconv.ParseInt(inst.Config["volatile.last_state.agent_duration"], 10, 64)
+		if err == nil {
+			fmt.Printf(i18n.G("Agent start duration: %s")+"\n", (time.Duration(agentDuration) * time.Millisecond).Round(100*time.Millisecond))
+		}
+	}
+
+	// The uptime of the current run is only recorded once the instance stops.
+	uptimeSeconds, _ := strconv.ParseInt(inst.Config["volatile.uptime"], 10, 64)
+	uptime := time.Duration(uptimeSeconds) * time.Second
+	if inst.State.Pid != 0 && !inst.LastUsedAt.IsZero() {
+		uptime += time.Since(inst.LastUsedAt)
+	}
+
+	if uptime > 0 {
+		fmt.Printf(i18n.G("Total uptime: %s")+"\n", uptime.Round(time.Second))
+	}
+
+	if inst.State.Pid != 0 {
+
 		// Operating System info
 		if inst.State.OSInfo != nil {
 			fmt.Println("\n" + i18n.G("Operating System:"))
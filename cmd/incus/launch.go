@@ -14,7 +14,8 @@ type cmdLaunch struct {
 	global *cmdGlobal
 	init   *cmdCreate
 
-	flagConsole string
+	flagConsole     string
+	flagInteractive bool
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -37,13 +38,17 @@ incus launch images:debian/12 v1 --vm -c limits.cpu=4 -c limits.memory=4GiB
     Create and start a virtual machine with 4 vCPUs and 4GiB of RAM
 
 incus launch images:debian/12 v2 --vm -d root,size=50GiB -d root,io.bus=nvme
-    Create and start a virtual machine, overriding the disk size and bus`))
+    Create and start a virtual machine, overriding the disk size and bus
+
+incus launch --interactive
+    Pick the image, resources, network, storage pool and profiles step by step`))
 	cmd.Hidden = false
 
 	cmd.RunE = c.Run
 
 	cmd.Flags().StringVar(&c.flagConsole, "console", "", i18n.G("Immediately attach to the console")+"``")
 	cmd.Flags().Lookup("console").NoOptDefVal = "console"
+	cmd.Flags().BoolVarP(&c.flagInteractive, "interactive", "i", false, i18n.G("Walk through the choices of the instance, printing the equivalent command"))
 
 	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) != 0 {
@@ -61,11 +66,23 @@ func (c *cmdLaunch) Run(cmd *cobra.Command, args []string) error {
 	conf := c.global.conf
 
 	// Quick checks.
-	exit, err := c.global.checkArgs(cmd, args, 1, 2)
+	minArgs := 1
+	if c.flagInteractive {
+		minArgs = 0
+	}
+
+	exit, err := c.global.checkArgs(cmd, args, minArgs, 2)
 	if exit {
 		return err
 	}
 
+	if c.flagInteractive {
+		args, err = c.interactive(args)
+		if err != nil || args == nil {
+			return err
+		}
+	}
+
 	// Call the matching code from init
 	d, name, err := c.init.create(conf, args, true)
 	if err != nil {
@@ -124,6 +141,16 @@ func (c *cmdLaunch) Run(cmd *cobra.Command, args []string) error {
 		Timeout: -1,
 	}
 
+	// Resume the state of instances created from live published images.
+	if d.HasExtension("image_publish_live") {
+		inst, _, err := d.GetInstance(name)
+		if err != nil {
+			return err
+		}
+
+		req.Stateful = inst.Stateful
+	}
+
 	op, err := d.UpdateInstanceState(name, req, "")
 	if err != nil {
 		return err
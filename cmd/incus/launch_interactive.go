@@ -0,0 +1,421 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kballard/go-shellquote"
+
+	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/termios"
+	"github.com/lxc/incus/v6/shared/units"
+)
+
+// launchInteractiveMaxImages is the number of matching images listed when searching for an image.
+const launchInteractiveMaxImages = 20
+
+// launchInteractiveDefaultImage is the image proposed by default, if its remote exists.
+const launchInteractiveDefaultImage = "images:debian/12"
+
+// interactive walks through the choices of a new instance, setting the flags of the command accordingly
+// and returning its arguments, or nil if the launch is canceled. The arguments and flags given on the
+// command line are used as defaults.
+func (c *cmdLaunch) interactive(args []string) ([]string, error) {
+	conf := c.global.conf
+	asker := c.global.asker
+
+	if !termios.IsTerminal(getStdinFd()) {
+		return nil, errors.New(i18n.G("The interactive mode requires a terminal"))
+	}
+
+	// Instance remote and name.
+	target := ""
+	if len(args) > 1 {
+		target = args[1]
+	}
+
+	remote, name, err := conf.ParseRemote(target)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := conf.GetInstanceServer(remote)
+	if err != nil {
+		return nil, err
+	}
+
+	// Instance type.
+	defaultType := api.InstanceTypeContainer
+	if c.init.flagVM {
+		defaultType = api.InstanceTypeVM
+	}
+
+	instanceType, err := asker.AskChoice(fmt.Sprintf(i18n.G("Instance type (container, virtual-machine) [default=%s]:")+" ", defaultType), []string{string(api.InstanceTypeContainer), string(api.InstanceTypeVM)}, string(defaultType))
+	if err != nil {
+		return nil, err
+	}
+
+	c.init.flagVM = instanceType == string(api.InstanceTypeVM)
+
+	// Image.
+	image := ""
+	if len(args) > 0 {
+		image = args[0]
+	} else if conf.Remotes["images"].Addr != "" {
+		image = launchInteractiveDefaultImage
+	}
+
+	image, err = c.askImage(remote, image, instanceType)
+	if err != nil {
+		return nil, err
+	}
+
+	// Name.
+	name, err = asker.AskString(i18n.G("Instance name (empty for a generated one):")+" ", name, func(string) error { return nil })
+	if err != nil {
+		return nil, err
+	}
+
+	// Resource limits.
+	config := map[string]string{}
+	for _, entry := range c.init.flagConfig {
+		key, value, _ := strings.Cut(entry, "=")
+		config[key] = value
+	}
+
+	cpus, err := asker.AskString(c.questionWithDefault(i18n.G("Number of CPUs (empty for no limit)"), config["limits.cpu"]), config["limits.cpu"], func(answer string) error {
+		if answer == "" {
+			return nil
+		}
+
+		value, err := strconv.Atoi(answer)
+		if err != nil || value < 1 {
+			return fmt.Errorf(i18n.G("Invalid number of CPUs %q"), answer)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	memory, err := asker.AskString(c.questionWithDefault(i18n.G("Memory limit, like 2GiB (empty for no limit)"), config["limits.memory"]), config["limits.memory"], func(answer string) error {
+		if answer == "" {
+			return nil
+		}
+
+		_, err := units.ParseByteSizeString(answer)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.init.flagConfig = slices.DeleteFunc(c.init.flagConfig, func(entry string) bool {
+		return strings.HasPrefix(entry, "limits.cpu=") || strings.HasPrefix(entry, "limits.memory=")
+	})
+
+	if cpus != "" {
+		c.init.flagConfig = append(c.init.flagConfig, "limits.cpu="+cpus)
+	}
+
+	if memory != "" {
+		c.init.flagConfig = append(c.init.flagConfig, "limits.memory="+memory)
+	}
+
+	// Profiles.
+	profiles, err := d.GetProfileNames()
+	if err != nil {
+		return nil, err
+	}
+
+	defaultProfiles := strings.Join(c.init.flagProfile, ",")
+	if c.init.flagProfile == nil && slices.Contains(profiles, api.ProjectDefaultName) {
+		defaultProfiles = api.ProjectDefaultName
+	}
+
+	fmt.Printf(i18n.G("Available profiles: %s")+"\n", strings.Join(profiles, ", "))
+	answer, err := asker.AskString(c.questionWithDefault(i18n.G("Profiles, comma-separated (empty for none)"), defaultProfiles), defaultProfiles, func(answer string) error {
+		for _, profile := range splitList(answer) {
+			if !slices.Contains(profiles, profile) {
+				return fmt.Errorf(i18n.G("Profile %q doesn't exist"), profile)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.init.flagProfile = splitList(answer)
+	c.init.flagNoProfiles = len(c.init.flagProfile) == 0
+
+	// Network.
+	networks, err := d.GetNetworkNames()
+	if err != nil {
+		return nil, err
+	}
+
+	c.init.flagNetwork, err = c.askChoiceOrProfiles(i18n.G("Network"), i18n.G("Available networks: %s"), networks, c.init.flagNetwork)
+	if err != nil {
+		return nil, err
+	}
+
+	// Storage pool.
+	pools, err := d.GetStoragePoolNames()
+	if err != nil {
+		return nil, err
+	}
+
+	c.init.flagStorage, err = c.askChoiceOrProfiles(i18n.G("Storage pool"), i18n.G("Available storage pools: %s"), pools, c.init.flagStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	// Arguments.
+	args = []string{image}
+	if name != "" || remote != conf.DefaultRemote {
+		if remote != conf.DefaultRemote {
+			name = remote + ":" + name
+		}
+
+		args = append(args, name)
+	}
+
+	fmt.Printf("\n%s\n", i18n.G("The equivalent command is:"))
+	fmt.Printf("    %s\n\n", shellquote.Join(c.command(args)...))
+
+	launch, err := asker.AskBool(i18n.G("Launch the instance? (yes/no) [default=yes]:")+" ", "yes")
+	if err != nil {
+		return nil, err
+	}
+
+	if !launch {
+		return nil, nil
+	}
+
+	return args, nil
+}
+
+// askImage asks for the image, searching the image servers and the remote of the instance
+// for the images matching the answer until one is picked.
+func (c *cmdLaunch) askImage(remote string, defaultImage string, instanceType string) (string, error) {
+	conf := c.global.conf
+
+	for {
+		answer, err := c.global.asker.AskString(c.questionWithDefault(i18n.G("Image, or search term ([<remote>:]<term>)"), defaultImage), defaultImage, nil)
+		if err != nil {
+			return "", err
+		}
+
+		// Search the given remote, or all the image servers and the remote of the instance.
+		remotes := []string{}
+		imageRemote, term, found := strings.Cut(answer, ":")
+		if found {
+			_, ok := conf.Remotes[imageRemote]
+			if !ok {
+				fmt.Fprintf(os.Stderr, i18n.G("Remote %s doesn't exist")+"\n\n", imageRemote)
+				continue
+			}
+
+			remotes = append(remotes, imageRemote)
+		} else {
+			term = answer
+
+			for name, rc := range conf.Remotes {
+				if name == remote || rc.Public || rc.Protocol != "incus" {
+					remotes = append(remotes, name)
+				}
+			}
+
+			sort.Strings(remotes)
+		}
+
+		matches := c.searchImages(remotes, term, instanceType)
+
+		// Exact matches are used as is.
+		if slices.Contains(matches, answer) || slices.Contains(matches, remote+":"+answer) {
+			return answer, nil
+		}
+
+		if len(matches) == 0 {
+			fmt.Fprintf(os.Stderr, i18n.G("No image matching %q")+"\n\n", answer)
+			continue
+		}
+
+		shown := min(len(matches), launchInteractiveMaxImages)
+		for i, match := range matches[:shown] {
+			fmt.Printf("%3d) %s\n", i+1, match)
+		}
+
+		if len(matches) > shown {
+			fmt.Printf(i18n.G("... and %d more, refine the search to see them")+"\n", len(matches)-shown)
+		}
+
+		choice, err := c.global.asker.AskInt(i18n.G("Number of the image to use (0 to search again) [default=1]:")+" ", 0, int64(shown), "1", nil)
+		if err != nil {
+			return "", err
+		}
+
+		if choice > 0 {
+			return matches[choice-1], nil
+		}
+
+		defaultImage = ""
+	}
+}
+
+// searchImages returns the aliases of the images for the instance type whose name contains the term,
+// on any of the remotes. The remotes which can't be reached are skipped.
+func (c *cmdLaunch) searchImages(remotes []string, term string, instanceType string) []string {
+	matches := []string{}
+
+	for _, remote := range remotes {
+		var server incus.ImageServer
+		var err error
+
+		if c.global.conf.Remotes[remote].Protocol == "incus" && !c.global.conf.Remotes[remote].Public {
+			server, err = c.global.conf.GetInstanceServer(remote)
+		} else {
+			server, err = c.global.conf.GetImageServer(remote)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		aliases, err := server.GetImageAliases()
+		if err != nil {
+			continue
+		}
+
+		for _, alias := range aliases {
+			if alias.Type != "" && alias.Type != instanceType {
+				continue
+			}
+
+			if !strings.Contains(alias.Name, term) {
+				continue
+			}
+
+			match := remote + ":" + alias.Name
+			if !slices.Contains(matches, match) {
+				matches = append(matches, match)
+			}
+		}
+	}
+
+	sort.Strings(matches)
+
+	return matches
+}
+
+// askChoiceOrProfiles asks for one of the choices, an empty answer meaning that the profiles provide it.
+func (c *cmdLaunch) askChoiceOrProfiles(question string, available string, choices []string, defaultAnswer string) (string, error) {
+	fmt.Printf(available+"\n", strings.Join(choices, ", "))
+
+	return c.global.asker.AskString(c.questionWithDefault(question+" "+i18n.G("(empty to use the profiles)"), defaultAnswer), defaultAnswer, func(answer string) error {
+		if answer != "" && !slices.Contains(choices, answer) {
+			return fmt.Errorf(i18n.G("%q isn't one of the available choices"), answer)
+		}
+
+		return nil
+	})
+}
+
+// questionWithDefault formats a question, showing its default answer if any.
+func (c *cmdLaunch) questionWithDefault(question string, defaultAnswer string) string {
+	if defaultAnswer == "" {
+		return question + ": "
+	}
+
+	return fmt.Sprintf("%s [default=%s]: ", question, defaultAnswer)
+}
+
+// command returns the non-interactive command equivalent to the answers.
+func (c *cmdLaunch) command(args []string) []string {
+	command := []string{"incus"}
+	if c.global.flagProject != "" {
+		command = append(command, "--project", c.global.flagProject)
+	}
+
+	command = append(command, "launch")
+	command = append(command, args...)
+
+	if c.init.flagVM {
+		command = append(command, "--vm")
+	}
+
+	if c.init.flagType != "" {
+		command = append(command, "-t", c.init.flagType)
+	}
+
+	if c.init.flagEphemeral {
+		command = append(command, "-e")
+	}
+
+	if c.init.flagNoProfiles {
+		command = append(command, "--no-profiles")
+	}
+
+	for _, profile := range c.init.flagProfile {
+		command = append(command, "-p", profile)
+	}
+
+	for _, entry := range c.init.flagConfig {
+		command = append(command, "-c", entry)
+	}
+
+	for _, entry := range c.init.flagDevice {
+		command = append(command, "-d", entry)
+	}
+
+	if c.init.flagNetwork != "" {
+		command = append(command, "-n", c.init.flagNetwork)
+	}
+
+	if c.init.flagStorage != "" {
+		command = append(command, "-s", c.init.flagStorage)
+	}
+
+	if c.init.flagTarget != "" {
+		command = append(command, "--target", c.init.flagTarget)
+	}
+
+	if c.init.flagDescription != "" {
+		command = append(command, "--description", c.init.flagDescription)
+	}
+
+	if c.init.flagEnvironmentFile != "" {
+		command = append(command, "--environment-file", c.init.flagEnvironmentFile)
+	}
+
+	if c.flagConsole == "console" {
+		command = append(command, "--console")
+	} else if c.flagConsole != "" {
+		command = append(command, "--console="+c.flagConsole)
+	}
+
+	return command
+}
+
+// splitList splits a comma-separated list, ignoring the empty items.
+func splitList(list string) []string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
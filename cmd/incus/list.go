@@ -80,6 +80,9 @@ A regular expression matching a configuration item or its value. (e.g. volatile.
 When multiple filters are passed, they are added one on top of the other,
 selecting instances which satisfy them all.
 
+When the remote is a remote group, the instances of all of its remotes are
+listed, with an additional REMOTE column.
+
 == Columns ==
 The -c option takes a comma separated list of arguments that control
 which instance attributes to output when displaying in table or csv
@@ -442,6 +445,10 @@ func (c *cmdList) Run(cmd *cobra.Command, args []string) error {
 		remote = conf.DefaultRemote
 	}
 
+	if conf.IsRemoteGroup(remote) {
+		return c.listRemoteGroup(remote, filters)
+	}
+
 	// Connect to the daemon.
 	d, err := conf.GetInstanceServer(remote)
 	if err != nil {
@@ -493,6 +500,85 @@ func (c *cmdList) Run(cmd *cobra.Command, args []string) error {
 	return c.listInstances(d, instances, clientFilters, columns)
 }
 
+// remoteInstance is an instance annotated with the remote it comes from, as listed for remote groups.
+type remoteInstance struct {
+	Remote string `json:"remote" yaml:"remote"`
+
+	api.InstanceFull `yaml:",inline"`
+}
+
+// listRemoteGroup lists the instances of all the remotes of a remote group, prefixing each row with the remote name.
+func (c *cmdList) listRemoteGroup(group string, filters []string) error {
+	servers, err := c.global.conf.GetInstanceServers(group)
+	if err != nil {
+		if len(servers) == 0 {
+			return err
+		}
+
+		fmt.Fprintln(os.Stderr, err)
+	}
+
+	// Show the location column if any of the remotes is clustered.
+	clustered := false
+	for _, d := range servers {
+		if d.IsClustered() {
+			clustered = true
+			break
+		}
+	}
+
+	columns, _, err := c.parseColumns(clustered)
+	if err != nil {
+		return err
+	}
+
+	serverFilters, clientFilters := getServerSupportedFilters(filters, []string{"ipv4", "ipv6"}, true)
+	serverFilters = prepareInstanceServerFilters(serverFilters, api.InstanceFull{})
+
+	results := incus.FanOut(servers, func(_ string, d incus.InstanceServer) ([]api.InstanceFull, error) {
+		if c.flagAllProjects {
+			return d.GetInstancesFullAllProjectsWithFilter(api.InstanceTypeAny, serverFilters)
+		}
+
+		return d.GetInstancesFullWithFilter(api.InstanceTypeAny, serverFilters)
+	})
+
+	// Generate the table data
+	data := [][]string{}
+	instances := []remoteInstance{}
+
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Fprintf(os.Stderr, i18n.G("Failed listing instances on remote %q: %v")+"\n", result.Name, result.Err)
+			continue
+		}
+
+		for _, inst := range result.Value {
+			if !c.shouldShow(clientFilters, &inst.Instance, inst.State) {
+				continue
+			}
+
+			instances = append(instances, remoteInstance{Remote: result.Name, InstanceFull: inst})
+
+			col := []string{result.Name}
+			for _, column := range columns {
+				col = append(col, column.Data(inst))
+			}
+
+			data = append(data, col)
+		}
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	headers := []string{i18n.G("REMOTE")}
+	for _, column := range columns {
+		headers = append(headers, column.Name)
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, headers, data, instances)
+}
+
 func (c *cmdList) parseColumns(clustered bool) ([]column, bool, error) {
 	columnsShorthandMap := map[rune]column{
 		'4': {i18n.G("IPV4"), c.ip4ColumnData, true, false},
@@ -33,6 +33,7 @@ type cmdGlobal struct {
 	cmd      *cobra.Command
 	ret      int
 
+	flagAs         string
 	flagForceLocal bool
 	flagHelp       bool
 	flagHelpAll    bool
@@ -118,6 +119,7 @@ Custom commands can be defined through aliases, use "incus alias" to control tho
 	app.PersistentFlags().BoolVarP(&globalCmd.flagHelp, "help", "h", false, i18n.G("Print help"))
 	app.PersistentFlags().BoolVar(&globalCmd.flagForceLocal, "force-local", false, i18n.G("Force using the local unix socket"))
 	app.PersistentFlags().StringVar(&globalCmd.flagProject, "project", "", i18n.G("Override the source project")+"``")
+	app.PersistentFlags().StringVar(&globalCmd.flagAs, "as", "", i18n.G("Run the requests as another identity (server administrators only)")+"``")
 	app.PersistentFlags().BoolVar(&globalCmd.flagLogDebug, "debug", false, i18n.G("Show all debug messages"))
 	app.PersistentFlags().BoolVarP(&globalCmd.flagLogVerbose, "verbose", "v", false, i18n.G("Show all information messages"))
 	app.PersistentFlags().BoolVarP(&globalCmd.flagQuiet, "quiet", "q", false, i18n.G("Don't show progress information"))
@@ -139,6 +141,10 @@ Custom commands can be defined through aliases, use "incus alias" to control tho
 	adminCmd := cmdAdmin{global: &globalCmd}
 	app.AddCommand(adminCmd.Command())
 
+	// app sub-command
+	appCmd := cmdApp{global: &globalCmd}
+	app.AddCommand(appCmd.Command())
+
 	// cluster sub-command
 	clusterCmd := cmdCluster{global: &globalCmd}
 	app.AddCommand(clusterCmd.Command())
@@ -255,6 +261,10 @@ Custom commands can be defined through aliases, use "incus alias" to control tho
 	resumeCmd := cmdResume{global: &globalCmd}
 	app.AddCommand(resumeCmd.Command())
 
+	// session sub-command
+	sessionCmd := cmdSession{global: &globalCmd}
+	app.AddCommand(sessionCmd.Command())
+
 	// snapshot sub-command
 	snapshotCmd := cmdSnapshot{global: &globalCmd}
 	app.AddCommand(snapshotCmd.Command())
@@ -422,6 +432,9 @@ func (c *cmdGlobal) PreRun(cmd *cobra.Command, _ []string) error {
 		c.conf.ProjectOverride = os.Getenv("INCUS_PROJECT")
 	}
 
+	// Impersonate another identity
+	c.conf.Impersonate = c.flagAs
+
 	// Setup password helper
 	c.conf.PromptPassword = func(filename string) (string, error) {
 		return c.asker.AskPasswordOnce(fmt.Sprintf(i18n.G("Password for %s: "), filename)), nil
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -18,6 +19,39 @@ import (
 
 var numberedArgRegex = regexp.MustCompile(`@ARG(\d+)@`)
 
+// aliasParamRegex matches the parameters of an alias, either positional ({0}) or named ({name}),
+// with an optional default value ({name:default}). Shell variables (${name}) are matched too, without
+// submatches, so that they are left alone.
+var aliasParamRegex = regexp.MustCompile(`\$\{[^{}]*\}|\{(\d+|[a-zA-Z_][a-zA-Z0-9_.-]*)(:[^{}]*)?\}`)
+
+// aliasCommandSeparator separates the commands an alias runs in sequence.
+const aliasCommandSeparator = "&&"
+
+// aliasParam is a parameter of an alias.
+type aliasParam struct {
+	name         string
+	index        int // Position of the argument, -1 for named parameters.
+	defaultValue string
+	hasDefault   bool
+}
+
+// String returns the parameter as shown in the list of aliases, optional ones being in brackets.
+func (p aliasParam) String() string {
+	if p.index >= 0 {
+		if p.hasDefault {
+			return fmt.Sprintf("[<%s>]", p.name)
+		}
+
+		return fmt.Sprintf("<%s>", p.name)
+	}
+
+	if p.hasDefault {
+		return fmt.Sprintf("[%s=%s]", p.name, p.defaultValue)
+	}
+
+	return fmt.Sprintf("%s=<value>", p.name)
+}
+
 // defaultAliases contains LXC's built-in command line aliases.  The built-in
 // aliases are checked only if no user-defined alias was found.
 var defaultAliases = map[string]string{
@@ -77,6 +111,165 @@ func findAlias(aliases map[string]string, origArgs []string) ([]string, []string
 	return aliasKey, aliasValue, foundAlias
 }
 
+// parseAliasParams returns the parameters of an alias, the positional ones first.
+func parseAliasParams(fields []string) ([]aliasParam, error) {
+	positional := []aliasParam{}
+	named := []aliasParam{}
+	seen := map[string]aliasParam{}
+
+	for _, field := range fields {
+		for _, match := range aliasParamRegex.FindAllStringSubmatch(field, -1) {
+			// Skip the shell variables.
+			if match[1] == "" {
+				continue
+			}
+
+			param := aliasParam{name: match[1], index: -1}
+			if match[2] != "" {
+				param.defaultValue = match[2][1:]
+				param.hasDefault = true
+			}
+
+			index, err := strconv.Atoi(param.name)
+			if err == nil {
+				param.index = index
+			}
+
+			previous, ok := seen[param.name]
+			if ok {
+				if param.hasDefault && (!previous.hasDefault || previous.defaultValue != param.defaultValue) {
+					return nil, fmt.Errorf(i18n.G("Conflicting default values for parameter %q"), param.name)
+				}
+
+				continue
+			}
+
+			seen[param.name] = param
+
+			if param.index >= 0 {
+				positional = append(positional, param)
+			} else {
+				named = append(named, param)
+			}
+		}
+	}
+
+	slices.SortFunc(positional, func(a aliasParam, b aliasParam) int { return a.index - b.index })
+
+	for i, param := range positional {
+		if param.index != i {
+			return nil, fmt.Errorf(i18n.G("Missing positional parameter {%d}"), i)
+		}
+	}
+
+	return append(positional, named...), nil
+}
+
+// validateAliasTarget checks the target of an alias, including its parameters and command sequence.
+func validateAliasTarget(target string) error {
+	fields, err := shellquote.Split(target)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Invalid alias target %q: %w"), target, err)
+	}
+
+	if len(fields) == 0 {
+		return errors.New(i18n.G("Alias target can't be empty"))
+	}
+
+	// Check the command sequence.
+	for i, field := range fields {
+		if field == aliasCommandSeparator && (i == 0 || i == len(fields)-1 || fields[i-1] == aliasCommandSeparator) {
+			return fmt.Errorf(i18n.G("Empty command in alias target %q"), target)
+		}
+	}
+
+	// Check the parameters.
+	params, err := parseAliasParams(fields)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		if strings.ContainsAny(aliasParamRegex.ReplaceAllString(field, ""), "{}") {
+			return fmt.Errorf(i18n.G("Invalid parameter in %q"), field)
+		}
+
+		if len(params) > 0 && numberedArgRegex.MatchString(field) {
+			return errors.New(i18n.G("Alias parameters can't be combined with @ARG<n>@"))
+		}
+	}
+
+	return nil
+}
+
+// expandAliasParams replaces the parameters of the alias by their values, returning the arguments left.
+//
+// Named parameters are given as "name=value" arguments, and positional ones take the other arguments in order.
+// When completing, the missing values are left empty.
+func expandAliasParams(aliasName string, fields []string, args []string, completion bool) ([]string, []string, error) {
+	params, err := parseAliasParams(fields)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(params) == 0 {
+		return fields, args, nil
+	}
+
+	named := map[string]bool{}
+	for _, param := range params {
+		if param.index < 0 {
+			named[param.name] = true
+		}
+	}
+
+	// Pick the named parameters.
+	values := map[string]string{}
+	remaining := []string{}
+	for _, arg := range args {
+		key, value, found := strings.Cut(arg, "=")
+		_, set := values[key]
+		if found && named[key] && !set {
+			values[key] = value
+			continue
+		}
+
+		remaining = append(remaining, arg)
+	}
+
+	// Fill in the positional parameters, then the defaults.
+	for _, param := range params {
+		if param.index >= 0 && len(remaining) > 0 {
+			values[param.name] = remaining[0]
+			remaining = remaining[1:]
+		}
+
+		_, ok := values[param.name]
+		if ok {
+			continue
+		}
+
+		if param.hasDefault {
+			values[param.name] = param.defaultValue
+		} else if !completion {
+			return nil, nil, fmt.Errorf(i18n.G("Missing value for parameter %q of alias %q"), param.name, aliasName)
+		}
+	}
+
+	expanded := make([]string, 0, len(fields))
+	for _, field := range fields {
+		expanded = append(expanded, aliasParamRegex.ReplaceAllStringFunc(field, func(match string) string {
+			if strings.HasPrefix(match, "$") {
+				return match
+			}
+
+			return values[aliasParamRegex.FindStringSubmatch(match)[1]]
+		}))
+	}
+
+	return expanded, remaining, nil
+}
+
 func expandAlias(conf *config.Config, args []string, app *cobra.Command) ([]string, bool, error) {
 	fset := app.Flags()
 
@@ -97,6 +290,7 @@ func expandAlias(conf *config.Config, args []string, app *cobra.Command) ([]stri
 
 	// newArgs contains all the flags before the first positional argument
 	newArgs := args[firstArgIndex:lastFlagIndex]
+	flags := slices.Clone(newArgs)
 
 	// origArgs contains everything except the flags in newArgs
 	origArgs := slices.Concat(args[:firstArgIndex], args[lastFlagIndex:])
@@ -128,6 +322,12 @@ func expandAlias(conf *config.Config, args []string, app *cobra.Command) ([]stri
 		atArgs = origArgs[len(aliasKey)+1:]
 	}
 
+	// Fill in the parameters ({0}, {name}), the arguments left being handled as usual.
+	aliasValue, atArgs, err := expandAliasParams(strings.Join(aliasKey, " "), aliasValue, atArgs, completion)
+	if err != nil {
+		return nil, false, err
+	}
+
 	// Find the arguments that have been referenced directly e.g. @ARG1@.
 	numberedArgsMap := map[int]string{}
 	for _, aliasArg := range aliasValue {
@@ -161,7 +361,23 @@ func expandAlias(conf *config.Config, args []string, app *cobra.Command) ([]stri
 
 	// Replace arguments
 	hasReplacedArgsVar := false
-	for _, aliasArg := range aliasValue {
+	for i, aliasArg := range aliasValue {
+		// Start the next command of the sequence, with the same flags
+		if aliasArg == aliasCommandSeparator {
+			// if completing we only complete the first command
+			if completion {
+				break
+			}
+
+			newArgs = append(newArgs, aliasCommandSeparator)
+			if i+1 < len(aliasValue) && !strings.HasPrefix(aliasValue[i+1], "/") {
+				newArgs = append(newArgs, origArgs[0])
+				newArgs = append(newArgs, flags...)
+			}
+
+			continue
+		}
+
 		// Only replace all @ARGS@ when it is not part of another string
 		if aliasArg == "@ARGS@" {
 			// if completing we want to stop on @ARGS@ and append the completion below
@@ -229,6 +445,21 @@ func execIfAliases(app *cobra.Command) error {
 		return nil
 	}
 
+	environ := getEnviron()
+	environ = append(environ, "INCUS_ALIASES=1")
+
+	// Run the commands of a sequence one after the other, stopping at the first failure
+	if slices.Contains(newArgs, aliasCommandSeparator) {
+		for _, command := range splitAliasCommands(newArgs) {
+			err := runAliasCommand(command, environ)
+			if err != nil {
+				return fmt.Errorf(i18n.G("Processing aliases failed: %s"), err)
+			}
+		}
+
+		os.Exit(0)
+	}
+
 	// Look for the executable
 	path, err := exec.LookPath(newArgs[0])
 	if err != nil {
@@ -236,8 +467,46 @@ func execIfAliases(app *cobra.Command) error {
 	}
 
 	// Re-exec
-	environ := getEnviron()
-	environ = append(environ, "INCUS_ALIASES=1")
 	ret := doExec(path, newArgs, environ)
 	return fmt.Errorf(i18n.G("Processing aliases failed: %s"), ret)
 }
+
+// splitAliasCommands splits the expanded arguments of an alias into its sequence of commands.
+func splitAliasCommands(args []string) [][]string {
+	commands := [][]string{}
+	command := []string{}
+
+	for _, arg := range args {
+		if arg == aliasCommandSeparator {
+			commands = append(commands, command)
+			command = []string{}
+			continue
+		}
+
+		command = append(command, arg)
+	}
+
+	return append(commands, command)
+}
+
+// runAliasCommand runs one of the commands of an alias sequence, exiting with its status if it fails
+// as the command already reported its error.
+func runAliasCommand(command []string, environ []string) error {
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Env = environ
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+	if err != nil {
+		exitErr := &exec.ExitError{}
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+
+		return err
+	}
+
+	return nil
+}
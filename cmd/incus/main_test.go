@@ -45,6 +45,10 @@ func TestExpandAliases(t *testing.T) {
 		"fizz":                     "exec @ARG1@ -- echo @ARG2@",
 		"snaps":                    "query /1.0/instances/@ARG1@/snapshots",
 		"snapshots with recursion": "query /1.0/instances/@ARG1@/snapshots?recursion=@ARG2@",
+		"redeploy":                 "delete -f {0} && launch {1} {0}",
+		"cpus":                     "config set {0} limits.cpu={count:1}",
+		"home":                     "exec {0} -- sh -c 'echo ${HOME} && cd ${DIR:-/root} && ls {path:.}'",
+		"env":                      "exec c1 -- sh -c 'echo ${HOME}'",
 	}
 
 	testcases := []aliasTestcase{
@@ -92,6 +96,34 @@ func TestExpandAliases(t *testing.T) {
 			input:    []string{"incus", "--project=default", "fizz", "c1", "buzz"},
 			expected: []string{"incus", "--project=default", "exec", "c1", "--", "echo", "buzz"},
 		},
+		{
+			input:    []string{"incus", "--project", "foo", "redeploy", "c1", "images:debian/12", "-s", "pool1"},
+			expected: []string{"incus", "--project", "foo", "delete", "-f", "c1", "&&", "incus", "--project", "foo", "launch", "images:debian/12", "c1", "-s", "pool1"},
+		},
+		{
+			input:     []string{"incus", "redeploy", "c1"},
+			expectErr: true,
+		},
+		{
+			input:    []string{"incus", "cpus", "count=4", "c1"},
+			expected: []string{"incus", "config", "set", "c1", "limits.cpu=4"},
+		},
+		{
+			input:    []string{"incus", "cpus", "c1"},
+			expected: []string{"incus", "config", "set", "c1", "limits.cpu=1"},
+		},
+		{
+			input:    []string{"incus", "home", "c1"},
+			expected: []string{"incus", "exec", "c1", "--", "sh", "-c", "echo ${HOME} && cd ${DIR:-/root} && ls ."},
+		},
+		{
+			input:    []string{"incus", "home", "path=/tmp", "c1"},
+			expected: []string{"incus", "exec", "c1", "--", "sh", "-c", "echo ${HOME} && cd ${DIR:-/root} && ls /tmp"},
+		},
+		{
+			input:    []string{"incus", "env"},
+			expected: []string{"incus", "exec", "c1", "--", "sh", "-c", "echo ${HOME}"},
+		},
 	}
 
 	conf := &config.Config{Aliases: aliases}
@@ -118,3 +150,40 @@ func TestExpandAliases(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateAliasTarget(t *testing.T) {
+	valid := []string{
+		"list -c ns46S",
+		"exec @ARG1@ -- echo @ARG2@",
+		"delete -f {0} && launch {1} {0}",
+		"config set {0} limits.cpu={count:1} limits.memory={memory:1GiB}",
+		"exec c1 -- sh -c 'echo ${HOME}'",
+		"exec {0} -- sh -c 'cd ${DIR:-/root} && echo ${PATH//:/ }'",
+	}
+
+	for _, target := range valid {
+		assert.NoError(t, validateAliasTarget(target), target)
+	}
+
+	invalid := []string{
+		"",
+		"list 'foo",
+		"&& list",
+		"delete {0} && && launch {0}",
+		"delete {1}",
+		"config set {0} limits.cpu={count:1} && config get {0} {count:2}",
+		"exec {0} -- echo {",
+		"exec {0} -- echo @ARG2@",
+	}
+
+	for _, target := range invalid {
+		assert.Error(t, validateAliasTarget(target), target)
+	}
+}
+
+func TestAliasParamsColumn(t *testing.T) {
+	assert.Equal(t, "", aliasParamsColumn("list -c ns46S"))
+	assert.Equal(t, "<0> <1>", aliasParamsColumn("delete -f {0} && launch {1} {0}"))
+	assert.Equal(t, "<0> [count=1] memory=<value>", aliasParamsColumn("config set {0} limits.cpu={count:1} limits.memory={memory}"))
+	assert.Equal(t, "<0>", aliasParamsColumn("exec {0} -- sh -c 'echo ${HOME} ${DIR:-/root}'"))
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"slices"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -96,20 +97,51 @@ func (c *cmdMonitor) Run(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	d, err := conf.GetInstanceServer(remote)
-	if err != nil {
-		return err
-	}
+	// With a remote group, monitor all of its remotes and annotate the events with their origin.
+	group := conf.IsRemoteGroup(remote)
 
-	var listener *incus.EventListener
-	if c.flagAllProjects {
-		listener, err = d.GetEventsAllProjects()
+	var servers map[string]incus.InstanceServer
+	if group {
+		servers, err = conf.GetInstanceServers(remote)
+		if err != nil {
+			if len(servers) == 0 {
+				return err
+			}
+
+			fmt.Fprintln(os.Stderr, err)
+		}
 	} else {
-		listener, err = d.GetEvents()
+		d, err := conf.GetInstanceServer(remote)
+		if err != nil {
+			return err
+		}
+
+		servers = map[string]incus.InstanceServer{remote: d}
 	}
 
-	if err != nil {
-		return err
+	listeners := map[string]*incus.EventListener{}
+	for name, d := range servers {
+		var listener *incus.EventListener
+		if c.flagAllProjects {
+			listener, err = d.GetEventsAllProjects()
+		} else {
+			listener, err = d.GetEvents()
+		}
+
+		if err != nil {
+			if !group {
+				return err
+			}
+
+			fmt.Fprintf(os.Stderr, i18n.G("Failed getting events from remote %q: %v")+"\n", name, err)
+			continue
+		}
+
+		listeners[name] = listener
+	}
+
+	if len(listeners) == 0 {
+		return errors.New(i18n.G("Couldn't get events from any of the remotes"))
 	}
 
 	logLevel := logrus.DebugLevel
@@ -120,106 +152,127 @@ func (c *cmdMonitor) Run(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	chError := make(chan error, 1)
-
-	handler := func(event api.Event) {
-		if c.flagFormat == "pretty" {
-			// Parse the event.
-			record, err := event.ToLogging()
-			if err != nil {
-				chError <- err
+	chError := make(chan error, len(listeners))
+	outputLock := sync.Mutex{}
+
+	newHandler := func(name string, clustered bool) func(event api.Event) {
+		return func(event api.Event) {
+			outputLock.Lock()
+			defer outputLock.Unlock()
+
+			if c.flagFormat == "pretty" {
+				// Parse the event.
+				record, err := event.ToLogging()
+				if err != nil {
+					chError <- err
+					return
+				}
+
+				if record.Lvl == "dbug" {
+					record.Lvl = "debug"
+				}
+
+				// Get the log level.
+				msgLevel, err := logrus.ParseLevel(record.Lvl)
+				if err != nil {
+					chError <- err
+					return
+				}
+
+				// Check log level.
+				if msgLevel > logLevel {
+					return
+				}
+
+				// Setup logrus.
+				logger := &logrus.Logger{
+					Out: os.Stdout,
+				}
+
+				entry := &logrus.Entry{Logger: logger}
+				entry.Data = c.unpackCtx(record.Ctx)
+
+				if event.Type == "logging" && clustered {
+					entry.Message = fmt.Sprintf("[%s] %s", event.Location, record.Msg)
+				} else {
+					entry.Message = record.Msg
+				}
+
+				if group {
+					entry.Message = fmt.Sprintf("[%s] %s", name, entry.Message)
+				}
+
+				entry.Time = record.Time
+				entry.Level = msgLevel
+				format := logrus.TextFormatter{FullTimestamp: true, PadLevelText: true}
+
+				line, err := format.Format(entry)
+				if err != nil {
+					chError <- err
+					return
+				}
+
+				fmt.Print(string(line))
 				return
 			}
 
-			if record.Lvl == "dbug" {
-				record.Lvl = "debug"
-			}
-
-			// Get the log level.
-			msgLevel, err := logrus.ParseLevel(record.Lvl)
+			// Render as JSON (to expand RawMessage)
+			jsonRender, err := json.Marshal(&event)
 			if err != nil {
 				chError <- err
 				return
 			}
 
-			// Check log level.
-			if msgLevel > logLevel {
+			// Read back to a clean interface
+			var rawEvent map[string]any
+			err = json.Unmarshal(jsonRender, &rawEvent)
+			if err != nil {
+				chError <- err
 				return
 			}
 
-			// Setup logrus.
-			logger := &logrus.Logger{
-				Out: os.Stdout,
+			if group {
+				rawEvent["remote"] = name
 			}
 
-			entry := &logrus.Entry{Logger: logger}
-			entry.Data = c.unpackCtx(record.Ctx)
-
-			if event.Type == "logging" && d.IsClustered() {
-				entry.Message = fmt.Sprintf("[%s] %s", event.Location, record.Msg)
-			} else {
-				entry.Message = record.Msg
-			}
-
-			entry.Time = record.Time
-			entry.Level = msgLevel
-			format := logrus.TextFormatter{FullTimestamp: true, PadLevelText: true}
-
-			line, err := format.Format(entry)
-			if err != nil {
-				chError <- err
-				return
+			// And now print the result.
+			var render []byte
+			switch c.flagFormat {
+			case "yaml":
+				render, err = yaml.Marshal(&rawEvent)
+				if err != nil {
+					chError <- err
+					return
+				}
+
+			case "json":
+				render, err = json.Marshal(&rawEvent)
+				if err != nil {
+					chError <- err
+					return
+				}
 			}
 
-			fmt.Print(string(line))
-			return
-		}
-
-		// Render as JSON (to expand RawMessage)
-		jsonRender, err := json.Marshal(&event)
-		if err != nil {
-			chError <- err
-			return
+			fmt.Printf("%s\n\n", render)
 		}
+	}
 
-		// Read back to a clean interface
-		var rawEvent any
-		err = json.Unmarshal(jsonRender, &rawEvent)
+	for name, listener := range listeners {
+		_, err = listener.AddHandler(c.flagType, newHandler(name, servers[name].IsClustered()))
 		if err != nil {
-			chError <- err
-			return
+			return err
 		}
 
-		// And now print the result.
-		var render []byte
-		switch c.flagFormat {
-		case "yaml":
-			render, err = yaml.Marshal(&rawEvent)
-			if err != nil {
-				chError <- err
-				return
-			}
-
-		case "json":
-			render, err = json.Marshal(&rawEvent)
-			if err != nil {
-				chError <- err
-				return
+		go func() {
+			err := listener.Wait()
+			if group && err != nil {
+				err = fmt.Errorf(i18n.G("Lost the events from remote %q: %w"), name, err)
 			}
-		}
-
-		fmt.Printf("%s\n\n", render)
-	}
 
-	_, err = listener.AddHandler(c.flagType, handler)
-	if err != nil {
-		return err
+			chError <- err
+		}()
 	}
 
-	go func() {
-		chError <- listener.Wait()
-	}()
-
 	return <-chError
 }
 
@@ -88,6 +88,10 @@ func (c *cmdNetwork) Command() *cobra.Command {
 	networkListLeasesCmd := cmdNetworkListLeases{global: c.global, network: c}
 	cmd.AddCommand(networkListLeasesCmd.Command())
 
+	// Map
+	networkMapCmd := cmdNetworkMap{global: c.global, network: c}
+	cmd.AddCommand(networkMapCmd.Command())
+
 	// Rename
 	networkRenameCmd := cmdNetworkRename{global: c.global, network: c}
 	cmd.AddCommand(networkRenameCmd.Command())
@@ -112,6 +116,10 @@ func (c *cmdNetwork) Command() *cobra.Command {
 	networkAddressSetCmd := cmdNetworkAddressSet{global: c.global}
 	cmd.AddCommand(networkAddressSetCmd.Command())
 
+	// Firewall
+	networkFirewallCmd := cmdNetworkFirewall{global: c.global}
+	cmd.AddCommand(networkFirewallCmd.Command())
+
 	// Forward
 	networkForwardCmd := cmdNetworkForward{global: c.global}
 	cmd.AddCommand(networkForwardCmd.Command())
@@ -142,6 +150,8 @@ func (c *cmdNetwork) Command() *cobra.Command {
 type cmdNetworkAttach struct {
 	global  *cmdGlobal
 	network *cmdNetwork
+
+	flagCheck bool
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -152,6 +162,7 @@ func (c *cmdNetworkAttach) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Attach new network interfaces to instances`))
 
+	cmd.Flags().BoolVar(&c.flagCheck, "check", false, i18n.G("Only report the effect of the change on the project resources"))
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -229,7 +240,7 @@ func (c *cmdNetworkAttach) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Add the device to the instance
-	err = instanceDeviceAdd(resource.server, args[1], devName, device)
+	err = instanceDeviceAdd(resource.server, args[1], devName, device, c.flagCheck)
 	if err != nil {
 		return err
 	}
@@ -829,6 +840,12 @@ func (c *cmdNetworkEdit) Run(cmd *cobra.Command, args []string) error {
 
 		// Respawn the editor
 		if err != nil {
+			// Editing again won't help when another client modified the object in the meantime.
+			conflictErr := checkEditConflict(err)
+			if conflictErr != nil {
+				return conflictErr
+			}
+
 			fmt.Fprintf(os.Stderr, i18n.G("Config parsing error: %s")+"\n", err)
 			fmt.Println(i18n.G("Press enter to open the editor again or ctrl+c to abort change"))
 
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+)
+
+// networkFirewallChains lists the user chains that rules can be added to.
+var networkFirewallChains = []string{"input", "forward", "output"}
+
+type cmdNetworkFirewall struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdNetworkFirewall) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("firewall")
+	cmd.Short = i18n.G("Manage user-defined network firewall rules")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Manage user-defined network firewall rules
+
+Rules are stored in the network's raw.nftables.input, raw.nftables.forward
+and raw.nftables.output configuration keys and are re-applied whenever the
+network is started or reconfigured.`))
+
+	// Show.
+	networkFirewallShowCmd := cmdNetworkFirewallShow{global: c.global, networkFirewall: c}
+	cmd.AddCommand(networkFirewallShowCmd.Command())
+
+	// Add rule.
+	networkFirewallAddRuleCmd := cmdNetworkFirewallAddRule{global: c.global, networkFirewall: c}
+	cmd.AddCommand(networkFirewallAddRuleCmd.Command())
+
+	// Remove rule.
+	networkFirewallRemoveRuleCmd := cmdNetworkFirewallRemoveRule{global: c.global, networkFirewall: c}
+	cmd.AddCommand(networkFirewallRemoveRuleCmd.Command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, _ []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// networkFirewallIsRule returns whether a line of a raw.nftables.* config value is a rule.
+// Like on the server, the empty lines and the comments are skipped.
+func networkFirewallIsRule(line string) bool {
+	line = strings.TrimSpace(line)
+
+	return line != "" && !strings.HasPrefix(line, "#")
+}
+
+// networkFirewallRules splits a raw.nftables.* config value into its rules.
+func networkFirewallRules(value string) []string {
+	rules := []string{}
+
+	for _, line := range strings.Split(value, "\n") {
+		if !networkFirewallIsRule(line) {
+			continue
+		}
+
+		rules = append(rules, strings.TrimSpace(line))
+	}
+
+	return rules
+}
+
+// networkFirewallRemoveRule removes the rules matching the given one or its index from a raw.nftables.*
+// config value, keeping the comments. It returns the new value and whether any rule was removed.
+func networkFirewallRemoveRule(value string, rule string) (string, bool) {
+	lines := []string{}
+	removed := false
+	index := 0
+
+	for _, line := range strings.Split(value, "\n") {
+		if !networkFirewallIsRule(line) {
+			if strings.TrimSpace(line) != "" {
+				lines = append(lines, line)
+			}
+
+			continue
+		}
+
+		existing := strings.TrimSpace(line)
+		if existing == rule || fmt.Sprintf("%d", index) == rule {
+			removed = true
+		} else {
+			lines = append(lines, line)
+		}
+
+		index++
+	}
+
+	return strings.Join(lines, "\n"), removed
+}
+
+// networkFirewallChainKey validates the chain name and returns its config key.
+func networkFirewallChainKey(chain string) (string, error) {
+	if !slices.Contains(networkFirewallChains, chain) {
+		return "", fmt.Errorf(i18n.G("Invalid chain %q (must be one of %s)"), chain, strings.Join(networkFirewallChains, ", "))
+	}
+
+	return fmt.Sprintf("raw.nftables.%s", chain), nil
+}
+
+// Show.
+type cmdNetworkFirewallShow struct {
+	global          *cmdGlobal
+	networkFirewall *cmdNetworkFirewall
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdNetworkFirewallShow) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("show", i18n.G("[<remote>:]<network>"))
+	cmd.Short = i18n.G("Show user-defined network firewall rules")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Show user-defined network firewall rules`))
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpNetworks(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdNetworkFirewallShow) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing network name"))
+	}
+
+	network, _, err := resource.server.GetNetwork(resource.name)
+	if err != nil {
+		return err
+	}
+
+	for _, chain := range networkFirewallChains {
+		key, _ := networkFirewallChainKey(chain)
+
+		fmt.Printf("%s:\n", chain)
+		for i, rule := range networkFirewallRules(network.Config[key]) {
+			fmt.Printf("  %d: %s\n", i, rule)
+		}
+	}
+
+	return nil
+}
+
+// Add rule.
+type cmdNetworkFirewallAddRule struct {
+	global          *cmdGlobal
+	networkFirewall *cmdNetworkFirewall
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdNetworkFirewallAddRule) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("add-rule", i18n.G("[<remote>:]<network> <chain> <rule>..."))
+	cmd.Short = i18n.G("Add a user-defined network firewall rule")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Add a user-defined network firewall rule
+
+The chain must be one of input, forward or output and the rule is
+passed as-is to nftables.`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus network firewall add-rule incusbr0 input tcp dport 22 drop
+    Drop SSH connections from instances on incusbr0 to the host.`))
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpNetworks(toComplete)
+		}
+
+		if len(args) == 1 {
+			return networkFirewallChains, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdNetworkFirewallAddRule) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 3, -1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing network name"))
+	}
+
+	key, err := networkFirewallChainKey(args[1])
+	if err != nil {
+		return err
+	}
+
+	rule := strings.Join(args[2:], " ")
+
+	network, etag, err := resource.server.GetNetwork(resource.name)
+	if err != nil {
+		return err
+	}
+
+	rules := networkFirewallRules(network.Config[key])
+	if slices.Contains(rules, rule) {
+		return errors.New(i18n.G("Rule already exists"))
+	}
+
+	// Append the rule, keeping the existing comments.
+	value := strings.TrimRight(network.Config[key], "\n")
+	if value != "" {
+		value += "\n"
+	}
+
+	network.Config[key] = value + rule
+
+	return resource.server.UpdateNetwork(resource.name, network.Writable(), etag)
+}
+
+// Remove rule.
+type cmdNetworkFirewallRemoveRule struct {
+	global          *cmdGlobal
+	networkFirewall *cmdNetworkFirewall
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdNetworkFirewallRemoveRule) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("remove-rule", i18n.G("[<remote>:]<network> <chain> <rule>..."))
+	cmd.Aliases = []string{"delete-rule"}
+	cmd.Short = i18n.G("Remove a user-defined network firewall rule")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Remove a user-defined network firewall rule
+
+The rule can be given either verbatim or by its index as shown by
+"incus network firewall show".`))
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpNetworks(toComplete)
+		}
+
+		if len(args) == 1 {
+			return networkFirewallChains, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdNetworkFirewallRemoveRule) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 3, -1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing network name"))
+	}
+
+	key, err := networkFirewallChainKey(args[1])
+	if err != nil {
+		return err
+	}
+
+	rule := strings.Join(args[2:], " ")
+
+	network, etag, err := resource.server.GetNetwork(resource.name)
+	if err != nil {
+		return err
+	}
+
+	value, removed := networkFirewallRemoveRule(network.Config[key], rule)
+	if !removed {
+		return errors.New(i18n.G("No matching rule found"))
+	}
+
+	if value == "" {
+		delete(network.Config, key)
+	} else {
+		network.Config[key] = value
+	}
+
+	return resource.server.UpdateNetwork(resource.name, network.Writable(), etag)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkFirewallRules(t *testing.T) {
+	value := "# Allow SSH\n  tcp dport 22 accept\n\n  # Allow DNS\nudp dport 53 accept\n"
+
+	assert.Equal(t, []string{"tcp dport 22 accept", "udp dport 53 accept"}, networkFirewallRules(value))
+	assert.Equal(t, []string{}, networkFirewallRules(""))
+	assert.Equal(t, []string{}, networkFirewallRules("# Only a comment"))
+}
+
+func TestNetworkFirewallRemoveRule(t *testing.T) {
+	value := "# Allow SSH\ntcp dport 22 accept\n# Allow DNS\nudp dport 53 accept"
+
+	// By rule, keeping the comments.
+	newValue, removed := networkFirewallRemoveRule(value, "tcp dport 22 accept")
+	assert.True(t, removed)
+	assert.Equal(t, "# Allow SSH\n# Allow DNS\nudp dport 53 accept", newValue)
+
+	// By index, the comments not being counted.
+	newValue, removed = networkFirewallRemoveRule(value, "1")
+	assert.True(t, removed)
+	assert.Equal(t, "# Allow SSH\ntcp dport 22 accept\n# Allow DNS", newValue)
+
+	// Comments aren't rules.
+	newValue, removed = networkFirewallRemoveRule(value, "# Allow SSH")
+	assert.False(t, removed)
+	assert.Equal(t, value, newValue)
+}
@@ -0,0 +1,419 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	incus "github.com/lxc/incus/v6/client"
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// networkMapNode represents an object in the network topology.
+type networkMapNode struct {
+	ID       string            `json:"id" yaml:"id"`
+	Type     string            `json:"type" yaml:"type"`
+	Name     string            `json:"name" yaml:"name"`
+	Project  string            `json:"project,omitempty" yaml:"project,omitempty"`
+	Location string            `json:"location,omitempty" yaml:"location,omitempty"`
+	Config   map[string]string `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// networkMapEdge represents a relationship between two objects in the network topology.
+type networkMapEdge struct {
+	Source string `json:"source" yaml:"source"`
+	Target string `json:"target" yaml:"target"`
+	Type   string `json:"type" yaml:"type"`
+	Label  string `json:"label,omitempty" yaml:"label,omitempty"`
+}
+
+// networkMap represents the network topology as a graph.
+type networkMap struct {
+	Nodes []networkMapNode `json:"nodes" yaml:"nodes"`
+	Edges []networkMapEdge `json:"edges" yaml:"edges"`
+}
+
+// addNode adds a node to the map if not already present and returns its ID.
+func (m *networkMap) addNode(node networkMapNode) string {
+	for _, existing := range m.Nodes {
+		if existing.ID == node.ID {
+			return node.ID
+		}
+	}
+
+	m.Nodes = append(m.Nodes, node)
+
+	return node.ID
+}
+
+// addEdge adds an edge to the map.
+func (m *networkMap) addEdge(source string, target string, edgeType string, label string) {
+	m.Edges = append(m.Edges, networkMapEdge{Source: source, Target: target, Type: edgeType, Label: label})
+}
+
+// dot renders the map in the graphviz DOT language.
+func (m *networkMap) dot() string {
+	shapes := map[string]string{
+		"network":       "ellipse",
+		"instance":      "box",
+		"forward":       "diamond",
+		"load-balancer": "diamond",
+		"peer":          "hexagon",
+		"member":        "box3d",
+	}
+
+	sb := &strings.Builder{}
+	sb.WriteString("digraph incus {\n")
+
+	for _, node := range m.Nodes {
+		label := node.Name
+		if node.Project != "" && node.Project != api.ProjectDefaultName {
+			label = fmt.Sprintf("%s/%s", node.Project, node.Name)
+		}
+
+		fmt.Fprintf(sb, "\t%q [label=%q, shape=%q];\n", node.ID, fmt.Sprintf("%s\n(%s)", label, node.Type), shapes[node.Type])
+	}
+
+	for _, edge := range m.Edges {
+		label := edge.Type
+		if edge.Label != "" {
+			label = fmt.Sprintf("%s: %s", edge.Type, edge.Label)
+		}
+
+		fmt.Fprintf(sb, "\t%q -> %q [label=%q];\n", edge.Source, edge.Target, label)
+	}
+
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+type cmdNetworkMap struct {
+	global  *cmdGlobal
+	network *cmdNetwork
+
+	flagFormat      string
+	flagAllProjects bool
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdNetworkMap) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("map", i18n.G("[<remote>:]"))
+	cmd.Short = i18n.G("Show the network topology")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Show the network topology
+
+The topology includes the networks, their uplinks, peers, forwards and
+load balancers, the instance NICs attached to them and the cluster
+members they're present on.
+
+The dot format can be rendered with graphviz, for example:
+    incus network map --format=dot | dot -Tsvg > network.svg`))
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (dot|json|yaml)")+"``")
+	cmd.Flags().BoolVar(&c.flagAllProjects, "all-projects", false, i18n.G("Show the topology of all projects"))
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(toComplete, false)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdNetworkMap) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	if !slices.Contains([]string{"dot", "json", "yaml"}, c.flagFormat) {
+		return fmt.Errorf(i18n.G("Invalid format %q"), c.flagFormat)
+	}
+
+	// Parse remote.
+	remote := ""
+	if len(args) > 0 {
+		remote = args[0]
+	}
+
+	resources, err := c.global.parseServers(remote)
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name != "" {
+		return errors.New(i18n.G("Filtering isn't supported yet"))
+	}
+
+	topology, err := c.buildMap(resource.server)
+	if err != nil {
+		return err
+	}
+
+	switch c.flagFormat {
+	case "dot":
+		fmt.Print(topology.dot())
+	case "json":
+		data, err := json.MarshalIndent(topology, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(data))
+	case "yaml":
+		data, err := yaml.Marshal(topology)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(string(data))
+	}
+
+	return nil
+}
+
+// buildMap collects the network topology from the server.
+func (c *cmdNetworkMap) buildMap(server incus.InstanceServer) (*networkMap, error) {
+	topology := &networkMap{Nodes: []networkMapNode{}, Edges: []networkMapEdge{}}
+
+	var networks []api.Network
+	var instances []api.InstanceFull
+	var err error
+
+	if c.flagAllProjects {
+		networks, err = server.GetNetworksAllProjects()
+		if err != nil {
+			return nil, err
+		}
+
+		instances, err = server.GetInstancesFullAllProjects(api.InstanceTypeAny)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		networks, err = server.GetNetworks()
+		if err != nil {
+			return nil, err
+		}
+
+		instances, err = server.GetInstancesFull(api.InstanceTypeAny)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i].Project+"/"+networks[i].Name < networks[j].Project+"/"+networks[j].Name
+	})
+
+	networkID := func(projectName string, name string) string {
+		if projectName == "" {
+			projectName = api.ProjectDefaultName
+		}
+
+		return fmt.Sprintf("network/%s/%s", projectName, name)
+	}
+
+	// Track instance addresses so that forward and load balancer targets can be resolved.
+	instanceAddresses := map[string]string{}
+
+	for _, inst := range instances {
+		id := topology.addNode(networkMapNode{
+			ID:       fmt.Sprintf("instance/%s/%s", inst.Project, inst.Name),
+			Type:     "instance",
+			Name:     inst.Name,
+			Project:  inst.Project,
+			Location: inst.Location,
+		})
+
+		if inst.State != nil {
+			for _, nic := range inst.State.Network {
+				for _, addr := range nic.Addresses {
+					if addr.Scope == "global" {
+						instanceAddresses[fmt.Sprintf("%s/%s", inst.Project, addr.Address)] = id
+					}
+				}
+			}
+		}
+
+		if inst.Location != "" && inst.Location != "none" {
+			memberID := topology.addNode(networkMapNode{ID: fmt.Sprintf("member/%s", inst.Location), Type: "member", Name: inst.Location})
+			topology.addEdge(id, memberID, "location", "")
+		}
+
+		deviceNames := make([]string, 0, len(inst.ExpandedDevices))
+		for name := range inst.ExpandedDevices {
+			deviceNames = append(deviceNames, name)
+		}
+
+		sort.Strings(deviceNames)
+
+		for _, devName := range deviceNames {
+			dev := inst.ExpandedDevices[devName]
+			if dev["type"] != "nic" {
+				continue
+			}
+
+			netName := dev["network"]
+			if netName == "" {
+				netName = dev["parent"]
+			}
+
+			if netName == "" {
+				continue
+			}
+
+			// Managed networks live in the instance's project unless the project doesn't have its own networks.
+			target := networkID(inst.Project, netName)
+			found := false
+			for _, n := range networks {
+				if n.Name == netName && (n.Project == inst.Project || n.Project == "") {
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				target = networkID(api.ProjectDefaultName, netName)
+			}
+
+			topology.addEdge(id, target, "nic", devName)
+		}
+	}
+
+	for _, n := range networks {
+		id := topology.addNode(networkMapNode{
+			ID:      networkID(n.Project, n.Name),
+			Type:    "network",
+			Name:    n.Name,
+			Project: n.Project,
+			Config:  map[string]string{"type": n.Type, "managed": fmt.Sprintf("%t", n.Managed)},
+		})
+
+		for _, location := range n.Locations {
+			if location == "none" {
+				continue
+			}
+
+			memberID := topology.addNode(networkMapNode{ID: fmt.Sprintf("member/%s", location), Type: "member", Name: location})
+			topology.addEdge(id, memberID, "location", "")
+		}
+
+		if !n.Managed {
+			continue
+		}
+
+		// Uplinks are always in the default project.
+		if n.Type == "ovn" && n.Config["network"] != "" {
+			topology.addEdge(id, networkID(api.ProjectDefaultName, n.Config["network"]), "uplink", "")
+		}
+
+		client := server
+		if n.Project != "" {
+			client = server.UseProject(n.Project)
+		}
+
+		if n.Type == "ovn" {
+			peers, err := client.GetNetworkPeers(n.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, peer := range peers {
+				if peer.TargetNetwork != "" {
+					topology.addEdge(id, networkID(peer.TargetProject, peer.TargetNetwork), "peer", fmt.Sprintf("%s (%s)", peer.Name, peer.Status))
+					continue
+				}
+
+				peerID := topology.addNode(networkMapNode{ID: fmt.Sprintf("peer/%s/%s/%s", n.Project, n.Name, peer.Name), Type: "peer", Name: peer.Name, Project: n.Project, Config: map[string]string{"integration": peer.TargetIntegration}})
+				topology.addEdge(id, peerID, "peer", peer.Status)
+			}
+		}
+
+		if n.Type != "ovn" && n.Type != "bridge" {
+			continue
+		}
+
+		forwards, err := client.GetNetworkForwards(n.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, forward := range forwards {
+			forwardID := topology.addNode(networkMapNode{ID: fmt.Sprintf("forward/%s/%s/%s", n.Project, n.Name, forward.ListenAddress), Type: "forward", Name: forward.ListenAddress, Project: n.Project, Location: forward.Location})
+			topology.addEdge(forwardID, id, "forward", "")
+
+			targets := []string{forward.Config["target_address"]}
+			for _, port := range forward.Ports {
+				targets = append(targets, port.TargetAddress)
+			}
+
+			c.addTargetEdges(topology, forwardID, n.Project, targets, instanceAddresses)
+		}
+
+		if n.Type != "ovn" {
+			continue
+		}
+
+		loadBalancers, err := client.GetNetworkLoadBalancers(n.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, lb := range loadBalancers {
+			lbID := topology.addNode(networkMapNode{ID: fmt.Sprintf("load-balancer/%s/%s/%s", n.Project, n.Name, lb.ListenAddress), Type: "load-balancer", Name: lb.ListenAddress, Project: n.Project, Location: lb.Location})
+			topology.addEdge(lbID, id, "load-balancer", "")
+
+			targets := make([]string, 0, len(lb.Backends))
+			for _, backend := range lb.Backends {
+				targets = append(targets, backend.TargetAddress)
+			}
+
+			c.addTargetEdges(topology, lbID, n.Project, targets, instanceAddresses)
+		}
+	}
+
+	return topology, nil
+}
+
+// addTargetEdges links a forward or load balancer to the instances owning its target addresses.
+func (c *cmdNetworkMap) addTargetEdges(topology *networkMap, sourceID string, projectName string, targets []string, instanceAddresses map[string]string) {
+	if projectName == "" {
+		projectName = api.ProjectDefaultName
+	}
+
+	seen := map[string]bool{}
+	for _, target := range targets {
+		if target == "" || seen[target] {
+			continue
+		}
+
+		seen[target] = true
+
+		instanceID, found := instanceAddresses[fmt.Sprintf("%s/%s", projectName, net.ParseIP(target).String())]
+		if !found {
+			continue
+		}
+
+		topology.addEdge(sourceID, instanceID, "target", target)
+	}
+}
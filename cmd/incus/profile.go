@@ -616,10 +616,21 @@ func (c *cmdProfileEdit) Run(cmd *cobra.Command, args []string) error {
 		err = yaml.Unmarshal(content, &newdata)
 		if err == nil {
 			err = resource.server.UpdateProfile(resource.name, newdata, etag)
+
+			// The change was saved but some instances couldn't apply it, editing again won't help.
+			if api.StatusErrorCheck(err, http.StatusFailedDependency) {
+				return err
+			}
 		}
 
 		// Respawn the editor
 		if err != nil {
+			// Editing again won't help when another client modified the object in the meantime.
+			conflictErr := checkEditConflict(err)
+			if conflictErr != nil {
+				return conflictErr
+			}
+
 			fmt.Fprintf(os.Stderr, i18n.G("Config parsing error: %s")+"\n", err)
 			fmt.Println(i18n.G("Press enter to open the editor again or ctrl+c to abort change"))
 
@@ -1160,6 +1171,8 @@ func (c *cmdProfileSet) Run(cmd *cobra.Command, args []string) error {
 type cmdProfileShow struct {
 	global  *cmdGlobal
 	profile *cmdProfile
+
+	flagExpanded bool
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -1170,6 +1183,8 @@ func (c *cmdProfileShow) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Show profile configurations`))
 
+	cmd.Flags().BoolVarP(&c.flagExpanded, "expanded", "e", false, i18n.G("Show the configuration merged with the profiles it extends"))
+
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -1209,6 +1224,15 @@ func (c *cmdProfileShow) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if c.flagExpanded && profile.Extends != "" {
+		profile.Config = profile.ExpandedConfig
+		profile.Devices = profile.ExpandedDevices
+	}
+
+	// The expanded configuration replaces the profile's own one when requested.
+	profile.ExpandedConfig = nil
+	profile.ExpandedDevices = nil
+
 	data, err := yaml.Marshal(&profile)
 	if err != nil {
 		return err
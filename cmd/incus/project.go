@@ -39,6 +39,10 @@ func (c *cmdProject) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Manage projects`))
 
+	// Audit
+	projectAuditCmd := cmdProjectAudit{global: c.global, project: c}
+	cmd.AddCommand(projectAuditCmd.Command())
+
 	// Create
 	projectCreateCmd := cmdProjectCreate{global: c.global, project: c}
 	cmd.AddCommand(projectCreateCmd.Command())
@@ -51,14 +55,26 @@ func (c *cmdProject) Command() *cobra.Command {
 	projectEditCmd := cmdProjectEdit{global: c.global, project: c}
 	cmd.AddCommand(projectEditCmd.Command())
 
+	// Export
+	projectExportCmd := cmdProjectExport{global: c.global, project: c}
+	cmd.AddCommand(projectExportCmd.Command())
+
 	// Get
 	projectGetCmd := cmdProjectGet{global: c.global, project: c}
 	cmd.AddCommand(projectGetCmd.Command())
 
+	// Import
+	projectImportCmd := cmdProjectImport{global: c.global, project: c}
+	cmd.AddCommand(projectImportCmd.Command())
+
 	// List
 	projectListCmd := cmdProjectList{global: c.global, project: c}
 	cmd.AddCommand(projectListCmd.Command())
 
+	// Remap
+	projectRemapCmd := cmdProjectRemap{global: c.global, project: c}
+	cmd.AddCommand(projectRemapCmd.Command())
+
 	// Rename
 	projectRenameCmd := cmdProjectRename{global: c.global, project: c}
 	cmd.AddCommand(projectRenameCmd.Command())
@@ -93,12 +109,175 @@ func (c *cmdProject) Command() *cobra.Command {
 	return cmd
 }
 
+// Audit.
+type cmdProjectAudit struct {
+	global  *cmdGlobal
+	project *cmdProject
+
+	flagFix    bool
+	flagFormat string
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdProjectAudit) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("audit", i18n.G("[<remote>:]<project>"))
+	cmd.Short = i18n.G("Check instances and profiles against the project restrictions")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Check instances and profiles against the project restrictions
+
+Reports the configuration keys and devices of the instances and profiles of a
+restricted project which violate its current restrictions, for example because
+they were set before the project was restricted.
+
+With --fix, the offending configuration keys are unset. Devices have to be
+changed manually.`))
+	cmd.Flags().BoolVar(&c.flagFix, "fix", false, i18n.G("Unset the configuration keys violating the restrictions"))
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", c.global.defaultListFormat(), i18n.G(`Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`)+"``")
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		return cli.ValidateFlagFormatForListOutput(cmd.Flag("format").Value.String())
+	}
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpProjects(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdProjectAudit) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing project name"))
+	}
+
+	violations, err := resource.server.GetProjectAudit(resource.name)
+	if err != nil {
+		return err
+	}
+
+	if c.flagFix {
+		return c.fix(resource, violations)
+	}
+
+	data := [][]string{}
+	for _, violation := range violations {
+		data = append(data, []string{violation.EntityType, violation.EntityName, violation.Key, violation.Device, violation.Description})
+	}
+
+	header := []string{
+		i18n.G("TYPE"),
+		i18n.G("NAME"),
+		i18n.G("KEY"),
+		i18n.G("DEVICE"),
+		i18n.G("DESCRIPTION"),
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, violations)
+}
+
+// fix unsets the configuration keys violating the restrictions of the project.
+func (c *cmdProjectAudit) fix(resource remoteResource, violations []api.ProjectAuditViolation) error {
+	server := resource.server.UseProject(resource.name)
+
+	// Group the keys to unset by instance and profile.
+	instanceKeys := map[string][]string{}
+	profileKeys := map[string][]string{}
+	manual := []api.ProjectAuditViolation{}
+
+	for _, violation := range violations {
+		if violation.Key == "" {
+			manual = append(manual, violation)
+			continue
+		}
+
+		if violation.EntityType == "profile" {
+			profileKeys[violation.EntityName] = append(profileKeys[violation.EntityName], violation.Key)
+		} else {
+			instanceKeys[violation.EntityName] = append(instanceKeys[violation.EntityName], violation.Key)
+		}
+	}
+
+	for name, keys := range instanceKeys {
+		inst, etag, err := server.GetInstance(name)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			delete(inst.Config, key)
+		}
+
+		op, err := server.UpdateInstance(name, inst.Writable(), etag)
+		if err != nil {
+			return err
+		}
+
+		err = op.Wait()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf(i18n.G("Unset %s on instance %s")+"\n", strings.Join(keys, ", "), name)
+	}
+
+	for name, keys := range profileKeys {
+		profile, etag, err := server.GetProfile(name)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			delete(profile.Config, key)
+		}
+
+		err = server.UpdateProfile(name, profile.Writable(), etag)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf(i18n.G("Unset %s on profile %s")+"\n", strings.Join(keys, ", "), name)
+	}
+
+	if len(manual) > 0 {
+		for _, violation := range manual {
+			fmt.Printf(i18n.G("Device %s of %s %s must be changed manually: %s")+"\n", violation.Device, violation.EntityType, violation.EntityName, violation.Description)
+		}
+
+		return errors.New(i18n.G("Some violations must be fixed manually"))
+	}
+
+	return nil
+}
+
 // Create.
 type cmdProjectCreate struct {
 	global          *cmdGlobal
 	project         *cmdProject
 	flagConfig      []string
 	flagDescription string
+	flagFrom        string
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -113,10 +292,14 @@ func (c *cmdProjectCreate) Command() *cobra.Command {
     Create a project named p1
 
 incus project create p1 < config.yaml
-    Create a project named p1 with configuration from config.yaml`))
+    Create a project named p1 with configuration from config.yaml
+
+incus project create p1 --from tenant-template
+    Create a project named p1 with the configuration and profiles of the tenant-template project`))
 
 	cmd.Flags().StringArrayVarP(&c.flagConfig, "config", "c", nil, i18n.G("Config key/value to apply to the new project")+"``")
 	cmd.Flags().StringVar(&c.flagDescription, "description", "", i18n.G("Project description")+"``")
+	cmd.Flags().StringVar(&c.flagFrom, "from", "", i18n.G("Template project to copy the configuration and profiles from")+"``")
 
 	cmd.RunE = c.Run
 
@@ -187,6 +370,8 @@ func (c *cmdProjectCreate) Run(cmd *cobra.Command, args []string) error {
 		project.Description = c.flagDescription
 	}
 
+	project.Source = c.flagFrom
+
 	err = resource.server.CreateProject(project)
 	if err != nil {
 		return err
@@ -416,6 +601,12 @@ func (c *cmdProjectEdit) Run(cmd *cobra.Command, args []string) error {
 
 		// Respawn the editor
 		if err != nil {
+			// Editing again won't help when another client modified the object in the meantime.
+			conflictErr := checkEditConflict(err)
+			if conflictErr != nil {
+				return conflictErr
+			}
+
 			fmt.Fprintf(os.Stderr, i18n.G("Config parsing error: %s")+"\n", err)
 			fmt.Println(i18n.G("Press enter to open the editor again or ctrl+c to abort change"))
 
@@ -745,6 +936,94 @@ func (c *cmdProjectList) Run(cmd *cobra.Command, args []string) error {
 	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, projects)
 }
 
+// Remap.
+type cmdProjectRemap struct {
+	global  *cmdGlobal
+	project *cmdProject
+
+	flagTarget string
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdProjectRemap) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("remap", i18n.G("[<remote>:]<project>"))
+	cmd.Short = i18n.G("Remap the containers of projects")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Remap the containers of projects
+
+Stopped containers whose idmap doesn't match the idmap configuration of their
+project anymore get a new idmap and their filesystem shifted to it.
+Running containers are skipped.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus project remap foo
+    Remap the stopped containers of the "foo" project after changing its security.idmap.isolated or security.idmap.range configuration.`))
+
+	cmd.Flags().StringVar(&c.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpProjects(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdProjectRemap) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing project name"))
+	}
+
+	server := resource.server
+	if c.flagTarget != "" {
+		server = server.UseTarget(c.flagTarget)
+	}
+
+	// Remap the project
+	op, err := server.RemapProject(resource.name)
+	if err != nil {
+		return err
+	}
+
+	err = op.Wait()
+	if err != nil {
+		return err
+	}
+
+	if c.global.flagQuiet {
+		return nil
+	}
+
+	remapped, _ := op.Get().Metadata["remapped"].([]any)
+	skipped, _ := op.Get().Metadata["skipped"].([]any)
+
+	fmt.Printf(i18n.G("Remapped %d containers of project %s")+"\n", len(remapped), resource.name)
+	for _, name := range skipped {
+		fmt.Printf(i18n.G("Skipped running container %v")+"\n", name)
+	}
+
+	return nil
+}
+
 // Rename.
 type cmdProjectRename struct {
 	global  *cmdGlobal
@@ -1085,6 +1364,7 @@ type cmdProjectInfo struct {
 	project *cmdProject
 
 	flagShowAccess bool
+	flagUsage      bool
 	flagFormat     string
 }
 
@@ -1094,8 +1374,16 @@ func (c *cmdProjectInfo) Command() *cobra.Command {
 	cmd.Use = usage("info", i18n.G("[<remote>:]<project>"))
 	cmd.Short = i18n.G("Get a summary of resource allocations")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
-		`Get a summary of resource allocations`))
+		`Get a summary of resource allocations
+
+With --usage, the resource usage and limits of all projects are reported, one
+row per project and resource. The project can then be omitted, and is used to
+only report that project. In CSV format, sizes are reported in bytes.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus project info --usage --format=csv remote:
+    Export the resource usage of all projects of a remote.`))
 	cmd.Flags().BoolVar(&c.flagShowAccess, "show-access", false, i18n.G("Show the instance's access list"))
+	cmd.Flags().BoolVar(&c.flagUsage, "usage", false, i18n.G("Report the resource usage of all projects"))
 	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", c.global.defaultListFormat(), i18n.G(`Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`)+"``")
 
 	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
@@ -1118,19 +1406,33 @@ func (c *cmdProjectInfo) Command() *cobra.Command {
 // Run runs the actual command logic.
 func (c *cmdProjectInfo) Run(cmd *cobra.Command, args []string) error {
 	// Quick checks.
-	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	minArgs := 1
+	if c.flagUsage {
+		minArgs = 0
+	}
+
+	exit, err := c.global.checkArgs(cmd, args, minArgs, 1)
 	if exit {
 		return err
 	}
 
 	// Parse remote
-	resources, err := c.global.parseServers(args[0])
+	remote := ""
+	if len(args) > 0 {
+		remote = args[0]
+	}
+
+	resources, err := c.global.parseServers(remote)
 	if err != nil {
 		return err
 	}
 
 	resource := resources[0]
 
+	if c.flagUsage {
+		return c.usage(resource)
+	}
+
 	if resource.name == "" {
 		return errors.New(i18n.G("Missing project name"))
 	}
@@ -1157,45 +1459,98 @@ func (c *cmdProjectInfo) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Render the output
-	byteLimits := []string{"disk", "memory"}
 	data := [][]string{}
 	for k, v := range projectState.Resources {
-		shortKey := strings.SplitN(k, ".", 2)[0]
-
-		limit := i18n.G("UNLIMITED")
-		if v.Limit >= 0 {
-			if slices.Contains(byteLimits, shortKey) {
-				limit = units.GetByteSizeStringIEC(v.Limit, 2)
-			} else {
-				limit = fmt.Sprintf("%d", v.Limit)
-			}
-		}
+		data = append(data, c.resourceRow(k, v, false))
+	}
 
-		usage := ""
-		if slices.Contains(byteLimits, shortKey) {
-			usage = units.GetByteSizeStringIEC(v.Usage, 2)
-		} else {
-			usage = fmt.Sprintf("%d", v.Usage)
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{
+		i18n.G("RESOURCE"),
+		i18n.G("LIMIT"),
+		i18n.G("USAGE"),
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, projectState)
+}
+
+// usage reports the resource usage of all projects, or of the given one.
+func (c *cmdProjectInfo) usage(resource remoteResource) error {
+	projects, err := resource.server.GetProjectsFull()
+	if err != nil {
+		return err
+	}
+
+	if resource.name != "" {
+		projects = slices.DeleteFunc(projects, func(project api.ProjectFull) bool { return project.Name != resource.name })
+		if len(projects) == 0 {
+			return fmt.Errorf(i18n.G("Project %q not found"), resource.name)
 		}
+	}
 
-		columnName := strings.ToUpper(k)
-		fields := strings.SplitN(columnName, ".", 2)
-		if len(fields) == 2 {
-			columnName = fmt.Sprintf("%s (%s)", fields[0], fields[1])
+	// Sizes are left in bytes when exporting as CSV.
+	raw := strings.HasPrefix(c.flagFormat, cli.TableFormatCSV)
+
+	data := [][]string{}
+	for _, project := range projects {
+		if project.State == nil {
+			continue
 		}
 
-		data = append(data, []string{columnName, limit, usage})
+		for k, v := range project.State.Resources {
+			data = append(data, append([]string{project.Name}, c.resourceRow(k, v, raw)...))
+		}
 	}
 
 	sort.Sort(cli.SortColumnsNaturally(data))
 
 	header := []string{
+		i18n.G("PROJECT"),
 		i18n.G("RESOURCE"),
 		i18n.G("LIMIT"),
 		i18n.G("USAGE"),
 	}
 
-	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, projectState)
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, projects)
+}
+
+// resourceRow renders the limit and usage of a project resource.
+func (c *cmdProjectInfo) resourceRow(key string, resource api.ProjectStateResource, raw bool) []string {
+	limit := i18n.G("UNLIMITED")
+	if resource.Limit >= 0 {
+		limit = projectResourceValue(key, resource.Limit, raw)
+	}
+
+	return []string{projectResourceName(key), limit, projectResourceValue(key, resource.Usage, raw)}
+}
+
+// projectResourceName returns the column name of a project resource.
+func projectResourceName(key string) string {
+	columnName := strings.ToUpper(key)
+	fields := strings.SplitN(columnName, ".", 2)
+	if len(fields) == 2 {
+		columnName = fmt.Sprintf("%s (%s)", fields[0], fields[1])
+	}
+
+	return columnName
+}
+
+// projectResourceValue renders a limit or usage value of a project resource.
+func projectResourceValue(key string, value int64, raw bool) string {
+	if raw {
+		return fmt.Sprintf("%d", value)
+	}
+
+	if key == "networks.bandwidth" {
+		return units.GetBitSizeString(value, 2)
+	}
+
+	if slices.Contains([]string{"disk", "memory"}, strings.SplitN(key, ".", 2)[0]) {
+		return units.GetByteSizeStringIEC(value, 2)
+	}
+
+	return fmt.Sprintf("%d", value)
 }
 
 // Get current project.
@@ -0,0 +1,822 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	incus "github.com/lxc/incus/v6/client"
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// projectBundle is the declarative representation of a project and of the entities it contains.
+type projectBundle struct {
+	Project     api.ProjectsPost        `yaml:"project"`
+	NetworkACLs []api.NetworkACLsPost   `yaml:"network_acls,omitempty"`
+	Networks    []api.NetworksPost      `yaml:"networks,omitempty"`
+	Profiles    []api.ProfilesPost      `yaml:"profiles,omitempty"`
+	Instances   []projectBundleInstance `yaml:"instances,omitempty"`
+}
+
+// projectBundleInstance is the definition of an instance in a project bundle.
+type projectBundleInstance struct {
+	api.InstancePut `yaml:",inline"`
+
+	Name   string             `yaml:"name"`
+	Type   api.InstanceType   `yaml:"type"`
+	Source api.InstanceSource `yaml:"source"`
+}
+
+// projectBundleHasNetworks returns whether the networks and network ACLs of a project belong to it.
+func projectBundleHasNetworks(name string, config map[string]string) bool {
+	return name == api.ProjectDefaultName || util.IsTrue(config["features.networks"])
+}
+
+// projectBundleHasProfiles returns whether the profiles of a project belong to it, instead of being those of the default project.
+func projectBundleHasProfiles(name string, config map[string]string) bool {
+	return name == api.ProjectDefaultName || util.IsTrue(config["features.profiles"])
+}
+
+// projectBundleWithoutVolatile returns a copy of the configuration without its volatile keys.
+func projectBundleWithoutVolatile(config map[string]string) map[string]string {
+	result := map[string]string{}
+	for key, value := range config {
+		if !strings.HasPrefix(key, "volatile.") {
+			result[key] = value
+		}
+	}
+
+	return result
+}
+
+// projectBundleKeepVolatile returns the new configuration along with the volatile keys of the current one.
+func projectBundleKeepVolatile(current map[string]string, config map[string]string) map[string]string {
+	result := maps.Clone(config)
+	if result == nil {
+		result = map[string]string{}
+	}
+
+	for key, value := range current {
+		if strings.HasPrefix(key, "volatile.") {
+			result[key] = value
+		}
+	}
+
+	return result
+}
+
+// projectBundleEqual returns whether two definitions are the same once rendered.
+func projectBundleEqual(a any, b any) bool {
+	dataA, errA := yaml.Marshal(a)
+	dataB, errB := yaml.Marshal(b)
+
+	return errA == nil && errB == nil && string(dataA) == string(dataB)
+}
+
+// projectBundleSortProfiles orders the profiles so that extended profiles come before the profiles extending them.
+func projectBundleSortProfiles(profiles []api.ProfilesPost) []api.ProfilesPost {
+	byName := map[string]api.ProfilesPost{}
+	for _, profile := range profiles {
+		byName[profile.Name] = profile
+	}
+
+	sorted := make([]api.ProfilesPost, 0, len(profiles))
+	seen := map[string]bool{}
+
+	var add func(name string)
+	add = func(name string) {
+		profile, ok := byName[name]
+		if !ok || seen[name] {
+			return
+		}
+
+		seen[name] = true
+		add(profile.Extends)
+		sorted = append(sorted, profile)
+	}
+
+	for _, profile := range profiles {
+		add(profile.Name)
+	}
+
+	return sorted
+}
+
+// Export.
+type cmdProjectExport struct {
+	global  *cmdGlobal
+	project *cmdProject
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdProjectExport) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("export", i18n.G("[<remote>:]<project> [<bundle file>]"))
+	cmd.Short = i18n.G("Export a project as a bundle")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Export a project as a bundle
+
+The bundle is a YAML file describing the project along with its profiles,
+networks, network ACLs and instances. Instance data, snapshots and volatile
+keys are not included.
+
+The bundle is written to standard output if no file is given.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus project export foo foo.yaml
+    Export the foo project to foo.yaml.`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpProjects(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdProjectExport) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing project name"))
+	}
+
+	project, _, err := resource.server.GetProject(resource.name)
+	if err != nil {
+		return err
+	}
+
+	server := resource.server.UseProject(resource.name)
+
+	bundle := projectBundle{
+		Project: api.ProjectsPost{
+			Name:       project.Name,
+			ProjectPut: project.Writable(),
+		},
+	}
+
+	// Network ACLs and networks.
+	if projectBundleHasNetworks(project.Name, project.Config) {
+		acls, err := server.GetNetworkACLs()
+		if err != nil {
+			return err
+		}
+
+		for _, acl := range acls {
+			bundle.NetworkACLs = append(bundle.NetworkACLs, api.NetworkACLsPost{
+				NetworkACLPost: api.NetworkACLPost{Name: acl.Name},
+				NetworkACLPut:  acl.Writable(),
+			})
+		}
+
+		networks, err := server.GetNetworks()
+		if err != nil {
+			return err
+		}
+
+		for _, network := range networks {
+			if !network.Managed {
+				continue
+			}
+
+			put := network.Writable()
+			put.Config = projectBundleWithoutVolatile(put.Config)
+
+			bundle.Networks = append(bundle.Networks, api.NetworksPost{
+				Name:       network.Name,
+				Type:       network.Type,
+				NetworkPut: put,
+			})
+		}
+	}
+
+	// Profiles.
+	if projectBundleHasProfiles(project.Name, project.Config) {
+		profiles, err := server.GetProfiles()
+		if err != nil {
+			return err
+		}
+
+		for _, profile := range profiles {
+			bundle.Profiles = append(bundle.Profiles, api.ProfilesPost{
+				Name:       profile.Name,
+				ProfilePut: profile.Writable(),
+			})
+		}
+
+		bundle.Profiles = projectBundleSortProfiles(bundle.Profiles)
+	}
+
+	// Instances.
+	instances, err := server.GetInstances(api.InstanceTypeAny)
+	if err != nil {
+		return err
+	}
+
+	for _, inst := range instances {
+		put := inst.Writable()
+		put.Config = projectBundleWithoutVolatile(put.Config)
+
+		// Instances are re-created from the image they were created from if known.
+		source := api.InstanceSource{Type: "none"}
+		if inst.Config["volatile.base_image"] != "" {
+			source = api.InstanceSource{Type: "image", Fingerprint: inst.Config["volatile.base_image"]}
+		}
+
+		bundle.Instances = append(bundle.Instances, projectBundleInstance{
+			Name:        inst.Name,
+			Type:        api.InstanceType(inst.Type),
+			Source:      source,
+			InstancePut: put,
+		})
+	}
+
+	data, err := yaml.Marshal(&bundle)
+	if err != nil {
+		return err
+	}
+
+	if len(args) < 2 || args[1] == "-" {
+		fmt.Printf("%s", data)
+		return nil
+	}
+
+	return os.WriteFile(args[1], data, 0o600)
+}
+
+// Import.
+type cmdProjectImport struct {
+	global  *cmdGlobal
+	project *cmdProject
+
+	flagUpdate bool
+	flagPrune  bool
+	flagDryRun bool
+	flagForce  bool
+}
+
+// projectBundlePrune lists the entities of a project which aren't in the bundle being imported.
+type projectBundlePrune struct {
+	instances   []string
+	profiles    []string
+	networks    []string
+	networkACLs []string
+}
+
+// empty returns whether there's nothing to delete.
+func (p *projectBundlePrune) empty() bool {
+	return len(p.instances) == 0 && len(p.profiles) == 0 && len(p.networks) == 0 && len(p.networkACLs) == 0
+}
+
+// String lists the entities to delete, one per line.
+func (p *projectBundlePrune) String() string {
+	var sb strings.Builder
+
+	for _, name := range p.instances {
+		fmt.Fprintf(&sb, i18n.G(" - instance %s (including its data)")+"\n", name)
+	}
+
+	for _, name := range p.profiles {
+		fmt.Fprintf(&sb, i18n.G(" - profile %s")+"\n", name)
+	}
+
+	for _, name := range p.networks {
+		fmt.Fprintf(&sb, i18n.G(" - network %s")+"\n", name)
+	}
+
+	for _, name := range p.networkACLs {
+		fmt.Fprintf(&sb, i18n.G(" - network ACL %s")+"\n", name)
+	}
+
+	return sb.String()
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdProjectImport) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("import", i18n.G("[<remote>:] <bundle file> [<project>]"))
+	cmd.Short = i18n.G("Import a project from a bundle")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Import a project from a bundle
+
+Creates the project and the profiles, networks, network ACLs and instances
+described in a bundle produced by "incus project export". The project name
+defaults to the one in the bundle, "-" reads the bundle from standard input.
+
+With --update, an existing project is reconciled against the bundle: missing
+entities are created and the existing ones are updated to match it. Volatile
+keys of existing entities are kept. With --prune, the profiles, networks,
+network ACLs and instances which aren't in the bundle are also deleted, along
+with the data of those instances. They are listed and confirmation is asked
+before making any change, unless --force is given. With --dry-run, they are
+only listed and nothing is changed.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus project import foo.yaml
+    Create the project described in foo.yaml.
+
+incus project import foo.yaml --update
+    Create or update the project described in foo.yaml.
+
+incus project import foo.yaml --update --prune --dry-run
+    Show what importing foo.yaml would delete from the project.`))
+
+	cmd.Flags().BoolVar(&c.flagUpdate, "update", false, i18n.G("Update the project if it already exists"))
+	cmd.Flags().BoolVar(&c.flagPrune, "prune", false, i18n.G("Delete the entities which aren't in the bundle (requires --update)"))
+	cmd.Flags().BoolVar(&c.flagDryRun, "dry-run", false, i18n.G("Only show what --prune would delete, without changing anything"))
+	cmd.Flags().BoolVarP(&c.flagForce, "force", "f", false, i18n.G("Delete the entities which aren't in the bundle without asking for confirmation"))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(toComplete, false)
+		}
+
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdProjectImport) Run(cmd *cobra.Command, args []string) error {
+	conf := c.global.conf
+
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 3)
+	if exit {
+		return err
+	}
+
+	if c.flagPrune && !c.flagUpdate {
+		return errors.New(i18n.G("--prune requires --update"))
+	}
+
+	if c.flagDryRun && !c.flagPrune {
+		return errors.New(i18n.G("--dry-run requires --prune"))
+	}
+
+	srcFilePosition := 0
+
+	// Parse remote (identify 1st argument is remote by looking for a colon at the end).
+	remote := conf.DefaultRemote
+	if len(args) > 1 && strings.HasSuffix(args[0], ":") {
+		remote = strings.TrimSuffix(args[0], ":")
+		srcFilePosition = 1
+	}
+
+	if len(args) > srcFilePosition+2 {
+		return errors.New(i18n.G("Too many arguments"))
+	}
+
+	// The confirmation can't be read from standard input when the bundle is.
+	if c.flagPrune && !c.flagDryRun && !c.flagForce && args[srcFilePosition] == "-" {
+		return errors.New(i18n.G("--prune with a bundle read from standard input requires --force or --dry-run"))
+	}
+
+	// Read the bundle.
+	var contents []byte
+	if args[srcFilePosition] == "-" {
+		contents, err = io.ReadAll(os.Stdin)
+	} else {
+		contents, err = os.ReadFile(args[srcFilePosition])
+	}
+
+	if err != nil {
+		return err
+	}
+
+	bundle := projectBundle{}
+	err = yaml.Unmarshal(contents, &bundle)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed parsing the bundle: %w"), err)
+	}
+
+	if len(args) > srcFilePosition+1 {
+		bundle.Project.Name = args[srcFilePosition+1]
+	}
+
+	if bundle.Project.Name == "" {
+		return errors.New(i18n.G("Missing project name"))
+	}
+
+	if !projectBundleHasNetworks(bundle.Project.Name, bundle.Project.Config) && (len(bundle.Networks) > 0 || len(bundle.NetworkACLs) > 0) {
+		return fmt.Errorf(i18n.G("The bundle has networks but project %q doesn't have features.networks enabled"), bundle.Project.Name)
+	}
+
+	d, err := conf.GetInstanceServer(remote)
+	if err != nil {
+		return err
+	}
+
+	// Create or update the project.
+	project, _, err := d.GetProject(bundle.Project.Name)
+	if err != nil && !api.StatusErrorCheck(err, 404) {
+		return err
+	}
+
+	if project != nil && !c.flagUpdate {
+		return fmt.Errorf(i18n.G("Project %q already exists, use --update to update it"), bundle.Project.Name)
+	}
+
+	// Find what to delete and confirm it before making any change.
+	var prune *projectBundlePrune
+	if c.flagPrune {
+		prune = &projectBundlePrune{}
+		if project != nil {
+			prune, err = c.pruneList(d.UseProject(project.Name), bundle, projectBundleHasProfiles(project.Name, project.Config))
+			if err != nil {
+				return err
+			}
+		}
+
+		if c.flagDryRun {
+			if prune.empty() {
+				fmt.Println(i18n.G("Nothing would be deleted"))
+			} else {
+				fmt.Printf(i18n.G("The following entities aren't in the bundle and would be deleted:")+"\n%s", prune)
+			}
+
+			return nil
+		}
+
+		if !prune.empty() && !c.flagForce {
+			fmt.Printf(i18n.G("The following entities aren't in the bundle and will be deleted:")+"\n%s", prune)
+
+			proceed, err := c.global.asker.AskBool(i18n.G("Do you want to continue? (yes/no) [default=no]: "), "no")
+			if err != nil {
+				return err
+			}
+
+			if !proceed {
+				return nil
+			}
+		}
+	}
+
+	if project == nil {
+		err = d.CreateProject(bundle.Project)
+		if err != nil {
+			return err
+		}
+
+		c.printAction(i18n.G("Created project %s"), bundle.Project.Name)
+	} else {
+		if !projectBundleEqual(project.Writable(), bundle.Project.ProjectPut) {
+			err = d.UpdateProject(bundle.Project.Name, bundle.Project.ProjectPut, "")
+			if err != nil {
+				return err
+			}
+
+			c.printAction(i18n.G("Updated project %s"), bundle.Project.Name)
+		}
+	}
+
+	// The features of a new project are only known once created, as some are enabled by default.
+	project, _, err = d.GetProject(bundle.Project.Name)
+	if err != nil {
+		return err
+	}
+
+	hasProfiles := projectBundleHasProfiles(project.Name, project.Config)
+	if !hasProfiles && len(bundle.Profiles) > 0 {
+		return fmt.Errorf(i18n.G("The bundle has profiles but project %q doesn't have features.profiles enabled"), project.Name)
+	}
+
+	server := d.UseProject(bundle.Project.Name)
+
+	// Network ACLs go first as networks and instances may use them, followed by the networks and profiles.
+	err = c.importNetworkACLs(server, bundle.NetworkACLs)
+	if err != nil {
+		return err
+	}
+
+	err = c.importNetworks(server, bundle.Networks)
+	if err != nil {
+		return err
+	}
+
+	err = c.importProfiles(server, projectBundleSortProfiles(bundle.Profiles))
+	if err != nil {
+		return err
+	}
+
+	err = c.importInstances(server, bundle.Instances)
+	if err != nil {
+		return err
+	}
+
+	if prune == nil {
+		return nil
+	}
+
+	return c.prune(server, prune)
+}
+
+// pruneList lists the entities of the project which aren't in the bundle.
+func (c *cmdProjectImport) pruneList(server incus.InstanceServer, bundle projectBundle, hasProfiles bool) (*projectBundlePrune, error) {
+	prune := &projectBundlePrune{}
+
+	instances, err := server.GetInstances(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, inst := range instances {
+		if !slices.ContainsFunc(bundle.Instances, func(entry projectBundleInstance) bool { return entry.Name == inst.Name }) {
+			prune.instances = append(prune.instances, inst.Name)
+		}
+	}
+
+	// The profiles of the default project are left alone if the project doesn't have its own.
+	if hasProfiles {
+		profiles, err := server.GetProfiles()
+		if err != nil {
+			return nil, err
+		}
+
+		// Profiles extending others must be deleted before them.
+		existingProfiles := []api.ProfilesPost{}
+		for _, profile := range profiles {
+			existingProfiles = append(existingProfiles, api.ProfilesPost{Name: profile.Name, ProfilePut: profile.Writable()})
+		}
+
+		existingProfiles = projectBundleSortProfiles(existingProfiles)
+		slices.Reverse(existingProfiles)
+
+		for _, profile := range existingProfiles {
+			if profile.Name != "default" && !slices.ContainsFunc(bundle.Profiles, func(entry api.ProfilesPost) bool { return entry.Name == profile.Name }) {
+				prune.profiles = append(prune.profiles, profile.Name)
+			}
+		}
+	}
+
+	if !projectBundleHasNetworks(bundle.Project.Name, bundle.Project.Config) {
+		return prune, nil
+	}
+
+	networks, err := server.GetNetworks()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, network := range networks {
+		if network.Managed && !slices.ContainsFunc(bundle.Networks, func(entry api.NetworksPost) bool { return entry.Name == network.Name }) {
+			prune.networks = append(prune.networks, network.Name)
+		}
+	}
+
+	acls, err := server.GetNetworkACLs()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, acl := range acls {
+		if !slices.ContainsFunc(bundle.NetworkACLs, func(entry api.NetworkACLsPost) bool { return entry.Name == acl.Name }) {
+			prune.networkACLs = append(prune.networkACLs, acl.Name)
+		}
+	}
+
+	return prune, nil
+}
+
+// prune deletes the entities which aren't in the bundle, in the reverse order of their creation.
+func (c *cmdProjectImport) prune(server incus.InstanceServer, prune *projectBundlePrune) error {
+	for _, name := range prune.instances {
+		op, err := server.DeleteInstance(name)
+		if err != nil {
+			return err
+		}
+
+		err = op.Wait()
+		if err != nil {
+			return err
+		}
+
+		c.printAction(i18n.G("Deleted instance %s"), name)
+	}
+
+	for _, name := range prune.profiles {
+		err := server.DeleteProfile(name)
+		if err != nil {
+			return err
+		}
+
+		c.printAction(i18n.G("Deleted profile %s"), name)
+	}
+
+	for _, name := range prune.networks {
+		err := server.DeleteNetwork(name)
+		if err != nil {
+			return err
+		}
+
+		c.printAction(i18n.G("Deleted network %s"), name)
+	}
+
+	for _, name := range prune.networkACLs {
+		err := server.DeleteNetworkACL(name)
+		if err != nil {
+			return err
+		}
+
+		c.printAction(i18n.G("Deleted network ACL %s"), name)
+	}
+
+	return nil
+}
+
+// printAction prints a change made to the project unless in quiet mode.
+func (c *cmdProjectImport) printAction(format string, name string) {
+	if !c.global.flagQuiet {
+		fmt.Printf(format+"\n", name)
+	}
+}
+
+// importNetworkACLs creates or updates the network ACLs of the bundle.
+func (c *cmdProjectImport) importNetworkACLs(server incus.InstanceServer, acls []api.NetworkACLsPost) error {
+	for _, acl := range acls {
+		current, _, err := server.GetNetworkACL(acl.Name)
+		if err != nil && !api.StatusErrorCheck(err, 404) {
+			return err
+		}
+
+		if current == nil {
+			err = server.CreateNetworkACL(acl)
+			if err != nil {
+				return err
+			}
+
+			c.printAction(i18n.G("Created network ACL %s"), acl.Name)
+			continue
+		}
+
+		if projectBundleEqual(current.Writable(), acl.NetworkACLPut) {
+			continue
+		}
+
+		err = server.UpdateNetworkACL(acl.Name, acl.NetworkACLPut, "")
+		if err != nil {
+			return err
+		}
+
+		c.printAction(i18n.G("Updated network ACL %s"), acl.Name)
+	}
+
+	return nil
+}
+
+// importNetworks creates or updates the networks of the bundle.
+func (c *cmdProjectImport) importNetworks(server incus.InstanceServer, networks []api.NetworksPost) error {
+	for _, network := range networks {
+		current, _, err := server.GetNetwork(network.Name)
+		if err != nil && !api.StatusErrorCheck(err, 404) {
+			return err
+		}
+
+		if current == nil || !current.Managed {
+			err = server.CreateNetwork(network)
+			if err != nil {
+				return err
+			}
+
+			c.printAction(i18n.G("Created network %s"), network.Name)
+			continue
+		}
+
+		if current.Type != network.Type {
+			return fmt.Errorf(i18n.G("Network %q is of type %q instead of %q"), network.Name, current.Type, network.Type)
+		}
+
+		put := network.NetworkPut
+		put.Config = projectBundleKeepVolatile(current.Config, put.Config)
+
+		if projectBundleEqual(current.Writable(), put) {
+			continue
+		}
+
+		err = server.UpdateNetwork(network.Name, put, "")
+		if err != nil {
+			return err
+		}
+
+		c.printAction(i18n.G("Updated network %s"), network.Name)
+	}
+
+	return nil
+}
+
+// importProfiles creates or updates the profiles of the bundle.
+func (c *cmdProjectImport) importProfiles(server incus.InstanceServer, profiles []api.ProfilesPost) error {
+	for _, profile := range profiles {
+		current, _, err := server.GetProfile(profile.Name)
+		if err != nil && !api.StatusErrorCheck(err, 404) {
+			return err
+		}
+
+		if current == nil {
+			err = server.CreateProfile(profile)
+			if err != nil {
+				return err
+			}
+
+			c.printAction(i18n.G("Created profile %s"), profile.Name)
+			continue
+		}
+
+		if projectBundleEqual(current.Writable(), profile.ProfilePut) {
+			continue
+		}
+
+		err = server.UpdateProfile(profile.Name, profile.ProfilePut, "")
+		if err != nil {
+			return err
+		}
+
+		c.printAction(i18n.G("Updated profile %s"), profile.Name)
+	}
+
+	return nil
+}
+
+// importInstances creates or updates the instances of the bundle.
+func (c *cmdProjectImport) importInstances(server incus.InstanceServer, instances []projectBundleInstance) error {
+	for _, inst := range instances {
+		current, _, err := server.GetInstance(inst.Name)
+		if err != nil && !api.StatusErrorCheck(err, 404) {
+			return err
+		}
+
+		if current == nil {
+			op, err := server.CreateInstance(api.InstancesPost{
+				Name:        inst.Name,
+				Type:        inst.Type,
+				Source:      inst.Source,
+				InstancePut: inst.InstancePut,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = op.Wait()
+			if err != nil {
+				return fmt.Errorf(i18n.G("Failed creating instance %q: %w"), inst.Name, err)
+			}
+
+			c.printAction(i18n.G("Created instance %s"), inst.Name)
+			continue
+		}
+
+		if inst.Type != "" && string(inst.Type) != current.Type {
+			return fmt.Errorf(i18n.G("Instance %q is of type %q instead of %q"), inst.Name, current.Type, inst.Type)
+		}
+
+		put := inst.InstancePut
+		put.Config = projectBundleKeepVolatile(current.Config, put.Config)
+
+		if projectBundleEqual(current.Writable(), put) {
+			continue
+		}
+
+		op, err := server.UpdateInstance(inst.Name, put, "")
+		if err != nil {
+			return err
+		}
+
+		err = op.Wait()
+		if err != nil {
+			return fmt.Errorf(i18n.G("Failed updating instance %q: %w"), inst.Name, err)
+		}
+
+		c.printAction(i18n.G("Updated instance %s"), inst.Name)
+	}
+
+	return nil
+}
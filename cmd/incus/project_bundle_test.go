@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func TestProjectBundleFeatures(t *testing.T) {
+	// The default project always has its own networks and profiles.
+	assert.True(t, projectBundleHasNetworks(api.ProjectDefaultName, nil))
+	assert.True(t, projectBundleHasProfiles(api.ProjectDefaultName, nil))
+
+	// Other projects use those of the default project unless the features are enabled.
+	assert.False(t, projectBundleHasNetworks("foo", nil))
+	assert.False(t, projectBundleHasProfiles("foo", map[string]string{"features.profiles": "false"}))
+	assert.False(t, projectBundleHasProfiles("foo", map[string]string{"features.networks": "true"}))
+	assert.True(t, projectBundleHasNetworks("foo", map[string]string{"features.networks": "true"}))
+	assert.True(t, projectBundleHasProfiles("foo", map[string]string{"features.profiles": "true"}))
+}
+
+func TestProjectBundleVolatile(t *testing.T) {
+	current := map[string]string{"limits.cpu": "2", "volatile.uuid": "1234"}
+
+	assert.Equal(t, map[string]string{"limits.cpu": "2"}, projectBundleWithoutVolatile(current))
+	assert.Equal(t, map[string]string{"limits.cpu": "4", "volatile.uuid": "1234"}, projectBundleKeepVolatile(current, map[string]string{"limits.cpu": "4"}))
+	assert.Equal(t, map[string]string{"volatile.uuid": "1234"}, projectBundleKeepVolatile(current, nil))
+}
+
+func TestProjectBundleEqual(t *testing.T) {
+	a := api.ProfilePut{Config: map[string]string{"limits.cpu": "2"}, Description: "foo"}
+	b := api.ProfilePut{Config: map[string]string{"limits.cpu": "2"}, Description: "foo"}
+
+	assert.True(t, projectBundleEqual(a, b))
+
+	b.Config["limits.cpu"] = "4"
+	assert.False(t, projectBundleEqual(a, b))
+}
+
+func TestProjectBundleSortProfiles(t *testing.T) {
+	profiles := []api.ProfilesPost{
+		{Name: "c", ProfilePut: api.ProfilePut{Extends: "b"}},
+		{Name: "default"},
+		{Name: "b", ProfilePut: api.ProfilePut{Extends: "a"}},
+		{Name: "a"},
+		{Name: "d", ProfilePut: api.ProfilePut{Extends: "missing"}},
+	}
+
+	names := []string{}
+	for _, profile := range projectBundleSortProfiles(profiles) {
+		names = append(names, profile.Name)
+	}
+
+	assert.Equal(t, []string{"a", "b", "c", "default", "d"}, names)
+}
+
+func TestProjectBundlePrune(t *testing.T) {
+	prune := &projectBundlePrune{}
+	assert.True(t, prune.empty())
+	assert.Equal(t, "", prune.String())
+
+	prune.instances = []string{"c1"}
+	prune.networkACLs = []string{"web"}
+	assert.False(t, prune.empty())
+	assert.Equal(t, " - instance c1 (including its data)\n - network ACL web\n", prune.String())
+}
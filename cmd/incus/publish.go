@@ -23,6 +23,7 @@ type cmdPublish struct {
 	flagExpiresAt            string
 	flagMakePublic           bool
 	flagForce                bool
+	flagLive                 bool
 	flagReuse                bool
 	flagFormat               string
 }
@@ -39,6 +40,7 @@ func (c *cmdPublish) Command() *cobra.Command {
 	cmd.Flags().BoolVar(&c.flagMakePublic, "public", false, i18n.G("Make the image public"))
 	cmd.Flags().StringArrayVar(&c.flagAliases, "alias", nil, i18n.G("New alias to define at target")+"``")
 	cmd.Flags().BoolVarP(&c.flagForce, "force", "f", false, i18n.G("Stop the instance if currently running"))
+	cmd.Flags().BoolVar(&c.flagLive, "live", false, i18n.G("Checkpoint the running instance and include its state in the image"))
 	cmd.Flags().StringVar(&c.flagCompressionAlgorithm, "compression", "", i18n.G("Compression algorithm to use (`none` for uncompressed)"))
 	cmd.Flags().StringVar(&c.flagExpiresAt, "expire", "", i18n.G("Image expiration date (format: rfc3339)")+"``")
 	cmd.Flags().BoolVar(&c.flagReuse, "reuse", false, i18n.G("If the image alias already exists, delete and create a new one"))
@@ -113,7 +115,21 @@ func (c *cmdPublish) Run(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if !instance.IsSnapshot(cName) {
+	if c.flagLive {
+		if instance.IsSnapshot(cName) {
+			return errors.New(i18n.G("--live can't be used with snapshots"))
+		}
+
+		if c.flagForce {
+			return errors.New(i18n.G("--live can't be used with --force"))
+		}
+
+		if !s.HasExtension("image_publish_live") {
+			return errors.New(i18n.G("The server doesn't support live publishing"))
+		}
+	}
+
+	if !instance.IsSnapshot(cName) && !c.flagLive {
 		ct, etag, err := s.GetInstance(cName)
 		if err != nil {
 			return err
@@ -220,6 +236,7 @@ func (c *cmdPublish) Run(cmd *cobra.Command, args []string) error {
 		Source: &api.ImagesPostSource{
 			Type: "instance",
 			Name: cName,
+			Live: c.flagLive,
 		},
 		CompressionAlgorithm: c.flagCompressionAlgorithm,
 	}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	config "github.com/lxc/incus/v6/shared/cliconfig"
+)
+
+type cmdRemoteGroup struct {
+	global *cmdGlobal
+	remote *cmdRemote
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdRemoteGroup) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("group")
+	cmd.Short = i18n.G("Manage remote groups")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Manage remote groups
+
+A remote group can be used in place of a remote by "incus list",
+"incus image list" and "incus monitor" to target all of its remotes at once,
+as "@<group>:". The implicit "@all:" group contains all the remotes.`))
+
+	// Create
+	remoteGroupCreateCmd := cmdRemoteGroupCreate{global: c.global, remoteGroup: c}
+	cmd.AddCommand(remoteGroupCreateCmd.Command())
+
+	// Delete
+	remoteGroupDeleteCmd := cmdRemoteGroupDelete{global: c.global, remoteGroup: c}
+	cmd.AddCommand(remoteGroupDeleteCmd.Command())
+
+	// List
+	remoteGroupListCmd := cmdRemoteGroupList{global: c.global, remoteGroup: c}
+	cmd.AddCommand(remoteGroupListCmd.Command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, _ []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// Create.
+type cmdRemoteGroupCreate struct {
+	global      *cmdGlobal
+	remoteGroup *cmdRemoteGroup
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdRemoteGroupCreate) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("create", i18n.G("<group> <remote> [<remote>...]"))
+	cmd.Short = i18n.G("Create remote groups")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Create remote groups`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus remote group create prod cluster1 cluster2
+    Create a group named "prod" holding the cluster1 and cluster2 remotes.
+
+incus list @prod:
+    List the instances of both clusters.`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return c.global.cmpRemoteNames()
+	}
+
+	return cmd
+}
+
+// Run is used in the RunE field of the cobra.Command returned by Command.
+func (c *cmdRemoteGroupCreate) Run(cmd *cobra.Command, args []string) error {
+	conf := c.global.conf
+
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 2, -1)
+	if exit {
+		return err
+	}
+
+	name := args[0]
+
+	if strings.Contains(name, ":") {
+		return errors.New(i18n.G("Remote group names may not contain colons"))
+	}
+
+	if strings.HasPrefix(name, config.RemoteGroupPrefix) {
+		return fmt.Errorf(i18n.G("Remote group names may not start with %q"), config.RemoteGroupPrefix)
+	}
+
+	if name == config.RemoteGroupAll {
+		return fmt.Errorf(i18n.G("The %q remote group is reserved for all the remotes"), config.RemoteGroupAll)
+	}
+
+	_, ok := conf.Remotes[name]
+	if ok {
+		return fmt.Errorf(i18n.G("Remote %s already exists"), name)
+	}
+
+	_, ok = conf.RemoteGroups[name]
+	if ok {
+		return fmt.Errorf(i18n.G("Remote group %s already exists"), name)
+	}
+
+	remotes := []string{}
+	for _, remote := range args[1:] {
+		_, ok := conf.Remotes[remote]
+		if !ok {
+			return fmt.Errorf(i18n.G("Remote %s doesn't exist"), remote)
+		}
+
+		if !slices.Contains(remotes, remote) {
+			remotes = append(remotes, remote)
+		}
+	}
+
+	if conf.RemoteGroups == nil {
+		conf.RemoteGroups = map[string][]string{}
+	}
+
+	conf.RemoteGroups[name] = remotes
+
+	return conf.SaveConfig(c.global.confPath)
+}
+
+// Delete.
+type cmdRemoteGroupDelete struct {
+	global      *cmdGlobal
+	remoteGroup *cmdRemoteGroup
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdRemoteGroupDelete) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("delete", i18n.G("<group>"))
+	cmd.Aliases = []string{"rm", "remove"}
+	cmd.Short = i18n.G("Delete remote groups")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Delete remote groups
+
+The remotes of the group are left untouched.`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemoteGroupNames()
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run is used in the RunE field of the cobra.Command returned by Command.
+func (c *cmdRemoteGroupDelete) Run(cmd *cobra.Command, args []string) error {
+	conf := c.global.conf
+
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	_, ok := conf.RemoteGroups[args[0]]
+	if !ok {
+		return fmt.Errorf(i18n.G("Remote group %s doesn't exist"), args[0])
+	}
+
+	delete(conf.RemoteGroups, args[0])
+
+	return conf.SaveConfig(c.global.confPath)
+}
+
+// List.
+type cmdRemoteGroupList struct {
+	global      *cmdGlobal
+	remoteGroup *cmdRemoteGroup
+
+	flagFormat string
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdRemoteGroupList) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("list")
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = i18n.G("List the remote groups")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`List the remote groups`))
+
+	cmd.RunE = c.Run
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", c.global.defaultListFormat(), i18n.G(`Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`)+"``")
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		return cli.ValidateFlagFormatForListOutput(cmd.Flag("format").Value.String())
+	}
+
+	return cmd
+}
+
+// Run is used in the RunE field of the cobra.Command returned by Command.
+func (c *cmdRemoteGroupList) Run(cmd *cobra.Command, args []string) error {
+	conf := c.global.conf
+
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 0, 0)
+	if exit {
+		return err
+	}
+
+	data := [][]string{}
+	for name, remotes := range conf.RemoteGroups {
+		data = append(data, []string{name, strings.Join(remotes, "\n")})
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{
+		i18n.G("NAME"),
+		i18n.G("REMOTES"),
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, conf.RemoteGroups)
+}
@@ -0,0 +1,528 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+type sessionColumn struct {
+	Name string
+	Data func(api.Session) string
+}
+
+type cmdSession struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdSession) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("session")
+	cmd.Short = i18n.G("Manage client sessions")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Manage client sessions
+
+Sessions are the long-lived connections of the clients to the server,
+like event listeners or the console and exec connections of instances.`))
+
+	// List
+	sessionListCmd := cmdSessionList{global: c.global, session: c}
+	cmd.AddCommand(sessionListCmd.Command())
+
+	// Show
+	sessionShowCmd := cmdSessionShow{global: c.global, session: c}
+	cmd.AddCommand(sessionShowCmd.Command())
+
+	// Delete
+	sessionDeleteCmd := cmdSessionDelete{global: c.global, session: c}
+	cmd.AddCommand(sessionDeleteCmd.Command())
+
+	// Block
+	sessionBlockCmd := cmdSessionBlock{global: c.global, session: c}
+	cmd.AddCommand(sessionBlockCmd.Command())
+
+	// Unblock
+	sessionUnblockCmd := cmdSessionUnblock{global: c.global, session: c}
+	cmd.AddCommand(sessionUnblockCmd.Command())
+
+	// List blocked
+	sessionListBlockedCmd := cmdSessionListBlocked{global: c.global, session: c}
+	cmd.AddCommand(sessionListBlockedCmd.Command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, _ []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// List.
+type cmdSessionList struct {
+	global  *cmdGlobal
+	session *cmdSession
+
+	flagColumns string
+	flagFormat  string
+}
+
+const defaultSessionColumns = "iuPatdLc"
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdSessionList) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("list", i18n.G("[<remote>:]"))
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = i18n.G("List client sessions")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`List client sessions
+
+The -c option takes a (optionally comma-separated) list of arguments
+that control which session attributes to output when displaying in table
+or csv format.
+
+Default column layout is: iuPatdLc
+
+Column shorthand chars:
+
+    a - Address
+    c - Connected since
+    d - Description
+    i - ID
+    L - Location
+    o - Operation
+    P - Protocol
+    t - Type
+    u - Username`))
+
+	cmd.Flags().StringVarP(&c.flagColumns, "columns", "c", defaultSessionColumns, i18n.G("Columns")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", c.global.defaultListFormat(), i18n.G(`Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`)+"``")
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		return cli.ValidateFlagFormatForListOutput(cmd.Flag("format").Value.String())
+	}
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdSessionList) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	remote := ""
+	if len(args) > 0 {
+		remote = args[0]
+	}
+
+	remoteName, _, err := c.global.conf.ParseRemote(remote)
+	if err != nil {
+		return err
+	}
+
+	remoteServer, err := c.global.conf.GetInstanceServer(remoteName)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := remoteServer.GetSessions()
+	if err != nil {
+		return err
+	}
+
+	// Process the columns
+	columns, err := c.parseColumns(remoteServer.IsClustered())
+	if err != nil {
+		return err
+	}
+
+	// Render the table
+	data := [][]string{}
+	for _, session := range sessions {
+		row := []string{}
+		for _, column := range columns {
+			row = append(row, column.Data(session))
+		}
+
+		data = append(data, row)
+	}
+
+	sort.Sort(cli.StringList(data))
+
+	rawData := make([]*api.Session, len(sessions))
+	for i := range sessions {
+		rawData[i] = &sessions[i]
+	}
+
+	headers := []string{}
+	for _, column := range columns {
+		headers = append(headers, column.Name)
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, headers, data, rawData)
+}
+
+func (c *cmdSessionList) addressColumnData(session api.Session) string {
+	return session.Address
+}
+
+func (c *cmdSessionList) createdColumnData(session api.Session) string {
+	return session.CreatedAt.Local().Format(dateLayout)
+}
+
+func (c *cmdSessionList) descriptionColumnData(session api.Session) string {
+	return session.Description
+}
+
+func (c *cmdSessionList) idColumnData(session api.Session) string {
+	return session.ID
+}
+
+func (c *cmdSessionList) locationColumnData(session api.Session) string {
+	return session.Location
+}
+
+func (c *cmdSessionList) operationColumnData(session api.Session) string {
+	return session.Operation
+}
+
+func (c *cmdSessionList) protocolColumnData(session api.Session) string {
+	return strings.ToUpper(session.Protocol)
+}
+
+func (c *cmdSessionList) typeColumnData(session api.Session) string {
+	return session.Type
+}
+
+func (c *cmdSessionList) usernameColumnData(session api.Session) string {
+	return session.Username
+}
+
+func (c *cmdSessionList) parseColumns(clustered bool) ([]sessionColumn, error) {
+	columnsShorthandMap := map[rune]sessionColumn{
+		'a': {i18n.G("ADDRESS"), c.addressColumnData},
+		'c': {i18n.G("CONNECTED SINCE"), c.createdColumnData},
+		'd': {i18n.G("DESCRIPTION"), c.descriptionColumnData},
+		'i': {i18n.G("ID"), c.idColumnData},
+		'o': {i18n.G("OPERATION"), c.operationColumnData},
+		'P': {i18n.G("PROTOCOL"), c.protocolColumnData},
+		't': {i18n.G("TYPE"), c.typeColumnData},
+		'u': {i18n.G("USERNAME"), c.usernameColumnData},
+	}
+
+	if clustered {
+		columnsShorthandMap['L'] = sessionColumn{i18n.G("LOCATION"), c.locationColumnData}
+	} else {
+		if c.flagColumns != defaultSessionColumns {
+			if strings.ContainsAny(c.flagColumns, "L") {
+				return nil, errors.New(i18n.G("Can't specify column L when not clustered"))
+			}
+		}
+		c.flagColumns = strings.ReplaceAll(c.flagColumns, "L", "")
+	}
+
+	columnList := strings.Split(c.flagColumns, ",")
+
+	columns := []sessionColumn{}
+	for _, columnEntry := range columnList {
+		if columnEntry == "" {
+			return nil, fmt.Errorf(i18n.G("Empty column entry (redundant, leading or trailing command) in '%s'"), c.flagColumns)
+		}
+
+		for _, columnRune := range columnEntry {
+			column, ok := columnsShorthandMap[columnRune]
+			if !ok {
+				return nil, fmt.Errorf(i18n.G("Unknown column shorthand char '%c' in '%s'"), columnRune, columnEntry)
+			}
+
+			columns = append(columns, column)
+		}
+	}
+
+	return columns, nil
+}
+
+// Show.
+type cmdSessionShow struct {
+	global  *cmdGlobal
+	session *cmdSession
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdSessionShow) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("show", i18n.G("[<remote>:]<session-id>"))
+	cmd.Short = i18n.G("Show client session")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Show client session`))
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdSessionShow) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	remoteName, id, err := c.global.conf.ParseRemote(args[0])
+	if err != nil {
+		return err
+	}
+
+	remoteServer, err := c.global.conf.GetInstanceServer(remoteName)
+	if err != nil {
+		return err
+	}
+
+	session, err := remoteServer.GetSession(id)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(&session)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s", data)
+
+	return nil
+}
+
+// Delete.
+type cmdSessionDelete struct {
+	global  *cmdGlobal
+	session *cmdSession
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdSessionDelete) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("delete", i18n.G("[<remote>:]<session-id>"))
+	cmd.Aliases = []string{"rm", "remove", "terminate"}
+	cmd.Short = i18n.G("Terminate client session")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Terminate client session
+
+This closes the connection of the client.`))
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdSessionDelete) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	remoteName, id, err := c.global.conf.ParseRemote(args[0])
+	if err != nil {
+		return err
+	}
+
+	remoteServer, err := c.global.conf.GetInstanceServer(remoteName)
+	if err != nil {
+		return err
+	}
+
+	return remoteServer.DeleteSession(id)
+}
+
+// Block.
+type cmdSessionBlock struct {
+	global  *cmdGlobal
+	session *cmdSession
+
+	flagDuration string
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdSessionBlock) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("block", i18n.G("[<remote>:]<username>"))
+	cmd.Short = i18n.G("Temporarily block an identity")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Temporarily block an identity
+
+The requests of the identity (certificate fingerprint or OIDC user) are rejected
+until the block expires and its sessions are terminated.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus session block jdoe@example.com --duration 30m
+    Block the jdoe@example.com OIDC user for 30 minutes.`))
+
+	cmd.Flags().StringVarP(&c.flagDuration, "duration", "d", "1h", i18n.G("How long to block the identity for")+"``")
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdSessionBlock) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	duration, err := time.ParseDuration(c.flagDuration)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Invalid duration %q: %w"), c.flagDuration, err)
+	}
+
+	// Parse remote
+	remoteName, username, err := c.global.conf.ParseRemote(args[0])
+	if err != nil {
+		return err
+	}
+
+	remoteServer, err := c.global.conf.GetInstanceServer(remoteName)
+	if err != nil {
+		return err
+	}
+
+	block := api.SessionBlock{
+		Username:  username,
+		ExpiresAt: time.Now().Add(duration),
+	}
+
+	return remoteServer.CreateSessionBlock(block)
+}
+
+// Unblock.
+type cmdSessionUnblock struct {
+	global  *cmdGlobal
+	session *cmdSession
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdSessionUnblock) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("unblock", i18n.G("[<remote>:]<username>"))
+	cmd.Short = i18n.G("Unblock an identity")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Unblock an identity`))
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdSessionUnblock) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	remoteName, username, err := c.global.conf.ParseRemote(args[0])
+	if err != nil {
+		return err
+	}
+
+	remoteServer, err := c.global.conf.GetInstanceServer(remoteName)
+	if err != nil {
+		return err
+	}
+
+	return remoteServer.DeleteSessionBlock(username)
+}
+
+// List blocked.
+type cmdSessionListBlocked struct {
+	global  *cmdGlobal
+	session *cmdSession
+
+	flagFormat string
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdSessionListBlocked) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("list-blocked", i18n.G("[<remote>:]"))
+	cmd.Short = i18n.G("List blocked identities")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`List blocked identities`))
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", c.global.defaultListFormat(), i18n.G(`Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`)+"``")
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		return cli.ValidateFlagFormatForListOutput(cmd.Flag("format").Value.String())
+	}
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdSessionListBlocked) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	remote := ""
+	if len(args) > 0 {
+		remote = args[0]
+	}
+
+	remoteName, _, err := c.global.conf.ParseRemote(remote)
+	if err != nil {
+		return err
+	}
+
+	remoteServer, err := c.global.conf.GetInstanceServer(remoteName)
+	if err != nil {
+		return err
+	}
+
+	blocks, err := remoteServer.GetSessionBlocks()
+	if err != nil {
+		return err
+	}
+
+	data := [][]string{}
+	for _, block := range blocks {
+		data = append(data, []string{block.Username, block.ExpiresAt.Local().Format(dateLayout)})
+	}
+
+	sort.Sort(cli.StringList(data))
+
+	headers := []string{
+		i18n.G("USERNAME"),
+		i18n.G("EXPIRES AT"),
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, headers, data, blocks)
+}
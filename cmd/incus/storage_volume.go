@@ -162,6 +162,8 @@ type cmdStorageVolumeAttach struct {
 	global        *cmdGlobal
 	storage       *cmdStorage
 	storageVolume *cmdStorageVolume
+
+	flagCheck bool
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -172,6 +174,7 @@ func (c *cmdStorageVolumeAttach) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Attach new custom storage volumes to instances`))
 
+	cmd.Flags().BoolVar(&c.flagCheck, "check", false, i18n.G("Only report the effect of the change on the project resources"))
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -242,7 +245,7 @@ func (c *cmdStorageVolumeAttach) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Add the device to the instance
-	err = instanceDeviceAdd(resource.server, args[2], devName, device)
+	err = instanceDeviceAdd(resource.server, args[2], devName, device, c.flagCheck)
 	if err != nil {
 		return err
 	}
@@ -1143,6 +1146,12 @@ func (c *cmdStorageVolumeEdit) Run(cmd *cobra.Command, args []string) error {
 
 			// Respawn the editor
 			if err != nil {
+				// Editing again won't help when another client modified the object in the meantime.
+				conflictErr := checkEditConflict(err)
+				if conflictErr != nil {
+					return conflictErr
+				}
+
 				fmt.Fprintf(os.Stderr, i18n.G("Config parsing error: %s")+"\n", err)
 				fmt.Println(i18n.G("Press enter to open the editor again or ctrl+c to abort change"))
 
@@ -1175,6 +1184,12 @@ func (c *cmdStorageVolumeEdit) Run(cmd *cobra.Command, args []string) error {
 
 		// Respawn the editor
 		if err != nil {
+			// Editing again won't help when another client modified the object in the meantime.
+			conflictErr := checkEditConflict(err)
+			if conflictErr != nil {
+				return conflictErr
+			}
+
 			fmt.Fprintf(os.Stderr, i18n.G("Config parsing error: %s")+"\n", err)
 			fmt.Println(i18n.G("Press enter to open the editor again or ctrl+c to abort change"))
 
@@ -3459,12 +3474,7 @@ func (c *cmdStorageVolumeImport) Run(cmd *cobra.Command, args []string) error {
 	createArgs := incus.StorageVolumeBackupArgs{
 		BackupFile: &ioprogress.ProgressReader{
 			ReadCloser: file,
-			Tracker: &ioprogress.ProgressTracker{
-				Length: fstat.Size(),
-				Handler: func(percent int64, speed int64) {
-					progress.UpdateProgress(ioprogress.ProgressData{Text: fmt.Sprintf("%d%% (%s/s)", percent, units.GetByteSizeString(speed, 2))})
-				},
-			},
+			Tracker:    ioprogress.NewProgressTracker(ioprogress.StageBackupUpload, fstat.Size(), progress.UpdateProgress),
 		},
 		Name: volName,
 	}
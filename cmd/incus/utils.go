@@ -7,6 +7,7 @@ import (
 	"io"
 	"math/rand"
 	"net"
+	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -17,6 +18,7 @@ import (
 	"golang.org/x/crypto/ssh"
 
 	incus "github.com/lxc/incus/v6/client"
+	cli "github.com/lxc/incus/v6/internal/cmd"
 	"github.com/lxc/incus/v6/internal/i18n"
 	"github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/shared/api"
@@ -52,8 +54,8 @@ func runBatch(names []string, action func(name string) error) []batchResult {
 	return results
 }
 
-// Add a device to an instance.
-func instanceDeviceAdd(client incus.InstanceServer, name string, devName string, dev map[string]string) error {
+// Add a device to an instance, or only check the effect adding it would have on the project resources.
+func instanceDeviceAdd(client incus.InstanceServer, name string, devName string, dev map[string]string, check bool) error {
 	// Get the instance entry
 	inst, etag, err := client.GetInstance(name)
 	if err != nil {
@@ -68,6 +70,10 @@ func instanceDeviceAdd(client incus.InstanceServer, name string, devName string,
 
 	inst.Devices[devName] = dev
 
+	if check {
+		return instanceUpdateCheck(client, name, inst.Writable())
+	}
+
 	op, err := client.UpdateInstance(name, inst.Writable(), etag)
 	if err != nil {
 		return err
@@ -76,6 +82,51 @@ func instanceDeviceAdd(client incus.InstanceServer, name string, devName string,
 	return op.Wait()
 }
 
+// Report the effect an instance update would have on the resources of its project.
+func instanceUpdateCheck(client incus.InstanceServer, name string, inst api.InstancePut) error {
+	check, err := client.CheckInstanceUpdate(name, inst)
+	if err != nil {
+		return err
+	}
+
+	data := [][]string{}
+	for key, resource := range check.Resources {
+		// Skip the resources which are neither limited nor affected by the update.
+		if resource.Limit < 0 && resource.Usage == resource.NewUsage {
+			continue
+		}
+
+		limit := i18n.G("UNLIMITED")
+		if resource.Limit >= 0 {
+			limit = projectResourceValue(key, resource.Limit, false)
+		}
+
+		data = append(data, []string{projectResourceName(key), limit, projectResourceValue(key, resource.Usage, false), projectResourceValue(key, resource.NewUsage, false)})
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{
+		i18n.G("RESOURCE"),
+		i18n.G("LIMIT"),
+		i18n.G("USAGE"),
+		i18n.G("NEW USAGE"),
+	}
+
+	err = cli.RenderTable(os.Stdout, cli.TableFormatTable, header, data, check)
+	if err != nil {
+		return err
+	}
+
+	if check.Error != "" {
+		return fmt.Errorf(i18n.G("The change would be refused: %s"), check.Error)
+	}
+
+	fmt.Println(i18n.G("The change would be allowed"))
+
+	return nil
+}
+
 // Add a device to a profile.
 func profileDeviceAdd(client incus.InstanceServer, name string, devName string, dev map[string]string) error {
 	// Get the profile entry
@@ -841,3 +892,13 @@ func sshSFTPServer(ctx context.Context, sftpConn func() (net.Conn, error), entit
 		}()
 	}
 }
+
+// checkEditConflict returns an error when an update was rejected because another client modified the object since
+// it was retrieved, in which case editing it again would keep failing.
+func checkEditConflict(err error) error {
+	if !api.StatusErrorCheck(err, http.StatusPreconditionFailed) {
+		return nil
+	}
+
+	return errors.New(i18n.G("The object was modified by another client while being edited, run the command again to edit its current state"))
+}
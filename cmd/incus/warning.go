@@ -6,6 +6,7 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	yaml "gopkg.in/yaml.v2"
@@ -262,6 +263,8 @@ func (c *cmdWarningList) parseColumns(clustered bool) ([]warningColumn, error) {
 type cmdWarningAcknowledge struct {
 	global  *cmdGlobal
 	warning *cmdWarning
+
+	flagDuration string
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -271,7 +274,14 @@ func (c *cmdWarningAcknowledge) Command() *cobra.Command {
 	cmd.Aliases = []string{"ack"}
 	cmd.Short = i18n.G("Acknowledge warning")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
-		`Acknowledge warning`))
+		`Acknowledge warning
+
+The warning becomes new again once the duration passed, if one is given.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus warning ack 8ba0e1c7-56d4-4e34-a9ae-91b1f2f6e16b --duration 24h
+    Acknowledge the warning for the next 24 hours.`))
+
+	cmd.Flags().StringVarP(&c.flagDuration, "duration", "d", "", i18n.G("How long to acknowledge the warning for")+"``")
 
 	cmd.RunE = c.Run
 
@@ -299,6 +309,16 @@ func (c *cmdWarningAcknowledge) Run(cmd *cobra.Command, args []string) error {
 
 	warning := api.WarningPut{Status: "acknowledged"}
 
+	if c.flagDuration != "" {
+		duration, err := time.ParseDuration(c.flagDuration)
+		if err != nil {
+			return fmt.Errorf(i18n.G("Invalid duration %q: %w"), c.flagDuration, err)
+		}
+
+		acknowledgedUntil := time.Now().Add(duration)
+		warning.AcknowledgedUntil = &acknowledgedUntil
+	}
+
 	return remoteServer.UpdateWarning(UUID, warning, "")
 }
 
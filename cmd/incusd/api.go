@@ -71,27 +71,52 @@ func restServer(d *Daemon) *http.Server {
 	router.SkipClean(true)
 	router.UseEncodedPath() // Allow encoded values in path segments.
 
-	// Serving the UI.
-	uiPath := os.Getenv("INCUS_UI")
-	uiEnabled := uiPath != "" && util.PathExists(fmt.Sprintf("%s/index.html", uiPath))
-	if uiEnabled {
-		uiHttpDir := uiHttpDir{http.Dir(uiPath)}
-		router.PathPrefix("/ui/").Handler(http.StripPrefix("/ui/", http.FileServer(uiHttpDir)))
-		router.HandleFunc("/ui", func(w http.ResponseWriter, r *http.Request) {
-			http.Redirect(w, r, "/ui/", http.StatusMovedPermanently)
-		})
+	notFound := func(w http.ResponseWriter, r *http.Request) {
+		logger.Info("Sending top level 404", logger.Ctx{"url": r.URL, "method": r.Method, "remote": r.RemoteAddr})
+		w.Header().Set("Content-Type", "application/json")
+		_ = response.NotFound(nil).Render(w)
 	}
 
-	// Serving the documentation.
-	documentationPath := os.Getenv("INCUS_DOCUMENTATION")
-	docEnabled := documentationPath != "" && util.PathExists(documentationPath)
-	if docEnabled {
-		documentationHttpDir := documentationHttpDir{http.Dir(documentationPath)}
-		router.PathPrefix("/documentation/").Handler(http.StripPrefix("/documentation/", http.FileServer(documentationHttpDir)))
-		router.HandleFunc("/documentation", func(w http.ResponseWriter, r *http.Request) {
-			http.Redirect(w, r, "/documentation/", http.StatusMovedPermanently)
-		})
-	}
+	// Serving the UI and the documentation, when their directories are available (re-checked on reload).
+	d.detectWebDirectories()
+
+	uiHandler := http.StripPrefix("/ui/", http.FileServer(uiHttpDir{http.Dir(os.Getenv("INCUS_UI"))}))
+	router.PathPrefix("/ui/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !d.uiEnabled.Load() {
+			notFound(w, r)
+			return
+		}
+
+		uiHandler.ServeHTTP(w, r)
+	})
+
+	router.HandleFunc("/ui", func(w http.ResponseWriter, r *http.Request) {
+		if !d.uiEnabled.Load() {
+			notFound(w, r)
+			return
+		}
+
+		http.Redirect(w, r, "/ui/", http.StatusMovedPermanently)
+	})
+
+	documentationHandler := http.StripPrefix("/documentation/", http.FileServer(documentationHttpDir{http.Dir(os.Getenv("INCUS_DOCUMENTATION"))}))
+	router.PathPrefix("/documentation/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !d.documentationEnabled.Load() {
+			notFound(w, r)
+			return
+		}
+
+		documentationHandler.ServeHTTP(w, r)
+	})
+
+	router.HandleFunc("/documentation", func(w http.ResponseWriter, r *http.Request) {
+		if !d.documentationEnabled.Load() {
+			notFound(w, r)
+			return
+		}
+
+		http.Redirect(w, r, "/documentation/", http.StatusMovedPermanently)
+	})
 
 	// Serving the OS API.
 	d.createCmd(router, "os", apiOS)
@@ -127,11 +152,15 @@ func restServer(d *Daemon) *http.Server {
 		d.oidcVerifier.Logout(w, r)
 	})
 
+	// Health and readiness probes.
+	router.HandleFunc("/healthz", healthHandler(d, false))
+	router.HandleFunc("/readyz", healthHandler(d, true))
+
 	router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 
 		ua := r.Header.Get("User-Agent")
-		if uiEnabled && strings.Contains(ua, "Gecko") {
+		if d.uiEnabled.Load() && strings.Contains(ua, "Gecko") {
 			// Web browser handling.
 			http.Redirect(w, r, "/ui/", http.StatusMovedPermanently)
 		} else {
@@ -166,11 +195,8 @@ func restServer(d *Daemon) *http.Server {
 		d.createCmd(router, "", c)
 	}
 
-	router.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		logger.Info("Sending top level 404", logger.Ctx{"url": r.URL, "method": r.Method, "remote": r.RemoteAddr})
-		w.Header().Set("Content-Type", "application/json")
-		_ = response.NotFound(nil).Render(w)
-	})
+	router.NotFoundHandler = http.HandlerFunc(notFound)
+	d.restRouter = router
 
 	return &http.Server{
 		Handler:     &httpServer{r: router, d: d},
@@ -179,6 +205,15 @@ func restServer(d *Daemon) *http.Server {
 	}
 }
 
+// detectWebDirectories checks whether the UI and documentation directories set in the environment are available.
+func (d *Daemon) detectWebDirectories() {
+	uiPath := os.Getenv("INCUS_UI")
+	d.uiEnabled.Store(uiPath != "" && util.PathExists(fmt.Sprintf("%s/index.html", uiPath)))
+
+	documentationPath := os.Getenv("INCUS_DOCUMENTATION")
+	d.documentationEnabled.Store(documentationPath != "" && util.PathExists(documentationPath))
+}
+
 func hoistReqVM(f func(*Daemon, instance.Instance, http.ResponseWriter, *http.Request) response.Response, d *Daemon) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		trusted, inst, err := authenticateAgentCert(d.State(), r)
@@ -216,6 +251,9 @@ func metricsServer(d *Daemon) *http.Server {
 		router.HandleFunc(endpoint, f)
 	}
 
+	router.HandleFunc("/healthz", healthHandler(d, false))
+	router.HandleFunc("/readyz", healthHandler(d, true))
+
 	d.createCmd(router, "1.0", api10Cmd)
 	d.createCmd(router, "1.0", metricsCmd)
 
@@ -23,6 +23,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
 	scriptletLoad "github.com/lxc/incus/v6/internal/server/scriptlet/load"
+	"github.com/lxc/incus/v6/internal/server/tracing"
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
 	"github.com/lxc/incus/v6/internal/version"
 	"github.com/lxc/incus/v6/shared/api"
@@ -42,6 +43,7 @@ var api10Cmd = APIEndpoint{
 var api10 = []APIEndpoint{
 	api10Cmd,
 	api10ResourcesCmd,
+	batchCmd,
 	certificateCmd,
 	certificatesCmd,
 	clusterCmd,
@@ -72,6 +74,10 @@ var api10 = []APIEndpoint{
 	instanceStateCmd,
 	instanceAccessCmd,
 	instanceDebugMemoryCmd,
+	instanceDebugQMPCmd,
+	instanceUEFICmd,
+	instanceUEFINVRAMCmd,
+	instanceUEFISecureBootKeysCmd,
 	eventsCmd,
 	imageAliasCmd,
 	imageAliasesCmd,
@@ -113,7 +119,11 @@ var api10 = []APIEndpoint{
 	projectCmd,
 	projectsCmd,
 	projectStateCmd,
+	projectAuditCmd,
 	projectAccessCmd,
+	projectRemapCmd,
+	scheduledTasksCmd,
+	scheduledTaskCmd,
 	storagePoolCmd,
 	storagePoolResourcesCmd,
 	storagePoolsCmd,
@@ -136,7 +146,12 @@ var api10 = []APIEndpoint{
 	storagePoolVolumeTypeStateCmd,
 	warningsCmd,
 	warningCmd,
+	sessionsCmd,
+	sessionCmd,
+	sessionBlocksCmd,
+	sessionBlockCmd,
 	metricsCmd,
+	serverLogsCmd,
 }
 
 // swagger:operation GET /1.0?public server server_get_untrusted
@@ -312,6 +327,17 @@ func api10Get(d *Daemon, r *http.Request) response.Response {
 		architectures = append(architectures, architectureName)
 	}
 
+	emulatedArchitectures := []string{}
+
+	for _, architecture := range s.OS.EmulatedArchitectures {
+		architectureName, err := osarch.ArchitectureName(architecture)
+		if err != nil {
+			return response.InternalError(err)
+		}
+
+		emulatedArchitectures = append(emulatedArchitectures, architectureName)
+	}
+
 	projectName := r.FormValue("project")
 	if projectName == "" {
 		projectName = api.ProjectDefaultName
@@ -320,6 +346,7 @@ func api10Get(d *Daemon, r *http.Request) response.Response {
 	env := api.ServerEnvironment{
 		Addresses:              addresses,
 		Architectures:          architectures,
+		EmulatedArchitectures:  emulatedArchitectures,
 		Certificate:            certificate,
 		CertificateFingerprint: certificateFingerprint,
 		Kernel:                 s.OS.Uname.Sysname,
@@ -800,11 +827,13 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 	linstorChanged := false
 	ovsChanged := false
 	syslogChanged := false
+	emulationChanged := false
+	tracingChanged := false
 	loggingChanges := map[string]struct{}{}
 
 	for key := range clusterChanged {
 		switch key {
-		case "acme.agree_tos", "acme.ca_url", "acme.challenge", "acme.domain", "acme.email", "acme.provider", "acme.provider.environment", "acme.provider.resolvers":
+		case "acme.agree_tos", "acme.ca_url", "acme.challenge", "acme.domain", "acme.email", "acme.member_domain", "acme.provider", "acme.provider.environment", "acme.provider.resolvers":
 			acmeChanged = true
 
 		case "cluster.images_minimal_replica":
@@ -826,6 +855,19 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 		case "core.proxy_http", "core.proxy_https", "core.proxy_ignore_hosts":
 			daemonConfigSetProxy(d, clusterConfig)
 
+			// Re-create the loggers so that the network based ones use the new proxy.
+			loggers, err := clusterConfig.Loggers()
+			if err != nil {
+				return err
+			}
+
+			for loggerName := range loggers {
+				loggingChanges[loggerName] = struct{}{}
+			}
+
+		case "core.slow_query_threshold":
+			s.DB.Cluster.SlowLog().SetThreshold(clusterConfig.SlowQueryThreshold())
+
 		case "images.auto_update_interval", "images.remote_cache_expiry":
 			if !s.OS.MockMode {
 				d.taskPruneImages.Reset()
@@ -838,7 +880,7 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 		case "network.ovn.northbound_connection", "network.ovn.ca_cert", "network.ovn.client_cert", "network.ovn.client_key":
 			ovnChanged = true
 
-		case "oidc.issuer", "oidc.client.id", "oidc.audience", "oidc.claim":
+		case "oidc.issuer", "oidc.client.id", "oidc.scopes", "oidc.audience", "oidc.claim", "oidc.groups.claim", "oidc.groups.mapping":
 			oidcChanged = true
 
 		case "openfga.api.url", "openfga.api.token", "openfga.store.id":
@@ -846,6 +888,9 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 
 		case "storage.linstor.controller_connection", "storage.linstor.ca_cert", "storage.linstor.client_cert", "storage.linstor.client_key":
 			linstorChanged = true
+
+		case "tracing.endpoint", "tracing.ca_cert", "tracing.sample_percentage":
+			tracingChanged = true
 		default:
 			if strings.HasPrefix(key, "logging.") {
 				fields := strings.Split(key, ".")
@@ -867,6 +912,9 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 		case "core.syslog_socket":
 			syslogChanged = true
 
+		case "instances.emulation.architectures":
+			emulationChanged = true
+
 		case "network.ovs.connection":
 			ovsChanged = true
 		}
@@ -970,14 +1018,16 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 			return err
 		}
 	}
+
 	if oidcChanged {
 		oidcIssuer, oidcClientID, oidcScope, oidcAudience, oidcClaim := clusterConfig.OIDCServer()
+		oidcGroupsClaim, oidcGroupsMapping := clusterConfig.OIDCGroups()
 
 		if oidcIssuer == "" || oidcClientID == "" {
 			d.oidcVerifier = nil
 		} else {
 			var err error
-			d.oidcVerifier, err = oidc.NewVerifier(oidcIssuer, oidcClientID, oidcScope, oidcAudience, oidcClaim)
+			d.oidcVerifier, err = oidc.NewVerifier(oidcIssuer, oidcClientID, oidcScope, oidcAudience, oidcClaim, oidcGroupsClaim, oidcGroupsMapping)
 			if err != nil {
 				return fmt.Errorf("Failed creating verifier: %w", err)
 			}
@@ -1013,6 +1063,13 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 		}
 	}
 
+	if emulationChanged {
+		err := d.setupEmulation(nodeConfig.InstancesEmulationArchitectures())
+		if err != nil {
+			return err
+		}
+	}
+
 	if linstorChanged {
 		err := d.setupLinstor()
 		if err != nil {
@@ -1020,6 +1077,15 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 		}
 	}
 
+	if tracingChanged {
+		tracingEndpoint, tracingCACert, tracingSamplePercentage := clusterConfig.Tracing()
+
+		err := tracing.Configure(tracingEndpoint, tracingCACert, tracingSamplePercentage, s.ServerName)
+		if err != nil {
+			return fmt.Errorf("Failed reconfiguring tracing: %w", err)
+		}
+	}
+
 	// Compile and load the instance placement scriptlet.
 	value, ok = clusterChanged["instances.placement.scriptlet"]
 	if ok {
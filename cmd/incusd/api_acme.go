@@ -2,16 +2,20 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/lxc/incus/v6/internal/server/acme"
 	"github.com/lxc/incus/v6/internal/server/cluster"
+	"github.com/lxc/incus/v6/internal/server/db"
 	"github.com/lxc/incus/v6/internal/server/db/operationtype"
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/internal/server/task"
 	"github.com/lxc/incus/v6/internal/util"
 	"github.com/lxc/incus/v6/shared/api"
@@ -43,6 +47,11 @@ func acmeProvideChallenge(d *Daemon, r *http.Request) response.Response {
 		clusterAddress := s.LocalConfig.ClusterAddress()
 
 		if clusterAddress != "" && clusterAddress != leader {
+			// Keep track of the domain being validated.
+			if r.Header.Get("X-Forwarded-Host") == "" {
+				r.Header.Set("X-Forwarded-Host", r.Host)
+			}
+
 			// Forward the request to the leader
 			client, err := cluster.Connect(leader, s.Endpoints.NetworkCert(), s.ServerCert(), r, true)
 			if err != nil {
@@ -59,7 +68,27 @@ func acmeProvideChallenge(d *Daemon, r *http.Request) response.Response {
 		addr = "127.0.0.1" + addr
 	}
 
-	domain, _, _, _, _ := s.GlobalConfig.ACME()
+	// Use the domain being validated, which may be the one of a cluster member.
+	domains, err := acmeDomains(r.Context(), s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	domain := domains[0]
+
+	host := r.Header.Get("X-Forwarded-Host")
+	if host == "" {
+		host = r.Host
+	}
+
+	hostname, _, err := net.SplitHostPort(host)
+	if err != nil {
+		hostname = host
+	}
+
+	if slices.Contains(domains, hostname) {
+		domain = hostname
+	}
 
 	client := http.Client{}
 	client.Transport = &http.Transport{
@@ -123,8 +152,13 @@ func autoRenewCertificate(ctx context.Context, d *Daemon, force bool) error {
 		}
 	}
 
+	domains, err := acmeDomains(ctx, s)
+	if err != nil {
+		return err
+	}
+
 	opRun := func(op *operations.Operation) error {
-		newCert, err := acme.UpdateCertificate(s, challengeType, s.ServerClustered, domain, email, caURL, force)
+		newCert, err := acme.UpdateCertificate(s, challengeType, s.ServerClustered, domains, email, caURL, force)
 		if err != nil {
 			return err
 		}
@@ -195,3 +229,32 @@ func autoRenewCertificateTask(d *Daemon) (task.Func, task.Schedule) {
 
 	return f, task.Daily()
 }
+
+// acmeDomains returns the domains the certificate must be issued for, starting with the main one.
+func acmeDomains(ctx context.Context, s *state.State) ([]string, error) {
+	domain, _, _, _, _ := s.GlobalConfig.ACME()
+	domains := []string{domain}
+
+	memberDomain := s.GlobalConfig.ACMEMemberDomain()
+	if !s.ServerClustered || memberDomain == "" {
+		return domains, nil
+	}
+
+	var members []db.NodeInfo
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		members, err = tx.GetNodes(ctx)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting cluster members: %w", err)
+	}
+
+	for _, member := range members {
+		domains = append(domains, strings.ReplaceAll(memberDomain, "{member}", member.Name))
+	}
+
+	return domains, nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/gorilla/mux"
 
 	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/internal/filter"
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/server/auth"
 	"github.com/lxc/incus/v6/internal/server/certificate"
@@ -1080,6 +1081,12 @@ func clusterAcceptMember(client incus.InstanceServer, name string, address strin
 //  ---
 //  produces:
 //    - application/json
+//  parameters:
+//    - in: query
+//      name: filter
+//      description: Collection filter
+//      type: string
+//      example: default
 //  responses:
 //    "200":
 //      description: API endpoints
@@ -1123,6 +1130,12 @@ func clusterAcceptMember(client incus.InstanceServer, name string, address strin
 //	---
 //	produces:
 //	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: filter
+//	    description: Collection filter
+//	    type: string
+//	    example: default
 //	responses:
 //	  "200":
 //	    description: API endpoints
@@ -1155,6 +1168,15 @@ func clusterNodesGet(d *Daemon, r *http.Request) response.Response {
 	recursion := localUtil.IsRecursionRequest(r)
 	s := d.State()
 
+	// Parse filter value.
+	filterStr := r.FormValue("filter")
+	clauses, err := filter.Parse(filterStr, filter.QueryOperatorSet())
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid filter: %w", err))
+	}
+
+	mustLoadObjects := recursion || (clauses != nil && len(clauses.Clauses) > 0)
+
 	leaderAddress, err := s.Cluster.LeaderAddress()
 	if err != nil {
 		return response.InternalError(err)
@@ -1205,7 +1227,7 @@ func clusterNodesGet(d *Daemon, r *http.Request) response.Response {
 			RaftNodes:            raftNodes,
 		}
 
-		if recursion {
+		if mustLoadObjects {
 			membersInfo = make([]api.ClusterMember, 0, len(members))
 			for i := range members {
 				member, err := members[i].ToAPI(ctx, tx, args)
@@ -1213,6 +1235,17 @@ func clusterNodesGet(d *Daemon, r *http.Request) response.Response {
 					return err
 				}
 
+				if clauses != nil && len(clauses.Clauses) > 0 {
+					match, err := filter.Match(*member, *clauses)
+					if err != nil {
+						return err
+					}
+
+					if !match {
+						continue
+					}
+				}
+
 				membersInfo = append(membersInfo, *member)
 			}
 		}
@@ -1228,9 +1261,16 @@ func clusterNodesGet(d *Daemon, r *http.Request) response.Response {
 	}
 
 	urls := make([]string, 0, len(members))
-	for _, member := range members {
-		u := api.NewURL().Path(version.APIVersion, "cluster", "members", member.Name)
-		urls = append(urls, u.String())
+	if mustLoadObjects {
+		for _, member := range membersInfo {
+			u := api.NewURL().Path(version.APIVersion, "cluster", "members", member.ServerName)
+			urls = append(urls, u.String())
+		}
+	} else {
+		for _, member := range members {
+			u := api.NewURL().Path(version.APIVersion, "cluster", "members", member.Name)
+			urls = append(urls, u.String())
+		}
 	}
 
 	return response.SyncResponse(true, urls)
@@ -1670,6 +1710,18 @@ func updateClusterNode(s *state.State, gateway *cluster.Gateway, r *http.Request
 			return fmt.Errorf("Loading node information: %w", err)
 		}
 
+		// Witnesses can't host instances.
+		if !slices.Contains(memberInfo.Roles, string(db.ClusterRoleWitness)) && slices.Contains(req.Roles, string(db.ClusterRoleWitness)) {
+			instances, err := dbCluster.GetInstances(ctx, tx.Tx(), dbCluster.InstanceFilter{Node: &name})
+			if err != nil {
+				return fmt.Errorf("Failed to get instances: %w", err)
+			}
+
+			if len(instances) > 0 {
+				return api.StatusErrorf(http.StatusBadRequest, "The %q role can't be added to a cluster member hosting instances", db.ClusterRoleWitness)
+			}
+		}
+
 		err = clusterValidateConfig(req.Config)
 		if err != nil {
 			return err
@@ -2689,6 +2741,146 @@ func internalClusterRaftNodeDelete(d *Daemon, r *http.Request) response.Response
 	return response.SyncResponse(true, nil)
 }
 
+// internalClusterRaftMember is the view of the raft cluster of a member, as used by "incus admin cluster heal".
+type internalClusterRaftMember struct {
+	Name          string          `json:"name" yaml:"name"`
+	Address       string          `json:"address" yaml:"address"`
+	Leader        string          `json:"leader" yaml:"leader"`
+	LatestSegment string          `json:"latest_segment" yaml:"latest_segment"`
+	RaftNodes     []ClusterMember `json:"raft_nodes" yaml:"raft_nodes"`
+	Error         string          `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type internalClusterRaftPostRequest struct {
+	Action string `json:"action" yaml:"action"`
+}
+
+// internalClusterRaftGet returns the local view of the raft cluster.
+// With the peers parameter, the views of all the raft members are returned, those which
+// can't be reached being reported with an error.
+func internalClusterRaftGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	local := internalClusterRaftMember{
+		Name:      s.ServerName,
+		Address:   s.LocalConfig.ClusterAddress(),
+		RaftNodes: []ClusterMember{},
+	}
+
+	var raftNodes []db.RaftNode
+	err := s.DB.Node.Transaction(r.Context(), func(ctx context.Context, tx *db.NodeTx) error {
+		var err error
+
+		raftNodes, err = tx.GetRaftNodes(ctx)
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed loading raft nodes: %w", err))
+	}
+
+	for _, node := range raftNodes {
+		local.RaftNodes = append(local.RaftNodes, ClusterMember{ID: node.ID, Name: node.Name, Address: node.Address, Role: node.Role.String()})
+	}
+
+	local.LatestSegment, err = db.DqliteLatestSegment()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Without quorum, there's no leader.
+	local.Leader, err = s.Cluster.LeaderAddress()
+	if err != nil {
+		local.Leader = ""
+	}
+
+	if !util.IsTrue(request.QueryParam(r, "peers")) {
+		return response.SyncResponse(true, local)
+	}
+
+	members := make([]internalClusterRaftMember, len(raftNodes))
+	wg := sync.WaitGroup{}
+	for i, node := range raftNodes {
+		if node.Address == local.Address {
+			members[i] = local
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			members[i] = internalClusterRaftMember{Name: node.Name, Address: node.Address}
+
+			client, err := cluster.Connect(node.Address, s.Endpoints.NetworkCert(), s.ServerCert(), r, true)
+			if err != nil {
+				members[i].Error = err.Error()
+				return
+			}
+
+			resp, _, err := client.RawQuery("GET", "/internal/cluster/raft", nil, "")
+			if err != nil {
+				members[i].Error = err.Error()
+				return
+			}
+
+			err = json.Unmarshal(resp.Metadata, &members[i])
+			if err != nil {
+				members[i].Error = err.Error()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return response.SyncResponse(true, members)
+}
+
+// internalClusterRaftPost runs recovery actions on the raft cluster.
+// The only action is "rebalance", which reassigns the database roles of unreachable members, going through the leader.
+func internalClusterRaftPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+	req := internalClusterRaftPostRequest{}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Action != "rebalance" {
+		return response.BadRequest(fmt.Errorf("Unknown action %q", req.Action))
+	}
+
+	leader, err := s.Cluster.LeaderAddress()
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed getting the leader address: %w", err))
+	}
+
+	if leader != s.LocalConfig.ClusterAddress() {
+		client, err := cluster.Connect(leader, s.Endpoints.NetworkCert(), s.ServerCert(), r, true)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		_, _, err = client.RawQuery("POST", "/internal/cluster/rebalance", nil, "")
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		return response.EmptySyncResponse
+	}
+
+	d.clusterMembershipMutex.Lock()
+	defer d.clusterMembershipMutex.Unlock()
+
+	err = rebalanceMemberRoles(s, d.gateway, r, nil)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
 // swagger:operation GET /1.0/cluster/members/{name}/state cluster cluster_member_state_get
 //
 //	Get state of the cluster member
@@ -2793,6 +2985,11 @@ func clusterNodeStatePost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	// Draining always picks the most appropriate action for each instance.
+	if req.Action == "drain" && req.Mode != "" {
+		return response.BadRequest(fmt.Errorf("The evacuation mode can't be overridden when draining"))
+	}
+
 	// Validate the overrides.
 	if req.Action == "evacuate" && req.Mode != "" {
 		// Use the validator from the instance logic.
@@ -2803,7 +3000,12 @@ func clusterNodeStatePost(d *Daemon, r *http.Request) response.Response {
 		}
 	}
 
-	if req.Action == "evacuate" {
+	if req.Action == "evacuate" || req.Action == "drain" {
+		mode := req.Mode
+		if req.Action == "drain" {
+			mode = "drain"
+		}
+
 		stopFunc := func(inst instance.Instance, action string) error {
 			l := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
 
@@ -2892,7 +3094,7 @@ func clusterNodeStatePost(d *Daemon, r *http.Request) response.Response {
 		}
 
 		run := func(op *operations.Operation) error {
-			return evacuateClusterMember(context.Background(), s, op, name, req.Mode, stopFunc, migrateFunc)
+			return evacuateClusterMember(context.Background(), s, op, name, mode, stopFunc, migrateFunc)
 		}
 
 		op, err := operations.OperationCreate(s, "", operations.OperationClassTask, operationtype.ClusterMemberEvacuate, nil, nil, run, nil, nil, r)
@@ -30,7 +30,102 @@ import (
 var clusterCertificateCmd = APIEndpoint{
 	Path: "cluster/certificate",
 
-	Put: APIEndpointAction{Handler: clusterCertificatePut, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Post: APIEndpointAction{Handler: clusterCertificatePost, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Put:  APIEndpointAction{Handler: clusterCertificatePut, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+// swagger:operation POST /1.0/cluster/certificate cluster clustering_renew_cert
+//
+//	Renew the certificate for the cluster
+//
+//	Replaces the cluster certificate with a newly issued one, through ACME if configured or
+//	self-signed otherwise, and reloads each cluster member.
+//	This can be used to rotate the certificate and its key ahead of time, for example after a compromise.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: New cluster certificate
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: string
+//	          description: The new cluster certificate (PEM encoded)
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func clusterCertificatePost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	if !s.ServerClustered {
+		return response.BadRequest(fmt.Errorf("This server isn't clustered"))
+	}
+
+	// The renewal is handled by the leader.
+	leader, err := s.Cluster.LeaderAddress()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if leader != s.LocalConfig.ClusterAddress() {
+		client, err := cluster.Connect(leader, s.Endpoints.NetworkCert(), s.ServerCert(), r, false)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		return response.ForwardedResponse(client, r)
+	}
+
+	err = renewClusterCertificate(r.Context(), d)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	requestor := request.CreateRequestor(r)
+	s.Events.SendLifecycle(request.ProjectParam(r), lifecycle.ClusterCertificateUpdated.Event("certificate", requestor, nil))
+
+	return response.SyncResponse(true, string(s.Endpoints.NetworkCert().PublicKey()))
+}
+
+// renewClusterCertificate replaces the cluster certificate with a newly issued one.
+func renewClusterCertificate(ctx context.Context, d *Daemon) error {
+	s := d.State()
+
+	domain, email, _, agreeToS, challengeType := s.GlobalConfig.ACME()
+	if domain != "" && email != "" && agreeToS && challengeType != "" {
+		return autoRenewCertificate(ctx, d, true)
+	}
+
+	cert, key, err := localtls.GenerateMemCert(false, true)
+	if err != nil {
+		return fmt.Errorf("Failed generating the cluster certificate: %w", err)
+	}
+
+	req := api.ClusterCertificatePut{
+		ClusterCertificate:    string(cert),
+		ClusterCertificateKey: string(key),
+	}
+
+	return updateClusterCertificate(ctx, s, d.gateway, nil, req)
 }
 
 // swagger:operation PUT /1.0/cluster/certificate cluster clustering_update_cert
@@ -84,6 +179,26 @@ func clusterCertificatePut(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("Private key must be base64 encoded PEM key: %w", err))
 	}
 
+	// Handle the stages of a coordinated rotation across the cluster.
+	stage := request.QueryParam(r, "stage")
+	if stage != "" && isClusterNotification(r) {
+		var cert *localtls.CertInfo
+
+		if stage == "prepare" {
+			cert, err = localtls.KeyPairFromRaw(certBytes, keyBytes)
+			if err != nil {
+				return response.BadRequest(err)
+			}
+		}
+
+		err = cluster.SetAdditionalNetworkCert(cert)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		return response.EmptySyncResponse
+	}
+
 	err = updateClusterCertificate(r.Context(), s, d.gateway, r, req)
 	if err != nil {
 		return response.SmartError(err)
@@ -156,6 +271,21 @@ func updateClusterCertificate(ctx context.Context, s *state.State, gateway *clus
 			return err
 		}
 
+		// Have all members trust the new certificate before any of them switches to it, so that the
+		// connections between members keep working during the rotation. Once done, only the new
+		// certificate is trusted.
+		err = notifyClusterCertificateStage(s, members, r, "prepare", req)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			err := notifyClusterCertificateStage(s, members, r, "complete", req)
+			if err != nil {
+				logger.Warn("Failed completing the cluster certificate rotation", logger.Ctx{"err": err})
+			}
+		}()
+
 		var c incus.InstanceServer
 
 		for i := range members {
@@ -192,7 +322,13 @@ func updateClusterCertificate(ctx context.Context, s *state.State, gateway *clus
 		}
 	}
 
-	err := internalUtil.WriteCert(s.OS.VarDir, "cluster", []byte(req.ClusterCertificate), []byte(req.ClusterCertificateKey), nil)
+	// Keep trusting the previous certificate until the rotation is complete on all members.
+	err := cluster.SetAdditionalNetworkCert(s.Endpoints.NetworkCert())
+	if err != nil {
+		return err
+	}
+
+	err = internalUtil.WriteCert(s.OS.VarDir, "cluster", []byte(req.ClusterCertificate), []byte(req.ClusterCertificateKey), nil)
 	if err != nil {
 		return err
 	}
@@ -221,3 +357,37 @@ func updateClusterCertificate(ctx context.Context, s *state.State, gateway *clus
 
 	return nil
 }
+
+// notifyClusterCertificateStage notifies all cluster members, including the local one, of a stage of the
+// rotation of the cluster certificate.
+func notifyClusterCertificateStage(s *state.State, members []db.NodeInfo, r *http.Request, stage string, req api.ClusterCertificatePut) error {
+	localClusterAddress := s.LocalConfig.ClusterAddress()
+
+	for _, member := range members {
+		if member.Address == localClusterAddress {
+			continue
+		}
+
+		c, err := cluster.Connect(member.Address, s.Endpoints.NetworkCert(), s.ServerCert(), r, true)
+		if err != nil {
+			return err
+		}
+
+		_, _, err = c.RawQuery("PUT", "/1.0/cluster/certificate?stage="+stage, req, "")
+		if err != nil {
+			return fmt.Errorf("Failed notifying cluster member %q: %w", member.Name, err)
+		}
+	}
+
+	var cert *localtls.CertInfo
+	if stage == "prepare" {
+		var err error
+
+		cert, err = localtls.KeyPairFromRaw([]byte(req.ClusterCertificate), []byte(req.ClusterCertificateKey))
+		if err != nil {
+			return err
+		}
+	}
+
+	return cluster.SetAdditionalNetworkCert(cert)
+}
@@ -11,6 +11,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -51,6 +52,31 @@ type evacuateOpts struct {
 	stopInstance    evacuateStopFunc
 	migrateInstance evacuateMigrateFunc
 	op              *operations.Operation
+	plan            *evacuatePlan
+}
+
+// evacuatePlan records what draining does to each instance, so it can be reported as the restore plan.
+type evacuatePlan struct {
+	mu      sync.Mutex
+	entries []api.ClusterMemberDrainEntry
+}
+
+// add records the outcome for an instance, doing nothing when no plan is being recorded.
+func (p *evacuatePlan) add(inst instance.Instance, action string, location string, restore string) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.entries = append(p.entries, api.ClusterMemberDrainEntry{
+		Project:  inst.Project().Name,
+		Instance: inst.Name(),
+		Action:   action,
+		Location: location,
+		Restore:  restore,
+	})
 }
 
 func evacuateClusterSetState(s *state.State, name string, newState int) error {
@@ -142,11 +168,24 @@ func evacuateClusterMember(ctx context.Context, s *state.State, op *operations.O
 		op:              op,
 	}
 
+	if mode == "drain" {
+		opts.plan = &evacuatePlan{entries: []api.ClusterMemberDrainEntry{}}
+	}
+
 	err = evacuateInstances(ctx, opts)
 	if err != nil {
 		return err
 	}
 
+	// Report the restore plan.
+	if opts.plan != nil {
+		slices.SortFunc(opts.plan.entries, func(a api.ClusterMemberDrainEntry, b api.ClusterMemberDrainEntry) int {
+			return strings.Compare(a.Project+"/"+a.Instance, b.Project+"/"+b.Instance)
+		})
+
+		_ = op.UpdateMetadata(map[string]any{"restore_plan": opts.plan.entries})
+	}
+
 	// Stop networks after evacuation.
 	networkShutdown(s)
 
@@ -205,7 +244,7 @@ func evacuateInstancesFunc(ctx context.Context, inst instance.Instance, opts eva
 				// We can only migrate instances or leave them as they are.
 				return nil
 			}
-		} else if opts.mode != "auto" {
+		} else if opts.mode != "auto" && opts.mode != "drain" {
 			action = opts.mode
 		}
 	}
@@ -225,6 +264,12 @@ func evacuateInstancesFunc(ctx context.Context, inst instance.Instance, opts eva
 
 		if action != "migrate" {
 			// Done with this instance.
+			if isRunning && opts.stopInstance != nil {
+				opts.plan.add(inst, action, "", "start")
+			} else {
+				opts.plan.add(inst, "none", "", "none")
+			}
+
 			return nil
 		}
 	} else if !isRunning {
@@ -235,6 +280,29 @@ func evacuateInstancesFunc(ctx context.Context, inst instance.Instance, opts eva
 	// Find a new location for the instance.
 	sourceMemberInfo, targetMemberInfo, err := evacuateClusterSelectTarget(ctx, opts.s, inst)
 	if err != nil {
+		if opts.mode == "drain" && api.StatusErrorCheck(err, http.StatusNotFound, http.StatusConflict) {
+			// When draining, cleanly stop what can't be moved.
+			l.Warn("No migration target available for instance, stopping it", logger.Ctx{"err": err})
+
+			if action == "live-migrate" && opts.stopInstance != nil {
+				metadata["evacuation_progress"] = fmt.Sprintf("Stopping %q in project %q", inst.Name(), instProject.Name)
+				_ = opts.op.UpdateMetadata(metadata)
+
+				err := opts.stopInstance(inst, "stop")
+				if err != nil {
+					return err
+				}
+			}
+
+			if isRunning {
+				opts.plan.add(inst, "stop", "", "start")
+			} else {
+				opts.plan.add(inst, "none", "", "none")
+			}
+
+			return nil
+		}
+
 		if api.StatusErrorCheck(err, http.StatusNotFound) {
 			// Skip migration if no target is available.
 			l.Warn("No migration target available for instance")
@@ -259,6 +327,8 @@ func evacuateInstancesFunc(ctx context.Context, inst instance.Instance, opts eva
 		return err
 	}
 
+	opts.plan.add(inst, action, targetMemberInfo.Name, "migrate")
+
 	return nil
 }
 
@@ -329,7 +399,8 @@ func restoreClusterMember(d *Daemon, r *http.Request) response.Response {
 			return err
 		}
 
-		// Restart the local instances.
+		// Restart the local instances, starting dependencies first.
+		localInstances = instancesSortByDependencies(localInstances)
 		for _, inst := range localInstances {
 			// Don't start instances which were stopped by the user.
 			if inst.LocalConfig()["volatile.last_state.power"] != instance.PowerStateRunning {
@@ -346,6 +417,9 @@ func restoreClusterMember(d *Daemon, r *http.Request) response.Response {
 			metadata["evacuation_progress"] = fmt.Sprintf("Starting %q in project %q", inst.Name(), inst.Project().Name)
 			_ = op.UpdateMetadata(metadata)
 
+			// Wait for the instances it depends on to be ready.
+			instanceWaitDependencies(s, inst, localInstances)
+
 			// If configured for stateful stop, try restoring its state.
 			action := inst.CanMigrate()
 			if action == "stateful-stop" {
@@ -566,6 +640,12 @@ func evacuateClusterSelectTarget(ctx context.Context, s *state.State, inst insta
 			return err
 		}
 
+		// Filter servers not satisfying the placement rules.
+		candidateMembers, err = clusterMembersWithPlacementRules(ctx, tx, inst.Project().Name, inst.Name(), inst.ExpandedConfig(), candidateMembers)
+		if err != nil {
+			return err
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -619,7 +699,7 @@ func evacuateClusterSelectTarget(ctx context.Context, s *state.State, inst insta
 	}
 
 	if targetMemberInfo == nil {
-		return nil, nil, fmt.Errorf("Couldn't find a cluster member for the instance")
+		return nil, nil, api.StatusErrorf(http.StatusNotFound, "Couldn't find a cluster member for the instance")
 	}
 
 	return sourceMemberInfo, targetMemberInfo, nil
@@ -12,6 +12,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/lxc/incus/v6/internal/filter"
 	"github.com/lxc/incus/v6/internal/server/auth"
 	"github.com/lxc/incus/v6/internal/server/db"
 	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
@@ -21,6 +22,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/response"
 	"github.com/lxc/incus/v6/internal/server/state"
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
+	"github.com/lxc/incus/v6/internal/version"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/osarch"
@@ -151,6 +153,12 @@ func clusterGroupsPost(d *Daemon, r *http.Request) response.Response {
 //  ---
 //  produces:
 //    - application/json
+//  parameters:
+//    - in: query
+//      name: filter
+//      description: Collection filter
+//      type: string
+//      example: default
 //  responses:
 //    "200":
 //      description: API endpoints
@@ -194,6 +202,12 @@ func clusterGroupsPost(d *Daemon, r *http.Request) response.Response {
 //	---
 //	produces:
 //	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: filter
+//	    description: Collection filter
+//	    type: string
+//	    example: default
 //	responses:
 //	  "200":
 //	    description: API endpoints
@@ -231,12 +245,21 @@ func clusterGroupsGet(d *Daemon, r *http.Request) response.Response {
 
 	recursion := localUtil.IsRecursionRequest(r)
 
+	// Parse filter value.
+	filterStr := r.FormValue("filter")
+	clauses, err := filter.Parse(filterStr, filter.QueryOperatorSet())
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid filter: %w", err))
+	}
+
+	mustLoadObjects := recursion || (clauses != nil && len(clauses.Clauses) > 0)
+
 	var result any
 
-	err := s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		var err error
 
-		if recursion {
+		if mustLoadObjects {
 			clusterGroups, err := dbCluster.GetClusterGroups(ctx, tx.Tx())
 			if err != nil {
 				return err
@@ -254,17 +277,36 @@ func clusterGroupsGet(d *Daemon, r *http.Request) response.Response {
 				}
 			}
 
-			apiClusterGroups := make([]*api.ClusterGroup, len(clusterGroups))
-			for i, clusterGroup := range clusterGroups {
+			apiClusterGroups := make([]*api.ClusterGroup, 0, len(clusterGroups))
+			urls := make([]string, 0, len(clusterGroups))
+			for _, clusterGroup := range clusterGroups {
 				members, err := tx.GetClusterGroupNodes(ctx, clusterGroup.Name)
 				if err != nil {
 					return err
 				}
 
-				apiClusterGroups[i] = db.ClusterGroupToAPI(&clusterGroup, members)
+				apiClusterGroup := db.ClusterGroupToAPI(&clusterGroup, members)
+
+				if clauses != nil && len(clauses.Clauses) > 0 {
+					match, err := filter.Match(*apiClusterGroup, *clauses)
+					if err != nil {
+						return err
+					}
+
+					if !match {
+						continue
+					}
+				}
+
+				apiClusterGroups = append(apiClusterGroups, apiClusterGroup)
+				urls = append(urls, api.NewURL().Path(version.APIVersion, "cluster", "groups", clusterGroup.Name).String())
 			}
 
-			result = apiClusterGroups
+			if recursion {
+				result = apiClusterGroups
+			} else {
+				result = urls
+			}
 		} else {
 			result, err = tx.GetClusterGroupURIs(ctx, dbCluster.ClusterGroupFilter{})
 		}
@@ -888,7 +930,29 @@ func clusterGroupValidateName(name string) error {
 
 // clusterGroupValidate validates the configuration keys/values for cluster groups.
 func clusterGroupValidate(config map[string]string) error {
-	configKeys := map[string]func(value string) error{}
+	configKeys := map[string]func(value string) error{
+		// gendoc:generate(entity=cluster_group, group=common, key=backups.compression_algorithm)
+		// Overrides {config:option}`server-miscellaneous:backups.compression_algorithm` for the members of the group.
+		// ---
+		//  type: string
+		//  shortdesc: Compression algorithm to use for backups
+		"backups.compression_algorithm": validate.Optional(validate.IsCompressionAlgorithm),
+
+		// gendoc:generate(entity=cluster_group, group=common, key=images.compression_algorithm)
+		// Overrides {config:option}`server-images:images.compression_algorithm` for the members of the group.
+		// ---
+		//  type: string
+		//  shortdesc: Compression algorithm to use for new images
+		"images.compression_algorithm": validate.Optional(validate.IsCompressionAlgorithm),
+
+		// gendoc:generate(entity=cluster_group, group=common, key=scheduler.instance)
+		// Used for the members of the group which don't set {config:option}`cluster-cluster:scheduler.instance` themselves.
+		// Possible values are `all`, `manual`, and `group`.
+		// ---
+		//  type: string
+		//  shortdesc: Controls how instances are scheduled to run on the members of the group
+		"scheduler.instance": validate.Optional(validate.IsOneOf("all", "group", "manual")),
+	}
 
 	// Add architecture keys.
 	for _, arch := range osarch.SupportedArchitectures() {
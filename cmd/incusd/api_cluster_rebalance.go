@@ -15,26 +15,31 @@ import (
 	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/internal/server/task"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/util"
 )
 
 // ServerScore represents server score taken into account during load balancing.
 type ServerScore struct {
 	NodeInfo  db.NodeInfo
 	Resources *api.Resources
+	Usage     *ServerUsage
 	Score     uint8
 }
 
 // ServerUsage represents current server load.
 type ServerUsage struct {
-	MemoryUsage uint64
-	MemoryTotal uint64
-	CPUUsage    float64
-	CPUTotal    uint64
+	MemoryUsage   uint64
+	MemoryTotal   uint64
+	CPUUsage      float64
+	CPUTotal      uint64
+	InstanceCount uint64
+	InstanceTotal uint64
 }
 
 // sortAndGroupByArch sorts servers by its score and groups them by cpu architecture.
@@ -71,15 +76,46 @@ func calculateScore(su *ServerUsage, au *ServerUsage) uint8 {
 		cpuTotal += au.CPUTotal
 	}
 
-	memoryScore := uint8(float64(memoryUsage) * 100 / float64(memoryTotal))
-	cpuScore := uint8((cpuUsage * 100) / float64(cpuTotal))
+	memoryScore := float64(memoryUsage) * 100 / float64(memoryTotal)
+	cpuScore := (cpuUsage * 100) / float64(cpuTotal)
 
-	return (memoryScore + cpuScore) / 2
+	// The instance count is relative to the instances of all the servers being compared.
+	if su.InstanceTotal == 0 {
+		return uint8((memoryScore + cpuScore) / 2)
+	}
+
+	instanceCount := su.InstanceCount
+	if au != nil {
+		instanceCount += au.InstanceCount
+	}
+
+	instanceScore := float64(instanceCount) * 100 / float64(su.InstanceTotal)
+
+	return uint8((memoryScore + cpuScore + instanceScore) / 3)
 }
 
-// calculateServersScore calculates score based on memory and CPU usage for servers in cluster.
-func calculateServersScore(s *state.State, members []db.NodeInfo) (map[string][]*ServerScore, error) {
+// calculateServersScore calculates score based on memory, CPU usage and instance count for servers in cluster.
+func calculateServersScore(ctx context.Context, s *state.State, members []db.NodeInfo) (map[string][]*ServerScore, error) {
+	// Count the instances of each server.
+	instanceCounts := map[string]uint64{}
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbInstances, err := dbCluster.GetInstances(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		for _, dbInst := range dbInstances {
+			instanceCounts[dbInst.Node]++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get instances: %w", err)
+	}
+
 	scores := []*ServerScore{}
+	instanceTotals := map[string]uint64{}
 	for _, member := range members {
 		clusterMember, err := cluster.Connect(member.Address, s.Endpoints.NetworkCert(), s.ServerCert(), nil, true)
 		if err != nil {
@@ -92,14 +128,21 @@ func calculateServersScore(s *state.State, members []db.NodeInfo) (map[string][]
 		}
 
 		su := &ServerUsage{
-			MemoryUsage: res.Memory.Used,
-			MemoryTotal: res.Memory.Total,
-			CPUUsage:    res.Load.Average1Min,
-			CPUTotal:    res.CPU.Total,
+			MemoryUsage:   res.Memory.Used,
+			MemoryTotal:   res.Memory.Total,
+			CPUUsage:      res.Load.Average1Min,
+			CPUTotal:      res.CPU.Total,
+			InstanceCount: instanceCounts[member.Name],
 		}
 
-		serverScore := calculateScore(su, nil)
-		scores = append(scores, &ServerScore{NodeInfo: member, Resources: res, Score: serverScore})
+		instanceTotals[res.CPU.Architecture] += su.InstanceCount
+		scores = append(scores, &ServerScore{NodeInfo: member, Resources: res, Usage: su})
+	}
+
+	// Servers are only compared to those of the same architecture.
+	for _, score := range scores {
+		score.Usage.InstanceTotal = instanceTotals[score.Resources.CPU.Architecture]
+		score.Score = calculateScore(score.Usage, nil)
 	}
 
 	return sortAndGroupByArch(scores), nil
@@ -164,11 +207,30 @@ func clusterRebalanceServers(ctx context.Context, s *state.State, srcServer *Ser
 			return -1, fmt.Errorf("Failed to load instance: %w", err)
 		}
 
+		// Skip instances excluded from re-balancing.
+		if util.IsTrue(inst.ExpandedConfig()["cluster.rebalance.exclude"]) {
+			continue
+		}
+
+		// Skip instances whose project doesn't allow background tasks right now.
+		if !project.InMaintenanceWindow(inst.Project(), time.Now()) {
+			continue
+		}
+
 		// Do not allow to migrate instance which doesn't support live migration.
 		if inst.CanMigrate() != "live-migrate" {
 			continue
 		}
 
+		// Skip instances whose placement rules prevent moving to that target.
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			_, err := clusterMembersWithPlacementRules(ctx, tx, inst.Project().Name, inst.Name(), inst.ExpandedConfig(), []db.NodeInfo{dstServer.NodeInfo})
+			return err
+		})
+		if err != nil {
+			continue
+		}
+
 		// Check if instance is ready for next migration.
 		lastMove := inst.LocalConfig()["volatile.rebalance.last_move"]
 		cooldown := s.GlobalConfig.ClusterRebalanceCooldown()
@@ -194,12 +256,8 @@ func clusterRebalanceServers(ctx context.Context, s *state.State, srcServer *Ser
 	// Calculate current and target scores.
 	targetScore := (srcServer.Score + dstServer.Score) / 2
 	currentScore := dstServer.Score
-	targetServerUsage := &ServerUsage{
-		MemoryUsage: dstServer.Resources.Memory.Used,
-		MemoryTotal: dstServer.Resources.Memory.Total,
-		CPUUsage:    dstServer.Resources.Load.Average1Min,
-		CPUTotal:    dstServer.Resources.CPU.Total,
-	}
+	targetServerUsage := *dstServer.Usage
+	dryRun := s.GlobalConfig.ClusterRebalanceDryRun()
 
 	// Prepare the API client.
 	srcNode, err := cluster.Connect(srcServer.NodeInfo.Address, s.Endpoints.NetworkCert(), s.ServerCert(), nil, true)
@@ -228,43 +286,51 @@ func clusterRebalanceServers(ctx context.Context, s *state.State, srcServer *Ser
 
 		// Calculate impact of migration.
 		additionalUsage := &ServerUsage{
-			MemoryUsage: uint64(cpuUsage),
-			CPUUsage:    float64(memUsage),
+			MemoryUsage:   uint64(memUsage),
+			CPUUsage:      float64(cpuUsage),
+			InstanceCount: 1,
 		}
 
-		expectedScore := calculateScore(targetServerUsage, additionalUsage)
+		expectedScore := calculateScore(&targetServerUsage, additionalUsage)
 		if expectedScore >= targetScore {
 			// Skip the instance as it would have too big an impact.
 			continue
 		}
 
-		// Prepare for live migration.
-		req := api.InstancePost{
-			Migration: true,
-			Live:      true,
-		}
+		if dryRun {
+			logger.Info("Automatic re-balancing would move instance", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "source": srcServer.NodeInfo.Name, "target": dstServer.NodeInfo.Name, "expectedScore": expectedScore})
+		} else {
+			// Prepare for live migration.
+			req := api.InstancePost{
+				Migration: true,
+				Live:      true,
+			}
 
-		migrationOp, err := srcNode.MigrateInstance(inst.Name(), req)
-		if err != nil {
-			return -1, fmt.Errorf("Migration API failure: %w", err)
-		}
+			migrationOp, err := srcNode.MigrateInstance(inst.Name(), req)
+			if err != nil {
+				return -1, fmt.Errorf("Migration API failure: %w", err)
+			}
 
-		err = migrationOp.Wait()
-		if err != nil {
-			return -1, fmt.Errorf("Failed to wait for migration to finish: %w", err)
-		}
+			err = migrationOp.Wait()
+			if err != nil {
+				return -1, fmt.Errorf("Failed to wait for migration to finish: %w", err)
+			}
 
-		// Record the migration in the instance volatile storage.
-		err = inst.VolatileSet(map[string]string{"volatile.rebalance.last_move": strconv.FormatInt(time.Now().Unix(), 10)})
-		if err != nil {
-			return -1, err
+			// Record the migration in the instance volatile storage.
+			err = inst.VolatileSet(map[string]string{"volatile.rebalance.last_move": strconv.FormatInt(time.Now().Unix(), 10)})
+			if err != nil {
+				return -1, err
+			}
 		}
 
+		s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceRebalanced.Event(inst, map[string]any{"source": srcServer.NodeInfo.Name, "target": dstServer.NodeInfo.Name, "dry_run": dryRun}))
+
 		// Update counters and scores.
 		numOfMigrated += 1
 		currentScore = expectedScore
 		targetServerUsage.MemoryUsage += additionalUsage.MemoryUsage
 		targetServerUsage.CPUUsage += additionalUsage.CPUUsage
+		targetServerUsage.InstanceCount += additionalUsage.InstanceCount
 	}
 
 	return numOfMigrated, nil
@@ -349,7 +415,7 @@ func autoRebalanceCluster(ctx context.Context, d *Daemon) error {
 		return fmt.Errorf("Failed getting cluster members: %w", err)
 	}
 
-	servers, err := calculateServersScore(s, onlineMembers)
+	servers, err := calculateServersScore(ctx, s, onlineMembers)
 	if err != nil {
 		return fmt.Errorf("Failed calculating servers score: %w", err)
 	}
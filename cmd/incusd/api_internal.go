@@ -48,15 +48,19 @@ import (
 )
 
 var apiInternal = []APIEndpoint{
+	internalAuditCmd,
+	internalAuditVerifyCmd,
 	internalBGPStateCmd,
 	internalClusterAcceptCmd,
 	internalClusterAssignCmd,
 	internalClusterHandoverCmd,
+	internalClusterRaftCmd,
 	internalClusterRaftNodeCmd,
 	internalClusterRebalanceCmd,
 	internalContainerOnStartCmd,
 	internalContainerOnStopCmd,
 	internalContainerOnStopNSCmd,
+	internalDebugPprofCmd,
 	internalVirtualMachineOnResizeCmd,
 	internalGarbageCollectorCmd,
 	internalImageOptimizeCmd,
@@ -64,8 +68,10 @@ var apiInternal = []APIEndpoint{
 	internalRAFTSnapshotCmd,
 	internalRebalanceLoadCmd,
 	internalReadyCmd,
+	internalReloadCmd,
 	internalShutdownCmd,
 	internalSQLCmd,
+	internalSQLSlowCmd,
 	internalWarningCreateCmd,
 }
 
@@ -76,6 +82,20 @@ var internalReadyCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: internalWaitReady, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
 }
 
+var internalReloadCmd = APIEndpoint{
+	Path: "reload",
+
+	Put: APIEndpointAction{Handler: internalReload, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+var internalDebugPprofCmd = APIEndpoint{
+	Path: "debug/pprof",
+
+	Delete: APIEndpointAction{Handler: internalDebugPprofDelete, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Get:    APIEndpointAction{Handler: internalDebugPprofGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Put:    APIEndpointAction{Handler: internalDebugPprofPut, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
 var internalShutdownCmd = APIEndpoint{
 	Path: "shutdown",
 
@@ -102,6 +122,12 @@ var internalSQLCmd = APIEndpoint{
 	Post: APIEndpointAction{Handler: internalSQLPost, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
 }
 
+var internalSQLSlowCmd = APIEndpoint{
+	Path: "sql/slow",
+
+	Get: APIEndpointAction{Handler: internalSQLSlowGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
 // Internal cluster traffic.
 var internalClusterAcceptCmd = APIEndpoint{
 	Path: "cluster/accept",
@@ -121,6 +147,13 @@ var internalClusterHandoverCmd = APIEndpoint{
 	Post: APIEndpointAction{Handler: internalClusterPostHandover, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
 }
 
+var internalClusterRaftCmd = APIEndpoint{
+	Path: "cluster/raft",
+
+	Get:  APIEndpointAction{Handler: internalClusterRaftGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Post: APIEndpointAction{Handler: internalClusterRaftPost, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
 var internalClusterRaftNodeCmd = APIEndpoint{
 	Path: "cluster/raft-node/{address}",
 
@@ -289,6 +322,25 @@ func internalWaitReady(d *Daemon, _ *http.Request) response.Response {
 	return response.EmptySyncResponse
 }
 
+func internalReload(d *Daemon, _ *http.Request) response.Response {
+	logger.Info("Asked to reload the configuration by API")
+
+	if d.State().ShutdownCtx.Err() != nil {
+		return response.Unavailable(fmt.Errorf("Daemon is shutting down"))
+	}
+
+	if d.waitReady.Err() == nil {
+		return response.Unavailable(fmt.Errorf("Daemon not ready yet"))
+	}
+
+	err := daemonConfigReload(d)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed reloading the configuration: %w", err))
+	}
+
+	return response.EmptySyncResponse
+}
+
 func internalShutdown(d *Daemon, r *http.Request) response.Response {
 	force := request.QueryParam(r, "force")
 	logger.Info("Asked to shutdown by API", logger.Ctx{"force": force})
@@ -538,6 +590,26 @@ func internalSQLGet(d *Daemon, r *http.Request) response.Response {
 	return response.SyncResponse(true, internalSQL.SQLDump{Text: dump})
 }
 
+// List the slow queries of the cluster database.
+func internalSQLSlowGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	queries := s.DB.Cluster.SlowLog().Queries()
+
+	result := make([]internalSQL.SQLSlowQuery, 0, len(queries))
+	for _, entry := range queries {
+		result = append(result, internalSQL.SQLSlowQuery{
+			Timestamp: entry.Timestamp,
+			Duration:  entry.Duration.Seconds(),
+			Query:     entry.Query,
+			Args:      entry.Args,
+			Caller:    entry.Caller,
+		})
+	}
+
+	return response.SyncResponse(true, result)
+}
+
 // Execute queries.
 func internalSQLPost(d *Daemon, r *http.Request) response.Response {
 	s := d.State()
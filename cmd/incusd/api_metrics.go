@@ -103,6 +103,7 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 	metricSet := metrics.NewMetricSet(nil)
 
 	var projectNames []string
+	var internal *metrics.MetricSet
 
 	err := s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		// Figure out the projects to retrieve.
@@ -122,7 +123,10 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 		}
 
 		// Add internal metrics.
-		metricSet.Merge(internalMetrics(ctx, s.StartTime, tx))
+		internal = internalMetrics(ctx, s.StartTime, tx)
+		internal.Merge(d.eventCounters.MetricSet(s.ServerName))
+		internal.Merge(d.requestMetrics.MetricSet(s.ServerName))
+		metricSet.Merge(internal)
 
 		return nil
 	})
@@ -178,8 +182,9 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 
 	defer unlock()
 
-	// Setup a new response.
+	// Setup a new response, keeping the internal metrics.
 	metricSet = metrics.NewMetricSet(nil)
+	metricSet.Merge(internal)
 
 	// Check if any of the missing data has been filled in since acquiring the lock.
 	// As its possible another request was already populating the cache when we tried to take the lock.
@@ -6,10 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"net"
 	"net/http"
 	"net/url"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
@@ -32,6 +34,7 @@ import (
 	"github.com/lxc/incus/v6/internal/version"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/units"
 	"github.com/lxc/incus/v6/shared/util"
 	"github.com/lxc/incus/v6/shared/validate"
 )
@@ -40,23 +43,29 @@ var projectsCmd = APIEndpoint{
 	Path: "projects",
 
 	Get:  APIEndpointAction{Handler: projectsGet, AccessHandler: allowAuthenticated},
-	Post: APIEndpointAction{Handler: projectsPost, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanCreateProjects)},
+	Post: APIEndpointAction{Handler: projectsPost, AccessHandler: allowAuthenticated},
 }
 
 var projectCmd = APIEndpoint{
 	Path: "projects/{name}",
 
-	Delete: APIEndpointAction{Handler: projectDelete, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanEdit, "name")},
-	Get:    APIEndpointAction{Handler: projectGet, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanView, "name")},
-	Patch:  APIEndpointAction{Handler: projectPatch, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanEdit, "name")},
-	Post:   APIEndpointAction{Handler: projectPost, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanEdit, "name")},
-	Put:    APIEndpointAction{Handler: projectPut, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanEdit, "name")},
+	Delete: APIEndpointAction{Handler: projectDelete, AccessHandler: allowProjectPermission(auth.EntitlementCanEdit)},
+	Get:    APIEndpointAction{Handler: projectGet, AccessHandler: allowProjectPermission(auth.EntitlementCanView)},
+	Patch:  APIEndpointAction{Handler: projectPatch, AccessHandler: allowProjectPermission(auth.EntitlementCanEdit)},
+	Post:   APIEndpointAction{Handler: projectPost, AccessHandler: allowProjectPermission(auth.EntitlementCanEdit)},
+	Put:    APIEndpointAction{Handler: projectPut, AccessHandler: allowProjectPermission(auth.EntitlementCanEdit)},
 }
 
 var projectStateCmd = APIEndpoint{
 	Path: "projects/{name}/state",
 
-	Get: APIEndpointAction{Handler: projectStateGet, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanView, "name")},
+	Get: APIEndpointAction{Handler: projectStateGet, AccessHandler: allowProjectPermission(auth.EntitlementCanView)},
+}
+
+var projectAuditCmd = APIEndpoint{
+	Path: "projects/{name}/audit",
+
+	Get: APIEndpointAction{Handler: projectAuditGet, AccessHandler: allowProjectPermission(auth.EntitlementCanView)},
 }
 
 var projectAccessCmd = APIEndpoint{
@@ -65,6 +74,52 @@ var projectAccessCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: projectAccess, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanEdit, "name")},
 }
 
+// allowProjectPermission is like allowPermission for projects, but also grants the entitlement to the users
+// having it on one of the parent projects, so that the management of child projects can be delegated.
+func allowProjectPermission(entitlement auth.Entitlement) func(d *Daemon, r *http.Request) response.Response {
+	return func(d *Daemon, r *http.Request) response.Response {
+		resp := allowPermission(auth.ObjectTypeProject, entitlement, "name")(d, r)
+		if resp == response.EmptySyncResponse {
+			return resp
+		}
+
+		name, err := url.PathUnescape(mux.Vars(r)["name"])
+		if err != nil {
+			return resp
+		}
+
+		s := d.State()
+
+		var parents []api.Project
+		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+			dbProject, err := cluster.GetProject(ctx, tx.Tx(), name)
+			if err != nil {
+				return err
+			}
+
+			project, err := dbProject.ToAPI(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			parents, err = projectParents(ctx, tx, project.Config)
+			return err
+		})
+		if err != nil {
+			return resp
+		}
+
+		for _, parent := range parents {
+			err = s.Authorizer.CheckPermission(r.Context(), r, auth.ObjectProject(parent.Name), entitlement)
+			if err == nil {
+				return response.EmptySyncResponse
+			}
+		}
+
+		return resp
+	}
+}
+
 // swagger:operation GET /1.0/projects projects projects_get
 //
 //  Get the projects
@@ -158,10 +213,58 @@ var projectAccessCmd = APIEndpoint{
 //    "500":
 //      $ref: "#/responses/InternalServerError"
 
+// swagger:operation GET /1.0/projects?recursion=2 projects projects_get_recursion2
+//
+//  Get the projects with their resource usage
+//
+//  Returns a list of projects (structs), along with their current resource usage and limits.
+//
+//  ---
+//  produces:
+//    - application/json
+//  parameters:
+//    - in: query
+//      name: filter
+//      description: Collection filter
+//      type: string
+//      example: default
+//  responses:
+//    "200":
+//      description: API endpoints
+//      schema:
+//        type: object
+//        description: Sync response
+//        properties:
+//          type:
+//            type: string
+//            description: Response type
+//            example: sync
+//          status:
+//            type: string
+//            description: Status description
+//            example: Success
+//          status_code:
+//            type: integer
+//            description: Status code
+//            example: 200
+//          metadata:
+//            type: array
+//            description: List of projects
+//            items:
+//              $ref: "#/definitions/ProjectFull"
+//    "403":
+//      $ref: "#/responses/Forbidden"
+//    "500":
+//      $ref: "#/responses/InternalServerError"
+
 func projectsGet(d *Daemon, r *http.Request) response.Response {
 	s := d.State()
 
-	recursion := localUtil.IsRecursionRequest(r)
+	// Parse the recursion field.
+	recursion, err := strconv.Atoi(r.FormValue("recursion"))
+	if err != nil {
+		recursion = 0
+	}
 
 	// Parse filter value.
 	filterStr := r.FormValue("filter")
@@ -175,22 +278,45 @@ func projectsGet(d *Daemon, r *http.Request) response.Response {
 		return response.InternalError(err)
 	}
 
-	filtered := make([]api.Project, 0)
+	filtered := make([]api.ProjectFull, 0)
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		projects, err := cluster.GetProjects(ctx, tx.Tx())
 		if err != nil {
 			return err
 		}
 
+		apiProjects := make(map[string]*api.Project, len(projects))
 		for _, project := range projects {
-			if !userHasPermission(auth.ObjectProject(project.Name)) {
-				continue
-			}
-
-			apiProject, err := project.ToAPI(ctx, tx.Tx())
+			apiProjects[project.Name], err = project.ToAPI(ctx, tx.Tx())
 			if err != nil {
 				return err
 			}
+		}
+
+		// Users allowed to view a project can also view its child projects.
+		canView := func(name string) bool {
+			for range len(apiProjects) {
+				if userHasPermission(auth.ObjectProject(name)) {
+					return true
+				}
+
+				parent, ok := apiProjects[name]
+				if !ok || parent.Config["parent"] == "" {
+					return false
+				}
+
+				name = parent.Config["parent"]
+			}
+
+			return false
+		}
+
+		for _, project := range projects {
+			if !canView(project.Name) {
+				continue
+			}
+
+			apiProject := apiProjects[project.Name]
 
 			apiProject.UsedBy, err = projectUsedBy(ctx, tx, &project)
 			if err != nil {
@@ -208,7 +334,16 @@ func projectsGet(d *Daemon, r *http.Request) response.Response {
 				}
 			}
 
-			filtered = append(filtered, *apiProject)
+			projectFull := api.ProjectFull{Project: *apiProject}
+			if recursion > 1 {
+				projectFull.State = &api.ProjectState{}
+				projectFull.State.Resources, err = projecthelpers.GetCurrentAllocations(ctx, tx, project.Name)
+				if err != nil {
+					return err
+				}
+			}
+
+			filtered = append(filtered, projectFull)
 		}
 
 		return nil
@@ -217,10 +352,19 @@ func projectsGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
-	if recursion {
+	if recursion > 1 {
 		return response.SyncResponse(true, filtered)
 	}
 
+	if recursion == 1 {
+		projects := make([]api.Project, len(filtered))
+		for i, p := range filtered {
+			projects[i] = p.Project
+		}
+
+		return response.SyncResponse(true, projects)
+	}
+
 	urls := make([]string, len(filtered))
 	for i, p := range filtered {
 		urls[i] = p.URL(version.APIVersion).String()
@@ -337,11 +481,55 @@ func projectsPost(d *Daemon, r *http.Request) response.Response {
 	// Parse the request.
 	project := api.ProjectsPost{}
 
-	// Set default features.
+	err := json.NewDecoder(r.Body).Decode(&project)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// Quick checks.
+	err = projectValidateName(project.Name)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
 	if project.Config == nil {
 		project.Config = map[string]string{}
 	}
 
+	// Copy the configuration of the template project, the request taking precedence.
+	var source *api.Project
+	if project.Source != "" {
+		err = s.Authorizer.CheckPermission(r.Context(), r, auth.ObjectProject(project.Source), auth.EntitlementCanView)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+			dbProject, err := cluster.GetProject(ctx, tx.Tx(), project.Source)
+			if err != nil {
+				return err
+			}
+
+			source, err = dbProject.ToAPI(ctx, tx.Tx())
+			return err
+		})
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed loading template project %q: %w", project.Source, err))
+		}
+
+		if util.IsFalseOrEmpty(source.Config["template"]) {
+			return response.BadRequest(fmt.Errorf("Project %q isn't a template", project.Source))
+		}
+
+		for key, value := range source.Config {
+			_, ok := project.Config[key]
+			if !ok && key != "template" {
+				project.Config[key] = value
+			}
+		}
+	}
+
+	// Set default features.
 	for featureName, featureInfo := range cluster.ProjectFeatures {
 		_, ok := project.Config[featureName]
 		if !ok && featureInfo.DefaultEnabled {
@@ -349,15 +537,43 @@ func projectsPost(d *Daemon, r *http.Request) response.Response {
 		}
 	}
 
-	err := json.NewDecoder(r.Body).Decode(&project)
-	if err != nil {
-		return response.BadRequest(err)
+	// Creating child projects is delegated to the users allowed to edit one of the parent projects.
+	err = s.Authorizer.CheckPermission(r.Context(), r, auth.ObjectServer(), auth.EntitlementCanCreateProjects)
+	if err != nil && (project.Config["parent"] == "" || !api.StatusErrorCheck(err, http.StatusForbidden)) {
+		return response.SmartError(err)
 	}
 
-	// Quick checks.
-	err = projectValidateName(project.Name)
-	if err != nil {
-		return response.BadRequest(err)
+	allowed := err == nil
+	if project.Config["parent"] != "" {
+		var parents []api.Project
+		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+			var err error
+			parents, err = projectParents(ctx, tx, project.Config)
+			return err
+		})
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		for _, parent := range parents {
+			if allowed {
+				break
+			}
+
+			allowed = s.Authorizer.CheckPermission(r.Context(), r, auth.ObjectProject(parent.Name), auth.EntitlementCanEdit) == nil
+		}
+
+		if !allowed {
+			return response.Forbidden(nil)
+		}
+
+		// Inherit the restrictions and limits of the parent project.
+		projecthelpers.InheritConfig(parents[0].Config, project.Config)
+
+		err = projecthelpers.CheckInheritedConfig(parents[0].Config, project.Config)
+		if err != nil {
+			return response.BadRequest(err)
+		}
 	}
 
 	// Validate the configuration.
@@ -367,7 +583,13 @@ func projectsPost(d *Daemon, r *http.Request) response.Response {
 	}
 
 	var id int64
+	var copiedProfiles []string
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		err := projecthelpers.CheckIdmapRange(ctx, tx, project.Name, project.Config)
+		if err != nil {
+			return api.StatusErrorf(http.StatusBadRequest, "%w", err)
+		}
+
 		id, err = cluster.CreateProject(ctx, tx.Tx(), cluster.Project{Description: project.Description, Name: project.Name})
 		if err != nil {
 			return fmt.Errorf("Failed adding database record: %w", err)
@@ -379,7 +601,12 @@ func projectsPost(d *Daemon, r *http.Request) response.Response {
 		}
 
 		if util.IsTrue(project.Config["features.profiles"]) {
-			err = projectCreateDefaultProfile(ctx, tx, project.Name)
+			if source != nil && util.IsTrue(source.Config["features.profiles"]) {
+				copiedProfiles, err = projectCopyProfiles(ctx, tx, source.Name, project.Name)
+			} else {
+				err = projectCreateDefaultProfile(ctx, tx, project.Name)
+			}
+
 			if err != nil {
 				return err
 			}
@@ -403,6 +630,13 @@ func projectsPost(d *Daemon, r *http.Request) response.Response {
 		logger.Error("Failed to add project to authorizer", logger.Ctx{"name": project.Name, "error": err})
 	}
 
+	for _, profileName := range copiedProfiles {
+		err = s.Authorizer.AddProfile(r.Context(), project.Name, profileName)
+		if err != nil {
+			logger.Error("Failed to add profile to authorizer", logger.Ctx{"name": profileName, "project": project.Name, "error": err})
+		}
+	}
+
 	requestor := request.CreateRequestor(r)
 	lc := lifecycle.ProjectCreated.Event(project.Name, requestor, nil)
 	s.Events.SendLifecycle(project.Name, lc)
@@ -426,6 +660,58 @@ func projectCreateDefaultProfile(ctx context.Context, tx *db.ClusterTx, project
 	return nil
 }
 
+// Copy the profiles of a template project to a new project, returning the names of the profiles other than
+// the default one.
+func projectCopyProfiles(ctx context.Context, tx *db.ClusterTx, sourceProject string, targetProject string) ([]string, error) {
+	profiles, err := cluster.GetProfiles(ctx, tx.Tx(), cluster.ProfileFilter{Project: &sourceProject})
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading profiles of project %q: %w", sourceProject, err)
+	}
+
+	names := []string{}
+	for _, profile := range profiles {
+		config, err := cluster.GetProfileConfig(ctx, tx.Tx(), profile.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		devices, err := cluster.GetProfileDevices(ctx, tx.Tx(), profile.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		newProfile := cluster.Profile{
+			Project:     targetProject,
+			Name:        profile.Name,
+			Description: profile.Description,
+			Extends:     profile.Extends,
+		}
+
+		if profile.Name == api.ProjectDefaultName {
+			newProfile.Description = fmt.Sprintf("Default Incus profile for project %s", targetProject)
+		} else {
+			names = append(names, profile.Name)
+		}
+
+		id, err := cluster.CreateProfile(ctx, tx.Tx(), newProfile)
+		if err != nil {
+			return nil, fmt.Errorf("Failed copying profile %q: %w", profile.Name, err)
+		}
+
+		err = cluster.CreateProfileConfig(ctx, tx.Tx(), id, config)
+		if err != nil {
+			return nil, err
+		}
+
+		err = cluster.CreateProfileDevices(ctx, tx.Tx(), id, devices)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return names, nil
+}
+
 // swagger:operation GET /1.0/projects/{name} projects project_get
 //
 //	Get the project
@@ -751,6 +1037,10 @@ func projectChange(ctx context.Context, s *state.State, project *api.Project, re
 		}
 	}
 
+	if req.Config["parent"] != project.Config["parent"] {
+		return response.BadRequest(fmt.Errorf("The parent of a project can't be changed"))
+	}
+
 	// Validate the configuration.
 	err := projectValidateConfig(s, req.Config)
 	if err != nil {
@@ -759,7 +1049,24 @@ func projectChange(ctx context.Context, s *state.State, project *api.Project, re
 
 	// Update the database entry.
 	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
-		err := projecthelpers.AllowProjectUpdate(tx, project.Name, req.Config, configChanged)
+		if req.Config["parent"] != "" {
+			parents, err := projectParents(ctx, tx, req.Config)
+			if err != nil {
+				return err
+			}
+
+			err = projecthelpers.CheckInheritedConfig(parents[0].Config, req.Config)
+			if err != nil {
+				return api.StatusErrorf(http.StatusBadRequest, "%w", err)
+			}
+		}
+
+		err := projecthelpers.CheckIdmapRange(ctx, tx, project.Name, req.Config)
+		if err != nil {
+			return api.StatusErrorf(http.StatusBadRequest, "%w", err)
+		}
+
+		err = projecthelpers.AllowProjectUpdate(tx, project.Name, req.Config, configChanged)
 		if err != nil {
 			return err
 		}
@@ -769,6 +1076,11 @@ func projectChange(ctx context.Context, s *state.State, project *api.Project, re
 			return fmt.Errorf("Persist profile changes: %w", err)
 		}
 
+		err = projectUpdateChildren(ctx, tx, project.Name, project.Config, req.Config)
+		if err != nil {
+			return err
+		}
+
 		if slices.Contains(configChanged, "features.profiles") {
 			if util.IsTrue(req.Config["features.profiles"]) {
 				err = projectCreateDefaultProfile(ctx, tx, project.Name)
@@ -800,6 +1112,125 @@ func projectChange(ctx context.Context, s *state.State, project *api.Project, re
 	return response.EmptySyncResponse
 }
 
+// projectParents returns the parent projects of the project with the given configuration, starting with its
+// direct parent.
+func projectParents(ctx context.Context, tx *db.ClusterTx, config map[string]string) ([]api.Project, error) {
+	parents := []api.Project{}
+
+	parentName := config["parent"]
+	for parentName != "" {
+		if slices.ContainsFunc(parents, func(parent api.Project) bool { return parent.Name == parentName }) {
+			return nil, fmt.Errorf("Loop detected in the parents of project %q", parentName)
+		}
+
+		dbProject, err := cluster.GetProject(ctx, tx.Tx(), parentName)
+		if err != nil {
+			return nil, fmt.Errorf("Failed loading parent project %q: %w", parentName, err)
+		}
+
+		parent, err := dbProject.ToAPI(ctx, tx.Tx())
+		if err != nil {
+			return nil, err
+		}
+
+		parents = append(parents, *parent)
+		parentName = parent.Config["parent"]
+	}
+
+	return parents, nil
+}
+
+// projectChildren returns the direct child projects of a project.
+func projectChildren(ctx context.Context, tx *db.ClusterTx, name string) ([]api.Project, error) {
+	projects, err := cluster.GetProjects(ctx, tx.Tx())
+	if err != nil {
+		return nil, err
+	}
+
+	children := []api.Project{}
+	for _, project := range projects {
+		apiProject, err := project.ToAPI(ctx, tx.Tx())
+		if err != nil {
+			return nil, err
+		}
+
+		if apiProject.Config["parent"] == name {
+			children = append(children, *apiProject)
+		}
+	}
+
+	return children, nil
+}
+
+// projectUpdateChildren propagates the changes to the inherited configuration of a project to its child
+// projects, failing if a child project would be less restrictive than its parent.
+func projectUpdateChildren(ctx context.Context, tx *db.ClusterTx, name string, oldConfig map[string]string, newConfig map[string]string) error {
+	changedKeys := []string{}
+	for _, config := range []map[string]string{oldConfig, newConfig} {
+		for key := range config {
+			if projecthelpers.IsInheritedConfigKey(key) && oldConfig[key] != newConfig[key] && !slices.Contains(changedKeys, key) {
+				changedKeys = append(changedKeys, key)
+			}
+		}
+	}
+
+	if len(changedKeys) == 0 {
+		return nil
+	}
+
+	children, err := projectChildren(ctx, tx, name)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		config := maps.Clone(child.Config)
+		changed := []string{}
+
+		// Values which were inherited follow the parent project.
+		for _, key := range changedKeys {
+			if config[key] != oldConfig[key] {
+				continue
+			}
+
+			value, ok := newConfig[key]
+			if ok {
+				config[key] = value
+			} else {
+				delete(config, key)
+			}
+
+			changed = append(changed, key)
+		}
+
+		err = projecthelpers.CheckInheritedConfig(newConfig, config)
+		if err != nil {
+			return api.StatusErrorf(http.StatusBadRequest, "Child project %q conflicts with the new configuration: %w", child.Name, err)
+		}
+
+		if len(changed) == 0 {
+			continue
+		}
+
+		err = projecthelpers.AllowProjectUpdate(tx, child.Name, config, changed)
+		if err != nil {
+			return fmt.Errorf("Failed updating child project %q: %w", child.Name, err)
+		}
+
+		err = cluster.UpdateProject(ctx, tx.Tx(), child.Name, api.ProjectPut{Description: child.Description, Config: config})
+		if err != nil {
+			return fmt.Errorf("Failed updating child project %q: %w", child.Name, err)
+		}
+
+		err = projectUpdateChildren(ctx, tx, child.Name, child.Config, config)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // swagger:operation POST /1.0/projects/{name} projects project_post
 //
 //	Rename the project
@@ -875,6 +1306,15 @@ func projectPost(d *Daemon, r *http.Request) response.Response {
 				return fmt.Errorf("Only empty projects can be renamed")
 			}
 
+			children, err := projectChildren(ctx, tx, name)
+			if err != nil {
+				return err
+			}
+
+			if len(children) > 0 {
+				return fmt.Errorf("Projects with child projects can't be renamed")
+			}
+
 			id, err = cluster.GetProjectID(ctx, tx.Tx(), name)
 			if err != nil {
 				return fmt.Errorf("Failed getting project ID for project %q: %w", name, err)
@@ -956,6 +1396,15 @@ func projectDelete(d *Daemon, r *http.Request) response.Response {
 			return fmt.Errorf("Fetch project %q: %w", name, err)
 		}
 
+		children, err := projectChildren(ctx, tx, name)
+		if err != nil {
+			return err
+		}
+
+		if len(children) > 0 {
+			return fmt.Errorf("Projects with child projects can't be removed")
+		}
+
 		if !force {
 			empty, err := projectIsEmpty(ctx, project, tx)
 			if err != nil {
@@ -1310,6 +1759,66 @@ func projectStateGet(d *Daemon, r *http.Request) response.Response {
 	return response.SyncResponse(true, &state)
 }
 
+// swagger:operation GET /1.0/projects/{name}/audit projects project_audit_get
+//
+//	Audit the project
+//
+//	Gets the configuration keys and devices of the instances and profiles of the project which violate its
+//	current restrictions.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: Restriction violations
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of restriction violations
+//	          items:
+//	            $ref: "#/definitions/ProjectAuditViolation"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func projectAuditGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	var violations []api.ProjectAuditViolation
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		violations, err = projecthelpers.AuditRestrictions(tx, name)
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, violations)
+}
+
 // Check if a project is empty.
 func projectIsEmpty(ctx context.Context, project *cluster.Project, tx *db.ClusterTx) (bool, error) {
 	usedBy, err := projectUsedBy(ctx, tx, project)
@@ -1349,6 +1858,40 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		//  shortdesc: Compression algorithm to use for backups
 		"backups.compression_algorithm": validate.IsCompressionAlgorithm,
 
+		// gendoc:generate(entity=project, group=specific, key=cluster.default_group)
+		// New instances of the project which don't specify a target are placed on the members of this cluster group.
+		// ---
+		//  type: string
+		//  shortdesc: Cluster group to place new instances on by default
+		"cluster.default_group": validate.Optional(validate.IsAny),
+
+		// gendoc:generate(entity=project, group=specific, key=maintenance.window)
+		// Comma separated list of time ranges (in the local time of the servers) during which the scheduled snapshots, image refreshes and automatic re-balancing of the project's instances are allowed.
+		// Each time range is specified as `[<day>[-<day>]] <HH:MM>-<HH:MM>`, for example `mon-fri 22:00-06:00, sat-sun 00:00-24:00`.
+		// The snapshots scheduled outside of these time ranges are taken once the next one starts.
+		// When not set, these tasks are allowed at any time.
+		// ---
+		//  type: string
+		//  shortdesc: Time ranges during which background tasks are allowed
+		"maintenance.window": projecthelpers.ValidateMaintenanceWindow,
+
+		// gendoc:generate(entity=project, group=specific, key=parent)
+		// The project inherits the `restricted` and `limits.*` configuration of its parent project, which it can only tighten.
+		// The users allowed to view or edit the parent project can also view or edit this project itself, but not its instances and other resources.
+		// This option can only be set when creating the project.
+		// ---
+		//  type: string
+		//  shortdesc: Name of the parent project
+		"parent": validate.Optional(projectValidateName),
+
+		// gendoc:generate(entity=project, group=specific, key=template)
+		// Template projects can be used as the source of new projects, which then get a copy of their configuration and profiles.
+		// ---
+		//  type: bool
+		//  defaultdesc: `false`
+		//  shortdesc: Whether the project can be used as a template for new projects
+		"template": validate.Optional(validate.IsBool),
+
 		// gendoc:generate(entity=project, group=features, key=features.profiles)
 		//
 		// ---
@@ -1439,6 +1982,48 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		//  shortdesc: When an unused cached remote image is flushed in the project
 		"images.remote_cache_expiry": validate.Optional(validate.IsInt64),
 
+		// gendoc:generate(entity=project, group=specific, key=images.replication_aliases)
+		// As soon as an image with one of those aliases is imported, it's copied to the cluster members selected by {config:option}`project-specific:images.replication_group`, so that creating instances from it never waits for a transfer between members.
+		// ---
+		//  type: string
+		//  shortdesc: Comma-separated list of image aliases to pre-replicate across the cluster
+		"images.replication_aliases": validate.Optional(validate.IsListOf(validate.IsAny)),
+
+		// gendoc:generate(entity=project, group=specific, key=images.replication_group)
+		// If unset, the images are replicated to all cluster members.
+		// ---
+		//  type: string
+		//  shortdesc: Cluster group to pre-replicate images to
+		"images.replication_group": validate.Optional(validate.IsAny),
+
+		// gendoc:generate(entity=project, group=specific, key=images.replication_pool)
+		// If set, the pre-replicated images are also unpacked into this storage pool on each cluster member.
+		// ---
+		//  type: string
+		//  shortdesc: Storage pool to unpack pre-replicated images into
+		"images.replication_pool": validate.Optional(validate.IsAny),
+
+		// gendoc:generate(entity=project, group=specific, key=security.idmap.isolated)
+		// When enabled, containers in the project that don't set {config:option}`instance-security:security.idmap.isolated` get an isolated idmap.
+		// Existing containers keep their current idmap until they're remapped with `incus project remap`.
+		// ---
+		//  type: bool
+		//  defaultdesc: `true` (`false` for the `default` project)
+		//  shortdesc: Whether containers in the project use isolated idmaps by default
+		"security.idmap.isolated": validate.Optional(validate.IsBool),
+
+		// gendoc:generate(entity=project, group=specific, key=security.idmap.range)
+		// Specify a range of host IDs in the `<first>-<last>` format, for example `1065536-2065535`.
+		// The isolated idmaps of the project's containers are then allocated from this range only, which must be within the range allocated to Incus on the system.
+		// The range is reserved to the project: it can't overlap with the range of another project, and the containers of other projects don't get IDs from it.
+		// ---
+		//  type: string
+		//  shortdesc: Host ID range for the isolated idmaps of the project
+		"security.idmap.range": validate.Optional(func(value string) error {
+			_, _, err := projecthelpers.IdmapRange(value)
+			return err
+		}),
+
 		// gendoc:generate(entity=project, group=limits, key=limits.instances)
 		//
 		// ---
@@ -1495,6 +2080,18 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		//  shortdesc: Maximum number of networks that the project can have
 		"limits.networks": validate.Optional(validate.IsUint32),
 
+		// gendoc:generate(entity=project, group=limits, key=limits.networks.bandwidth)
+		// This value is the maximum value for the sum of the bandwidth of the NICs of the instances of the project, in bit/s.
+		// The bandwidth of a NIC is the highest of its `limits.ingress` and `limits.egress` values, which both fall back to `limits.max`.
+		// When this option is set, all NICs of the instances of the project must have those limits set.
+		// ---
+		//  type: string
+		//  shortdesc: Maximum network bandwidth used by the instances of the project
+		"limits.networks.bandwidth": validate.Optional(func(value string) error {
+			_, err := units.ParseBitSizeString(value)
+			return err
+		}),
+
 		// gendoc:generate(entity=project, group=restricted, key=restricted)
 		// This option must be enabled to allow the `restricted.*` keys to take effect.
 		// To temporarily remove the restrictions, you can disable this option instead of clearing the related keys.
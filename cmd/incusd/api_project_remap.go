@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+var projectRemapCmd = APIEndpoint{
+	Path: "projects/{name}/remap",
+
+	Post: APIEndpointAction{Handler: projectRemapPost, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanEdit, "name")},
+}
+
+// swagger:operation POST /1.0/projects/{name}/remap projects project_remap_post
+//
+//	Remap the project's containers
+//
+//	Allocates new idmaps to the stopped containers of the project which don't match the
+//	project's idmap configuration anymore and shifts their filesystem in the background.
+//	Only the containers located on the targeted cluster member are processed.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func projectRemapPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Forward the request if targeting another cluster member.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	run := func(op *operations.Operation) error {
+		insts, err := instance.LoadNodeAll(s, instancetype.Container)
+		if err != nil {
+			return err
+		}
+
+		remapped := []string{}
+		skipped := []string{}
+		for _, inst := range insts {
+			if inst.Project().Name != name {
+				continue
+			}
+
+			c, ok := inst.(instance.Container)
+			if !ok {
+				continue
+			}
+
+			if c.IsRunning() {
+				skipped = append(skipped, c.Name())
+				continue
+			}
+
+			changed, err := c.Remap()
+			if err != nil {
+				return fmt.Errorf("Failed remapping %q: %w", c.Name(), err)
+			}
+
+			if changed {
+				remapped = append(remapped, c.Name())
+				_ = op.UpdateMetadata(map[string]any{"remapped": remapped, "skipped": skipped})
+			}
+		}
+
+		if len(skipped) > 0 {
+			logger.Warn("Skipped remapping running containers", logger.Ctx{"project": name, "instances": skipped})
+		}
+
+		return op.UpdateMetadata(map[string]any{"remapped": remapped, "skipped": skipped})
+	}
+
+	resources := map[string][]api.URL{}
+	resources["projects"] = []api.URL{*api.NewURL().Path("1.0", "projects", name)}
+
+	op, err := operations.OperationCreate(s, name, operations.OperationClassTask, operationtype.ProjectRemap, resources, nil, run, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
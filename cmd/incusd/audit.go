@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/jmap"
+	"github.com/lxc/incus/v6/internal/server/audit"
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+var internalAuditCmd = APIEndpoint{
+	Path: "audit",
+
+	Get: APIEndpointAction{Handler: internalAuditGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+var internalAuditVerifyCmd = APIEndpoint{
+	Path: "audit/verify",
+
+	Get: APIEndpointAction{Handler: internalAuditVerifyGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+// auditRequest records an authenticated API request in the audit log and forwards it to the loggers.
+// The reason is only set for requests rejected before reaching their handler.
+func (d *Daemon) auditRequest(r *http.Request, username string, protocol string, statusCode int, reason string) {
+	d.globalConfigMu.Lock()
+	enabled := d.globalConfig != nil && d.globalConfig.AuditLog()
+	d.globalConfigMu.Unlock()
+
+	if !enabled {
+		return
+	}
+
+	result := "success"
+	if statusCode >= http.StatusBadRequest {
+		result = "failure"
+	}
+
+	impersonator, _ := r.Context().Value(request.CtxImpersonator).(string)
+
+	entry, err := d.audit.Append(api.AuditEntry{
+		Timestamp:    time.Now(),
+		Location:     d.serverName,
+		Username:     username,
+		Impersonator: impersonator,
+		Protocol:     protocol,
+		Address:      r.RemoteAddr,
+		Method:       r.Method,
+		URL:          r.URL.RequestURI(),
+		StatusCode:   statusCode,
+		Result:       result,
+		Reason:       reason,
+	})
+	if err != nil {
+		logger.Error("Failed recording request in audit log", logger.Ctx{"url": r.URL.RequestURI(), "username": username, "err": err})
+		return
+	}
+
+	_ = d.events.Send("", api.EventTypeAudit, entry)
+}
+
+// Query the audit log of the local member.
+func internalAuditGet(d *Daemon, r *http.Request) response.Response {
+	filter := audit.Filter{Username: r.FormValue("username")}
+
+	for key, value := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if r.FormValue(key) == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, r.FormValue(key))
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid %q time: %w", key, err))
+		}
+
+		*value = t
+	}
+
+	entries, err := audit.Read(d.audit.Path(), filter)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, entries)
+}
+
+// Verify the hash chain of the audit log of the local member.
+func internalAuditVerifyGet(d *Daemon, r *http.Request) response.Response {
+	count, err := d.audit.Verify()
+
+	result := jmap.Map{"entries": count}
+	if err != nil {
+		result["error"] = err.Error()
+	}
+
+	return response.SyncResponse(true, result)
+}
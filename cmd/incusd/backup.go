@@ -85,6 +85,7 @@ func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.In
 		compress = b.CompressionAlgorithm()
 	} else {
 		var p *api.Project
+		var groupCompress string
 		err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 			project, err := dbCluster.GetProject(ctx, tx.Tx(), sourceInst.Project().Name)
 			if err != nil {
@@ -92,6 +93,12 @@ func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.In
 			}
 
 			p, err = project.ToAPI(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			// Members of cluster groups may override the server default.
+			groupCompress, err = tx.GetNodeClusterGroupsConfigValue(ctx, s.ServerName, "backups.compression_algorithm")
 
 			return err
 		})
@@ -101,6 +108,8 @@ func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.In
 
 		if p.Config["backups.compression_algorithm"] != "" {
 			compress = p.Config["backups.compression_algorithm"]
+		} else if groupCompress != "" {
+			compress = groupCompress
 		} else {
 			compress = s.GlobalConfig.BackupsCompressionAlgorithm()
 		}
@@ -158,6 +167,7 @@ func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.In
 
 				progressText := fmt.Sprintf("%s (%s/s)", units.GetByteSizeString(value, 2), units.GetByteSizeString(speed, 2))
 				meta["create_backup_progress"] = progressText
+				operations.SetProgressData(meta, "create_backup", 0, value, speed)
 				_ = op.UpdateMetadata(meta)
 			},
 		},
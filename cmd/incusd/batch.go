@@ -0,0 +1,367 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// batchMaxRequests is the maximum number of requests of a batch.
+const batchMaxRequests = 1000
+
+// batchMaxParallel is the maximum number of requests of a batch run at the same time.
+const batchMaxParallel = 32
+
+var batchCmd = APIEndpoint{
+	Path: "batch",
+
+	Post: APIEndpointAction{Handler: batchPost, AccessHandler: allowAuthenticated},
+}
+
+// swagger:operation POST /1.0/batch batch batch_post
+//
+//	Run a batch of requests
+//
+//	Runs a list of API requests server-side as a single background operation.
+//	Each request is authenticated and authorized with the credentials of the batch request.
+//	The operation metadata holds the result of each request under the "results" key.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name of the batch operation
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: batch
+//	    description: Batch of requests
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/BatchPost"
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func batchPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	req := api.BatchPost{}
+
+	// Parse the request.
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = batchValidate(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// The requests of the batch re-use the connection and credentials of the batch request, which is over by the
+	// time they run.
+	base := r.Clone(context.WithoutCancel(r.Context()))
+
+	results := make([]api.BatchResult, len(req.Requests))
+	for i, batchReq := range req.Requests {
+		results[i] = api.BatchResult{
+			Method:     batchReq.Method,
+			URL:        batchReq.URL,
+			Status:     api.Pending.String(),
+			StatusCode: api.Pending,
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	run := func(op *operations.Operation) error {
+		defer cancel()
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		failed := 0
+
+		setResult := func(i int, result api.BatchResult) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			results[i] = result
+			if result.StatusCode != api.Success {
+				failed++
+			}
+
+			err := op.UpdateMetadata(map[string]any{"results": slices.Clone(results)})
+			if err != nil {
+				logger.Debug("Failed updating batch operation metadata", logger.Ctx{"err": err})
+			}
+		}
+
+		slots := make(chan struct{}, max(req.Parallel, 1))
+		for i, batchReq := range req.Requests {
+			// Wait for a free slot, unless the operation got cancelled.
+			acquired := false
+			select {
+			case slots <- struct{}{}:
+				acquired = true
+			case <-ctx.Done():
+			}
+
+			mu.Lock()
+			skip := ctx.Err() != nil || (req.StopOnError && failed > 0)
+			if !skip {
+				results[i].Status = api.Running.String()
+				results[i].StatusCode = api.Running
+			}
+
+			mu.Unlock()
+
+			// The remaining requests keep their pending status.
+			if skip {
+				if acquired {
+					<-slots
+				}
+
+				break
+			}
+
+			wg.Add(1)
+			go func(i int, batchReq api.BatchRequest) {
+				defer wg.Done()
+				defer func() { <-slots }()
+
+				setResult(i, batchRun(ctx, d, base, batchReq))
+			}(i, batchReq)
+		}
+
+		wg.Wait()
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d requests failed", failed, len(req.Requests))
+		}
+
+		if ctx.Err() != nil {
+			return fmt.Errorf("Batch cancelled")
+		}
+
+		return nil
+	}
+
+	onCancel := func(op *operations.Operation) error {
+		cancel()
+		return nil
+	}
+
+	op, err := operations.OperationCreate(s, request.ProjectParam(r), operations.OperationClassTask, operationtype.Batch, nil, map[string]any{"results": results}, run, onCancel, nil, r)
+	if err != nil {
+		cancel()
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
+
+// batchValidate checks the requests of a batch.
+func batchValidate(req *api.BatchPost) error {
+	if len(req.Requests) == 0 {
+		return fmt.Errorf("No requests in batch")
+	}
+
+	if len(req.Requests) > batchMaxRequests {
+		return fmt.Errorf("Batches are limited to %d requests", batchMaxRequests)
+	}
+
+	if req.Parallel < 0 || req.Parallel > batchMaxParallel {
+		return fmt.Errorf("Invalid parallel value %d (must be between 0 and %d)", req.Parallel, batchMaxParallel)
+	}
+
+	for i, batchReq := range req.Requests {
+		switch batchReq.Method {
+		case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			return fmt.Errorf("Invalid method %q of request %d", batchReq.Method, i)
+		}
+
+		u, err := url.ParseRequestURI(batchReq.URL)
+		if err != nil || u.Host != "" || !strings.HasPrefix(u.Path, "/1.0/") {
+			return fmt.Errorf("Invalid URL %q of request %d (must be a /1.0/ path)", batchReq.URL, i)
+		}
+
+		if u.Path == "/1.0/batch" || u.Path == "/1.0/events" {
+			return fmt.Errorf("Request %d can't be run as part of a batch", i)
+		}
+	}
+
+	return nil
+}
+
+// batchRun runs a single request of a batch and waits for its operation, if any.
+func batchRun(ctx context.Context, d *Daemon, base *http.Request, batchReq api.BatchRequest) api.BatchResult {
+	result := api.BatchResult{
+		Method: batchReq.Method,
+		URL:    batchReq.URL,
+	}
+
+	fail := func(code int, err string) api.BatchResult {
+		result.Status = api.Failure.String()
+		result.StatusCode = api.Failure
+		result.ErrorCode = code
+		result.Error = err
+
+		return result
+	}
+
+	var body []byte
+	if batchReq.Body != nil {
+		var err error
+
+		body, err = json.Marshal(batchReq.Body)
+		if err != nil {
+			return fail(http.StatusBadRequest, err.Error())
+		}
+	}
+
+	resp, err := batchDispatch(d, base, batchReq.Method, batchReq.URL, body)
+	if err != nil {
+		return fail(http.StatusInternalServerError, err.Error())
+	}
+
+	switch resp.Type {
+	case api.ErrorResponse:
+		return fail(resp.Code, resp.Error)
+
+	case api.SyncResponse:
+		result.Status = api.Success.String()
+		result.StatusCode = api.Success
+
+		if len(resp.Metadata) > 0 {
+			_ = json.Unmarshal(resp.Metadata, &result.Metadata)
+		}
+
+		return result
+	}
+
+	// Wait for the operation created by the request.
+	result.Operation = resp.Operation
+
+	op, err := resp.MetadataAsOperation()
+	if err != nil {
+		return fail(http.StatusInternalServerError, err.Error())
+	}
+
+	// Operations waiting for websocket connections would never complete.
+	if op.Class == operations.OperationClassWebsocket.String() {
+		_, err := batchDispatch(d, base, http.MethodDelete, resp.Operation, nil)
+		if err != nil {
+			logger.Warn("Failed cancelling websocket operation of batch", logger.Ctx{"operation": resp.Operation, "err": err})
+		}
+
+		return fail(http.StatusBadRequest, "Requests using websockets can't be run as part of a batch")
+	}
+
+	// Stop waiting, leaving the operation running, when the batch gets cancelled.
+	waitCtx, waitCancel := context.WithCancel(base.Context())
+	defer waitCancel()
+
+	stop := context.AfterFunc(ctx, waitCancel)
+	defer stop()
+
+	waitResp, err := batchDispatch(d, base.WithContext(waitCtx), http.MethodGet, resp.Operation+"/wait", nil)
+	if err == nil && waitResp.Type == api.SyncResponse {
+		op, err = waitResp.MetadataAsOperation()
+	} else if err == nil {
+		err = fmt.Errorf("Failed waiting for operation: %s", waitResp.Error)
+	}
+
+	if err != nil {
+		return fail(http.StatusInternalServerError, err.Error())
+	}
+
+	result.Metadata = op.Metadata
+	result.Status = op.Status
+	result.StatusCode = op.StatusCode
+	result.Error = op.Err
+
+	return result
+}
+
+// batchDispatch runs a request through the API router, with the connection and credentials of the given request.
+func batchDispatch(d *Daemon, base *http.Request, method string, uri string, body []byte) (*api.Response, error) {
+	u, err := url.ParseRequestURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	r := base.Clone(base.Context())
+	r.Form = nil
+	r.PostForm = nil
+	r.MultipartForm = nil
+	r.Method = method
+	r.URL = u
+	r.RequestURI = uri
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	r.Header.Set("Content-Type", "application/json")
+
+	w := &batchResponseWriter{header: http.Header{}}
+	d.restRouter.ServeHTTP(w, r)
+
+	resp := api.Response{}
+	err = json.Unmarshal(w.body.Bytes(), &resp)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing response (status %d): %w", w.status, err)
+	}
+
+	return &resp, nil
+}
+
+// batchResponseWriter records the response of a request of a batch.
+type batchResponseWriter struct {
+	header http.Header
+	body   bytes.Buffer
+	status int
+}
+
+// Header returns the response headers.
+func (w *batchResponseWriter) Header() http.Header {
+	return w.header
+}
+
+// Write records the response body.
+func (w *batchResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	return w.body.Write(b)
+}
+
+// WriteHeader records the response status code.
+func (w *batchResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -243,6 +244,7 @@ func updateCertificateCache(d *Daemon) {
 
 	newCerts := map[certificate.Type]map[string]x509.Certificate{}
 	newProjects := map[string][]string{}
+	newSources := map[string][]*net.IPNet{}
 
 	var certs []*api.Certificate
 	var dbCerts []dbCluster.Certificate
@@ -292,6 +294,22 @@ func updateCertificateCache(d *Daemon) {
 			newProjects[localtls.CertFingerprint(cert)] = certs[i].Projects
 		}
 
+		// Invalid networks are skipped but still restrict the certificate, so that it can't be used from anywhere.
+		if len(certs[i].AllowedSources) > 0 {
+			networks := []*net.IPNet{}
+			for _, source := range certs[i].AllowedSources {
+				_, network, err := net.ParseCIDR(source)
+				if err != nil {
+					logger.Warn("Failed parsing allowed source of certificate", logger.Ctx{"name": dbCert.Name, "source": source, "err": err})
+					continue
+				}
+
+				networks = append(networks, network)
+			}
+
+			newSources[localtls.CertFingerprint(cert)] = networks
+		}
+
 		// Add server certs to list of certificates to store in local database to allow cluster restart.
 		if dbCert.Type == certificate.TypeServer {
 			localCerts = append(localCerts, dbCert)
@@ -309,6 +327,19 @@ func updateCertificateCache(d *Daemon) {
 	}
 
 	d.clientCerts.SetCertificatesAndProjects(newCerts, newProjects)
+	d.clientCerts.SetSources(newSources)
+}
+
+// certificateAllowedSources validates the allowed source networks of a certificate and returns them as stored in the database.
+func certificateAllowedSources(sources []string) (string, error) {
+	for _, source := range sources {
+		_, _, err := net.ParseCIDR(source)
+		if err != nil {
+			return "", fmt.Errorf("Invalid allowed source %q: %w", source, err)
+		}
+	}
+
+	return strings.Join(sources, ","), nil
 }
 
 // updateCertificateCacheFromLocal loads trusted server certificates from local database into memory.
@@ -627,6 +658,7 @@ func certificatesPost(d *Daemon, r *http.Request) response.Response {
 					req.Type = tokenReq.Type
 					req.Restricted = tokenReq.Restricted
 					req.Projects = tokenReq.Projects
+					req.AllowedSources = tokenReq.AllowedSources
 				case map[string]any:
 					req.Name = tokenReq["name"].(string)
 					req.Type = tokenReq["type"].(string)
@@ -635,6 +667,11 @@ func certificatesPost(d *Daemon, r *http.Request) response.Response {
 						req.Projects = append(req.Projects, project.(string))
 					}
 
+					sources, _ := tokenReq["allowed_sources"].([]any)
+					for _, source := range sources {
+						req.AllowedSources = append(req.AllowedSources, source.(string))
+					}
+
 				default:
 					return response.InternalError(fmt.Errorf("Bad certificate add operation data"))
 				}
@@ -649,6 +686,11 @@ func certificatesPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	allowedSources, err := certificateAllowedSources(req.AllowedSources)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
 	// Extract the certificate.
 	var cert *x509.Certificate
 	if req.Certificate != "" {
@@ -773,12 +815,13 @@ func certificatesPost(d *Daemon, r *http.Request) response.Response {
 
 			// Store the certificate in the cluster database.
 			dbCert := dbCluster.Certificate{
-				Fingerprint: localtls.CertFingerprint(cert),
-				Type:        dbReqType,
-				Name:        name,
-				Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})),
-				Restricted:  req.Restricted,
-				Description: req.Description,
+				Fingerprint:    localtls.CertFingerprint(cert),
+				Type:           dbReqType,
+				Name:           name,
+				Certificate:    string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})),
+				Restricted:     req.Restricted,
+				Description:    req.Description,
+				AllowedSources: allowedSources,
 			}
 
 			_, err := dbCluster.CreateCertificateWithProjects(ctx, tx.Tx(), dbCert, req.Projects)
@@ -1028,14 +1071,20 @@ func doCertificateUpdate(d *Daemon, dbInfo api.Certificate, req api.CertificateP
 			return response.BadRequest(err)
 		}
 
+		allowedSources, err := certificateAllowedSources(req.AllowedSources)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+
 		// Convert to the database type.
 		dbCert := dbCluster.Certificate{
-			Certificate: dbInfo.Certificate,
-			Fingerprint: dbInfo.Fingerprint,
-			Restricted:  req.Restricted,
-			Name:        req.Name,
-			Type:        reqDBType,
-			Description: req.Description,
+			Certificate:    dbInfo.Certificate,
+			Fingerprint:    dbInfo.Fingerprint,
+			Restricted:     req.Restricted,
+			Name:           req.Name,
+			Type:           reqDBType,
+			Description:    req.Description,
+			AllowedSources: allowedSources,
 		}
 
 		var userCanEditCertificate bool
@@ -1056,7 +1105,7 @@ func doCertificateUpdate(d *Daemon, dbInfo api.Certificate, req api.CertificateP
 			}
 
 			// Ensure the user in not trying to change fields other than the certificate.
-			if dbInfo.Restricted != req.Restricted || dbInfo.Name != req.Name || len(dbInfo.Projects) != len(req.Projects) {
+			if dbInfo.Restricted != req.Restricted || dbInfo.Name != req.Name || len(dbInfo.Projects) != len(req.Projects) || strings.Join(dbInfo.AllowedSources, ",") != allowedSources {
 				return response.Forbidden(fmt.Errorf("Only the certificate can be changed"))
 			}
 
@@ -1068,12 +1117,13 @@ func doCertificateUpdate(d *Daemon, dbInfo api.Certificate, req api.CertificateP
 
 			// Reset dbCert in order to prevent possible future security issues.
 			dbCert = dbCluster.Certificate{
-				Certificate: dbInfo.Certificate,
-				Fingerprint: dbInfo.Fingerprint,
-				Restricted:  dbInfo.Restricted,
-				Name:        dbInfo.Name,
-				Type:        reqDBType,
-				Description: req.Description,
+				Certificate:    dbInfo.Certificate,
+				Fingerprint:    dbInfo.Fingerprint,
+				Restricted:     dbInfo.Restricted,
+				Name:           dbInfo.Name,
+				Type:           reqDBType,
+				Description:    req.Description,
+				AllowedSources: strings.Join(dbInfo.AllowedSources, ","),
 			}
 
 			certProjects = dbInfo.Projects
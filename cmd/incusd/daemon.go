@@ -18,21 +18,28 @@ import (
 	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	dqliteClient "github.com/cowsql/go-cowsql/client"
 	"github.com/cowsql/go-cowsql/driver"
 	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
 	liblxc "github.com/lxc/go-lxc"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"golang.org/x/sys/unix"
 
 	internalIO "github.com/lxc/incus/v6/internal/io"
 	"github.com/lxc/incus/v6/internal/linux"
 	"github.com/lxc/incus/v6/internal/rsync"
 	"github.com/lxc/incus/v6/internal/server/apparmor"
+	"github.com/lxc/incus/v6/internal/server/audit"
 	"github.com/lxc/incus/v6/internal/server/auth"
 	"github.com/lxc/incus/v6/internal/server/auth/oidc"
 	"github.com/lxc/incus/v6/internal/server/bgp"
+	"github.com/lxc/incus/v6/internal/server/binfmt"
 	"github.com/lxc/incus/v6/internal/server/certificate"
 	"github.com/lxc/incus/v6/internal/server/cluster"
 	clusterConfig "github.com/lxc/incus/v6/internal/server/cluster/config"
@@ -50,15 +57,20 @@ import (
 	instanceDrivers "github.com/lxc/incus/v6/internal/server/instance/drivers"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/internal/server/logging"
+	"github.com/lxc/incus/v6/internal/server/logstore"
+	"github.com/lxc/incus/v6/internal/server/metrics"
 	"github.com/lxc/incus/v6/internal/server/network/ovn"
 	"github.com/lxc/incus/v6/internal/server/network/ovs"
 	networkZone "github.com/lxc/incus/v6/internal/server/network/zone"
 	"github.com/lxc/incus/v6/internal/server/node"
 	"github.com/lxc/incus/v6/internal/server/project"
+	"github.com/lxc/incus/v6/internal/server/ratelimit"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
 	scriptletLoad "github.com/lxc/incus/v6/internal/server/scriptlet/load"
 	"github.com/lxc/incus/v6/internal/server/seccomp"
+	"github.com/lxc/incus/v6/internal/server/secrets"
+	"github.com/lxc/incus/v6/internal/server/sessions"
 	"github.com/lxc/incus/v6/internal/server/state"
 	storagePools "github.com/lxc/incus/v6/internal/server/storage"
 	storageDrivers "github.com/lxc/incus/v6/internal/server/storage/drivers"
@@ -67,6 +79,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/sys"
 	"github.com/lxc/incus/v6/internal/server/syslog"
 	"github.com/lxc/incus/v6/internal/server/task"
+	"github.com/lxc/incus/v6/internal/server/tracing"
 	"github.com/lxc/incus/v6/internal/server/ucred"
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
 	"github.com/lxc/incus/v6/internal/server/warnings"
@@ -77,6 +90,7 @@ import (
 	"github.com/lxc/incus/v6/shared/cancel"
 	"github.com/lxc/incus/v6/shared/idmap"
 	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/osarch"
 	"github.com/lxc/incus/v6/shared/proxy"
 	"github.com/lxc/incus/v6/shared/revert"
 	localtls "github.com/lxc/incus/v6/shared/tls"
@@ -97,6 +111,27 @@ type Daemon struct {
 	events           *events.Server
 	internalListener *events.InternalListener
 
+	// Active client sessions and blocked identities
+	sessions *sessions.Manager
+
+	// Audit log of the API requests
+	audit *audit.Log
+
+	// Store of the log messages of the server
+	logStore *logstore.Store
+
+	// Counters of the lifecycle events and failed operations
+	eventCounters *metrics.EventCounters
+
+	// Counts and durations of the API requests
+	requestMetrics *metrics.RequestMetrics
+
+	// pprof endpoint enabled through the API
+	pprof pprofSession
+
+	// Rate limits of the API requests
+	rateLimiter *ratelimit.Limiter
+
 	// Tasks registry for long-running background tasks
 	// Keep clustering tasks separate as they cause a lot of CPU wakeups
 	tasks        task.Group
@@ -121,6 +156,13 @@ type Daemon struct {
 
 	oidcVerifier *oidc.Verifier
 
+	// Router of the REST API, used to run the requests of batches.
+	restRouter *mux.Router
+
+	// Whether the UI and documentation directories are available.
+	uiEnabled            atomic.Bool
+	documentationEnabled atomic.Bool
+
 	// Stores last heartbeat node information to detect node changes.
 	lastNodeList *cluster.APIHeartbeat
 
@@ -197,6 +239,12 @@ func newDaemon(config *DaemonConfig, os *sys.OS) *Daemon {
 		config:         config,
 		devIncusEvents: devIncusEvents,
 		events:         incusEvents,
+		sessions:       sessions.NewManager(),
+		audit:          audit.NewLog(internalUtil.VarPath("audit.log"), internalUtil.VarPath("audit.key")),
+		logStore:       logstore.NewStore(internalUtil.VarPath("server-logs"), logrus.InfoLevel),
+		eventCounters:  metrics.NewEventCounters(),
+		requestMetrics: metrics.NewRequestMetrics(),
+		rateLimiter:    ratelimit.NewLimiter(),
 		db:             &db.DB{},
 		os:             os,
 		setupChan:      make(chan struct{}),
@@ -467,6 +515,91 @@ func (d *Daemon) getTrustedCertificates() (map[certificate.Type]map[string]x509.
 	return certs, nil
 }
 
+// identityProtocol returns the authentication method of an identity to impersonate.
+// Trusted client certificates are referred to by their fingerprint, any other identity is considered an OIDC user.
+func (d *Daemon) identityProtocol(identity string) (string, error) {
+	_, found := d.clientCerts.GetCertificates()[certificate.TypeClient][identity]
+	if found {
+		return api.AuthenticationMethodTLS, nil
+	}
+
+	if d.oidcVerifier == nil {
+		return "", fmt.Errorf("Identity %q isn't a trusted client certificate", identity)
+	}
+
+	return api.AuthenticationMethodOIDC, nil
+}
+
+// rateAllowed returns whether a request from the given identity is within the configured rate limits.
+// Local and internal requests aren't limited, and untrusted clients are limited by remote address.
+func (d *Daemon) rateAllowed(r *http.Request, username string, protocol string) bool {
+	if protocol == "unix" || protocol == "cluster" {
+		return true
+	}
+
+	// Give each remote address of the untrusted clients its own bucket, prefixed so that it can't match a username.
+	identity := username
+	if identity == "" {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+
+		identity = "anonymous:" + host
+	}
+
+	var globalRate, identityRate int64
+
+	d.globalConfigMu.Lock()
+	if d.globalConfig != nil {
+		globalRate, identityRate = d.globalConfig.RateLimits()
+	}
+	d.globalConfigMu.Unlock()
+
+	return d.rateLimiter.Allow(identity, globalRate, identityRate)
+}
+
+// sourceAllowed returns whether the authenticated identity is allowed to use the API from the address of the request.
+func (d *Daemon) sourceAllowed(r *http.Request, username string, protocol string) bool {
+	if protocol != api.AuthenticationMethodTLS && protocol != api.AuthenticationMethodOIDC {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	address := net.ParseIP(host)
+	if address == nil {
+		return false
+	}
+
+	if protocol == api.AuthenticationMethodTLS {
+		return d.clientCerts.SourceAllowed(username, address)
+	}
+
+	var networks []*net.IPNet
+
+	d.globalConfigMu.Lock()
+	if d.globalConfig != nil {
+		networks = d.globalConfig.OIDCAllowedSources()[username]
+	}
+	d.globalConfigMu.Unlock()
+
+	if networks == nil {
+		return true
+	}
+
+	for _, network := range networks {
+		if network.Contains(address) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Authenticate validates an incoming http Request
 // It will check over what protocol it came, what type of request it is and
 // will validate the TLS certificate.
@@ -543,11 +676,19 @@ func (d *Daemon) Authenticate(w http.ResponseWriter, r *http.Request) (bool, str
 
 	// Check for JWT token signed by an OpenID Connect provider.
 	if d.oidcVerifier != nil && d.oidcVerifier.IsRequest(r) {
-		userName, err := d.oidcVerifier.Auth(d.shutdownCtx, w, r)
+		userName, groups, err := d.oidcVerifier.Auth(d.shutdownCtx, w, r)
 		if err != nil {
 			return false, "", "", err
 		}
 
+		// Keep the group memberships of the user in sync with the identity provider.
+		if groups != nil {
+			err = d.authorizer.SetUserGroups(r.Context(), userName, groups, d.oidcVerifier.ManagedGroups())
+			if err != nil {
+				logger.Warn("Failed updating the groups of OIDC user", logger.Ctx{"username": userName, "err": err})
+			}
+		}
+
 		return true, userName, api.AuthenticationMethodOIDC, nil
 	}
 
@@ -630,6 +771,7 @@ func (d *Daemon) createCmd(restAPI *mux.Router, version string, c APIEndpoint) {
 	}
 
 	route := restAPI.HandleFunc(uri, func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
 		w.Header().Set("Content-Type", "application/json")
 
 		if !(r.RemoteAddr == "@" && version == "internal") {
@@ -705,6 +847,74 @@ func (d *Daemon) createCmd(restAPI *mux.Router, version string, c APIEndpoint) {
 			return
 		}
 
+		// Reject the requests of blocked identities.
+		if trusted && protocol != "cluster" && d.sessions.IsBlocked(username) {
+			logger.Warn("Rejecting request from blocked identity", logCtx)
+			d.auditRequest(r, username, protocol, http.StatusForbidden, "Identity is temporarily blocked")
+			_ = response.Forbidden(fmt.Errorf("Identity is temporarily blocked")).Render(w)
+			return
+		}
+
+		// Reject the requests of identities coming from outside of their allowed networks.
+		if trusted && !d.sourceAllowed(r, username, protocol) {
+			logger.Warn("Rejecting request from disallowed source address", logger.Ctx{"ip": r.RemoteAddr, "username": username, "protocol": protocol})
+			d.auditRequest(r, username, protocol, http.StatusForbidden, "Source address not allowed for this identity")
+			_ = response.Forbidden(fmt.Errorf("Source address not allowed for this identity")).Render(w)
+			return
+		}
+
+		// Reject the requests beyond the configured rate limits, including each of the requests of a batch.
+		if !d.rateAllowed(r, username, protocol) {
+			logger.Debug("Rejecting request beyond rate limit", logCtx)
+			w.Header().Set("Retry-After", "1")
+			_ = response.SmartError(api.StatusErrorf(http.StatusTooManyRequests, "Too many requests")).Render(w)
+			return
+		}
+
+		// Run the request as another identity when asked to by a server administrator.
+		impersonate := r.Header.Get(request.HeaderImpersonate)
+		if trusted && impersonate != "" && protocol != "cluster" {
+			err := d.authorizer.CheckPermission(r.Context(), r, auth.ObjectServer(), auth.EntitlementCanEdit)
+			if err != nil {
+				logger.Warn("Rejecting impersonation request", logger.Ctx{"ip": r.RemoteAddr, "username": username, "impersonate": impersonate, "err": err})
+				d.auditRequest(r, username, protocol, http.StatusForbidden, "Impersonation not allowed for this identity")
+				_ = response.Forbidden(fmt.Errorf("Only server administrators can impersonate other identities")).Render(w)
+				return
+			}
+
+			impersonatedProtocol, err := d.identityProtocol(impersonate)
+			if err != nil {
+				_ = response.BadRequest(err).Render(w)
+				return
+			}
+
+			logger.Info("Impersonating identity", logger.Ctx{"url": r.URL.RequestURI(), "username": username, "impersonate": impersonate})
+
+			ctx := context.WithValue(r.Context(), request.CtxImpersonator, username)
+			ctx = context.WithValue(ctx, request.CtxUsername, impersonate)
+			ctx = context.WithValue(ctx, request.CtxProtocol, impersonatedProtocol)
+			r = r.WithContext(ctx)
+
+			username = impersonate
+			protocol = impersonatedProtocol
+		}
+
+		// Keep track of the long-lived connections of the clients.
+		if websocket.IsWebSocketUpgrade(r) {
+			info, track := sessionInfo(d, r, c, username, protocol)
+			if track {
+				if d.sessions.IsBlocked(info.Username) {
+					logger.Warn("Rejecting connection from blocked identity", logger.Ctx{"ip": r.RemoteAddr, "username": info.Username})
+					_ = response.Forbidden(fmt.Errorf("Identity is temporarily blocked")).Render(w)
+					return
+				}
+
+				var done func()
+				w, done = d.sessions.Track(w, info)
+				defer done()
+			}
+		}
+
 		// Dump full request JSON when in debug mode
 		if daemon.Debug && r.Method != "GET" && localUtil.IsJSONRequest(r) {
 			newBody := &bytes.Buffer{}
@@ -751,6 +961,10 @@ func (d *Daemon) createCmd(restAPI *mux.Router, version string, c APIEndpoint) {
 			return
 		}
 
+		// Trace the handling of the request, continuing the trace of the client if any.
+		ctx, span := tracing.StartRequest(r, uri, attribute.String("incus.username", username), attribute.String("incus.protocol", protocol))
+		r = r.WithContext(ctx)
+
 		handleRequest := func(action APIEndpointAction) response.Response {
 			if action.Handler == nil {
 				return response.NotImplemented(nil)
@@ -799,14 +1013,43 @@ func (d *Daemon) createCmd(restAPI *mux.Router, version string, c APIEndpoint) {
 			_ = d.oidcVerifier.WriteHeaders(w)
 		}
 
+		// Reply without body to the clients already having the current version of the object.
+		rw, finish := response.NotModifiedWriter(w, r)
+
 		// Handle errors
-		err = resp.Render(w)
+		statusCode := resp.Code()
+		err = resp.Render(rw)
 		if err != nil {
-			writeErr := response.SmartError(err).Render(w)
+			errResp := response.SmartError(err)
+			statusCode = errResp.Code()
+
+			writeErr := errResp.Render(rw)
 			if writeErr != nil {
 				logger.Error("Failed writing error for HTTP response", logger.Ctx{"url": uri, "err": err, "writeErr": writeErr})
 			}
 		}
+
+		err = finish()
+		if err != nil {
+			logger.Error("Failed writing HTTP response", logger.Ctx{"url": uri, "err": err})
+		}
+
+		span.SetAttributes(attribute.Int("http.response.status_code", statusCode))
+		if statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(statusCode))
+		}
+
+		span.End()
+
+		// Record the duration of the request, except for the long-lived connections.
+		if !websocket.IsWebSocketUpgrade(r) {
+			d.requestMetrics.Observe(r.Method, uri, statusCode, time.Since(start))
+		}
+
+		// Record the authenticated requests of the clients.
+		if trusted && version != "internal" && protocol != "cluster" {
+			d.auditRequest(r, username, protocol, statusCode, "")
+		}
 	})
 
 	// If the endpoint has a canonical name then record it so it can be used to build URLS
@@ -1429,10 +1672,14 @@ func (d *Daemon) init() error {
 
 	d.gateway.HeartbeatOfflineThreshold = d.globalConfig.OfflineThreshold()
 	oidcIssuer, oidcClientID, oidcScope, oidcAudience, oidcClaim := d.globalConfig.OIDCServer()
+	oidcGroupsClaim, oidcGroupsMapping := d.globalConfig.OIDCGroups()
 	syslogSocketEnabled := d.localConfig.SyslogSocket()
+	emulatedArchitectures := d.localConfig.InstancesEmulationArchitectures()
 	openfgaAPIURL, openfgaAPIToken, openfgaStoreID := d.globalConfig.OpenFGA()
 	instancePlacementScriptlet := d.globalConfig.InstancesPlacementScriptlet()
 	authorizationScriptlet := d.globalConfig.AuthorizationScriptlet()
+	tracingEndpoint, tracingCACert, tracingSamplePercentage := d.globalConfig.Tracing()
+	d.db.Cluster.SlowLog().SetThreshold(d.globalConfig.SlowQueryThreshold())
 
 	d.endpoints.NetworkUpdateTrustedProxy(d.globalConfig.HTTPSTrustedProxy())
 	d.globalConfigMu.Unlock()
@@ -1443,6 +1690,24 @@ func (d *Daemon) init() error {
 		return err
 	}
 
+	// Record the log messages in the log store. The name can't clash with the loggers, which can't contain dots.
+	d.internalListener.AddHandler("internal.logstore", d.logStore.HandleEvent)
+
+	// Count the lifecycle events and failed operations of this member, skipping those pushed by the other members.
+	d.internalListener.AddHandler("internal.metrics", func(event api.Event) {
+		if event.Location != d.serverName {
+			return
+		}
+
+		d.eventCounters.HandleEvent(event)
+	})
+
+	// Setup the export of the traces.
+	err = tracing.Configure(tracingEndpoint, tracingCACert, tracingSamplePercentage, d.serverName)
+	if err != nil {
+		return fmt.Errorf("Failed to configure tracing: %w", err)
+	}
+
 	// Setup syslog listener.
 	if syslogSocketEnabled {
 		err = d.setupSyslogSocket(true)
@@ -1451,9 +1716,17 @@ func (d *Daemon) init() error {
 		}
 	}
 
+	// Setup foreign architecture emulation.
+	if len(emulatedArchitectures) > 0 {
+		err = d.setupEmulation(emulatedArchitectures)
+		if err != nil {
+			logger.Error("Failed to setup architecture emulation", logger.Ctx{"err": err})
+		}
+	}
+
 	// Setup OIDC authentication.
 	if oidcIssuer != "" && oidcClientID != "" {
-		d.oidcVerifier, err = oidc.NewVerifier(oidcIssuer, oidcClientID, oidcScope, oidcAudience, oidcClaim)
+		d.oidcVerifier, err = oidc.NewVerifier(oidcIssuer, oidcClientID, oidcScope, oidcAudience, oidcClaim, oidcGroupsClaim, oidcGroupsMapping)
 		if err != nil {
 			return err
 		}
@@ -1572,6 +1845,9 @@ func (d *Daemon) init() error {
 		return err
 	}
 
+	// Roll back the operations interrupted by a crash.
+	rollbackInterruptedOperations(d.State())
+
 	// Cleanup leftover images.
 	pruneLeftoverImages(d.State())
 
@@ -1663,6 +1939,9 @@ func (d *Daemon) init() error {
 		// Log expiry (daily)
 		d.tasks.Add(expireLogsTask(d.State()))
 
+		// Send the console output of the instances to the loggers (every 10s)
+		d.tasks.Add(consoleLogsTask(d))
+
 		// Remove expired images (daily)
 		d.taskPruneImages = d.tasks.Add(pruneExpiredImagesTask(d))
 
@@ -1684,11 +1963,17 @@ func (d *Daemon) init() error {
 		// Remove resolved warnings (daily)
 		d.tasks.Add(pruneResolvedWarningsTask(d))
 
+		// Resolve cleared warnings, expire acknowledgments and notify new warnings (minutely)
+		d.tasks.Add(warningsTask(d))
+
 		// Auto-renew server certificate (daily)
 		d.tasks.Add(autoRenewCertificateTask(d))
 
 		// Remove expired tokens (hourly)
 		d.tasks.Add(autoRemoveExpiredTokensTask(d))
+
+		// Run the scheduled tasks (minutely check of configurable cron expression)
+		d.tasks.Add(scheduledTasksTask(d))
 	}
 
 	// Start all background tasks
@@ -1903,6 +2188,10 @@ func (d *Daemon) Stop(ctx context.Context, sig os.Signal) error {
 		trackError(d.endpoints.Down(), "Shutdown endpoints")
 	}
 
+	trackError(d.audit.Close(), "Close audit log")
+	trackError(d.logStore.Close(), "Close log store")
+	trackError(tracing.Shutdown(ctx), "Flush traces")
+
 	if shouldUnmount {
 		logger.Info("Unmounting temporary filesystems")
 
@@ -1956,6 +2245,11 @@ func (d *Daemon) setupOpenFGA(apiURL string, apiToken string, storeID string) er
 		return nil
 	}
 
+	apiToken, err = secrets.Resolve(d.shutdownCtx, apiToken)
+	if err != nil {
+		return fmt.Errorf("Failed resolving OpenFGA API token: %w", err)
+	}
+
 	config := map[string]any{
 		"openfga.api.url":   apiURL,
 		"openfga.api.token": apiToken,
@@ -2283,6 +2577,45 @@ func (d *Daemon) setupSyslogSocket(enable bool) error {
 	return nil
 }
 
+// Foreign architecture emulation.
+func (d *Daemon) setupEmulation(names []string) error {
+	architectures := []int{}
+	for _, name := range names {
+		arch, err := osarch.ArchitectureID(name)
+		if err != nil {
+			return err
+		}
+
+		// Native architectures don't need emulation.
+		if slices.Contains(d.os.Architectures, arch) || slices.Contains(architectures, arch) {
+			continue
+		}
+
+		err = binfmt.Register(arch)
+		if err != nil {
+			return err
+		}
+
+		architectures = append(architectures, arch)
+	}
+
+	// Remove the handlers which are no longer needed.
+	for _, arch := range d.os.EmulatedArchitectures {
+		if slices.Contains(architectures, arch) {
+			continue
+		}
+
+		err := binfmt.Unregister(arch)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.os.EmulatedArchitectures = architectures
+
+	return nil
+}
+
 // Create a database connection and perform any updates needed.
 func initializeDbObject(d *Daemon) error {
 	logger.Info("Initializing local database")
@@ -46,3 +46,86 @@ func daemonConfigSetProxy(d *Daemon, config *clusterConfig.Config) {
 		config.ProxyIgnoreHosts(),
 	)
 }
+
+// daemonConfigReload re-reads the server configuration from the database and applies any change that the daemon
+// missed, for example because it was made directly in the database. It also re-creates the loggers, OIDC verifier
+// and OpenFGA authorizer so that the secrets and provider metadata they use are fetched again, and checks again
+// whether the UI and documentation directories are available.
+func daemonConfigReload(d *Daemon) error {
+	var newClusterConfig *clusterConfig.Config
+	err := d.db.Cluster.Transaction(context.Background(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+		newClusterConfig, err = clusterConfig.Load(ctx, tx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	var newNodeConfig *node.Config
+	err = d.db.Node.Transaction(context.Background(), func(ctx context.Context, tx *db.NodeTx) error {
+		var err error
+		newNodeConfig, err = node.ConfigLoad(ctx, tx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// Swap in the new config, keeping the old one to find what changed.
+	d.globalConfigMu.Lock()
+	oldClusterConfig := d.globalConfig
+	oldNodeConfig := d.localConfig
+	d.globalConfig = newClusterConfig
+	d.localConfig = newNodeConfig
+	d.globalConfigMu.Unlock()
+
+	clusterChanged := configChanges(oldClusterConfig.Dump(), newClusterConfig.Dump())
+	nodeChanged := configChanges(oldNodeConfig.Dump(), newNodeConfig.Dump())
+
+	// Re-create the loggers, as their passwords may come from secret references.
+	loggers, err := newClusterConfig.Loggers()
+	if err != nil {
+		return err
+	}
+
+	for loggerName := range loggers {
+		clusterChanged["logging."+loggerName+".target.type"] = loggers[loggerName]
+	}
+
+	// Same for the OIDC verifier (provider metadata) and the OpenFGA authorizer (token).
+	oidcIssuer, _, _, _, _ := newClusterConfig.OIDCServer()
+	if oidcIssuer != "" {
+		clusterChanged["oidc.issuer"] = oidcIssuer
+	}
+
+	openfgaAPIURL, openfgaAPIToken, _ := newClusterConfig.OpenFGA()
+	if openfgaAPIURL != "" {
+		clusterChanged["openfga.api.token"] = openfgaAPIToken
+	}
+
+	d.detectWebDirectories()
+
+	return doApi10UpdateTriggers(d, nodeChanged, clusterChanged, newNodeConfig, newClusterConfig)
+}
+
+// configChanges returns the keys whose value differs between the two configs, with their new value.
+// Removed keys are returned with an empty value.
+func configChanges(oldConfig map[string]string, newConfig map[string]string) map[string]string {
+	changed := map[string]string{}
+
+	for key, value := range newConfig {
+		if oldConfig[key] != value {
+			changed[key] = value
+		}
+	}
+
+	for key := range oldConfig {
+		_, ok := newConfig[key]
+		if !ok {
+			changed[key] = ""
+		}
+	}
+
+	return changed
+}
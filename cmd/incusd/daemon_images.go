@@ -339,6 +339,7 @@ func ImageDownload(ctx context.Context, r *http.Request, s *state.State, op *ope
 
 		if meta["download_progress"] != progress.Text {
 			meta["download_progress"] = progress.Text
+			operations.SetProgressData(meta, "download", int64(progress.Percentage), progress.TransferredBytes, 0)
 			_ = op.UpdateMetadata(meta)
 		}
 	}
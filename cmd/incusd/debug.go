@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// pprofDefaultTimeout is how long the pprof endpoint stays enabled when no timeout is given.
+const pprofDefaultTimeout = time.Hour
+
+// pprofSession tracks the pprof endpoint enabled through the API, until it's disabled or times out.
+type pprofSession struct {
+	mu      sync.Mutex
+	timer   *time.Timer
+	expires time.Time
+
+	// generation is bumped every time the endpoint is enabled or disabled, so that a timer which fired
+	// while a new session was being set up doesn't disable it.
+	generation uint64
+}
+
+// pprofStatus returns the address of the pprof endpoint, and when it gets disabled if enabled through the API.
+func (d *Daemon) pprofStatus() map[string]string {
+	status := map[string]string{
+		"address":    d.endpoints.PprofAddress(),
+		"expires_at": "",
+	}
+
+	d.pprof.mu.Lock()
+	defer d.pprof.mu.Unlock()
+
+	if d.pprof.timer != nil {
+		status["expires_at"] = d.pprof.expires.Format(time.RFC3339)
+	}
+
+	return status
+}
+
+// pprofEnable starts the pprof endpoint on the given address, reverting to the configured one after the timeout.
+func (d *Daemon) pprofEnable(address string, timeout time.Duration) error {
+	d.pprof.mu.Lock()
+	defer d.pprof.mu.Unlock()
+
+	err := d.endpoints.PprofUpdateAddress(address)
+	if err != nil {
+		return err
+	}
+
+	if d.pprof.timer != nil {
+		d.pprof.timer.Stop()
+	}
+
+	logger.Warn("Enabled pprof endpoint", logger.Ctx{"address": d.endpoints.PprofAddress(), "timeout": timeout})
+
+	d.pprof.generation++
+	generation := d.pprof.generation
+
+	d.pprof.expires = time.Now().Add(timeout)
+	d.pprof.timer = time.AfterFunc(timeout, func() {
+		d.pprof.mu.Lock()
+		defer d.pprof.mu.Unlock()
+
+		// Leave the session alone if it was replaced or disabled since.
+		if d.pprof.generation != generation {
+			return
+		}
+
+		err := d.pprofReset()
+		if err != nil {
+			logger.Error("Failed disabling pprof endpoint", logger.Ctx{"err": err})
+		}
+	})
+
+	return nil
+}
+
+// pprofDisable reverts the pprof endpoint to the address of the core.debug_address configuration key.
+func (d *Daemon) pprofDisable() error {
+	d.pprof.mu.Lock()
+	defer d.pprof.mu.Unlock()
+
+	return d.pprofReset()
+}
+
+// pprofReset does the work of pprofDisable, with the lock of the session held.
+func (d *Daemon) pprofReset() error {
+	d.pprof.generation++
+
+	if d.pprof.timer != nil {
+		d.pprof.timer.Stop()
+		d.pprof.timer = nil
+	}
+
+	err := d.endpoints.PprofUpdateAddress(d.State().LocalConfig.DebugAddress())
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Disabled pprof endpoint")
+
+	return nil
+}
+
+// Return the status of the pprof endpoint.
+func internalDebugPprofGet(d *Daemon, r *http.Request) response.Response {
+	return response.SyncResponse(true, d.pprofStatus())
+}
+
+// Enable the pprof endpoint.
+func internalDebugPprofPut(d *Daemon, r *http.Request) response.Response {
+	address := request.QueryParam(r, "address")
+	if address == "" {
+		return response.BadRequest(errors.New("Missing pprof address"))
+	}
+
+	timeout := pprofDefaultTimeout
+
+	value := request.QueryParam(r, "timeout")
+	if value != "" {
+		var err error
+
+		timeout, err = time.ParseDuration(value)
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid timeout %q: %w", value, err))
+		}
+
+		if timeout <= 0 {
+			return response.BadRequest(fmt.Errorf("Invalid timeout %q: Must be positive", value))
+		}
+	}
+
+	err := d.pprofEnable(address, timeout)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, d.pprofStatus())
+}
+
+// Disable the pprof endpoint.
+func internalDebugPprofDelete(d *Daemon, r *http.Request) response.Response {
+	err := d.pprofDisable()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
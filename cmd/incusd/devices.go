@@ -527,6 +527,13 @@ func deviceTaskBalance(s *state.State) {
 				logger.Warnf("The pinned CPUs: %v, override the NUMA configuration with the CPUs: %v", containerCpus, numaCpus)
 			}
 
+			// Instances using a cpuset partition get exclusive use of their CPUs so aren't balanced.
+			partition := conf["limits.cpu.partition"]
+			if partition == "root" || partition == "isolated" {
+				deviceTaskSetPartition(c, containerCpus, partition)
+				continue
+			}
+
 			fillFixedInstances(fixedInstances, c, cpus, containerCpus, len(containerCpus), false)
 		}
 	}
@@ -610,6 +617,81 @@ func deviceTaskBalance(s *state.State) {
 	}
 }
 
+// deviceTaskSetPartition applies the pinned CPUs of an instance and turns them into a cpuset partition.
+func deviceTaskSetPartition(inst instance.Instance, cpus []int64, partition string) {
+	set := make([]string, 0, len(cpus))
+	for _, id := range cpus {
+		set = append(set, fmt.Sprintf("%d", id))
+	}
+
+	cg, err := inst.CGroup()
+	if err != nil {
+		logger.Error("balance: Unable to get cgroup struct", logger.Ctx{"name": inst.Name(), "err": err})
+		return
+	}
+
+	err = cg.SetCpuset(strings.Join(set, ","))
+	if err != nil {
+		logger.Error("balance: Unable to set cpuset", logger.Ctx{"name": inst.Name(), "err": err, "value": strings.Join(set, ",")})
+		return
+	}
+
+	// New partitions can only be created under a partition root.
+	parent, err := deviceTaskCgroupParent(inst.InitPID())
+	if err != nil {
+		logger.Error("balance: Unable to find the parent cgroup", logger.Ctx{"name": inst.Name(), "err": err})
+		return
+	}
+
+	isRoot, err := cgroup.CpusetIsPartitionRoot(parent)
+	if err != nil {
+		logger.Error("balance: Unable to get the parent cpuset partition", logger.Ctx{"name": inst.Name(), "err": err, "parent": parent})
+		return
+	}
+
+	if !isRoot {
+		logger.Error("balance: Unable to set cpuset partition as the parent cgroup isn't a partition root", logger.Ctx{"name": inst.Name(), "value": partition, "parent": parent})
+		return
+	}
+
+	err = cg.SetCpusetPartition(partition)
+	if err != nil {
+		logger.Error("balance: Unable to set cpuset partition", logger.Ctx{"name": inst.Name(), "err": err, "value": partition})
+
+		// Go back to a regular member rather than keeping an invalid partition.
+		err = cg.SetCpusetPartition("member")
+		if err != nil {
+			logger.Error("balance: Unable to reset cpuset partition", logger.Ctx{"name": inst.Name(), "err": err})
+		}
+	}
+}
+
+// deviceTaskCgroupParent returns the path, in the unified hierarchy, of the parent of the cgroup of the
+// container whose init process is given. That process may be in a sub-cgroup of the container, like init.scope.
+func deviceTaskCgroupParent(pid int) (string, error) {
+	content, err := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return "", err
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		cgPath, found := strings.CutPrefix(strings.TrimSpace(line), "0::")
+		if !found {
+			continue
+		}
+
+		for dir := filepath.Clean(cgPath); dir != "/" && dir != "."; dir = filepath.Dir(dir) {
+			if strings.HasPrefix(filepath.Base(dir), "lxc.payload.") {
+				return filepath.Dir(dir), nil
+			}
+		}
+
+		return "", fmt.Errorf("Couldn't find the container cgroup in %q", cgPath)
+	}
+
+	return "", fmt.Errorf("Process %d isn't in the unified cgroup hierarchy", pid)
+}
+
 // deviceEventListener starts the event listener for resource scheduling.
 // Accepts stateFunc which will be called each time it needs a fresh state.State.
 func deviceEventListener(stateFunc func() *state.State) {
@@ -21,8 +21,8 @@ import (
 )
 
 var (
-	eventTypes           = []string{api.EventTypeLogging, api.EventTypeOperation, api.EventTypeLifecycle, api.EventTypeNetworkACL}
-	privilegedEventTypes = []string{api.EventTypeLogging}
+	eventTypes           = []string{api.EventTypeLogging, api.EventTypeOperation, api.EventTypeLifecycle, api.EventTypeNetworkACL, api.EventTypeAudit}
+	privilegedEventTypes = []string{api.EventTypeLogging, api.EventTypeAudit}
 )
 
 var eventsCmd = APIEndpoint{
@@ -102,8 +102,12 @@ func eventsSocket(s *state.State, r *http.Request, w http.ResponseWriter) error
 		}
 	}
 
-	if slices.Contains(types, api.EventTypeLogging) && !canViewPrivilegedEvents {
-		return api.StatusErrorf(http.StatusForbidden, "Forbidden")
+	if !canViewPrivilegedEvents {
+		for _, entry := range types {
+			if slices.Contains(privilegedEventTypes, entry) {
+				return api.StatusErrorf(http.StatusForbidden, "Forbidden")
+			}
+		}
 	}
 
 	l := logger.AddContext(logger.Ctx{"remote": r.RemoteAddr})
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/cluster"
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/response"
+	storagePools "github.com/lxc/incus/v6/internal/server/storage"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// healthCheckTimeout is the maximum time spent waiting for the database when checking the health of the server.
+const healthCheckTimeout = 5 * time.Second
+
+// healthHandler returns the handler of the /healthz (or /readyz when ready is true) endpoint.
+// Both endpoints are unauthenticated and only served when core.health_endpoints is enabled.
+func healthHandler(d *Daemon, ready bool) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		d.globalConfigMu.Lock()
+		enabled := d.globalConfig != nil && d.globalConfig.HealthEndpoints()
+		d.globalConfigMu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if !enabled {
+			_ = response.NotFound(nil).Render(w)
+			return
+		}
+
+		health := healthCheck(r.Context(), d, ready)
+
+		if health.Status != api.HealthStatusOK {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		_ = json.NewEncoder(w).Encode(health)
+	}
+}
+
+// healthCheck checks the database, storage pools and event distribution of the server.
+// When ready is true, it also checks that the daemon is fully started and not shutting down.
+func healthCheck(ctx context.Context, d *Daemon, ready bool) api.Health {
+	health := api.Health{
+		Status:     api.HealthStatusOK,
+		Components: map[string]api.HealthComponent{},
+	}
+
+	setComponent := func(name string, err error) {
+		if err == nil {
+			health.Components[name] = api.HealthComponent{Status: api.HealthStatusOK}
+			return
+		}
+
+		health.Status = api.HealthStatusError
+		health.Components[name] = api.HealthComponent{Status: api.HealthStatusError, Message: err.Error()}
+	}
+
+	// Check that the cluster database can be queried, getting the storage pools at the same time.
+	var poolNames []string
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	err := d.db.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		poolNames, err = tx.GetCreatedStoragePoolNames(ctx)
+		if err != nil && !api.StatusErrorCheck(err, http.StatusNotFound) {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		setComponent("database", fmt.Errorf("Failed querying the database: %w", err))
+		setComponent("storage", errors.New("Failed getting the storage pools"))
+	} else {
+		setComponent("database", nil)
+
+		// Check that none of the storage pools failed to mount.
+		unavailable := []string{}
+		for _, poolName := range poolNames {
+			if !storagePools.IsAvailable(poolName) {
+				unavailable = append(unavailable, poolName)
+			}
+		}
+
+		if len(unavailable) > 0 {
+			sort.Strings(unavailable)
+			setComponent("storage", fmt.Errorf("Unavailable storage pools: %s", strings.Join(unavailable, ", ")))
+		} else {
+			setComponent("storage", nil)
+		}
+	}
+
+	// Check that events from the other cluster members can be received.
+	setComponent("events", cluster.EventHubStatus())
+
+	if ready {
+		var err error
+		if d.shutdownCtx.Err() != nil {
+			err = errors.New("Daemon is shutting down")
+		} else if d.waitReady.Err() == nil {
+			err = errors.New("Daemon not ready yet")
+		}
+
+		setComponent("daemon", err)
+	}
+
+	return health
+}
@@ -25,6 +25,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/kballard/go-shellquote"
 	"gopkg.in/yaml.v2"
@@ -244,6 +245,30 @@ func imgPostInstanceInfo(ctx context.Context, s *state.State, r *http.Request, r
 		return nil, err
 	}
 
+	// Checkpoint running containers into a temporary stateful snapshot and publish it instead.
+	if req.Source.Live {
+		if ctype == "snapshot" || c.Type() != instancetype.Container {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Live publishing is only supported for containers")
+		}
+
+		if !c.IsRunning() {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Live publishing requires the instance to be running")
+		}
+
+		snapName := "publish-" + strings.ReplaceAll(uuid.New().String(), "-", "")
+		err = c.Snapshot(snapName, time.Time{}, true)
+		if err != nil {
+			return nil, fmt.Errorf("Failed checkpointing instance: %w", err)
+		}
+
+		c, err = instance.LoadByProjectAndName(s, projectName, name+internalInstance.SnapshotDelimiter+snapName)
+		if err != nil {
+			return nil, err
+		}
+
+		defer func() { _ = c.Delete(true) }()
+	}
+
 	info.Type = c.Type().String()
 
 	// Build the actual image file
@@ -326,6 +351,7 @@ func imgPostInstanceInfo(ctx context.Context, s *state.State, r *http.Request, r
 		compress = req.CompressionAlgorithm
 	} else {
 		var p *api.Project
+		var groupCompress string
 		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
 			project, err := dbCluster.GetProject(ctx, tx.Tx(), projectName)
 			if err != nil {
@@ -333,6 +359,12 @@ func imgPostInstanceInfo(ctx context.Context, s *state.State, r *http.Request, r
 			}
 
 			p, err = project.ToAPI(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			// Members of cluster groups may override the server default.
+			groupCompress, err = tx.GetNodeClusterGroupsConfigValue(ctx, s.ServerName, "images.compression_algorithm")
 
 			return err
 		})
@@ -342,6 +374,8 @@ func imgPostInstanceInfo(ctx context.Context, s *state.State, r *http.Request, r
 
 		if p.Config["images.compression_algorithm"] != "" {
 			compress = p.Config["images.compression_algorithm"]
+		} else if groupCompress != "" {
+			compress = groupCompress
 		} else {
 			compress = s.GlobalConfig.ImagesCompressionAlgorithm()
 		}
@@ -415,9 +449,9 @@ func imgPostInstanceInfo(ctx context.Context, s *state.State, r *http.Request, r
 	metaWriter = internalIO.NewQuotaWriter(metaWriter, budget)
 	rootfsWriter = internalIO.NewQuotaWriter(rootfsWriter, budget)
 	if imageType != "split" {
-		meta, err = c.Export(metaWriter, nil, req.Properties, req.ExpiresAt, tracker)
+		meta, err = c.Export(metaWriter, nil, req.Properties, req.ExpiresAt, req.Source.Live, tracker)
 	} else {
-		meta, err = c.Export(metaWriter, rootfsWriter, req.Properties, req.ExpiresAt, tracker)
+		meta, err = c.Export(metaWriter, rootfsWriter, req.Properties, req.ExpiresAt, req.Source.Live, tracker)
 	}
 
 	// Clean up file handles.
@@ -1349,6 +1383,12 @@ func imagesPost(d *Daemon, r *http.Request) response.Response {
 			return fmt.Errorf("Failed syncing image between servers: %w", err)
 		}
 
+		// Pre-replicate the image if required by its project.
+		err = imageReplicate(context.TODO(), s, r, projectName, info.Fingerprint)
+		if err != nil {
+			return fmt.Errorf("Failed replicating image to cluster members: %w", err)
+		}
+
 		// Add the image to the authorizer.
 		err = s.Authorizer.AddImage(s.ShutdownCtx, projectName, info.Fingerprint)
 		if err != nil {
@@ -1878,7 +1918,17 @@ func autoUpdateImages(ctx context.Context, s *state.State) error {
 			return err
 		}
 
+		// Skip the images whose project doesn't allow background tasks right now.
+		skipProjects, err := projectutils.OutsideMaintenanceWindow(ctx, tx, time.Now())
+		if err != nil {
+			return err
+		}
+
 		for _, image := range images {
+			if slices.Contains(skipProjects, image.Project) {
+				continue
+			}
+
 			imageMap[image.Fingerprint] = append(imageMap[image.Fingerprint], image)
 		}
 
@@ -3507,6 +3557,8 @@ func imageAliasesPost(d *Daemon, r *http.Request) response.Response {
 	lc := lifecycle.ImageAliasCreated.Event(req.Name, projectName, requestor, logger.Ctx{"target": req.Target})
 	s.Events.SendLifecycle(projectName, lc)
 
+	imageReplicateBackground(s, projectName, req.Target)
+
 	return response.SyncResponseLocation(true, nil, lc.Source)
 }
 
@@ -3920,6 +3972,8 @@ func imageAliasPut(d *Daemon, r *http.Request) response.Response {
 	requestor := request.CreateRequestor(r)
 	s.Events.SendLifecycle(projectName, lifecycle.ImageAliasUpdated.Event(imgAlias.Name, projectName, requestor, logger.Ctx{"target": req.Target}))
 
+	imageReplicateBackground(s, projectName, req.Target)
+
 	return response.EmptySyncResponse
 }
 
@@ -4026,6 +4080,8 @@ func imageAliasPatch(d *Daemon, r *http.Request) response.Response {
 	requestor := request.CreateRequestor(r)
 	s.Events.SendLifecycle(projectName, lifecycle.ImageAliasUpdated.Event(imgAlias.Name, projectName, requestor, logger.Ctx{"target": imgAlias.Target}))
 
+	imageReplicateBackground(s, projectName, imgAlias.Target)
+
 	return response.EmptySyncResponse
 }
 
@@ -4076,6 +4132,7 @@ func imageAliasPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	var target string
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		// This is just to see if the alias name already exists.
 		_, _, err := tx.GetImageAlias(ctx, projectName, req.Name, true)
@@ -4087,11 +4144,13 @@ func imageAliasPost(d *Daemon, r *http.Request) response.Response {
 			return api.StatusErrorf(http.StatusConflict, "Alias %q already exists", req.Name)
 		}
 
-		imgAliasID, _, err := tx.GetImageAlias(ctx, projectName, name, true)
+		imgAliasID, imgAlias, err := tx.GetImageAlias(ctx, projectName, name, true)
 		if err != nil {
 			return err
 		}
 
+		target = imgAlias.Target
+
 		return tx.RenameImageAlias(ctx, imgAliasID, req.Name)
 	})
 	if err != nil {
@@ -4108,6 +4167,8 @@ func imageAliasPost(d *Daemon, r *http.Request) response.Response {
 	lc := lifecycle.ImageAliasRenamed.Event(req.Name, projectName, requestor, logger.Ctx{"old_name": name})
 	s.Events.SendLifecycle(projectName, lc)
 
+	imageReplicateBackground(s, projectName, target)
+
 	return response.SyncResponseLocation(true, nil, lc.Source)
 }
 
@@ -4735,6 +4796,14 @@ func autoSyncImages(ctx context.Context, s *state.State) error {
 				logger.Error("Failed to synchronize images", logger.Ctx{"err": err, "project": projectName, "fingerprint": fingerprint})
 			}
 
+			// Catch up on pre-replication, for members which were offline or have joined since the image was imported.
+			for _, projectName := range projects {
+				err := imageReplicate(ctx, s, nil, projectName, fingerprint)
+				if err != nil {
+					logger.Error("Failed to replicate image", logger.Ctx{"err": err, "project": projectName, "fingerprint": fingerprint})
+				}
+			}
+
 			ch <- nil
 		}(projects[0], fingerprint)
 
@@ -4872,6 +4941,164 @@ func imageSyncBetweenNodes(ctx context.Context, s *state.State, r *http.Request,
 	return nil
 }
 
+// imageReplicate pre-replicates an image onto the cluster members selected by the images.replication_group
+// configuration key of its project when one of its aliases is listed in images.replication_aliases.
+// When images.replication_pool is set, the image is also unpacked into that storage pool on those members.
+func imageReplicate(ctx context.Context, s *state.State, r *http.Request, projectName string, fingerprint string) error {
+	if !s.ServerClustered {
+		return nil
+	}
+
+	var image *api.Image
+	var pool string
+	var members []db.NodeInfo
+	var membersWithImage []string
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		// The replication settings are those of the project holding the images.
+		hasImages, err := dbCluster.ProjectHasImages(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return err
+		}
+
+		if !hasImages {
+			projectName = api.ProjectDefaultName
+		}
+
+		dbProject, err := dbCluster.GetProject(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return err
+		}
+
+		p, err := dbProject.ToAPI(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		aliases := util.SplitNTrimSpace(p.Config["images.replication_aliases"], ",", -1, true)
+		if len(aliases) == 0 {
+			return nil
+		}
+
+		_, img, err := tx.GetImage(ctx, fingerprint, dbCluster.ImageFilter{Project: &projectName})
+		if err != nil {
+			return err
+		}
+
+		if !slices.ContainsFunc(img.Aliases, func(alias api.ImageAlias) bool { return slices.Contains(aliases, alias.Name) }) {
+			return nil
+		}
+
+		image = img
+		pool = p.Config["images.replication_pool"]
+
+		allMembers, err := tx.GetNodes(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed getting cluster members: %w", err)
+		}
+
+		var groupMembers []string
+		group := p.Config["images.replication_group"]
+		if group != "" {
+			groupMembers, err = tx.GetClusterGroupNodes(ctx, group)
+			if err != nil {
+				return fmt.Errorf("Failed getting members of cluster group %q: %w", group, err)
+			}
+		}
+
+		for _, member := range allMembers {
+			// Witness members don't run instances, and offline members are caught up by the image synchronization task.
+			if slices.Contains(member.Roles, db.ClusterRoleWitness) || member.IsOffline(s.GlobalConfig.OfflineThreshold()) {
+				continue
+			}
+
+			if group != "" && !slices.Contains(groupMembers, member.Name) {
+				continue
+			}
+
+			members = append(members, member)
+		}
+
+		membersWithImage, err = tx.GetNodesWithImage(ctx, image.Fingerprint)
+		if err != nil {
+			return fmt.Errorf("Failed getting cluster members with the image: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if image == nil {
+		return nil
+	}
+
+	req := api.ImagesPost{
+		Source: &api.ImagesPostSource{
+			Fingerprint: image.Fingerprint,
+			Mode:        "pull",
+			Type:        "image",
+			Project:     projectName,
+		},
+	}
+
+	for _, member := range members {
+		if !slices.Contains(membersWithImage, member.Address) {
+			client, err := cluster.Connect(member.Address, s.Endpoints.NetworkCert(), s.ServerCert(), r, true)
+			if err != nil {
+				return fmt.Errorf("Failed to connect to cluster member %q for image replication: %w", member.Name, err)
+			}
+
+			logger.Info("Replicating image to member", logger.Ctx{"fingerprint": image.Fingerprint, "member": member.Name, "project": projectName})
+			op, err := client.UseProject(projectName).CreateImage(req, nil)
+			if err != nil {
+				return fmt.Errorf("Failed to replicate image to cluster member %q: %w", member.Name, err)
+			}
+
+			err = op.Wait()
+			if err != nil {
+				return fmt.Errorf("Failed to replicate image to cluster member %q: %w", member.Name, err)
+			}
+		}
+
+		if pool == "" {
+			continue
+		}
+
+		if member.Address == s.LocalConfig.ClusterAddress() {
+			err = imageCreateInPool(s, image, pool)
+		} else {
+			var client incus.InstanceServer
+
+			client, err = cluster.Connect(member.Address, s.Endpoints.NetworkCert(), s.ServerCert(), r, true)
+			if err == nil {
+				_, _, err = client.RawQuery("POST", "/internal/image-optimize", internalImageOptimizePost{Image: *image, Pool: pool}, "")
+			}
+		}
+
+		if err != nil {
+			return fmt.Errorf("Failed to create image in storage pool %q of cluster member %q: %w", pool, member.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// imageReplicateBackground runs imageReplicate for requests which shouldn't wait for the replication, like alias changes.
+func imageReplicateBackground(s *state.State, projectName string, fingerprint string) {
+	if !s.ServerClustered {
+		return
+	}
+
+	go func() {
+		err := imageReplicate(s.ShutdownCtx, s, nil, projectName, fingerprint)
+		if err != nil {
+			logger.Warn("Failed replicating image to cluster members", logger.Ctx{"err": err, "project": projectName, "fingerprint": fingerprint})
+		}
+	}()
+}
+
 func createTokenResponse(s *state.State, r *http.Request, projectName string, fingerprint string, metadata jmap.Map) response.Response {
 	secret, err := internalUtil.RandomHexString(32)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -158,6 +159,15 @@ func instanceCreateFromImage(ctx context.Context, s *state.State, img *api.Image
 	// Set the BaseImage field (regardless of previous value).
 	args.BaseImage = img.Fingerprint
 
+	// Restore the checkpoint of live published images on first start.
+	if args.Type == instancetype.Container && util.IsTrue(img.Properties["stateful"]) {
+		args.Stateful = true
+
+		if args.Config["migration.stateful"] == "" {
+			args.Config["migration.stateful"] = "true"
+		}
+	}
+
 	// Create the instance.
 	inst, instOp, cleanup, err := instance.CreateInternal(s, args, op, true, true)
 	if err != nil {
@@ -200,6 +210,8 @@ func instanceCreateFromImage(ctx context.Context, s *state.State, img *api.Image
 
 	reverter.Add(func() { _ = inst.Delete(true) })
 
+	defer op.StartPhase("post_processing")()
+
 	// If dealing with an OCI image, parse the configuration.
 	if args.Type == instancetype.Container && inst.LocalConfig()["image.type"] == "oci" {
 		// Reset the config to the post-generation one.
@@ -516,6 +528,8 @@ func instanceCreateAsCopy(s *state.State, opts instanceCreateAsCopyOpts, op *ope
 		}
 	}
 
+	defer op.StartPhase("post_processing")()
+
 	err = inst.UpdateBackupFile()
 	if err != nil {
 		return nil, err
@@ -613,6 +627,9 @@ func pruneExpiredInstanceSnapshots(ctx context.Context, snapshots []instance.Ins
 	return nil
 }
 
+// instanceSnapshotsDeferred holds the scheduled instance snapshots waiting for the maintenance window of their project.
+var instanceSnapshotsDeferred = project.NewDeferredTasks()
+
 func pruneExpiredAndAutoCreateInstanceSnapshotsTask(d *Daemon) (task.Func, task.Schedule) {
 	// `f` creates new scheduled instance snapshots and then, prune the expired ones
 	f := func(ctx context.Context) {
@@ -711,8 +728,13 @@ func pruneExpiredAndAutoCreateInstanceSnapshotsTask(d *Daemon) (task.Func, task.
 					return nil
 				}
 
-				// Check if snapshot is scheduled.
-				if !snapshotIsScheduledNow(schedule, int64(inst.ID())) {
+				// Check if snapshot is scheduled, deferring it to the next maintenance window of the project.
+				scheduled := snapshotIsScheduledNow(schedule, int64(inst.ID()))
+				if !instanceSnapshotsDeferred.Due(p, strconv.Itoa(inst.ID()), scheduled, time.Now()) {
+					if scheduled {
+						logger.Info("Deferring scheduled instance snapshot to the next maintenance window", logger.Ctx{"instance": inst.Name(), "project": inst.Project().Name})
+					}
+
 					return nil
 				}
 
@@ -11,6 +11,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/lxc/incus/v6/internal/filter"
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/jmap"
 	"github.com/lxc/incus/v6/internal/server/db"
@@ -42,6 +43,11 @@ import (
 //      description: Project name
 //      type: string
 //      example: default
+//    - in: query
+//      name: filter
+//      description: Collection filter
+//      type: string
+//      example: default
 //  responses:
 //    "200":
 //      description: API endpoints
@@ -91,6 +97,11 @@ import (
 //	    description: Project name
 //	    type: string
 //	    example: default
+//	  - in: query
+//	    name: filter
+//	    description: Collection filter
+//	    type: string
+//	    example: default
 //	responses:
 //	  "200":
 //	    description: API endpoints
@@ -144,6 +155,13 @@ func instanceBackupsGet(d *Daemon, r *http.Request) response.Response {
 
 	recursion := localUtil.IsRecursionRequest(r)
 
+	// Parse filter value.
+	filterStr := r.FormValue("filter")
+	clauses, err := filter.Parse(filterStr, filter.QueryOperatorSet())
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid filter: %w", err))
+	}
+
 	c, err := instance.LoadByProjectAndName(s, projectName, cname)
 	if err != nil {
 		return response.SmartError(err)
@@ -158,6 +176,17 @@ func instanceBackupsGet(d *Daemon, r *http.Request) response.Response {
 	resultMap := []*api.InstanceBackup{}
 
 	for _, backup := range backups {
+		if clauses != nil && len(clauses.Clauses) > 0 {
+			match, err := filter.Match(*backup.Render(), *clauses)
+			if err != nil {
+				return response.SmartError(err)
+			}
+
+			if !match {
+				continue
+			}
+		}
+
 		if !recursion {
 			url := fmt.Sprintf("/%s/instances/%s/backups/%s",
 				version.APIVersion, cname, strings.Split(backup.Name(), "/")[1])
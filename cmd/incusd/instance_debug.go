@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,8 +13,11 @@ import (
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
 )
 
 // swagger:operation GET /1.0/instances/{name}/debug/memory instances instance_debug_memory_get
@@ -131,3 +135,121 @@ func instanceDebugMemoryGet(d *Daemon, r *http.Request) response.Response {
 		return nil
 	})
 }
+
+// swagger:operation POST /1.0/instances/{name}/debug/qmp instances instance_debug_qmp_post
+//
+//	Run a QMP command on an instance
+//
+//	Runs a read-only QMP command on the monitor of a running virtual machine and returns its result.
+//	Only a fixed list of commands is allowed and every use is logged.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: command
+//	    description: QMP command
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceDebugQMPPost"
+//	responses:
+//	  "200":
+//	    description: QMP command result
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: object
+//	          description: Return value of the QMP command
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceDebugQMPPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName := request.ProjectParam(r)
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if internalInstance.IsSnapshot(name) {
+		return response.BadRequest(fmt.Errorf("Invalid instance name"))
+	}
+
+	// Handle requests targeted to a VM on a different node.
+	resp, err := forwardedResponseIfInstanceIsRemote(s, r, projectName, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if resp != nil {
+		return resp
+	}
+
+	req := api.InstanceDebugQMPPost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Execute == "" {
+		return response.BadRequest(fmt.Errorf("No QMP command provided"))
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, projectName, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if inst.Type() != instancetype.VM {
+		return response.BadRequest(fmt.Errorf("QMP commands are only supported for virtual machines"))
+	}
+
+	v, ok := inst.(instance.VM)
+	if !ok {
+		return response.InternalError(fmt.Errorf("Failed to cast inst to VM"))
+	}
+
+	// Record every use, including rejected commands.
+	requestor := request.CreateRequestor(r)
+	logger.Info("Running QMP debug command", logger.Ctx{"project": projectName, "instance": name, "command": req.Execute, "arguments": req.Arguments, "username": requestor.Username, "protocol": requestor.Protocol, "address": requestor.Address})
+
+	result, err := v.DebugQMP(req.Execute, req.Arguments)
+	if err != nil {
+		logger.Warn("Failed running QMP debug command", logger.Ctx{"project": projectName, "instance": name, "command": req.Execute, "username": requestor.Username, "err": err})
+		return response.SmartError(err)
+	}
+
+	lc := lifecycle.InstanceDebugQMP.Event(inst, logger.Ctx{"command": req.Execute, "arguments": req.Arguments})
+	lc.Requestor = requestor
+	s.Events.SendLifecycle(projectName, lc)
+
+	return response.SyncResponse(true, result)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/http"
@@ -10,9 +11,12 @@ import (
 	"github.com/gorilla/mux"
 
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/db"
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/util"
 )
 
 // swagger:operation GET /1.0/instances/{name} instances instance_get
@@ -30,6 +34,11 @@ import (
 //      description: Project name
 //      type: string
 //      example: default
+//    - in: query
+//      name: origin
+//      description: Whether to include the origin of the expanded configuration and devices
+//      type: boolean
+//      example: true
 //  responses:
 //    "200":
 //      description: Instance
@@ -73,6 +82,11 @@ import (
 //	    description: Project name
 //	    type: string
 //	    example: default
+//	  - in: query
+//	    name: origin
+//	    description: Whether to include the origin of the expanded configuration and devices
+//	    type: boolean
+//	    example: true
 //	responses:
 //	  "200":
 //	    description: Instance
@@ -147,5 +161,22 @@ func instanceGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	// Add the origin of the expanded configuration if requested.
+	if util.IsTrue(r.FormValue("origin")) {
+		apiInst, ok := state.(*api.Instance)
+		if !ok {
+			apiInst = &state.(*api.InstanceFull).Instance
+		}
+
+		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+			apiInst.ExpandedConfigOrigins, apiInst.ExpandedDevicesOrigins, err = tx.GetInstanceConfigOrigins(ctx, c.LocalConfig(), c.LocalDevices(), c.Profiles())
+
+			return err
+		})
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	return response.SyncResponseETag(true, state, etag)
 }
@@ -319,6 +319,20 @@ func instancePost(d *Daemon, r *http.Request) response.Response {
 			return response.SmartError(err)
 		}
 
+		// Apply the placement rules, validating the selected member if any.
+		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+			if targetMemberInfo != nil {
+				_, err := clusterMembersWithPlacementRules(ctx, tx, instProject, name, inst.ExpandedConfig(), []db.NodeInfo{*targetMemberInfo})
+				return err
+			}
+
+			targetCandidates, err = clusterMembersWithPlacementRules(ctx, tx, instProject, name, inst.ExpandedConfig(), targetCandidates)
+			return err
+		})
+		if err != nil {
+			return response.SmartError(err)
+		}
+
 		// Run instance placement scriptlet if enabled.
 		if s.GlobalConfig.InstancesPlacementScriptlet() != "" {
 			// If a target was specified, limit the list of candidates to that target.
@@ -26,6 +26,7 @@ import (
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/osarch"
 	"github.com/lxc/incus/v6/shared/revert"
+	"github.com/lxc/incus/v6/shared/util"
 )
 
 // swagger:operation PUT /1.0/instances/{name} instances instance_put
@@ -45,12 +46,21 @@ import (
 //	    description: Project name
 //	    type: string
 //	    example: default
+//	  - in: query
+//	    name: check
+//	    description: Only report the effect of the update on the project resources
+//	    type: boolean
+//	    example: true
 //	  - in: body
 //	    name: instance
 //	    description: Update request
 //	    schema:
 //	      $ref: "#/definitions/InstancePut"
 //	responses:
+//	  "200":
+//	    description: Effect of the update on the project resources (when checking)
+//	    schema:
+//	      $ref: "#/definitions/InstanceUpdateCheck"
 //	  "202":
 //	    $ref: "#/responses/Operation"
 //	  "400":
@@ -121,6 +131,25 @@ func instancePut(d *Daemon, r *http.Request) response.Response {
 		architecture = 0
 	}
 
+	// Only report the effect of the update on the project resources if requested.
+	if util.IsTrue(r.FormValue("check")) {
+		if configRaw.Restore != "" {
+			return response.BadRequest(fmt.Errorf("Snapshot restores can't be checked"))
+		}
+
+		var check *api.InstanceUpdateCheck
+		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+			check, err = projecthelpers.CheckInstanceUpdate(tx, projectName, name, configRaw, inst.LocalConfig())
+
+			return err
+		})
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		return response.SyncResponse(true, check)
+	}
+
 	var do func(*operations.Operation) error
 	var opType operationtype.Type
 	if configRaw.Restore == "" {
@@ -228,6 +257,24 @@ func instanceSnapRestore(s *state.State, projectName string, name string, snap s
 	}
 	source.SetOperation(op)
 
+	// Check project limits, the snapshot's configuration and devices replacing the instance ones. The volatile
+	// keys are restored as they were, so they are passed as the current configuration.
+	err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		req := api.InstancePut{
+			Config:  source.LocalConfig(),
+			Devices: source.LocalDevices().CloneNative(),
+		}
+
+		for _, profile := range source.Profiles() {
+			req.Profiles = append(req.Profiles, profile.Name)
+		}
+
+		return projecthelpers.AllowInstanceUpdate(tx, projectName, name, req, source.LocalConfig())
+	})
+	if err != nil {
+		return err
+	}
+
 	// Generate a new `volatile.uuid.generation` to differentiate this instance restored from a snapshot from the original instance.
 	source.LocalConfig()["volatile.uuid.generation"] = uuid.New().String()
 
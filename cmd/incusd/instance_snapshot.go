@@ -12,6 +12,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/lxc/incus/v6/internal/filter"
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/jmap"
 	"github.com/lxc/incus/v6/internal/server/db"
@@ -44,6 +45,11 @@ import (
 //      description: Project name
 //      type: string
 //      example: default
+//    - in: query
+//      name: filter
+//      description: Collection filter
+//      type: string
+//      example: default
 //  responses:
 //    "200":
 //      description: API endpoints
@@ -93,6 +99,11 @@ import (
 //	    description: Project name
 //	    type: string
 //	    example: default
+//	  - in: query
+//	    name: filter
+//	    description: Collection filter
+//	    type: string
+//	    example: default
 //	responses:
 //	  "200":
 //	    description: API endpoints
@@ -148,7 +159,24 @@ func instanceSnapshotsGet(d *Daemon, r *http.Request) response.Response {
 	resultString := []string{}
 	resultMap := []*api.InstanceSnapshot{}
 
-	if !recursion {
+	// Parse filter value.
+	filterStr := r.FormValue("filter")
+	clauses, err := filter.Parse(filterStr, filter.QueryOperatorSet())
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid filter: %w", err))
+	}
+
+	mustLoadObjects := recursion || (clauses != nil && len(clauses.Clauses) > 0)
+
+	snapshotURL := func(snapName string) string {
+		if projectName == api.ProjectDefaultName {
+			return fmt.Sprintf("/%s/instances/%s/snapshots/%s", version.APIVersion, cname, snapName)
+		}
+
+		return fmt.Sprintf("/%s/instances/%s/snapshots/%s?project=%s", version.APIVersion, cname, snapName, projectName)
+	}
+
+	if !mustLoadObjects {
 		var snaps []string
 
 		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
@@ -164,13 +192,7 @@ func instanceSnapshotsGet(d *Daemon, r *http.Request) response.Response {
 
 		for _, snap := range snaps {
 			_, snapName, _ := api.GetParentAndSnapshotName(snap)
-			if projectName == api.ProjectDefaultName {
-				url := fmt.Sprintf("/%s/instances/%s/snapshots/%s", version.APIVersion, cname, snapName)
-				resultString = append(resultString, url)
-			} else {
-				url := fmt.Sprintf("/%s/instances/%s/snapshots/%s?project=%s", version.APIVersion, cname, snapName, projectName)
-				resultString = append(resultString, url)
-			}
+			resultString = append(resultString, snapshotURL(snapName))
 		}
 	} else {
 		c, err := instance.LoadByProjectAndName(s, projectName, cname)
@@ -189,7 +211,21 @@ func instanceSnapshotsGet(d *Daemon, r *http.Request) response.Response {
 				continue
 			}
 
-			resultMap = append(resultMap, render.(*api.InstanceSnapshot))
+			apiSnap := render.(*api.InstanceSnapshot)
+
+			if clauses != nil && len(clauses.Clauses) > 0 {
+				match, err := filter.Match(*apiSnap, *clauses)
+				if err != nil {
+					return response.SmartError(err)
+				}
+
+				if !match {
+					continue
+				}
+			}
+
+			resultMap = append(resultMap, apiSnap)
+			resultString = append(resultString, snapshotURL(apiSnap.Name))
 		}
 	}
 
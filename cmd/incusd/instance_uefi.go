@@ -0,0 +1,385 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	localUtil "github.com/lxc/incus/v6/internal/server/util"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// instanceUEFILoad loads the virtual machine targeted by a UEFI request.
+// A non-nil response is returned if the request was forwarded or failed.
+func instanceUEFILoad(d *Daemon, r *http.Request) (instance.VM, response.Response) {
+	s := d.State()
+
+	projectName := request.ProjectParam(r)
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return nil, response.SmartError(err)
+	}
+
+	if internalInstance.IsSnapshot(name) {
+		return nil, response.BadRequest(fmt.Errorf("Invalid instance name"))
+	}
+
+	// Handle requests targeted to a VM on a different node.
+	resp, err := forwardedResponseIfInstanceIsRemote(s, r, projectName, name)
+	if err != nil {
+		return nil, response.SmartError(err)
+	}
+
+	if resp != nil {
+		return nil, resp
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, projectName, name)
+	if err != nil {
+		return nil, response.SmartError(err)
+	}
+
+	if inst.Type() != instancetype.VM {
+		return nil, response.BadRequest(fmt.Errorf("UEFI variables are only supported for virtual machines"))
+	}
+
+	v, ok := inst.(instance.VM)
+	if !ok {
+		return nil, response.InternalError(fmt.Errorf("Failed to cast inst to VM"))
+	}
+
+	return v, nil
+}
+
+// swagger:operation GET /1.0/instances/{name}/uefi instances instance_uefi_get
+//
+//	Get the UEFI boot configuration
+//
+//	Gets the UEFI boot entries, boot order and variables of a virtual machine.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: UEFI boot configuration
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/InstanceUEFI"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceUEFIGet(d *Daemon, r *http.Request) response.Response {
+	inst, resp := instanceUEFILoad(d, r)
+	if resp != nil {
+		return resp
+	}
+
+	uefi, err := inst.UEFI()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponseETag(true, uefi, uefi.InstanceUEFIPut)
+}
+
+// swagger:operation PUT /1.0/instances/{name}/uefi instances instance_uefi_put
+//
+//	Update the UEFI boot configuration
+//
+//	Updates the UEFI boot order and next boot entry of a stopped virtual machine.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: uefi
+//	    description: UEFI boot configuration
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceUEFIPut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceUEFIPut(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	inst, resp := instanceUEFILoad(d, r)
+	if resp != nil {
+		return resp
+	}
+
+	uefi, err := inst.UEFI()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Validate ETag.
+	err = localUtil.EtagCheck(r, uefi.InstanceUEFIPut)
+	if err != nil {
+		return response.PreconditionFailed(err)
+	}
+
+	req := api.InstanceUEFIPut{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = inst.UEFIUpdate(req)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceUpdated.Event(inst, nil))
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation GET /1.0/instances/{name}/uefi/nvram instances instance_uefi_nvram_get
+//
+//	Export the UEFI variable store
+//
+//	Downloads the raw UEFI variable store (firmware vars file) of a virtual machine.
+//
+//	---
+//	produces:
+//	  - application/octet-stream
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: Raw UEFI variable store
+//	    content:
+//	      application/octet-stream:
+//	        schema:
+//	          type: string
+//	          example: raw file content
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceUEFINVRAMGet(d *Daemon, r *http.Request) response.Response {
+	inst, resp := instanceUEFILoad(d, r)
+	if resp != nil {
+		return resp
+	}
+
+	// Check that the variable store can be accessed before starting the response.
+	_, err := inst.UEFI()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.ManualResponse(func(w http.ResponseWriter) error {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+
+		return inst.UEFIExport(w)
+	})
+}
+
+// swagger:operation PUT /1.0/instances/{name}/uefi/nvram instances instance_uefi_nvram_put
+//
+//	Import the UEFI variable store
+//
+//	Replaces the raw UEFI variable store (firmware vars file) of a stopped virtual machine.
+//
+//	---
+//	consumes:
+//	  - application/octet-stream
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: raw_file
+//	    description: Raw UEFI variable store
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceUEFINVRAMPut(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	inst, resp := instanceUEFILoad(d, r)
+	if resp != nil {
+		return resp
+	}
+
+	err := inst.UEFIImport(r.Body)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceUpdated.Event(inst, nil))
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation DELETE /1.0/instances/{name}/uefi/nvram instances instance_uefi_nvram_delete
+//
+//	Reset the UEFI variable store
+//
+//	Resets the UEFI variables of a stopped virtual machine to the firmware defaults.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceUEFINVRAMDelete(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	inst, resp := instanceUEFILoad(d, r)
+	if resp != nil {
+		return resp
+	}
+
+	err := inst.UEFIReset()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceUpdated.Event(inst, nil))
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation POST /1.0/instances/{name}/uefi/secureboot-keys instances instance_uefi_secureboot_keys_post
+//
+//	Enroll Secure Boot keys
+//
+//	Enrolls certificates into one of the Secure Boot variables (PK, KEK, db or dbx) of a stopped virtual machine.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: keys
+//	    description: Secure Boot keys
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceUEFISecureBootKeysPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceUEFISecureBootKeysPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	inst, resp := instanceUEFILoad(d, r)
+	if resp != nil {
+		return resp
+	}
+
+	req := api.InstanceUEFISecureBootKeysPost{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = inst.UEFIEnrollSecureBootKeys(req)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceUpdated.Event(inst, nil))
+
+	return response.EmptySyncResponse
+}
@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"sync"
@@ -134,6 +136,30 @@ var instanceMetadataTemplatesCmd = APIEndpoint{
 	Delete: APIEndpointAction{Handler: instanceMetadataTemplatesDelete, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanEdit, "name")},
 }
 
+var instanceUEFICmd = APIEndpoint{
+	Name: "instanceUEFI",
+	Path: "instances/{name}/uefi",
+
+	Get: APIEndpointAction{Handler: instanceUEFIGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanView, "name")},
+	Put: APIEndpointAction{Handler: instanceUEFIPut, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanEdit, "name")},
+}
+
+var instanceUEFINVRAMCmd = APIEndpoint{
+	Name: "instanceUEFINVRAM",
+	Path: "instances/{name}/uefi/nvram",
+
+	Get:    APIEndpointAction{Handler: instanceUEFINVRAMGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanView, "name")},
+	Put:    APIEndpointAction{Handler: instanceUEFINVRAMPut, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanEdit, "name")},
+	Delete: APIEndpointAction{Handler: instanceUEFINVRAMDelete, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanEdit, "name")},
+}
+
+var instanceUEFISecureBootKeysCmd = APIEndpoint{
+	Name: "instanceUEFISecureBootKeys",
+	Path: "instances/{name}/uefi/secureboot-keys",
+
+	Post: APIEndpointAction{Handler: instanceUEFISecureBootKeysPost, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanEdit, "name")},
+}
+
 var instanceBackupsCmd = APIEndpoint{
 	Name: "instanceBackups",
 	Path: "instances/{name}/backups",
@@ -172,6 +198,13 @@ var instanceDebugMemoryCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: instanceDebugMemoryGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanEdit, "name")},
 }
 
+var instanceDebugQMPCmd = APIEndpoint{
+	Name: "instanceDebugQMP",
+	Path: "instances/{name}/debug/qmp",
+
+	Post: APIEndpointAction{Handler: instanceDebugQMPPost, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
 type instanceAutostartList []instance.Instance
 
 func (slice instanceAutostartList) Len() int {
@@ -207,6 +240,154 @@ func instanceShouldAutoStart(inst instance.Instance) bool {
 	return util.IsTrue(autoStart) || (autoStart == "" && lastState == instance.PowerStateRunning)
 }
 
+// instanceDependencies returns the names of the instances (in the same project) which the instance depends on.
+func instanceDependencies(inst instance.Instance) []string {
+	dependencies := []string{}
+	for _, name := range util.SplitNTrimSpace(inst.ExpandedConfig()["boot.depends_on"], ",", -1, true) {
+		if name == inst.Name() {
+			continue
+		}
+
+		dependencies = append(dependencies, name)
+	}
+
+	return dependencies
+}
+
+// instancesSortByDependencies re-orders the instances so that each of them comes after the instances it depends
+// on through boot.depends_on, otherwise keeping the existing order. Instances part of a dependency cycle are kept
+// in their existing order.
+func instancesSortByDependencies(instances []instance.Instance) []instance.Instance {
+	present := make(map[string]bool, len(instances))
+	for _, inst := range instances {
+		present[project.Instance(inst.Project().Name, inst.Name())] = true
+	}
+
+	sorted := make([]instance.Instance, 0, len(instances))
+	placed := make(map[string]bool, len(instances))
+	remaining := instances
+
+	for len(remaining) > 0 {
+		pending := []instance.Instance{}
+
+		for _, inst := range remaining {
+			ready := true
+			for _, name := range instanceDependencies(inst) {
+				dependency := project.Instance(inst.Project().Name, name)
+				if present[dependency] && !placed[dependency] {
+					ready = false
+					break
+				}
+			}
+
+			if !ready {
+				pending = append(pending, inst)
+				continue
+			}
+
+			sorted = append(sorted, inst)
+			placed[project.Instance(inst.Project().Name, inst.Name())] = true
+		}
+
+		if len(pending) == len(remaining) {
+			for _, inst := range pending {
+				logger.Warn("Ignoring instance dependencies due to a dependency cycle", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "dependencies": instanceDependencies(inst)})
+			}
+
+			sorted = append(sorted, pending...)
+			break
+		}
+
+		remaining = pending
+	}
+
+	return sorted
+}
+
+// instanceDependencyReady returns whether the dependency satisfies the given boot.depends_on.condition.
+func instanceDependencyReady(dependency instance.Instance, condition string) bool {
+	if !dependency.IsRunning() {
+		return false
+	}
+
+	if condition == "" || condition == "started" {
+		return true
+	}
+
+	hostInterfaces, _ := net.Interfaces()
+	instState, err := dependency.RenderState(hostInterfaces)
+	if err != nil {
+		return false
+	}
+
+	if condition == "agent" {
+		// The process count is only unavailable when the VM agent can't be reached.
+		return instState.Processes >= 0
+	}
+
+	for name, network := range instState.Network {
+		if name == "lo" {
+			continue
+		}
+
+		for _, address := range network.Addresses {
+			if address.Scope == "global" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// instanceWaitDependencies waits for the dependencies of the instance found in the given list to satisfy
+// boot.depends_on.condition, up to boot.depends_on.timeout.
+func instanceWaitDependencies(s *state.State, inst instance.Instance, instances []instance.Instance) {
+	dependencies := instanceDependencies(inst)
+	if len(dependencies) == 0 {
+		return
+	}
+
+	config := inst.ExpandedConfig()
+	condition := config["boot.depends_on.condition"]
+
+	timeout := 60 * time.Second
+	if config["boot.depends_on.timeout"] != "" {
+		seconds, err := strconv.Atoi(config["boot.depends_on.timeout"])
+		if err == nil {
+			timeout = time.Duration(seconds) * time.Second
+		}
+	}
+
+	instLogger := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+	deadline := time.Now().Add(timeout)
+
+	for _, dependency := range instances {
+		if dependency.Project().Name != inst.Project().Name || !slices.Contains(dependencies, dependency.Name()) {
+			continue
+		}
+
+		// Don't wait for dependencies which failed to start or aren't meant to be started.
+		if !dependency.IsRunning() {
+			instLogger.Warn("Instance dependency isn't running", logger.Ctx{"dependency": dependency.Name()})
+			continue
+		}
+
+		for !instanceDependencyReady(dependency, condition) {
+			if time.Now().After(deadline) {
+				instLogger.Warn("Timed out waiting for instance dependency", logger.Ctx{"dependency": dependency.Name(), "condition": condition})
+				return
+			}
+
+			select {
+			case <-s.ShutdownCtx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+		}
+	}
+}
+
 func instancesStart(s *state.State, instances []instance.Instance) {
 	// Check if the cluster is currently evacuated.
 	if s.ServerClustered && s.DB.Cluster.LocalNodeIsEvacuated() {
@@ -220,6 +401,9 @@ func instancesStart(s *state.State, instances []instance.Instance) {
 	// Sort based on instance boot priority.
 	sort.Sort(instanceAutostartList(instances))
 
+	// Start dependencies first.
+	instances = instancesSortByDependencies(instances)
+
 	// Let's make up to 3 attempts to start instances.
 	maxAttempts := 3
 
@@ -241,6 +425,9 @@ func instancesStart(s *state.State, instances []instance.Instance) {
 
 		instLogger := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
 
+		// Wait for the instances it depends on to be ready.
+		instanceWaitDependencies(s, inst, instances)
+
 		// Try to start the instance.
 		attempt := 0
 		for {
@@ -259,7 +259,8 @@ func instancesGet(d *Daemon, r *http.Request) response.Response {
 		return response.InternalError(err)
 	}
 
-	// Removes instances the user doesn't have access to.
+	// Removes instances the user doesn't have access to, as well as those the filter rules out based on their
+	// database record, so that they don't need to be loaded.
 	for address, instances := range memberAddressInstances {
 		var filteredInstances []db.Instance
 
@@ -268,6 +269,19 @@ func instancesGet(d *Daemon, r *http.Request) response.Response {
 				continue
 			}
 
+			if clauses != nil && len(clauses.Clauses) > 0 {
+				record := api.Instance{Name: inst.Name, Project: inst.Project, Location: inst.Location, Type: inst.Type.String()}
+
+				match, err := filter.MayMatch(record, *clauses, "name", "project", "location", "type")
+				if err != nil {
+					return response.BadRequest(fmt.Errorf("Invalid filter: %w", err))
+				}
+
+				if !match {
+					continue
+				}
+			}
+
 			filteredInstances = append(filteredInstances, inst)
 		}
 
@@ -309,6 +323,11 @@ func instancesGet(d *Daemon, r *http.Request) response.Response {
 			continue
 		}
 
+		// Skip the members without any instance left to load.
+		if len(instances) == 0 {
+			continue
+		}
+
 		// Mark instances on unavailable projectInstanceToNodeName as down.
 		if mustLoadObjects && memberAddress == "0.0.0.0" {
 			for _, inst := range instances {
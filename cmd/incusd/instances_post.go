@@ -28,6 +28,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/resources"
 	"github.com/lxc/incus/v6/internal/server/response"
 	"github.com/lxc/incus/v6/internal/server/scriptlet"
 	"github.com/lxc/incus/v6/internal/server/state"
@@ -316,6 +317,9 @@ func createFromMigration(ctx context.Context, s *state.State, r *http.Request, p
 
 	instanceOnly := req.Source.InstanceOnly
 
+	// Whether the instance is created by the migration, rather than being refreshed or moved.
+	created := inst == nil
+
 	if inst == nil {
 		_, err := storagePools.LoadByName(s, storagePool)
 		if err != nil {
@@ -469,6 +473,14 @@ func createFromMigration(ctx context.Context, s *state.State, r *http.Request, p
 		}
 	}
 
+	// Have the partially migrated instance deleted if the server goes down during the migration.
+	if created {
+		err = op.SetCreated(map[string][]api.URL{"instances": {*api.NewURL().Path(version.APIVersion, "instances", req.Name).Project(projectName)}})
+		if err != nil {
+			return response.InternalError(err)
+		}
+	}
+
 	reverter.Success()
 	return operations.OperationResponse(op)
 }
@@ -1001,6 +1013,16 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 			if err != nil {
 				return err
 			}
+
+			// Fall back to the default cluster group of the project.
+			if target == "" && targetProject.Config["cluster.default_group"] != "" {
+				targetGroupName = targetProject.Config["cluster.default_group"]
+
+				err = project.CheckTargetGroup(ctx, tx, targetProject, targetGroupName)
+				if err != nil {
+					return fmt.Errorf("Invalid default cluster group of the project: %w", err)
+				}
+			}
 		}
 
 		profileProject := project.ProfileProjectFromRecord(targetProject)
@@ -1189,6 +1211,21 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 			candidateMembers = []db.NodeInfo{*targetMemberInfo}
 		}
 
+		// Only consider members which can provide the requested mediated GPU devices.
+		if targetMemberInfo == nil && len(candidateMembers) > 1 {
+			devices := db.ExpandInstanceDevices(deviceConfig.NewDevices(req.Devices), profiles).CloneNative()
+			candidateMembers = clusterMembersWithMdevCapacity(s, candidateMembers, devices)
+		}
+
+		// Only consider members satisfying the placement rules.
+		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+			candidateMembers, err = clusterMembersWithPlacementRules(ctx, tx, targetProjectName, req.Name, db.ExpandInstanceConfig(req.Config, profiles), candidateMembers)
+			return err
+		})
+		if err != nil {
+			return response.SmartError(err)
+		}
+
 		// Run instance placement scriptlet if enabled.
 		if s.GlobalConfig.InstancesPlacementScriptlet() != "" {
 			leaderAddress, err := s.Cluster.LeaderAddress()
@@ -1433,3 +1470,160 @@ func instanceCreateFinish(s *state.State, req *api.InstancesPost, args db.Instan
 
 	return inst.Start(false)
 }
+
+// clusterMembersWithPlacementRules filters the candidate members down to those satisfying the
+// placement.affinity and placement.anti-affinity rules of the instance. An error is returned if
+// none of the candidate members satisfies them.
+func clusterMembersWithPlacementRules(ctx context.Context, tx *db.ClusterTx, projectName string, instanceName string, config map[string]string, members []db.NodeInfo) ([]db.NodeInfo, error) {
+	affinity, err := internalInstance.PlacementRules(config["placement.affinity"])
+	if err != nil {
+		return nil, err
+	}
+
+	antiAffinity, err := internalInstance.PlacementRules(config["placement.anti-affinity"])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(affinity) == 0 && len(antiAffinity) == 0 {
+		return members, nil
+	}
+
+	// Find the members running the instances matched by the rules.
+	affinityMembers := map[string]bool{}
+	antiAffinityMembers := map[string]bool{}
+
+	err = tx.InstanceList(ctx, func(inst db.InstanceArgs, _ api.Project) error {
+		if inst.Name == instanceName || inst.Node == "" {
+			return nil
+		}
+
+		instConfig := db.ExpandInstanceConfig(inst.Config, inst.Profiles)
+
+		if internalInstance.PlacementRulesMatch(affinity, inst.Name, instConfig) {
+			affinityMembers[inst.Node] = true
+		}
+
+		if internalInstance.PlacementRulesMatch(antiAffinity, inst.Name, instConfig) {
+			antiAffinityMembers[inst.Node] = true
+		}
+
+		return nil
+	}, dbCluster.InstanceFilter{Project: &projectName})
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]db.NodeInfo, 0, len(members))
+	for _, member := range members {
+		// Affinity only applies once a matching instance exists.
+		if len(affinityMembers) > 0 && !affinityMembers[member.Name] {
+			continue
+		}
+
+		if antiAffinityMembers[member.Name] {
+			continue
+		}
+
+		filtered = append(filtered, member)
+	}
+
+	if len(filtered) == 0 {
+		return nil, api.StatusErrorf(http.StatusConflict, "No cluster member satisfies the placement rules of instance %q", instanceName)
+	}
+
+	return filtered, nil
+}
+
+// clusterMembersWithMdevCapacity filters the candidate members down to those having enough available
+// mediated devices for the GPU devices of the instance. If no member has enough capacity, the candidate
+// list is returned unchanged so that the failure gets reported when the instance is started.
+func clusterMembersWithMdevCapacity(s *state.State, members []db.NodeInfo, devices map[string]map[string]string) []db.NodeInfo {
+	// Count the requested mediated devices per profile.
+	requested := map[string]uint64{}
+	for _, dev := range devices {
+		if dev["type"] == "gpu" && dev["gputype"] == "mdev" && dev["mdev"] != "" {
+			requested[dev["mdev"]]++
+		}
+	}
+
+	if len(requested) == 0 {
+		return members
+	}
+
+	// gpuAvailable returns the number of available mediated devices of the profile on the matching cards.
+	gpuAvailable := func(gpus *api.ResourcesGPU, mdevProfile string) uint64 {
+		var available uint64
+
+		for _, card := range gpus.Cards {
+			for _, dev := range devices {
+				if dev["type"] != "gpu" || dev["mdev"] != mdevProfile {
+					continue
+				}
+
+				if (dev["vendorid"] != "" && dev["vendorid"] != card.VendorID) || (dev["productid"] != "" && dev["productid"] != card.ProductID) || (dev["pci"] != "" && dev["pci"] != card.PCIAddress) {
+					continue
+				}
+
+				available += card.Mdev[mdevProfile].Available
+
+				if card.SRIOV != nil {
+					for _, vf := range card.SRIOV.VFs {
+						available += vf.Mdev[mdevProfile].Available
+					}
+				}
+
+				break
+			}
+		}
+
+		return available
+	}
+
+	filtered := make([]db.NodeInfo, 0, len(members))
+	for _, member := range members {
+		var gpus *api.ResourcesGPU
+
+		if member.Name == s.ServerName {
+			res, err := resources.GetGPU()
+			if err != nil {
+				logger.Warn("Failed getting GPU resources", logger.Ctx{"member": member.Name, "err": err})
+				continue
+			}
+
+			gpus = res
+		} else {
+			client, err := cluster.Connect(member.Address, s.Endpoints.NetworkCert(), s.ServerCert(), nil, true)
+			if err != nil {
+				logger.Warn("Failed connecting to cluster member", logger.Ctx{"member": member.Name, "err": err})
+				continue
+			}
+
+			res, err := client.GetServerResources()
+			if err != nil {
+				logger.Warn("Failed getting GPU resources", logger.Ctx{"member": member.Name, "err": err})
+				continue
+			}
+
+			gpus = &res.GPU
+		}
+
+		hasCapacity := true
+		for mdevProfile, count := range requested {
+			if gpuAvailable(gpus, mdevProfile) < count {
+				hasCapacity = false
+				break
+			}
+		}
+
+		if hasCapacity {
+			filtered = append(filtered, member)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return members
+	}
+
+	return filtered
+}
@@ -2,11 +2,17 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"slices"
 	"strings"
 	"time"
 
+	liblxc "github.com/lxc/go-lxc"
+	"golang.org/x/sys/unix"
+
+	"github.com/lxc/incus/v6/internal/linux"
 	"github.com/lxc/incus/v6/internal/server/db/operationtype"
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
@@ -166,3 +172,129 @@ func expireLogs(ctx context.Context, state *state.State) error {
 
 	return nil
 }
+
+// consoleLogsTailSize is the size of the end of the console output remembered to find the new output.
+const consoleLogsTailSize = 256
+
+// consoleLogsTask sends the new output of the consoles of the running instances to the loggers handling the
+// console events. It's started by the Daemon and runs every 10 seconds.
+func consoleLogsTask(d *Daemon) (task.Func, task.Schedule) {
+	// End of the output already sent for each instance.
+	tails := map[string]string{}
+	first := true
+
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		if d.loggingController == nil || !d.loggingController.HandlesConsole() {
+			clear(tails)
+			first = false
+			return
+		}
+
+		instances, err := instance.LoadNodeAll(s, instancetype.Any)
+		if err != nil {
+			logger.Warn("Failed loading instances for console logs", logger.Ctx{"err": err})
+			return
+		}
+
+		running := map[string]struct{}{}
+		for _, inst := range instances {
+			if ctx.Err() != nil {
+				return
+			}
+
+			if !inst.IsRunning() {
+				continue
+			}
+
+			key := project.Instance(inst.Project().Name, inst.Name())
+			running[key] = struct{}{}
+
+			output, err := consoleOutput(inst)
+			if err != nil {
+				logger.Debug("Failed reading instance console", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
+				continue
+			}
+
+			lines, tail, ok := consoleNewLines(tails[key], output)
+			if !ok {
+				continue
+			}
+
+			tails[key] = tail
+
+			// Don't resend the output of the instances already running when the daemon started.
+			if first {
+				continue
+			}
+
+			d.loggingController.SendConsole(s.ServerName, inst.Project().Name, inst.Name(), lines)
+		}
+
+		for key := range tails {
+			_, ok := running[key]
+			if !ok {
+				delete(tails, key)
+			}
+		}
+
+		first = false
+	}
+
+	return f, task.Every(10 * time.Second)
+}
+
+// consoleOutput returns the output of the console of a running instance, as far as it's kept.
+func consoleOutput(inst instance.Instance) (string, error) {
+	switch c := inst.(type) {
+	case instance.Container:
+		// Read the ring buffer without writing it to the log file, which would record a lifecycle event.
+		output, err := c.ConsoleLog(liblxc.ConsoleLogOptions{ReadLog: true})
+		if err != nil {
+			errno, isErrno := linux.GetErrno(err)
+			if isErrno && errors.Is(errno, unix.ENODATA) {
+				return "", nil
+			}
+
+			return "", err
+		}
+
+		return output, nil
+	case instance.VM:
+		return c.ConsoleLog()
+	}
+
+	return "", fmt.Errorf("Unsupported instance type %q", inst.Type())
+}
+
+// consoleNewLines returns the complete lines of the console output following the given end of the output
+// already sent, along with the new end of the sent output. It returns false when there are no new lines.
+func consoleNewLines(tail string, output string) ([]string, string, bool) {
+	start := 0
+	if tail != "" {
+		// The output restarts from scratch when the end of the output already sent can't be found anymore.
+		idx := strings.LastIndex(output, tail)
+		if idx >= 0 {
+			start = idx + len(tail)
+		}
+	}
+
+	// Only send complete lines.
+	cut := strings.LastIndex(output[start:], "\n")
+	if cut < 0 {
+		return nil, tail, false
+	}
+
+	end := start + cut + 1
+
+	lines := []string{}
+	for _, line := range strings.Split(output[start:end], "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, output[max(0, end-consoleLogsTailSize):end], true
+}
@@ -79,6 +79,10 @@ func (c *cmdCluster) command() *cobra.Command {
 	clusterShow := cmdClusterShow{global: c.global}
 	cmd.AddCommand(clusterShow.command())
 
+	// Diagnose and repair the cluster.
+	clusterHeal := cmdClusterHeal{global: c.global}
+	cmd.AddCommand(clusterHeal.command())
+
 	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
 	cmd.Args = cobra.NoArgs
 	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
@@ -89,10 +93,10 @@ const SegmentComment = "# Latest dqlite segment ID: %s"
 
 // ClusterMember is a more human-readable representation of the db.RaftNode struct.
 type ClusterMember struct {
-	ID      uint64 `yaml:"id"`
-	Name    string `yaml:"name,omitempty"`
-	Address string `yaml:"address"`
-	Role    string `yaml:"role"`
+	ID      uint64 `json:"id" yaml:"id"`
+	Name    string `json:"name,omitempty" yaml:"name,omitempty"`
+	Address string `json:"address" yaml:"address"`
+	Role    string `json:"role" yaml:"role"`
 }
 
 // ClusterConfig is a representation of the current cluster configuration.
@@ -0,0 +1,407 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	incus "github.com/lxc/incus/v6/client"
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/sys"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+type cmdClusterHeal struct {
+	global     *cmdGlobal
+	flagDryRun bool
+
+	reader *bufio.Reader
+}
+
+// healAction is a recovery step proposed to the user.
+type healAction struct {
+	description string
+	run         func() error
+}
+
+func (c *cmdClusterHeal) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "heal"
+	cmd.Short = "Diagnose and repair the cluster database"
+	cmd.Long = `Description:
+  Diagnose and repair the cluster database
+
+  This command checks the state of the raft cluster backing the database as seen
+  by each of its members: which members can be reached, whether they agree on the
+  leader and on the raft configuration, and how far their raft logs diverge.
+
+  It then offers the recovery steps which apply, one at a time: reassigning the
+  database roles of offline members, removing stale raft members, and removing
+  offline members so that they can rejoin the cluster.
+`
+	cmd.RunE = c.run
+
+	cmd.Flags().BoolVar(&c.flagDryRun, "dry-run", false, "Only show the diagnosis and the recovery steps")
+
+	return cmd
+}
+
+func (c *cmdClusterHeal) run(_ *cobra.Command, _ []string) error {
+	c.reader = bufio.NewReader(os.Stdin)
+
+	client, err := incus.ConnectIncusUnix("", nil)
+	if err != nil {
+		return c.runOffline()
+	}
+
+	server, _, err := client.GetServer()
+	if err != nil {
+		return err
+	}
+
+	if !server.Environment.ServerClustered {
+		return fmt.Errorf("This server isn't part of a cluster")
+	}
+
+	// Get the view of the raft cluster of all of its members.
+	resp, _, err := client.RawQuery("GET", "/internal/cluster/raft?peers=1", nil, "")
+	if err != nil {
+		return fmt.Errorf("Failed getting the state of the raft cluster: %w", err)
+	}
+
+	raftMembers := []internalClusterRaftMember{}
+	err = json.Unmarshal(resp.Metadata, &raftMembers)
+	if err != nil {
+		return err
+	}
+
+	var local *internalClusterRaftMember
+	for i := range raftMembers {
+		if raftMembers[i].Name == server.Environment.ServerName {
+			local = &raftMembers[i]
+			break
+		}
+	}
+
+	if local == nil {
+		return fmt.Errorf("This server isn't part of its own raft configuration")
+	}
+
+	c.showRaftMembers(local, raftMembers)
+	fmt.Println("")
+
+	findings := []string{}
+	actions := []healAction{}
+
+	// Check for a quorum of voters.
+	voters := 0
+	reachableVoters := 0
+	for _, node := range local.RaftNodes {
+		if node.Role != db.RaftVoter.String() {
+			continue
+		}
+
+		voters++
+
+		for _, member := range raftMembers {
+			if member.Address == node.Address && member.Error == "" {
+				reachableVoters++
+			}
+		}
+	}
+
+	if reachableVoters*2 <= voters {
+		fmt.Printf("The cluster has lost quorum: only %d out of %d database voters can be reached.\n\n", reachableVoters, voters)
+		fmt.Print(`Try to bring the unreachable members back first.
+
+If they're gone for good, stop the daemon on all remaining members and run
+"incus admin cluster recover-from-quorum-loss" on the one with the most recent
+raft log`)
+
+		mostRecent := c.mostRecentMember(raftMembers)
+		if mostRecent != nil {
+			fmt.Printf(" (%s)", mostRecent.Name)
+		}
+
+		fmt.Println(`.
+The other remaining members then need to be reconfigured with "incus admin cluster edit".`)
+
+		return nil
+	}
+
+	// Check for members disagreeing on the leader or on the raft configuration.
+	leaders := []string{}
+	for _, member := range raftMembers {
+		if member.Error == "" && member.Leader != "" && !slices.Contains(leaders, member.Leader) {
+			leaders = append(leaders, member.Leader)
+		}
+	}
+
+	if len(leaders) > 1 {
+		findings = append(findings, fmt.Sprintf("Members disagree on the database leader (%s), which indicates a network partition", strings.Join(leaders, ", ")))
+	}
+
+	for _, member := range raftMembers {
+		if member.Error != "" || member.Address == local.Address {
+			continue
+		}
+
+		diff := c.raftNodesDiff(local.RaftNodes, member.RaftNodes)
+		if diff != "" {
+			findings = append(findings, fmt.Sprintf("The raft configuration of %q differs from the local one: %s", member.Name, diff))
+		}
+	}
+
+	// Compare the raft configuration with the cluster members.
+	members, err := client.GetClusterMembers()
+	if err != nil {
+		return fmt.Errorf("Failed getting cluster members: %w", err)
+	}
+
+	for _, node := range local.RaftNodes {
+		if !slices.ContainsFunc(members, func(member api.ClusterMember) bool { return member.URL == "https://"+node.Address }) {
+			findings = append(findings, fmt.Sprintf("Raft member %q (%s) isn't a cluster member anymore", node.Name, node.Address))
+			actions = append(actions, healAction{
+				description: fmt.Sprintf("Remove the stale raft member %q (%s)", node.Name, node.Address),
+				run: func() error {
+					_, _, err := client.RawQuery("DELETE", "/internal/cluster/raft-node/"+node.Address, nil, "")
+					return err
+				},
+			})
+		}
+	}
+
+	offline := []api.ClusterMember{}
+	offlineDatabase := false
+	for _, member := range members {
+		if member.Status != "Offline" {
+			continue
+		}
+
+		offline = append(offline, member)
+		findings = append(findings, fmt.Sprintf("Cluster member %q is offline", member.ServerName))
+
+		if slices.Contains(member.Roles, "database") || slices.Contains(member.Roles, "database-standby") {
+			offlineDatabase = true
+		}
+	}
+
+	if offlineDatabase {
+		actions = append(actions, healAction{
+			description: "Reassign the database roles of the offline members to online ones",
+			run: func() error {
+				_, _, err := client.RawQuery("POST", "/internal/cluster/raft", internalClusterRaftPostRequest{Action: "rebalance"}, "")
+				return err
+			},
+		})
+	}
+
+	for _, member := range offline {
+		actions = append(actions, healAction{
+			description: fmt.Sprintf("Remove the offline member %q from the cluster, so that it can rejoin it", member.ServerName),
+			run: func() error {
+				err := client.DeleteClusterMember(member.ServerName, true)
+				if err != nil {
+					return err
+				}
+
+				if !c.confirm(fmt.Sprintf("Generate a join token for %q to rejoin the cluster?", member.ServerName)) {
+					return nil
+				}
+
+				op, err := client.CreateClusterMember(api.ClusterMembersPost{ServerName: member.ServerName})
+				if err != nil {
+					return err
+				}
+
+				opAPI := op.Get()
+				joinToken, err := opAPI.ToClusterJoinToken()
+				if err != nil {
+					return fmt.Errorf("Failed converting token operation to join token: %w", err)
+				}
+
+				fmt.Printf(`Member %s join token:
+%s
+
+Once its local database was wiped, the member can rejoin the cluster using this token with "incus admin init".
+`, member.ServerName, joinToken.String())
+
+				return nil
+			},
+		})
+	}
+
+	if len(findings) == 0 {
+		fmt.Println("No problems found.")
+		return nil
+	}
+
+	fmt.Println("Problems found:")
+	for _, finding := range findings {
+		fmt.Printf(" - %s\n", finding)
+	}
+
+	if len(actions) == 0 {
+		fmt.Println("\nNo automated recovery step applies, the problems may resolve once the network is restored.")
+		return nil
+	}
+
+	fmt.Println("\nRecovery steps:")
+	for _, action := range actions {
+		if c.flagDryRun {
+			fmt.Printf(" - %s\n", action.description)
+			continue
+		}
+
+		if !c.confirm(action.description + "?") {
+			continue
+		}
+
+		err := action.run()
+		if err != nil {
+			return fmt.Errorf("Failed to %s: %w", strings.ToLower(action.description[:1])+action.description[1:], err)
+		}
+	}
+
+	return nil
+}
+
+// runOffline shows the local view of the raft cluster when the daemon isn't running.
+func (c *cmdClusterHeal) runOffline() error {
+	database, err := db.OpenNode(filepath.Join(sys.DefaultOS().VarDir, "database"), nil)
+	if err != nil {
+		return fmt.Errorf("Failed to open local database: %w", err)
+	}
+
+	var nodes []db.RaftNode
+	err = database.Transaction(context.TODO(), func(ctx context.Context, tx *db.NodeTx) error {
+		var err error
+		nodes, err = tx.GetRaftNodes(ctx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(nodes) == 0 {
+		return fmt.Errorf("This server isn't part of a cluster")
+	}
+
+	segmentID, err := db.DqliteLatestSegment()
+	if err != nil {
+		return err
+	}
+
+	local := internalClusterRaftMember{LatestSegment: segmentID}
+	for _, node := range nodes {
+		local.RaftNodes = append(local.RaftNodes, ClusterMember{ID: node.ID, Name: node.Name, Address: node.Address, Role: node.Role.String()})
+	}
+
+	fmt.Println("The daemon isn't running, only the local view of the raft cluster is available.")
+	fmt.Println("")
+	c.showRaftMembers(&local, nil)
+
+	fmt.Printf(`
+Latest local raft log segment: %s
+
+If the daemon fails to start because the cluster lost quorum and the other
+database members are gone for good, run "incus admin cluster recover-from-quorum-loss"
+on the remaining member with the most recent raft log.
+`, segmentID)
+
+	return nil
+}
+
+// showRaftMembers renders the raft members along with their view of the cluster.
+func (c *cmdClusterHeal) showRaftMembers(local *internalClusterRaftMember, raftMembers []internalClusterRaftMember) {
+	mostRecent := c.mostRecentMember(raftMembers)
+
+	data := [][]string{}
+	for _, node := range local.RaftNodes {
+		row := []string{node.Name, node.Address, node.Role, "", "", ""}
+
+		for _, member := range raftMembers {
+			if member.Address != node.Address {
+				continue
+			}
+
+			if member.Error != "" {
+				row[5] = "UNREACHABLE: " + member.Error
+				break
+			}
+
+			row[3] = member.Leader
+			row[4] = member.LatestSegment
+			row[5] = "OK"
+
+			if mostRecent != nil && member.LatestSegment != mostRecent.LatestSegment {
+				behind := c.segmentNumber(mostRecent.LatestSegment) - c.segmentNumber(member.LatestSegment)
+				row[4] = fmt.Sprintf("%s (%d behind)", member.LatestSegment, behind)
+			}
+		}
+
+		data = append(data, row)
+	}
+
+	header := []string{"NAME", "ADDRESS", "ROLE", "LEADER", "LATEST SEGMENT", "STATUS"}
+	_ = cli.RenderTable(os.Stdout, cli.TableFormatTable, header, data, nil)
+}
+
+// mostRecentMember returns the reachable raft member with the highest raft log segment.
+func (c *cmdClusterHeal) mostRecentMember(raftMembers []internalClusterRaftMember) *internalClusterRaftMember {
+	var mostRecent *internalClusterRaftMember
+	for i, member := range raftMembers {
+		if member.Error != "" {
+			continue
+		}
+
+		if mostRecent == nil || c.segmentNumber(member.LatestSegment) > c.segmentNumber(mostRecent.LatestSegment) {
+			mostRecent = &raftMembers[i]
+		}
+	}
+
+	return mostRecent
+}
+
+// segmentNumber parses a raft log segment ID, "none" being 0.
+func (c *cmdClusterHeal) segmentNumber(segmentID string) uint64 {
+	number, _ := strconv.ParseUint(segmentID, 10, 64)
+	return number
+}
+
+// raftNodesDiff describes the differences between two raft configurations.
+func (c *cmdClusterHeal) raftNodesDiff(expected []ClusterMember, actual []ClusterMember) string {
+	differences := []string{}
+
+	for _, node := range expected {
+		if !slices.Contains(actual, node) {
+			differences = append(differences, fmt.Sprintf("missing %s (%s, %s)", node.Name, node.Address, node.Role))
+		}
+	}
+
+	for _, node := range actual {
+		if !slices.Contains(expected, node) {
+			differences = append(differences, fmt.Sprintf("extra %s (%s, %s)", node.Name, node.Address, node.Role))
+		}
+	}
+
+	return strings.Join(differences, ", ")
+}
+
+// confirm asks the user a yes/no question.
+func (c *cmdClusterHeal) confirm(question string) bool {
+	fmt.Printf("%s (yes/no) [default=no]: ", question)
+	input, _ := c.reader.ReadString('\n')
+	input = strings.TrimSuffix(input, "\n")
+
+	return slices.Contains([]string{"yes", "y"}, strings.ToLower(input))
+}
@@ -18,7 +18,8 @@ type cmdDaemon struct {
 	global *cmdGlobal
 
 	// Common options
-	flagGroup string
+	flagGroup    string
+	flagRootless bool
 }
 
 func (c *cmdDaemon) command() *cobra.Command {
@@ -30,9 +31,13 @@ func (c *cmdDaemon) command() *cobra.Command {
 
   This is the incus daemon command line. It's typically started directly by your
   init system and interacted with through a tool like ` + "`incus`" + `.
+
+  With --rootless, the daemon runs unprivileged for the current user inside
+  its own user, mount and network namespaces.
 `
 	cmd.RunE = c.run
 	cmd.Flags().StringVar(&c.flagGroup, "group", "", "The group of users that will be allowed to talk to Incus"+"``")
+	cmd.Flags().BoolVar(&c.flagRootless, "rootless", false, "Run the daemon unprivileged for the current user")
 
 	return cmd
 }
@@ -42,6 +47,16 @@ func (c *cmdDaemon) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("unknown command \"%s\" for \"%s\"", args[0], cmd.CommandPath())
 	}
 
+	// Handle rootless mode.
+	switch os.Getenv(rootlessEnv) {
+	case "pending":
+		return rootlessWait()
+	case "":
+		if c.flagRootless {
+			return c.rootlessStart()
+		}
+	}
+
 	// Only root should run this
 	if os.Geteuid() != 0 {
 		return fmt.Errorf("This must be run as root")
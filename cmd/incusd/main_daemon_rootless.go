@@ -0,0 +1,184 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/lxc/incus/v6/shared/idmap"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/subprocess"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// rootlessEnv is set in the environment of the re-executed daemon.
+// It's set to "pending" while waiting for the namespaces to be configured and to "1" afterwards.
+const rootlessEnv = "INCUS_ROOTLESS"
+
+// rootlessIDMapArgs returns the newuidmap/newgidmap arguments mapping the current user to root
+// and the user's subordinate ranges right after it.
+func rootlessIDMapArgs(pid int, hostID int, entries []idmap.Entry, isUID bool) []string {
+	args := []string{strconv.Itoa(pid), "0", strconv.Itoa(hostID), "1"}
+
+	nsID := int64(1)
+	for _, entry := range entries {
+		if (isUID && !entry.IsUID) || (!isUID && !entry.IsGID) {
+			continue
+		}
+
+		args = append(args, strconv.FormatInt(nsID, 10), strconv.FormatInt(entry.HostID, 10), strconv.FormatInt(entry.MapRange, 10))
+		nsID += entry.MapRange
+	}
+
+	return args
+}
+
+// rootlessNetwork returns the command providing outbound connectivity to the network namespace of the given process.
+func rootlessNetwork(pid int) (*exec.Cmd, error) {
+	_, err := exec.LookPath("pasta")
+	if err == nil {
+		return exec.Command("pasta", "--config-net", "--foreground", "--quiet", strconv.Itoa(pid)), nil
+	}
+
+	_, err = exec.LookPath("slirp4netns")
+	if err == nil {
+		return exec.Command("slirp4netns", "--configure", "--mtu=65520", "--disable-host-loopback", strconv.Itoa(pid), "tap0"), nil
+	}
+
+	return nil, errors.New("Rootless mode requires either pasta or slirp4netns")
+}
+
+// rootlessWait is run by the re-executed daemon before the namespaces are configured.
+// It waits for the parent to be done with the ID maps and networking, then re-executes
+// itself so it's granted the full set of capabilities in the new user namespace.
+func rootlessWait() error {
+	sync := os.NewFile(3, "rootless-sync")
+
+	buf := make([]byte, 1)
+	n, err := sync.Read(buf)
+	if err != nil || n != 1 {
+		return errors.New("Rootless daemon setup failed")
+	}
+
+	_ = sync.Close()
+
+	err = os.Setenv(rootlessEnv, "1")
+	if err != nil {
+		return err
+	}
+
+	return unix.Exec("/proc/self/exe", os.Args, os.Environ())
+}
+
+// rootlessStart runs the daemon in new user, mount and network namespaces owned by the current user.
+func (c *cmdDaemon) rootlessStart() error {
+	if os.Geteuid() == 0 {
+		return errors.New("Rootless mode must be run as a regular user")
+	}
+
+	// Get the subordinate ID ranges of the current user.
+	idmapSet, err := idmap.NewSetFromSystem("")
+	if err != nil {
+		return fmt.Errorf("Rootless mode requires subordinate UID and GID ranges for the current user: %w", err)
+	}
+
+	// Setup the data directory.
+	incusDir := os.Getenv("INCUS_DIR")
+	if incusDir == "" {
+		incusDir, err = util.RootlessDir()
+		if err != nil {
+			return fmt.Errorf("Failed to determine the rootless data directory: %w", err)
+		}
+	}
+
+	err = os.MkdirAll(incusDir, 0o711)
+	if err != nil {
+		return fmt.Errorf("Failed to create %q: %w", incusDir, err)
+	}
+
+	// Spawn the daemon in its own namespaces, waiting on the sync pipe.
+	syncR, syncW, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = syncW.Close() }()
+
+	cmd := exec.Command("/proc/self/exe", os.Args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = []*os.File{syncR}
+	cmd.Env = append(os.Environ(), "INCUS_DIR="+incusDir, rootlessEnv+"=pending")
+	cmd.SysProcAttr = &unix.SysProcAttr{
+		Cloneflags: unix.CLONE_NEWUSER | unix.CLONE_NEWNS | unix.CLONE_NEWNET,
+		Pdeathsig:  unix.SIGTERM,
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		return fmt.Errorf("Failed to spawn the rootless daemon: %w", err)
+	}
+
+	_ = syncR.Close()
+	pid := cmd.Process.Pid
+
+	// Map the current user to root and its subordinate ranges after it.
+	_, err = subprocess.RunCommand("newuidmap", rootlessIDMapArgs(pid, os.Getuid(), idmapSet.Entries, true)...)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		return fmt.Errorf("Failed to setup the UID map: %w", err)
+	}
+
+	_, err = subprocess.RunCommand("newgidmap", rootlessIDMapArgs(pid, os.Getgid(), idmapSet.Entries, false)...)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		return fmt.Errorf("Failed to setup the GID map: %w", err)
+	}
+
+	// Provide networking to the daemon's network namespace.
+	netCmd, err := rootlessNetwork(pid)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		return err
+	}
+
+	netCmd.Stderr = os.Stderr
+	err = netCmd.Start()
+	if err != nil {
+		_ = cmd.Process.Kill()
+		return fmt.Errorf("Failed to start %q: %w", netCmd.Args[0], err)
+	}
+
+	defer func() {
+		_ = netCmd.Process.Kill()
+		_ = netCmd.Wait()
+	}()
+
+	logger.Info("Starting rootless daemon", logger.Ctx{"dir": incusDir, "network": netCmd.Args[0]})
+
+	// Let the daemon proceed.
+	_, err = syncW.Write([]byte{0})
+	if err != nil {
+		_ = cmd.Process.Kill()
+		return err
+	}
+
+	// Forward the shutdown signals to the daemon.
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, unix.SIGPWR, unix.SIGINT, unix.SIGQUIT, unix.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	go func() {
+		for sig := range sigCh {
+			_ = cmd.Process.Signal(sig)
+		}
+	}()
+
+	return cmd.Wait()
+}
@@ -9,11 +9,14 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/internal/server/instance/operationlock"
 	"github.com/lxc/incus/v6/internal/server/migration"
 	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/tracing"
 	internalUtil "github.com/lxc/incus/v6/internal/util"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
@@ -84,6 +87,9 @@ func newMigrationSource(inst instance.Instance, stateful bool, instanceOnly bool
 func (s *migrationSourceWs) do(migrateOp *operations.Operation) error {
 	l := logger.AddContext(logger.Ctx{"project": s.instance.Project().Name, "instance": s.instance.Name(), "live": s.live, "clusterMoveSourceName": s.clusterMoveSourceName, "push": s.pushOperationURL != ""})
 
+	_, span := tracing.Start(migrateOp.TraceContext(), "migration.InstanceSource", attribute.String("incus.project", s.instance.Project().Name), attribute.String("incus.instance", s.instance.Name()), attribute.Bool("incus.migration.live", s.live))
+	defer span.End()
+
 	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*30)
 	defer cancel()
 
@@ -215,6 +221,9 @@ func newMigrationSink(args *migrationSinkArgs) (*migrationSink, error) {
 func (c *migrationSink) do(instOp *operationlock.InstanceOperation) error {
 	l := logger.AddContext(logger.Ctx{"project": c.instance.Project().Name, "instance": c.instance.Name(), "live": c.live, "clusterMoveSourceName": c.clusterMoveSourceName, "push": c.push})
 
+	_, span := tracing.Start(c.instance.Operation().TraceContext(), "migration.InstanceTarget", attribute.String("incus.project", c.instance.Project().Name), attribute.String("incus.instance", c.instance.Name()), attribute.Bool("incus.migration.live", c.live))
+	defer span.End()
+
 	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*30)
 	defer cancel()
 
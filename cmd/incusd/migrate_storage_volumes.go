@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/lxc/incus/v6/internal/migration"
@@ -18,6 +19,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/state"
 	storagePools "github.com/lxc/incus/v6/internal/server/storage"
 	storageDrivers "github.com/lxc/incus/v6/internal/server/storage/drivers"
+	"github.com/lxc/incus/v6/internal/server/tracing"
 	internalUtil "github.com/lxc/incus/v6/internal/util"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
@@ -71,6 +73,9 @@ func newStorageMigrationSource(volumeOnly bool, pushTarget *api.StorageVolumePos
 func (s *migrationSourceWs) DoStorage(state *state.State, projectName string, poolName string, volName string, migrateOp *operations.Operation) error {
 	l := logger.AddContext(logger.Ctx{"project": projectName, "pool": poolName, "volume": volName, "push": s.pushOperationURL != ""})
 
+	_, span := tracing.Start(migrateOp.TraceContext(), "migration.VolumeSource", attribute.String("incus.project", projectName), attribute.String("incus.storage.pool", poolName), attribute.String("incus.storage.volume", volName))
+	defer span.End()
+
 	ctx, cancel := context.WithTimeout(state.ShutdownCtx, time.Second*30)
 	defer cancel()
 
@@ -289,6 +294,9 @@ func newStorageMigrationSink(args *migrationSinkArgs) (*migrationSink, error) {
 func (c *migrationSink) DoStorage(state *state.State, projectName string, poolName string, req *api.StorageVolumesPost, op *operations.Operation) error {
 	l := logger.AddContext(logger.Ctx{"project": projectName, "pool": poolName, "volume": req.Name, "push": c.push})
 
+	_, span := tracing.Start(op.TraceContext(), "migration.VolumeTarget", attribute.String("incus.project", projectName), attribute.String("incus.storage.pool", poolName), attribute.String("incus.storage.volume", req.Name))
+	defer span.End()
+
 	ctx, cancel := context.WithTimeout(state.ShutdownCtx, time.Second*30)
 	defer cancel()
 
@@ -598,6 +598,26 @@ func networkForwardPut(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	targetMember := request.QueryParam(r, "target")
+	memberSpecific := targetMember != ""
+
+	var forward *api.NetworkForward
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		_, forward, err = tx.GetNetworkForward(ctx, n.ID(), memberSpecific, listenAddress)
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Validate the ETag.
+	err = localUtil.EtagCheck(r, forward.Etag())
+	if err != nil {
+		return response.PreconditionFailed(err)
+	}
+
 	// Decode the request.
 	req := api.NetworkForwardPut{}
 	err = json.NewDecoder(r.Body).Decode(&req)
@@ -605,21 +625,7 @@ func networkForwardPut(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
-	targetMember := request.QueryParam(r, "target")
-	memberSpecific := targetMember != ""
-
 	if r.Method == http.MethodPatch {
-		var forward *api.NetworkForward
-
-		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
-			_, forward, err = tx.GetNetworkForward(ctx, n.ID(), memberSpecific, listenAddress)
-
-			return err
-		})
-		if err != nil {
-			return response.SmartError(err)
-		}
-
 		// If config being updated via "patch" method, then merge all existing config with the keys that
 		// are present in the request config.
 		for k, v := range forward.Config {
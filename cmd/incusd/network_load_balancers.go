@@ -605,6 +605,26 @@ func networkLoadBalancerPut(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	targetMember := request.QueryParam(r, "target")
+	memberSpecific := targetMember != ""
+
+	var loadBalancer *api.NetworkLoadBalancer
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		_, loadBalancer, err = tx.GetNetworkLoadBalancer(ctx, n.ID(), memberSpecific, listenAddress)
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Validate the ETag.
+	err = localUtil.EtagCheck(r, loadBalancer.Etag())
+	if err != nil {
+		return response.PreconditionFailed(err)
+	}
+
 	// Decode the request.
 	req := api.NetworkLoadBalancerPut{}
 	err = json.NewDecoder(r.Body).Decode(&req)
@@ -612,21 +632,7 @@ func networkLoadBalancerPut(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
-	targetMember := request.QueryParam(r, "target")
-	memberSpecific := targetMember != ""
-
 	if r.Method == http.MethodPatch {
-		var loadBalancer *api.NetworkLoadBalancer
-
-		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
-			_, loadBalancer, err = tx.GetNetworkLoadBalancer(ctx, n.ID(), memberSpecific, listenAddress)
-
-			return err
-		})
-		if err != nil {
-			return response.SmartError(err)
-		}
-
 		// If config being updated via "patch" method, then merge all existing config with the keys that
 		// are present in the request config.
 		for k, v := range loadBalancer.Config {
@@ -591,6 +591,23 @@ func networkPeerPut(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	var peer *api.NetworkPeer
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		_, peer, err = tx.GetNetworkPeer(ctx, n.ID(), peerName)
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Validate the ETag.
+	err = localUtil.EtagCheck(r, peer.Etag())
+	if err != nil {
+		return response.PreconditionFailed(err)
+	}
+
 	// Decode the request.
 	req := api.NetworkPeerPut{}
 	err = json.NewDecoder(r.Body).Decode(&req)
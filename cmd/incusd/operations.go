@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -12,17 +13,21 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/lxc/incus/v6/internal/filter"
 	"github.com/lxc/incus/v6/internal/jmap"
 	"github.com/lxc/incus/v6/internal/server/auth"
 	"github.com/lxc/incus/v6/internal/server/cluster"
 	"github.com/lxc/incus/v6/internal/server/db"
 	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
 	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/lifecycle"
 	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
 	"github.com/lxc/incus/v6/internal/server/state"
+	storagePools "github.com/lxc/incus/v6/internal/server/storage"
 	"github.com/lxc/incus/v6/internal/server/task"
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
 	"github.com/lxc/incus/v6/shared/api"
@@ -408,6 +413,11 @@ func operationCancel(s *state.State, r *http.Request, projectName string, op *ap
 //      name: all-projects
 //      description: Retrieve operations from all projects
 //      type: boolean
+//    - in: query
+//      name: filter
+//      description: Collection filter
+//      type: string
+//      example: default
 //  responses:
 //    "200":
 //      description: API endpoints
@@ -464,6 +474,11 @@ func operationCancel(s *state.State, r *http.Request, projectName string, op *ap
 //	    name: all-projects
 //	    description: Retrieve operations from all projects
 //	    type: boolean
+//	  - in: query
+//	    name: filter
+//	    description: Collection filter
+//	    type: string
+//	    example: default
 //	responses:
 //	  "200":
 //	    description: API endpoints
@@ -512,6 +527,23 @@ func operationsGet(d *Daemon, r *http.Request) response.Response {
 		return response.InternalError(fmt.Errorf("Failed to get operation permission checker: %w", err))
 	}
 
+	// Parse filter value.
+	filterStr := r.FormValue("filter")
+	clauses, err := filter.Parse(filterStr, filter.QueryOperatorSet())
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid filter: %w", err))
+	}
+
+	filtering := clauses != nil && len(clauses.Clauses) > 0
+
+	operationMatch := func(op *api.Operation) (bool, error) {
+		if !filtering {
+			return true, nil
+		}
+
+		return filter.Match(*op, *clauses)
+	}
+
 	localOperationURLs := func() (jmap.Map, error) {
 		// Get all the operations.
 		localOps := operations.Clone()
@@ -528,6 +560,22 @@ func operationsGet(d *Daemon, r *http.Request) response.Response {
 				continue
 			}
 
+			if filtering {
+				_, op, err := v.Render()
+				if err != nil {
+					return nil, err
+				}
+
+				match, err := operationMatch(op)
+				if err != nil {
+					return nil, err
+				}
+
+				if !match {
+					continue
+				}
+			}
+
 			status := strings.ToLower(v.Status().String())
 			_, ok := body[status]
 			if !ok {
@@ -556,17 +604,26 @@ func operationsGet(d *Daemon, r *http.Request) response.Response {
 				continue
 			}
 
-			status := strings.ToLower(v.Status().String())
-			_, ok := body[status]
-			if !ok {
-				body[status] = make([]*api.Operation, 0)
+			_, op, err := v.Render()
+			if err != nil {
+				return nil, err
 			}
 
-			_, op, err := v.Render()
+			match, err := operationMatch(op)
 			if err != nil {
 				return nil, err
 			}
 
+			if !match {
+				continue
+			}
+
+			status := strings.ToLower(v.Status().String())
+			_, ok := body[status]
+			if !ok {
+				body[status] = make([]*api.Operation, 0)
+			}
+
 			body[status] = append(body[status].([]*api.Operation), op)
 		}
 
@@ -693,6 +750,16 @@ func operationsGet(d *Daemon, r *http.Request) response.Response {
 		// Merge with existing data.
 		for _, o := range ops {
 			op := o // Local var for pointer.
+
+			match, err := operationMatch(&op)
+			if err != nil {
+				return response.BadRequest(fmt.Errorf("Invalid filter: %w", err))
+			}
+
+			if !match {
+				continue
+			}
+
 			status := strings.ToLower(op.Status)
 
 			_, ok := md[status]
@@ -1241,3 +1308,204 @@ func autoRemoveOrphanedOperations(ctx context.Context, s *state.State) error {
 
 	return nil
 }
+
+// operationRollbacks lists the functions reverting the changes of the operations interrupted by a crash, by operation type.
+var operationRollbacks = map[operationtype.Type]func(s *state.State, projectName string, fields []string) error{
+	operationtype.BackupCreate: func(s *state.State, projectName string, fields []string) error {
+		// /1.0/instances/<instance>/backups/<backup>
+		if len(fields) != 5 || fields[1] != "instances" {
+			return fmt.Errorf("Unexpected instance backup URL")
+		}
+
+		b, err := instance.BackupLoadByName(s, projectName, fields[2]+"/"+fields[4])
+		if err != nil {
+			return err
+		}
+
+		return b.Delete()
+	},
+	operationtype.CustomVolumeBackupCreate: func(s *state.State, projectName string, fields []string) error {
+		// /1.0/storage-pools/<pool>/volumes/<type>/<volume>/backups/<backup>
+		if len(fields) != 8 || fields[1] != "storage-pools" {
+			return fmt.Errorf("Unexpected storage volume backup URL")
+		}
+
+		projectName, err := project.StorageVolumeProject(s.DB.Cluster, projectName, db.StoragePoolVolumeTypeCustom)
+		if err != nil {
+			return err
+		}
+
+		b, err := storagePoolVolumeBackupLoadByName(context.TODO(), s, projectName, fields[2], fields[5]+"/"+fields[7])
+		if err != nil {
+			return err
+		}
+
+		return b.Delete()
+	},
+	operationtype.BucketBackupCreate: func(s *state.State, projectName string, fields []string) error {
+		// /1.0/storage-pools/<pool>/buckets/<bucket>/backups/<backup>
+		if len(fields) != 7 || fields[1] != "storage-pools" {
+			return fmt.Errorf("Unexpected storage bucket backup URL")
+		}
+
+		projectName, err := project.StorageBucketProject(context.TODO(), s.DB.Cluster, projectName)
+		if err != nil {
+			return err
+		}
+
+		b, err := storagePoolBucketBackupLoadByName(context.TODO(), s, projectName, fields[2], fields[4]+"/"+fields[6])
+		if err != nil {
+			return err
+		}
+
+		return b.Delete()
+	},
+}
+
+// createdRollbacks lists the functions deleting the resources partially created by the operations interrupted
+// by a crash, like migrated instances and volumes, by resource type.
+var createdRollbacks = map[string]func(s *state.State, projectName string, fields []string) error{
+	"instances": func(s *state.State, projectName string, fields []string) error {
+		// /1.0/instances/<instance>
+		if len(fields) != 3 || fields[1] != "instances" {
+			return fmt.Errorf("Unexpected instance URL")
+		}
+
+		inst, err := instance.LoadByProjectAndName(s, projectName, fields[2])
+		if err != nil {
+			return err
+		}
+
+		return inst.Delete(true)
+	},
+	"storage_volumes": func(s *state.State, projectName string, fields []string) error {
+		// /1.0/storage-pools/<pool>/volumes/custom/<volume>
+		if len(fields) != 6 || fields[1] != "storage-pools" || fields[4] != db.StoragePoolVolumeTypeNameCustom {
+			return fmt.Errorf("Unexpected storage volume URL")
+		}
+
+		projectName, err := project.StorageVolumeProject(s.DB.Cluster, projectName, db.StoragePoolVolumeTypeCustom)
+		if err != nil {
+			return err
+		}
+
+		pool, err := storagePools.LoadByName(s, fields[2])
+		if err != nil {
+			return err
+		}
+
+		return pool.DeleteCustomVolume(projectName, fields[5], nil)
+	},
+}
+
+// operationCleanedUp lists the operation types whose partial changes are cleaned up separately on startup, like
+// the image files left behind by image downloads which are removed by pruneLeftoverImages.
+var operationCleanedUp = map[operationtype.Type]bool{
+	operationtype.ImageDownload: true,
+}
+
+// rollbackInterruptedOperations reverts the changes of the operations of the local member which were still
+// running when the server went down, removing the partial backups, instances and volumes they left behind.
+// Other operations are only removed from the database, logging a warning as their partial changes may need
+// to be cleaned up manually.
+func rollbackInterruptedOperations(s *state.State) {
+	var ops []dbCluster.Operation
+	projectNames := map[int64]string{}
+
+	err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		nodeID := tx.GetNodeID()
+
+		var err error
+
+		ops, err = dbCluster.GetOperations(ctx, tx.Tx(), dbCluster.OperationFilter{NodeID: &nodeID})
+		if err != nil {
+			return err
+		}
+
+		projects, err := dbCluster.GetProjects(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		for _, p := range projects {
+			projectNames[int64(p.ID)] = p.Name
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Warn("Failed loading interrupted operations", logger.Ctx{"err": err})
+		return
+	}
+
+	for _, op := range ops {
+		// Skip the operations started since the server came up.
+		_, err := operations.OperationGetInternal(op.UUID)
+		if err == nil {
+			continue
+		}
+
+		projectName := api.ProjectDefaultName
+		if op.ProjectID != nil {
+			projectName = projectNames[*op.ProjectID]
+		}
+
+		resources := map[string][]string{}
+		if op.Resources != "" {
+			err := json.Unmarshal([]byte(op.Resources), &resources)
+			if err != nil {
+				logger.Warn("Failed parsing resources of interrupted operation", logger.Ctx{"operation": op.UUID, "err": err})
+			}
+		}
+
+		// Pick the rollback of each of the recorded resources.
+		rollbacks := map[string]func(s *state.State, projectName string, fields []string) error{}
+
+		rollback, ok := operationRollbacks[op.Type]
+		if ok {
+			for _, resource := range resources["backups"] {
+				rollbacks[resource] = rollback
+			}
+		}
+
+		for key, rollback := range createdRollbacks {
+			for _, resource := range resources[operations.CreatedResourcePrefix+key] {
+				rollbacks[resource] = rollback
+			}
+		}
+
+		if len(rollbacks) == 0 && !ok && !operationCleanedUp[op.Type] {
+			logger.Warn("Interrupted operation can't be rolled back, its partial changes may need to be cleaned up", logger.Ctx{"operation": op.UUID, "description": op.Type.Description()})
+		}
+
+		for resource, rollback := range rollbacks {
+			u, err := url.Parse(resource)
+			if err != nil {
+				continue
+			}
+
+			// The created resources carry the project they're in.
+			resourceProject := projectName
+			if u.Query().Get("project") != "" {
+				resourceProject = u.Query().Get("project")
+			}
+
+			l := logger.AddContext(logger.Ctx{"operation": op.UUID, "description": op.Type.Description(), "project": resourceProject, "resource": u.Path})
+
+			err = rollback(s, resourceProject, strings.Split(strings.Trim(u.Path, "/"), "/"))
+			if err != nil && !response.IsNotFoundError(err) {
+				l.Warn("Failed rolling back interrupted operation", logger.Ctx{"err": err})
+				continue
+			}
+
+			l.Info("Rolled back interrupted operation")
+		}
+
+		err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			return dbCluster.DeleteOperation(ctx, tx.Tx(), op.UUID)
+		})
+		if err != nil {
+			logger.Warn("Failed removing interrupted operation", logger.Ctx{"operation": op.UUID, "err": err})
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"slices"
 	"strings"
+	"sync"
 
 	"github.com/gorilla/mux"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
 	"github.com/lxc/incus/v6/internal/version"
 	"github.com/lxc/incus/v6/shared/api"
@@ -228,6 +230,20 @@ func profilesGet(d *Daemon, r *http.Request) response.Response {
 					continue
 				}
 
+				// Skip the profiles ruled out by the filter before finding their users.
+				if clauses != nil && len(clauses.Clauses) > 0 {
+					record := api.Profile{Name: profile.Name, Project: profile.Project}
+
+					match, err := filter.MayMatch(record, *clauses, "name", "project")
+					if err != nil {
+						return err
+					}
+
+					if !match {
+						continue
+					}
+				}
+
 				apiProfile, err := profile.ToAPI(ctx, tx.Tx(), profileConfigs, profileDevices)
 				if err != nil {
 					return err
@@ -291,6 +307,19 @@ func profileUsedBy(ctx context.Context, tx *db.ClusterTx, profile dbCluster.Prof
 		usedBy[i] = apiInst.URL(version.APIVersion, inst.Project).String()
 	}
 
+	// Add the profiles extending it.
+	profiles, err := dbCluster.GetProfiles(ctx, tx.Tx(), dbCluster.ProfileFilter{Project: &profile.Project})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, extending := range profiles {
+		if extending.Extends == profile.Name {
+			apiProfile := &api.Profile{Name: extending.Name}
+			usedBy = append(usedBy, apiProfile.URL(version.APIVersion, extending.Project).String())
+		}
+	}
+
 	return usedBy, nil
 }
 
@@ -376,10 +405,16 @@ func profilesPost(d *Daemon, r *http.Request) response.Response {
 			return fmt.Errorf("The profile already exists")
 		}
 
+		err = profileValidateExtends(ctx, tx, p.Name, req.Name, req.ProfilePut)
+		if err != nil {
+			return err
+		}
+
 		profile := dbCluster.Profile{
 			Project:     p.Name,
 			Name:        req.Name,
 			Description: req.Description,
+			Extends:     req.Extends,
 		}
 
 		id, err := dbCluster.CreateProfile(ctx, tx.Tx(), profile)
@@ -504,7 +539,7 @@ func profileGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
-	etag := []any{resp.Config, resp.Description, resp.Devices}
+	etag := []any{resp.Config, resp.Description, resp.Devices, resp.Extends}
 	return response.SyncResponseETag(true, resp, etag)
 }
 
@@ -588,7 +623,7 @@ func profilePut(d *Daemon, r *http.Request) response.Response {
 	}
 
 	// Validate the ETag.
-	etag := []any{profile.Config, profile.Description, profile.Devices}
+	etag := []any{profile.Config, profile.Description, profile.Devices, profile.Extends}
 	err = localUtil.EtagCheck(r, etag)
 	if err != nil {
 		return response.PreconditionFailed(err)
@@ -600,27 +635,49 @@ func profilePut(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
-	err = doProfileUpdate(r.Context(), s, *p, name, profile, req)
-
-	if err == nil && !isClusterNotification(r) {
-		// Notify all other nodes. If a node is down, it will be ignored.
-		notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAlive)
-		if err != nil {
-			return response.SmartError(err)
-		}
+	result, err := doProfileUpdate(r.Context(), s, *p, name, profile, req)
+	if err != nil {
+		return response.SmartError(err)
+	}
 
-		err = notifier(func(client incus.InstanceServer) error {
-			return client.UseProject(p.Name).UpdateProfile(name, profile.ProfilePut, "")
-		})
-		if err != nil {
-			return response.SmartError(err)
-		}
+	err = profileUpdateNotify(s, p.Name, name, profile.ProfilePut, result)
+	if err != nil {
+		return response.SmartError(err)
 	}
 
 	requestor := request.CreateRequestor(r)
 	s.Events.SendLifecycle(p.Name, lifecycle.ProfileUpdated.Event(name, p.Name, requestor, nil))
 
-	return response.SmartError(err)
+	return response.SmartError(result.Err())
+}
+
+// profileUpdateNotify has the other cluster members apply a profile change to their instances and merges their
+// failures into the result. The old profile config is sent as the new one has already been saved.
+func profileUpdateNotify(s *state.State, projectName string, profileName string, old api.ProfilePut, result *profileUpdateResult) error {
+	// Notify all other nodes. If a node is down, it will be ignored.
+	notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAlive)
+	if err != nil {
+		return err
+	}
+
+	var resultMu sync.Mutex
+
+	return notifier(func(client incus.InstanceServer) error {
+		err := client.UseProject(projectName).UpdateProfile(profileName, old, "")
+		if err != nil {
+			address := ""
+			info, _ := client.GetConnectionInfo()
+			if info != nil {
+				address = info.URL
+			}
+
+			resultMu.Lock()
+			result.addRemoteFailures(address, err)
+			resultMu.Unlock()
+		}
+
+		return nil
+	})
 }
 
 // swagger:operation PATCH /1.0/profiles/{name} profiles profile_patch
@@ -690,7 +747,7 @@ func profilePatch(d *Daemon, r *http.Request) response.Response {
 	}
 
 	// Validate the ETag.
-	etag := []any{profile.Config, profile.Description, profile.Devices}
+	etag := []any{profile.Config, profile.Description, profile.Devices, profile.Extends}
 	err = localUtil.EtagCheck(r, etag)
 	if err != nil {
 		return response.PreconditionFailed(err)
@@ -722,6 +779,12 @@ func profilePatch(d *Daemon, r *http.Request) response.Response {
 		req.Description = profile.Description
 	}
 
+	// Get Extends.
+	_, err = reqRaw.GetString("extends")
+	if err != nil {
+		req.Extends = profile.Extends
+	}
+
 	// Get Config.
 	if req.Config == nil {
 		req.Config = profile.Config
@@ -746,10 +809,20 @@ func profilePatch(d *Daemon, r *http.Request) response.Response {
 		}
 	}
 
+	result, err := doProfileUpdate(r.Context(), s, *p, name, profile, req)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = profileUpdateNotify(s, p.Name, name, profile.ProfilePut, result)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	requestor := request.CreateRequestor(r)
 	s.Events.SendLifecycle(p.Name, lifecycle.ProfileUpdated.Event(name, p.Name, requestor, nil))
 
-	return response.SmartError(doProfileUpdate(r.Context(), s, *p, name, profile, req))
+	return response.SmartError(result.Err())
 }
 
 // swagger:operation POST /1.0/profiles/{name} profiles profile_post
@@ -833,7 +906,30 @@ func profilePost(d *Daemon, r *http.Request) response.Response {
 			return fmt.Errorf("Profile %q already exists", req.Name)
 		}
 
-		return dbCluster.RenameProfile(ctx, tx.Tx(), p.Name, name, req.Name)
+		err = dbCluster.RenameProfile(ctx, tx.Tx(), p.Name, name, req.Name)
+		if err != nil {
+			return err
+		}
+
+		// Update the profiles extending it.
+		profiles, err := dbCluster.GetProfiles(ctx, tx.Tx(), dbCluster.ProfileFilter{Project: &p.Name})
+		if err != nil {
+			return err
+		}
+
+		for _, profile := range profiles {
+			if profile.Extends != name {
+				continue
+			}
+
+			profile.Extends = req.Name
+			err = dbCluster.UpdateProfile(ctx, tx.Tx(), p.Name, profile.Name, profile)
+			if err != nil {
+				return fmt.Errorf("Failed updating profile %q: %w", profile.Name, err)
+			}
+		}
+
+		return nil
 	})
 	if err != nil {
 		return response.SmartError(err)
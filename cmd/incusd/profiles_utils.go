@@ -3,6 +3,10 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
 
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/server/db"
@@ -15,31 +19,108 @@ import (
 	"github.com/lxc/incus/v6/shared/api"
 )
 
-func doProfileUpdate(ctx context.Context, s *state.State, p api.Project, profileName string, profile *api.Profile, req api.ProfilePut) error {
+// profileUpdateResult records which of the instances using a profile failed to apply a profile change.
+type profileUpdateResult struct {
+	instances []string
+	failures  map[string]string
+}
+
+// newProfileUpdateResult returns a result for the given instances using the profile.
+func newProfileUpdateResult(insts map[int]db.InstanceArgs) *profileUpdateResult {
+	result := &profileUpdateResult{failures: map[string]string{}}
+	for _, inst := range insts {
+		result.instances = append(result.instances, profileUpdateInstanceKey(inst.Project, inst.Name))
+	}
+
+	sort.Strings(result.instances)
+
+	return result
+}
+
+// profileUpdateInstanceKey returns how an instance is identified in a profile update result.
+func profileUpdateInstanceKey(projectName string, instanceName string) string {
+	return fmt.Sprintf("Project: %s, Instance: %s", projectName, instanceName)
+}
+
+// addRemoteFailures merges the failures reported by another cluster member.
+func (r *profileUpdateResult) addRemoteFailures(address string, err error) {
+	found := false
+	for _, line := range strings.Split(err.Error(), "\n") {
+		entry, ok := strings.CutPrefix(line, " - ")
+		if !ok {
+			continue
+		}
+
+		key, reason, ok := strings.Cut(entry, ": Failed: ")
+		if !ok {
+			continue
+		}
+
+		r.failures[key] = reason
+		found = true
+	}
+
+	// Record failures not related to specific instances against the member.
+	if !found {
+		key := fmt.Sprintf("Member: %s", address)
+		r.instances = append(r.instances, key)
+		r.failures[key] = err.Error()
+	}
+}
+
+// Err returns an error listing which instances could and couldn't apply the change if any of them failed.
+// As the change was saved, the error uses the 424 Failed Dependency status code rather than a generic one,
+// which lets the clients tell it apart from a rejected change.
+func (r *profileUpdateResult) Err() error {
+	if len(r.failures) == 0 {
+		return nil
+	}
+
+	msg := "The profile change was saved but couldn't be applied to all instances (restart the failed instances to apply it):\n"
+	for _, key := range r.instances {
+		reason, ok := r.failures[key]
+		if ok {
+			msg += fmt.Sprintf(" - %s: Failed: %s\n", key, strings.ReplaceAll(reason, "\n", " "))
+		} else {
+			msg += fmt.Sprintf(" - %s: Applied\n", key)
+		}
+	}
+
+	return api.StatusErrorf(http.StatusFailedDependency, "%s", msg)
+}
+
+// doProfileUpdate validates and saves a profile change before applying it to the local instances using the
+// profile. The returned result lists the instances which failed to apply the change.
+func doProfileUpdate(ctx context.Context, s *state.State, p api.Project, profileName string, profile *api.Profile, req api.ProfilePut) (*profileUpdateResult, error) {
 	// Check project limits.
 	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		err := profileValidateExtends(ctx, tx, p.Name, profileName, req)
+		if err != nil {
+			return err
+		}
+
 		return project.AllowProfileUpdate(tx, p.Name, profileName, req)
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Quick checks.
 	err = instance.ValidConfig(s.OS, req.Config, false, instancetype.Any)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Profiles can be applied to any instance type, so just use instancetype.Any type for validation so that
 	// instance type specific validation checks are not performed.
 	err = instance.ValidDevices(s, p, instancetype.Any, deviceConfig.NewDevices(req.Devices), nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	insts, projects, err := getProfileInstancesInfo(ctx, s.DB.Cluster, p.Name, profileName)
 	if err != nil {
-		return fmt.Errorf("Failed to query instances associated with profile %q: %w", profileName, err)
+		return nil, fmt.Errorf("Failed to query instances associated with profile %q: %w", profileName, err)
 	}
 
 	// Check if the root disk device's pool would be changed or removed and prevent that if there are instances
@@ -80,7 +161,7 @@ func doProfileUpdate(ctx context.Context, s *state.State, p api.Project, profile
 				return nil
 			})
 			if err != nil {
-				return err
+				return nil, err
 			}
 		}
 	}
@@ -96,6 +177,7 @@ func doProfileUpdate(ctx context.Context, s *state.State, p api.Project, profile
 			Project:     p.Name,
 			Name:        profileName,
 			Description: req.Description,
+			Extends:     req.Extends,
 		})
 		if err != nil {
 			return err
@@ -128,34 +210,23 @@ func doProfileUpdate(ctx context.Context, s *state.State, p api.Project, profile
 		return nil
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Update all the instances on this node using the profile. Must be done after db.TxCommit due to DB lock.
-	failures := map[*db.InstanceArgs]error{}
-	for _, it := range insts {
-		inst := it // Local var for instance pointer.
-
+	result := newProfileUpdateResult(insts)
+	for _, inst := range insts {
 		if inst.Node != "" && inst.Node != s.ServerName {
 			continue // This instance does not belong to this member, skip.
 		}
 
 		err := doProfileUpdateInstance(ctx, s, inst, *projects[inst.Project])
 		if err != nil {
-			failures[&inst] = err
+			result.failures[profileUpdateInstanceKey(inst.Project, inst.Name)] = err.Error()
 		}
 	}
 
-	if len(failures) != 0 {
-		msg := "The following instances failed to update (profile change still saved):\n"
-		for inst, err := range failures {
-			msg += fmt.Sprintf(" - Project: %s, Instance: %s: %v\n", inst.Project, inst.Name, err)
-		}
-
-		return fmt.Errorf("%s", msg)
-	}
-
-	return nil
+	return result, nil
 }
 
 // Like doProfileUpdate but does not update the database, since it was already
@@ -166,14 +237,14 @@ func doProfileUpdateCluster(ctx context.Context, s *state.State, projectName str
 		return fmt.Errorf("Failed to query instances associated with profile %q: %w", profileName, err)
 	}
 
-	failures := map[*db.InstanceArgs]error{}
-	for _, it := range insts {
-		inst := it // Local var for instance pointer.
-
+	result := &profileUpdateResult{failures: map[string]string{}}
+	for _, inst := range insts {
 		if inst.Node != "" && inst.Node != s.ServerName {
 			continue // This instance does not belong to this member, skip.
 		}
 
+		result.instances = append(result.instances, profileUpdateInstanceKey(inst.Project, inst.Name))
+
 		for i, profile := range inst.Profiles {
 			if profile.Name == profileName {
 				// As profile has already been updated in the database by this point, overwrite the
@@ -181,26 +252,29 @@ func doProfileUpdateCluster(ctx context.Context, s *state.State, projectName str
 				// doProfileUpdateInstance will detect the changes and apply them.
 				inst.Profiles[i].Config = old.Config
 				inst.Profiles[i].Devices = old.Devices
+				inst.Profiles[i].Extends = old.Extends
 				break
 			}
 		}
 
-		err := doProfileUpdateInstance(ctx, s, inst, *projects[inst.Project])
+		// Same for the profiles extending the updated profile.
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			return profilesExpandWithOld(ctx, tx, inst.Profiles, profileName, old)
+		})
 		if err != nil {
-			failures[&inst] = err
+			result.failures[profileUpdateInstanceKey(inst.Project, inst.Name)] = err.Error()
+			continue
 		}
-	}
 
-	if len(failures) != 0 {
-		msg := "The following instances failed to update (profile change still saved):\n"
-		for inst, err := range failures {
-			msg += fmt.Sprintf(" - Project: %s, Instance: %s: %v\n", inst.Project, inst.Name, err)
+		err := doProfileUpdateInstance(ctx, s, inst, *projects[inst.Project])
+		if err != nil {
+			result.failures[profileUpdateInstanceKey(inst.Project, inst.Name)] = err.Error()
 		}
-
-		return fmt.Errorf("%s", msg)
 	}
 
-	return nil
+	sort.Strings(result.instances)
+
+	return result.Err()
 }
 
 // Profile update of a single instance.
@@ -253,8 +327,32 @@ func getProfileInstancesInfo(ctx context.Context, dbCluster *db.Cluster, project
 		var err error
 
 		projectInstNames, err = tx.GetInstancesWithProfile(ctx, projectName, profileName)
+		if err != nil {
+			return err
+		}
 
-		return err
+		// Include the instances using the profiles extending it.
+		profiles, err := profileExtendedBy(ctx, tx, projectName, profileName)
+		if err != nil {
+			return err
+		}
+
+		for _, profile := range profiles {
+			extendingInstNames, err := tx.GetInstancesWithProfile(ctx, projectName, profile.Name)
+			if err != nil {
+				return err
+			}
+
+			for instProject, instNames := range extendingInstNames {
+				for _, instName := range instNames {
+					if !slices.Contains(projectInstNames[instProject], instName) {
+						projectInstNames[instProject] = append(projectInstNames[instProject], instName)
+					}
+				}
+			}
+		}
+
+		return nil
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("Failed to query instances with profile %q: %w", profileName, err)
@@ -304,3 +402,87 @@ func getProfileInstancesInfo(ctx context.Context, dbCluster *db.Cluster, project
 
 	return instances, projects, nil
 }
+
+// profileValidateExtends checks that the profile extended by a profile exists and that extending it doesn't
+// form a loop.
+func profileValidateExtends(ctx context.Context, tx *db.ClusterTx, projectName string, profileName string, req api.ProfilePut) error {
+	if req.Extends == "" {
+		return nil
+	}
+
+	_, _, err := cluster.ExpandProfile(api.Profile{Name: profileName, ProfilePut: req}, func(name string) (*api.Profile, error) {
+		dbProfile, err := cluster.GetProfile(ctx, tx.Tx(), projectName, name)
+		if err != nil {
+			return nil, fmt.Errorf("Failed loading profile %q: %w", name, err)
+		}
+
+		return dbProfile.ToAPI(ctx, tx.Tx(), nil, nil)
+	})
+	if err != nil {
+		return api.StatusErrorf(http.StatusBadRequest, "Invalid extended profile: %w", err)
+	}
+
+	return nil
+}
+
+// profileExtendedBy returns the profiles of a project extending the given profile, directly or not.
+func profileExtendedBy(ctx context.Context, tx *db.ClusterTx, projectName string, profileName string) ([]cluster.Profile, error) {
+	enabled, err := cluster.ProjectHasProfiles(ctx, tx.Tx(), projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !enabled {
+		projectName = api.ProjectDefaultName
+	}
+
+	profiles, err := cluster.GetProfiles(ctx, tx.Tx(), cluster.ProfileFilter{Project: &projectName})
+	if err != nil {
+		return nil, err
+	}
+
+	result := []cluster.Profile{}
+	names := []string{profileName}
+	for i := 0; i < len(names); i++ {
+		for _, profile := range profiles {
+			if profile.Extends == names[i] && !slices.Contains(names, profile.Name) {
+				names = append(names, profile.Name)
+				result = append(result, profile)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// profilesExpandWithOld expands the given profiles again, using the old configuration and devices of the
+// updated profile.
+func profilesExpandWithOld(ctx context.Context, tx *db.ClusterTx, profiles []api.Profile, profileName string, old api.ProfilePut) error {
+	for i, profile := range profiles {
+		profiles[i].ExpandedConfig = nil
+		profiles[i].ExpandedDevices = nil
+
+		if profile.Extends == "" {
+			continue
+		}
+
+		var err error
+		profiles[i].ExpandedConfig, profiles[i].ExpandedDevices, err = cluster.ExpandProfile(profile, func(name string) (*api.Profile, error) {
+			if name == profileName {
+				return &api.Profile{Name: profileName, ProfilePut: old}, nil
+			}
+
+			dbProfile, err := cluster.GetProfile(ctx, tx.Tx(), profile.Project, name)
+			if err != nil {
+				return nil, fmt.Errorf("Failed loading profile %q: %w", name, err)
+			}
+
+			return dbProfile.ToAPI(ctx, tx.Tx(), nil, nil)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,1001 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/lxc/incus/v6/internal/filter"
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/cluster"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/internal/server/task"
+	localUtil "github.com/lxc/incus/v6/internal/server/util"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/util"
+	"github.com/lxc/incus/v6/shared/validate"
+)
+
+var scheduledTasksCmd = APIEndpoint{
+	Path: "scheduled-tasks",
+
+	Get:  APIEndpointAction{Handler: scheduledTasksGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Post: APIEndpointAction{Handler: scheduledTasksPost, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+var scheduledTaskCmd = APIEndpoint{
+	Path: "scheduled-tasks/{name}",
+
+	Delete: APIEndpointAction{Handler: scheduledTaskDelete, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Get:    APIEndpointAction{Handler: scheduledTaskGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Put:    APIEndpointAction{Handler: scheduledTaskPut, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Patch:  APIEndpointAction{Handler: scheduledTaskPut, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+// Supported scheduled task actions.
+const (
+	scheduledTaskActionSnapshot     = "snapshot"
+	scheduledTaskActionBackup       = "backup"
+	scheduledTaskActionImageRefresh = "image-refresh"
+	scheduledTaskActionExec         = "exec"
+	scheduledTaskActionWebhook      = "webhook"
+)
+
+// scheduledTaskFilterKeys lists the keys which can be used in the filter of a scheduled task, on top of config.* keys.
+var scheduledTaskFilterKeys = []string{"project", "name", "type", "status"}
+
+// scheduledTaskParseFilter parses a comma separated list of key=value filters.
+func scheduledTaskParseFilter(filter string) (map[string]string, error) {
+	filters := map[string]string{}
+
+	for _, entry := range util.SplitNTrimSpace(filter, ",", -1, true) {
+		key, value, found := strings.Cut(entry, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("Invalid filter %q, expected key=value", entry)
+		}
+
+		if !strings.HasPrefix(key, "config.") && !slices.Contains(scheduledTaskFilterKeys, key) {
+			return nil, fmt.Errorf("Invalid filter key %q", key)
+		}
+
+		if key == "name" {
+			_, err := path.Match(value, "")
+			if err != nil {
+				return nil, fmt.Errorf("Invalid name pattern %q: %w", value, err)
+			}
+		}
+
+		filters[key] = value
+	}
+
+	return filters, nil
+}
+
+// scheduledTaskValidate validates the fields of a scheduled task.
+func scheduledTaskValidate(req *api.ScheduledTaskPut) error {
+	err := validate.Required(validate.IsCron([]string{"@hourly", "@daily", "@midnight", "@weekly", "@monthly", "@annually", "@yearly"}))(req.Schedule)
+	if err != nil {
+		return fmt.Errorf("Invalid schedule: %w", err)
+	}
+
+	_, err = scheduledTaskParseFilter(req.Filter)
+	if err != nil {
+		return err
+	}
+
+	rules := map[string]func(value string) error{}
+
+	switch req.Action {
+	case scheduledTaskActionSnapshot:
+		// gendoc:generate(entity=scheduled_task, group=common, key=snapshot.expiry)
+		//
+		// ---
+		//  type: string
+		//  required: no
+		//  defaultdesc: `snapshots.expiry` of the instance
+		//  shortdesc: When snapshots are to be deleted (`snapshot` action)
+		//  longdesc: Specify an expression like `1M 2H 3d 4w 5m 6y`.
+		rules["snapshot.expiry"] = func(value string) error {
+			_, err := internalInstance.GetExpiry(time.Time{}, value)
+			return err
+		}
+
+		// gendoc:generate(entity=scheduled_task, group=common, key=snapshot.stopped)
+		//
+		// ---
+		//  type: bool
+		//  required: no
+		//  defaultdesc: `false`
+		//  shortdesc: Whether to also snapshot stopped instances (`snapshot` action)
+		rules["snapshot.stopped"] = validate.Optional(validate.IsBool)
+
+	case scheduledTaskActionBackup:
+		// gendoc:generate(entity=scheduled_task, group=common, key=backup.expiry)
+		//
+		// ---
+		//  type: string
+		//  required: no
+		//  shortdesc: When backups are to be deleted (`backup` action)
+		//  longdesc: Specify an expression like `1M 2H 3d 4w 5m 6y`.
+		rules["backup.expiry"] = func(value string) error {
+			_, err := internalInstance.GetExpiry(time.Time{}, value)
+			return err
+		}
+
+		// gendoc:generate(entity=scheduled_task, group=common, key=backup.instance_only)
+		//
+		// ---
+		//  type: bool
+		//  required: no
+		//  defaultdesc: `false`
+		//  shortdesc: Whether to leave the snapshots out of the backups (`backup` action)
+		rules["backup.instance_only"] = validate.Optional(validate.IsBool)
+
+		// gendoc:generate(entity=scheduled_task, group=common, key=backup.compression_algorithm)
+		//
+		// ---
+		//  type: string
+		//  required: no
+		//  defaultdesc: `backups.compression_algorithm` server option
+		//  shortdesc: Compression algorithm of the backups (`backup` action)
+		rules["backup.compression_algorithm"] = validate.Optional(validate.IsCompressionAlgorithm)
+
+	case scheduledTaskActionExec:
+		// gendoc:generate(entity=scheduled_task, group=common, key=exec.command)
+		//
+		// ---
+		//  type: string
+		//  required: yes
+		//  shortdesc: Command to run in the running instances through `/bin/sh -c` (`exec` action)
+		rules["exec.command"] = validate.IsNotEmpty
+
+	case scheduledTaskActionWebhook:
+		// gendoc:generate(entity=scheduled_task, group=common, key=webhook.url)
+		//
+		// ---
+		//  type: string
+		//  required: yes
+		//  shortdesc: URL that a `POST` request is sent to (`webhook` action)
+		rules["webhook.url"] = validate.IsRequestURL
+
+	case scheduledTaskActionImageRefresh:
+
+	default:
+		return fmt.Errorf("Invalid action %q", req.Action)
+	}
+
+	// Only the instance actions take a filter.
+	if req.Filter != "" && (req.Action == scheduledTaskActionImageRefresh || req.Action == scheduledTaskActionWebhook) {
+		return fmt.Errorf("The %q action doesn't support filters", req.Action)
+	}
+
+	for k, v := range req.Config {
+		// User keys are free for all.
+		if strings.HasPrefix(k, "user.") {
+			continue
+		}
+
+		validator, ok := rules[k]
+		if !ok {
+			return fmt.Errorf("Invalid option %q for the %q action", k, req.Action)
+		}
+
+		err := validator(v)
+		if err != nil {
+			return fmt.Errorf("Invalid value for option %q: %w", k, err)
+		}
+	}
+
+	// Check the required keys.
+	for k, validator := range rules {
+		_, ok := req.Config[k]
+		if ok {
+			continue
+		}
+
+		err := validator("")
+		if err != nil {
+			return fmt.Errorf("Missing option %q for the %q action", k, req.Action)
+		}
+	}
+
+	return nil
+}
+
+// swagger:operation GET /1.0/scheduled-tasks scheduled-tasks scheduled_tasks_get
+//
+//	Get the scheduled tasks
+//
+//	Returns a list of scheduled tasks (URLs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: filter
+//	    description: Collection filter
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of endpoints
+//	          items:
+//	            type: string
+//	          example: |-
+//	            [
+//	              "/1.0/scheduled-tasks/nightly-snapshots",
+//	              "/1.0/scheduled-tasks/weekly-backups"
+//	            ]
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/scheduled-tasks?recursion=1 scheduled-tasks scheduled_tasks_get_recursion1
+//
+//	Get the scheduled tasks
+//
+//	Returns a list of scheduled tasks (structs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: filter
+//	    description: Collection filter
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of scheduled tasks
+//	          items:
+//	            $ref: "#/definitions/ScheduledTask"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func scheduledTasksGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	recursion := localUtil.IsRecursionRequest(r)
+
+	// Parse filter value.
+	filterStr := r.FormValue("filter")
+	clauses, err := filter.Parse(filterStr, filter.QueryOperatorSet())
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid filter: %w", err))
+	}
+
+	mustLoadObjects := recursion || (clauses != nil && len(clauses.Clauses) > 0)
+
+	linkResults := []string{}
+	fullResults := []api.ScheduledTask{}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		names, err := tx.GetScheduledTaskNames(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, name := range names {
+			if mustLoadObjects {
+				_, scheduledTask, err := tx.GetScheduledTask(ctx, name)
+				if err != nil {
+					return err
+				}
+
+				if clauses != nil && len(clauses.Clauses) > 0 {
+					match, err := filter.Match(*scheduledTask, *clauses)
+					if err != nil {
+						return err
+					}
+
+					if !match {
+						continue
+					}
+				}
+
+				fullResults = append(fullResults, *scheduledTask)
+			}
+
+			linkResults = append(linkResults, api.NewURL().Path(version.APIVersion, "scheduled-tasks", name).String())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if !recursion {
+		return response.SyncResponse(true, linkResults)
+	}
+
+	return response.SyncResponse(true, fullResults)
+}
+
+// swagger:operation POST /1.0/scheduled-tasks scheduled-tasks scheduled_tasks_post
+//
+//	Add a scheduled task
+//
+//	Creates a new scheduled task.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: task
+//	    description: Scheduled task
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ScheduledTasksPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func scheduledTasksPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	req := api.ScheduledTasksPost{}
+
+	// Parse the request into a record.
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = validate.IsURLSegmentSafe(req.Name)
+	if err != nil || req.Name == "" {
+		return response.BadRequest(fmt.Errorf("Invalid scheduled task name %q", req.Name))
+	}
+
+	err = scheduledTaskValidate(&req.ScheduledTaskPut)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		_, _, err := tx.GetScheduledTask(ctx, req.Name)
+		if err == nil {
+			return api.StatusErrorf(http.StatusConflict, "The scheduled task already exists")
+		}
+
+		_, err = tx.CreateScheduledTask(ctx, &req)
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	lc := lifecycle.ScheduledTaskCreated.Event(req.Name, request.CreateRequestor(r), nil)
+	s.Events.SendLifecycle(api.ProjectDefaultName, lc)
+
+	return response.SyncResponseLocation(true, nil, lc.Source)
+}
+
+// swagger:operation DELETE /1.0/scheduled-tasks/{name} scheduled-tasks scheduled_task_delete
+//
+//	Delete the scheduled task
+//
+//	Removes the scheduled task and its run history.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func scheduledTaskDelete(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		id, _, err := tx.GetScheduledTask(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		return tx.DeleteScheduledTask(ctx, id)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.ScheduledTaskDeleted.Event(name, request.CreateRequestor(r), nil))
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation GET /1.0/scheduled-tasks/{name} scheduled-tasks scheduled_task_get
+//
+//	Get the scheduled task
+//
+//	Gets a specific scheduled task, along with its most recent runs.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: Scheduled task
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ScheduledTask"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func scheduledTaskGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	var scheduledTask *api.ScheduledTask
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		_, scheduledTask, err = tx.GetScheduledTask(ctx, name)
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponseETag(true, scheduledTask, scheduledTask.Writable())
+}
+
+// swagger:operation PATCH /1.0/scheduled-tasks/{name} scheduled-tasks scheduled_task_patch
+//
+//	Partially update the scheduled task
+//
+//	Updates a subset of the scheduled task configuration.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: task
+//	    description: Scheduled task configuration
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ScheduledTaskPut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation PUT /1.0/scheduled-tasks/{name} scheduled-tasks scheduled_task_put
+//
+//	Update the scheduled task
+//
+//	Updates the entire scheduled task configuration.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: task
+//	    description: Scheduled task configuration
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ScheduledTaskPut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func scheduledTaskPut(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.ScheduledTaskPut{}
+
+	// Decode the request.
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		id, scheduledTask, err := tx.GetScheduledTask(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		// Validate the ETag.
+		err = localUtil.EtagCheck(r, scheduledTask.Writable())
+		if err != nil {
+			return api.StatusErrorf(http.StatusPreconditionFailed, "%v", err)
+		}
+
+		if r.Method == http.MethodPatch {
+			// Only update the fields and config keys present in the request.
+			if req.Description == "" {
+				req.Description = scheduledTask.Description
+			}
+
+			if req.Schedule == "" {
+				req.Schedule = scheduledTask.Schedule
+			}
+
+			if req.Action == "" {
+				req.Action = scheduledTask.Action
+			}
+
+			if req.Filter == "" {
+				req.Filter = scheduledTask.Filter
+			}
+
+			if req.Config == nil {
+				req.Config = map[string]string{}
+			}
+
+			for k, v := range scheduledTask.Config {
+				_, ok := req.Config[k]
+				if !ok {
+					req.Config[k] = v
+				}
+			}
+		}
+
+		err = scheduledTaskValidate(&req)
+		if err != nil {
+			return api.StatusErrorf(http.StatusBadRequest, "%v", err)
+		}
+
+		return tx.UpdateScheduledTask(ctx, id, &req)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.ScheduledTaskUpdated.Event(name, request.CreateRequestor(r), nil))
+
+	return response.EmptySyncResponse
+}
+
+// scheduledTaskInstances returns the instances of the local member matching the filter of a scheduled task.
+func scheduledTaskInstances(ctx context.Context, s *state.State, filter string) ([]instance.Instance, error) {
+	filters, err := scheduledTaskParseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	instFilter := dbCluster.InstanceFilter{Node: &s.ServerName}
+
+	projectName, ok := filters["project"]
+	if ok {
+		instFilter.Project = &projectName
+	}
+
+	var instances []instance.Instance
+
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.InstanceList(ctx, func(dbInst db.InstanceArgs, p api.Project) error {
+			pattern, ok := filters["name"]
+			if ok {
+				match, _ := path.Match(pattern, dbInst.Name)
+				if !match {
+					return nil
+				}
+			}
+
+			instType, ok := filters["type"]
+			if ok && instType != dbInst.Type.String() {
+				return nil
+			}
+
+			inst, err := instance.Load(s, dbInst, p)
+			if err != nil {
+				return fmt.Errorf("Failed loading instance %q (project %q) for scheduled task: %w", dbInst.Name, dbInst.Project, err)
+			}
+
+			status, ok := filters["status"]
+			if ok && !strings.EqualFold(status, inst.State()) {
+				return nil
+			}
+
+			for key, value := range filters {
+				configKey, found := strings.CutPrefix(key, "config.")
+				if found && inst.ExpandedConfig()[configKey] != value {
+					return nil
+				}
+			}
+
+			instances = append(instances, inst)
+
+			return nil
+		}, instFilter)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return instances, nil
+}
+
+// scheduledTaskRunInstances runs a function on each of the instances matching the filter of a scheduled task.
+// The failures don't prevent the function from running on the other instances.
+func scheduledTaskRunInstances(ctx context.Context, s *state.State, scheduledTask *api.ScheduledTask, f func(inst instance.Instance) error) (string, error) {
+	instances, err := scheduledTaskInstances(ctx, s, scheduledTask.Filter)
+	if err != nil {
+		return "", err
+	}
+
+	var errs []error
+	for _, inst := range instances {
+		err := ctx.Err()
+		if err != nil {
+			return "", err
+		}
+
+		err = f(inst)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Instance %q in project %q: %w", inst.Name(), inst.Project().Name, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return "", fmt.Errorf("Failed on %d out of %d instances: %w", len(errs), len(instances), errors.Join(errs...))
+	}
+
+	return fmt.Sprintf("Processed %d instances", len(instances)), nil
+}
+
+// scheduledTaskRun runs the action of a scheduled task on the local member and returns a summary of what it did.
+func scheduledTaskRun(ctx context.Context, s *state.State, scheduledTask *api.ScheduledTask, op *operations.Operation) (string, error) {
+	now := time.Now()
+
+	switch scheduledTask.Action {
+	case scheduledTaskActionSnapshot:
+		return scheduledTaskRunInstances(ctx, s, scheduledTask, func(inst instance.Instance) error {
+			if util.IsFalseOrEmpty(scheduledTask.Config["snapshot.stopped"]) && !inst.IsRunning() {
+				return nil
+			}
+
+			snapshotName, err := instance.NextSnapshotName(s, inst, "snap%d")
+			if err != nil {
+				return err
+			}
+
+			expiry := scheduledTask.Config["snapshot.expiry"]
+			if expiry == "" {
+				expiry = inst.ExpandedConfig()["snapshots.expiry"]
+			}
+
+			expiryDate, err := internalInstance.GetExpiry(now, expiry)
+			if err != nil {
+				return err
+			}
+
+			return inst.Snapshot(snapshotName, expiryDate, false)
+		})
+
+	case scheduledTaskActionBackup:
+		expiryDate, err := internalInstance.GetExpiry(now, scheduledTask.Config["backup.expiry"])
+		if err != nil {
+			return "", err
+		}
+
+		return scheduledTaskRunInstances(ctx, s, scheduledTask, func(inst instance.Instance) error {
+			args := db.InstanceBackup{
+				Name:                 inst.Name() + internalInstance.SnapshotDelimiter + scheduledTask.Name + "-" + now.Format("20060102-1504"),
+				InstanceID:           inst.ID(),
+				CreationDate:         now,
+				ExpiryDate:           expiryDate,
+				InstanceOnly:         util.IsTrue(scheduledTask.Config["backup.instance_only"]),
+				CompressionAlgorithm: scheduledTask.Config["backup.compression_algorithm"],
+			}
+
+			return backupCreate(s, args, inst, op)
+		})
+
+	case scheduledTaskActionExec:
+		return scheduledTaskRunInstances(ctx, s, scheduledTask, func(inst instance.Instance) error {
+			if !inst.IsRunning() {
+				return nil
+			}
+
+			devNull, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
+			if err != nil {
+				return err
+			}
+
+			defer func() { _ = devNull.Close() }()
+
+			cmd, err := inst.Exec(api.InstanceExecPost{Command: []string{"/bin/sh", "-c", scheduledTask.Config["exec.command"]}}, devNull, devNull, devNull)
+			if err != nil {
+				return err
+			}
+
+			exitStatus, err := cmd.Wait()
+			if err != nil {
+				return err
+			}
+
+			if exitStatus != 0 {
+				return fmt.Errorf("Command exited with status %d", exitStatus)
+			}
+
+			return nil
+		})
+
+	case scheduledTaskActionImageRefresh:
+		imageTaskMu.Lock()
+		defer imageTaskMu.Unlock()
+
+		err := autoUpdateImages(ctx, s)
+		if err != nil {
+			return "", err
+		}
+
+		return "Refreshed the images", nil
+
+	case scheduledTaskActionWebhook:
+		body, err := json.Marshal(map[string]any{"name": scheduledTask.Name, "date": now, "location": s.ServerName})
+		if err != nil {
+			return "", err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, scheduledTask.Config["webhook.url"], bytes.NewReader(body))
+		if err != nil {
+			return "", err
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", version.UserAgent)
+
+		client := &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: &http.Transport{Proxy: s.Proxy},
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", err
+		}
+
+		_ = resp.Body.Close()
+
+		if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+			return "", fmt.Errorf("Webhook returned status %q", resp.Status)
+		}
+
+		return fmt.Sprintf("Webhook returned status %q", resp.Status), nil
+	}
+
+	return "", fmt.Errorf("Invalid action %q", scheduledTask.Action)
+}
+
+// scheduledTasksTask runs the scheduled tasks due in the current minute.
+// Instance actions apply to the instances of each member, while webhooks are only sent by the leader.
+func scheduledTasksTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		type dueTask struct {
+			id   int64
+			info *api.ScheduledTask
+		}
+
+		var dueTasks []dueTask
+
+		err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			names, err := tx.GetScheduledTaskNames(ctx)
+			if err != nil {
+				return err
+			}
+
+			for _, name := range names {
+				id, scheduledTask, err := tx.GetScheduledTask(ctx, name)
+				if err != nil {
+					return err
+				}
+
+				if snapshotIsScheduledNow(scheduledTask.Schedule, id) {
+					dueTasks = append(dueTasks, dueTask{id: id, info: scheduledTask})
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			logger.Error("Failed loading scheduled tasks", logger.Ctx{"err": err})
+			return
+		}
+
+		if len(dueTasks) == 0 {
+			return
+		}
+
+		isLeader := true
+		leader, err := s.Cluster.LeaderAddress()
+		if err == nil {
+			isLeader = leader == s.LocalConfig.ClusterAddress()
+		} else if !errors.Is(err, cluster.ErrNodeIsNotClustered) {
+			logger.Error("Failed to get leader cluster member address", logger.Ctx{"err": err})
+			return
+		}
+
+		for _, due := range dueTasks {
+			if due.info.Action == scheduledTaskActionWebhook && !isLeader {
+				continue
+			}
+
+			l := logger.AddContext(logger.Ctx{"task": due.info.Name, "action": due.info.Action})
+
+			var message string
+
+			opRun := func(op *operations.Operation) error {
+				var err error
+
+				message, err = scheduledTaskRun(ctx, s, due.info, op)
+
+				return err
+			}
+
+			status := api.ScheduledTaskStatusSuccess
+
+			op, err := operations.OperationCreate(s, "", operations.OperationClassTask, operationtype.ScheduledTaskRun, nil, map[string]any{"task": due.info.Name}, opRun, nil, nil, nil)
+			if err == nil {
+				l.Info("Running scheduled task")
+
+				err = op.Start()
+				if err == nil {
+					err = op.Wait(ctx)
+				}
+			}
+
+			if err != nil {
+				l.Error("Failed running scheduled task", logger.Ctx{"err": err})
+				status = api.ScheduledTaskStatusFailure
+				message = err.Error()
+			}
+
+			err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+				return tx.CreateScheduledTaskRun(ctx, due.id, time.Now(), status, message)
+			})
+			if err != nil {
+				l.Error("Failed recording scheduled task run", logger.Ctx{"err": err})
+			}
+
+			s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.ScheduledTaskRun.Event(due.info.Name, nil, map[string]any{"status": status}))
+		}
+	}
+
+	first := true
+	schedule := func() (time.Duration, error) {
+		interval := time.Minute
+
+		if first {
+			first = false
+			return interval, task.ErrSkip
+		}
+
+		return interval, nil
+	}
+
+	return f, schedule
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/cluster"
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/logstore"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+var serverLogsCmd = APIEndpoint{
+	Path: "logs",
+
+	Get: APIEndpointAction{Handler: serverLogsGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanViewPrivilegedEvents)},
+}
+
+// swagger:operation GET /1.0/logs server server_logs_get
+//
+//	Get the server log
+//
+//	Returns the messages logged by the servers, at the info level or more severe, during the last 7 days.
+//	On clusters, the messages of all the online members are returned unless a target is given.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: level
+//	    description: Least severe level of the messages (defaults to info)
+//	    type: string
+//	    example: warning
+//	  - in: query
+//	    name: since
+//	    description: Only return the messages logged since the given time (RFC3339)
+//	    type: string
+//	    example: 2021-03-23T17:38:37Z
+//	  - in: query
+//	    name: until
+//	    description: Only return the messages logged until the given time (RFC3339)
+//	    type: string
+//	    example: 2021-03-23T18:38:37Z
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of log messages
+//	          items:
+//	            $ref: "#/definitions/ServerLogEntry"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func serverLogsGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	filter := logstore.Filter{Level: logrus.InfoLevel}
+	args := incus.GetServerLogsArgs{Level: r.FormValue("level")}
+
+	if args.Level != "" {
+		level, err := logrus.ParseLevel(args.Level)
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid level %q: %w", args.Level, err))
+		}
+
+		filter.Level = level
+	}
+
+	for key, value := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if r.FormValue(key) == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, r.FormValue(key))
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid %q time: %w", key, err))
+		}
+
+		*value = t
+	}
+
+	args.Since = filter.Since
+	args.Until = filter.Until
+
+	entries, err := d.logStore.Read(filter)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	for i := range entries {
+		entries[i].Location = s.ServerName
+	}
+
+	// Only return the local messages to the other members and when targeting the local member.
+	if !s.ServerClustered || isClusterNotification(r) || request.QueryParam(r, "target") != "" {
+		return response.SyncResponse(true, entries)
+	}
+
+	var members []db.NodeInfo
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		members, err = tx.GetNodes(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed getting cluster members: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	localClusterAddress := s.LocalConfig.ClusterAddress()
+	offlineThreshold := s.GlobalConfig.OfflineThreshold()
+	networkCert := s.Endpoints.NetworkCert()
+
+	for _, member := range members {
+		if member.Address == localClusterAddress {
+			continue
+		}
+
+		if member.IsOffline(offlineThreshold) {
+			logger.Warn("Excluding offline member from server log", logger.Ctx{"member": member.Name, "address": member.Address})
+			continue
+		}
+
+		// Connect to the remote server. Use notify=true to only get the messages of the remote member.
+		client, err := cluster.Connect(member.Address, networkCert, s.ServerCert(), r, true)
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed connecting to member %q: %w", member.Name, err))
+		}
+
+		memberEntries, err := client.GetServerLogs(&args)
+		if err != nil {
+			logger.Warn("Failed getting server log from member", logger.Ctx{"member": member.Name, "err": err})
+			continue
+		}
+
+		entries = append(entries, memberEntries...)
+	}
+
+	slices.SortStableFunc(entries, func(a api.ServerLogEntry, b api.ServerLogEntry) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	return response.SyncResponse(true, entries)
+}
@@ -0,0 +1,595 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/internal/filter"
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/cluster"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	localUtil "github.com/lxc/incus/v6/internal/server/util"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+var sessionsCmd = APIEndpoint{
+	Path: "sessions",
+
+	Get: APIEndpointAction{Handler: sessionsGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+var sessionCmd = APIEndpoint{
+	Path: "sessions/{id}",
+
+	Get:    APIEndpointAction{Handler: sessionGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Delete: APIEndpointAction{Handler: sessionDelete, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+var sessionBlocksCmd = APIEndpoint{
+	Path: "session-blocks",
+
+	Get:  APIEndpointAction{Handler: sessionBlocksGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Post: APIEndpointAction{Handler: sessionBlocksPost, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+var sessionBlockCmd = APIEndpoint{
+	Path: "session-blocks/{username}",
+
+	Delete: APIEndpointAction{Handler: sessionBlockDelete, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+// sessionInfo returns the details of the session opened by a websocket request, and whether it should be tracked.
+func sessionInfo(d *Daemon, r *http.Request, c APIEndpoint, username string, protocol string) (api.Session, bool) {
+	info := api.Session{
+		Username: username,
+		Protocol: protocol,
+		Address:  r.RemoteAddr,
+		Type:     "websocket",
+		Location: d.serverName,
+	}
+
+	// Use the identity of the client for requests forwarded by other cluster members.
+	if protocol == "cluster" {
+		info.Username = r.Header.Get(request.HeaderForwardedUsername)
+		info.Protocol = r.Header.Get(request.HeaderForwardedProtocol)
+		info.Address = r.Header.Get(request.HeaderForwardedAddress)
+	}
+
+	switch c.Path {
+	case eventsCmd.Path:
+		info.Type = "events"
+		info.Description = "Listening for events"
+
+	case operationWebsocket.Path:
+		// Operation websockets are authenticated with a secret, use the identity of the operation requestor.
+		op, err := operations.OperationGetInternal(mux.Vars(r)["id"])
+		if err != nil {
+			return info, false
+		}
+
+		requestor := op.Requestor()
+		if requestor != nil {
+			info.Username = requestor.Username
+			info.Protocol = requestor.Protocol
+			info.Address = requestor.Address
+		}
+
+		info.Type = "operation"
+		info.Operation = op.URL()
+		info.Description = op.Type().Description()
+
+	default:
+		info.Description = r.URL.Path
+	}
+
+	// Connections between cluster members aren't client sessions.
+	if info.Username == "" || info.Protocol == "cluster" {
+		return info, false
+	}
+
+	return info, true
+}
+
+// swagger:operation GET /1.0/sessions sessions sessions_get
+//
+//	List the sessions
+//
+//	Returns a list of active client sessions (URLs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: filter
+//	    description: Collection filter
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of endpoints
+//	          items:
+//	            type: string
+//	          example: |-
+//	            [
+//	              "/1.0/sessions/0cc1d7b6-51b1-4dd9-9b6c-0b4a40b3a450",
+//	              "/1.0/sessions/5d1c84a3-3e7b-4f4e-b0b2-7c3b2fd3a1a4"
+//	            ]
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/sessions?recursion=1 sessions sessions_get_recursion1
+//
+//	Get the sessions
+//
+//	Returns a list of active client sessions (structs), across all cluster members.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: filter
+//	    description: Collection filter
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of sessions
+//	          items:
+//	            $ref: "#/definitions/Session"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func sessionsGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	recursion := localUtil.IsRecursionRequest(r)
+
+	// Parse filter value.
+	filterStr := r.FormValue("filter")
+	clauses, err := filter.Parse(filterStr, filter.QueryOperatorSet())
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid filter: %w", err))
+	}
+
+	sessions := d.sessions.List()
+
+	// Add the sessions of the other cluster members.
+	if s.ServerClustered && !isClusterNotification(r) {
+		notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAlive)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		err = notifier(func(client incus.InstanceServer) error {
+			memberSessions, err := client.GetSessions()
+			if err != nil {
+				return err
+			}
+
+			sessions = append(sessions, memberSessions...)
+
+			return nil
+		})
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
+	if clauses != nil && len(clauses.Clauses) > 0 {
+		filtered := make([]api.Session, 0, len(sessions))
+		for _, session := range sessions {
+			match, err := filter.Match(session, *clauses)
+			if err != nil {
+				return response.SmartError(err)
+			}
+
+			if match {
+				filtered = append(filtered, session)
+			}
+		}
+
+		sessions = filtered
+	}
+
+	if !recursion {
+		urls := make([]string, 0, len(sessions))
+		for _, session := range sessions {
+			urls = append(urls, api.NewURL().Path(version.APIVersion, "sessions", session.ID).String())
+		}
+
+		return response.SyncResponse(true, urls)
+	}
+
+	return response.SyncResponse(true, sessions)
+}
+
+// swagger:operation GET /1.0/sessions/{id} sessions session_get
+//
+//	Get the session
+//
+//	Gets a specific active client session.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: Session
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/Session"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func sessionGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	id, err := url.PathUnescape(mux.Vars(r)["id"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	session, err := d.sessions.Get(id)
+	if err == nil || !s.ServerClustered || isClusterNotification(r) {
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		return response.SyncResponse(true, session)
+	}
+
+	// Look for the session on the other cluster members.
+	notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAlive)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = notifier(func(client incus.InstanceServer) error {
+		memberSession, err := client.GetSession(id)
+		if err != nil {
+			if api.StatusErrorCheck(err, http.StatusNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		session = memberSession
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if session == nil {
+		return response.NotFound(fmt.Errorf("Session not found"))
+	}
+
+	return response.SyncResponse(true, session)
+}
+
+// swagger:operation DELETE /1.0/sessions/{id} sessions session_delete
+//
+//	Terminate the session
+//
+//	Closes the connection of an active client session.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func sessionDelete(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	id, err := url.PathUnescape(mux.Vars(r)["id"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = d.sessions.Terminate(id)
+	if err == nil || !s.ServerClustered || isClusterNotification(r) {
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		logger.Info("Terminated client session", logger.Ctx{"id": id, "requestor": request.CreateRequestor(r).Username})
+
+		return response.EmptySyncResponse
+	}
+
+	// Look for the session on the other cluster members.
+	notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAlive)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	found := false
+	err = notifier(func(client incus.InstanceServer) error {
+		err := client.DeleteSession(id)
+		if err != nil {
+			if api.StatusErrorCheck(err, http.StatusNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		found = true
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if !found {
+		return response.NotFound(fmt.Errorf("Session not found"))
+	}
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation GET /1.0/session-blocks sessions session_blocks_get
+//
+//	Get the blocked identities
+//
+//	Returns the identities whose requests are temporarily rejected.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of blocked identities
+//	          items:
+//	            $ref: "#/definitions/SessionBlock"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func sessionBlocksGet(d *Daemon, r *http.Request) response.Response {
+	return response.SyncResponse(true, d.sessions.Blocks())
+}
+
+// swagger:operation POST /1.0/session-blocks sessions session_blocks_post
+//
+//	Block an identity
+//
+//	Rejects the requests of an identity until the block expires and terminates its active sessions,
+//	on all cluster members.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: block
+//	    description: Blocked identity
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/SessionBlock"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func sessionBlocksPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	req := api.SessionBlock{}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Username == "" {
+		return response.BadRequest(fmt.Errorf("No identity provided"))
+	}
+
+	if !req.ExpiresAt.After(time.Now()) {
+		return response.BadRequest(fmt.Errorf("The block must expire in the future"))
+	}
+
+	if req.Username == request.CreateRequestor(r).Username && !isClusterNotification(r) {
+		return response.BadRequest(fmt.Errorf("Blocking your own identity isn't allowed"))
+	}
+
+	d.sessions.Block(req.Username, req.ExpiresAt)
+
+	if isClusterNotification(r) {
+		return response.EmptySyncResponse
+	}
+
+	logger.Warn("Blocked identity", logger.Ctx{"username": req.Username, "expiry": req.ExpiresAt, "requestor": request.CreateRequestor(r).Username})
+
+	// Block the identity on the other cluster members.
+	notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAlive)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = notifier(func(client incus.InstanceServer) error {
+		return client.CreateSessionBlock(req)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation DELETE /1.0/session-blocks/{username} sessions session_block_delete
+//
+//	Unblock an identity
+//
+//	Removes the block of an identity on all cluster members.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func sessionBlockDelete(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	username, err := url.PathUnescape(mux.Vars(r)["username"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = d.sessions.Unblock(username)
+	if err != nil && (isClusterNotification(r) || !s.ServerClustered) {
+		return response.SmartError(err)
+	}
+
+	if isClusterNotification(r) {
+		return response.EmptySyncResponse
+	}
+
+	// Remove the block from the other cluster members, which may still have it after a restart of this one.
+	found := err == nil
+
+	notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAlive)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = notifier(func(client incus.InstanceServer) error {
+		err := client.DeleteSessionBlock(username)
+		if err != nil {
+			if api.StatusErrorCheck(err, http.StatusNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		found = true
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if !found {
+		return response.NotFound(fmt.Errorf("Identity isn't blocked"))
+	}
+
+	logger.Info("Unblocked identity", logger.Ctx{"username": username, "requestor": request.CreateRequestor(r).Username})
+
+	return response.EmptySyncResponse
+}
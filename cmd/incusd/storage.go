@@ -87,6 +87,11 @@ func storageStartup(s *state.State) error {
 			return false
 		}
 
+		// Skip the pools which aren't defined on this member, like the ones created after it became a witness.
+		if pool.LocalStatus() == api.StoragePoolStatusUnknown {
+			return true
+		}
+
 		_, err = pool.Mount()
 		if err != nil {
 			logger.Error("Failed mounting storage pool", logger.Ctx{"pool": poolName, "err": err})
@@ -606,6 +606,24 @@ func storagePoolBucketPut(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	targetMember := request.QueryParam(r, "target")
+	memberSpecific := targetMember != ""
+
+	var bucket *db.StorageBucket
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		bucket, err = tx.GetStoragePoolBucket(ctx, pool.ID(), bucketProjectName, memberSpecific, bucketName)
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Validate the ETag.
+	err = localUtil.EtagCheck(r, bucket.Etag())
+	if err != nil {
+		return response.PreconditionFailed(err)
+	}
+
 	// Decode the request.
 	req := api.StorageBucketPut{}
 	err = json.NewDecoder(r.Body).Decode(&req)
@@ -614,18 +632,6 @@ func storagePoolBucketPut(d *Daemon, r *http.Request) response.Response {
 	}
 
 	if r.Method == http.MethodPatch {
-		targetMember := request.QueryParam(r, "target")
-		memberSpecific := targetMember != ""
-
-		var bucket *db.StorageBucket
-		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
-			bucket, err = tx.GetStoragePoolBucket(ctx, pool.ID(), bucketProjectName, memberSpecific, bucketName)
-			return err
-		})
-		if err != nil {
-			return response.SmartError(err)
-		}
-
 		// If config being updated via "patch" method, then merge all existing config with the keys that
 		// are present in the request config.
 		for k, v := range bucket.Config {
@@ -1203,6 +1209,33 @@ func storagePoolBucketKeyPut(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	targetMember := request.QueryParam(r, "target")
+	memberSpecific := targetMember != ""
+
+	var bucketKey *db.StorageBucketKey
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		bucket, err := tx.GetStoragePoolBucket(ctx, pool.ID(), bucketProjectName, memberSpecific, bucketName)
+		if err != nil {
+			return err
+		}
+
+		bucketKey, err = tx.GetStoragePoolBucketKey(ctx, bucket.ID, keyName)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Validate the ETag.
+	err = localUtil.EtagCheck(r, bucketKey.Etag())
+	if err != nil {
+		return response.PreconditionFailed(err)
+	}
+
 	// Decode the request.
 	req := api.StorageBucketKeyPut{}
 	err = json.NewDecoder(r.Body).Decode(&req)
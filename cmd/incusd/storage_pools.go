@@ -386,6 +386,16 @@ func storagePoolsPost(d *Daemon, r *http.Request) response.Response {
 
 		exists := false
 		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+			// Witnesses don't host any storage.
+			member, err := tx.GetNodeByName(ctx, targetNode)
+			if err != nil {
+				return err
+			}
+
+			if slices.Contains(member.Roles, db.ClusterRoleWitness) {
+				return api.StatusErrorf(http.StatusBadRequest, "Storage pools can't be defined on the witness member %q", targetNode)
+			}
+
 			_, err = tx.GetStoragePoolID(ctx, req.Name)
 			if err == nil {
 				exists = true
@@ -508,9 +518,18 @@ func storagePoolsPostCluster(ctx context.Context, s *state.State, pool *api.Stor
 	// Check that the pool is properly defined, fetch the node-specific configs and insert the global config.
 	var configs map[string]map[string]string
 	var poolID int64
+	var localWitness bool
 	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
 		var err error
 
+		// Witnesses don't host any storage.
+		member, err := tx.GetNodeByName(ctx, s.ServerName)
+		if err != nil {
+			return err
+		}
+
+		localWitness = slices.Contains(member.Roles, db.ClusterRoleWitness)
+
 		// Check that the pool was defined at all. Must come before partially created checks.
 		poolID, err = tx.GetStoragePoolID(ctx, req.Name)
 		if err != nil {
@@ -551,7 +570,7 @@ func storagePoolsPostCluster(ctx context.Context, s *state.State, pool *api.Stor
 	}
 
 	// Create notifier for other nodes to create the storage pool.
-	notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAll)
+	notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAllHosts)
 	if err != nil {
 		return err
 	}
@@ -564,13 +583,15 @@ func storagePoolsPostCluster(ctx context.Context, s *state.State, pool *api.Stor
 		nodeReq.Config[key] = value
 	}
 
-	updatedConfig, err := storagePoolCreateLocal(ctx, s, poolID, req, clientType)
-	if err != nil {
-		return err
-	}
+	if !localWitness {
+		updatedConfig, err := storagePoolCreateLocal(ctx, s, poolID, req, clientType)
+		if err != nil {
+			return err
+		}
 
-	req.Config = updatedConfig
-	logger.Debug("Created storage pool on local cluster member", logger.Ctx{"pool": req.Name})
+		req.Config = updatedConfig
+		logger.Debug("Created storage pool on local cluster member", logger.Ctx{"pool": req.Name})
+	}
 
 	// Strip node specific config keys from config. Very important so we don't forward node-specific config.
 	for _, k := range db.NodeSpecificStorageConfig {
@@ -978,6 +999,11 @@ func doStoragePoolUpdate(s *state.State, pool storagePools.Pool, req api.Storage
 		}
 	}
 
+	// Members which don't have the pool, like the witnesses, have nothing to update.
+	if clientType == clusterRequest.ClientTypeNotifier && pool.LocalStatus() == api.StoragePoolStatusUnknown {
+		return response.EmptySyncResponse
+	}
+
 	err = pool.Update(clientType, req.Description, req.Config, nil)
 	if err != nil {
 		return response.InternalError(err)
@@ -1094,7 +1120,8 @@ func storagePoolDelete(d *Daemon, r *http.Request) response.Response {
 		}
 	}
 
-	if pool.LocalStatus() != api.StoragePoolStatusPending {
+	// Members which don't have the pool, like the witnesses, have nothing to delete.
+	if pool.LocalStatus() != api.StoragePoolStatusPending && pool.LocalStatus() != api.StoragePoolStatusUnknown {
 		err = pool.Delete(clientType, nil)
 		if err != nil {
 			return response.InternalError(err)
@@ -45,6 +45,11 @@ func storagePoolDBCreate(ctx context.Context, s *state.State, poolName string, p
 }
 
 func storagePoolValidate(s *state.State, poolName string, driverName string, config map[string]string) error {
+	// Rootless daemons can only manage directories.
+	if s.OS.Rootless && driverName != "dir" {
+		return fmt.Errorf("Only the %q storage driver is supported in rootless mode", "dir")
+	}
+
 	poolType, err := storagePools.LoadByType(s, driverName)
 	if err != nil {
 		return err
@@ -427,16 +427,29 @@ func storagePoolVolumesGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
-	// Pre-fill UsedBy if using filtering.
+	// Pre-fill UsedBy if using filtering, skipping the volumes that the other fields rule out.
 	if clauses != nil && len(clauses.Clauses) > 0 {
-		for i, vol := range dbVolumes {
+		candidates := make([]*db.StorageVolume, 0, len(dbVolumes))
+		for _, vol := range dbVolumes {
+			match, err := filter.MayMatchExcept(newFilterStorageVolume(vol), *clauses, "used_by")
+			if err != nil {
+				return response.SmartError(err)
+			}
+
+			if !match {
+				continue
+			}
+
 			volumeUsedBy, err := storagePoolVolumeUsedByGet(s, requestProjectName, poolName, vol)
 			if err != nil {
 				return response.InternalError(err)
 			}
 
-			dbVolumes[i].UsedBy = project.FilterUsedBy(s.Authorizer, r, volumeUsedBy)
+			vol.UsedBy = project.FilterUsedBy(s.Authorizer, r, volumeUsedBy)
+			candidates = append(candidates, vol)
 		}
+
+		dbVolumes = candidates
 	}
 
 	// Filter the results.
@@ -512,15 +525,23 @@ func storagePoolVolumesGet(d *Daemon, r *http.Request) response.Response {
 	return response.SyncResponse(true, urls)
 }
 
-// filterVolumes returns a filtered list of volumes that match the given clauses.
-func filterVolumes(volumes []*db.StorageVolume, clauses *filter.ClauseSet, allProjects bool, filterProjectImages []string) ([]*db.StorageVolume, error) {
-	// FilterStorageVolume is for filtering purpose only.
-	// It allows to filter snapshots by using default filter mechanism.
-	type FilterStorageVolume struct {
-		api.StorageVolume `yaml:",inline"`
-		Snapshot          string `yaml:"snapshot"`
+// filterStorageVolume is for filtering purpose only.
+// It allows to filter snapshots by using default filter mechanism.
+type filterStorageVolume struct {
+	api.StorageVolume `yaml:",inline"`
+	Snapshot          string `yaml:"snapshot"`
+}
+
+// newFilterStorageVolume returns the representation of the volume that filters are evaluated against.
+func newFilterStorageVolume(volume *db.StorageVolume) filterStorageVolume {
+	return filterStorageVolume{
+		StorageVolume: volume.StorageVolume,
+		Snapshot:      strconv.FormatBool(strings.Contains(volume.Name, internalInstance.SnapshotDelimiter)),
 	}
+}
 
+// filterVolumes returns a filtered list of volumes that match the given clauses.
+func filterVolumes(volumes []*db.StorageVolume, clauses *filter.ClauseSet, allProjects bool, filterProjectImages []string) ([]*db.StorageVolume, error) {
 	filtered := []*db.StorageVolume{}
 	for _, volume := range volumes {
 		// Filter out image volumes that are not used by this project.
@@ -528,12 +549,7 @@ func filterVolumes(volumes []*db.StorageVolume, clauses *filter.ClauseSet, allPr
 			continue
 		}
 
-		tmpVolume := FilterStorageVolume{
-			StorageVolume: volume.StorageVolume,
-			Snapshot:      strconv.FormatBool(strings.Contains(volume.Name, internalInstance.SnapshotDelimiter)),
-		}
-
-		match, err := filter.Match(tmpVolume, *clauses)
+		match, err := filter.Match(newFilterStorageVolume(volume), *clauses)
 		if err != nil {
 			return nil, err
 		}
@@ -647,6 +663,25 @@ func storagePoolVolumesPost(d *Daemon, r *http.Request) response.Response {
 		return resp
 	}
 
+	// Witnesses don't host any storage.
+	if s.ServerClustered {
+		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+			member, err := tx.GetNodeByName(ctx, s.ServerName)
+			if err != nil {
+				return err
+			}
+
+			if slices.Contains(member.Roles, db.ClusterRoleWitness) {
+				return api.StatusErrorf(http.StatusBadRequest, "Storage volumes can't be created on the witness member %q, target another member", s.ServerName)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	// If we're getting binary content, process separately.
 	if r.Header.Get("Content-Type") == "application/octet-stream" {
 		if r.Header.Get("X-Incus-type") == "iso" {
@@ -985,6 +1020,15 @@ func doVolumeMigration(s *state.State, r *http.Request, requestProjectName strin
 		}
 	}
 
+	// Have the partially migrated volume deleted if the server goes down during the migration. When refreshing,
+	// the volume may have existed before.
+	if !req.Source.Refresh {
+		err = op.SetCreated(map[string][]api.URL{"storage_volumes": {*api.NewURL().Path(version.APIVersion, "storage-pools", poolName, "volumes", "custom", req.Name).Project(projectName)}})
+		if err != nil {
+			return response.InternalError(err)
+		}
+	}
+
 	return operations.OperationResponse(op)
 }
 
@@ -11,6 +11,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/lxc/incus/v6/internal/filter"
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/jmap"
 	"github.com/lxc/incus/v6/internal/server/auth"
@@ -71,6 +72,11 @@ var storagePoolVolumeTypeCustomBackupExportCmd = APIEndpoint{
 //      description: Cluster member name
 //      type: string
 //      example: server01
+//    - in: query
+//      name: filter
+//      description: Collection filter
+//      type: string
+//      example: default
 //  responses:
 //    "200":
 //      description: API endpoints
@@ -125,6 +131,11 @@ var storagePoolVolumeTypeCustomBackupExportCmd = APIEndpoint{
 //	    description: Cluster member name
 //	    type: string
 //	    example: server01
+//	  - in: query
+//	    name: filter
+//	    description: Collection filter
+//	    type: string
+//	    example: default
 //	responses:
 //	  "200":
 //	    description: API endpoints
@@ -211,6 +222,13 @@ func storagePoolVolumeTypeCustomBackupsGet(d *Daemon, r *http.Request) response.
 
 	recursion := localUtil.IsRecursionRequest(r)
 
+	// Parse filter value.
+	filterStr := r.FormValue("filter")
+	clauses, err := filter.Parse(filterStr, filter.QueryOperatorSet())
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid filter: %w", err))
+	}
+
 	var volumeBackups []db.StoragePoolVolumeBackup
 
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
@@ -231,11 +249,23 @@ func storagePoolVolumeTypeCustomBackupsGet(d *Daemon, r *http.Request) response.
 	resultMap := []*api.StorageVolumeBackup{}
 
 	for _, backup := range backups {
+		render := backup.Render()
+
+		if clauses != nil && len(clauses.Clauses) > 0 {
+			match, err := filter.Match(*render, *clauses)
+			if err != nil {
+				return response.SmartError(err)
+			}
+
+			if !match {
+				continue
+			}
+		}
+
 		if !recursion {
 			url := api.NewURL().Path(version.APIVersion, "storage-pools", poolName, "volumes", "custom", volumeName, "backups", strings.Split(backup.Name(), "/")[1]).String()
 			resultString = append(resultString, url)
 		} else {
-			render := backup.Render()
 			resultMap = append(resultMap, render)
 		}
 	}
@@ -375,7 +375,25 @@ func warningPut(d *Daemon, r *http.Request) response.Response {
 		return response.Forbidden(fmt.Errorf(`Status may only be set to "acknowledge" or "new"`))
 	}
 
+	// An expiry may only be set on acknowledgments.
+	var acknowledgedUntil time.Time
+	if req.AcknowledgedUntil != nil {
+		if status != warningtype.StatusAcknowledged {
+			return response.BadRequest(fmt.Errorf("An acknowledgment expiry may only be set when acknowledging a warning"))
+		}
+
+		if !req.AcknowledgedUntil.After(time.Now()) {
+			return response.BadRequest(fmt.Errorf("The acknowledgment expiry must be in the future"))
+		}
+
+		acknowledgedUntil = *req.AcknowledgedUntil
+	}
+
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		if status == warningtype.StatusAcknowledged {
+			return tx.AcknowledgeWarning(id, acknowledgedUntil)
+		}
+
 		err := tx.UpdateWarningStatus(id, status)
 		if err != nil {
 			return err
@@ -0,0 +1,561 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/cluster"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/warningtype"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/network"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/secrets"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/internal/server/task"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// warningCheck returns whether the condition of a warning is still present.
+type warningCheck func(ctx context.Context, s *state.State, w dbCluster.Warning) (bool, error)
+
+// warningChecks are the checks of the warning types whose condition can clear without the subsystem raising them
+// noticing it, like instances started manually after failing to start automatically.
+var warningChecks = map[warningtype.Type]warningCheck{
+	warningtype.InstanceAutostartFailure:       warningCheckInstanceStopped,
+	warningtype.OfflineClusterMember:           warningCheckMemberOffline,
+	warningtype.ProxyBridgeNetfilterNotEnabled: warningCheckBridgeNetfilterDisabled,
+}
+
+// warningCheckInstanceStopped checks whether the instance which failed to start automatically is still stopped.
+func warningCheckInstanceStopped(ctx context.Context, s *state.State, w dbCluster.Warning) (bool, error) {
+	if w.EntityTypeCode != dbCluster.TypeInstance {
+		return true, nil
+	}
+
+	inst, err := instance.LoadByID(s, w.EntityID)
+	if err != nil {
+		if response.IsNotFoundError(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return !inst.IsRunning(), nil
+}
+
+// warningCheckMemberOffline checks whether the cluster member is still offline.
+func warningCheckMemberOffline(ctx context.Context, s *state.State, w dbCluster.Warning) (bool, error) {
+	if w.EntityTypeCode != dbCluster.TypeNode {
+		return true, nil
+	}
+
+	offline := false
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		members, err := tx.GetNodes(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, member := range members {
+			if member.ID == int64(w.EntityID) {
+				offline = member.IsOffline(s.GlobalConfig.OfflineThreshold())
+				break
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return offline, nil
+}
+
+// warningCheckBridgeNetfilterDisabled checks whether the bridge netfilter of the IP version of the warning is still disabled.
+func warningCheckBridgeNetfilterDisabled(ctx context.Context, s *state.State, w dbCluster.Warning) (bool, error) {
+	var ipVersion uint = 4
+	if strings.HasPrefix(w.LastMessage, "IPv6") {
+		ipVersion = 6
+	}
+
+	return network.BridgeNetfilterEnabled(ipVersion) != nil, nil
+}
+
+// warningsTask resolves the local warnings whose condition cleared, and on the leader, expires the acknowledgments
+// and notifies the new warnings.
+func warningsTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		err := resolveClearedWarnings(ctx, s)
+		if err != nil {
+			logger.Error("Failed resolving cleared warnings", logger.Ctx{"err": err})
+		}
+
+		leader, err := s.Cluster.LeaderAddress()
+		if err == nil {
+			if leader != s.LocalConfig.ClusterAddress() {
+				return
+			}
+		} else if !errors.Is(err, cluster.ErrNodeIsNotClustered) {
+			logger.Error("Failed to get leader cluster member address", logger.Ctx{"err": err})
+			return
+		}
+
+		err = expireWarningAcknowledgments(ctx, s)
+		if err != nil {
+			logger.Error("Failed expiring warning acknowledgments", logger.Ctx{"err": err})
+		}
+
+		err = notifyWarnings(ctx, s)
+		if err != nil {
+			logger.Error("Failed notifying warnings", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Every(time.Minute)
+}
+
+// resolveClearedWarnings resolves the unresolved local warnings whose condition cleared.
+func resolveClearedWarnings(ctx context.Context, s *state.State) error {
+	var warnings []dbCluster.Warning
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		localName, err := tx.GetLocalNodeName(ctx)
+		if err != nil {
+			return err
+		}
+
+		for typeCode := range warningChecks {
+			filter := dbCluster.WarningFilter{
+				Node:     &localName,
+				TypeCode: &typeCode,
+			}
+
+			typeWarnings, err := dbCluster.GetWarnings(ctx, tx.Tx(), filter)
+			if err != nil {
+				return err
+			}
+
+			for _, w := range typeWarnings {
+				if w.Status != warningtype.StatusResolved {
+					warnings = append(warnings, w)
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to get local warnings: %w", err)
+	}
+
+	for _, w := range warnings {
+		present, err := warningChecks[w.TypeCode](ctx, s, w)
+		if err != nil {
+			logger.Warn("Failed checking warning", logger.Ctx{"uuid": w.UUID, "type": w.TypeCode, "err": err})
+			continue
+		}
+
+		if present {
+			continue
+		}
+
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.UpdateWarningStatus(w.UUID, warningtype.StatusResolved)
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to resolve warning %q: %w", w.UUID, err)
+		}
+
+		logger.Info("Resolved cleared warning", logger.Ctx{"uuid": w.UUID, "type": w.TypeCode})
+	}
+
+	return nil
+}
+
+// expireWarningAcknowledgments makes the warnings whose acknowledgment expired new again.
+func expireWarningAcknowledgments(ctx context.Context, s *state.State) error {
+	return s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		statusAcknowledged := warningtype.StatusAcknowledged
+		filter := dbCluster.WarningFilter{
+			Status: &statusAcknowledged,
+		}
+
+		warnings, err := dbCluster.GetWarnings(ctx, tx.Tx(), filter)
+		if err != nil {
+			return fmt.Errorf("Failed to get acknowledged warnings: %w", err)
+		}
+
+		for _, w := range warnings {
+			if !w.AcknowledgedUntil.Valid || w.AcknowledgedUntil.Time.After(time.Now()) {
+				continue
+			}
+
+			err = tx.ExpireWarningAcknowledgment(w.UUID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// warningNotifyMaxAttempts is how many times a warning is sent to a channel before giving up.
+const warningNotifyMaxAttempts = 10
+
+// warningNotifyMaxBackoff caps the delay between the attempts of sending a warning to a channel.
+const warningNotifyMaxBackoff = time.Hour
+
+// Channels warnings are notified through.
+const (
+	warningChannelWebhook = "webhook"
+	warningChannelEmail   = "email"
+)
+
+// warningNotifyRetry is a warning which failed being sent to a channel.
+type warningNotifyRetry struct {
+	warning  api.Warning
+	attempts int
+	next     time.Time
+}
+
+// warningNotifyRetries tracks the warnings to send again, per channel, so that a failing channel doesn't cause
+// the warning to be sent again to the ones which succeeded. They are held in memory by the leader only.
+type warningNotifyRetries struct {
+	mu      sync.Mutex
+	pending map[string]map[string]*warningNotifyRetry
+}
+
+// newWarningNotifyRetries returns an empty set of warnings to send again.
+func newWarningNotifyRetries() *warningNotifyRetries {
+	return &warningNotifyRetries{pending: map[string]map[string]*warningNotifyRetry{}}
+}
+
+// warningsNotifyRetries holds the warnings which failed being sent.
+var warningsNotifyRetries = newWarningNotifyRetries()
+
+// warningNotifyBackoff returns the delay before the next attempt after the given number of failed ones,
+// doubling from a minute.
+func warningNotifyBackoff(attempts int) time.Duration {
+	backoff := time.Minute
+	for i := 1; i < attempts && backoff < warningNotifyMaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, warningNotifyMaxBackoff)
+}
+
+// failed records a failed attempt of sending the warning to the channel, returning false once it's given up on.
+func (r *warningNotifyRetries) failed(channel string, warning api.Warning, t time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pending[channel] == nil {
+		r.pending[channel] = map[string]*warningNotifyRetry{}
+	}
+
+	retry := r.pending[channel][warning.UUID]
+	if retry == nil {
+		retry = &warningNotifyRetry{}
+		r.pending[channel][warning.UUID] = retry
+	}
+
+	retry.warning = warning
+	retry.attempts++
+
+	if retry.attempts >= warningNotifyMaxAttempts {
+		delete(r.pending[channel], warning.UUID)
+		return false
+	}
+
+	retry.next = t.Add(warningNotifyBackoff(retry.attempts))
+
+	return true
+}
+
+// succeeded forgets about the warning sent to the channel.
+func (r *warningNotifyRetries) succeeded(channel string, uuid string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.pending[channel], uuid)
+}
+
+// due returns the warnings whose next attempt of being sent to the channel is due. The retries of the channel
+// are dropped if it's no longer enabled.
+func (r *warningNotifyRetries) due(channel string, enabled bool, t time.Time) []api.Warning {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !enabled {
+		delete(r.pending, channel)
+		return nil
+	}
+
+	warnings := []api.Warning{}
+	for _, retry := range r.pending[channel] {
+		if !retry.next.After(t) {
+			warnings = append(warnings, retry.warning)
+		}
+	}
+
+	return warnings
+}
+
+// notifyWarnings sends the new warnings to the configured webhook and email recipients.
+// Warnings below the configured severity are recorded as notified without being sent. Warnings are recorded as
+// notified once they were tried on all the channels, the ones which failed being sent to a channel being retried
+// for that channel only, with an increasing delay.
+func notifyWarnings(ctx context.Context, s *state.State) error {
+	webhookURL := s.GlobalConfig.WarningsWebhook()
+	emailTo, emailFrom, smtpAddress, _, _ := s.GlobalConfig.WarningsEmail()
+	emailEnabled := emailTo != "" && emailFrom != "" && smtpAddress != ""
+	minSeverity := warningtype.SeverityTypes[s.GlobalConfig.WarningsSeverity()]
+
+	channels := map[string]func(warning api.Warning) error{}
+
+	if webhookURL != "" {
+		channels[warningChannelWebhook] = func(warning api.Warning) error {
+			return warningSendWebhook(ctx, s, webhookURL, warning)
+		}
+	}
+
+	if emailEnabled {
+		channels[warningChannelEmail] = func(warning api.Warning) error {
+			return warningSendEmail(ctx, s, warning)
+		}
+	}
+
+	send := func(channel string, warning api.Warning) {
+		err := channels[channel](warning)
+		if err == nil {
+			warningsNotifyRetries.succeeded(channel, warning.UUID)
+			return
+		}
+
+		if !warningsNotifyRetries.failed(channel, warning, time.Now()) {
+			logger.Error("Giving up sending warning", logger.Ctx{"uuid": warning.UUID, "channel": channel, "err": err})
+			return
+		}
+
+		logger.Warn("Failed sending warning, will retry", logger.Ctx{"uuid": warning.UUID, "channel": channel, "err": err})
+	}
+
+	// Retry the warnings which previously failed being sent.
+	for _, channel := range []string{warningChannelWebhook, warningChannelEmail} {
+		_, enabled := channels[channel]
+		for _, warning := range warningsNotifyRetries.due(channel, enabled, time.Now()) {
+			send(channel, warning)
+		}
+	}
+
+	var warnings []api.Warning
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbWarnings, err := tx.GetUnnotifiedWarnings(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, dbWarning := range dbWarnings {
+			// Warnings nobody is to be notified of are simply recorded as notified.
+			if len(channels) == 0 || dbWarning.TypeCode.Severity() < minSeverity {
+				err = tx.SetWarningNotified(dbWarning.UUID)
+				if err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			warning := dbWarning.ToAPI()
+
+			warning.EntityURL, err = getWarningEntityURL(ctx, tx.Tx(), &dbWarning)
+			if err != nil {
+				logger.Debug("Failed getting warning entity URL", logger.Ctx{"uuid": dbWarning.UUID, "err": err})
+			}
+
+			warnings = append(warnings, warning)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range warnings {
+		for channel := range channels {
+			send(channel, warning)
+		}
+
+		err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.SetWarningNotified(warning.UUID)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// warningSendWebhook sends the warning as the JSON body of a POST request to the webhook.
+func warningSendWebhook(ctx context.Context, s *state.State, webhookURL string, warning api.Warning) error {
+	body, err := json.Marshal(warning)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", version.UserAgent)
+
+	client := &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: &http.Transport{Proxy: s.Proxy},
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("Webhook returned status %q", resp.Status)
+	}
+
+	return nil
+}
+
+// warningSendEmail sends the warning by email to the configured recipients.
+func warningSendEmail(ctx context.Context, s *state.State, warning api.Warning) error {
+	to, from, smtpAddress, smtpUsername, smtpPassword := s.GlobalConfig.WarningsEmail()
+
+	host, _, err := net.SplitHostPort(smtpAddress)
+	if err != nil {
+		return fmt.Errorf("Invalid SMTP server address %q: %w", smtpAddress, err)
+	}
+
+	dialer := net.Dialer{Timeout: 30 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", smtpAddress)
+	if err != nil {
+		return err
+	}
+
+	_ = conn.SetDeadline(time.Now().Add(time.Minute))
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	defer func() { _ = client.Close() }()
+
+	ok, _ := client.Extension("STARTTLS")
+	if ok {
+		err = client.StartTLS(&tls.Config{ServerName: host})
+		if err != nil {
+			return err
+		}
+	}
+
+	if smtpUsername != "" {
+		password, err := secrets.Resolve(ctx, smtpPassword)
+		if err != nil {
+			return fmt.Errorf("Failed resolving SMTP password: %w", err)
+		}
+
+		err = client.Auth(smtp.PlainAuth("", smtpUsername, password, host))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = client.Mail(from)
+	if err != nil {
+		return err
+	}
+
+	recipients := strings.Split(to, ",")
+	for i, recipient := range recipients {
+		recipients[i] = strings.TrimSpace(recipient)
+
+		err = client.Rcpt(recipients[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	location := warning.Location
+	if location == "" {
+		location = s.ServerName
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(recipients, ", "))
+	fmt.Fprintf(&msg, "Subject: [Incus] %s severity warning on %s: %s\r\n", warning.Severity, location, warning.Type)
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=utf-8\r\n")
+	fmt.Fprintf(&msg, "\r\n")
+	fmt.Fprintf(&msg, "UUID: %s\r\n", warning.UUID)
+	fmt.Fprintf(&msg, "Type: %s\r\n", warning.Type)
+	fmt.Fprintf(&msg, "Severity: %s\r\n", warning.Severity)
+	fmt.Fprintf(&msg, "Location: %s\r\n", location)
+
+	if warning.Project != "" {
+		fmt.Fprintf(&msg, "Project: %s\r\n", warning.Project)
+	}
+
+	if warning.EntityURL != "" {
+		fmt.Fprintf(&msg, "Entity: %s\r\n", warning.EntityURL)
+	}
+
+	fmt.Fprintf(&msg, "First seen: %s\r\n", warning.FirstSeenAt.Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Last seen: %s\r\n", warning.LastSeenAt.Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Occurrences: %d\r\n", warning.Count)
+	fmt.Fprintf(&msg, "\r\n%s\r\n", strings.ReplaceAll(warning.LastMessage, "\n", "\r\n"))
+
+	_, err = w.Write([]byte(msg.String()))
+	if err != nil {
+		return err
+	}
+
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
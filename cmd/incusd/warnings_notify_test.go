@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func TestWarningNotifyBackoff(t *testing.T) {
+	assert.Equal(t, time.Minute, warningNotifyBackoff(1))
+	assert.Equal(t, 2*time.Minute, warningNotifyBackoff(2))
+	assert.Equal(t, 32*time.Minute, warningNotifyBackoff(6))
+	assert.Equal(t, time.Hour, warningNotifyBackoff(7))
+	assert.Equal(t, time.Hour, warningNotifyBackoff(100))
+}
+
+func TestWarningNotifyRetries(t *testing.T) {
+	retries := newWarningNotifyRetries()
+	warning := api.Warning{UUID: "abc"}
+	now := time.Now()
+
+	// Only the failed channel is retried, once the backoff elapsed.
+	assert.True(t, retries.failed(warningChannelEmail, warning, now))
+	assert.Empty(t, retries.due(warningChannelWebhook, true, now.Add(time.Hour)))
+	assert.Empty(t, retries.due(warningChannelEmail, true, now))
+	assert.Equal(t, []api.Warning{warning}, retries.due(warningChannelEmail, true, now.Add(time.Minute)))
+
+	// Succeeding stops the retries.
+	retries.succeeded(warningChannelEmail, warning.UUID)
+	assert.Empty(t, retries.due(warningChannelEmail, true, now.Add(time.Hour)))
+
+	// Disabling the channel drops its retries.
+	assert.True(t, retries.failed(warningChannelEmail, warning, now))
+	assert.Empty(t, retries.due(warningChannelEmail, false, now.Add(time.Hour)))
+	assert.Empty(t, retries.due(warningChannelEmail, true, now.Add(time.Hour)))
+
+	// The warning is given up on after too many attempts.
+	for i := 1; i < warningNotifyMaxAttempts; i++ {
+		assert.True(t, retries.failed(warningChannelWebhook, warning, now))
+	}
+
+	assert.False(t, retries.failed(warningChannelWebhook, warning, now))
+	assert.Empty(t, retries.due(warningChannelWebhook, true, now.Add(time.Hour)))
+}
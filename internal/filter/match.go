@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -13,29 +14,7 @@ const stringMultiValueDelimiter = ","
 
 // Match returns true if the given object matches the given filter.
 func Match(obj any, set ClauseSet) (bool, error) {
-	if set.ParseInt == nil {
-		set.ParseInt = DefaultParseInt
-	}
-
-	if set.ParseUint == nil {
-		set.ParseUint = DefaultParseUint
-	}
-
-	if set.ParseString == nil {
-		set.ParseString = DefaultParseString
-	}
-
-	if set.ParseBool == nil {
-		set.ParseBool = DefaultParseBool
-	}
-
-	if set.ParseRegexp == nil {
-		set.ParseRegexp = DefaultParseRegexp
-	}
-
-	if set.ParseStringSlice == nil {
-		set.ParseStringSlice = DefaultParseStringSlice
-	}
+	set.setDefaults()
 
 	match := true
 
@@ -64,6 +43,92 @@ func Match(obj any, set ClauseSet) (bool, error) {
 	return match, nil
 }
 
+// MayMatch returns false if the given partially loaded object can't match the given filter, whatever the value
+// of its fields that aren't in the given list. The clauses on the other fields are considered as unknown, which
+// allows skipping objects before loading them fully.
+func MayMatch(obj any, set ClauseSet, fields ...string) (bool, error) {
+	return mayMatch(obj, set, func(field string) bool { return slices.Contains(fields, field) })
+}
+
+// MayMatchExcept is like MayMatch, with all the fields of the object being known except the given ones.
+func MayMatchExcept(obj any, set ClauseSet, fields ...string) (bool, error) {
+	return mayMatch(obj, set, func(field string) bool { return !slices.Contains(fields, field) })
+}
+
+func mayMatch(obj any, set ClauseSet, isKnown func(field string) bool) (bool, error) {
+	set.setDefaults()
+
+	// Three-valued logic, with a nil pointer for unknown.
+	known := func(b bool) *bool { return &b }
+	match := known(true)
+
+	for _, clause := range set.Clauses {
+		var clauseMatch *bool
+
+		if isKnown(clause.Field) {
+			value := ValueOf(obj, clause.Field)
+			m, err := set.match(clause, value)
+			if err != nil {
+				return false, err
+			}
+
+			if clause.Not {
+				m = !m
+			}
+
+			clauseMatch = known(m)
+		}
+
+		switch clause.PrevLogical {
+		case set.Ops.And:
+			if (match != nil && !*match) || (clauseMatch != nil && !*clauseMatch) {
+				match = known(false)
+			} else if match == nil || clauseMatch == nil {
+				match = nil
+			}
+
+		case set.Ops.Or:
+			if (match != nil && *match) || (clauseMatch != nil && *clauseMatch) {
+				match = known(true)
+			} else if match == nil || clauseMatch == nil {
+				match = nil
+			}
+
+		default:
+			return false, fmt.Errorf("unexpected clause operator")
+		}
+	}
+
+	return match == nil || *match, nil
+}
+
+// setDefaults sets the default parsing functions of the clause set.
+func (s *ClauseSet) setDefaults() {
+	if s.ParseInt == nil {
+		s.ParseInt = DefaultParseInt
+	}
+
+	if s.ParseUint == nil {
+		s.ParseUint = DefaultParseUint
+	}
+
+	if s.ParseString == nil {
+		s.ParseString = DefaultParseString
+	}
+
+	if s.ParseBool == nil {
+		s.ParseBool = DefaultParseBool
+	}
+
+	if s.ParseRegexp == nil {
+		s.ParseRegexp = DefaultParseRegexp
+	}
+
+	if s.ParseStringSlice == nil {
+		s.ParseStringSlice = DefaultParseStringSlice
+	}
+}
+
 // DefaultParseInt converts the value of the clause to int64.
 func DefaultParseInt(c Clause) (int64, error) {
 	return strconv.ParseInt(c.Value, 10, 0)
@@ -83,3 +83,40 @@ func TestMatch_Image(t *testing.T) {
 		})
 	}
 }
+
+func TestMayMatch(t *testing.T) {
+	// Only the name and location of the instance are known.
+	instance := api.Instance{
+		Name:     "c1",
+		Location: "server01",
+	}
+
+	cases := map[string]bool{
+		"name eq c1":                                     true,
+		"name eq c2":                                     false,
+		"not name eq c1":                                 false,
+		"status eq Running":                              true,
+		"name eq c2 and status eq Running":               false,
+		"status eq Running and name eq c2":               false,
+		"name eq c2 or status eq Running":                true,
+		"name eq c2 or location eq server02":             false,
+		"name eq c1 and location eq server02":            false,
+		"status eq Running or name eq c2":                true,
+		"location eq server01 and status eq Stopped":     true,
+		"name eq c2 or name eq c3 and status eq Running": false,
+	}
+
+	for s := range cases {
+		t.Run(s, func(t *testing.T) {
+			f, err := filter.Parse(s, filter.QueryOperatorSet())
+			require.NoError(t, err)
+			match, err := filter.MayMatch(instance, *f, "name", "location")
+			require.NoError(t, err)
+			assert.Equal(t, cases[s], match)
+
+			match, err = filter.MayMatchExcept(instance, *f, "status")
+			require.NoError(t, err)
+			assert.Equal(t, cases[s], match)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package instance
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// appArmorVariable matches references to AppArmor variables such as @{PROC}.
+var appArmorVariable = regexp.MustCompile(`@\{[A-Za-z0-9_]+\}`)
+
+// appArmorRuleQualifiers are the qualifiers which may prefix an AppArmor rule.
+var appArmorRuleQualifiers = []string{"allow", "audit", "deny", "owner"}
+
+// appArmorRuleTypes are the AppArmor rule types allowed in security.apparmor.extra_rules.
+var appArmorRuleTypes = []string{"capability", "dbus", "file", "mount", "network", "ptrace", "remount", "signal", "umount", "unix"}
+
+// AppArmorExtraRules parses the rules of security.apparmor.extra_rules.
+// Each non-empty line must hold a single rule of one of the supported types, terminated by a comma.
+// Comments, nested profiles, includes and profile transitions are rejected.
+func AppArmorExtraRules(value string) ([]string, error) {
+	rules := []string{}
+
+	for i, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if strings.ContainsAny(appArmorVariable.ReplaceAllString(line, ""), "{}#^\x00") {
+			return nil, fmt.Errorf("Invalid AppArmor rule on line %d: Comments, includes and nested profiles aren't allowed", i+1)
+		}
+
+		if !strings.HasSuffix(line, ",") || appArmorRuleCount(line) != 1 {
+			return nil, fmt.Errorf("Invalid AppArmor rule on line %d: Each line must hold a single rule terminated by a comma", i+1)
+		}
+
+		fields := strings.Fields(strings.TrimSuffix(line, ","))
+		for len(fields) > 1 && slices.Contains(appArmorRuleQualifiers, fields[0]) {
+			fields = fields[1:]
+		}
+
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("Invalid AppArmor rule on line %d: Missing rule", i+1)
+		}
+
+		// Paths are file rules.
+		ruleType := strings.TrimSuffix(fields[0], ",")
+		if !strings.HasPrefix(ruleType, "/") && !strings.HasPrefix(ruleType, "@{") && !slices.Contains(appArmorRuleTypes, ruleType) {
+			return nil, fmt.Errorf("Invalid AppArmor rule on line %d: Unsupported rule type %q", i+1, ruleType)
+		}
+
+		// Don't allow leaving the instance profile.
+		if slices.ContainsFunc(fields, appArmorIsTransition) {
+			return nil, fmt.Errorf("Invalid AppArmor rule on line %d: Profile transitions aren't allowed", i+1)
+		}
+
+		rules = append(rules, line)
+	}
+
+	return rules, nil
+}
+
+// appArmorRuleCount returns the number of rules on a line, ignoring commas within parentheses.
+func appArmorRuleCount(line string) int {
+	count := 0
+	depth := 0
+
+	for _, r := range line {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// appArmorFilePermissions are the characters of the permissions of a file rule, other than the exec qualifiers.
+const appArmorFilePermissions = "rwaklmx"
+
+// appArmorExecQualifiers are the qualifiers of the x permission (as in ix, px, Pix, cux or CUx) changing or dropping the profile.
+// Only ix (inherit) keeps the instance profile.
+const appArmorExecQualifiers = "pPcCuU"
+
+// appArmorIsTransition returns whether a rule field leads to a profile transition, either through an
+// explicit target (-> profile) or through an exec mode other than ix.
+func appArmorIsTransition(field string) bool {
+	if strings.Contains(field, "->") {
+		return true
+	}
+
+	// Only look at the permissions, holding nothing but permission characters.
+	if strings.Trim(field, appArmorFilePermissions+"i"+appArmorExecQualifiers) != "" || !strings.Contains(field, "x") {
+		return false
+	}
+
+	return strings.ContainsAny(field, appArmorExecQualifiers)
+}
+
+// ValidAppArmorExtraRules validates the value of security.apparmor.extra_rules.
+func ValidAppArmorExtraRules(value string) error {
+	_, err := AppArmorExtraRules(value)
+	return err
+}
@@ -0,0 +1,131 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppArmorIsTransition(t *testing.T) {
+	tests := map[string]bool{
+		// Permissions keeping the profile.
+		"r":     false,
+		"rw":    false,
+		"mr":    false,
+		"ix":    false,
+		"rix":   false,
+		"mrwlk": false,
+
+		// Exec modes changing or dropping the profile.
+		"px":   true,
+		"Px":   true,
+		"ux":   true,
+		"Ux":   true,
+		"cx":   true,
+		"Cx":   true,
+		"pix":  true,
+		"Pix":  true,
+		"cix":  true,
+		"Cix":  true,
+		"pux":  true,
+		"PUx":  true,
+		"cux":  true,
+		"CUx":  true,
+		"rPx":  true,
+		"mrCx": true,
+
+		// Explicit targets.
+		"->":           true,
+		"->unconfined": true,
+
+		// Other fields.
+		"/usr/bin/cux": false,
+		"call":         false,
+		"fstype=nfs":   false,
+		"capability":   false,
+	}
+
+	for field, expected := range tests {
+		t.Run(field, func(t *testing.T) {
+			assert.Equal(t, expected, appArmorIsTransition(field))
+		})
+	}
+}
+
+func TestAppArmorExtraRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		rules   []string
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			value: "",
+			rules: []string{},
+		},
+		{
+			name:  "supported rules",
+			value: "mount fstype=nfs,\n\n  dbus send bus=system,\n/usr/bin/foo rix,\n",
+			rules: []string{"mount fstype=nfs,", "dbus send bus=system,", "/usr/bin/foo rix,"},
+		},
+		{
+			name:  "qualified rule",
+			value: "audit deny owner @{PROC}/sys/** w,",
+			rules: []string{"audit deny owner @{PROC}/sys/** w,"},
+		},
+		{
+			name:    "missing comma",
+			value:   "mount fstype=nfs",
+			wantErr: true,
+		},
+		{
+			name:    "several rules on a line",
+			value:   "mount, umount,",
+			wantErr: true,
+		},
+		{
+			name:    "comment",
+			value:   "mount, # nfs",
+			wantErr: true,
+		},
+		{
+			name:    "nested profile",
+			value:   "profile foo { },",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported rule type",
+			value:   "change_profile -> unconfined,",
+			wantErr: true,
+		},
+		{
+			name:    "transition exec mode",
+			value:   "/usr/bin/foo Pix,",
+			wantErr: true,
+		},
+		{
+			name:    "unconfined exec mode",
+			value:   "/usr/bin/foo rUx,",
+			wantErr: true,
+		},
+		{
+			name:    "transition target",
+			value:   "/usr/bin/foo ix -> other,",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rules, err := AppArmorExtraRules(test.value)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.rules, rules)
+		})
+	}
+}
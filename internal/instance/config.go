@@ -31,6 +31,8 @@ var HugePageSizeSuffix = [...]string{"64KB", "1MB", "2MB", "1GB"}
 var InstanceConfigKeysAny = map[string]func(value string) error{
 	// gendoc:generate(entity=instance, group=boot, key=boot.autorestart)
 	// If set to `true` will attempt up to 10 restarts over a 1 minute period upon unexpected instance exit.
+	//
+	// This is equivalent to setting {config:option}`instance-restart:restart.policy` to `always` and is ignored if that option is set.
 	// ---
 	//  type: bool
 	//  liveupdate: no
@@ -63,6 +65,37 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: What order to start the instances in
 	"boot.autostart.priority": validate.Optional(validate.IsInt64),
 
+	// gendoc:generate(entity=instance, group=boot, key=boot.depends_on)
+	// Comma-separated list of instances in the same project that must be started before this instance
+	// when instances are automatically started (on daemon start or cluster member restore).
+	//
+	// This takes precedence over `boot.autostart.priority`.
+	// ---
+	//  type: string
+	//  liveupdate: no
+	//  shortdesc: Instances to start before this one
+	"boot.depends_on": validate.Optional(validate.IsListOf(validate.IsHostname)),
+
+	// gendoc:generate(entity=instance, group=boot, key=boot.depends_on.condition)
+	// What the instances listed in `boot.depends_on` must reach before this instance is started.
+	// One of `started`, `agent` (the VM agent is reachable, same as `started` for containers) or `network`
+	// (a global address is configured).
+	// ---
+	//  type: string
+	//  defaultdesc: `started`
+	//  liveupdate: no
+	//  shortdesc: Condition the dependencies must satisfy
+	"boot.depends_on.condition": validate.Optional(validate.IsOneOf("started", "agent", "network")),
+
+	// gendoc:generate(entity=instance, group=boot, key=boot.depends_on.timeout)
+	// Number of seconds to wait for the dependencies to satisfy `boot.depends_on.condition` before starting the instance anyway.
+	// ---
+	//  type: integer
+	//  defaultdesc: `60`
+	//  liveupdate: no
+	//  shortdesc: How long to wait for the dependencies
+	"boot.depends_on.timeout": validate.Optional(validate.IsUint32),
+
 	// gendoc:generate(entity=instance, group=boot, key=boot.stop.priority)
 	// The instance with the highest value is shut down first.
 	// ---
@@ -177,6 +210,18 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: What to do when evacuating the instance
 	"cluster.evacuate": validate.Optional(validate.IsOneOf("auto", "migrate", "live-migrate", "stop", "stateful-stop", "force-stop")),
 
+	// gendoc:generate(entity=instance, group=miscellaneous, key=cluster.rebalance.exclude)
+	// When set, the instance is never moved by the automatic cluster re-balancing.
+	// This is typically set through a profile shared by the instances to exclude.
+	//
+	// See {ref}`cluster-automatic-balancing` for more information.
+	// ---
+	//  type: bool
+	//  defaultdesc: `false`
+	//  liveupdate: yes
+	//  shortdesc: Whether to exclude the instance from automatic re-balancing
+	"cluster.rebalance.exclude": validate.Optional(validate.IsBool),
+
 	// gendoc:generate(entity=instance, group=resource-limits, key=limits.cpu)
 	// A number or a specific range of CPUs to expose to the instance.
 	//
@@ -259,6 +304,34 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: Whether to allow for stateful stop/start and snapshots
 	"migration.stateful": validate.Optional(validate.IsBool),
 
+	// gendoc:generate(entity=instance, group=placement, key=placement.affinity)
+	// Specify a comma-separated list of `group:<name>` or `instance:<name>` rules.
+	// The instance is placed on a cluster member that already runs one of the matching instances of the project, if any exists.
+	// See {ref}`instance-options-placement`.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  shortdesc: Instances to co-locate the instance with
+	"placement.affinity": validate.Optional(ValidPlacementRules),
+
+	// gendoc:generate(entity=instance, group=placement, key=placement.anti-affinity)
+	// Specify a comma-separated list of `group:<name>` or `instance:<name>` rules.
+	// The instance is placed on a cluster member that doesn't run any of the matching instances of the project.
+	// See {ref}`instance-options-placement`.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  shortdesc: Instances to spread the instance away from
+	"placement.anti-affinity": validate.Optional(ValidPlacementRules),
+
+	// gendoc:generate(entity=instance, group=placement, key=placement.group)
+	// Name of the placement group the instance is part of, as matched by `group:<name>` rules.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  shortdesc: Placement group of the instance
+	"placement.group": validate.Optional(validate.IsHostname),
+
 	// Caller is responsible for full validation of any raw.* value.
 
 	// gendoc:generate(entity=instance, group=raw, key=raw.apparmor)
@@ -278,6 +351,17 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: Raw idmap configuration
 	"raw.idmap": validate.IsAny,
 
+	// gendoc:generate(entity=instance, group=security, key=security.apparmor.extra_rules)
+	// One AppArmor rule per line (for example `mount fstype=nfs,` or `dbus send bus=system,`), appended to the generated profile.
+	// Unlike {config:option}`instance-raw:raw.apparmor`, the rules are validated: only `capability`, `dbus`, file, `mount`,
+	// `network`, `ptrace`, `remount`, `signal`, `umount` and `unix` rules are allowed, without includes, nested profiles or profile transitions.
+	// The rules are also checked by the AppArmor parser when set.
+	// ---
+	//  type: blob
+	//  liveupdate: yes
+	//  shortdesc: Additional AppArmor rules
+	"security.apparmor.extra_rules": ValidAppArmorExtraRules,
+
 	// gendoc:generate(entity=instance, group=security, key=security.guestapi)
 	// See {ref}`dev-incus` for more information.
 	// ---
@@ -296,6 +380,18 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: Prevents the instance from being deleted
 	"security.protection.delete": validate.Optional(validate.IsBool),
 
+	// gendoc:generate(entity=instance, group=restart, key=restart.policy)
+	// One of `never`, `on-failure` (restart after the instance crashed or was stopped by its watchdog)
+	// or `always` (restart whenever the instance stops without being asked to).
+	//
+	// See {ref}`instance-options-restart` for more information.
+	// ---
+	//  type: string
+	//  defaultdesc: `never` (`always` if `boot.autorestart` is enabled)
+	//  liveupdate: yes
+	//  shortdesc: When to automatically restart the instance
+	"restart.policy": validate.Optional(validate.IsOneOf("never", "on-failure", "always")),
+
 	// gendoc:generate(entity=instance, group=snapshots, key=snapshots.schedule)
 	// Specify either a cron expression (`<minute> <hour> <dom> <month> <dow>`), a comma-and-space-separated list of schedule aliases (`@startup`, `@hourly`, `@daily`, `@midnight`, `@weekly`, `@monthly`, `@annually`, `@yearly`), or leave empty to disable automatic snapshots.
 	//
@@ -400,6 +496,13 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: Instance marked itself as ready
 	"volatile.last_state.ready": validate.IsBool,
 
+	// gendoc:generate(entity=instance, group=volatile, key=volatile.last_state.start_duration)
+	//
+	// ---
+	//  type: integer
+	//  shortdesc: Time taken by the last start of the instance, in milliseconds
+	"volatile.last_state.start_duration": validate.Optional(validate.IsInt64),
+
 	// gendoc:generate(entity=instance, group=volatile, key=volatile.rebalance.last_move)
 	//
 	// ---
@@ -407,6 +510,13 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: Timestamp of last move by automatic live-migration
 	"volatile.rebalance.last_move": validate.Optional(validate.IsInt64),
 
+	// gendoc:generate(entity=instance, group=volatile, key=volatile.uptime)
+	// The time of the current run isn't included until the instance stops.
+	// ---
+	//  type: integer
+	//  shortdesc: Cumulative time the instance ran for, in seconds
+	"volatile.uptime": validate.Optional(validate.IsInt64),
+
 	// gendoc:generate(entity=instance, group=volatile, key=volatile.uuid)
 	// The instance UUID is globally unique across all servers and projects.
 	// ---
@@ -470,6 +580,20 @@ var InstanceConfigKeysContainer = map[string]func(value string) error{
 		return nil
 	},
 
+	// gendoc:generate(entity=instance, group=resource-limits, key=limits.cpu.partition)
+	// Set to `root` or `isolated` to turn the instance's CPU set into a cpuset partition,
+	// giving it exclusive use of its CPUs (`isolated` also removes them from scheduler load balancing).
+	// This requires `limits.cpu` to be set to specific CPUs and the unified cgroup hierarchy (cgroup v2).
+	//
+	// See {ref}`instance-options-limits-cpu-container` for more information.
+	// ---
+	//  type: string
+	//  defaultdesc: `member`
+	//  liveupdate: yes
+	//  condition: container
+	//  shortdesc: Whether the instance gets exclusive use of its CPUs
+	"limits.cpu.partition": validate.Optional(validate.IsOneOf("member", "root", "isolated")),
+
 	// gendoc:generate(entity=instance, group=resource-limits, key=limits.cpu.priority)
 	// When overcommitting resources, specify the CPU scheduling priority compared to other instances that share the same CPUs.
 	// Specify an integer between 0 and 10.
@@ -542,6 +666,30 @@ var InstanceConfigKeysContainer = map[string]func(value string) error{
 	//  shortdesc: Whether the memory limit is `hard` or `soft`
 	"limits.memory.enforce": validate.Optional(validate.IsOneOf("soft", "hard")),
 
+	// gendoc:generate(entity=instance, group=resource-limits, key=limits.memory.min)
+	// Fixed value in bytes of memory that is never reclaimed from the instance.
+	// Various suffixes are supported (see {ref}`instances-limit-units`).
+	//
+	// This requires the unified cgroup hierarchy (cgroup v2).
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  condition: container
+	//  shortdesc: Guaranteed amount of memory
+	"limits.memory.min": validate.Optional(validate.IsSize),
+
+	// gendoc:generate(entity=instance, group=resource-limits, key=limits.memory.low)
+	// Fixed value in bytes of memory that is only reclaimed from the instance when no unprotected memory can be reclaimed elsewhere.
+	// Various suffixes are supported (see {ref}`instances-limit-units`).
+	//
+	// This requires the unified cgroup hierarchy (cgroup v2).
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  condition: container
+	//  shortdesc: Best-effort memory protection
+	"limits.memory.low": validate.Optional(validate.IsSize),
+
 	// gendoc:generate(entity=instance, group=resource-limits, key=limits.memory.swap)
 	// When set to `true` or `false`, it controls whether the container is likely to get some of
 	// its memory swapped by the kernel. Alternatively, it can be set to a bytes value which will
@@ -575,6 +723,60 @@ var InstanceConfigKeysContainer = map[string]func(value string) error{
 	//  shortdesc: Maximum number of processes that can run in the instance
 	"limits.processes": validate.Optional(validate.IsInt64),
 
+	// gendoc:generate(entity=instance, group=miscellaneous, key=linux.cgroup.max_depth)
+	// Limits how deep the cgroup tree delegated to the container can be, for example to bound the nesting done by systemd.
+	// This requires the host to use cgroup2.
+	// ---
+	//  type: integer
+	//  defaultdesc: unlimited
+	//  liveupdate: yes
+	//  condition: container
+	//  shortdesc: Maximum depth of the delegated cgroup tree
+	"linux.cgroup.max_depth": validate.Optional(validate.IsUint32),
+
+	// gendoc:generate(entity=instance, group=miscellaneous, key=linux.hooks.mount)
+	// Specify the absolute path of a host executable, followed by its arguments.
+	// It is run as an LXC `mount` hook, in the container's mount namespace once its file systems are mounted.
+	// See {ref}`instance-options-lxc-hooks`.
+	// ---
+	//  type: string
+	//  liveupdate: no
+	//  condition: container
+	//  shortdesc: Host executable to run when the container's file systems are mounted
+	"linux.hooks.mount": validate.Optional(ValidLXCHook),
+
+	// gendoc:generate(entity=instance, group=miscellaneous, key=linux.hooks.post_stop)
+	// Specify the absolute path of a host executable, followed by its arguments.
+	// It is run as an LXC `post-stop` hook, on the host once the container has stopped.
+	// See {ref}`instance-options-lxc-hooks`.
+	// ---
+	//  type: string
+	//  liveupdate: no
+	//  condition: container
+	//  shortdesc: Host executable to run after the container stops
+	"linux.hooks.post_stop": validate.Optional(ValidLXCHook),
+
+	// gendoc:generate(entity=instance, group=miscellaneous, key=linux.hooks.pre_start)
+	// Specify the absolute path of a host executable, followed by its arguments.
+	// It is run as an LXC `pre-start` hook, on the host before the container's namespaces are created.
+	// See {ref}`instance-options-lxc-hooks`.
+	// ---
+	//  type: string
+	//  liveupdate: no
+	//  condition: container
+	//  shortdesc: Host executable to run before the container starts
+	"linux.hooks.pre_start": validate.Optional(ValidLXCHook),
+
+	// gendoc:generate(entity=instance, group=miscellaneous, key=linux.journal.host)
+	// When enabled, the host's systemd journal sockets are bind-mounted into `/dev/.incus-host/journal` in the container.
+	// ---
+	//  type: bool
+	//  defaultdesc: `false`
+	//  liveupdate: no
+	//  condition: container
+	//  shortdesc: Whether to expose the host journal sockets
+	"linux.journal.host": validate.Optional(validate.IsBool),
+
 	// gendoc:generate(entity=instance, group=miscellaneous, key=linux.kernel_modules)
 	// Specify the kernel modules as a comma-separated list.
 	// ---
@@ -584,6 +786,39 @@ var InstanceConfigKeysContainer = map[string]func(value string) error{
 	//  shortdesc: Kernel modules to load before starting the instance
 	"linux.kernel_modules": validate.IsAny,
 
+	// gendoc:generate(entity=instance, group=miscellaneous, key=linux.network_namespace)
+	// Name of another container in the same project whose network namespace is joined by this container, for example to run a sidecar.
+	// That container must be running when this one starts, and this container can't have any network device.
+	// When that container restarts, this container is restarted too so it joins the new network namespace.
+	// See {ref}`instance-options-network-namespace`.
+	// ---
+	//  type: string
+	//  liveupdate: no
+	//  condition: container
+	//  shortdesc: Container to share the network namespace of
+	"linux.network_namespace": validate.Optional(validate.IsHostname),
+
+	// gendoc:generate(entity=instance, group=miscellaneous, key=linux.systemd.notify)
+	// When enabled, the container's init is given a `NOTIFY_SOCKET` and starting the container waits until it reports `READY=1`,
+	// as systemd does once it finished booting.
+	// ---
+	//  type: bool
+	//  defaultdesc: `false`
+	//  liveupdate: no
+	//  condition: container
+	//  shortdesc: Whether to wait for the container's init to report ready when starting
+	"linux.systemd.notify": validate.Optional(validate.IsBool),
+
+	// gendoc:generate(entity=instance, group=miscellaneous, key=linux.systemd.notify_timeout)
+	// Number of seconds to wait for the readiness notification before stopping the container and failing the start operation.
+	// ---
+	//  type: integer
+	//  defaultdesc: `60`
+	//  liveupdate: yes
+	//  condition: container
+	//  shortdesc: How long to wait for the container's init to report ready
+	"linux.systemd.notify_timeout": validate.Optional(validate.IsUint32),
+
 	// gendoc:generate(entity=instance, group=migration, key=migration.incremental.memory)
 	// Using incremental memory transfer of the instance's memory can reduce downtime.
 	// ---
@@ -652,6 +887,16 @@ var InstanceConfigKeysContainer = map[string]func(value string) error{
 	//  shortdesc: Required driver version
 	"nvidia.require.driver": validate.IsAny,
 
+	// gendoc:generate(entity=instance, group=oci, key=oci.application)
+	// Name of the OCI application the container is part of.
+	// This is used by `incus app` to group the containers of an application.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  condition: OCI container
+	//  shortdesc: OCI application name
+	"oci.application": validate.Optional(validate.IsHostname),
+
 	// gendoc:generate(entity=instance, group=oci, key=oci.entrypoint)
 	// Override the entry point of an OCI container.
 	// ---
@@ -961,6 +1206,43 @@ var InstanceConfigKeysContainer = map[string]func(value string) error{
 
 // InstanceConfigKeysVM is a map of config key to validator. (keys applying to VM only).
 var InstanceConfigKeysVM = map[string]func(value string) error{
+	// gendoc:generate(entity=instance, group=resource-limits, key=limits.cpu.model)
+	// The name of the CPU model to expose to the guest as listed by `qemu-system-x86_64 -cpu help` (for example, `EPYC-v4` or `Skylake-Server-v5`).
+	// Using a named model rather than the host CPU allows for live migration between servers with different CPUs.
+	//
+	// See {ref}`instance-options-limits-cpu-model` for more information.
+	// ---
+	//  type: string
+	//  defaultdesc: `host`
+	//  liveupdate: no
+	//  condition: virtual machine
+	//  shortdesc: CPU model to expose to the guest
+	"limits.cpu.model": validate.Optional(func(value string) error {
+		if strings.ContainsAny(value, ",= ") {
+			return fmt.Errorf("CPU model names can't contain commas, equal signs or spaces")
+		}
+
+		return nil
+	}),
+
+	// gendoc:generate(entity=instance, group=resource-limits, key=limits.cpu.flags)
+	// A comma-separated list of CPU flags to add to the CPU model, prefix a flag with `-` to remove it instead.
+	//
+	// See {ref}`instance-options-limits-cpu-model` for more information.
+	// ---
+	//  type: string
+	//  liveupdate: no
+	//  condition: virtual machine
+	//  shortdesc: CPU flags to add to or remove from the CPU model
+	"limits.cpu.flags": validate.Optional(validate.IsListOf(func(value string) error {
+		flag := strings.TrimPrefix(strings.TrimPrefix(value, "-"), "+")
+		if flag == "" || strings.ContainsAny(flag, "+= ") {
+			return fmt.Errorf("Invalid CPU flag %q", value)
+		}
+
+		return nil
+	})),
+
 	// gendoc:generate(entity=instance, group=resource-limits, key=limits.memory.hugepages)
 	// If this option is set to `false`, regular system memory is used.
 	// ---
@@ -1027,6 +1309,30 @@ var InstanceConfigKeysVM = map[string]func(value string) error{
 	//  shortdesc: QEMU scriptlet to run at early, pre-start and post-start stages
 	"raw.qemu.scriptlet": validate.Optional(scriptletLoad.QEMUValidate),
 
+	// gendoc:generate(entity=instance, group=restart, key=restart.watchdog)
+	// Set to `agent` to have Incus periodically check that the VM agent responds, or to `hardware`
+	// to add an emulated hardware watchdog device that the guest must keep feeding.
+	// When the watchdog fires, the VM is stopped and treated as failed.
+	//
+	// See {ref}`instance-options-restart` for more information.
+	// ---
+	//  type: string
+	//  defaultdesc: `none`
+	//  liveupdate: no
+	//  condition: virtual machine
+	//  shortdesc: Watchdog used to detect a hung instance
+	"restart.watchdog": validate.Optional(validate.IsOneOf("none", "agent", "hardware")),
+
+	// gendoc:generate(entity=instance, group=restart, key=restart.watchdog.timeout)
+	// Number of seconds the VM agent can be unresponsive before the `agent` watchdog fires.
+	// ---
+	//  type: integer
+	//  defaultdesc: `60`
+	//  liveupdate: no
+	//  condition: virtual machine
+	//  shortdesc: Timeout of the agent watchdog
+	"restart.watchdog.timeout": validate.Optional(validate.IsUint32),
+
 	// gendoc:generate(entity=instance, group=security, key=security.agent.metrics)
 	//
 	// ---
@@ -1118,6 +1424,26 @@ var InstanceConfigKeysVM = map[string]func(value string) error{
 	//  shortdesc: Whether to use the name and MTU of the default network interfaces
 	"agent.nic_config": validate.Optional(validate.IsBool),
 
+	// gendoc:generate(entity=instance, group=miscellaneous, key=agent.metrics.processes)
+	// The `incus-agent` reports the processes using the most CPU time since they started, up to the given number, in the instance state and metrics.
+	// ---
+	//  type: integer
+	//  defaultdesc: `0`
+	//  liveupdate: yes
+	//  condition: virtual machine
+	//  shortdesc: Number of processes reported by the agent
+	"agent.metrics.processes": validate.Optional(validate.IsInRange(0, 100)),
+
+	// gendoc:generate(entity=instance, group=miscellaneous, key=agent.metrics.services)
+	// The `incus-agent` reports the number of loaded `systemd` units, and the failed ones, in the instance state and metrics.
+	// ---
+	//  type: bool
+	//  defaultdesc: `false`
+	//  liveupdate: yes
+	//  condition: virtual machine
+	//  shortdesc: Whether the agent reports the state of the `systemd` units
+	"agent.metrics.services": validate.Optional(validate.IsBool),
+
 	// gendoc:generate(entity=instance, group=volatile, key=volatile.apply_nvram)
 	//
 	// ---
@@ -1125,8 +1451,23 @@ var InstanceConfigKeysVM = map[string]func(value string) error{
 	//  shortdesc: Whether to regenerate VM NVRAM the next time the instance starts
 	"volatile.apply_nvram": validate.Optional(validate.IsBool),
 
+	// gendoc:generate(entity=instance, group=volatile, key=volatile.apply_machine_upgrade)
+	// See {ref}`instances-machine-definition`.
+	// ---
+	//  type: bool
+	//  shortdesc: Whether to move the VM to the latest QEMU machine definition the next time the instance starts
+	"volatile.apply_machine_upgrade": validate.Optional(validate.IsBool),
+
+	// gendoc:generate(entity=instance, group=volatile, key=volatile.last_state.agent_duration)
+	// The time is measured from the start of the virtual machine to the start of its agent.
+	// ---
+	//  type: integer
+	//  shortdesc: Time taken by the agent to start, in milliseconds
+	"volatile.last_state.agent_duration": validate.Optional(validate.IsInt64),
+
 	// gendoc:generate(entity=instance, group=volatile, key=volatile.vm.definition)
-	//
+	// The machine definition is recorded at first start and kept across QEMU upgrades.
+	// See {ref}`instances-machine-definition`.
 	// ---
 	//  type: string
 	//  shortdesc: QEMU VM definition name (used for migration between versions)
@@ -1275,6 +1616,15 @@ func ConfigKeyChecker(key string, instanceType api.InstanceType) (func(value str
 			return validate.IsAny, nil
 		}
 
+		// gendoc:generate(entity=instance, group=volatile, key=volatile.<name>.last_state.pci.iommu.drivers)
+		// The original host drivers of the other devices of the IOMMU group, bound to `vfio-pci` with the PCI device.
+		// ---
+		//  type: string
+		//  shortdesc: PCI IOMMU group original host drivers
+		if strings.HasSuffix(key, ".last_state.pci.iommu.drivers") {
+			return validate.IsAny, nil
+		}
+
 		// gendoc:generate(entity=instance, group=volatile, key=volatile.<name>.last_state.pci.parent)
 		// The parent host device used when allocating a PCI device to an instance.
 		// ---
@@ -1531,6 +1881,25 @@ func ConfigKeyChecker(key string, instanceType api.InstanceType) (func(value str
 		return validate.IsAny, nil
 	}
 
+	// gendoc:generate(entity=instance, group=security, key=security.syscalls.policy.<syscall>)
+	// Specify how the given system call is handled, regardless of the default deny list.
+	// Possible values are `allow` to let it through, `deny` to fail it with `EPERM` (or `deny:<errno>` for a specific error number)
+	// and `emulate` to skip it while reporting success.
+	// ---
+	//  type: string
+	//  liveupdate: no
+	//  condition: container
+	//  shortdesc: Policy for a specific system call
+	if (instanceType == api.InstanceTypeAny || instanceType == api.InstanceTypeContainer) &&
+		strings.HasPrefix(key, "security.syscalls.policy.") {
+		err := ValidSyscallName(strings.TrimPrefix(key, "security.syscalls.policy."))
+		if err != nil {
+			return nil, err
+		}
+
+		return validate.Optional(ValidSyscallPolicy), nil
+	}
+
 	return nil, fmt.Errorf("Unknown configuration key: %s", key)
 }
 
@@ -1551,3 +1920,43 @@ func InstanceIncludeWhenCopying(configKey string, remoteCopy bool) bool {
 
 	return true // Keep all other keys.
 }
+
+// ValidSyscallName checks that a system call name is valid for use in a seccomp policy.
+func ValidSyscallName(name string) error {
+	if name == "" {
+		return fmt.Errorf("Missing system call name")
+	}
+
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			return fmt.Errorf("Invalid system call name %q", name)
+		}
+	}
+
+	return nil
+}
+
+// ValidSyscallPolicy checks that a value is a valid system call policy (allow, deny, deny:<errno> or emulate).
+func ValidSyscallPolicy(value string) error {
+	action, errno, hasErrno := strings.Cut(value, ":")
+
+	switch action {
+	case "allow", "emulate":
+		if hasErrno {
+			return fmt.Errorf("The %q policy doesn't take an error number", action)
+		}
+
+	case "deny":
+		if hasErrno {
+			n, err := strconv.ParseUint(errno, 10, 16)
+			if err != nil || n == 0 {
+				return fmt.Errorf("Invalid error number %q", errno)
+			}
+		}
+
+	default:
+		return fmt.Errorf("Invalid system call policy %q, must be one of allow, deny, deny:<errno> or emulate", value)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package instance
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// LXCHookPlaceholders are the placeholders which can be used in the arguments of an LXC hook.
+var LXCHookPlaceholders = []string{"{name}", "{path}", "{project}", "{rootfs}"}
+
+// lxcHookPlaceholder matches the placeholders in an LXC hook argument.
+var lxcHookPlaceholder = regexp.MustCompile(`\{[a-z]+\}`)
+
+// lxcHookLiteral matches the characters allowed outside of placeholders in an LXC hook, none of which is special to the shell.
+var lxcHookLiteral = regexp.MustCompile(`^[A-Za-z0-9_./:=,+@%-]*$`)
+
+// ValidLXCHook validates the value of one of the linux.hooks.* keys.
+// The value is the absolute path of a host executable, followed by its arguments separated by spaces.
+func ValidLXCHook(value string) error {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return fmt.Errorf("Missing hook executable")
+	}
+
+	if !filepath.IsAbs(fields[0]) || lxcHookPlaceholder.MatchString(fields[0]) {
+		return fmt.Errorf("The hook executable must be an absolute path")
+	}
+
+	for _, field := range fields {
+		for _, placeholder := range lxcHookPlaceholder.FindAllString(field, -1) {
+			if !slices.Contains(LXCHookPlaceholders, placeholder) {
+				return fmt.Errorf("Unknown hook placeholder %q", placeholder)
+			}
+		}
+
+		if !lxcHookLiteral.MatchString(lxcHookPlaceholder.ReplaceAllString(field, "")) {
+			return fmt.Errorf("Invalid hook argument %q", field)
+		}
+	}
+
+	return nil
+}
+
+// LXCHookCommand returns the command line running an LXC hook, with its placeholders replaced by the given values.
+// The values are quoted as LXC runs the hooks through the shell.
+func LXCHookCommand(value string, values map[string]string) (string, error) {
+	err := ValidLXCHook(value)
+	if err != nil {
+		return "", err
+	}
+
+	fields := strings.Fields(value)
+	for i, field := range fields {
+		fields[i] = lxcHookPlaceholder.ReplaceAllStringFunc(field, func(placeholder string) string {
+			return "'" + strings.ReplaceAll(values[strings.Trim(placeholder, "{}")], "'", `'\''`) + "'"
+		})
+	}
+
+	return strings.Join(fields, " "), nil
+}
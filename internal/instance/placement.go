@@ -0,0 +1,63 @@
+package instance
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/lxc/incus/v6/shared/util"
+	"github.com/lxc/incus/v6/shared/validate"
+)
+
+// PlacementRule is an entry of the placement.affinity or placement.anti-affinity keys.
+type PlacementRule struct {
+	Kind string // Either "group" or "instance".
+	Name string
+}
+
+// PlacementRules parses the value of the placement.affinity or placement.anti-affinity keys.
+// The value is a comma-separated list of "group:<name>" or "instance:<name>" entries.
+func PlacementRules(value string) ([]PlacementRule, error) {
+	rules := []PlacementRule{}
+
+	for _, entry := range util.SplitNTrimSpace(value, ",", -1, true) {
+		kind, name, ok := strings.Cut(entry, ":")
+		if !ok || !slices.Contains([]string{"group", "instance"}, kind) {
+			return nil, fmt.Errorf("Invalid placement rule %q, must be group:<name> or instance:<name>", entry)
+		}
+
+		err := validate.IsHostname(name)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid placement rule %q: %w", entry, err)
+		}
+
+		rules = append(rules, PlacementRule{Kind: kind, Name: name})
+	}
+
+	return rules, nil
+}
+
+// ValidPlacementRules validates the value of the placement.affinity or placement.anti-affinity keys.
+func ValidPlacementRules(value string) error {
+	_, err := PlacementRules(value)
+	return err
+}
+
+// PlacementRulesMatch returns whether any of the rules applies to the instance with the given name and expanded config.
+func PlacementRulesMatch(rules []PlacementRule, name string, config map[string]string) bool {
+	for _, rule := range rules {
+		switch rule.Kind {
+		case "group":
+			if config["placement.group"] == rule.Name {
+				return true
+			}
+
+		case "instance":
+			if name == rule.Name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
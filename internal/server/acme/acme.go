@@ -8,8 +8,10 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strings"
 	"time"
 
+	"github.com/lxc/incus/v6/internal/server/secrets"
 	"github.com/lxc/incus/v6/internal/server/state"
 	internalUtil "github.com/lxc/incus/v6/internal/util"
 	"github.com/lxc/incus/v6/shared/logger"
@@ -33,17 +35,26 @@ type CertKeyPair struct {
 	PrivateKey  []byte `json:"-"`
 }
 
-// certificateNeedsUpdate returns true if the domain doesn't match the certificate's DNS names
+// certificateNeedsUpdate returns true if one of the domains doesn't match the certificate's DNS names
 // or it's valid for less than 30 days.
-func certificateNeedsUpdate(domain string, cert *x509.Certificate) bool {
-	return !slices.Contains(cert.DNSNames, domain) || time.Now().After(cert.NotAfter.Add(-30*24*time.Hour))
+func certificateNeedsUpdate(domains []string, cert *x509.Certificate) bool {
+	for _, domain := range domains {
+		if !slices.Contains(cert.DNSNames, domain) {
+			return true
+		}
+	}
+
+	return time.Now().After(cert.NotAfter.Add(-30 * 24 * time.Hour))
 }
 
-// UpdateCertificate updates the certificate.
-func UpdateCertificate(s *state.State, challengeType string, clustered bool, domain string, email string, caURL string, force bool) (*CertKeyPair, error) {
+// UpdateCertificate updates the certificate. The first domain is the main one, the others are added as
+// alternative names.
+func UpdateCertificate(s *state.State, challengeType string, clustered bool, domains []string, email string, caURL string, force bool) (*CertKeyPair, error) {
 	clusterCertFilename := internalUtil.VarPath(ClusterCertFilename)
 
-	l := logger.AddContext(logger.Ctx{"domain": domain, "caURL": caURL, "challenge": challengeType})
+	domain := domains[0]
+
+	l := logger.AddContext(logger.Ctx{"domains": domains, "caURL": caURL, "challenge": challengeType})
 
 	// If clusterCertFilename exists, it means that a previously issued certificate couldn't be
 	// distributed to all cluster members and was therefore kept back. In this case, don't issue
@@ -71,7 +82,7 @@ func UpdateCertificate(s *state.State, challengeType string, clustered bool, dom
 			return nil, fmt.Errorf("Failed to parse certificate: %w", err)
 		}
 
-		if !certificateNeedsUpdate(domain, cert) {
+		if !certificateNeedsUpdate(domains, cert) {
 			return &CertKeyPair{
 				Certificate: clusterCert,
 				PrivateKey:  key,
@@ -94,7 +105,7 @@ func UpdateCertificate(s *state.State, challengeType string, clustered bool, dom
 		return nil, fmt.Errorf("Failed to parse certificate: %w", err)
 	}
 
-	if !force && !certificateNeedsUpdate(domain, cert) {
+	if !force && !certificateNeedsUpdate(domains, cert) {
 		l.Debug("Skipping certificate renewal as it is still valid for more than 30 days")
 		return nil, nil
 	}
@@ -115,16 +126,28 @@ func UpdateCertificate(s *state.State, challengeType string, clustered bool, dom
 
 	args := []string{
 		"--accept-tos",
-		"--domains", domain,
 		"--email", email,
 		"--path", tmpDir,
 		"--server", caURL,
 	}
 
+	for _, domain := range domains {
+		args = append(args, "--domains", domain)
+	}
+
 	if challengeType == "DNS-01" {
 		provider, environment, resolvers := s.GlobalConfig.ACMEDNS()
 
-		env = append(env, environment...)
+		for _, variable := range environment {
+			key, value, _ := strings.Cut(variable, "=")
+
+			value, err = secrets.Resolve(s.ShutdownCtx, value)
+			if err != nil {
+				return nil, fmt.Errorf("Failed resolving %q environment variable: %w", key, err)
+			}
+
+			env = append(env, key+"="+value)
+		}
 
 		if provider == "" {
 			return nil, fmt.Errorf("DNS-01 challenge type requires acme.dns.provider configuration key to be set")
@@ -10,8 +10,8 @@ import (
 
 func Test_certificateNeedsUpdate(t *testing.T) {
 	type args struct {
-		domain string
-		cert   *x509.Certificate
+		domains []string
+		cert    *x509.Certificate
 	}
 
 	tests := []struct {
@@ -22,7 +22,7 @@ func Test_certificateNeedsUpdate(t *testing.T) {
 		{
 			"Certificate is valid for more than 30 days",
 			args{
-				domain: "foo.example.net",
+				domains: []string{"foo.example.net"},
 				cert: &x509.Certificate{
 					DNSNames: []string{"foo.example.net"},
 					NotAfter: time.Now().Add(90 * 24 * time.Hour),
@@ -33,7 +33,7 @@ func Test_certificateNeedsUpdate(t *testing.T) {
 		{
 			"Certificate is valid for less than 30 days",
 			args{
-				domain: "foo.example.net",
+				domains: []string{"foo.example.net"},
 				cert: &x509.Certificate{
 					DNSNames: []string{"foo.example.net"},
 					NotAfter: time.Now().Add(15 * 24 * time.Hour),
@@ -44,7 +44,7 @@ func Test_certificateNeedsUpdate(t *testing.T) {
 		{
 			"Domain differs from certificate and is valid for more than 30 days",
 			args{
-				domain: "foo.example.org",
+				domains: []string{"foo.example.org"},
 				cert: &x509.Certificate{
 					DNSNames: []string{"foo.example.net"},
 					NotAfter: time.Now().Add(90 * 24 * time.Hour),
@@ -52,10 +52,32 @@ func Test_certificateNeedsUpdate(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"Certificate is missing one of the domains",
+			args{
+				domains: []string{"foo.example.net", "member1.example.net"},
+				cert: &x509.Certificate{
+					DNSNames: []string{"foo.example.net"},
+					NotAfter: time.Now().Add(90 * 24 * time.Hour),
+				},
+			},
+			true,
+		},
+		{
+			"Certificate has all the domains",
+			args{
+				domains: []string{"foo.example.net", "member1.example.net"},
+				cert: &x509.Certificate{
+					DNSNames: []string{"member1.example.net", "foo.example.net"},
+					NotAfter: time.Now().Add(90 * 24 * time.Hour),
+				},
+			},
+			false,
+		},
 		{
 			"Domain differs from certificate and is valid for less than 30 days",
 			args{
-				domain: "foo.example.org",
+				domains: []string{"foo.example.org"},
 				cert: &x509.Certificate{
 					DNSNames: []string{"foo.example.net"},
 					NotAfter: time.Now().Add(15 * 24 * time.Hour),
@@ -67,7 +89,7 @@ func Test_certificateNeedsUpdate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			needsUpdate := certificateNeedsUpdate(tt.args.domain, tt.args.cert)
+			needsUpdate := certificateNeedsUpdate(tt.args.domains, tt.args.cert)
 			require.Equal(t, needsUpdate, tt.want)
 		})
 	}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/server/cgroup"
 	"github.com/lxc/incus/v6/internal/server/instance/drivers/edk2"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
@@ -18,6 +19,7 @@ import (
 	internalUtil "github.com/lxc/incus/v6/internal/util"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/osarch"
+	"github.com/lxc/incus/v6/shared/subprocess"
 	"github.com/lxc/incus/v6/shared/util"
 )
 
@@ -105,6 +107,44 @@ func InstanceValidate(sysOS *sys.OS, inst instance, extraBinaries []string) erro
 	return parseProfile(sysOS, instanceProfileFilename(inst))
 }
 
+// ExtraRulesValidate checks the rules of security.apparmor.extra_rules with the AppArmor parser,
+// within an otherwise empty profile which isn't loaded into the kernel.
+func ExtraRulesValidate(sysOS *sys.OS, value string) error {
+	rules, err := internalInstance.AppArmorExtraRules(value)
+	if err != nil {
+		return err
+	}
+
+	if !sysOS.AppArmorAvailable || len(rules) == 0 {
+		return nil
+	}
+
+	f, err := os.CreateTemp("", "incus_apparmor_")
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	_, err = fmt.Fprintf(f, "#include <tunables/global>\nprofile \"incus-extra-rules\" {\n  %s\n}\n", strings.Join(rules, "\n  "))
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	_, err = subprocess.RunCommand("apparmor_parser", "-QK", f.Name())
+	if err != nil {
+		return fmt.Errorf("Invalid AppArmor rules: %w", err)
+	}
+
+	return nil
+}
+
 // InstanceDelete removes the policy from cache/disk.
 func InstanceDelete(sysOS *sys.OS, inst instance) error {
 	return deleteProfile(sysOS, InstanceProfileName(inst), instanceProfileFilename(inst))
@@ -155,6 +195,17 @@ func instanceProfile(sysOS *sys.OS, inst instance, extraBinaries []string) (stri
 		}
 	}
 
+	// Prepare security.apparmor.extra_rules.
+	extraRules, err := internalInstance.AppArmorExtraRules(inst.ExpandedConfig()["security.apparmor.extra_rules"])
+	if err != nil {
+		return "", err
+	}
+
+	extraContent := ""
+	for _, rule := range extraRules {
+		extraContent += fmt.Sprintf("  %s\n", rule)
+	}
+
 	// Check for features.
 	unixSupported, err := parserSupports(sysOS, "unix")
 	if err != nil {
@@ -176,6 +227,7 @@ func instanceProfile(sysOS *sys.OS, inst instance, extraBinaries []string) (stri
 	if inst.Type() == instancetype.Container {
 		err = lxcProfileTpl.Execute(sb, map[string]any{
 			"extra_binaries":   extraBinaries,
+			"extra_rules":      extraContent,
 			"feature_cgns":     sysOS.CGInfo.Namespacing,
 			"feature_cgroup2":  sysOS.CGInfo.Layout == cgroup.CgroupsUnified || sysOS.CGInfo.Layout == cgroup.CgroupsHybrid,
 			"feature_stacking": sysOS.AppArmorStacking && !sysOS.AppArmorStacked,
@@ -262,6 +314,7 @@ func instanceProfile(sysOS *sys.OS, inst instance, extraBinaries []string) (stri
 			"exePath":        execPath,
 			"extra_config":   extraConfig,
 			"extra_binaries": extraBinaries,
+			"extra_rules":    extraContent,
 			"libraryPath":    strings.Split(os.Getenv("LD_LIBRARY_PATH"), ":"),
 			"logPath":        inst.LogPath(),
 			"runPath":        inst.RunPath(),
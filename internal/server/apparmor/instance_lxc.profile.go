@@ -524,6 +524,12 @@ profile "{{ .name }}" flags=(attach_disconnected,mediate_deleted) {
   mount,
 {{- end }}
 
+{{- if .extra_rules }}
+
+  ### Configuration: security.apparmor.extra_rules
+{{ .extra_rules }}
+{{- end }}
+
 {{- if .raw }}
 
   ### Configuration: raw.apparmor
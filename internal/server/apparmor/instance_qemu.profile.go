@@ -95,6 +95,12 @@ profile "{{ .name }}" flags=(attach_disconnected,mediate_deleted) {
 {{- end }}
 {{- end }}
 
+{{- if .extra_rules }}
+
+  ### Configuration: security.apparmor.extra_rules
+{{ .extra_rules }}
+{{- end }}
+
 {{- if .raw }}
 
   ### Configuration: raw.apparmor
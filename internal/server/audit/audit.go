@@ -0,0 +1,282 @@
+package audit
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// Filter restricts the entries returned by Read.
+type Filter struct {
+	Since    time.Time
+	Until    time.Time
+	Username string
+}
+
+// match returns whether the entry matches the filter.
+func (f Filter) match(entry api.AuditEntry) bool {
+	if !f.Since.IsZero() && entry.Timestamp.Before(f.Since) {
+		return false
+	}
+
+	if !f.Until.IsZero() && entry.Timestamp.After(f.Until) {
+		return false
+	}
+
+	if f.Username != "" && entry.Username != f.Username {
+		return false
+	}
+
+	return true
+}
+
+// keySize is the size of the key used to sign the entries.
+const keySize = 32
+
+// Log is an append-only audit log, where each entry is chained to the previous one by its hash.
+// The hashes are keyed with a secret of the server, so that the entries can't be rewritten without it.
+type Log struct {
+	mu       sync.Mutex
+	path     string
+	keyPath  string
+	key      []byte
+	file     *os.File
+	lastHash string
+}
+
+// NewLog returns an audit log stored in the given file, whose entries are signed with the key stored in keyPath.
+// The key is generated when first needed and the file is only opened when the first entry is appended.
+func NewLog(path string, keyPath string) *Log {
+	return &Log{path: path, keyPath: keyPath}
+}
+
+// loadKey loads the key signing the entries, generating it if missing.
+func (l *Log) loadKey() error {
+	if l.key != nil {
+		return nil
+	}
+
+	key, err := os.ReadFile(l.keyPath)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("Failed reading audit log key: %w", err)
+		}
+
+		key = make([]byte, keySize)
+
+		_, err = rand.Read(key)
+		if err != nil {
+			return fmt.Errorf("Failed generating audit log key: %w", err)
+		}
+
+		err = os.WriteFile(l.keyPath, key, 0o600)
+		if err != nil {
+			return fmt.Errorf("Failed writing audit log key: %w", err)
+		}
+	}
+
+	if len(key) != keySize {
+		return fmt.Errorf("Invalid audit log key %q", l.keyPath)
+	}
+
+	l.key = key
+
+	return nil
+}
+
+// Path returns the path of the file holding the audit log.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append records a new entry, chained to the last one of the log.
+func (l *Log) Append(entry api.AuditEntry) (*api.AuditEntry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		err := l.loadKey()
+		if err != nil {
+			return nil, err
+		}
+
+		// Resume the chain from the last recorded entry.
+		err = scan(l.path, func(_ int, entry api.AuditEntry) error {
+			l.lastHash = entry.Hash
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		l.file, err = os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("Failed opening audit log: %w", err)
+		}
+	}
+
+	entry.Timestamp = entry.Timestamp.UTC()
+	entry.PreviousHash = l.lastHash
+
+	var err error
+
+	entry.Hash, err = hash(l.key, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = l.file.Write(append(line, '\n'))
+	if err != nil {
+		return nil, fmt.Errorf("Failed writing audit log: %w", err)
+	}
+
+	l.lastHash = entry.Hash
+
+	return &entry, nil
+}
+
+// Close closes the file holding the audit log.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	err := l.file.Close()
+	l.file = nil
+
+	return err
+}
+
+// hash returns the keyed hash (HMAC-SHA256) of an entry, covering all its fields but the hash itself.
+func hash(key []byte, entry api.AuditEntry) (string, error) {
+	entry.Hash = ""
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(data)
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// scan calls the given function for each entry of the audit log.
+func scan(path string, f func(line int, entry api.AuditEntry) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("Failed opening audit log: %w", err)
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		entry := api.AuditEntry{}
+
+		err := json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			return fmt.Errorf("Invalid audit log entry on line %d: %w", line, err)
+		}
+
+		err = f(line, entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// Read returns the entries of the audit log matching the filter.
+func Read(path string, filter Filter) ([]api.AuditEntry, error) {
+	entries := []api.AuditEntry{}
+
+	err := scan(path, func(_ int, entry api.AuditEntry) error {
+		if filter.match(entry) {
+			entries = append(entries, entry)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Verify checks the hash chain of the audit log and returns the number of entries it holds.
+// The chain must start at the first entry of the file, whose previous hash is empty, so that removing the
+// first entries is detected as well.
+func (l *Log) Verify() (int, error) {
+	l.mu.Lock()
+	err := l.loadKey()
+	key := l.key
+	l.mu.Unlock()
+
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	previousHash := ""
+
+	err = scan(l.path, func(line int, entry api.AuditEntry) error {
+		if entry.PreviousHash != previousHash {
+			if count == 0 {
+				return fmt.Errorf("Audit log entry on line %d isn't the start of the chain", line)
+			}
+
+			return fmt.Errorf("Audit log entry on line %d isn't chained to the previous one", line)
+		}
+
+		entryHash, err := hash(key, entry)
+		if err != nil {
+			return err
+		}
+
+		if !hmac.Equal([]byte(entryHash), []byte(entry.Hash)) {
+			return fmt.Errorf("Audit log entry on line %d was modified", line)
+		}
+
+		previousHash = entry.Hash
+		count++
+
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+
+	return count, nil
+}
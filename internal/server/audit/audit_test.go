@@ -0,0 +1,111 @@
+package audit_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/internal/server/audit"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func TestLog(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	keyPath := filepath.Join(dir, "audit.key")
+	start := time.Now()
+
+	l := audit.NewLog(path, keyPath)
+	for _, username := range []string{"foo", "bar", "foo"} {
+		_, err := l.Append(api.AuditEntry{Timestamp: time.Now(), Username: username, Method: "GET", URL: "/1.0"})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, l.Close())
+
+	// The chain is resumed when re-opening the log.
+	l = audit.NewLog(path, keyPath)
+	last, err := l.Append(api.AuditEntry{Timestamp: time.Now(), Username: "baz", Method: "DELETE", URL: "/1.0/instances/c1"})
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+
+	count, err := l.Verify()
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+
+	entries, err := audit.Read(path, audit.Filter{Username: "foo"})
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	entries, err = audit.Read(path, audit.Filter{Since: start})
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	assert.Equal(t, entries[2].Hash, last.PreviousHash)
+
+	entries, err = audit.Read(path, audit.Filter{Until: start})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// Tampering with an entry is detected.
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(string(content), `"bar"`, `"qux"`, 1)), 0o600))
+
+	_, err = l.Verify()
+	assert.Error(t, err)
+
+	// Removing an entry is detected.
+	lines := strings.SplitAfter(string(content), "\n")
+	require.NoError(t, os.WriteFile(path, []byte(lines[0]+lines[2]), 0o600))
+
+	_, err = l.Verify()
+	assert.Error(t, err)
+
+	// Removing the first entries is detected.
+	require.NoError(t, os.WriteFile(path, []byte(lines[2]+lines[3]), 0o600))
+
+	_, err = l.Verify()
+	assert.Error(t, err)
+}
+
+func TestLogKey(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	keyPath := filepath.Join(dir, "audit.key")
+
+	l := audit.NewLog(path, keyPath)
+	_, err := l.Append(api.AuditEntry{Timestamp: time.Now(), Username: "foo", Method: "GET", URL: "/1.0"})
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+
+	info, err := os.Stat(keyPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// A log rewritten with another key, like one forged without the key of the server, is detected.
+	otherDir := t.TempDir()
+	otherPath := filepath.Join(otherDir, "audit.log")
+
+	other := audit.NewLog(otherPath, filepath.Join(otherDir, "audit.key"))
+	_, err = other.Append(api.AuditEntry{Timestamp: time.Now(), Username: "bar", Method: "GET", URL: "/1.0"})
+	require.NoError(t, err)
+	require.NoError(t, other.Close())
+
+	content, err := os.ReadFile(otherPath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+
+	_, err = l.Verify()
+	assert.Error(t, err)
+
+	// An invalid key is refused.
+	require.NoError(t, os.WriteFile(keyPath, []byte("foo"), 0o600))
+
+	_, err = audit.NewLog(path, keyPath).Verify()
+	assert.Error(t, err)
+}
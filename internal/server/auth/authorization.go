@@ -50,6 +50,8 @@ type Authorizer interface {
 	CheckPermission(ctx context.Context, r *http.Request, object Object, entitlement Entitlement) error
 	GetPermissionChecker(ctx context.Context, r *http.Request, entitlement Entitlement, objectType ObjectType) (PermissionChecker, error)
 
+	SetUserGroups(ctx context.Context, username string, groups []string, managedGroups []string) error
+
 	AddProject(ctx context.Context, projectID int64, projectName string) error
 	DeleteProject(ctx context.Context, projectID int64, projectName string) error
 	RenameProject(ctx context.Context, projectID int64, oldName string, newName string) error
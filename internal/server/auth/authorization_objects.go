@@ -107,6 +107,7 @@ type objectValidator struct {
 
 var objectValidators = map[ObjectType]objectValidator{
 	ObjectTypeUser:               {minIdentifierElements: 1, maxIdentifierElements: 1, requireProject: false},
+	ObjectTypeGroup:              {minIdentifierElements: 1, maxIdentifierElements: 1, requireProject: false},
 	ObjectTypeServer:             {minIdentifierElements: 1, maxIdentifierElements: 1, requireProject: false},
 	ObjectTypeCertificate:        {minIdentifierElements: 1, maxIdentifierElements: 1, requireProject: false},
 	ObjectTypeStoragePool:        {minIdentifierElements: 1, maxIdentifierElements: 1, requireProject: false},
@@ -254,6 +255,12 @@ func ObjectUser(userName string) Object {
 	return object
 }
 
+// ObjectGroup represents a group of users.
+func ObjectGroup(groupName string) Object {
+	object, _ := NewObject(ObjectTypeGroup, "", groupName)
+	return object
+}
+
 // ObjectServer represents a server.
 func ObjectServer() Object {
 	object, _ := NewObject(ObjectTypeServer, "", "incus")
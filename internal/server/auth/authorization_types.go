@@ -52,6 +52,9 @@ const (
 	// ObjectTypeUser represents a user.
 	ObjectTypeUser ObjectType = "user"
 
+	// ObjectTypeGroup represents a group of users.
+	ObjectTypeGroup ObjectType = "group"
+
 	// ObjectTypeServer represents a server.
 	ObjectTypeServer ObjectType = "server"
 
@@ -101,4 +104,5 @@ const (
 const (
 	relationServer  = "server"
 	relationProject = "project"
+	relationMember  = "member"
 )
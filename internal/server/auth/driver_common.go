@@ -150,6 +150,11 @@ func (c *commonAuthorizer) ApplyPatch(ctx context.Context, name string) error {
 	return nil
 }
 
+// SetUserGroups is a no-op.
+func (c *commonAuthorizer) SetUserGroups(ctx context.Context, username string, groups []string, managedGroups []string) error {
+	return nil
+}
+
 // AddProject is a no-op.
 func (c *commonAuthorizer) AddProject(ctx context.Context, projectID int64, name string) error {
 	return nil
@@ -31,6 +31,10 @@ type FGA struct {
 	onlineMu sync.Mutex
 	online   bool
 
+	// Last group memberships set for each user, to only update them when they change.
+	userGroupsMu sync.Mutex
+	userGroups   map[string][]string
+
 	shutdownCtx    context.Context
 	shutdownCancel context.CancelFunc
 
@@ -357,6 +361,96 @@ func (f *FGA) GetPermissionChecker(ctx context.Context, r *http.Request, entitle
 	}, nil
 }
 
+// SetUserGroups makes the user a member of exactly the given groups among the managed ones, nil meaning all groups.
+// The memberships of the groups which aren't managed, like those written manually, are left alone.
+func (f *FGA) SetUserGroups(ctx context.Context, username string, groups []string, managedGroups []string) error {
+	groups = slices.Clone(groups)
+	slices.Sort(groups)
+
+	f.userGroupsMu.Lock()
+	defer f.userGroupsMu.Unlock()
+
+	cachedGroups, ok := f.userGroups[username]
+	if ok && slices.Equal(cachedGroups, groups) {
+		return nil
+	}
+
+	// If offline, skip updating so that the memberships are updated on the next request.
+	f.onlineMu.Lock()
+	online := f.online
+	f.onlineMu.Unlock()
+
+	if !online {
+		return nil
+	}
+
+	objectUser := ObjectUser(username).String()
+	resp, err := f.client.ListObjects(ctx).Body(client.ClientListObjectsRequest{
+		User:     objectUser,
+		Relation: relationMember,
+		Type:     string(ObjectTypeGroup),
+	}).Execute()
+	if err != nil {
+		return fmt.Errorf("Failed to list the OpenFGA groups of user %q: %w", username, err)
+	}
+
+	added, removed := userGroupsChanges(resp.GetObjects(), groups, managedGroups)
+
+	var writes []client.ClientTupleKey
+	for _, group := range added {
+		writes = append(writes, client.ClientTupleKey{User: objectUser, Relation: relationMember, Object: group})
+	}
+
+	var deletions []client.ClientTupleKeyWithoutCondition
+	for _, group := range removed {
+		deletions = append(deletions, client.ClientTupleKeyWithoutCondition{User: objectUser, Relation: relationMember, Object: group})
+	}
+
+	err = f.updateTuples(ctx, writes, deletions)
+	if err != nil {
+		return err
+	}
+
+	if f.userGroups == nil {
+		f.userGroups = map[string][]string{}
+	}
+
+	f.userGroups[username] = groups
+
+	return nil
+}
+
+// userGroupsChanges returns the group objects to add the user to and to remove it from, given the group objects
+// it's currently a member of and the names of the wanted groups. Only the managed groups are removed, nil meaning all groups.
+func userGroupsChanges(currentGroups []string, groups []string, managedGroups []string) ([]string, []string) {
+	wantedGroups := make([]string, 0, len(groups))
+	for _, group := range groups {
+		wantedGroups = append(wantedGroups, ObjectGroup(group).String())
+	}
+
+	added := []string{}
+	for _, group := range wantedGroups {
+		if !slices.Contains(currentGroups, group) {
+			added = append(added, group)
+		}
+	}
+
+	removed := []string{}
+	for _, group := range currentGroups {
+		if slices.Contains(wantedGroups, group) {
+			continue
+		}
+
+		if managedGroups != nil && !slices.ContainsFunc(managedGroups, func(name string) bool { return ObjectGroup(name).String() == group }) {
+			continue
+		}
+
+		removed = append(removed, group)
+	}
+
+	return added, removed
+}
+
 // AddProject adds a project to the authorizer.
 func (f *FGA) AddProject(ctx context.Context, _ int64, projectName string) error {
 	writes := []client.ClientTupleKey{
@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserGroupsChanges(t *testing.T) {
+	current := []string{ObjectGroup("admins").String(), ObjectGroup("manual").String(), ObjectGroup("old").String()}
+
+	// Without mapping, all the groups follow the claim.
+	added, removed := userGroupsChanges(current, []string{"admins", "new"}, nil)
+	assert.Equal(t, []string{ObjectGroup("new").String()}, added)
+	assert.Equal(t, []string{ObjectGroup("manual").String(), ObjectGroup("old").String()}, removed)
+
+	// With a mapping, the memberships of the groups which aren't mapped are kept.
+	added, removed = userGroupsChanges(current, []string{"admins", "new"}, []string{"admins", "new", "old"})
+	assert.Equal(t, []string{ObjectGroup("new").String()}, added)
+	assert.Equal(t, []string{ObjectGroup("old").String()}, removed)
+
+	// Nothing changes if the user is already a member of the wanted groups.
+	added, removed = userGroupsChanges(current, []string{"admins"}, []string{"admins"})
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+
+	// An empty claim removes all the managed groups.
+	added, removed = userGroupsChanges(current, []string{}, []string{"admins", "old"})
+	assert.Empty(t, added)
+	assert.Equal(t, []string{ObjectGroup("admins").String(), ObjectGroup("old").String()}, removed)
+}
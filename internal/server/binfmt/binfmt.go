@@ -0,0 +1,157 @@
+package binfmt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/lxc/incus/v6/shared/osarch"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// Path is the mount point of binfmt_misc.
+const Path = "/proc/sys/fs/binfmt_misc"
+
+// handler describes how to recognize the ELF binaries of an architecture and which emulator runs them.
+type handler struct {
+	emulator string
+	magic    string
+	mask     string
+}
+
+// handlers are the architectures which can be emulated through qemu-user.
+// The magic and mask values match those registered by QEMU's qemu-binfmt-conf.sh.
+var handlers = map[int]handler{
+	osarch.ARCH_32BIT_INTEL_X86: {
+		emulator: "qemu-i386-static",
+		magic:    `\x7fELF\x01\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x02\x00\x03\x00`,
+		mask:     `\xff\xff\xff\xff\xff\xfe\xfe\x00\xff\xff\xff\xff\xff\xff\xff\xff\xfe\xff\xff\xff`,
+	},
+	osarch.ARCH_64BIT_INTEL_X86: {
+		emulator: "qemu-x86_64-static",
+		magic:    `\x7fELF\x02\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x02\x00\x3e\x00`,
+		mask:     `\xff\xff\xff\xff\xff\xfe\xfe\x00\xff\xff\xff\xff\xff\xff\xff\xff\xfe\xff\xff\xff`,
+	},
+	osarch.ARCH_32BIT_ARMV7_LITTLE_ENDIAN: {
+		emulator: "qemu-arm-static",
+		magic:    `\x7fELF\x01\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x02\x00\x28\x00`,
+		mask:     `\xff\xff\xff\xff\xff\xff\xff\x00\xff\xff\xff\xff\xff\xff\xff\xff\xfe\xff\xff\xff`,
+	},
+	osarch.ARCH_64BIT_ARMV8_LITTLE_ENDIAN: {
+		emulator: "qemu-aarch64-static",
+		magic:    `\x7fELF\x02\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x02\x00\xb7\x00`,
+		mask:     `\xff\xff\xff\xff\xff\xff\xff\x00\xff\xff\xff\xff\xff\xff\xff\xff\xfe\xff\xff\xff`,
+	},
+	osarch.ARCH_64BIT_POWERPC_LITTLE_ENDIAN: {
+		emulator: "qemu-ppc64le-static",
+		magic:    `\x7fELF\x02\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x02\x00\x15\x00`,
+		mask:     `\xff\xff\xff\xff\xff\xff\xff\xfc\xff\xff\xff\xff\xff\xff\xff\xff\xfe\xff\xff\x00`,
+	},
+	osarch.ARCH_64BIT_S390_BIG_ENDIAN: {
+		emulator: "qemu-s390x-static",
+		magic:    `\x7fELF\x02\x02\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x02\x00\x16`,
+		mask:     `\xff\xff\xff\xff\xff\xff\xff\xfc\xff\xff\xff\xff\xff\xff\xff\xff\xff\xfe\xff\xff`,
+	},
+	osarch.ARCH_64BIT_RISCV_LITTLE_ENDIAN: {
+		emulator: "qemu-riscv64-static",
+		magic:    `\x7fELF\x02\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x02\x00\xf3\x00`,
+		mask:     `\xff\xff\xff\xff\xff\xff\xff\x00\xff\xff\xff\xff\xff\xff\xff\xff\xfe\xff\xff\xff`,
+	},
+	osarch.ARCH_64BIT_LOONGARCH: {
+		emulator: "qemu-loongarch64-static",
+		magic:    `\x7fELF\x02\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x02\x00\x02\x01`,
+		mask:     `\xff\xff\xff\xff\xff\xff\xff\xfc\x00\xff\xff\xff\xff\xff\xff\xff\xfe\xff\xff\xff`,
+	},
+}
+
+// Supported returns whether the architecture can be emulated.
+func Supported(arch int) bool {
+	_, ok := handlers[arch]
+	return ok
+}
+
+// ValidArchitecture validates that the named architecture can be emulated.
+func ValidArchitecture(value string) error {
+	arch, err := osarch.ArchitectureID(value)
+	if err != nil {
+		return err
+	}
+
+	if !Supported(arch) {
+		return fmt.Errorf("Architecture %q can't be emulated", value)
+	}
+
+	return nil
+}
+
+// entryName returns the name of the binfmt_misc entry registered for the architecture.
+func entryName(arch int) string {
+	name, _ := osarch.ArchitectureName(arch)
+	return "incus-" + name
+}
+
+// mount makes sure that binfmt_misc is available.
+func mount() error {
+	if util.PathExists(filepath.Join(Path, "register")) {
+		return nil
+	}
+
+	err := unix.Mount("binfmt_misc", Path, "binfmt_misc", 0, "")
+	if err != nil {
+		return fmt.Errorf("Failed to mount binfmt_misc: %w", err)
+	}
+
+	return nil
+}
+
+// Register registers the qemu-user emulator of the architecture with binfmt_misc.
+//
+// The emulator is opened at registration time (fix-binary flag) so that it doesn't need
+// to be present within the instances.
+func Register(arch int) error {
+	h, ok := handlers[arch]
+	if !ok {
+		name, _ := osarch.ArchitectureName(arch)
+		return fmt.Errorf("Architecture %q can't be emulated", name)
+	}
+
+	err := mount()
+	if err != nil {
+		return err
+	}
+
+	// Skip if already registered.
+	if util.PathExists(filepath.Join(Path, entryName(arch))) {
+		return nil
+	}
+
+	emulator, err := exec.LookPath(h.emulator)
+	if err != nil {
+		return fmt.Errorf("Emulation requires %q to be installed: %w", h.emulator, err)
+	}
+
+	entry := fmt.Sprintf(":%s:M::%s:%s:%s:F", entryName(arch), h.magic, h.mask, emulator)
+
+	err = os.WriteFile(filepath.Join(Path, "register"), []byte(entry), 0)
+	if err != nil {
+		return fmt.Errorf("Failed to register %q with binfmt_misc: %w", h.emulator, err)
+	}
+
+	return nil
+}
+
+// Unregister removes the binfmt_misc entry of the architecture.
+func Unregister(arch int) error {
+	path := filepath.Join(Path, entryName(arch))
+
+	err := os.WriteFile(path, []byte("-1"), 0)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("Failed to unregister %q from binfmt_misc: %w", entryName(arch), err)
+	}
+
+	return nil
+}
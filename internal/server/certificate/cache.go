@@ -2,6 +2,7 @@ package certificate
 
 import (
 	"crypto/x509"
+	"net"
 	"sync"
 )
 
@@ -14,7 +15,11 @@ type Cache struct {
 	// If a certificate fingerprint is present in certificates, but not present in projects, it means the certificate is
 	// not restricted.
 	projects map[string][]string
-	mu       sync.RWMutex
+
+	// sources is a map of certificate fingerprint to slice of networks the certificate may be used from.
+	// If a certificate fingerprint isn't present in sources, the certificate may be used from any address.
+	sources map[string][]*net.IPNet
+	mu      sync.RWMutex
 }
 
 // SetCertificatesAndProjects sets both certificates and projects on the Cache.
@@ -95,3 +100,30 @@ func (c *Cache) GetProjects() map[string][]string {
 
 	return projects
 }
+
+// SetSources sets the allowed source networks on the Cache.
+func (c *Cache) SetSources(sources map[string][]*net.IPNet) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sources = sources
+}
+
+// SourceAllowed returns whether the certificate with the given fingerprint may be used from the given address.
+func (c *Cache) SourceAllowed(fingerprint string, address net.IP) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	networks, found := c.sources[fingerprint]
+	if !found {
+		return true
+	}
+
+	for _, network := range networks {
+		if network.Contains(address) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
@@ -39,6 +40,19 @@ func (cg *CGroup) SetMaxProcesses(limit int64) error {
 	return ErrUnknownVersion
 }
 
+// SetMaxDepth sets the maximum depth of the cgroup tree below the cgroup (-1 for unlimited).
+func (cg *CGroup) SetMaxDepth(limit int64) error {
+	if !cg.UnifiedCapable {
+		return ErrControllerMissing
+	}
+
+	if limit == -1 {
+		return cg.rw.Set(V2, "cgroup", "cgroup.max.depth", "max")
+	}
+
+	return cg.rw.Set(V2, "cgroup", "cgroup.max.depth", fmt.Sprintf("%d", limit))
+}
+
 // GetMemorySoftLimit returns the soft limit for memory.
 func (cg *CGroup) GetMemorySoftLimit() (int64, error) {
 	version := cgControllers["memory"]
@@ -93,6 +107,36 @@ func (cg *CGroup) SetMemorySoftLimit(limit int64) error {
 	return ErrUnknownVersion
 }
 
+// SetMemoryMin sets the amount of memory protected from reclaim.
+func (cg *CGroup) SetMemoryMin(limit int64) error {
+	version := cgControllers["memory"]
+	switch version {
+	case Unavailable:
+		return ErrControllerMissing
+	case V1:
+		return ErrControllerMissing
+	case V2:
+		return cg.rw.Set(version, "memory", "memory.min", fmt.Sprintf("%d", limit))
+	}
+
+	return ErrUnknownVersion
+}
+
+// SetMemoryLow sets the amount of memory protected from reclaim on a best-effort basis.
+func (cg *CGroup) SetMemoryLow(limit int64) error {
+	version := cgControllers["memory"]
+	switch version {
+	case Unavailable:
+		return ErrControllerMissing
+	case V1:
+		return ErrControllerMissing
+	case V2:
+		return cg.rw.Set(version, "memory", "memory.low", fmt.Sprintf("%d", limit))
+	}
+
+	return ErrUnknownVersion
+}
+
 // GetMemoryLimit return the hard limit for memory.
 func (cg *CGroup) GetMemoryLimit() (int64, error) {
 	version := cgControllers["memory"]
@@ -908,6 +952,71 @@ func (cg *CGroup) SetCpuset(limit string) error {
 	return ErrUnknownVersion
 }
 
+// GetCpusetPartition returns the partition type of the cgroup's cpuset.
+// The kernel reports the partitions it couldn't set up as "root invalid" or "isolated invalid", followed by the reason.
+func (cg *CGroup) GetCpusetPartition() (string, error) {
+	version := cgControllers["cpuset"]
+	switch version {
+	case Unavailable:
+		return "", ErrControllerMissing
+	case V1:
+		return "", ErrControllerMissing
+	case V2:
+		return cg.rw.Get(version, "cpuset", "cpuset.cpus.partition")
+	}
+
+	return "", ErrUnknownVersion
+}
+
+// SetCpusetPartition sets the partition type (member, root or isolated) of the cgroup's cpuset.
+// As the kernel accepts the partitions it can't set up, marking them invalid instead, the resulting
+// partition type is read back and an error returned if it's invalid.
+func (cg *CGroup) SetCpusetPartition(partition string) error {
+	version := cgControllers["cpuset"]
+	switch version {
+	case Unavailable:
+		return ErrControllerMissing
+	case V1:
+		return ErrControllerMissing
+	case V2:
+		err := cg.rw.Set(version, "cpuset", "cpuset.cpus.partition", partition)
+		if err != nil {
+			return err
+		}
+
+		current, err := cg.rw.Get(version, "cpuset", "cpuset.cpus.partition")
+		if err != nil {
+			return err
+		}
+
+		if strings.Contains(current, "invalid") {
+			return fmt.Errorf("The kernel reported the cpuset partition as %q", current)
+		}
+
+		return nil
+	}
+
+	return ErrUnknownVersion
+}
+
+// CpusetIsPartitionRoot returns whether the cgroup at the given path of the unified hierarchy is a valid
+// cpuset partition root, which is required of the parent of a new partition. The root cgroup always is one.
+func CpusetIsPartitionRoot(path string) (bool, error) {
+	path = filepath.Clean("/" + path)
+	if path == "/" {
+		return true, nil
+	}
+
+	content, err := os.ReadFile(filepath.Join("/sys/fs/cgroup", path, "cpuset.cpus.partition"))
+	if err != nil {
+		return false, err
+	}
+
+	partition := strings.TrimSpace(string(content))
+
+	return partition == "root" || partition == "isolated", nil
+}
+
 // GetMemoryStats returns memory stats.
 func (cg *CGroup) GetMemoryStats() (map[string]uint64, error) {
 	var (
@@ -3,6 +3,8 @@ package config
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/mail"
 	"strconv"
 	"strings"
 	"time"
@@ -13,6 +15,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/config"
 	"github.com/lxc/incus/v6/internal/server/db"
 	scriptletLoad "github.com/lxc/incus/v6/internal/server/scriptlet/load"
+	"github.com/lxc/incus/v6/internal/server/secrets"
 	"github.com/lxc/incus/v6/shared/validate"
 )
 
@@ -49,6 +52,36 @@ func (c *Config) MetricsAuthentication() bool {
 	return c.m.GetBool("core.metrics_authentication")
 }
 
+// AuditLog returns whether the API requests are recorded in the audit log.
+func (c *Config) AuditLog() bool {
+	return c.m.GetBool("core.audit_log")
+}
+
+// HealthEndpoints returns whether the unauthenticated /healthz and /readyz endpoints are enabled.
+func (c *Config) HealthEndpoints() bool {
+	return c.m.GetBool("core.health_endpoints")
+}
+
+// Tracing returns the settings of the export of the OpenTelemetry traces.
+func (c *Config) Tracing() (endpoint string, caCert string, samplePercentage int64) {
+	return c.m.GetString("tracing.endpoint"), c.m.GetString("tracing.ca_cert"), c.m.GetInt64("tracing.sample_percentage")
+}
+
+// WarningsSeverity returns the minimum severity of the warnings to notify.
+func (c *Config) WarningsSeverity() string {
+	return c.m.GetString("warnings.severity")
+}
+
+// WarningsWebhook returns the URL that the new warnings are sent to.
+func (c *Config) WarningsWebhook() string {
+	return c.m.GetString("warnings.webhook.url")
+}
+
+// WarningsEmail returns the settings of the emails sent for the new warnings.
+func (c *Config) WarningsEmail() (to string, from string, smtpAddress string, smtpUsername string, smtpPassword string) {
+	return c.m.GetString("warnings.email.to"), c.m.GetString("warnings.email.from"), c.m.GetString("warnings.email.smtp_address"), c.m.GetString("warnings.email.smtp_username"), c.m.GetString("warnings.email.smtp_password")
+}
+
 // BGPASN returns the BGP ASN setting.
 func (c *Config) BGPASN() int64 {
 	return c.m.GetInt64("core.bgp_asn")
@@ -135,6 +168,11 @@ func (c *Config) ClusterRebalanceCooldown() string {
 	return c.m.GetString("cluster.rebalance.cooldown")
 }
 
+// ClusterRebalanceDryRun returns whether re-balancing only reports the moves it would perform.
+func (c *Config) ClusterRebalanceDryRun() bool {
+	return c.m.GetBool("cluster.rebalance.dry_run")
+}
+
 // ClusterRebalanceInterval returns the interval at which to evaluate re-balanicng.
 func (c *Config) ClusterRebalanceInterval() int64 {
 	return c.m.GetInt64("cluster.rebalance.interval")
@@ -178,6 +216,23 @@ func (c *Config) ShutdownTimeout() time.Duration {
 	return time.Duration(n) * time.Minute
 }
 
+// SlowQueryThreshold returns the duration above which the database queries are recorded, 0 when disabled.
+func (c *Config) SlowQueryThreshold() time.Duration {
+	n := c.m.GetInt64("core.slow_query_threshold")
+	return time.Duration(n) * time.Millisecond
+}
+
+// MaxConcurrentOperations returns the maximum number of expensive operations running at once, 0 for unlimited.
+func (c *Config) MaxConcurrentOperations() int {
+	return int(c.m.GetInt64("core.max_concurrent_operations"))
+}
+
+// RateLimits returns the maximum number of API requests per second from all clients and from each identity,
+// 0 for unlimited.
+func (c *Config) RateLimits() (int64, int64) {
+	return c.m.GetInt64("core.rate_limit.global"), c.m.GetInt64("core.rate_limit.identity")
+}
+
 // ImagesDefaultArchitecture returns the default architecture.
 func (c *Config) ImagesDefaultArchitecture() string {
 	return c.m.GetString("images.default_architecture")
@@ -244,6 +299,11 @@ func (c *Config) ACME() (string, string, string, bool, string) {
 	return c.m.GetString("acme.domain"), c.m.GetString("acme.email"), c.m.GetString("acme.ca_url"), c.m.GetBool("acme.agree_tos"), c.m.GetString("acme.challenge")
 }
 
+// ACMEMemberDomain returns the domain name pattern of the cluster members in the ACME certificate.
+func (c *Config) ACMEMemberDomain() string {
+	return c.m.GetString("acme.member_domain")
+}
+
 // ACMEDNS returns all ACME DNS settings needed for DNS-01 challenge.
 func (c *Config) ACMEDNS() (string, []string, []string) {
 	var environment []string
@@ -297,6 +357,21 @@ func (c *Config) OIDCServer() (string, string, string, string, string) {
 	return c.m.GetString("oidc.issuer"), c.m.GetString("oidc.client.id"), c.m.GetString("oidc.scopes"), c.m.GetString("oidc.audience"), c.m.GetString("oidc.claim")
 }
 
+// OIDCGroups returns the OpenID Connect claim holding the groups of the users and the mapping of its values to
+// authorization groups.
+func (c *Config) OIDCGroups() (string, map[string]string) {
+	mapping, _ := parseOIDCGroupsMapping(c.m.GetString("oidc.groups.mapping"))
+
+	return c.m.GetString("oidc.groups.claim"), mapping
+}
+
+// OIDCAllowedSources returns the networks the OpenID Connect users are restricted to, indexed by username.
+func (c *Config) OIDCAllowedSources() map[string][]*net.IPNet {
+	sources, _ := parseOIDCAllowedSources(c.m.GetString("oidc.allowed_sources"))
+
+	return sources
+}
+
 // ClusterHealingThreshold returns the configured healing threshold, i.e. the
 // number of seconds after which an offline node will be evacuated automatically. If the config key
 // is set but its value is lower than cluster.offline_threshold it returns
@@ -373,6 +448,15 @@ func (c *Config) LoggingCommonConfig(loggerName string) (string, string, string,
 	return c.m.GetString(lifecycleProjectsKey), c.m.GetString(lifecycleTypesKey), c.m.GetString(loggingLevelKey), c.m.GetString(typesKey)
 }
 
+// LoggingBufferSize returns the number of events which can wait for delivery to the logger.
+func (c *Config) LoggingBufferSize(loggerName string) int {
+	if loggerName == "loki" && c.m.GetString("loki.api.url") != "" {
+		return 1000
+	}
+
+	return int(c.m.GetInt64(fmt.Sprintf("logging.%s.target.buffer", loggerName)))
+}
+
 // LoggingConfigForSyslog returns the logging configuration for the syslog logger type.
 func (c *Config) LoggingConfigForSyslog(loggerName string) (string, string) {
 	prefix := fmt.Sprintf("logging.%s", loggerName)
@@ -466,6 +550,14 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Email address used for the account registration
 	"acme.email": {},
 
+	// gendoc:generate(entity=server, group=acme, key=acme.member_domain)
+	// When set on a cluster, the certificate also covers a domain name for each cluster member, built by replacing `{member}` with the member name (for example, `{member}.incus.example.net`).
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: Domain name pattern of the cluster members
+	"acme.member_domain": {Validator: validate.Optional(acmeMemberDomainValidator)},
+
 	// gendoc:generate(entity=server, group=acme, key=acme.agree_tos)
 	//
 	// ---
@@ -500,7 +592,7 @@ var ConfigSchema = config.Schema{
 	//  scope: global
 	//  defaultdesc: ``
 	//  shortdesc: Environment variables to set during the challenge (used by DNS-01)
-	"acme.provider.environment": {Type: config.String, Default: ""},
+	"acme.provider.environment": {Type: config.String, Default: "", Validator: acmeProviderEnvironmentValidator},
 
 	// gendoc:generate(entity=server, group=acme, key=acme.provider.resolvers)
 	// DNS resolvers to use for performing (recursive) `CNAME` resolving and apex domain determination during DNS-01 challenge.
@@ -613,6 +705,15 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Amount of time during which an instance will not be moved again
 	"cluster.rebalance.cooldown": {Type: config.String, Default: "6H", Validator: validate.Optional(expiryValidator)},
 
+	// gendoc:generate(entity=server, group=cluster, key=cluster.rebalance.dry_run)
+	// When enabled, the instances which would be moved are only reported through `instance-rebalanced` events.
+	// ---
+	//  type: bool
+	//  scope: global
+	//  defaultdesc: `false`
+	//  shortdesc: Whether to only report the instances re-balancing would move
+	"cluster.rebalance.dry_run": {Type: config.Bool, Default: "false"},
+
 	// gendoc:generate(entity=server, group=cluster, key=cluster.rebalance.interval)
 	//
 	// ---
@@ -640,6 +741,26 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Whether to enforce authentication on the metrics endpoint
 	"core.metrics_authentication": {Type: config.Bool, Default: "true"},
 
+	// gendoc:generate(entity=server, group=core, key=core.health_endpoints)
+	// When enabled, the unauthenticated `/healthz` and `/readyz` endpoints report the status of the database, storage pools and event distribution of the server.
+	// See {ref}`health-endpoints`.
+	// ---
+	//  type: bool
+	//  scope: global
+	//  defaultdesc: `false`
+	//  shortdesc: Whether to enable the unauthenticated health and readiness endpoints
+	"core.health_endpoints": {Type: config.Bool, Default: "false"},
+
+	// gendoc:generate(entity=server, group=core, key=core.audit_log)
+	// When enabled, each cluster member records the authenticated API requests it handles in a hash-chained audit log.
+	// See {ref}`audit-log`.
+	// ---
+	//  type: bool
+	//  scope: global
+	//  defaultdesc: `false`
+	//  shortdesc: Whether to record API requests in the audit log
+	"core.audit_log": {Type: config.Bool, Default: "false"},
+
 	// gendoc:generate(entity=server, group=core, key=core.bgp_asn)
 	//
 	// ---
@@ -689,6 +810,16 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Trusted servers to provide the client's address
 	"core.https_trusted_proxy": {},
 
+	// gendoc:generate(entity=server, group=core, key=core.max_concurrent_operations)
+	// Migrations, backups and image downloads beyond this number are queued until one of the running ones completes.
+	// The limit applies to each cluster member.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0` (unlimited)
+	//  shortdesc: Maximum number of concurrent expensive operations on each member
+	"core.max_concurrent_operations": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsUint32)},
+
 	// gendoc:generate(entity=server, group=core, key=core.proxy_http)
 	// If this option is not specified, the daemon falls back to the `HTTP_PROXY` environment variable (if set).
 	// ---
@@ -715,6 +846,27 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Hosts that don't need the proxy
 
 	"core.proxy_ignore_hosts": {},
+
+	// gendoc:generate(entity=server, group=core, key=core.rate_limit.global)
+	// Requests beyond this rate get a `429 Too Many Requests` error.
+	// The limit applies to each cluster member, and doesn't cover the requests made through the local Unix socket or between cluster members.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0` (unlimited)
+	//  shortdesc: Maximum number of API requests per second from all clients
+	"core.rate_limit.global": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsUint32)},
+
+	// gendoc:generate(entity=server, group=core, key=core.rate_limit.identity)
+	// Requests of an identity beyond this rate get a `429 Too Many Requests` error.
+	// The limit applies to each cluster member, and doesn't cover the requests made through the local Unix socket or between cluster members.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0` (unlimited)
+	//  shortdesc: Maximum number of API requests per second from each identity
+	"core.rate_limit.identity": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsUint32)},
+
 	// gendoc:generate(entity=server, group=core, key=core.remote_token_expiry)
 	//
 	// ---
@@ -733,6 +885,16 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: How long to wait before shutdown
 	"core.shutdown_timeout": {Type: config.Int64, Default: "5"},
 
+	// gendoc:generate(entity=server, group=core, key=core.slow_query_threshold)
+	// Specify the number of milliseconds above which the queries of the cluster database are recorded, with their parameters and call site.
+	// The last 1000 slow queries of each cluster member are listed by `incus admin sql --slow-log`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0` (disabled)
+	//  shortdesc: Duration above which the database queries are recorded
+	"core.slow_query_threshold": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsUint32)},
+
 	// gendoc:generate(entity=server, group=core, key=core.trust_ca_certificates)
 	//
 	// ---
@@ -838,7 +1000,7 @@ var ConfigSchema = config.Schema{
 	//  type: string
 	//  scope: global
 	//  shortdesc: Password used for Loki authentication
-	"loki.auth.password": {Deprecated: "Use 'logging.*.target.password' instead"},
+	"loki.auth.password": {Validator: secrets.ValidateValue, Deprecated: "Use 'logging.*.target.password' instead"},
 
 	// gendoc:generate(entity=server, group=loki, key=loki.api.ca_cert)
 	//
@@ -898,7 +1060,7 @@ var ConfigSchema = config.Schema{
 	// type: string
 	// scope: global
 	// shortdesc: API token of the OpenFGA server
-	"openfga.api.token": {},
+	"openfga.api.token": {Validator: secrets.ValidateValue},
 
 	// gendoc:generate(entity=server, group=openfga, key=openfga.api.url)
 	//
@@ -956,6 +1118,35 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: OpenID Connect claim to use as the username
 	"oidc.claim": {},
 
+	// gendoc:generate(entity=server, group=oidc, key=oidc.groups.claim)
+	// When set, the members of the groups listed in this claim are made members of the groups of the same name in OpenFGA (see `oidc.groups.mapping`).
+	// The memberships are updated whenever the user authenticates with a token that has different groups.
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: OpenID Connect claim holding the groups of the user
+	"oidc.groups.claim": {},
+
+	// gendoc:generate(entity=server, group=oidc, key=oidc.groups.mapping)
+	// Comma separated list of `<claim group>=<group>` pairs.
+	// When set, only the listed groups of the claim are mapped, to the given OpenFGA groups.
+	// The memberships of the other OpenFGA groups, for example those granted manually, are then left alone.
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: Mapping of the OpenID Connect groups to OpenFGA groups
+	"oidc.groups.mapping": {Validator: validate.Optional(oidcGroupsMappingValidator)},
+
+	// gendoc:generate(entity=server, group=oidc, key=oidc.allowed_sources)
+	// Comma separated list of `<user>=<CIDR>` pairs.
+	// When set, the listed users are only allowed to use the API from the given networks.
+	// A user may be listed multiple times to allow several networks.
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: Networks the OpenID Connect users are restricted to
+	"oidc.allowed_sources": {Validator: validate.Optional(oidcAllowedSourcesValidator)},
+
 	// OVN networking global keys.
 
 	// gendoc:generate(entity=server, group=miscellaneous, key=network.ovn.integration_bridge)
@@ -1034,6 +1225,97 @@ var ConfigSchema = config.Schema{
 	//  scope: global
 	//  shortdesc: LINSTOR SSL client key
 	"storage.linstor.client_key": {Default: ""},
+
+	// gendoc:generate(entity=server, group=tracing, key=tracing.ca_cert)
+	//
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: CA certificate of the OTLP collector
+	"tracing.ca_cert": {},
+
+	// gendoc:generate(entity=server, group=tracing, key=tracing.endpoint)
+	// Spans are sent using the JSON encoding of the OTLP/HTTP protocol, to the `/v1/traces` path unless the URL has one.
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: URL of the OTLP/HTTP collector (for example `http://otel-collector:4318`)
+	"tracing.endpoint": {Validator: validate.Optional(validate.IsRequestURL)},
+
+	// gendoc:generate(entity=server, group=tracing, key=tracing.sample_percentage)
+	// Traces started by clients carrying a W3C trace context follow the sampling decision of the client instead.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `100`
+	//  shortdesc: Percentage of the traces to record
+	"tracing.sample_percentage": {Type: config.Int64, Default: "100", Validator: validate.Optional(validate.IsInRange(0, 100))},
+
+	// gendoc:generate(entity=server, group=warnings, key=warnings.email.from)
+	//
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: Sender address of the warning emails
+	"warnings.email.from": {Validator: validate.Optional(emailValidator)},
+
+	// gendoc:generate(entity=server, group=warnings, key=warnings.email.smtp_address)
+	// The connection is upgraded using `STARTTLS` when the server supports it.
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: Address and port of the SMTP server sending the warning emails
+	"warnings.email.smtp_address": {Validator: validate.Optional(validate.IsListenAddress(true, false, true))},
+
+	// gendoc:generate(entity=server, group=warnings, key=warnings.email.smtp_password)
+	//
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: Password used to authenticate with the SMTP server
+	"warnings.email.smtp_password": {Validator: secrets.ValidateValue},
+
+	// gendoc:generate(entity=server, group=warnings, key=warnings.email.smtp_username)
+	//
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: User name used to authenticate with the SMTP server
+	"warnings.email.smtp_username": {},
+
+	// gendoc:generate(entity=server, group=warnings, key=warnings.email.to)
+	// Specify a comma-separated list of addresses.
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: Recipients of the warning emails
+	"warnings.email.to": {Validator: validate.Optional(validate.IsListOf(emailValidator))},
+
+	// gendoc:generate(entity=server, group=warnings, key=warnings.severity)
+	// Possible values are `low`, `moderate` and `high`.
+	// ---
+	//  type: string
+	//  scope: global
+	//  defaultdesc: `low`
+	//  shortdesc: Minimum severity of the warnings to notify
+	"warnings.severity": {Default: "low", Validator: validate.Optional(validate.IsOneOf("low", "moderate", "high"))},
+
+	// gendoc:generate(entity=server, group=warnings, key=warnings.webhook.url)
+	// A `POST` request with the warning as its JSON body is sent when a warning is raised.
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: URL that the new warnings are sent to
+	"warnings.webhook.url": {Validator: validate.Optional(validate.IsRequestURL)},
+}
+
+func emailValidator(value string) error {
+	_, err := mail.ParseAddress(value)
+	if err != nil {
+		return fmt.Errorf("Invalid email address %q: %w", value, err)
+	}
+
+	return nil
 }
 
 func expiryValidator(value string) error {
@@ -1117,3 +1399,87 @@ func rebalanceThresholdValidator(value string) error {
 
 	return nil
 }
+
+func oidcGroupsMappingValidator(value string) error {
+	_, err := parseOIDCGroupsMapping(value)
+
+	return err
+}
+
+// parseOIDCGroupsMapping parses a comma separated list of "<claim group>=<group>" pairs.
+func parseOIDCGroupsMapping(value string) (map[string]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	mapping := map[string]string{}
+	for _, entry := range strings.Split(value, ",") {
+		claimGroup, group, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		claimGroup = strings.TrimSpace(claimGroup)
+		group = strings.TrimSpace(group)
+
+		if !ok || claimGroup == "" || group == "" {
+			return nil, fmt.Errorf("Invalid group mapping %q", entry)
+		}
+
+		mapping[claimGroup] = group
+	}
+
+	return mapping, nil
+}
+
+func oidcAllowedSourcesValidator(value string) error {
+	_, err := parseOIDCAllowedSources(value)
+
+	return err
+}
+
+// parseOIDCAllowedSources parses a comma separated list of "<user>=<CIDR>" pairs.
+func parseOIDCAllowedSources(value string) (map[string][]*net.IPNet, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	sources := map[string][]*net.IPNet{}
+	for _, entry := range strings.Split(value, ",") {
+		user, cidr, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		user = strings.TrimSpace(user)
+
+		if !ok || user == "" {
+			return nil, fmt.Errorf("Invalid allowed source %q", entry)
+		}
+
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid allowed source %q: %w", entry, err)
+		}
+
+		sources[user] = append(sources[user], network)
+	}
+
+	return sources, nil
+}
+
+func acmeMemberDomainValidator(value string) error {
+	if !strings.Contains(value, "{member}") {
+		return fmt.Errorf("The domain name pattern must contain {member}")
+	}
+
+	return nil
+}
+
+func acmeProviderEnvironmentValidator(value string) error {
+	for _, line := range strings.Split(value, "\n") {
+		_, envValue, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+
+		err := secrets.ValidateValue(strings.TrimSpace(envValue))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
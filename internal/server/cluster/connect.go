@@ -16,6 +16,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/state"
 	storagePools "github.com/lxc/incus/v6/internal/server/storage"
+	"github.com/lxc/incus/v6/internal/server/tracing"
 	"github.com/lxc/incus/v6/internal/version"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/proxy"
@@ -72,6 +73,9 @@ func Connect(address string, networkCert *localtls.CertInfo, serverCert *localtl
 
 			req.Header.Add(request.HeaderForwardedAddress, r.RemoteAddr)
 
+			// Continue the trace of the request on the other member.
+			tracing.Inject(ctx, req.Header)
+
 			return proxy.FromEnvironment(req)
 		}
 
@@ -2,6 +2,7 @@ package cluster
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"sync"
@@ -131,6 +132,26 @@ func ServerEventMode() EventMode {
 	return eventMode
 }
 
+// EventHubStatus returns an error if the server is operating as an event-hub client and isn't connected to any
+// of the event-hub members.
+func EventHubStatus() error {
+	listenersLock.Lock()
+	defer listenersLock.Unlock()
+
+	if eventMode != EventModeHubClient {
+		return nil
+	}
+
+	for _, eventHubAddress := range eventHubAddresses {
+		listener, found := listeners[eventHubAddress]
+		if found && listener.IsActive() {
+			return nil
+		}
+	}
+
+	return errors.New("Not connected to any event-hub member")
+}
+
 // RoleInSlice returns whether or not the rule is within the roles list.
 func RoleInSlice(role db.ClusterRole, roles []db.ClusterRole) bool {
 	for _, r := range roles {
@@ -2,8 +2,11 @@ package cluster
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -45,6 +48,36 @@ func getLoadAvgs() ([]float64, error) {
 	return loadAvgs, nil
 }
 
+// getPressure returns the share of the last 10 seconds during which some tasks were stalled on the resource
+// ("cpu", "memory" or "io"), as reported by the kernel's pressure stall information.
+// Zero is returned on kernels without pressure stall information.
+func getPressure(resource string) (float64, error) {
+	content, err := os.ReadFile(filepath.Join("/proc/pressure", resource))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, unix.EOPNOTSUPP) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "some" {
+			continue
+		}
+
+		value, ok := strings.CutPrefix(fields[1], "avg10=")
+		if !ok {
+			break
+		}
+
+		return strconv.ParseFloat(value, 64)
+	}
+
+	return 0, fmt.Errorf("Failed parsing %s pressure information", resource)
+}
+
 // MemberState retrieves state information about the cluster member.
 func MemberState(ctx context.Context, s *state.State, memberName string) (*api.ClusterMemberState, error) {
 	var err error
@@ -74,6 +107,21 @@ func MemberState(ctx context.Context, s *state.State, memberName string) (*api.C
 		return nil, fmt.Errorf("Failed getting load averages: %w", err)
 	}
 
+	memberState.SysInfo.CPUPressure, err = getPressure("cpu")
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting CPU pressure: %w", err)
+	}
+
+	memberState.SysInfo.MemoryPressure, err = getPressure("memory")
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting memory pressure: %w", err)
+	}
+
+	memberState.SysInfo.IOPressure, err = getPressure("io")
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting IO pressure: %w", err)
+	}
+
 	// Get storage pool states.
 	stateCreated := db.StoragePoolCreated
 
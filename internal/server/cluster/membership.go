@@ -1022,6 +1022,7 @@ func Handover(state *state.State, gateway *Gateway, address string) (string, []d
 // Build an app.RolesChanges object fed with the current cluster state.
 func newRolesChanges(state *state.State, gateway *Gateway, nodes []db.RaftNode, unavailableMembers []string) (*app.RolesChanges, error) {
 	var domains map[string]uint64
+	witnesses := map[string]bool{}
 	err := state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 		var err error
 
@@ -1030,6 +1031,15 @@ func newRolesChanges(state *state.State, gateway *Gateway, nodes []db.RaftNode,
 			return fmt.Errorf("Load failure domains: %w", err)
 		}
 
+		members, err := tx.GetNodes(ctx)
+		if err != nil {
+			return fmt.Errorf("Load cluster members: %w", err)
+		}
+
+		for _, member := range members {
+			witnesses[member.Address] = RoleInSlice(db.ClusterRoleWitness, member.Roles)
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -1043,6 +1053,11 @@ func newRolesChanges(state *state.State, gateway *Gateway, nodes []db.RaftNode,
 			cluster[node.NodeInfo] = &client.NodeMetadata{
 				FailureDomain: domains[node.Address],
 			}
+
+			// Candidates with the lowest weight are preferred, so witnesses get promoted first.
+			if !witnesses[node.Address] {
+				cluster[node.NodeInfo].Weight = 1
+			}
 		} else {
 			cluster[node.NodeInfo] = nil
 		}
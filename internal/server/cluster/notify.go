@@ -23,9 +23,10 @@ type NotifierPolicy int
 
 // Possible notification policies.
 const (
-	NotifyAll    NotifierPolicy = iota // Requires that all nodes are up.
-	NotifyAlive                        // Only notifies nodes that are alive
-	NotifyTryAll                       // Attempt to notify all nodes regardless of state.
+	NotifyAll      NotifierPolicy = iota // Requires that all nodes are up.
+	NotifyAlive                          // Only notifies nodes that are alive
+	NotifyTryAll                         // Attempt to notify all nodes regardless of state.
+	NotifyAllHosts                       // Requires that all nodes but the witnesses, which don't host instances or storage, are up.
 )
 
 // NewNotifier builds a Notifier that can be used to notify other peers using
@@ -65,6 +66,10 @@ func NewNotifier(state *state.State, networkCert *localtls.CertInfo, serverCert
 			continue // Exclude ourselves
 		}
 
+		if policy == NotifyAllHosts && RoleInSlice(db.ClusterRoleWitness, member.Roles) {
+			continue // Exclude the witnesses
+		}
+
 		if member.IsOffline(offlineThreshold) {
 			// Even if the heartbeat timestamp is not recent
 			// enough, let's try to connect to the node, just in
@@ -72,7 +77,7 @@ func NewNotifier(state *state.State, networkCert *localtls.CertInfo, serverCert
 			// and the node is actually up.
 			if !HasConnectivity(networkCert, serverCert, member.Address, true) {
 				switch policy {
-				case NotifyAll:
+				case NotifyAll, NotifyAllHosts:
 					return nil, fmt.Errorf("peer node %s is down", member.Address)
 				case NotifyAlive:
 					continue // Just skip this node
@@ -1,10 +1,12 @@
 package cluster
 
 import (
+	"bytes"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/lxc/incus/v6/internal/server/certificate"
@@ -13,6 +15,35 @@ import (
 	localtls "github.com/lxc/incus/v6/shared/tls"
 )
 
+// additionalNetworkCert is a network certificate trusted in addition to the current one while the cluster
+// certificate is being rotated, so that the connections to members which haven't switched yet (or switched
+// already) keep working.
+var (
+	additionalNetworkCert   *x509.Certificate
+	additionalNetworkCertMu sync.Mutex
+)
+
+// SetAdditionalNetworkCert sets the network certificate to trust in addition to the current one for
+// intra-member connections, or none if nil.
+func SetAdditionalNetworkCert(cert *localtls.CertInfo) error {
+	additionalNetworkCertMu.Lock()
+	defer additionalNetworkCertMu.Unlock()
+
+	if cert == nil {
+		additionalNetworkCert = nil
+		return nil
+	}
+
+	parsed, err := x509.ParseCertificate(cert.KeyPair().Certificate[0])
+	if err != nil {
+		return err
+	}
+
+	additionalNetworkCert = parsed
+
+	return nil
+}
+
 // Return a TLS configuration suitable for establishing intra-member network connections using the server cert.
 func tlsClientConfig(networkCert *localtls.CertInfo, serverCert *localtls.CertInfo) (*tls.Config, error) {
 	if networkCert == nil {
@@ -49,6 +80,49 @@ func tlsClientConfig(networkCert *localtls.CertInfo, serverCert *localtls.CertIn
 		config.ServerName = netCert.DNSNames[0]
 	}
 
+	// During a rotation of the cluster certificate, the other members may present either certificate. As their
+	// names may differ, pin them instead of relying on the server name.
+	additionalNetworkCertMu.Lock()
+	additionalCert := additionalNetworkCert
+	additionalNetworkCertMu.Unlock()
+
+	if additionalCert != nil {
+		rootCAs := config.RootCAs
+		serverName := config.ServerName
+		pinnedCerts := [][]byte{networkKeypair.Certificate[0], additionalCert.Raw}
+
+		config.InsecureSkipVerify = true
+		config.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return fmt.Errorf("No certificate presented by the cluster member")
+			}
+
+			for _, pinnedCert := range pinnedCerts {
+				if bytes.Equal(rawCerts[0], pinnedCert) {
+					return nil
+				}
+			}
+
+			// Fall back to the regular verification, for members using a certificate signed by the cluster CA.
+			cert, err := x509.ParseCertificate(rawCerts[0])
+			if err != nil {
+				return err
+			}
+
+			intermediates := x509.NewCertPool()
+			for _, rawCert := range rawCerts[1:] {
+				intermediate, err := x509.ParseCertificate(rawCert)
+				if err == nil {
+					intermediates.AddCert(intermediate)
+				}
+			}
+
+			_, err = cert.Verify(x509.VerifyOptions{Roots: rootCAs, Intermediates: intermediates, DNSName: serverName})
+
+			return err
+		}
+	}
+
 	return config, nil
 }
 
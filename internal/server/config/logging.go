@@ -6,6 +6,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/lxc/incus/v6/internal/server/secrets"
 	"github.com/lxc/incus/v6/shared/validate"
 )
 
@@ -47,7 +48,7 @@ func GetLoggingRuleForKey(key string) (Key, error) {
 		//  type: string
 		//  scope: global
 		//  shortdesc: Password used for authentication
-		return Key{}, nil
+		return Key{Validator: secrets.ValidateValue}, nil
 	case "target.ca_cert":
 		// gendoc:generate(entity=server, group=logging, key=logging.NAME.target.ca_cert)
 		//
@@ -97,16 +98,26 @@ func GetLoggingRuleForKey(key string) (Key, error) {
 		//  scope: global
 		//  shortdesc: number of delivery retries, default 3
 		return Key{Validator: validate.Optional(), Default: "3"}, nil
+	case "target.buffer":
+		// gendoc:generate(entity=server, group=logging, key=logging.NAME.target.buffer)
+		// Events are dropped, and a warning is logged, when more events wait for delivery than the buffer can hold.
+		// ---
+		//  type: integer
+		//  scope: global
+		//  defaultdesc: `1000`
+		//  shortdesc: Number of events waiting for delivery to the logger
+		return Key{Validator: validate.Optional(validate.IsInRange(1, 1000000)), Default: "1000"}, nil
 	case "types":
 		// gendoc:generate(entity=server, group=logging, key=logging.NAME.types)
 		// Specify a comma-separated list of events to send to the logger.
-		// The events can be any combination of `lifecycle`, `logging`, and `network-acl`.
+		// The events can be any combination of `audit`, `console`, `lifecycle`, `logging`, and `network-acl`.
+		// The `console` events are the lines of output of the consoles of the running instances.
 		// ---
 		//  type: string
 		//  scope: global
 		//  defaultdesc: `lifecycle,logging`
 		//  shortdesc: Events to send to the logger
-		return Key{Validator: validate.Optional(validate.IsListOf(validate.IsOneOf("lifecycle", "logging", "network-acl"))), Default: "lifecycle,logging"}, nil
+		return Key{Validator: validate.Optional(validate.IsListOf(validate.IsOneOf("audit", "console", "lifecycle", "logging", "network-acl"))), Default: "lifecycle,logging"}, nil
 	case "logging.level":
 		// gendoc:generate(entity=server, group=logging, key=logging.NAME.logging.level)
 		//
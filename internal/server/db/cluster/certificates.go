@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/lxc/incus/v6/internal/server/certificate"
 	"github.com/lxc/incus/v6/internal/server/db/query"
@@ -38,13 +39,14 @@ import (
 
 // Certificate is here to pass the certificates content from the database around.
 type Certificate struct {
-	ID          int
-	Fingerprint string `db:"primary=yes"`
-	Type        certificate.Type
-	Name        string
-	Certificate string
-	Restricted  bool
-	Description string
+	ID             int
+	Fingerprint    string `db:"primary=yes"`
+	Type           certificate.Type
+	Name           string
+	Certificate    string
+	Restricted     bool
+	Description    string
+	AllowedSources string
 }
 
 // CertificateFilter specifies potential query parameter fields.
@@ -80,6 +82,11 @@ func (cert *Certificate) ToAPI(ctx context.Context, tx *sql.Tx) (*api.Certificat
 	resp.Type = cert.ToAPIType()
 	resp.Description = cert.Description
 
+	resp.AllowedSources = []string{}
+	if cert.AllowedSources != "" {
+		resp.AllowedSources = strings.Split(cert.AllowedSources, ",")
+	}
+
 	projects, err := GetCertificateProjects(ctx, tx, cert.ID)
 	if err != nil {
 		return nil, err
@@ -16,20 +16,20 @@ import (
 )
 
 var certificateObjects = RegisterStmt(`
-SELECT certificates.id, certificates.fingerprint, certificates.type, certificates.name, certificates.certificate, certificates.restricted, certificates.description
+SELECT certificates.id, certificates.fingerprint, certificates.type, certificates.name, certificates.certificate, certificates.restricted, certificates.description, certificates.allowed_sources
   FROM certificates
   ORDER BY certificates.fingerprint
 `)
 
 var certificateObjectsByID = RegisterStmt(`
-SELECT certificates.id, certificates.fingerprint, certificates.type, certificates.name, certificates.certificate, certificates.restricted, certificates.description
+SELECT certificates.id, certificates.fingerprint, certificates.type, certificates.name, certificates.certificate, certificates.restricted, certificates.description, certificates.allowed_sources
   FROM certificates
   WHERE ( certificates.id = ? )
   ORDER BY certificates.fingerprint
 `)
 
 var certificateObjectsByFingerprint = RegisterStmt(`
-SELECT certificates.id, certificates.fingerprint, certificates.type, certificates.name, certificates.certificate, certificates.restricted, certificates.description
+SELECT certificates.id, certificates.fingerprint, certificates.type, certificates.name, certificates.certificate, certificates.restricted, certificates.description, certificates.allowed_sources
   FROM certificates
   WHERE ( certificates.fingerprint = ? )
   ORDER BY certificates.fingerprint
@@ -41,8 +41,8 @@ SELECT certificates.id FROM certificates
 `)
 
 var certificateCreate = RegisterStmt(`
-INSERT INTO certificates (fingerprint, type, name, certificate, restricted, description)
-  VALUES (?, ?, ?, ?, ?, ?)
+INSERT INTO certificates (fingerprint, type, name, certificate, restricted, description, allowed_sources)
+  VALUES (?, ?, ?, ?, ?, ?, ?)
 `)
 
 var certificateDeleteByFingerprint = RegisterStmt(`
@@ -55,14 +55,14 @@ DELETE FROM certificates WHERE name = ? AND type = ?
 
 var certificateUpdate = RegisterStmt(`
 UPDATE certificates
-  SET fingerprint = ?, type = ?, name = ?, certificate = ?, restricted = ?, description = ?
+  SET fingerprint = ?, type = ?, name = ?, certificate = ?, restricted = ?, description = ?, allowed_sources = ?
  WHERE id = ?
 `)
 
 // certificateColumns returns a string of column names to be used with a SELECT statement for the entity.
 // Use this function when building statements to retrieve database entries matching the Certificate entity.
 func certificateColumns() string {
-	return "certificates.id, certificates.fingerprint, certificates.type, certificates.name, certificates.certificate, certificates.restricted, certificates.description"
+	return "certificates.id, certificates.fingerprint, certificates.type, certificates.name, certificates.certificate, certificates.restricted, certificates.description, certificates.allowed_sources"
 }
 
 // getCertificates can be used to run handwritten sql.Stmts to return a slice of objects.
@@ -71,7 +71,7 @@ func getCertificates(ctx context.Context, stmt *sql.Stmt, args ...any) ([]Certif
 
 	dest := func(scan func(dest ...any) error) error {
 		c := Certificate{}
-		err := scan(&c.ID, &c.Fingerprint, &c.Type, &c.Name, &c.Certificate, &c.Restricted, &c.Description)
+		err := scan(&c.ID, &c.Fingerprint, &c.Type, &c.Name, &c.Certificate, &c.Restricted, &c.Description, &c.AllowedSources)
 		if err != nil {
 			return err
 		}
@@ -95,7 +95,7 @@ func getCertificatesRaw(ctx context.Context, db dbtx, sql string, args ...any) (
 
 	dest := func(scan func(dest ...any) error) error {
 		c := Certificate{}
-		err := scan(&c.ID, &c.Fingerprint, &c.Type, &c.Name, &c.Certificate, &c.Restricted, &c.Description)
+		err := scan(&c.ID, &c.Fingerprint, &c.Type, &c.Name, &c.Certificate, &c.Restricted, &c.Description, &c.AllowedSources)
 		if err != nil {
 			return err
 		}
@@ -292,7 +292,7 @@ func CreateCertificate(ctx context.Context, db dbtx, object Certificate) (_ int6
 		_err = mapErr(_err, "Certificate")
 	}()
 
-	args := make([]any, 6)
+	args := make([]any, 7)
 
 	// Populate the statement arguments.
 	args[0] = object.Fingerprint
@@ -301,6 +301,7 @@ func CreateCertificate(ctx context.Context, db dbtx, object Certificate) (_ int6
 	args[3] = object.Certificate
 	args[4] = object.Restricted
 	args[5] = object.Description
+	args[6] = object.AllowedSources
 
 	// Prepared statement to use.
 	stmt, err := Stmt(db, certificateCreate)
@@ -402,7 +403,7 @@ func UpdateCertificate(ctx context.Context, db tx, fingerprint string, object Ce
 		return fmt.Errorf("Failed to get \"certificateUpdate\" prepared statement: %w", err)
 	}
 
-	result, err := stmt.Exec(object.Fingerprint, object.Type, object.Name, object.Certificate, object.Restricted, object.Description, id)
+	result, err := stmt.Exec(object.Fingerprint, object.Type, object.Name, object.Certificate, object.Restricted, object.Description, object.AllowedSources, id)
 	if err != nil {
 		return fmt.Errorf("Update \"certificates\" entry failed: %w", err)
 	}
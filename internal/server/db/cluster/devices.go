@@ -53,6 +53,8 @@ const (
 	TypeUnixHotplug = DeviceType(9)
 	TypeTPM         = DeviceType(10)
 	TypePCI         = DeviceType(11)
+	TypeAudio       = DeviceType(12)
+	TypeInput       = DeviceType(13)
 )
 
 func (t DeviceType) String() string {
@@ -81,6 +83,10 @@ func (t DeviceType) String() string {
 		return "tpm"
 	case TypePCI:
 		return "pci"
+	case TypeAudio:
+		return "audio"
+	case TypeInput:
+		return "input"
 	}
 
 	return ""
@@ -113,6 +119,10 @@ func NewDeviceType(t string) (DeviceType, error) {
 		return TypeTPM, nil
 	case "pci":
 		return TypePCI, nil
+	case "audio":
+		return TypeAudio, nil
+	case "input":
+		return TypeInput, nil
 	default:
 		return -1, fmt.Errorf("Invalid device type %q", t)
 	}
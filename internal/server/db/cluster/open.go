@@ -27,14 +27,17 @@ import (
 //
 // The dialer argument is a function that returns a gRPC dialer that can be
 // used to connect to a database node using the gRPC SQL package.
-func Open(name string, store driver.NodeStore, options ...driver.Option) (*sql.DB, error) {
+//
+// The slowLog argument records the queries of the database taking longer than
+// its threshold.
+func Open(name string, store driver.NodeStore, slowLog *query.SlowLog, options ...driver.Option) (*sql.DB, error) {
 	driver, err := driver.New(store, options...)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to create dqlite driver: %w", err)
 	}
 
 	driverName := dqliteDriverName()
-	sql.Register(driverName, driver)
+	sql.Register(driverName, slowLog.Wrap(driver))
 
 	// Create the cluster db. This won't immediately establish any network
 	// connection, that will happen only when a db transaction is started
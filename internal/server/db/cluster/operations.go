@@ -32,6 +32,7 @@ type Operation struct {
 	ProjectID   *int64             // ID of the project for the operation.
 	NodeID      int64              // ID of the node the operation is running on
 	Type        operationtype.Type // Type of the operation
+	Resources   string             // JSON encoded resources affected by the operation
 }
 
 // OperationFilter specifies potential query parameter fields.
@@ -5,6 +5,9 @@ package cluster
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/lxc/incus/v6/internal/server/device/config"
 	"github.com/lxc/incus/v6/shared/api"
@@ -42,6 +45,7 @@ type Profile struct {
 	Project     string `db:"primary=yes&join=projects.name"`
 	Name        string `db:"primary=yes"`
 	Description string `db:"coalesce=''"`
+	Extends     string
 }
 
 // ProfileFilter specifies potential query parameter fields.
@@ -51,8 +55,35 @@ type ProfileFilter struct {
 	Name    *string
 }
 
-// ToAPI returns a cluster Profile as an API struct.
+// ToAPI returns a cluster Profile as an API struct, including its expanded configuration and devices if it
+// extends another profile.
 func (p *Profile) ToAPI(ctx context.Context, tx *sql.Tx, profileConfigs map[int]map[string]string, profileDevices map[int][]Device) (*api.Profile, error) {
+	profile, err := p.toAPI(ctx, tx, profileConfigs, profileDevices)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Extends == "" {
+		return profile, nil
+	}
+
+	profile.ExpandedConfig, profile.ExpandedDevices, err = ExpandProfile(*profile, func(name string) (*api.Profile, error) {
+		base, err := GetProfile(ctx, tx, p.Project, name)
+		if err != nil {
+			return nil, fmt.Errorf("Failed loading profile %q: %w", name, err)
+		}
+
+		return base.toAPI(ctx, tx, profileConfigs, profileDevices)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// toAPI returns a cluster Profile as an API struct, without expanding it.
+func (p *Profile) toAPI(ctx context.Context, tx *sql.Tx, profileConfigs map[int]map[string]string, profileDevices map[int][]Device) (*api.Profile, error) {
 	var err error
 
 	var dbConfig map[string]string
@@ -88,6 +119,7 @@ func (p *Profile) ToAPI(ctx context.Context, tx *sql.Tx, profileConfigs map[int]
 			Description: p.Description,
 			Config:      dbConfig,
 			Devices:     DevicesToAPI(dbDevices),
+			Extends:     p.Extends,
 		},
 		Project: p.Project,
 	}
@@ -95,6 +127,53 @@ func (p *Profile) ToAPI(ctx context.Context, tx *sql.Tx, profileConfigs map[int]
 	return profile, nil
 }
 
+// ExpandProfile returns the configuration and devices of a profile merged with the ones of the profiles it
+// extends, the extending profile taking precedence. The getProfile function loads the unexpanded profiles by
+// name.
+func ExpandProfile(profile api.Profile, getProfile func(name string) (*api.Profile, error)) (map[string]string, map[string]map[string]string, error) {
+	chain, err := ProfileChain(profile, getProfile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	expandedConfig := map[string]string{}
+	expandedDevices := map[string]map[string]string{}
+	for _, p := range chain {
+		maps.Copy(expandedConfig, p.Config)
+
+		for devName, device := range p.Devices {
+			expandedDevices[devName] = maps.Clone(device)
+		}
+	}
+
+	return expandedConfig, expandedDevices, nil
+}
+
+// ProfileChain returns the profiles extended by a profile, starting from the base one and ending with the
+// profile itself. The getProfile function loads the profiles by name.
+func ProfileChain(profile api.Profile, getProfile func(name string) (*api.Profile, error)) ([]api.Profile, error) {
+	chain := []api.Profile{profile}
+
+	name := profile.Extends
+	for name != "" {
+		if slices.ContainsFunc(chain, func(p api.Profile) bool { return p.Name == name }) {
+			return nil, fmt.Errorf("Profile %q can't extend %q as it would form a loop", profile.Name, profile.Extends)
+		}
+
+		base, err := getProfile(name)
+		if err != nil {
+			return nil, err
+		}
+
+		chain = append(chain, *base)
+		name = base.Extends
+	}
+
+	slices.Reverse(chain)
+
+	return chain, nil
+}
+
 // GetProfilesIfEnabled returns the profiles from the given project, or the
 // default project if "features.profiles" is not set.
 func GetProfilesIfEnabled(ctx context.Context, tx *sql.Tx, projectName string, names []string) ([]Profile, error) {
@@ -129,6 +208,9 @@ func ExpandInstanceConfig(config map[string]string, profiles []api.Profile) map[
 	profileConfigs := make([]map[string]string, len(profiles))
 	for i, profile := range profiles {
 		profileConfigs[i] = profile.Config
+		if profile.ExpandedConfig != nil {
+			profileConfigs[i] = profile.ExpandedConfig
+		}
 	}
 
 	for i := range profileConfigs {
@@ -154,6 +236,9 @@ func ExpandInstanceDevices(devices config.Devices, profiles []api.Profile) confi
 	profileDevices := make([]config.Devices, len(profiles))
 	for i, profile := range profiles {
 		profileDevices[i] = config.NewDevices(profile.Devices)
+		if profile.ExpandedDevices != nil {
+			profileDevices[i] = config.NewDevices(profile.ExpandedDevices)
+		}
 	}
 
 	for i := range profileDevices {
@@ -15,14 +15,14 @@ import (
 )
 
 var profileObjects = RegisterStmt(`
-SELECT profiles.id, profiles.project_id, projects.name AS project, profiles.name, coalesce(profiles.description, '')
+SELECT profiles.id, profiles.project_id, projects.name AS project, profiles.name, coalesce(profiles.description, ''), profiles.extends
   FROM profiles
   JOIN projects ON profiles.project_id = projects.id
   ORDER BY projects.id, profiles.name
 `)
 
 var profileObjectsByID = RegisterStmt(`
-SELECT profiles.id, profiles.project_id, projects.name AS project, profiles.name, coalesce(profiles.description, '')
+SELECT profiles.id, profiles.project_id, projects.name AS project, profiles.name, coalesce(profiles.description, ''), profiles.extends
   FROM profiles
   JOIN projects ON profiles.project_id = projects.id
   WHERE ( profiles.id = ? )
@@ -30,7 +30,7 @@ SELECT profiles.id, profiles.project_id, projects.name AS project, profiles.name
 `)
 
 var profileObjectsByName = RegisterStmt(`
-SELECT profiles.id, profiles.project_id, projects.name AS project, profiles.name, coalesce(profiles.description, '')
+SELECT profiles.id, profiles.project_id, projects.name AS project, profiles.name, coalesce(profiles.description, ''), profiles.extends
   FROM profiles
   JOIN projects ON profiles.project_id = projects.id
   WHERE ( profiles.name = ? )
@@ -38,7 +38,7 @@ SELECT profiles.id, profiles.project_id, projects.name AS project, profiles.name
 `)
 
 var profileObjectsByProject = RegisterStmt(`
-SELECT profiles.id, profiles.project_id, projects.name AS project, profiles.name, coalesce(profiles.description, '')
+SELECT profiles.id, profiles.project_id, projects.name AS project, profiles.name, coalesce(profiles.description, ''), profiles.extends
   FROM profiles
   JOIN projects ON profiles.project_id = projects.id
   WHERE ( project = ? )
@@ -46,7 +46,7 @@ SELECT profiles.id, profiles.project_id, projects.name AS project, profiles.name
 `)
 
 var profileObjectsByProjectAndName = RegisterStmt(`
-SELECT profiles.id, profiles.project_id, projects.name AS project, profiles.name, coalesce(profiles.description, '')
+SELECT profiles.id, profiles.project_id, projects.name AS project, profiles.name, coalesce(profiles.description, ''), profiles.extends
   FROM profiles
   JOIN projects ON profiles.project_id = projects.id
   WHERE ( project = ? AND profiles.name = ? )
@@ -60,8 +60,8 @@ SELECT profiles.id FROM profiles
 `)
 
 var profileCreate = RegisterStmt(`
-INSERT INTO profiles (project_id, name, description)
-  VALUES ((SELECT projects.id FROM projects WHERE projects.name = ?), ?, ?)
+INSERT INTO profiles (project_id, name, description, extends)
+  VALUES ((SELECT projects.id FROM projects WHERE projects.name = ?), ?, ?, ?)
 `)
 
 var profileRename = RegisterStmt(`
@@ -70,7 +70,7 @@ UPDATE profiles SET name = ? WHERE project_id = (SELECT projects.id FROM project
 
 var profileUpdate = RegisterStmt(`
 UPDATE profiles
-  SET project_id = (SELECT projects.id FROM projects WHERE projects.name = ?), name = ?, description = ?
+  SET project_id = (SELECT projects.id FROM projects WHERE projects.name = ?), name = ?, description = ?, extends = ?
  WHERE id = ?
 `)
 
@@ -133,7 +133,7 @@ func ProfileExists(ctx context.Context, db dbtx, project string, name string) (_
 // profileColumns returns a string of column names to be used with a SELECT statement for the entity.
 // Use this function when building statements to retrieve database entries matching the Profile entity.
 func profileColumns() string {
-	return "profiles.id, profiles.project_id, projects.name AS project, profiles.name, coalesce(profiles.description, '')"
+	return "profiles.id, profiles.project_id, projects.name AS project, profiles.name, coalesce(profiles.description, ''), profiles.extends"
 }
 
 // getProfiles can be used to run handwritten sql.Stmts to return a slice of objects.
@@ -142,7 +142,7 @@ func getProfiles(ctx context.Context, stmt *sql.Stmt, args ...any) ([]Profile, e
 
 	dest := func(scan func(dest ...any) error) error {
 		p := Profile{}
-		err := scan(&p.ID, &p.ProjectID, &p.Project, &p.Name, &p.Description)
+		err := scan(&p.ID, &p.ProjectID, &p.Project, &p.Name, &p.Description, &p.Extends)
 		if err != nil {
 			return err
 		}
@@ -166,7 +166,7 @@ func getProfilesRaw(ctx context.Context, db dbtx, sql string, args ...any) ([]Pr
 
 	dest := func(scan func(dest ...any) error) error {
 		p := Profile{}
-		err := scan(&p.ID, &p.ProjectID, &p.Project, &p.Name, &p.Description)
+		err := scan(&p.ID, &p.ProjectID, &p.Project, &p.Name, &p.Description, &p.Extends)
 		if err != nil {
 			return err
 		}
@@ -405,12 +405,13 @@ func CreateProfile(ctx context.Context, db dbtx, object Profile) (_ int64, _err
 		_err = mapErr(_err, "Profile")
 	}()
 
-	args := make([]any, 3)
+	args := make([]any, 4)
 
 	// Populate the statement arguments.
 	args[0] = object.Project
 	args[1] = object.Name
 	args[2] = object.Description
+	args[3] = object.Extends
 
 	// Prepared statement to use.
 	stmt, err := Stmt(db, profileCreate)
@@ -530,7 +531,7 @@ func UpdateProfile(ctx context.Context, db tx, project string, name string, obje
 		return fmt.Errorf("Failed to get \"profileUpdate\" prepared statement: %w", err)
 	}
 
-	result, err := stmt.Exec(object.Project, object.Name, object.Description, id)
+	result, err := stmt.Exec(object.Project, object.Name, object.Description, object.Extends, id)
 	if err != nil {
 		return fmt.Errorf("Update \"profiles\" entry failed: %w", err)
 	}
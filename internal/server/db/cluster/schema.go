@@ -14,6 +14,7 @@ CREATE TABLE certificates (
     certificate TEXT NOT NULL,
     restricted INTEGER NOT NULL DEFAULT 0,
     description TEXT NOT NULL DEFAULT "",
+    allowed_sources TEXT NOT NULL DEFAULT "",
     UNIQUE (fingerprint)
 );
 CREATE TABLE "certificates_projects" (
@@ -433,6 +434,7 @@ CREATE TABLE "operations" (
     node_id TEXT NOT NULL,
     type INTEGER NOT NULL DEFAULT 0,
     project_id INTEGER,
+    resources TEXT NOT NULL DEFAULT "",
     UNIQUE (uuid),
     FOREIGN KEY (node_id) REFERENCES "nodes" (id) ON DELETE CASCADE,
     FOREIGN KEY (project_id) REFERENCES "projects" (id) ON DELETE CASCADE
@@ -442,6 +444,7 @@ CREATE TABLE "profiles" (
     name TEXT NOT NULL,
     description TEXT NOT NULL,
     project_id INTEGER NOT NULL,
+    extends TEXT NOT NULL DEFAULT "",
     UNIQUE (project_id, name),
     FOREIGN KEY (project_id) REFERENCES "projects" (id) ON DELETE CASCADE
 );
@@ -484,6 +487,33 @@ CREATE TABLE "projects_config" (
     FOREIGN KEY (project_id) REFERENCES "projects" (id) ON DELETE CASCADE,
     UNIQUE (project_id, key)
 );
+CREATE TABLE scheduled_tasks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    schedule TEXT NOT NULL,
+    action TEXT NOT NULL,
+    filter TEXT NOT NULL,
+    UNIQUE (name)
+);
+CREATE TABLE scheduled_tasks_config (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    scheduled_task_id INTEGER NOT NULL,
+    key TEXT NOT NULL,
+    value TEXT NOT NULL,
+    FOREIGN KEY (scheduled_task_id) REFERENCES scheduled_tasks (id) ON DELETE CASCADE,
+    UNIQUE (scheduled_task_id, key)
+);
+CREATE TABLE scheduled_tasks_runs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    scheduled_task_id INTEGER NOT NULL,
+    node_id INTEGER NOT NULL,
+    date DATETIME NOT NULL,
+    status TEXT NOT NULL,
+    message TEXT NOT NULL,
+    FOREIGN KEY (scheduled_task_id) REFERENCES scheduled_tasks (id) ON DELETE CASCADE,
+    FOREIGN KEY (node_id) REFERENCES nodes (id) ON DELETE CASCADE
+);
 CREATE TABLE "storage_buckets" (
     id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
     name TEXT NOT NULL,
@@ -668,11 +698,13 @@ CREATE TABLE "warnings" (
     updated_date DATETIME,
     last_message TEXT NOT NULL,
     count INTEGER NOT NULL,
+    acknowledged_until DATETIME,
+    notified INTEGER NOT NULL DEFAULT 0,
     UNIQUE (uuid),
     FOREIGN KEY (node_id) REFERENCES "nodes"(id) ON DELETE CASCADE,
     FOREIGN KEY (project_id) REFERENCES "projects" (id) ON DELETE CASCADE
 );
 CREATE UNIQUE INDEX warnings_unique_node_id_project_id_entity_type_code_entity_id_type_code ON warnings(IFNULL(node_id, -1), IFNULL(project_id, -1), entity_type_code, entity_id, type_code);
 
-INSERT INTO schema (version, updated_at) VALUES (76, strftime("%s"))
+INSERT INTO schema (version, updated_at) VALUES (81, strftime("%s"))
 `
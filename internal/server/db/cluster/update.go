@@ -113,6 +113,95 @@ var updates = map[int]schema.Update{
 	74: updateFromV73,
 	75: updateFromV74,
 	76: updateFromV75,
+	77: updateFromV76,
+	78: updateFromV77,
+	79: updateFromV78,
+	80: updateFromV79,
+	81: updateFromV80,
+}
+
+// updateFromV80 adds the acknowledgment expiry of the warnings and tracks which of them were notified.
+func updateFromV80(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+ALTER TABLE warnings ADD COLUMN acknowledged_until DATETIME;
+ALTER TABLE warnings ADD COLUMN notified INTEGER NOT NULL DEFAULT 0;
+UPDATE warnings SET notified = 1;
+`)
+	if err != nil {
+		return fmt.Errorf("Failed adding acknowledged_until and notified columns to warnings table: %w", err)
+	}
+
+	return nil
+}
+
+// updateFromV79 adds the scheduled tasks and their run history.
+func updateFromV79(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+CREATE TABLE scheduled_tasks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    schedule TEXT NOT NULL,
+    action TEXT NOT NULL,
+    filter TEXT NOT NULL,
+    UNIQUE (name)
+);
+
+CREATE TABLE scheduled_tasks_config (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    scheduled_task_id INTEGER NOT NULL,
+    key TEXT NOT NULL,
+    value TEXT NOT NULL,
+    FOREIGN KEY (scheduled_task_id) REFERENCES scheduled_tasks (id) ON DELETE CASCADE,
+    UNIQUE (scheduled_task_id, key)
+);
+
+CREATE TABLE scheduled_tasks_runs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    scheduled_task_id INTEGER NOT NULL,
+    node_id INTEGER NOT NULL,
+    date DATETIME NOT NULL,
+    status TEXT NOT NULL,
+    message TEXT NOT NULL,
+    FOREIGN KEY (scheduled_task_id) REFERENCES scheduled_tasks (id) ON DELETE CASCADE,
+    FOREIGN KEY (node_id) REFERENCES nodes (id) ON DELETE CASCADE
+);
+`)
+	if err != nil {
+		return fmt.Errorf("Failed creating scheduled tasks tables: %w", err)
+	}
+
+	return nil
+}
+
+// updateFromV78 adds the resources of the operations, used to roll them back after a crash.
+func updateFromV78(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE operations ADD COLUMN resources TEXT NOT NULL DEFAULT "";`)
+	if err != nil {
+		return fmt.Errorf("Failed adding resources column to operations table: %w", err)
+	}
+
+	return nil
+}
+
+// updateFromV77 adds the source addresses a certificate can be used from.
+func updateFromV77(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE certificates ADD COLUMN allowed_sources TEXT NOT NULL DEFAULT "";`)
+	if err != nil {
+		return fmt.Errorf("Failed adding allowed_sources column to certificates table: %w", err)
+	}
+
+	return nil
+}
+
+// updateFromV76 adds the profile a profile extends.
+func updateFromV76(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE profiles ADD COLUMN extends TEXT NOT NULL DEFAULT "";`)
+	if err != nil {
+		return fmt.Errorf("Failed adding extends column to profiles table: %w", err)
+	}
+
+	return nil
 }
 
 func updateFromV75(ctx context.Context, tx *sql.Tx) error {
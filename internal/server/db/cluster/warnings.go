@@ -3,6 +3,7 @@
 package cluster
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/lxc/incus/v6/internal/server/db/warningtype"
@@ -34,19 +35,20 @@ import (
 
 // Warning is a value object holding db-related details about a warning.
 type Warning struct {
-	ID             int
-	Node           string `db:"coalesce=''&leftjoin=nodes.name"`
-	Project        string `db:"coalesce=''&leftjoin=projects.name"`
-	EntityTypeCode int    `db:"coalesce=-1"`
-	EntityID       int    `db:"coalesce=-1"`
-	UUID           string `db:"primary=yes"`
-	TypeCode       warningtype.Type
-	Status         warningtype.Status
-	FirstSeenDate  time.Time
-	LastSeenDate   time.Time
-	UpdatedDate    time.Time
-	LastMessage    string
-	Count          int
+	ID                int
+	Node              string `db:"coalesce=''&leftjoin=nodes.name"`
+	Project           string `db:"coalesce=''&leftjoin=projects.name"`
+	EntityTypeCode    int    `db:"coalesce=-1"`
+	EntityID          int    `db:"coalesce=-1"`
+	UUID              string `db:"primary=yes"`
+	TypeCode          warningtype.Type
+	Status            warningtype.Status
+	FirstSeenDate     time.Time
+	LastSeenDate      time.Time
+	UpdatedDate       time.Time
+	LastMessage       string
+	Count             int
+	AcknowledgedUntil sql.NullTime
 }
 
 // WarningFilter specifies potential query parameter fields.
@@ -65,9 +67,15 @@ type WarningFilter struct {
 func (w Warning) ToAPI() api.Warning {
 	typeCode := warningtype.Type(w.TypeCode)
 
+	var acknowledgedUntil *time.Time
+	if w.AcknowledgedUntil.Valid {
+		acknowledgedUntil = &w.AcknowledgedUntil.Time
+	}
+
 	return api.Warning{
 		WarningPut: api.WarningPut{
-			Status: warningtype.Statuses[warningtype.Status(w.Status)],
+			Status:            warningtype.Statuses[warningtype.Status(w.Status)],
+			AcknowledgedUntil: acknowledgedUntil,
 		},
 		UUID:        w.UUID,
 		Location:    w.Node,
@@ -15,10 +15,12 @@ import (
 	"time"
 
 	"github.com/cowsql/go-cowsql/driver"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/lxc/incus/v6/internal/server/db/cluster"
 	"github.com/lxc/incus/v6/internal/server/db/node"
 	"github.com/lxc/incus/v6/internal/server/db/query"
+	"github.com/lxc/incus/v6/internal/server/tracing"
 	internalUtil "github.com/lxc/incus/v6/internal/util"
 	"github.com/lxc/incus/v6/shared/logger"
 )
@@ -97,11 +99,19 @@ func (n *Node) Dir() string {
 // function returns no error, all database changes are committed to the
 // node-level database, otherwise they are rolled back.
 func (n *Node) Transaction(ctx context.Context, f func(context.Context, *NodeTx) error) error {
+	ctx, span := tracing.Start(ctx, "db.NodeTransaction", attribute.String("db.system", "sqlite"))
+	if span.IsRecording() {
+		span.SetAttributes(tracing.Caller(1))
+	}
+
 	nodeTx := &NodeTx{}
-	return query.Transaction(ctx, n.db, func(ctx context.Context, tx *sql.Tx) error {
+	err := query.Transaction(ctx, n.db, func(ctx context.Context, tx *sql.Tx) error {
 		nodeTx.tx = tx
 		return f(ctx, nodeTx)
 	})
+	tracing.End(span, err)
+
+	return err
 }
 
 // Close the database facade.
@@ -111,8 +121,9 @@ func (n *Node) Close() error {
 
 // Cluster mediates access to data stored in the cluster dqlite database.
 type Cluster struct {
-	db         *sql.DB // Handle to the cluster dqlite database, gated behind gRPC SQL.
-	nodeID     int64   // Node ID of this server.
+	db         *sql.DB        // Handle to the cluster dqlite database, gated behind gRPC SQL.
+	nodeID     int64          // Node ID of this server.
+	slowLog    *query.SlowLog // Slow queries of the cluster database.
 	mu         sync.RWMutex
 	closingCtx context.Context
 }
@@ -132,7 +143,9 @@ type Cluster struct {
 // behind, an Upgrading error is returned.
 // Accepts a closingCtx context argument used to indicate when the daemon is shutting down.
 func OpenCluster(closingCtx context.Context, name string, store driver.NodeStore, address, dir string, timeout time.Duration, options ...driver.Option) (*Cluster, error) {
-	db, err := cluster.Open(name, store, options...)
+	slowLog := query.NewSlowLog()
+
+	db, err := cluster.Open(name, store, slowLog, options...)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to open database: %w", err)
 	}
@@ -198,6 +211,7 @@ func OpenCluster(closingCtx context.Context, name string, store driver.NodeStore
 	if !nodesVersionsMatch {
 		cluster := &Cluster{
 			db:         db,
+			slowLog:    slowLog,
 			closingCtx: closingCtx,
 		}
 
@@ -213,6 +227,7 @@ func OpenCluster(closingCtx context.Context, name string, store driver.NodeStore
 
 	clusterDB := &Cluster{
 		db:         db,
+		slowLog:    slowLog,
 		closingCtx: closingCtx,
 	}
 
@@ -300,9 +315,17 @@ func (c *Cluster) GetNodeID() int64 {
 // If EnterExclusive has been called before, calling Transaction will block
 // until ExitExclusive has been called as well to release the lock.
 func (c *Cluster) Transaction(ctx context.Context, f func(context.Context, *ClusterTx) error) error {
+	ctx, span := tracing.Start(ctx, "db.ClusterTransaction", attribute.String("db.system", "dqlite"))
+	if span.IsRecording() {
+		span.SetAttributes(tracing.Caller(1))
+	}
+
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return c.transaction(ctx, f)
+	err := c.transaction(ctx, f)
+	tracing.End(span, err)
+
+	return err
 }
 
 // EnterExclusive acquires a lock on the cluster db, so any successive call to
@@ -382,6 +405,11 @@ func (c *Cluster) DB() *sql.DB {
 	return c.db
 }
 
+// SlowLog returns the log of the slow queries of the cluster database, nil if not recorded.
+func (c *Cluster) SlowLog() *query.SlowLog {
+	return c.slowLog
+}
+
 // Begin a new transaction against the cluster database.
 //
 // FIXME: legacy method.
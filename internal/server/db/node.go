@@ -40,6 +40,9 @@ const ClusterRoleEventHub = ClusterRole("event-hub")
 // ClusterRoleOVNChassis represents a cluster member who operates as an OVN chassis.
 const ClusterRoleOVNChassis = ClusterRole("ovn-chassis")
 
+// ClusterRoleWitness represents a cluster member which only takes part in database voting.
+const ClusterRoleWitness = ClusterRole("witness")
+
 // ClusterRoles maps role ids into human-readable names.
 //
 // Note: the database role is currently stored directly in the raft
@@ -48,6 +51,7 @@ const ClusterRoleOVNChassis = ClusterRole("ovn-chassis")
 var ClusterRoles = map[int]ClusterRole{
 	1: ClusterRoleEventHub,
 	2: ClusterRoleOVNChassis,
+	3: ClusterRoleWitness,
 }
 
 // Numeric type codes identifying different cluster member states.
@@ -1057,25 +1061,96 @@ func (c *ClusterTx) GetNodeOfflineThreshold(ctx context.Context) (time.Duration,
 	return threshold, nil
 }
 
+// GetClusterGroupsConfig returns the configuration of all cluster groups, indexed by group name.
+func (c *ClusterTx) GetClusterGroupsConfig(ctx context.Context) (map[string]map[string]string, error) {
+	groups, err := cluster.GetClusterGroups(ctx, c.Tx())
+	if err != nil {
+		return nil, err
+	}
+
+	groupsConfig := make(map[string]map[string]string, len(groups))
+	for _, group := range groups {
+		groupsConfig[group.Name], err = cluster.GetClusterGroupConfig(ctx, c.Tx(), group.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return groupsConfig, nil
+}
+
+// GetNodeClusterGroupsConfigValue returns the value of a configuration key as set by the cluster groups of a member.
+// See ClusterGroupsConfigValue for how conflicting groups are handled.
+func (c *ClusterTx) GetNodeClusterGroupsConfigValue(ctx context.Context, name string, key string) (string, error) {
+	member, err := c.GetNodeByName(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	groupsConfig, err := c.GetClusterGroupsConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return ClusterGroupsConfigValue(groupsConfig, member.Groups, key), nil
+}
+
+// ClusterGroupsConfigValue returns the value of a configuration key for a member of the given cluster groups.
+// If several groups set the key, the first one in alphabetical order wins.
+func ClusterGroupsConfigValue(groupsConfig map[string]map[string]string, groups []string, key string) string {
+	groups = slices.Clone(groups)
+	slices.Sort(groups)
+
+	for _, group := range groups {
+		value := groupsConfig[group][key]
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// SchedulerInstance returns the scheduling mode of the member, falling back to the one of its cluster groups.
+func (n NodeInfo) SchedulerInstance(groupsConfig map[string]map[string]string) string {
+	value := n.Config["scheduler.instance"]
+	if value != "" {
+		return value
+	}
+
+	return ClusterGroupsConfigValue(groupsConfig, n.Groups, "scheduler.instance")
+}
+
 // GetCandidateMembers returns cluster members that are online, in created state and don't need manual targeting.
 // It excludes members that do not support any of the targetArchitectures (if non-nil) or not in targetClusterGroup
 // (if non-empty). It also takes into account any restrictions on allowedClusterGroups (if non-nil).
 func (c *ClusterTx) GetCandidateMembers(ctx context.Context, allMembers []NodeInfo, targetArchitectures []int, targetClusterGroup string, allowedClusterGroups []string, offlineThreshold time.Duration) ([]NodeInfo, error) {
 	var candidateMembers []NodeInfo
 
+	groupsConfig, err := c.GetClusterGroupsConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, member := range allMembers {
 		// Skip pending, evacuated or offline members.
 		if member.State != ClusterMemberStateCreated || member.IsOffline(offlineThreshold) {
 			continue
 		}
 
+		// Skip witnesses as they don't host instances.
+		if slices.Contains(member.Roles, ClusterRoleWitness) {
+			continue
+		}
+
 		// Skip manually targeted members.
-		if member.Config["scheduler.instance"] == "manual" {
+		schedulerInstance := member.SchedulerInstance(groupsConfig)
+		if schedulerInstance == "manual" {
 			continue
 		}
 
 		// Skip group-only members if targeted cluster group doesn't match.
-		if member.Config["scheduler.instance"] == "group" && !slices.Contains(member.Groups, targetClusterGroup) {
+		if schedulerInstance == "group" && !slices.Contains(member.Groups, targetClusterGroup) {
 			continue
 		}
 
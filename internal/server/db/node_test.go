@@ -416,6 +416,27 @@ INSERT INTO instances (id, node_id, name, architecture, type, project_id, descri
 	assert.Equal(t, "none", members[0].Name)
 }
 
+func TestClusterGroupsConfigValue(t *testing.T) {
+	groupsConfig := map[string]map[string]string{
+		"default": {},
+		"gpu":     {"scheduler.instance": "group"},
+		"arm":     {"scheduler.instance": "manual"},
+	}
+
+	assert.Equal(t, "", db.ClusterGroupsConfigValue(groupsConfig, []string{"default"}, "scheduler.instance"))
+	assert.Equal(t, "group", db.ClusterGroupsConfigValue(groupsConfig, []string{"default", "gpu"}, "scheduler.instance"))
+
+	// The first group in alphabetical order wins.
+	assert.Equal(t, "manual", db.ClusterGroupsConfigValue(groupsConfig, []string{"gpu", "arm"}, "scheduler.instance"))
+
+	// The member's own setting takes precedence.
+	member := db.NodeInfo{Config: map[string]string{"scheduler.instance": "all"}, Groups: []string{"gpu"}}
+	assert.Equal(t, "all", member.SchedulerInstance(groupsConfig))
+
+	member.Config = map[string]string{}
+	assert.Equal(t, "group", member.SchedulerInstance(groupsConfig))
+}
+
 func TestUpdateNodeFailureDomain(t *testing.T) {
 	tx, cleanup := db.NewTestClusterTx(t)
 	defer cleanup()
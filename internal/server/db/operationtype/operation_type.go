@@ -79,6 +79,9 @@ const (
 	BucketBackupRemove
 	BucketBackupRename
 	BucketBackupRestore
+	ProjectRemap
+	ScheduledTaskRun
+	Batch
 )
 
 // Description return a human-readable description of the operation type.
@@ -162,6 +165,8 @@ func (t Type) Description() string {
 		return "Renaming storage volume snapshot"
 	case ProjectRename:
 		return "Renaming project"
+	case ProjectRemap:
+		return "Remapping project containers"
 	case ImagesExpire:
 		return "Cleaning up expired images"
 	case ImagesPruneLeftover:
@@ -210,6 +215,10 @@ func (t Type) Description() string {
 		return "Renaming bucket backup"
 	case BucketBackupRestore:
 		return "Restoring bucket backup"
+	case ScheduledTaskRun:
+		return "Running scheduled task"
+	case Batch:
+		return "Running batch"
 	default:
 		return "Executing operation"
 	}
@@ -305,3 +314,18 @@ func (t Type) Permission() (auth.ObjectType, auth.Entitlement) {
 		return "", ""
 	}
 }
+
+// Expensive returns whether operations of this type transfer large amounts of data, so that they are subject to
+// the limit on concurrent operations.
+func (t Type) Expensive() bool {
+	switch t {
+	case BackupCreate, BackupRestore, CustomVolumeBackupCreate, CustomVolumeBackupRestore, BucketBackupCreate, BucketBackupRestore:
+		return true
+	case InstanceMigrate, InstanceLiveMigrate, VolumeMigrate:
+		return true
+	case ImageDownload:
+		return true
+	default:
+		return false
+	}
+}
@@ -167,6 +167,9 @@ func ExpandInstanceConfig(config map[string]string, profiles []api.Profile) map[
 	profileConfigs := make([]map[string]string, len(profiles))
 	for i, profile := range profiles {
 		profileConfigs[i] = profile.Config
+		if profile.ExpandedConfig != nil {
+			profileConfigs[i] = profile.ExpandedConfig
+		}
 	}
 
 	for i := range profileConfigs {
@@ -192,6 +195,9 @@ func ExpandInstanceDevices(devices deviceConfig.Devices, profiles []api.Profile)
 	profileDevices := make([]deviceConfig.Devices, len(profiles))
 	for i, profile := range profiles {
 		profileDevices[i] = deviceConfig.NewDevices(profile.Devices)
+		if profile.ExpandedDevices != nil {
+			profileDevices[i] = deviceConfig.NewDevices(profile.ExpandedDevices)
+		}
 	}
 
 	for i := range profileDevices {
@@ -207,3 +213,48 @@ func ExpandInstanceDevices(devices deviceConfig.Devices, profiles []api.Profile)
 
 	return expandedDevices
 }
+
+// GetInstanceConfigOrigins returns where each key of the expanded configuration and each expanded device of an
+// instance comes from, given its local configuration and devices and its profiles.
+func (c *ClusterTx) GetInstanceConfigOrigins(ctx context.Context, config map[string]string, devices deviceConfig.Devices, profiles []api.Profile) (map[string]api.InstanceConfigOrigin, map[string]api.InstanceConfigOrigin, error) {
+	configOrigins := map[string]api.InstanceConfigOrigin{}
+	devicesOrigins := map[string]api.InstanceConfigOrigin{}
+
+	// Apply all the profiles, including the ones they extend.
+	for _, profile := range profiles {
+		chain, err := cluster.ProfileChain(profile, func(name string) (*api.Profile, error) {
+			base, err := cluster.GetProfile(ctx, c.tx, profile.Project, name)
+			if err != nil {
+				return nil, fmt.Errorf("Failed loading profile %q: %w", name, err)
+			}
+
+			return base.ToAPI(ctx, c.tx, nil, nil)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, p := range chain {
+			origin := api.InstanceConfigOrigin{Type: "profile", Profile: p.Name, Project: p.Project}
+
+			for k := range p.Config {
+				configOrigins[k] = origin
+			}
+
+			for k := range p.Devices {
+				devicesOrigins[k] = origin
+			}
+		}
+	}
+
+	// Then the local config and devices.
+	for k := range config {
+		configOrigins[k] = api.InstanceConfigOrigin{Type: "local"}
+	}
+
+	for k := range devices {
+		devicesOrigins[k] = api.InstanceConfigOrigin{Type: "local"}
+	}
+
+	return configOrigins, devicesOrigins, nil
+}
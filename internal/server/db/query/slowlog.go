@@ -0,0 +1,294 @@
+package query
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SlowLogSize is the number of slow queries kept by a SlowLog.
+const SlowLogSize = 1000
+
+// SlowQuery represents a query which took longer than the threshold of the SlowLog.
+type SlowQuery struct {
+	Timestamp time.Time
+	Duration  time.Duration
+	Query     string
+	Args      []string
+	Caller    string
+}
+
+// SlowLog records the queries taking longer than a threshold, keeping the most recent ones.
+type SlowLog struct {
+	threshold atomic.Int64
+
+	mu      sync.Mutex
+	queries []SlowQuery
+	next    int
+}
+
+// NewSlowLog returns a new SlowLog, disabled until a threshold is set.
+func NewSlowLog() *SlowLog {
+	return &SlowLog{}
+}
+
+// SetThreshold sets the duration above which the queries are recorded, 0 to disable the recording.
+func (l *SlowLog) SetThreshold(threshold time.Duration) {
+	if l == nil {
+		return
+	}
+
+	l.threshold.Store(int64(threshold))
+}
+
+// Queries returns the recorded queries, oldest first.
+func (l *SlowLog) Queries() []SlowQuery {
+	queries := []SlowQuery{}
+	if l == nil {
+		return queries
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	queries = append(queries, l.queries[l.next:]...)
+	queries = append(queries, l.queries[:l.next]...)
+
+	return queries
+}
+
+// Wrap returns a driver recording the slow queries of the given driver.
+func (l *SlowLog) Wrap(d driver.Driver) driver.Driver {
+	if l == nil {
+		return d
+	}
+
+	return &slowLogDriver{Driver: d, log: l}
+}
+
+// observe records the query if it took longer than the threshold since start.
+func (l *SlowLog) observe(start time.Time, query string, args []driver.NamedValue) {
+	threshold := time.Duration(l.threshold.Load())
+	if threshold <= 0 {
+		return
+	}
+
+	duration := time.Since(start)
+	if duration < threshold {
+		return
+	}
+
+	entry := SlowQuery{
+		Timestamp: start,
+		Duration:  duration,
+		Query:     query,
+		Args:      make([]string, 0, len(args)),
+		Caller:    slowLogCaller(),
+	}
+
+	for _, arg := range args {
+		entry.Args = append(entry.Args, fmt.Sprintf("%v", arg.Value))
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.queries) < SlowLogSize {
+		l.queries = append(l.queries, entry)
+		return
+	}
+
+	l.queries[l.next] = entry
+	l.next = (l.next + 1) % SlowLogSize
+}
+
+// slowLogCaller returns the first function outside of the database packages in the call stack.
+func slowLogCaller() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		frame, more := frames.Next()
+
+		if !strings.HasPrefix(frame.Function, "database/sql.") && !strings.HasPrefix(frame.Function, "github.com/lxc/incus/v6/internal/server/db/query.") {
+			return fmt.Sprintf("%s (%s:%d)", frame.Function, filepath.Base(frame.File), frame.Line)
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
+
+// slowLogDriver wraps a driver to record the slow queries of its connections.
+type slowLogDriver struct {
+	driver.Driver
+
+	log *SlowLog
+}
+
+// Open returns a new connection to the database.
+func (d *slowLogDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &slowLogConn{Conn: conn, log: d.log}, nil
+}
+
+// OpenConnector returns a connector for the database, using the one of the wrapped driver if any.
+func (d *slowLogDriver) OpenConnector(name string) (driver.Connector, error) {
+	driverContext, ok := d.Driver.(driver.DriverContext)
+	if !ok {
+		return &slowLogConnector{driver: d, name: name}, nil
+	}
+
+	connector, err := driverContext.OpenConnector(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &slowLogConnector{Connector: connector, driver: d}, nil
+}
+
+// slowLogConnector wraps a connector to record the slow queries of its connections.
+type slowLogConnector struct {
+	driver.Connector
+
+	driver *slowLogDriver
+	name   string
+}
+
+// Connect returns a new connection to the database.
+func (c *slowLogConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	if c.Connector == nil {
+		return c.driver.Open(c.name)
+	}
+
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &slowLogConn{Conn: conn, log: c.driver.log}, nil
+}
+
+// Driver returns the wrapping driver.
+func (c *slowLogConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// slowLogConn wraps a connection to record its slow queries.
+type slowLogConn struct {
+	driver.Conn
+
+	log *SlowLog
+}
+
+// Prepare returns a prepared statement.
+func (c *slowLogConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext returns a prepared statement.
+func (c *slowLogConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+
+	preparer, ok := c.Conn.(driver.ConnPrepareContext)
+	if ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &slowLogStmt{Stmt: stmt, log: c.log, query: query}, nil
+}
+
+// BeginTx starts a transaction.
+func (c *slowLogConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	beginner, ok := c.Conn.(driver.ConnBeginTx)
+	if ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+
+	return c.Conn.Begin() //nolint:staticcheck
+}
+
+// ExecContext executes a query without returning rows.
+func (c *slowLogConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	defer c.log.observe(time.Now(), query, args)
+
+	return execer.ExecContext(ctx, query, args)
+}
+
+// QueryContext executes a query returning rows.
+func (c *slowLogConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	defer c.log.observe(time.Now(), query, args)
+
+	return queryer.QueryContext(ctx, query, args)
+}
+
+// slowLogStmt wraps a prepared statement to record its slow executions.
+type slowLogStmt struct {
+	driver.Stmt
+
+	log   *SlowLog
+	query string
+}
+
+// ExecContext executes the statement without returning rows.
+func (s *slowLogStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	defer s.log.observe(time.Now(), s.query, args)
+
+	execer, ok := s.Stmt.(driver.StmtExecContext)
+	if ok {
+		return execer.ExecContext(ctx, args)
+	}
+
+	return s.Stmt.Exec(namedValuesToValues(args)) //nolint:staticcheck
+}
+
+// QueryContext executes the statement returning rows.
+func (s *slowLogStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	defer s.log.observe(time.Now(), s.query, args)
+
+	queryer, ok := s.Stmt.(driver.StmtQueryContext)
+	if ok {
+		return queryer.QueryContext(ctx, args)
+	}
+
+	return s.Stmt.Query(namedValuesToValues(args)) //nolint:staticcheck
+}
+
+// namedValuesToValues converts the arguments for the drivers not supporting contexts.
+func namedValuesToValues(args []driver.NamedValue) []driver.Value {
+	values := make([]driver.Value, 0, len(args))
+	for _, arg := range args {
+		values = append(values, arg.Value)
+	}
+
+	return values
+}
@@ -0,0 +1,58 @@
+package query_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/internal/server/db/query"
+)
+
+func TestSlowLog(t *testing.T) {
+	slowLog := query.NewSlowLog()
+	sql.Register("sqlite3_slow_log", slowLog.Wrap(&sqlite3.SQLiteDriver{}))
+
+	db, err := sql.Open("sqlite3_slow_log", ":memory:")
+	require.NoError(t, err)
+
+	defer func() { _ = db.Close() }()
+
+	// Nothing is recorded until a threshold is set.
+	_, err = db.Exec("CREATE TABLE test (id INTEGER, name TEXT)")
+	require.NoError(t, err)
+	assert.Empty(t, slowLog.Queries())
+
+	slowLog.SetThreshold(time.Nanosecond)
+
+	_, err = db.Exec("INSERT INTO test VALUES (?, ?)", 1, "foo")
+	require.NoError(t, err)
+
+	stmt, err := db.Prepare("SELECT name FROM test WHERE id = ?")
+	require.NoError(t, err)
+
+	defer func() { _ = stmt.Close() }()
+
+	var name string
+	require.NoError(t, stmt.QueryRow(1).Scan(&name))
+
+	queries := slowLog.Queries()
+	require.Len(t, queries, 2)
+	assert.Equal(t, "INSERT INTO test VALUES (?, ?)", queries[0].Query)
+	assert.Equal(t, []string{"1", "foo"}, queries[0].Args)
+	assert.Equal(t, "SELECT name FROM test WHERE id = ?", queries[1].Query)
+	assert.Contains(t, queries[1].Caller, "query_test.TestSlowLog (slowlog_test.go:")
+
+	// Only the most recent queries are kept.
+	for range query.SlowLogSize {
+		_, err = db.Exec("SELECT 1")
+		require.NoError(t, err)
+	}
+
+	queries = slowLog.Queries()
+	require.Len(t, queries, query.SlowLogSize)
+	assert.Equal(t, "SELECT 1", queries[0].Query)
+}
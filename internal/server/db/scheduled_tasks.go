@@ -0,0 +1,233 @@
+//go:build linux && cgo && !agent
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/db/query"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// ScheduledTaskRunsMax is the number of runs kept in the history of each scheduled task.
+const ScheduledTaskRunsMax = 20
+
+// GetScheduledTaskNames returns the names of the existing scheduled tasks.
+func (c *ClusterTx) GetScheduledTaskNames(ctx context.Context) ([]string, error) {
+	q := `SELECT name FROM scheduled_tasks ORDER BY name`
+
+	names := []string{}
+
+	err := query.Scan(ctx, c.tx, q, func(scan func(dest ...any) error) error {
+		var name string
+
+		err := scan(&name)
+		if err != nil {
+			return err
+		}
+
+		names = append(names, name)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// GetScheduledTask returns the scheduled task with the given name, along with its most recent runs.
+func (c *ClusterTx) GetScheduledTask(ctx context.Context, name string) (int64, *api.ScheduledTask, error) {
+	var id int64 = int64(-1)
+
+	scheduledTask := api.ScheduledTask{
+		Name: name,
+	}
+
+	q := `
+		SELECT id, description, schedule, action, filter
+		FROM scheduled_tasks
+		WHERE name=?
+		LIMIT 1
+	`
+
+	err := c.tx.QueryRowContext(ctx, q, name).Scan(&id, &scheduledTask.Description, &scheduledTask.Schedule, &scheduledTask.Action, &scheduledTask.Filter)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return -1, nil, api.StatusErrorf(http.StatusNotFound, "Scheduled task not found")
+		}
+
+		return -1, nil, err
+	}
+
+	err = scheduledTaskConfig(ctx, c, id, &scheduledTask)
+	if err != nil {
+		return -1, nil, fmt.Errorf("Failed loading config: %w", err)
+	}
+
+	err = scheduledTaskRuns(ctx, c, id, &scheduledTask)
+	if err != nil {
+		return -1, nil, fmt.Errorf("Failed loading runs: %w", err)
+	}
+
+	return id, &scheduledTask, nil
+}
+
+// scheduledTaskConfig populates the config map of the scheduled task with the given ID.
+func scheduledTaskConfig(ctx context.Context, tx *ClusterTx, id int64, scheduledTask *api.ScheduledTask) error {
+	q := `
+		SELECT key, value
+		FROM scheduled_tasks_config
+		WHERE scheduled_task_id=?
+	`
+
+	scheduledTask.Config = make(map[string]string)
+	return query.Scan(ctx, tx.Tx(), q, func(scan func(dest ...any) error) error {
+		var key, value string
+
+		err := scan(&key, &value)
+		if err != nil {
+			return err
+		}
+
+		_, found := scheduledTask.Config[key]
+		if found {
+			return fmt.Errorf("Duplicate config row found for key %q for scheduled task ID %d", key, id)
+		}
+
+		scheduledTask.Config[key] = value
+
+		return nil
+	}, id)
+}
+
+// scheduledTaskRuns populates the runs of the scheduled task with the given ID, newest first.
+func scheduledTaskRuns(ctx context.Context, tx *ClusterTx, id int64, scheduledTask *api.ScheduledTask) error {
+	q := `
+		SELECT scheduled_tasks_runs.date, nodes.name, scheduled_tasks_runs.status, scheduled_tasks_runs.message
+		FROM scheduled_tasks_runs
+		JOIN nodes ON nodes.id=scheduled_tasks_runs.node_id
+		WHERE scheduled_tasks_runs.scheduled_task_id=?
+		ORDER BY scheduled_tasks_runs.date DESC, scheduled_tasks_runs.id DESC
+	`
+
+	scheduledTask.Runs = []api.ScheduledTaskRun{}
+	return query.Scan(ctx, tx.Tx(), q, func(scan func(dest ...any) error) error {
+		run := api.ScheduledTaskRun{}
+
+		err := scan(&run.Date, &run.Location, &run.Status, &run.Message)
+		if err != nil {
+			return err
+		}
+
+		scheduledTask.Runs = append(scheduledTask.Runs, run)
+
+		return nil
+	}, id)
+}
+
+// CreateScheduledTask creates a new scheduled task.
+func (c *ClusterTx) CreateScheduledTask(ctx context.Context, info *api.ScheduledTasksPost) (int64, error) {
+	// Insert a new scheduled task record.
+	result, err := c.tx.ExecContext(ctx, `
+			INSERT INTO scheduled_tasks (name, description, schedule, action, filter)
+			VALUES (?, ?, ?, ?, ?)
+		`, info.Name, info.Description, info.Schedule, info.Action, info.Filter)
+	if err != nil {
+		return -1, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return -1, err
+	}
+
+	err = scheduledTaskConfigAdd(c.tx, id, info.Config)
+	if err != nil {
+		return -1, err
+	}
+
+	return id, nil
+}
+
+// scheduledTaskConfigAdd inserts scheduled task config keys.
+func scheduledTaskConfigAdd(tx *sql.Tx, id int64, config map[string]string) error {
+	sql := "INSERT INTO scheduled_tasks_config (scheduled_task_id, key, value) VALUES(?, ?, ?)"
+	stmt, err := tx.Prepare(sql)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = stmt.Close() }()
+
+	for k, v := range config {
+		if v == "" {
+			continue
+		}
+
+		_, err = stmt.Exec(id, k, v)
+		if err != nil {
+			return fmt.Errorf("Failed inserting config: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// UpdateScheduledTask updates the scheduled task with the given ID.
+func (c *ClusterTx) UpdateScheduledTask(ctx context.Context, id int64, config *api.ScheduledTaskPut) error {
+	_, err := c.tx.ExecContext(ctx, `
+			UPDATE scheduled_tasks
+			SET description=?, schedule=?, action=?, filter=?
+			WHERE id=?
+		`, config.Description, config.Schedule, config.Action, config.Filter, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.tx.ExecContext(ctx, "DELETE FROM scheduled_tasks_config WHERE scheduled_task_id=?", id)
+	if err != nil {
+		return err
+	}
+
+	err = scheduledTaskConfigAdd(c.tx, id, config.Config)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteScheduledTask deletes the scheduled task.
+func (c *ClusterTx) DeleteScheduledTask(ctx context.Context, id int64) error {
+	_, err := c.tx.ExecContext(ctx, "DELETE FROM scheduled_tasks WHERE id=?", id)
+
+	return err
+}
+
+// CreateScheduledTaskRun records a run of the scheduled task with the given ID on the local member.
+// Only the most recent runs of each task are kept.
+func (c *ClusterTx) CreateScheduledTaskRun(ctx context.Context, id int64, date time.Time, status string, message string) error {
+	_, err := c.tx.ExecContext(ctx, `
+			INSERT INTO scheduled_tasks_runs (scheduled_task_id, node_id, date, status, message)
+			VALUES (?, ?, ?, ?, ?)
+		`, id, c.nodeID, date, status, message)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.tx.ExecContext(ctx, `
+			DELETE FROM scheduled_tasks_runs
+			WHERE scheduled_task_id=? AND id NOT IN (
+				SELECT id FROM scheduled_tasks_runs WHERE scheduled_task_id=? ORDER BY date DESC, id DESC LIMIT ?
+			)
+		`, id, id, ScheduledTaskRunsMax)
+
+	return err
+}
@@ -522,9 +522,13 @@ WHERE storage_pools.id = ? AND storage_pools.state = ?
 		return nil, err
 	}
 
-	// Figure which nodes are missing
+	// Figure which nodes are missing, skipping the witnesses as they don't host any storage.
 	missing := []string{}
 	for _, node := range nodes {
+		if slices.Contains(node.Roles, ClusterRoleWitness) {
+			continue
+		}
+
 		if !slices.Contains(defined, node.Name) {
 			missing = append(missing, node.Name)
 		}
@@ -536,6 +540,9 @@ WHERE storage_pools.id = ? AND storage_pools.state = ?
 
 	configs := map[string]map[string]string{}
 	for _, node := range nodes {
+		if slices.Contains(node.Roles, ClusterRoleWitness) {
+			continue
+		}
 		config, err := query.SelectConfig(ctx, c.tx, "storage_pools_config", "storage_pool_id=? AND node_id=?", poolID, node.ID)
 		if err != nil {
 			return nil, err
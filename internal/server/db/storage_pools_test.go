@@ -100,6 +100,29 @@ func TestStoragePoolsCreatePending(t *testing.T) {
 	assert.Equal(t, map[string]string{"source": "/egg"}, configs["none"])
 }
 
+// Witness members don't need to define the pool.
+func TestStoragePoolsCreatePending_Witness(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	id, err := tx.CreateNode("buzz", "1.2.3.4:666")
+	require.NoError(t, err)
+
+	err = tx.UpdateNodeRoles(id, []db.ClusterRole{db.ClusterRoleWitness})
+	require.NoError(t, err)
+
+	err = tx.CreatePendingStoragePool(context.Background(), "none", "pool1", "dir", map[string]string{"source": "/foo"})
+	require.NoError(t, err)
+
+	poolID, err := tx.GetStoragePoolID(context.Background(), "pool1")
+	require.NoError(t, err)
+
+	configs, err := tx.GetStoragePoolNodeConfigs(context.Background(), poolID)
+	require.NoError(t, err)
+	assert.Len(t, configs, 1)
+	assert.Equal(t, map[string]string{"source": "/foo"}, configs["none"])
+}
+
 func TestStoragePoolsCreatePending_OtherPool(t *testing.T) {
 	tx, cleanup := db.NewTestClusterTx(t)
 	defer cleanup()
@@ -4,6 +4,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"net/http"
 	"slices"
@@ -12,6 +13,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/query"
 	"github.com/lxc/incus/v6/internal/server/db/warningtype"
 	"github.com/lxc/incus/v6/shared/api"
 )
@@ -103,8 +105,13 @@ func (c *ClusterTx) UpsertWarning(ctx context.Context, nodeName string, projectN
 }
 
 // UpdateWarningStatus updates the status of the warning with the given UUID.
+// The expiry of a previous acknowledgment is cleared, and resolved warnings get notified again if raised again.
 func (c *ClusterTx) UpdateWarningStatus(UUID string, status warningtype.Status) error {
-	str := "UPDATE warnings SET status=?, updated_date=? WHERE uuid=?"
+	str := "UPDATE warnings SET status=?, updated_date=?, acknowledged_until=NULL WHERE uuid=?"
+	if status == warningtype.StatusResolved {
+		str = "UPDATE warnings SET status=?, updated_date=?, acknowledged_until=NULL, notified=0 WHERE uuid=?"
+	}
+
 	res, err := c.tx.Exec(str, status, time.Now(), UUID)
 	if err != nil {
 		return fmt.Errorf("Failed to update warning status for warning %q: %w", UUID, err)
@@ -219,3 +226,79 @@ func (c *ClusterTx) createWarning(ctx context.Context, object cluster.Warning) (
 
 	return id, nil
 }
+
+// AcknowledgeWarning acknowledges the warning with the given UUID, until the given date if not zero.
+func (c *ClusterTx) AcknowledgeWarning(UUID string, until time.Time) error {
+	acknowledgedUntil := sql.NullTime{Time: until, Valid: !until.IsZero()}
+
+	str := "UPDATE warnings SET status=?, updated_date=?, acknowledged_until=? WHERE uuid=?"
+	res, err := c.tx.Exec(str, warningtype.StatusAcknowledged, time.Now(), acknowledgedUntil, UUID)
+	if err != nil {
+		return fmt.Errorf("Failed to acknowledge warning %q: %w", UUID, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Failed to get affected rows to acknowledge warning %q: %w", UUID, err)
+	}
+
+	if rowsAffected == 0 {
+		return api.StatusErrorf(http.StatusNotFound, "Warning not found")
+	}
+
+	return nil
+}
+
+// ExpireWarningAcknowledgment makes the acknowledged warning with the given UUID new again, to be notified again.
+func (c *ClusterTx) ExpireWarningAcknowledgment(UUID string) error {
+	str := "UPDATE warnings SET status=?, updated_date=?, acknowledged_until=NULL, notified=0 WHERE uuid=? AND status=?"
+	_, err := c.tx.Exec(str, warningtype.StatusNew, time.Now(), UUID, warningtype.StatusAcknowledged)
+	if err != nil {
+		return fmt.Errorf("Failed to expire acknowledgment of warning %q: %w", UUID, err)
+	}
+
+	return nil
+}
+
+// GetUnnotifiedWarnings returns the new warnings which haven't been notified yet.
+func (c *ClusterTx) GetUnnotifiedWarnings(ctx context.Context) ([]cluster.Warning, error) {
+	var uuids []string
+
+	err := query.Scan(ctx, c.tx, "SELECT uuid FROM warnings WHERE status=? AND notified=0 ORDER BY id", func(scan func(dest ...any) error) error {
+		var warningUUID string
+
+		err := scan(&warningUUID)
+		if err != nil {
+			return err
+		}
+
+		uuids = append(uuids, warningUUID)
+
+		return nil
+	}, warningtype.StatusNew)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get unnotified warnings: %w", err)
+	}
+
+	warnings := make([]cluster.Warning, 0, len(uuids))
+	for _, warningUUID := range uuids {
+		warning, err := cluster.GetWarning(ctx, c.tx, warningUUID)
+		if err != nil {
+			return nil, err
+		}
+
+		warnings = append(warnings, *warning)
+	}
+
+	return warnings, nil
+}
+
+// SetWarningNotified records that the warning with the given UUID was notified.
+func (c *ClusterTx) SetWarningNotified(UUID string) error {
+	_, err := c.tx.Exec("UPDATE warnings SET notified=1 WHERE uuid=?", UUID)
+	if err != nil {
+		return fmt.Errorf("Failed to record notification of warning %q: %w", UUID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package device
+
+import (
+	"fmt"
+
+	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/shared/osarch"
+	"github.com/lxc/incus/v6/shared/validate"
+)
+
+type audio struct {
+	deviceCommon
+}
+
+// CanMigrate returns whether the device can be migrated to any other cluster member.
+func (d *audio) CanMigrate() bool {
+	return true
+}
+
+// validateConfig checks the supplied config for correctness.
+func (d *audio) validateConfig(instConf instance.ConfigReader) error {
+	if !instanceSupported(instConf.Type(), instancetype.VM) {
+		return ErrUnsupportedDevType
+	}
+
+	rules := map[string]func(string) error{
+		// gendoc:generate(entity=devices, group=audio, key=model)
+		//
+		// ---
+		//  type: string
+		//  default: `virtio`
+		//  required: no
+		//  shortdesc: The emulated sound card (`virtio` or `hda` for Intel HD Audio)
+		"model": validate.Optional(validate.IsOneOf("virtio", "hda")),
+	}
+
+	err := d.config.Validate(rules)
+	if err != nil {
+		return fmt.Errorf("Failed to validate config: %w", err)
+	}
+
+	// All sound cards share the single audio channel of the console.
+	for name, dev := range instConf.ExpandedDevices() {
+		if name != d.name && dev["type"] == "audio" {
+			return fmt.Errorf("Only one audio device can be used per instance")
+		}
+	}
+
+	return nil
+}
+
+// Start is run when the device is added to the instance.
+func (d *audio) Start() (*deviceConfig.RunConfig, error) {
+	if d.inst.Architecture() == osarch.ARCH_64BIT_S390_BIG_ENDIAN {
+		return nil, fmt.Errorf("Audio devices aren't supported on this architecture")
+	}
+
+	model := d.config["model"]
+	if model == "" {
+		model = "virtio"
+	}
+
+	runConf := deviceConfig.RunConfig{}
+	runConf.AudioDevice = append(runConf.AudioDevice,
+		[]deviceConfig.RunConfigItem{
+			{Key: "devName", Value: d.name},
+			{Key: "model", Value: model},
+		}...)
+
+	return &runConf, nil
+}
+
+// Stop is run when the device is removed from the instance.
+func (d *audio) Stop() (*deviceConfig.RunConfig, error) {
+	return &deviceConfig.RunConfig{}, nil
+}
@@ -65,6 +65,8 @@ type RunConfig struct {
 	USBDevice        []USBDeviceItem  // USB device configuration settings.
 	TPMDevice        []RunConfigItem  // TPM device configuration settings.
 	PCIDevice        []RunConfigItem  // PCI device configuration settings.
+	AudioDevice      []RunConfigItem  // Audio device configuration settings.
+	InputDevice      []RunConfigItem  // Input device configuration settings.
 	Revert           revert.Hook      // Revert setup of device on post-setup error.
 	UseUSBBus        bool             // Whether to use a USB bus for the device.
 }
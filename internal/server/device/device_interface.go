@@ -3,6 +3,7 @@ package device
 import (
 	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
 	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/metrics"
 	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/shared/api"
 )
@@ -86,3 +87,8 @@ type device interface {
 type NICState interface {
 	State() (*api.InstanceStateNetwork, error)
 }
+
+// Metrics provides the ability to access the host-side metrics of a device.
+type Metrics interface {
+	Metrics() (*metrics.MetricSet, error)
+}
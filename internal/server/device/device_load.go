@@ -67,6 +67,8 @@ func newByType(state *state.State, projectName string, conf deviceConfig.Device)
 			dev = &gpuMdev{}
 		case "sriov":
 			dev = &gpuSRIOV{}
+		case "virtio":
+			dev = &gpuVirtio{}
 		default:
 			dev = &gpuPhysical{}
 		}
@@ -87,6 +89,10 @@ func newByType(state *state.State, projectName string, conf deviceConfig.Device)
 		dev = &tpm{}
 	case "pci":
 		dev = &pci{}
+	case "audio":
+		dev = &audio{}
+	case "input":
+		dev = &input{}
 	}
 
 	// Check a valid device type has been found.
@@ -293,7 +293,7 @@ func diskCephfsOptions(clusterName string, userName string, fsName string, fsPat
 // Returns UnsupportedError error if the host system or instance does not support virtiosfd, returns normal error
 // type if process cannot be started for other reasons.
 // Returns revert function and listener file handle on success.
-func DiskVMVirtiofsdStart(execPath string, inst instance.Instance, socketPath string, pidPath string, logPath string, sharePath string, idmaps []idmap.Entry, cacheOption string) (func(), net.Listener, error) {
+func DiskVMVirtiofsdStart(execPath string, inst instance.Instance, socketPath string, pidPath string, logPath string, sharePath string, idmaps []idmap.Entry, cacheOption string, threads string) (func(), net.Listener, error) {
 	reverter := revert.New()
 	defer reverter.Fail()
 
@@ -372,6 +372,10 @@ func DiskVMVirtiofsdStart(execPath string, inst instance.Instance, socketPath st
 	// Start the virtiofsd process in non-daemon mode.
 	args := []string{"--fd=3", fmt.Sprintf("--cache=%s", cacheOption), fmt.Sprintf("--shared-dir=%s", sharePath)}
 
+	if threads != "" {
+		args = append(args, fmt.Sprintf("--thread-pool-size=%s", threads))
+	}
+
 	if len(idmaps) > 0 {
 		idmapSet := &idmap.Set{Entries: idmaps}
 		sort.Sort(idmapSet)
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
+	pcidev "github.com/lxc/incus/v6/internal/server/device/pci"
 	"github.com/lxc/incus/v6/shared/util"
 )
 
@@ -26,6 +28,44 @@ func validatePCIDevice(address string) error {
 	return nil
 }
 
+// pciIOMMUGroupHostDevices returns the devices sharing the IOMMU group of the given PCI device which are bound
+// to a host driver and so would prevent the group from being passed through, along with the subset of those
+// which are likely critical to the host (storage, network, display and USB controllers).
+// PCI bridges and devices not bound to any driver (or already bound to vfio-pci) are not returned.
+func pciIOMMUGroupHostDevices(slotName string) ([]pcidev.Device, []pcidev.Device, error) {
+	members, err := pcidev.DeviceIOMMUGroupMembers(slotName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var hostDevices []pcidev.Device
+	var criticalDevices []pcidev.Device
+
+	for _, member := range members {
+		if member.Driver == "" || member.Driver == "vfio-pci" || member.Driver == "pci-stub" {
+			continue
+		}
+
+		class, err := pcidev.DeviceClass(member.SlotName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// PCI bridges may remain bound to their host driver.
+		if class>>8 == 0x0604 {
+			continue
+		}
+
+		hostDevices = append(hostDevices, member)
+
+		if slices.Contains([]uint32{0x01, 0x02, 0x03}, class>>16) || class>>8 == 0x0c03 {
+			criticalDevices = append(criticalDevices, member)
+		}
+	}
+
+	return hostDevices, criticalDevices, nil
+}
+
 // checkAttachedRunningProcess checks if a device is tied to running processes.
 func checkAttachedRunningProcesses(devicePath string) ([]string, error) {
 	var processes []string
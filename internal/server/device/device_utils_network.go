@@ -21,6 +21,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/internal/server/ip"
+	"github.com/lxc/incus/v6/internal/server/metrics"
 	"github.com/lxc/incus/v6/internal/server/network"
 	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/shared/logger"
@@ -405,6 +406,41 @@ func networkVethFillFromVolatile(device deviceConfig.Device, volatile map[string
 	}
 }
 
+// networkNICHostMetrics returns the metrics of a NIC device from the counters of its host-side interface. The
+// counters are reversed to be reported from the instance's point of view.
+func networkNICHostMetrics(deviceName string, hostName string) (*metrics.MetricSet, error) {
+	if hostName == "" || !network.InterfaceExists(hostName) {
+		return nil, nil
+	}
+
+	counters := map[string]uint64{}
+	for _, counter := range []string{"rx_bytes", "rx_dropped", "rx_errors", "rx_packets", "tx_bytes", "tx_dropped", "tx_errors", "tx_packets"} {
+		content, err := os.ReadFile(fmt.Sprintf("/sys/class/net/%s/statistics/%s", hostName, counter))
+		if err != nil {
+			return nil, fmt.Errorf("Failed reading %q counter of interface %q: %w", counter, hostName, err)
+		}
+
+		counters[counter], err = strconv.ParseUint(strings.TrimSpace(string(content)), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Failed parsing %q counter of interface %q: %w", counter, hostName, err)
+		}
+	}
+
+	out := metrics.NewMetricSet(nil)
+	labels := map[string]string{"device": deviceName, "interface": hostName}
+
+	out.AddSamples(metrics.DeviceNetworkReceiveBytesTotal, metrics.Sample{Value: float64(counters["tx_bytes"]), Labels: labels})
+	out.AddSamples(metrics.DeviceNetworkReceiveDropTotal, metrics.Sample{Value: float64(counters["tx_dropped"]), Labels: labels})
+	out.AddSamples(metrics.DeviceNetworkReceiveErrsTotal, metrics.Sample{Value: float64(counters["tx_errors"]), Labels: labels})
+	out.AddSamples(metrics.DeviceNetworkReceivePacketsTotal, metrics.Sample{Value: float64(counters["tx_packets"]), Labels: labels})
+	out.AddSamples(metrics.DeviceNetworkTransmitBytesTotal, metrics.Sample{Value: float64(counters["rx_bytes"]), Labels: labels})
+	out.AddSamples(metrics.DeviceNetworkTransmitDropTotal, metrics.Sample{Value: float64(counters["rx_dropped"]), Labels: labels})
+	out.AddSamples(metrics.DeviceNetworkTransmitErrsTotal, metrics.Sample{Value: float64(counters["rx_errors"]), Labels: labels})
+	out.AddSamples(metrics.DeviceNetworkTransmitPacketsTotal, metrics.Sample{Value: float64(counters["rx_packets"]), Labels: labels})
+
+	return out, nil
+}
+
 // networkNICRouteAdd applies any static host-side routes configured for an instance NIC.
 func networkNICRouteAdd(routeDev string, routes ...string) error {
 	if !network.InterfaceExists(routeDev) {
@@ -9,6 +9,7 @@ import (
 
 	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
 	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
 	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/shared/logger"
 )
@@ -93,6 +94,22 @@ func USBRunHandlers(state *state.State, event *USBEvent) {
 				logger.Error("USB event instance handler failed", logger.Ctx{"err": err, "project": projectName, "instance": instanceName, "device": deviceName})
 				continue
 			}
+
+			ctx := map[string]any{
+				"device":    deviceName,
+				"type":      "usb",
+				"vendorid":  event.Vendor,
+				"productid": event.Product,
+				"serial":    event.Serial,
+				"busnum":    event.BusNum,
+				"devnum":    event.DevNum,
+			}
+
+			if event.Action == "add" {
+				state.Events.SendLifecycle(projectName, lifecycle.InstanceDeviceAttached.Event(instance, ctx))
+			} else if event.Action == "remove" {
+				state.Events.SendLifecycle(projectName, lifecycle.InstanceDeviceDetached.Event(instance, ctx))
+			}
 		}
 	}
 }
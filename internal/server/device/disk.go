@@ -51,6 +51,10 @@ const diskSourceAgent = "agent:config"
 // the QEMU driver.
 const DiskVirtiofsdSockMountOpt = "virtiofsdSock"
 
+// DiskVirtiofsDAXMountOpt indicates the mount option prefix used to provide the virtio-fs DAX window size
+// (in bytes) to the QEMU driver.
+const DiskVirtiofsDAXMountOpt = "virtiofsDAX"
+
 // DiskFileDescriptorMountPrefix indicates the mount dev path is using a file descriptor rather than a normal path.
 // The Mount.DevPath field will be expected to be in the format: "fd:<fdNum>:<devPath>".
 // It still includes the original dev path so that the instance driver can perform additional probing of the path
@@ -363,6 +367,25 @@ func (d *disk) validateConfig(instConf instance.ConfigReader) error {
 		//  required: no
 		//  shortdesc: Only for VMs: Override the bus for the device
 		"io.bus": validate.Optional(validate.IsOneOf("nvme", "virtio-blk", "virtio-scsi", "auto", "9p", "virtiofs", "usb")),
+
+		// gendoc:generate(entity=devices, group=disk, key=io.virtiofs.threads)
+		// This sets the size of the `virtiofsd` thread pool used to process requests.
+		// When unset, `virtiofsd` processes requests on its main thread.
+		// ---
+		//  type: integer
+		//  required: no
+		//  shortdesc: Only for VMs: Number of `virtiofsd` worker threads for file system shares
+		"io.virtiofs.threads": validate.Optional(validate.IsUint32),
+
+		// gendoc:generate(entity=devices, group=disk, key=io.virtiofs.dax)
+		// This enables a DAX window of the given size for the share, allowing the guest to map
+		// file contents directly from the host page cache.
+		// This requires a QEMU build with DAX support for virtio-fs.
+		// ---
+		//  type: string
+		//  required: no
+		//  shortdesc: Only for VMs: Size of the virtio-fs DAX window (for example `1GiB`)
+		"io.virtiofs.dax": validate.Optional(validate.IsSize),
 	}
 
 	err := d.config.Validate(rules)
@@ -378,6 +401,14 @@ func (d *disk) validateConfig(instConf instance.ConfigReader) error {
 		return fmt.Errorf("IO cache configuration cannot be applied to containers")
 	}
 
+	if instConf.Type() == instancetype.Container && (d.config["io.virtiofs.threads"] != "" || d.config["io.virtiofs.dax"] != "") {
+		return fmt.Errorf("virtio-fs configuration cannot be applied to containers")
+	}
+
+	if (d.config["io.virtiofs.threads"] != "" || d.config["io.virtiofs.dax"] != "") && (d.config["path"] == "/" || d.config["io.bus"] == "9p") {
+		return fmt.Errorf("virtio-fs configuration can only be applied to file system shares using virtio-fs")
+	}
+
 	if d.config["required"] != "" && d.config["optional"] != "" {
 		return fmt.Errorf(`Cannot use both "required" and deprecated "optional" properties at the same time`)
 	}
@@ -738,7 +769,7 @@ func (d *disk) UpdatableFields(oldDevice Type) []string {
 		return []string{}
 	}
 
-	return []string{"limits.max", "limits.read", "limits.write", "size", "size.state"}
+	return []string{"boot.priority", "limits.max", "limits.read", "limits.write", "size", "size.state"}
 }
 
 // Register calls mount for the disk volume (which should already be mounted) to reinitialize the reference counter
@@ -1299,7 +1330,7 @@ func (d *disk) startVM() (*deviceConfig.RunConfig, error) {
 					logPath := filepath.Join(d.inst.LogPath(), fmt.Sprintf("disk.%s.log", d.name))
 					_ = os.Remove(logPath) // Remove old log if needed.
 
-					revertFunc, unixListener, err := DiskVMVirtiofsdStart(d.state.OS.ExecPath, d.inst, sockPath, pidPath, logPath, mount.DevPath, rawIDMaps.Entries, d.config["io.cache"])
+					revertFunc, unixListener, err := DiskVMVirtiofsdStart(d.state.OS.ExecPath, d.inst, sockPath, pidPath, logPath, mount.DevPath, rawIDMaps.Entries, d.config["io.cache"], d.config["io.virtiofs.threads"])
 					if err != nil {
 						if busOption == "virtiofs" {
 							return err
@@ -1343,6 +1374,16 @@ func (d *disk) startVM() (*deviceConfig.RunConfig, error) {
 					// QEMU driver also setup the virtio-fs share.
 					mount.Opts = append(mount.Opts, fmt.Sprintf("%s=%s", DiskVirtiofsdSockMountOpt, sockPath))
 
+					// Pass the DAX window size to the QEMU driver if requested.
+					if d.config["io.virtiofs.dax"] != "" {
+						daxSize, err := units.ParseByteSizeString(d.config["io.virtiofs.dax"])
+						if err != nil {
+							return err
+						}
+
+						mount.Opts = append(mount.Opts, fmt.Sprintf("%s=%d", DiskVirtiofsDAXMountOpt, daxSize))
+					}
+
 					return nil
 				}()
 				if err != nil {
@@ -17,7 +17,9 @@ import (
 	pcidev "github.com/lxc/incus/v6/internal/server/device/pci"
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/metrics"
 	"github.com/lxc/incus/v6/internal/server/resources"
+	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/util"
 )
 
@@ -394,6 +396,61 @@ func (d *gpuPhysical) pciDeviceDriverOverrideIOMMU(pciDev pcidev.Device, driverO
 	return nil
 }
 
+// Metrics returns the utilization and memory usage of the GPUs passed to the container, where their driver exposes
+// them (only amdgpu does currently). The GPUs passed to virtual machines are bound to vfio-pci and can't be queried.
+func (d *gpuPhysical) Metrics() (*metrics.MetricSet, error) {
+	if d.inst.Type() != instancetype.Container {
+		return nil, nil
+	}
+
+	gpus, err := resources.GetGPU()
+	if err != nil {
+		return nil, err
+	}
+
+	// readValue returns the numeric value of a sysfs file of the GPU.
+	readValue := func(gpu api.ResourcesGPUCard, name string) (float64, bool) {
+		content, err := os.ReadFile(filepath.Join("/sys/bus/pci/devices", gpu.PCIAddress, name))
+		if err != nil {
+			return 0, false
+		}
+
+		value, err := strconv.ParseUint(strings.TrimSpace(string(content)), 10, 64)
+		if err != nil {
+			return 0, false
+		}
+
+		return float64(value), true
+	}
+
+	out := metrics.NewMetricSet(nil)
+
+	for _, gpu := range gpus.Cards {
+		if gpu.PCIAddress == "" || !gpuSelected(d.Config(), gpu) {
+			continue
+		}
+
+		labels := map[string]string{"device": d.name, "pci": gpu.PCIAddress}
+
+		busyPercent, ok := readValue(gpu, "gpu_busy_percent")
+		if ok {
+			out.AddSamples(metrics.DeviceGPUUtilizationRatio, metrics.Sample{Value: busyPercent / 100, Labels: labels})
+		}
+
+		memoryUsed, ok := readValue(gpu, "mem_info_vram_used")
+		if ok {
+			out.AddSamples(metrics.DeviceGPUMemoryUsedBytes, metrics.Sample{Value: memoryUsed, Labels: labels})
+		}
+
+		memoryTotal, ok := readValue(gpu, "mem_info_vram_total")
+		if ok {
+			out.AddSamples(metrics.DeviceGPUMemoryTotalBytes, metrics.Sample{Value: memoryTotal, Labels: labels})
+		}
+	}
+
+	return out, nil
+}
+
 // Stop is run when the device is removed from the instance.
 func (d *gpuPhysical) Stop() (*deviceConfig.RunConfig, error) {
 	runConf := deviceConfig.RunConfig{
@@ -0,0 +1,210 @@
+package device
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
+	pcidev "github.com/lxc/incus/v6/internal/server/device/pci"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/resources"
+	"github.com/lxc/incus/v6/shared/osarch"
+	"github.com/lxc/incus/v6/shared/util"
+	"github.com/lxc/incus/v6/shared/validate"
+)
+
+type gpuVirtio struct {
+	deviceCommon
+}
+
+// validateConfig checks the supplied config for correctness.
+func (d *gpuVirtio) validateConfig(instConf instance.ConfigReader) error {
+	if !instanceSupported(instConf.Type(), instancetype.VM) {
+		return ErrUnsupportedDevType
+	}
+
+	optionalFields := []string{
+		// gendoc:generate(entity=devices, group=gpu_virtio, key=vendorid)
+		//
+		// ---
+		//  type: string
+		//  required: no
+		//  shortdesc: The vendor ID of the host GPU used for rendering
+		"vendorid",
+
+		// gendoc:generate(entity=devices, group=gpu_virtio, key=productid)
+		//
+		// ---
+		//  type: string
+		//  required: no
+		//  shortdesc: The product ID of the host GPU used for rendering
+		"productid",
+
+		// gendoc:generate(entity=devices, group=gpu_virtio, key=id)
+		//
+		// ---
+		//  type: string
+		//  required: no
+		//  shortdesc: The DRM card ID of the host GPU used for rendering
+		"id",
+
+		// gendoc:generate(entity=devices, group=gpu_virtio, key=pci)
+		//
+		// ---
+		//  type: string
+		//  required: no
+		//  shortdesc: The PCI address of the host GPU used for rendering
+		"pci",
+	}
+
+	rules := gpuValidationRules(nil, optionalFields)
+
+	// gendoc:generate(entity=devices, group=gpu_virtio, key=acceleration)
+	//
+	// ---
+	//  type: string
+	//  default: `virgl`
+	//  required: no
+	//  shortdesc: The 3D acceleration to provide to the guest (`virgl` for OpenGL or `venus` for Vulkan)
+	rules["acceleration"] = validate.Optional(validate.IsOneOf("virgl", "venus"))
+
+	err := d.config.Validate(rules)
+	if err != nil {
+		return err
+	}
+
+	if d.config["pci"] != "" {
+		for _, field := range []string{"id", "productid", "vendorid"} {
+			if d.config[field] != "" {
+				return fmt.Errorf(`Cannot use %q when "pci" is set`, field)
+			}
+		}
+
+		d.config["pci"] = pcidev.NormaliseAddress(d.config["pci"])
+	}
+
+	if d.config["id"] != "" {
+		for _, field := range []string{"pci", "productid", "vendorid"} {
+			if d.config[field] != "" {
+				return fmt.Errorf(`Cannot use %q when "id" is set`, field)
+			}
+		}
+	}
+
+	// The virtio GPU replaces the main display of the VM so only one can be used.
+	for name, dev := range instConf.ExpandedDevices() {
+		if name != d.name && dev["type"] == "gpu" && dev["gputype"] == "virtio" {
+			return fmt.Errorf("Only one virtio GPU device can be used per instance")
+		}
+	}
+
+	return nil
+}
+
+// validateEnvironment checks the runtime environment for correctness.
+func (d *gpuVirtio) validateEnvironment() error {
+	if util.IsTrue(d.inst.ExpandedConfig()["migration.stateful"]) {
+		return fmt.Errorf("GPU devices cannot be used when migration.stateful is enabled")
+	}
+
+	if d.inst.Architecture() == osarch.ARCH_64BIT_S390_BIG_ENDIAN {
+		return fmt.Errorf("Virtio GPU devices aren't supported on this architecture")
+	}
+
+	return validatePCIDevice(d.config["pci"])
+}
+
+// CanHotPlug returns whether the device can be managed whilst the instance is running.
+func (d *gpuVirtio) CanHotPlug() bool {
+	return false
+}
+
+// Start is run when the device is added to the instance.
+func (d *gpuVirtio) Start() (*deviceConfig.RunConfig, error) {
+	err := d.validateEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the render node of the host GPU.
+	gpus, err := resources.GetGPU()
+	if err != nil {
+		return nil, err
+	}
+
+	var renderPath string
+	for _, gpu := range gpus.Cards {
+		// Skip any cards that are not selected or don't have a render node.
+		if !gpuSelected(d.Config(), gpu) || gpu.DRM == nil || gpu.DRM.RenderName == "" {
+			continue
+		}
+
+		path := filepath.Join(gpuDRIDevPath, gpu.DRM.RenderName)
+		if util.PathExists(path) {
+			renderPath = path
+			break
+		}
+	}
+
+	if renderPath == "" {
+		return nil, fmt.Errorf("Failed to detect a host GPU with a render node")
+	}
+
+	// Clear any leftover device file from a previous run.
+	err = unixDeviceDeleteFiles(d.state, d.inst.DevicesPath(), "unix", d.name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a copy of the render node accessible to the unprivileged QEMU process.
+	renderDev := deviceConfig.Device{
+		"type":   "unix-char",
+		"source": renderPath,
+	}
+
+	if !d.state.OS.RunningInUserNS {
+		renderDev["uid"] = strconv.FormatUint(uint64(d.state.OS.UnprivUID), 10)
+		renderDev["mode"] = "0600"
+	}
+
+	dev, err := UnixDeviceCreate(d.state, nil, d.inst.DevicesPath(), deviceJoinPath("unix", d.name), renderDev, false)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to setup render node %q: %w", renderPath, err)
+	}
+
+	acceleration := d.config["acceleration"]
+	if acceleration == "" {
+		acceleration = "virgl"
+	}
+
+	runConf := deviceConfig.RunConfig{}
+	runConf.GPUDevice = append(runConf.GPUDevice,
+		[]deviceConfig.RunConfigItem{
+			{Key: "devName", Value: d.name},
+			{Key: "virtio", Value: acceleration},
+			{Key: "renderNode", Value: dev.HostPath},
+		}...)
+
+	return &runConf, nil
+}
+
+// Stop is run when the device is removed from the instance.
+func (d *gpuVirtio) Stop() (*deviceConfig.RunConfig, error) {
+	runConf := deviceConfig.RunConfig{
+		PostHooks: []func() error{d.postStop},
+	}
+
+	return &runConf, nil
+}
+
+// postStop is run after the device is removed from the instance.
+func (d *gpuVirtio) postStop() error {
+	err := unixDeviceDeleteFiles(d.state, d.inst.DevicesPath(), "unix", d.name, "")
+	if err != nil {
+		return fmt.Errorf("Failed to delete files for device %q: %w", d.name, err)
+	}
+
+	return nil
+}
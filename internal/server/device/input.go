@@ -0,0 +1,65 @@
+package device
+
+import (
+	"fmt"
+
+	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/shared/osarch"
+	"github.com/lxc/incus/v6/shared/validate"
+)
+
+type input struct {
+	deviceCommon
+}
+
+// CanMigrate returns whether the device can be migrated to any other cluster member.
+func (d *input) CanMigrate() bool {
+	return true
+}
+
+// validateConfig checks the supplied config for correctness.
+func (d *input) validateConfig(instConf instance.ConfigReader) error {
+	if !instanceSupported(instConf.Type(), instancetype.VM) {
+		return ErrUnsupportedDevType
+	}
+
+	rules := map[string]func(string) error{
+		// gendoc:generate(entity=devices, group=input, key=model)
+		//
+		// ---
+		//  type: string
+		//  required: yes
+		//  shortdesc: The emulated input device (`usb-tablet`, `usb-keyboard` or `usb-mouse`)
+		"model": validate.IsOneOf("usb-tablet", "usb-keyboard", "usb-mouse"),
+	}
+
+	err := d.config.Validate(rules)
+	if err != nil {
+		return fmt.Errorf("Failed to validate config: %w", err)
+	}
+
+	return nil
+}
+
+// Start is run when the device is added to the instance.
+func (d *input) Start() (*deviceConfig.RunConfig, error) {
+	if d.inst.Architecture() == osarch.ARCH_64BIT_S390_BIG_ENDIAN {
+		return nil, fmt.Errorf("USB input devices aren't supported on this architecture")
+	}
+
+	runConf := deviceConfig.RunConfig{}
+	runConf.InputDevice = append(runConf.InputDevice,
+		[]deviceConfig.RunConfigItem{
+			{Key: "devName", Value: d.name},
+			{Key: "model", Value: d.config["model"]},
+		}...)
+
+	return &runConf, nil
+}
+
+// Stop is run when the device is removed from the instance.
+func (d *input) Stop() (*deviceConfig.RunConfig, error) {
+	return &deviceConfig.RunConfig{}, nil
+}
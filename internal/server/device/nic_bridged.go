@@ -30,6 +30,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/internal/server/ip"
+	"github.com/lxc/incus/v6/internal/server/metrics"
 	"github.com/lxc/incus/v6/internal/server/network"
 	"github.com/lxc/incus/v6/internal/server/network/acl"
 	addressSet "github.com/lxc/incus/v6/internal/server/network/address-set"
@@ -456,7 +457,13 @@ func (d *nicBridged) validateConfig(instConf instance.ConfigReader) error {
 		d.config["parent"] = d.config["network"]
 
 		// Apply network level config options to device config before validation.
-		if netConfig["bridge.mtu"] != "" {
+		if netConfig["bridge.mtu"] == "auto" {
+			// Use the MTU discovered by the network on the local bridge.
+			mtu, err := network.GetDevMTU(d.config["network"])
+			if err == nil {
+				d.config["mtu"] = fmt.Sprintf("%d", mtu)
+			}
+		} else if netConfig["bridge.mtu"] != "" {
 			d.config["mtu"] = netConfig["bridge.mtu"]
 		}
 	} else {
@@ -695,7 +702,7 @@ func (d *nicBridged) UpdatableFields(oldDevice Type) []string {
 		return []string{}
 	}
 
-	return []string{"limits.ingress", "limits.egress", "limits.max", "limits.priority", "ipv4.routes", "ipv6.routes", "ipv4.routes.external", "ipv6.routes.external", "ipv4.address", "ipv6.address", "security.mac_filtering", "security.ipv4_filtering", "security.ipv6_filtering", "security.acls", "security.acls.default.egress.action", "security.acls.default.egress.logged", "security.acls.default.ingress.action", "security.acls.default.ingress.logged"}
+	return []string{"boot.priority", "limits.ingress", "limits.egress", "limits.max", "limits.priority", "ipv4.routes", "ipv6.routes", "ipv4.routes.external", "ipv6.routes.external", "ipv4.address", "ipv6.address", "security.mac_filtering", "security.ipv4_filtering", "security.ipv6_filtering", "security.acls", "security.acls.default.egress.action", "security.acls.default.egress.logged", "security.acls.default.ingress.action", "security.acls.default.ingress.logged"}
 }
 
 // Add is run when a device is added to a non-snapshot instance whether or not the instance is running.
@@ -1043,6 +1050,13 @@ func (d *nicBridged) Update(oldDevices deviceConfig.Devices, isRunning bool) err
 	return nil
 }
 
+// Metrics returns the counters of the host-side interface of the NIC.
+func (d *nicBridged) Metrics() (*metrics.MetricSet, error) {
+	networkVethFillFromVolatile(d.config, d.volatileGet())
+
+	return networkNICHostMetrics(d.name, d.config["host_name"])
+}
+
 // Stop is run when the device is removed from the instance.
 func (d *nicBridged) Stop() (*deviceConfig.RunConfig, error) {
 	// Remove BGP announcements.
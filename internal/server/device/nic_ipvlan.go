@@ -19,6 +19,7 @@ import (
 
 const (
 	ipvlanModeL3S = "l3s"
+	ipvlanModeL3  = "l3"
 	ipvlanModeL2  = "l2"
 )
 
@@ -74,16 +75,16 @@ func (d *nicIPVLAN) validateConfig(instConf instance.ConfigReader) error {
 		//
 		// ---
 		//  type: string
-		//  default: `auto` (in `l3s` mode), `-` (in `l2` mode)
-		//  shortdesc: In `l3s` mode, whether to add an automatic default IPv4 gateway (can be `auto` or `none`). In `l2` mode, the IPv4 address of the gateway
+		//  default: `auto` (in `l3` and `l3s` modes), `-` (in `l2` mode)
+		//  shortdesc: In `l3` and `l3s` modes, whether to add an automatic default IPv4 gateway (can be `auto` or `none`). In `l2` mode, the IPv4 address of the gateway (or `auto` to use the parent's default gateway)
 		"ipv4.gateway",
 
 		// gendoc:generate(entity=devices, group=nic_ipvlan, key=ipv6.gateway)
 		//
 		// ---
 		//  type: string
-		//  default: `auto` (in `l3s` mode), `-` (in `l2` mode)
-		//  shortdesc: In `l3s` mode, whether to add an automatic default IPv6 gateway (can be `auto` or `none`). In `l2` mode, the IPv6 address of the gateway
+		//  default: `auto` (in `l3` and `l3s` modes), `-` (in `l2` mode)
+		//  shortdesc: In `l3` and `l3s` modes, whether to add an automatic default IPv6 gateway (can be `auto` or `none`). In `l2` mode, the IPv6 address of the gateway (or `auto` to use the parent's default gateway)
 		"ipv6.gateway",
 
 		// gendoc:generate(entity=devices, group=nic_ipvlan, key=ipv4.host_table)
@@ -107,10 +108,19 @@ func (d *nicIPVLAN) validateConfig(instConf instance.ConfigReader) error {
 		//  default: false
 		//  shortdesc: Register VLAN using GARP VLAN Registration Protocol
 		"gvrp",
+
+		// gendoc:generate(entity=devices, group=nic_ipvlan, key=isolation)
+		//
+		// ---
+		//  type: string
+		//  default: `bridge`
+		//  shortdesc: The IPVLAN isolation mode (either `bridge`, `private` or `vepa`)
+		"isolation",
 	}
 
 	rules := nicValidationRules(requiredFields, optionalFields, instConf)
 	rules["gvrp"] = validate.Optional(validate.IsBool)
+	rules["isolation"] = validate.Optional(validate.IsOneOf("bridge", "private", "vepa"))
 
 	// gendoc:generate(entity=devices, group=nic_ipvlan, key=ipv4.address)
 	//
@@ -187,13 +197,13 @@ func (d *nicIPVLAN) validateConfig(instConf instance.ConfigReader) error {
 	// ---
 	//  type: string
 	//  default: `l3s`
-	//  shortdesc: The IPVLAN mode (either `l2` or `l3s`)
+	//  shortdesc: The IPVLAN mode (either `l2`, `l3` or `l3s`)
 	rules["mode"] = func(value string) error {
 		if value == "" {
 			return nil
 		}
 
-		validModes := []string{ipvlanModeL3S, ipvlanModeL2}
+		validModes := []string{ipvlanModeL3S, ipvlanModeL3, ipvlanModeL2}
 		if !slices.Contains(validModes, value) {
 			return fmt.Errorf("Must be one of: %v", strings.Join(validModes, ", "))
 		}
@@ -202,8 +212,8 @@ func (d *nicIPVLAN) validateConfig(instConf instance.ConfigReader) error {
 	}
 
 	if d.config["mode"] == ipvlanModeL2 {
-		rules["ipv4.gateway"] = validate.Optional(validate.IsNetworkAddressV4)
-		rules["ipv6.gateway"] = validate.Optional(validate.IsNetworkAddressV6)
+		rules["ipv4.gateway"] = validate.Optional(validate.Or(validate.IsOneOf("auto"), validate.IsNetworkAddressV4))
+		rules["ipv6.gateway"] = validate.Optional(validate.Or(validate.IsOneOf("auto"), validate.IsNetworkAddressV6))
 	}
 
 	err := d.config.Validate(rules)
@@ -211,8 +221,8 @@ func (d *nicIPVLAN) validateConfig(instConf instance.ConfigReader) error {
 		return err
 	}
 
-	if d.config["mode"] == ipvlanModeL2 && d.config["host_table"] != "" {
-		return fmt.Errorf("host_table option cannot be used in l2 mode")
+	if d.config["mode"] == ipvlanModeL2 && (d.config["ipv4.host_table"] != "" || d.config["ipv6.host_table"] != "") {
+		return fmt.Errorf("host_table options cannot be used in l2 mode")
 	}
 
 	return nil
@@ -242,8 +252,8 @@ func (d *nicIPVLAN) validateEnvironment() error {
 		return fmt.Errorf("The vlan setting can only be used when combined with a parent interface")
 	}
 
-	// Only check sysctls for l2proxy if mode is l3s.
-	if d.mode() != ipvlanModeL3S {
+	// Only check sysctls for l2proxy if mode is l3 or l3s.
+	if d.mode() == ipvlanModeL2 {
 		return nil
 	}
 
@@ -335,8 +345,8 @@ func (d *nicIPVLAN) Start() (*deviceConfig.RunConfig, error) {
 
 	mode := d.mode()
 
-	// If we created a VLAN interface, we need to setup the sysctls on that interface for l3s mode l2proxy.
-	if statusDev == "created" && mode == ipvlanModeL3S {
+	// If we created a VLAN interface, we need to setup the sysctls on that interface for l3 and l3s mode l2proxy.
+	if statusDev == "created" && mode != ipvlanModeL2 {
 		err := d.setupParentSysctls(parentName)
 		if err != nil {
 			return nil, err
@@ -354,7 +364,7 @@ func (d *nicIPVLAN) Start() (*deviceConfig.RunConfig, error) {
 		{Key: "type", Value: "ipvlan"},
 		{Key: "flags", Value: "up"},
 		{Key: "ipvlan.mode", Value: mode},
-		{Key: "ipvlan.isolation", Value: "bridge"},
+		{Key: "ipvlan.isolation", Value: d.isolation()},
 		{Key: "link", Value: parentName},
 	}
 
@@ -382,13 +392,19 @@ func (d *nicIPVLAN) Start() (*deviceConfig.RunConfig, error) {
 				return nil, err
 			}
 
+			// Make sure the address isn't already in use on the parent network.
+			err = d.checkAddressConflict(parentName, addr.IP)
+			if err != nil {
+				return nil, err
+			}
+
 			nic = append(nic, deviceConfig.RunConfigItem{
 				Key:   fmt.Sprintf("%s.address", keyPrefix),
 				Value: addr.String(),
 			})
 
 			// Perform host-side address configuration.
-			if mode == ipvlanModeL3S {
+			if mode != ipvlanModeL2 {
 				// Apply host-side static routes to main routing table to allow neighbour proxy.
 				r := ip.Route{
 					DevName: "lo",
@@ -422,7 +438,7 @@ func (d *nicIPVLAN) Start() (*deviceConfig.RunConfig, error) {
 					reverter.Add(func() { _ = r.Delete() })
 				}
 
-				// Add neighbour proxy entries on the host for l3s mode.
+				// Add neighbour proxy entries on the host for l3 and l3s modes.
 				np := ip.NeighProxy{
 					DevName: parentName,
 					Addr:    addr.IP,
@@ -440,7 +456,7 @@ func (d *nicIPVLAN) Start() (*deviceConfig.RunConfig, error) {
 		// Setup gateway configuration.
 		if len(addresses) > 0 {
 			gwKeyName := fmt.Sprintf("%s.gateway", keyPrefix)
-			if mode == ipvlanModeL3S && nicHasAutoGateway(d.config[gwKeyName]) {
+			if mode != ipvlanModeL2 && nicHasAutoGateway(d.config[gwKeyName]) {
 				nic = append(nic, deviceConfig.RunConfigItem{
 					Key:   gwKeyName,
 					Value: "dev",
@@ -448,9 +464,19 @@ func (d *nicIPVLAN) Start() (*deviceConfig.RunConfig, error) {
 			}
 
 			if mode == ipvlanModeL2 && d.config[gwKeyName] != "" {
+				gateway := d.config[gwKeyName]
+				if gateway == "auto" {
+					gwIP, err := network.GetDefaultGateway(parentName, keyPrefix == "ipv6")
+					if err != nil {
+						return nil, fmt.Errorf("Failed finding %s default gateway on %q: %w", keyPrefix, parentName, err)
+					}
+
+					gateway = gwIP.String()
+				}
+
 				nic = append(nic, deviceConfig.RunConfigItem{
 					Key:   gwKeyName,
-					Value: d.config[gwKeyName],
+					Value: gateway,
 				})
 			}
 		}
@@ -559,7 +585,7 @@ func (d *nicIPVLAN) postStop() error {
 			}
 
 			// Remove static routes and neighbour proxy rules to instance IPs from main routing table.
-			if mode == ipvlanModeL3S {
+			if mode != ipvlanModeL2 {
 				r := ip.Route{
 					DevName: "lo",
 					Route:   addr.String(),
@@ -618,13 +644,64 @@ func (d *nicIPVLAN) postStop() error {
 
 // mode returns the ipvlan mode to use.
 func (d *nicIPVLAN) mode() string {
-	if d.config["mode"] == ipvlanModeL2 {
-		return ipvlanModeL2
+	if d.config["mode"] == ipvlanModeL2 || d.config["mode"] == ipvlanModeL3 {
+		return d.config["mode"]
 	}
 
 	return ipvlanModeL3S
 }
 
+// isolation returns the ipvlan isolation mode to use.
+func (d *nicIPVLAN) isolation() string {
+	if d.config["isolation"] != "" {
+		return d.config["isolation"]
+	}
+
+	return "bridge"
+}
+
+// checkAddressConflict returns an error if the address is already used by the host or by another
+// device on the parent network. As IPVLAN interfaces share the parent's MAC address, duplicate address
+// detection cannot be relied upon inside the instance.
+func (d *nicIPVLAN) checkAddressConflict(parentName string, addr net.IP) error {
+	hostAddrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return fmt.Errorf("Failed getting host addresses: %w", err)
+	}
+
+	for _, hostAddr := range hostAddrs {
+		hostIP, _, err := net.ParseCIDR(hostAddr.String())
+		if err == nil && hostIP.Equal(addr) {
+			return fmt.Errorf("Address %q is already in use on the host", addr.String())
+		}
+	}
+
+	neigh := &ip.Neigh{DevName: parentName}
+	neighbours, err := neigh.Show()
+	if err != nil {
+		return nil
+	}
+
+	parentIface, _ := net.InterfaceByName(parentName)
+
+	for _, n := range neighbours {
+		if !n.Addr.Equal(addr) || n.MAC == nil {
+			continue
+		}
+
+		// Entries pointing at the parent's own MAC address belong to a previous use of the address by an IPVLAN interface.
+		if parentIface != nil && n.MAC.String() == parentIface.HardwareAddr.String() {
+			continue
+		}
+
+		if n.State == ip.NeighbourIPStateReachable || n.State == ip.NeighbourIPStatePermanent {
+			return fmt.Errorf("Address %q is already in use on %q by %q", addr.String(), parentName, n.MAC.String())
+		}
+	}
+
+	return nil
+}
+
 // parseAddress converts the specified address into a CIDR based on the IP family and mode.
 func (d *nicIPVLAN) parseAddress(addr string, ipFamily string, mode string) (*net.IPNet, error) {
 	// If singular IP specified then convert to appropriate CIDR value for family and mode.
@@ -632,7 +709,7 @@ func (d *nicIPVLAN) parseAddress(addr string, ipFamily string, mode string) (*ne
 		var defaultSubnetSize int
 
 		switch mode {
-		case ipvlanModeL3S:
+		case ipvlanModeL3S, ipvlanModeL3:
 			switch ipFamily {
 			case "ipv4":
 				defaultSubnetSize = 32
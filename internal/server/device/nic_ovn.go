@@ -23,6 +23,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/internal/server/ip"
+	"github.com/lxc/incus/v6/internal/server/metrics"
 	"github.com/lxc/incus/v6/internal/server/network"
 	"github.com/lxc/incus/v6/internal/server/network/acl"
 	addressset "github.com/lxc/incus/v6/internal/server/network/address-set"
@@ -77,7 +78,7 @@ func (d *nicOVN) UpdatableFields(oldDevice Type) []string {
 		return []string{}
 	}
 
-	return []string{"security.acls"}
+	return []string{"boot.priority", "security.acls"}
 }
 
 // validateConfig checks the supplied config for correctness.
@@ -133,6 +134,30 @@ func (d *nicOVN) validateConfig(instConf instance.ConfigReader) error {
 		//  shortdesc: The Maximum Transmit Unit (MTU) of the new interface
 		"mtu",
 
+		// gendoc:generate(entity=devices, group=nic_ovn, key=limits.ingress)
+		//
+		// ---
+		//  type: string
+		//  managed: no
+		//  shortdesc: I/O limit in bit/s for incoming traffic, enforced through OVN QoS (various suffixes supported, see {ref}instances-limit-units)
+		"limits.ingress",
+
+		// gendoc:generate(entity=devices, group=nic_ovn, key=limits.egress)
+		//
+		// ---
+		//  type: string
+		//  managed: no
+		//  shortdesc: I/O limit in bit/s for outgoing traffic, enforced through OVN QoS (various suffixes supported, see {ref}instances-limit-units)
+		"limits.egress",
+
+		// gendoc:generate(entity=devices, group=nic_ovn, key=limits.max)
+		//
+		// ---
+		//  type: string
+		//  managed: no
+		//  shortdesc: I/O limit in bit/s for both incoming and outgoing traffic (same as setting both limits.ingress and limits.egress)
+		"limits.max",
+
 		// gendoc:generate(entity=devices, group=nic_ovn, key=ipv4.address)
 		//
 		// ---
@@ -356,6 +381,9 @@ func (d *nicOVN) validateConfig(instConf instance.ConfigReader) error {
 
 	// Apply network level config options to device config before validation.
 	d.config["mtu"] = netConfig["bridge.mtu"]
+	if d.config["mtu"] == "auto" {
+		d.config["mtu"] = netConfig["volatile.bridge.mtu"]
+	}
 
 	// Check VLAN ID is valid.
 	if d.config["vlan"] != "" {
@@ -1058,6 +1086,13 @@ func (d *nicOVN) findRepresentorPort(volatile map[string]string) (string, error)
 	return representorPort, nil
 }
 
+// Metrics returns the counters of the host-side interface of the NIC.
+func (d *nicOVN) Metrics() (*metrics.MetricSet, error) {
+	networkVethFillFromVolatile(d.config, d.volatileGet())
+
+	return networkNICHostMetrics(d.name, d.config["host_name"])
+}
+
 // Stop is run when the device is removed from the instance.
 func (d *nicOVN) Stop() (*deviceConfig.RunConfig, error) {
 	runConf := deviceConfig.RunConfig{
@@ -3,12 +3,15 @@ package device
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/lxc/incus/v6/internal/linux"
 	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
 	pcidev "github.com/lxc/incus/v6/internal/server/device/pci"
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/revert"
 	"github.com/lxc/incus/v6/shared/util"
 	"github.com/lxc/incus/v6/shared/validate"
 )
@@ -31,6 +34,17 @@ func (d *pci) validateConfig(instConf instance.ConfigReader) error {
 		//  required: yes
 		//  shortdesc: PCI address of the device
 		"address": validate.IsPCIAddress,
+
+		// gendoc:generate(entity=devices, group=pci, key=iommu.bind)
+		// All devices in an IOMMU group must be passed through together.
+		// When enabled, other devices sharing the IOMMU group that are bound to a host driver
+		// get bound to `vfio-pci` when the instance starts and are restored when it stops.
+		// ---
+		//  type: bool
+		//  default: `false`
+		//  required: no
+		//  shortdesc: Whether to bind the other devices of the IOMMU group to `vfio-pci`
+		"iommu.bind": validate.Optional(validate.IsBool),
 	}
 
 	err := d.config.Validate(rules)
@@ -49,7 +63,31 @@ func (d *pci) validateEnvironment() error {
 		return fmt.Errorf("PCI devices cannot be used when migration.stateful is enabled")
 	}
 
-	return validatePCIDevice(d.config["address"])
+	err := validatePCIDevice(d.config["address"])
+	if err != nil {
+		return err
+	}
+
+	// Check the other devices sharing the IOMMU group.
+	hostDevices, criticalDevices, err := pciIOMMUGroupHostDevices(d.config["address"])
+	if err != nil {
+		return fmt.Errorf("Failed to check IOMMU group: %w", err)
+	}
+
+	if len(hostDevices) > 0 && util.IsFalseOrEmpty(d.config["iommu.bind"]) {
+		slotNames := make([]string, 0, len(hostDevices))
+		for _, hostDevice := range hostDevices {
+			slotNames = append(slotNames, fmt.Sprintf("%s (%s)", hostDevice.SlotName, hostDevice.Driver))
+		}
+
+		return fmt.Errorf("PCI device %q shares its IOMMU group with devices bound to host drivers: %s (set \"iommu.bind\" to pass them through too)", d.config["address"], strings.Join(slotNames, ", "))
+	}
+
+	for _, criticalDevice := range criticalDevices {
+		d.logger.Warn("PCI device shares its IOMMU group with a host-critical device", logger.Ctx{"address": d.config["address"], "device": criticalDevice.SlotName, "driver": criticalDevice.Driver})
+	}
+
+	return nil
 }
 
 // Start is run when the device is added to the instance.
@@ -59,6 +97,9 @@ func (d *pci) Start() (*deviceConfig.RunConfig, error) {
 		return nil, fmt.Errorf("Failed to validate environment: %w", err)
 	}
 
+	reverter := revert.New()
+	defer reverter.Fail()
+
 	runConf := deviceConfig.RunConfig{}
 	saveData := make(map[string]string)
 
@@ -84,11 +125,39 @@ func (d *pci) Start() (*deviceConfig.RunConfig, error) {
 		return nil, err
 	}
 
+	// Bind the other devices of the IOMMU group to vfio-pci if requested.
+	if util.IsTrue(d.config["iommu.bind"]) {
+		hostDevices, _, err := pciIOMMUGroupHostDevices(pciDev.SlotName)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to check IOMMU group: %w", err)
+		}
+
+		groupDrivers := make([]string, 0, len(hostDevices))
+		for _, hostDevice := range hostDevices {
+			err = pcidev.DeviceDriverOverride(hostDevice, "vfio-pci")
+			if err != nil {
+				return nil, fmt.Errorf("Failed to override IOMMU group driver for %q: %w", hostDevice.SlotName, err)
+			}
+
+			reverter.Add(func() {
+				_ = pcidev.DeviceDriverOverride(pcidev.Device{Driver: "vfio-pci", SlotName: hostDevice.SlotName}, hostDevice.Driver)
+			})
+
+			groupDrivers = append(groupDrivers, fmt.Sprintf("%s=%s", hostDevice.SlotName, hostDevice.Driver))
+		}
+
+		saveData["last_state.pci.iommu.drivers"] = strings.Join(groupDrivers, ",")
+	}
+
 	err = pcidev.DeviceDriverOverride(pciDev, "vfio-pci")
 	if err != nil {
 		return nil, fmt.Errorf("Failed to override IOMMU group driver: %w", err)
 	}
 
+	reverter.Add(func() {
+		_ = pcidev.DeviceDriverOverride(pcidev.Device{Driver: "vfio-pci", SlotName: pciDev.SlotName}, pciDev.Driver)
+	})
+
 	runConf.PCIDevice = append(runConf.PCIDevice,
 		[]deviceConfig.RunConfigItem{
 			{Key: "devName", Value: d.name},
@@ -101,6 +170,8 @@ func (d *pci) Start() (*deviceConfig.RunConfig, error) {
 		return nil, err
 	}
 
+	reverter.Success()
+
 	return &runConf, nil
 }
 
@@ -122,8 +193,9 @@ func (d *pci) Stop() (*deviceConfig.RunConfig, error) {
 func (d *pci) postStop() error {
 	defer func() {
 		_ = d.volatileSet(map[string]string{
-			"last_state.pci.slot.name": "",
-			"last_state.pci.driver":    "",
+			"last_state.pci.slot.name":     "",
+			"last_state.pci.driver":        "",
+			"last_state.pci.iommu.drivers": "",
 		})
 	}()
 
@@ -142,5 +214,20 @@ func (d *pci) postStop() error {
 		}
 	}
 
+	// Restore the host drivers of the other devices of the IOMMU group.
+	for _, entry := range util.SplitNTrimSpace(v["last_state.pci.iommu.drivers"], ",", -1, true) {
+		slotName, driver, _ := strings.Cut(entry, "=")
+
+		pciDev := pcidev.Device{
+			Driver:   "vfio-pci",
+			SlotName: slotName,
+		}
+
+		err := pcidev.DeviceDriverOverride(pciDev, driver)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
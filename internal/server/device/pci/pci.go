@@ -202,3 +202,49 @@ func DeviceIOMMUGroup(slotName string) (uint64, error) {
 
 	return iommuGroup, nil
 }
+
+// DeviceIOMMUGroupMembers returns the other PCI devices sharing the IOMMU group of a PCI device.
+// Returns an empty list if the device isn't part of an IOMMU group.
+func DeviceIOMMUGroupMembers(slotName string) ([]Device, error) {
+	groupDevicesPath := fmt.Sprintf("/sys/bus/pci/devices/%s/iommu_group/devices", slotName)
+	entries, err := os.ReadDir(groupDevicesPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return []Device{}, nil
+		}
+
+		return nil, fmt.Errorf("Failed listing IOMMU group devices via %q: %w", groupDevicesPath, err)
+	}
+
+	members := make([]Device, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Name() == slotName {
+			continue
+		}
+
+		dev, err := ParseUeventFile(filepath.Join("/sys/bus/pci/devices", entry.Name(), "uevent"))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get PCI device info for %q: %w", entry.Name(), err)
+		}
+
+		members = append(members, dev)
+	}
+
+	return members, nil
+}
+
+// DeviceClass returns the PCI class code (base class, sub class and programming interface) of a PCI device.
+func DeviceClass(slotName string) (uint32, error) {
+	classPath := fmt.Sprintf("/sys/bus/pci/devices/%s/class", slotName)
+	content, err := os.ReadFile(classPath)
+	if err != nil {
+		return 0, err
+	}
+
+	class, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimSpace(string(content)), "0x"), 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to parse %q: %w", classPath, err)
+	}
+
+	return uint32(class), nil
+}
@@ -126,6 +126,15 @@ func (d *proxy) validateConfig(instConf instance.ConfigReader) error {
 		// shortdesc: Whether to optimize proxying via NAT (requires that the instance NIC has a static IP address)
 		"nat": validate.Optional(validate.IsBool),
 
+		// gendoc:generate(entity=devices, group=proxy, key=nat.reflection)
+		//
+		// ---
+		// type: bool
+		// required: no
+		// default: `false`
+		// shortdesc: Whether to make the listen address reachable from other instances on the same network (requires `nat` and a managed network)
+		"nat.reflection": validate.Optional(validate.IsBool),
+
 		// gendoc:generate(entity=devices, group=proxy, key=gid)
 		//
 		// ---
@@ -210,6 +219,10 @@ func (d *proxy) validateConfig(instConf instance.ConfigReader) error {
 		return fmt.Errorf("Only proxy devices for non-abstract unix sockets can carry uid, gid, or mode properties")
 	}
 
+	if util.IsTrue(d.config["nat.reflection"]) && util.IsFalseOrEmpty(d.config["nat"]) {
+		return fmt.Errorf("NAT reflection can only be used in nat mode")
+	}
+
 	if util.IsTrue(d.config["nat"]) {
 		if d.inst != nil {
 			// Default project always has networks feature so don't bother loading the project config
@@ -476,6 +489,7 @@ func (d *proxy) setupNAT() error {
 
 	var connectIP net.IP
 	var hostName string
+	var nicConfig deviceConfig.Device
 
 	for devName, devConfig := range d.inst.ExpandedDevices() {
 		if devConfig["type"] != "nic" {
@@ -508,6 +522,7 @@ func (d *proxy) setupNAT() error {
 		if connectIP != nil {
 			// Get host_name of device so we can enable hairpin mode on bridge port.
 			hostName = d.inst.ExpandedConfig()[fmt.Sprintf("volatile.%s.host_name", devName)]
+			nicConfig = devConfig
 			break // Found a match, stop searching.
 		}
 	}
@@ -553,6 +568,24 @@ func (d *proxy) setupNAT() error {
 		}
 	}
 
+	// Get the subnet of the instance NIC's network to reflect traffic for if NAT reflection is enabled.
+	var reflection *net.IPNet
+	if util.IsTrue(d.config["nat.reflection"]) {
+		if nicConfig["network"] == "" {
+			return fmt.Errorf("NAT reflection requires the instance NIC to be connected to a managed network")
+		}
+
+		n, err := network.LoadByName(d.state, api.ProjectDefaultName, nicConfig["network"])
+		if err != nil {
+			return fmt.Errorf("Failed loading network %q: %w", nicConfig["network"], err)
+		}
+
+		_, reflection, err = net.ParseCIDR(n.Config()[fmt.Sprintf("ipv%d.address", ipVersion)])
+		if err != nil {
+			return fmt.Errorf("Network %q has no IPv%d subnet to use for NAT reflection", n.Name(), ipVersion)
+		}
+	}
+
 	// Convert proxy listen & connect addresses for firewall AddressForward.
 	addressForward := firewallDrivers.AddressForward{
 		Protocol:      listenAddr.ConnType,
@@ -560,6 +593,7 @@ func (d *proxy) setupNAT() error {
 		ListenPorts:   listenAddr.Ports,
 		TargetAddress: net.ParseIP(connectAddr.Address),
 		TargetPorts:   connectAddr.Ports,
+		Reflection:    reflection,
 	}
 
 	err = d.state.Firewall.InstanceSetupProxyNAT(d.inst.Project().Name, d.inst.Name(), d.name, &addressForward)
@@ -19,14 +19,42 @@ import (
 // usbDevPath is the path where USB devices can be enumerated.
 const usbDevPath = "/sys/bus/usb/devices"
 
+// usbMatches indicates whether the value matches the (possibly wildcard) filter.
+func usbMatches(filter string, value string) bool {
+	if filter == "" {
+		return true
+	}
+
+	matched, err := path.Match(filter, value)
+	if err != nil {
+		return false
+	}
+
+	return matched
+}
+
+// usbValidFilter validates a vendor or product ID filter, allowing shell wildcards.
+func usbValidFilter(value string) error {
+	if !strings.ContainsAny(value, "*?[") {
+		return validate.IsDeviceID(value)
+	}
+
+	_, err := path.Match(value, "")
+	if err != nil {
+		return fmt.Errorf("Invalid wildcard pattern %q: %w", value, err)
+	}
+
+	return nil
+}
+
 // usbIsOurDevice indicates whether the USB device event qualifies as part of our device.
 // This function is not defined against the usb struct type so that it can be used in event
 // callbacks without needing to keep a reference to the usb device struct.
 func usbIsOurDevice(config deviceConfig.Device, usb *USBEvent) bool {
 	// Check if event matches criteria for this device, if not return.
-	if (config["vendorid"] != "" && config["vendorid"] != usb.Vendor) ||
-		(config["productid"] != "" && config["productid"] != usb.Product) ||
-		(config["serial"] != "" && config["serial"] != usb.Serial) ||
+	if !usbMatches(config["vendorid"], usb.Vendor) ||
+		!usbMatches(config["productid"], usb.Product) ||
+		!usbMatches(config["serial"], usb.Serial) ||
 		(config["busnum"] != "" && config["busnum"] != fmt.Sprintf("%d", usb.BusNum)) ||
 		(config["devnum"] != "" && config["devnum"] != fmt.Sprintf("%d", usb.DevNum)) {
 		return false
@@ -60,22 +88,29 @@ func (d *usb) validateConfig(instConf instance.ConfigReader) error {
 		//
 		// ---
 		//  type: string
-		//  shortdesc: The vendor ID of the USB device
-		"vendorid": validate.Optional(validate.IsDeviceID),
+		//  shortdesc: The vendor ID of the USB device (supports `*`, `?` and `[]` wildcards)
+		"vendorid": validate.Optional(usbValidFilter),
 
 		// gendoc:generate(entity=devices, group=usb, key=productid)
 		//
 		// ---
 		//  type: string
-		//  shortdesc: The product ID of the USB device
-		"productid": validate.Optional(validate.IsDeviceID),
+		//  shortdesc: The product ID of the USB device (supports `*`, `?` and `[]` wildcards)
+		"productid": validate.Optional(usbValidFilter),
 
 		// gendoc:generate(entity=devices, group=usb, key=serial)
 		//
 		// ---
 		//  type: string
-		//  shortdesc: The serial number of the USB device
-		"serial": validate.Optional(validate.IsAny),
+		//  shortdesc: The serial number of the USB device (supports `*`, `?` and `[]` wildcards)
+		"serial": validate.Optional(func(value string) error {
+			_, err := path.Match(value, "")
+			if err != nil {
+				return fmt.Errorf("Invalid wildcard pattern %q: %w", value, err)
+			}
+
+			return nil
+		}),
 
 		// gendoc:generate(entity=devices, group=usb, key=uid)
 		//
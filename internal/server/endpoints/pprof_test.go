@@ -0,0 +1,31 @@
+package endpoints_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The pprof endpoint can be enabled and disabled after the endpoints are up.
+func TestEndpoints_PprofUpdateAddress(t *testing.T) {
+	endpoints, config, cleanup := newEndpoints(t)
+	defer cleanup()
+
+	require.NoError(t, endpoints.Up(config))
+	assert.Equal(t, "", endpoints.PprofAddress())
+
+	require.NoError(t, endpoints.PprofUpdateAddress("127.0.0.1:0"))
+
+	address := endpoints.PprofAddress()
+	require.NotEqual(t, "", address)
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/debug/pprof/", address))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	require.NoError(t, endpoints.PprofUpdateAddress(""))
+	assert.Equal(t, "", endpoints.PprofAddress())
+}
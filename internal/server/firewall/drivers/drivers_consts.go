@@ -50,6 +50,14 @@ type AddressForward struct {
 	ListenPorts   []uint64
 	TargetPorts   []uint64
 	SNAT          bool
+	Reflection    *net.IPNet // Masquerade traffic from this subnet reaching the target through the listen address. Off if nil.
+}
+
+// UserRules represents operator-defined rules inserted into a network's user chains.
+type UserRules struct {
+	Input   []string // Rules for traffic from the network to the host.
+	Forward []string // Rules for traffic forwarded to or from the network.
+	Output  []string // Rules for traffic from the host to the network.
 }
 
 // AddressSet represent an address set.
@@ -377,6 +377,7 @@ func (d Nftables) NetworkClear(networkName string, _ bool, _ []uint) error {
 		"fwd", "pstrt", "in", "out", // Chains used for network operation rules.
 		"aclin", "aclout", "aclfwd", "acl", // Chains used by ACL rules.
 		"fwdprert", "fwdout", "fwdpstrt", // Chains used by Address Forward rules.
+		"userin", "userout", "userfwd", // Chains used by user-defined rules.
 		"egress", // Chains added for limits.priority option
 	}
 
@@ -546,6 +547,16 @@ func (d Nftables) InstanceSetupProxyNAT(projectName string, instanceName string,
 			"targetHost":  targetAddressStr,
 			"targetPorts": targetPortRangeStr,
 		})
+
+		if forward.Reflection != nil {
+			snatRules = append(snatRules, map[string]any{
+				"ipFamily":         ipFamily,
+				"protocol":         forward.Protocol,
+				"targetHost":       targetAddressStr,
+				"targetPorts":      targetPortRangeStr,
+				"reflectionSubnet": forward.Reflection.String(),
+			})
+		}
 	}
 
 	dnatRanges := getOptimisedDNATRanges(forward)
@@ -1367,6 +1378,16 @@ func (d Nftables) NetworkApplyForwards(networkName string, rules []AddressForwar
 						"targetHost":  targetAddressStr,
 						"targetPorts": targetPortRangeStr,
 					})
+
+					if rule.Reflection != nil {
+						snatRules = append(snatRules, map[string]any{
+							"ipFamily":         ipFamily,
+							"protocol":         rule.Protocol,
+							"targetHost":       targetAddressStr,
+							"targetPorts":      targetPortRangeStr,
+							"reflectionSubnet": rule.Reflection.String(),
+						})
+					}
 				}
 
 				dnatRanges := getOptimisedDNATRanges(&rule)
@@ -1421,6 +1442,14 @@ func (d Nftables) NetworkApplyForwards(networkName string, rules []AddressForwar
 					"ipFamily":   ipFamily,
 					"targetHost": targetAddressStr,
 				})
+
+				if rule.Reflection != nil {
+					snatRules = append(snatRules, map[string]any{
+						"ipFamily":         ipFamily,
+						"targetHost":       targetAddressStr,
+						"reflectionSubnet": rule.Reflection.String(),
+					})
+				}
 			}
 		}
 	}
@@ -1457,6 +1486,41 @@ func (d Nftables) NetworkApplyForwards(networkName string, rules []AddressForwar
 	return nil
 }
 
+// NetworkApplyUserRules applies the user-defined rules to the network's user chains.
+func (d Nftables) NetworkApplyUserRules(networkName string, rules UserRules) error {
+	if len(rules.Input) == 0 && len(rules.Forward) == 0 && len(rules.Output) == 0 {
+		err := d.removeChains([]string{"inet"}, networkName, "userin", "userout", "userfwd")
+		if err != nil {
+			return fmt.Errorf("Failed clearing nftables user rules for network %q: %w", networkName, err)
+		}
+
+		return nil
+	}
+
+	tplFields := map[string]any{
+		"namespace":      nftablesNamespace,
+		"chainSeparator": nftablesChainSeparator,
+		"networkName":    networkName,
+		"family":         "inet",
+		"inputRules":     rules.Input,
+		"forwardRules":   rules.Forward,
+		"outputRules":    rules.Output,
+	}
+
+	config := &strings.Builder{}
+	err := nftablesNetUserRules.Execute(config, tplFields)
+	if err != nil {
+		return fmt.Errorf("Failed running %q template: %w", nftablesNetUserRules.Name(), err)
+	}
+
+	err = subprocess.RunCommandWithFds(context.TODO(), strings.NewReader(config.String()), nil, "nft", "-f", "-")
+	if err != nil {
+		return fmt.Errorf("Failed applying user rules for network %q: %w", networkName, err)
+	}
+
+	return nil
+}
+
 // NetworkApplyAddressSets creates or updates named nft sets for all address sets.
 func (d Nftables) NetworkApplyAddressSets(sets []AddressSet, nftTable string) error {
 	_, err := subprocess.RunCommand("nft", "create", "table", nftTable, nftablesNamespace)
@@ -105,7 +105,9 @@ table {{.family}} {{.namespace}} {
 	chain {{.chainPrefix}}pstrt{{.chainSeparator}}{{.label}} {
 		type nat hook postrouting priority 100; policy accept;
 		{{ range .snatRules }}
-		{{ if .targetHost }}
+		{{ if .reflectionSubnet }}
+		{{.ipFamily}} saddr {{.reflectionSubnet}} {{.ipFamily}} daddr {{.targetHost}} {{ if .protocol }}{{.protocol}} dport {{.targetPorts}}{{ end }} ct status dnat masquerade
+		{{ else if .targetHost }}
 		{{.ipFamily}} saddr {{.targetHost}} {{.ipFamily}} daddr {{.targetHost}} {{ if .protocol }}{{.protocol}} dport {{.targetPorts}}{{ end }} masquerade
 		{{ else }}
 		{{.ipFamily}} saddr {{.targetAddress}} {{.protocol}} sport {{.targetPorts}} snat to {{.listenAddress}}:{{.listenPorts}}
@@ -180,6 +182,41 @@ table {{.family}} {{.namespace}} {
 }
 `))
 
+// nftablesNetUserRules defines the user chains holding operator-defined rules for a network.
+// Each chain only applies to traffic entering or leaving the network's interface.
+var nftablesNetUserRules = template.Must(template.New("nftablesNetUserRules").Parse(`
+add table {{.family}} {{.namespace}}
+add chain {{.family}} {{.namespace}} userin{{.chainSeparator}}{{.networkName}} {type filter hook input priority -10; policy accept;}
+add chain {{.family}} {{.namespace}} userout{{.chainSeparator}}{{.networkName}} {type filter hook output priority -10; policy accept;}
+add chain {{.family}} {{.namespace}} userfwd{{.chainSeparator}}{{.networkName}} {type filter hook forward priority -10; policy accept;}
+flush chain {{.family}} {{.namespace}} userin{{.chainSeparator}}{{.networkName}}
+flush chain {{.family}} {{.namespace}} userout{{.chainSeparator}}{{.networkName}}
+flush chain {{.family}} {{.namespace}} userfwd{{.chainSeparator}}{{.networkName}}
+
+table {{.family}} {{.namespace}} {
+	chain userin{{.chainSeparator}}{{.networkName}} {
+		iifname != "{{.networkName}}" return
+		{{ range .inputRules }}
+		{{.}}
+		{{ end }}
+	}
+
+	chain userout{{.chainSeparator}}{{.networkName}} {
+		oifname != "{{.networkName}}" return
+		{{ range .outputRules }}
+		{{.}}
+		{{ end }}
+	}
+
+	chain userfwd{{.chainSeparator}}{{.networkName}} {
+		iifname != "{{.networkName}}" oifname != "{{.networkName}}" return
+		{{ range .forwardRules }}
+		{{.}}
+		{{ end }}
+	}
+}
+`))
+
 // nftablesInstanceBridgeFilter defines the rules needed for MAC, IPv4 and IPv6 bridge security filtering.
 // To prevent instances from using IPs that are different from their assigned IPs we use ARP and NDP filtering
 // to prevent neighbour advertisements that are not allowed. However in order for DHCPv4 & DHCPv6 to work back to
@@ -955,6 +955,15 @@ func (d Xtables) InstanceSetupProxyNAT(projectName string, instanceName string,
 		if err != nil {
 			return err
 		}
+
+		// Apply NAT reflection rule for each target range.
+		// instance <-> listen address <-> instance on the same network.
+		if forward.Reflection != nil {
+			err := d.iptablesPrepend(ipVersion, comment, "nat", "POSTROUTING", "-p", forward.Protocol, "--source", forward.Reflection.String(), "--destination", targetAddressStr, "--dport", targetPortRangeStr, "-m", "conntrack", "--ctstate", "DNAT", "-j", "MASQUERADE")
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	dnatRanges := getOptimisedDNATRanges(forward)
@@ -1602,6 +1611,15 @@ func (d Xtables) NetworkApplyForwards(networkName string, rules []AddressForward
 					if err != nil {
 						return err
 					}
+
+					// Apply NAT reflection rule for each target range.
+					// instance <-> listen address <-> instance on the same network.
+					if rule.Reflection != nil {
+						err := d.iptablesPrepend(ipVersion, comment, "nat", "POSTROUTING", "-p", rule.Protocol, "--source", rule.Reflection.String(), "--destination", targetAddressStr, "--dport", targetPortRangeStr, "-m", "conntrack", "--ctstate", "DNAT", "-j", "MASQUERADE")
+						if err != nil {
+							return err
+						}
+					}
 				}
 
 				dnatRanges := getOptimisedDNATRanges(&rule)
@@ -1655,6 +1673,14 @@ func (d Xtables) NetworkApplyForwards(networkName string, rules []AddressForward
 				if err != nil {
 					return err
 				}
+
+				// instance <-> listen address <-> instance on the same network.
+				if rule.Reflection != nil {
+					err = d.iptablesPrepend(ipVersion, comment, "nat", "POSTROUTING", "--source", rule.Reflection.String(), "--destination", targetAddressStr, "-m", "conntrack", "--ctstate", "DNAT", "-j", "MASQUERADE")
+					if err != nil {
+						return err
+					}
+				}
 			}
 		}
 	}
@@ -1664,6 +1690,15 @@ func (d Xtables) NetworkApplyForwards(networkName string, rules []AddressForward
 	return nil
 }
 
+// NetworkApplyUserRules isn't supported under xtables.
+func (d Xtables) NetworkApplyUserRules(networkName string, rules UserRules) error {
+	if len(rules.Input) == 0 && len(rules.Forward) == 0 && len(rules.Output) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("User firewall rules aren't supported by xtables firewalling")
+}
+
 // NetworkApplyAddressSets isn't supported under xtables.
 func (d Xtables) NetworkApplyAddressSets(sets []AddressSet, nftTable string) error {
 	return fmt.Errorf("Address sets aren't supported by xtables firewalling")
@@ -31,6 +31,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/instance/operationlock"
 	"github.com/lxc/incus/v6/internal/server/lifecycle"
 	"github.com/lxc/incus/v6/internal/server/locking"
+	"github.com/lxc/incus/v6/internal/server/metrics"
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/resources"
@@ -153,8 +154,31 @@ func (d *common) ExpiryDate() time.Time {
 	return time.Time{}
 }
 
-func (d *common) shouldAutoRestart() bool {
-	if !util.IsTrue(d.expandedConfig["boot.autorestart"]) {
+// restartPolicy returns the effective restart policy of the instance.
+func (d *common) restartPolicy() string {
+	policy := d.expandedConfig["restart.policy"]
+	if policy != "" {
+		return policy
+	}
+
+	if util.IsTrue(d.expandedConfig["boot.autorestart"]) {
+		return "always"
+	}
+
+	return "never"
+}
+
+// shouldAutoRestart returns whether the instance should be restarted after stopping on its own.
+// The failed argument indicates whether the instance crashed or was stopped by its watchdog.
+func (d *common) shouldAutoRestart(failed bool) bool {
+	switch d.restartPolicy() {
+	case "always":
+	case "on-failure":
+		if !failed {
+			return false
+		}
+
+	default:
 		return false
 	}
 
@@ -199,6 +223,26 @@ func (d *common) shouldAutoRestart() bool {
 	return false
 }
 
+// autoRestartDelay returns how long to wait before automatically restarting the instance.
+// The delay doubles with each automatic restart performed within the last minute, up to a minute.
+func (d *common) autoRestartDelay() time.Duration {
+	muInstancesLastRestart.Lock()
+	defer muInstancesLastRestart.Unlock()
+
+	recent := 0
+	for _, timestamp := range instancesLastRestart[d.id] {
+		if !timestamp.IsZero() && timestamp.After(time.Now().Add(-1*time.Minute)) {
+			recent++
+		}
+	}
+
+	if recent <= 1 {
+		return 0
+	}
+
+	return min(time.Second<<(recent-2), time.Minute)
+}
+
 // ID gets instances's ID.
 func (d *common) ID() int {
 	return d.id
@@ -949,7 +993,7 @@ func (d *common) validateStartup(stateful bool, statusCode api.StatusCode) error
 	}
 
 	// Validate architecture.
-	if !slices.Contains(d.state.OS.Architectures, d.architecture) {
+	if !instance.ArchitectureSupported(d.state.OS, d.Type(), d.architecture) {
 		return fmt.Errorf("Requested architecture isn't supported by this host")
 	}
 
@@ -1068,7 +1112,8 @@ func (d *common) recordLastState() error {
 		}
 
 		// Update time instance last started time.
-		err = tx.UpdateInstanceLastUsedDate(d.id, time.Now().UTC())
+		d.lastUsedDate = time.Now().UTC()
+		err = tx.UpdateInstanceLastUsedDate(d.id, d.lastUsedDate)
 		if err != nil {
 			err = fmt.Errorf("Error updating instance last used: %w", err)
 			return err
@@ -1078,6 +1123,62 @@ func (d *common) recordLastState() error {
 	})
 }
 
+// recordStartDuration records the time taken by the instance to start since the given time.
+func (d *common) recordStartDuration(start time.Time) {
+	err := d.VolatileSet(map[string]string{"volatile.last_state.start_duration": strconv.FormatInt(time.Since(start).Milliseconds(), 10)})
+	if err != nil {
+		d.logger.Warn("Failed recording start duration", logger.Ctx{"err": err})
+	}
+}
+
+// uptime returns the cumulative time the instance has been running for, including the current run when running.
+// The current run started when the instance was last used.
+func (d *common) uptime(running bool) time.Duration {
+	seconds, _ := strconv.ParseInt(d.localConfig["volatile.uptime"], 10, 64)
+	uptime := time.Duration(seconds) * time.Second
+
+	if running && !d.lastUsedDate.IsZero() {
+		uptime += time.Since(d.lastUsedDate)
+	}
+
+	return uptime
+}
+
+// stoppedConfig returns the volatile keys to record when the instance stops, adding its last run to its uptime.
+func (d *common) stoppedConfig() map[string]string {
+	changes := map[string]string{
+		"volatile.last_state.power":          instance.PowerStateStopped,
+		"volatile.last_state.ready":          "false",
+		"volatile.last_state.agent_duration": "",
+	}
+
+	// Only count the run once, should the stop be handled twice.
+	if d.localConfig["volatile.last_state.power"] == instance.PowerStateRunning {
+		changes["volatile.uptime"] = strconv.FormatInt(int64(d.uptime(true).Seconds()), 10)
+	}
+
+	return changes
+}
+
+// availabilityMetrics returns the metrics of the last start and of the cumulative uptime of the running instance.
+func (d *common) availabilityMetrics() *metrics.MetricSet {
+	out := metrics.NewMetricSet(nil)
+
+	startDuration, err := strconv.ParseInt(d.localConfig["volatile.last_state.start_duration"], 10, 64)
+	if err == nil {
+		out.AddSamples(metrics.StartDurationSeconds, metrics.Sample{Value: float64(startDuration) / 1000})
+	}
+
+	agentDuration, err := strconv.ParseInt(d.localConfig["volatile.last_state.agent_duration"], 10, 64)
+	if err == nil {
+		out.AddSamples(metrics.AgentStartDurationSeconds, metrics.Sample{Value: float64(agentDuration) / 1000})
+	}
+
+	out.AddSamples(metrics.RunningSecondsTotal, metrics.Sample{Value: d.uptime(true).Seconds()})
+
+	return out
+}
+
 func (d *common) setCoreSched(pids []int) error {
 	if !d.state.OS.CoreScheduling {
 		return nil
@@ -1250,6 +1351,55 @@ func (d *common) deviceLoad(inst instance.Instance, deviceName string, rawConfig
 	return dev, err
 }
 
+// deviceMetrics returns the per-device metrics of the instance, from the I/O statistics of its disk devices (keyed
+// by device name) and from the host-side metrics of its other devices.
+func (d *common) deviceMetrics(inst instance.Instance, disks []metrics.DiskMetrics) *metrics.MetricSet {
+	out := metrics.NewMetricSet(nil)
+
+	for _, stats := range disks {
+		labels := map[string]string{"device": stats.Device}
+		if d.expandedDevices[stats.Device]["pool"] != "" {
+			labels["pool"] = d.expandedDevices[stats.Device]["pool"]
+		}
+
+		out.AddSamples(metrics.DeviceDiskReadBytesTotal, metrics.Sample{Value: float64(stats.ReadBytes), Labels: labels})
+		out.AddSamples(metrics.DeviceDiskReadsCompletedTotal, metrics.Sample{Value: float64(stats.ReadsCompleted), Labels: labels})
+		out.AddSamples(metrics.DeviceDiskWrittenBytesTotal, metrics.Sample{Value: float64(stats.WrittenBytes), Labels: labels})
+		out.AddSamples(metrics.DeviceDiskWritesCompletedTotal, metrics.Sample{Value: float64(stats.WritesCompleted), Labels: labels})
+	}
+
+	for _, entry := range d.expandedDevices.Sorted() {
+		if !slices.Contains([]string{"nic", "gpu"}, entry.Config["type"]) {
+			continue
+		}
+
+		dev, err := d.deviceLoad(inst, entry.Name, entry.Config)
+		if err != nil {
+			if errors.Is(err, device.ErrUnsupportedDevType) {
+				continue // Skip unsupported device (allows for mixed instance type profiles).
+			}
+
+			d.logger.Warn("Failed loading device for metrics", logger.Ctx{"device": entry.Name, "err": err})
+			continue
+		}
+
+		devMetrics, ok := dev.(device.Metrics)
+		if !ok {
+			continue
+		}
+
+		metricSet, err := devMetrics.Metrics()
+		if err != nil {
+			d.logger.Warn("Failed getting device metrics", logger.Ctx{"device": entry.Name, "err": err})
+			continue
+		}
+
+		out.Merge(metricSet)
+	}
+
+	return out
+}
+
 // deviceAdd loads a new device and calls its Add() function.
 func (d *common) deviceAdd(dev device.Device, instanceRunning bool) error {
 	l := d.logger.AddContext(logger.Ctx{"device": dev.Name(), "type": dev.Config()["type"]})
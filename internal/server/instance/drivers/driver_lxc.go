@@ -450,10 +450,12 @@ func (d *lxc) findIdmap() (*idmap.Set, int64, error) {
 		return nil, 0, fmt.Errorf("System doesn't have a functional idmap setup")
 	}
 
+	isolated := project.IdmapIsolated(&d.project, d.expandedConfig)
+
 	idmapSize := func(size string) (int64, error) {
 		var idMapSize int64
 		if size == "" || size == "auto" {
-			if util.IsTrue(d.expandedConfig["security.idmap.isolated"]) {
+			if isolated {
 				idMapSize = 65536
 			} else {
 				if len(d.state.OS.IdmapSet.Entries) != 2 {
@@ -495,7 +497,7 @@ func (d *lxc) findIdmap() (*idmap.Set, int64, error) {
 		return set, nil
 	}
 
-	if !util.IsTrue(d.expandedConfig["security.idmap.isolated"]) {
+	if !isolated {
 		// Create a new set based from the global one.
 		newIdmapset := idmap.Set{Entries: make([]idmap.Entry, len(d.state.OS.IdmapSet.Entries))}
 		copy(newIdmapset.Entries, d.state.OS.IdmapSet.Entries)
@@ -554,7 +556,22 @@ func (d *lxc) findIdmap() (*idmap.Set, int64, error) {
 		return nil, 0, err
 	}
 
+	// Allocate from the project's dedicated range if set, otherwise from the system one.
 	offset := d.state.OS.IdmapSet.Entries[0].HostID + 65536
+	limit := d.state.OS.IdmapSet.Entries[0].HostID + d.state.OS.IdmapSet.Entries[0].MapRange
+	if d.project.Config["security.idmap.range"] != "" {
+		rangeBase, rangeSize, err := project.IdmapRange(d.project.Config["security.idmap.range"])
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if rangeBase < d.state.OS.IdmapSet.Entries[0].HostID || rangeBase+rangeSize > limit {
+			return nil, 0, fmt.Errorf("Project ID range %q isn't within the system's uid/gid allocation", d.project.Config["security.idmap.range"])
+		}
+
+		offset = rangeBase
+		limit = rangeBase + rangeSize
+	}
 
 	mapentries := idmap.ByHostID{}
 	for _, container := range cts {
@@ -571,7 +588,8 @@ func (d *lxc) findIdmap() (*idmap.Set, int64, error) {
 			continue
 		}
 
-		if util.IsFalseOrEmpty(container.ExpandedConfig()["security.idmap.isolated"]) {
+		containerProject := container.Project()
+		if !project.IdmapIsolated(&containerProject, container.ExpandedConfig()) {
 			continue
 		}
 
@@ -589,9 +607,32 @@ func (d *lxc) findIdmap() (*idmap.Set, int64, error) {
 			return nil, 0, err
 		}
 
+		// Ignore allocations outside of the range we're allocating from.
+		if cBase+cSize <= offset || cBase >= limit {
+			continue
+		}
+
 		mapentries.Entries = append(mapentries.Entries, idmap.Entry{HostID: int64(cBase), MapRange: cSize})
 	}
 
+	// Skip the ranges reserved to other projects.
+	var reserved map[string][2]int64
+	err = d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		reserved, err = project.IdmapRanges(ctx, tx)
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for projectName, reservedRange := range reserved {
+		if projectName == d.project.Name || reservedRange[0]+reservedRange[1] <= offset || reservedRange[0] >= limit {
+			continue
+		}
+
+		mapentries.Entries = append(mapentries.Entries, idmap.Entry{HostID: reservedRange[0], MapRange: reservedRange[1]})
+	}
+
 	sort.Sort(mapentries)
 
 	for i := range mapentries.Entries {
@@ -627,7 +668,7 @@ func (d *lxc) findIdmap() (*idmap.Set, int64, error) {
 		offset = mapentries.Entries[i].HostID + mapentries.Entries[i].MapRange
 	}
 
-	if offset+size <= d.state.OS.IdmapSet.Entries[0].HostID+d.state.OS.IdmapSet.Entries[0].MapRange {
+	if offset+size <= limit {
 		set, err := mkIdmap(offset, size)
 		if err != nil && errors.Is(err, idmap.ErrHostIDIsSubID) {
 			return nil, 0, err
@@ -649,6 +690,37 @@ func (d *lxc) init() error {
 	return nil
 }
 
+// setMemoryProtection applies the limits.memory.min or limits.memory.low value to the container's cgroup.
+func (d *lxc) setMemoryProtection(cg *cgroup.CGroup, key string) error {
+	// An empty value removes the protection.
+	limit := int64(0)
+	if d.expandedConfig[key] != "" {
+		value, err := units.ParseByteSizeString(d.expandedConfig[key])
+		if err != nil {
+			return err
+		}
+
+		limit = value
+	}
+
+	var err error
+	if key == "limits.memory.min" {
+		err = cg.SetMemoryMin(limit)
+	} else {
+		err = cg.SetMemoryLow(limit)
+	}
+
+	if err != nil {
+		if errors.Is(err, cgroup.ErrControllerMissing) {
+			return fmt.Errorf("Cannot apply %s as it requires the unified cgroup hierarchy", key)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
 func (d *lxc) initLXC(config bool) (*liblxc.Container, error) {
 	d.cMu.Lock()
 	defer d.cMu.Unlock()
@@ -818,8 +890,8 @@ func (d *lxc) initLXC(config bool) (*liblxc.Container, error) {
 		"/sys/kernel/tracing",
 	}
 
-	// Handle unprivileged binfmt_misc.
-	if d.IsPrivileged() || !d.state.OS.UnprivBinfmt {
+	// Handle unprivileged binfmt_misc and keep the host's emulators visible to emulated instances.
+	if d.IsPrivileged() || !d.state.OS.UnprivBinfmt || instance.ArchitectureEmulated(d.state.OS, d.architecture) {
 		bindMounts = append(bindMounts, "/proc/sys/fs/binfmt_misc")
 	}
 
@@ -968,6 +1040,12 @@ func (d *lxc) initLXC(config bool) (*liblxc.Container, error) {
 		return nil, err
 	}
 
+	// Setup the user hooks.
+	err = d.hooksConfig(cc)
+	if err != nil {
+		return nil, err
+	}
+
 	// Setup the console
 	err = lxcSetConfigItem(cc, "lxc.tty.max", "0")
 	if err != nil {
@@ -988,6 +1066,12 @@ func (d *lxc) initLXC(config bool) (*liblxc.Container, error) {
 		}
 	}
 
+	// Setup the systemd integration
+	err = d.systemdConfig(cc)
+	if err != nil {
+		return nil, err
+	}
+
 	// Setup AppArmor
 	if d.state.OS.AppArmorAvailable {
 		if d.state.OS.AppArmorConfined || !d.state.OS.AppArmorAdmin {
@@ -1209,6 +1293,18 @@ func (d *lxc) initLXC(config bool) (*liblxc.Container, error) {
 		}
 	}
 
+	// Memory protection
+	for _, key := range []string{"limits.memory.min", "limits.memory.low"} {
+		if d.expandedConfig[key] == "" {
+			continue
+		}
+
+		err = d.setMemoryProtection(cg, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// CPU limits
 	cpuPriority := d.expandedConfig["limits.cpu.priority"]
 	cpuAllowance := d.expandedConfig["limits.cpu.allowance"]
@@ -1275,6 +1371,24 @@ func (d *lxc) initLXC(config bool) (*liblxc.Container, error) {
 		}
 	}
 
+	// Cgroup depth
+	maxDepth := d.expandedConfig["linux.cgroup.max_depth"]
+	if maxDepth != "" {
+		if d.state.OS.CGInfo.Layout != cgroup.CgroupsUnified {
+			return nil, fmt.Errorf("Cannot apply linux.cgroup.max_depth as the host doesn't use cgroup2")
+		}
+
+		valueInt, err := strconv.ParseInt(maxDepth, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		err = cg.SetMaxDepth(valueInt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Hugepages
 	if d.state.OS.CGInfo.Supports(cgroup.Hugetlb, cg) {
 		for i, key := range internalInstance.HugePageSizeKeys {
@@ -1919,6 +2033,205 @@ func (d *lxc) handleIdmappedStorage() (idmap.StorageType, *idmap.Set, error) {
 	return idmapType, nextIdmap, nil
 }
 
+// idmapNeedsRemap returns whether the allocated idmap doesn't match the current idmap policy of the container and
+// its project anymore, or whether its filesystem still has to be shifted to it.
+func (d *lxc) idmapNeedsRemap() (bool, error) {
+	base, err := strconv.ParseInt(d.localConfig["volatile.idmap.base"], 10, 64)
+	if err != nil {
+		return true, nil
+	}
+
+	isolated := project.IdmapIsolated(&d.project, d.expandedConfig)
+	if isolated != (base != 0) {
+		return true, nil
+	}
+
+	if isolated && d.expandedConfig["security.idmap.base"] == "" && d.project.Config["security.idmap.range"] != "" {
+		rangeBase, rangeSize, err := project.IdmapRange(d.project.Config["security.idmap.range"])
+		if err != nil {
+			return false, err
+		}
+
+		if base < rangeBase || base >= rangeBase+rangeSize {
+			return true, nil
+		}
+	}
+
+	diskIdmap, err := d.DiskIdmap()
+	if err != nil {
+		return false, err
+	}
+
+	nextIdmap, err := d.NextIdmap()
+	if err != nil {
+		return false, err
+	}
+
+	return diskIdmap != nil && !nextIdmap.Equals(diskIdmap), nil
+}
+
+// Remap allocates a new idmap to the stopped container if its current one doesn't match the idmap
+// configuration of the container and its project, then shifts its filesystem accordingly.
+// It returns false if the container didn't need to be remapped.
+func (d *lxc) Remap() (bool, error) {
+	if d.IsPrivileged() {
+		return false, nil
+	}
+
+	// Prevent the container from being started while remapped.
+	op, err := operationlock.Create(d.Project().Name, d.Name(), d.op, operationlock.ActionUpdate, false, false)
+	if err != nil {
+		return false, fmt.Errorf("Failed to create instance remap operation: %w", err)
+	}
+
+	defer op.Done(nil)
+
+	if d.IsRunning() {
+		return false, api.StatusErrorf(http.StatusBadRequest, "The instance must be stopped to be remapped")
+	}
+
+	needsRemap, err := d.idmapNeedsRemap()
+	if err != nil {
+		return false, err
+	}
+
+	if !needsRemap {
+		return false, nil
+	}
+
+	idmapSet, base, err := d.findIdmap()
+	if err != nil {
+		return false, fmt.Errorf("Failed to get ID map: %w", err)
+	}
+
+	idmapSetJSON, err := idmapSet.ToJSON()
+	if err != nil {
+		return false, fmt.Errorf("Failed to encode ID map: %w", err)
+	}
+
+	volatile := map[string]string{
+		"volatile.idmap.next": idmapSetJSON,
+		"volatile.idmap.base": fmt.Sprintf("%v", base),
+	}
+
+	// Shift the filesystem to the new idmap before recording it, going back to the current one on failure.
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	for key, value := range volatile {
+		oldValue, ok := d.localConfig[key]
+		d.localConfig[key] = value
+
+		reverter.Add(func() {
+			if ok {
+				d.localConfig[key] = oldValue
+			} else {
+				delete(d.localConfig, key)
+			}
+		})
+	}
+
+	// Invalidate the idmap cache.
+	d.idmapset = nil
+	reverter.Add(func() { d.idmapset = nil })
+
+	_, err = d.mount()
+	if err != nil {
+		return false, err
+	}
+
+	defer func() { _ = d.unmount() }()
+
+	_, _, err = d.handleIdmappedStorage()
+	if err != nil {
+		return false, err
+	}
+
+	err = d.VolatileSet(volatile)
+	if err != nil {
+		return false, fmt.Errorf("Failed to update volatile idmap: %w", err)
+	}
+
+	reverter.Success()
+
+	d.logger.Info("Remapped container", logger.Ctx{"base": base})
+
+	return true, nil
+}
+
+// networkNamespaceInstance returns the name of the container whose network namespace is joined, if any.
+func (d *lxc) networkNamespaceInstance() string {
+	return d.expandedConfig["linux.network_namespace"]
+}
+
+// networkNamespacePID returns the PID of the running container whose network namespace is shared.
+func (d *lxc) networkNamespacePID(name string) (int, error) {
+	if name == d.name {
+		return -1, fmt.Errorf("A container can't share its own network namespace")
+	}
+
+	inst, err := instance.LoadByProjectAndName(d.state, d.project.Name, name)
+	if err != nil {
+		return -1, fmt.Errorf("Failed loading container %q to share the network namespace of: %w", name, err)
+	}
+
+	if inst.Type() != instancetype.Container {
+		return -1, fmt.Errorf("Only the network namespace of containers can be shared")
+	}
+
+	pid := inst.InitPID()
+	if !inst.IsRunning() || pid <= 0 {
+		return -1, fmt.Errorf("Container %q must be running to share its network namespace", name)
+	}
+
+	return pid, nil
+}
+
+// restartNetworkNamespaceUsers restarts the running containers which joined the network namespace of
+// this container, so they move to its new network namespace.
+func (d *lxc) restartNetworkNamespaceUsers() {
+	// Only look for the containers of the project on this member, loading the ones sharing the network namespace.
+	names := []string{}
+	instanceType := instancetype.Container
+	filter := cluster.InstanceFilter{Project: &d.project.Name, Node: &d.state.ServerName, Type: &instanceType}
+
+	err := d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.InstanceList(ctx, func(dbInst db.InstanceArgs, p api.Project) error {
+			if db.ExpandInstanceConfig(dbInst.Config, dbInst.Profiles)["linux.network_namespace"] == d.name {
+				names = append(names, dbInst.Name)
+			}
+
+			return nil
+		}, filter)
+	})
+	if err != nil {
+		d.logger.Warn("Failed listing containers sharing the network namespace", logger.Ctx{"err": err})
+		return
+	}
+
+	for _, name := range names {
+		inst, err := instance.LoadByProjectAndName(d.state, d.project.Name, name)
+		if err != nil {
+			d.logger.Warn("Failed loading container sharing the network namespace", logger.Ctx{"instance": name, "err": err})
+			continue
+		}
+
+		c, ok := inst.(*lxc)
+		if !ok || !c.IsRunning() {
+			continue
+		}
+
+		go func(c *lxc) {
+			c.logger.Info("Restarting container to join the new network namespace", logger.Ctx{"source": d.name})
+
+			err := c.Restart(30 * time.Second)
+			if err != nil {
+				c.logger.Warn("Failed restarting container to join the new network namespace", logger.Ctx{"source": d.name, "err": err})
+			}
+		}(c)
+	}
+}
+
 // Start functions.
 func (d *lxc) startCommon() (string, []func() error, error) {
 	postStartHooks := []func() error{}
@@ -1934,6 +2247,15 @@ func (d *lxc) startCommon() (string, []func() error, error) {
 		}
 	}
 
+	// Containers joining the network namespace of another container get their network from it.
+	if d.networkNamespaceInstance() != "" {
+		for name, dev := range d.expandedDevices {
+			if dev["type"] == "nic" {
+				return "", nil, fmt.Errorf("Network device %q can't be used when sharing the network namespace of %q", name, d.networkNamespaceInstance())
+			}
+		}
+	}
+
 	// Check if idmap needs changing.
 	if !d.IsPrivileged() {
 		nextMap, err := d.NextIdmap()
@@ -2300,6 +2622,20 @@ func (d *lxc) startCommon() (string, []func() error, error) {
 		}
 	}
 
+	// Join the network namespace of another container.
+	netnsInstance := d.networkNamespaceInstance()
+	if netnsInstance != "" {
+		pid, err := d.networkNamespacePID(netnsInstance)
+		if err != nil {
+			return "", nil, err
+		}
+
+		err = lxcSetConfigItem(cc, "lxc.namespace.share.net", fmt.Sprintf("%d", pid))
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
 	// Handle application containers.
 	if util.PathExists(filepath.Join(d.Path(), "config.json")) {
 		// Parse the OCI config.
@@ -2320,8 +2656,9 @@ func (d *lxc) startCommon() (string, []func() error, error) {
 		}
 
 		// Allow unprivileged users to use ping (requires a 6.6 kernel at least).
+		// The network sysctls are owned by the other container when sharing its network namespace.
 		minVer, _ := version.NewDottedVersion("6.6.0")
-		if d.state.OS.KernelVersion.Compare(minVer) >= 0 {
+		if netnsInstance == "" && d.state.OS.KernelVersion.Compare(minVer) >= 0 {
 			maxGid := int64(4294967294)
 
 			if !d.IsPrivileged() {
@@ -2345,9 +2682,11 @@ func (d *lxc) startCommon() (string, []func() error, error) {
 		}
 
 		// Allow unprivileged users to use low ports.
-		err = lxcSetConfigItem(cc, "lxc.sysctl.net.ipv4.ip_unprivileged_port_start", "0")
-		if err != nil {
-			return "", nil, err
+		if netnsInstance == "" {
+			err = lxcSetConfigItem(cc, "lxc.sysctl.net.ipv4.ip_unprivileged_port_start", "0")
+			if err != nil {
+				return "", nil, err
+			}
 		}
 
 		// Configure the entry point.
@@ -2696,6 +3035,8 @@ func (d *lxc) Start(stateful bool) error {
 		return fmt.Errorf("Stateful start requires that the instance migration.stateful be set to true")
 	}
 
+	startTime := time.Now()
+
 	d.logger.Debug("Start started", logger.Ctx{"stateful": stateful})
 	defer d.logger.Debug("Start finished", logger.Ctx{"stateful": stateful})
 
@@ -2769,6 +3110,8 @@ func (d *lxc) Start(stateful bool) error {
 			return fmt.Errorf("Failed clearing instance stateful flag: %w", err)
 		}
 
+		d.recordStartDuration(startTime)
+
 		if op.Action() == "start" {
 			d.logger.Info("Started instance", ctxMap)
 			d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceStarted.Event(d, nil))
@@ -2829,6 +3172,28 @@ func (d *lxc) Start(stateful bool) error {
 		env = append(env, fmt.Sprintf("%s=%s", k, v))
 	}
 
+	// Check and record the user hooks.
+	err = d.hooksValidate()
+	if err != nil {
+		op.Done(err)
+		return err
+	}
+
+	// Listen for the readiness notification of the container's init.
+	var notifyConn *net.UnixConn
+	if util.IsTrue(d.expandedConfig["linux.systemd.notify"]) {
+		notifyConn, err = d.notifyListen()
+		if err != nil {
+			op.Done(err)
+			return err
+		}
+
+		defer func() {
+			_ = notifyConn.Close()
+			_ = os.Remove(d.notifySocketPath())
+		}()
+	}
+
 	// Start the LXC container.
 	_, _, err = subprocess.RunCommandSplit(
 		context.TODO(),
@@ -2874,22 +3239,43 @@ func (d *lxc) Start(stateful bool) error {
 		return err
 	}
 
-	// Run any post start hooks.
-	err = d.runHooks(postStartHooks)
-	if err != nil {
-		op.Done(err) // Must come before Stop() otherwise stop will not proceed.
+	// Stop the container if it doesn't finish starting.
+	reverter := revert.New()
+	defer reverter.Fail()
 
-		// Attempt to stop container.
+	reverter.Add(func() {
+		// Runs after op.Done() otherwise stop will not proceed.
 		_ = d.Stop(false)
+	})
 
+	// Run any post start hooks.
+	err = d.runHooks(postStartHooks)
+	if err != nil {
+		op.Done(err)
 		return err
 	}
 
+	// Wait for the container's init to report ready.
+	if notifyConn != nil {
+		err = d.notifyWaitReady(notifyConn)
+		if err != nil {
+			op.Done(err)
+			return err
+		}
+	}
+
+	reverter.Success()
+
+	d.recordStartDuration(startTime)
+
 	if op.Action() == "start" {
 		d.logger.Info("Started instance", ctxMap)
 		d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceStarted.Event(d, nil))
 	}
 
+	// Move the containers sharing the network namespace to the new one.
+	d.restartNetworkNamespaceUsers()
+
 	return nil
 }
 
@@ -3319,11 +3705,8 @@ func (d *lxc) onStop(args map[string]string) error {
 	// Make sure we can't call go-lxc functions by mistake
 	d.fromHook = true
 
-	// Record power state.
-	err = d.VolatileSet(map[string]string{
-		"volatile.last_state.power": instance.PowerStateStopped,
-		"volatile.last_state.ready": "false",
-	})
+	// Record power state and uptime.
+	err = d.VolatileSet(d.stoppedConfig())
 	if err != nil {
 		// Don't return an error here as we still want to cleanup the instance even if DB not available.
 		d.logger.Error("Failed recording last power state", logger.Ctx{"err": err})
@@ -3423,13 +3806,21 @@ func (d *lxc) onStop(args map[string]string) error {
 		}
 
 		// Determine if instance should be auto-restarted.
+		// A container exiting can't be told apart from it crashing, so any unexpected stop counts as a failure.
 		var autoRestart bool
-		if target != "reboot" && op.GetInstanceInitiated() && d.shouldAutoRestart() {
+		if target != "reboot" && op.GetInstanceInitiated() && d.shouldAutoRestart(true) {
 			autoRestart = true
 
 			// Mark current shutdown as complete.
 			op.Done(nil)
 
+			// Back off when the instance keeps stopping.
+			delay := d.autoRestartDelay()
+			if delay > 0 {
+				d.logger.Info("Delaying automatic restart", logger.Ctx{"delay": delay})
+				time.Sleep(delay)
+			}
+
 			// Create a new restart operation.
 			op, err = operationlock.CreateWaitGet(d.Project().Name, d.Name(), d.op, operationlock.ActionRestart, nil, true, false)
 			if err == nil {
@@ -3462,7 +3853,12 @@ func (d *lxc) onStop(args map[string]string) error {
 				return
 			}
 
-			d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceRestarted.Event(d, nil))
+			var ctx map[string]any
+			if autoRestart {
+				ctx = map[string]any{"policy": d.restartPolicy()}
+			}
+
+			d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceRestarted.Event(d, ctx))
 
 			return
 		}
@@ -4810,7 +5206,7 @@ func (d *lxc) Update(args db.InstanceArgs, userRequested bool) error {
 	}
 
 	// If apparmor changed, re-validate the apparmor profile (even if not running).
-	if slices.Contains(changedConfig, "raw.apparmor") || slices.Contains(changedConfig, "security.nesting") {
+	if slices.Contains(changedConfig, "raw.apparmor") || slices.Contains(changedConfig, "security.apparmor.extra_rules") || slices.Contains(changedConfig, "security.nesting") {
 		err = apparmor.InstanceValidate(d.state.OS, d, nil)
 		if err != nil {
 			return fmt.Errorf("Parse AppArmor profile: %w", err)
@@ -4864,7 +5260,7 @@ func (d *lxc) Update(args db.InstanceArgs, userRequested bool) error {
 		for _, key := range changedConfig {
 			value := d.expandedConfig[key]
 
-			if key == "raw.apparmor" || key == "security.nesting" {
+			if key == "raw.apparmor" || key == "security.apparmor.extra_rules" || key == "security.nesting" {
 				// Update the AppArmor profile
 				err = apparmor.InstanceLoad(d.state.OS, d, nil)
 				if err != nil {
@@ -4930,6 +5326,11 @@ func (d *lxc) Update(args db.InstanceArgs, userRequested bool) error {
 				if err != nil {
 					return err
 				}
+			} else if key == "limits.memory.min" || key == "limits.memory.low" {
+				err = d.setMemoryProtection(cg, key)
+				if err != nil {
+					return err
+				}
 			} else if key == "limits.memory" || strings.HasPrefix(key, "limits.memory.") {
 				// Skip if no memory CGroup
 				if !d.state.OS.CGInfo.Supports(cgroup.Memory, cg) {
@@ -5081,6 +5482,17 @@ func (d *lxc) Update(args db.InstanceArgs, userRequested bool) error {
 						}
 					}
 				}
+			} else if key == "limits.cpu.partition" {
+				// Revert to a regular member before the scheduler re-applies the CPU set.
+				if value == "" || value == "member" {
+					err = cg.SetCpusetPartition("member")
+					if err != nil {
+						return fmt.Errorf("Failed to reset cpuset partition: %w", err)
+					}
+				}
+
+				// Trigger a scheduler re-run
+				defer cgroup.TaskSchedulerTrigger("container", d.name, "changed") //nolint:revive
 			} else if key == "limits.cpu" || key == "limits.cpu.nodes" {
 				// Trigger a scheduler re-run
 				defer cgroup.TaskSchedulerTrigger("container", d.name, "changed") //nolint:revive
@@ -5126,6 +5538,23 @@ func (d *lxc) Update(args db.InstanceArgs, userRequested bool) error {
 						return err
 					}
 				}
+			} else if key == "linux.cgroup.max_depth" {
+				if d.state.OS.CGInfo.Layout != cgroup.CgroupsUnified {
+					return fmt.Errorf("Cannot apply linux.cgroup.max_depth as the host doesn't use cgroup2")
+				}
+
+				valueInt := int64(-1)
+				if value != "" {
+					valueInt, err = strconv.ParseInt(value, 10, 64)
+					if err != nil {
+						return err
+					}
+				}
+
+				err = cg.SetMaxDepth(valueInt)
+				if err != nil {
+					return err
+				}
 			} else if strings.HasPrefix(key, "limits.hugepages.") {
 				if !d.state.OS.CGInfo.Supports(cgroup.Hugetlb, cg) {
 					continue
@@ -5297,7 +5726,7 @@ func (d *lxc) Update(args db.InstanceArgs, userRequested bool) error {
 }
 
 // Export backs up the instance.
-func (d *lxc) Export(metaWriter io.Writer, rootfsWriter io.Writer, properties map[string]string, expiration time.Time, tracker *ioprogress.ProgressTracker) (*api.ImageMetadata, error) {
+func (d *lxc) Export(metaWriter io.Writer, rootfsWriter io.Writer, properties map[string]string, expiration time.Time, stateful bool, tracker *ioprogress.ProgressTracker) (*api.ImageMetadata, error) {
 	ctxMap := logger.Ctx{
 		"created":   d.creationDate,
 		"ephemeral": d.ephemeral,
@@ -5319,6 +5748,11 @@ func (d *lxc) Export(metaWriter io.Writer, rootfsWriter io.Writer, properties ma
 
 	defer func() { _ = d.unmount() }()
 
+	if stateful && !util.PathExists(d.StatePath()) {
+		d.logger.Error("Failed exporting instance", ctxMap)
+		return nil, fmt.Errorf("The instance doesn't have any state to export")
+	}
+
 	// Get IDMap to unshift container as the tarball is created.
 	idmap, err := d.DiskIdmap()
 	if err != nil {
@@ -5446,6 +5880,11 @@ func (d *lxc) Export(metaWriter io.Writer, rootfsWriter io.Writer, properties ma
 		meta.ExpiryDate = expiration.UTC().Unix()
 	}
 
+	// Mark images holding a checkpoint so that their instances get restored on start.
+	if stateful {
+		meta.Properties["stateful"] = "true"
+	}
+
 	// Write the new metadata.yaml.
 	tempDir, err := os.MkdirTemp("", "incus_metadata_")
 	if err != nil {
@@ -5534,6 +5973,15 @@ func (d *lxc) Export(metaWriter io.Writer, rootfsWriter io.Writer, properties ma
 		}
 	}
 
+	// Include the checkpoint.
+	if stateful {
+		err = filepath.Walk(d.StatePath(), writeToMetaTar)
+		if err != nil {
+			d.logger.Error("Failed exporting instance", ctxMap)
+			return nil, err
+		}
+	}
+
 	err = metaTarWriter.Close()
 	if err != nil {
 		d.logger.Error("Failed exporting instance", ctxMap)
@@ -6843,6 +7291,16 @@ func (d *lxc) migrate(args *instance.CriuMigrationArgs) error {
 		d.logger.Warn("Unknown migrate call", logger.Ctx{"cmd": args.Cmd})
 	}
 
+	// Record the checkpoint and restore of live migrations in the timings of the operation.
+	if args.Function == "migration" {
+		phase := "snapshot"
+		if args.Cmd == liblxc.MIGRATE_RESTORE {
+			phase = "post_processing"
+		}
+
+		defer d.op.StartPhase(phase)()
+	}
+
 	pool, err := d.getStoragePool()
 	if err != nil {
 		return err
@@ -8936,6 +9394,12 @@ func (d *lxc) Metrics(hostInterfaces []net.Interface) (*metrics.MetricSet, error
 		}
 	}
 
+	// Get per-device stats
+	out.Merge(d.deviceMetrics(d, d.getDiskDeviceStats(diskStats)))
+
+	// Get start and uptime stats
+	out.Merge(d.availabilityMetrics())
+
 	// Get filesystem stats
 	fsStats, err := d.getFSStats()
 	if err != nil {
@@ -8971,6 +9435,79 @@ func (d *lxc) Metrics(hostInterfaces []net.Interface) (*metrics.MetricSet, error
 	return out, nil
 }
 
+// getDiskDeviceStats returns the I/O statistics of the disk devices backed by a block device, like the volumes of
+// block based storage pools, keyed by device name.
+func (d *lxc) getDiskDeviceStats(ioStats map[string]*cgroup.IOStats) []metrics.DiskMetrics {
+	if len(ioStats) == 0 {
+		return nil
+	}
+
+	mounts, err := os.ReadFile("/proc/mounts")
+	if err != nil {
+		d.logger.Warn("Failed to read /proc/mounts", logger.Ctx{"err": err})
+		return nil
+	}
+
+	// mountSources maps mount points to the mounted devices.
+	mountSources := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(mounts))
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		mountSources[fields[1]] = fields[0]
+	}
+
+	out := []metrics.DiskMetrics{}
+
+	for _, entry := range d.expandedDevices.Sorted() {
+		dev := entry.Config
+		if dev["type"] != "disk" || dev["path"] == "" {
+			continue
+		}
+
+		source := dev["source"]
+		if dev["pool"] != "" {
+			volName := project.Instance(d.project.Name, d.name)
+			volType := storageDrivers.VolumeTypeContainer
+			if dev["source"] != "" {
+				volName = project.StorageVolume(d.project.Name, dev["source"])
+				volType = storageDrivers.VolumeTypeCustom
+			}
+
+			source = mountSources[storageDrivers.GetVolumeMountPath(dev["pool"], volType, volName)]
+		}
+
+		if !strings.HasPrefix(source, "/dev/") {
+			continue
+		}
+
+		// The I/O statistics are keyed by kernel device name (e.g. /dev/mapper/pool-volume is dm-3).
+		realSource, err := filepath.EvalSymlinks(source)
+		if err != nil {
+			continue
+		}
+
+		stats := ioStats[filepath.Base(realSource)]
+		if stats == nil {
+			continue
+		}
+
+		out = append(out, metrics.DiskMetrics{
+			Device:          entry.Name,
+			ReadBytes:       stats.ReadBytes,
+			ReadsCompleted:  stats.ReadsCompleted,
+			WrittenBytes:    stats.WrittenBytes,
+			WritesCompleted: stats.WritesCompleted,
+		})
+	}
+
+	return out
+}
+
 func (d *lxc) getFSStats() (*metrics.MetricSet, error) {
 	type mountInfo struct {
 		Mountpoint string
@@ -0,0 +1,98 @@
+package drivers
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	liblxc "github.com/lxc/go-lxc"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// lxcHooks maps the linux.hooks.* keys to the LXC hooks they configure, in the order they're setup.
+var lxcHooks = [][2]string{
+	{"linux.hooks.pre_start", "lxc.hook.pre-start"},
+	{"linux.hooks.mount", "lxc.hook.mount"},
+	{"linux.hooks.post_stop", "lxc.hook.post-stop"},
+}
+
+// hookCommands returns the command lines of the configured user hooks, indexed by LXC hook.
+func (d *lxc) hookCommands() (map[string]string, error) {
+	values := map[string]string{
+		"name":    d.Name(),
+		"path":    d.Path(),
+		"project": d.Project().Name,
+		"rootfs":  d.RootfsPath(),
+	}
+
+	commands := map[string]string{}
+	for _, hook := range lxcHooks {
+		value := d.expandedConfig[hook[0]]
+		if value == "" {
+			continue
+		}
+
+		command, err := internalInstance.LXCHookCommand(value, values)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid %q: %w", hook[0], err)
+		}
+
+		commands[hook[1]] = command
+	}
+
+	return commands, nil
+}
+
+// hooksConfig adds the user hooks to the LXC configuration, after the internal ones.
+func (d *lxc) hooksConfig(cc *liblxc.Container) error {
+	commands, err := d.hookCommands()
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range lxcHooks {
+		command, ok := commands[hook[1]]
+		if !ok {
+			continue
+		}
+
+		err = lxcSetConfigItem(cc, hook[1], command)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hooksValidate checks that the executables of the user hooks exist on the host and logs them for auditing.
+func (d *lxc) hooksValidate() error {
+	commands, err := d.hookCommands()
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range lxcHooks {
+		command, ok := commands[hook[1]]
+		if !ok {
+			continue
+		}
+
+		executable := strings.Fields(command)[0]
+
+		info, err := os.Stat(executable)
+		if err != nil {
+			return fmt.Errorf("Failed to find the executable of %q: %w", hook[0], err)
+		}
+
+		if info.IsDir() || info.Mode().Perm()&0o111 == 0 {
+			return fmt.Errorf("The executable of %q (%q) isn't executable", hook[0], executable)
+		}
+
+		d.logger.Info("Configured LXC hook", logger.Ctx{"hook": strings.TrimPrefix(hook[1], "lxc.hook."), "command": command})
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package drivers
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	liblxc "github.com/lxc/go-lxc"
+
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// lxcHostJournalPath is the host directory holding the systemd journal sockets.
+const lxcHostJournalPath = "/run/systemd/journal"
+
+// lxcHostResourcesPath is the directory of the container which holds the resources shared by the host.
+// It lives in /dev as systemd mounts its own tmpfs over /run on boot.
+const lxcHostResourcesPath = "dev/.incus-host"
+
+// notifySocketPath returns the path of the socket receiving the readiness notifications of the container's init.
+func (d *lxc) notifySocketPath() string {
+	return filepath.Join(d.RunPath(), "notify.socket")
+}
+
+// systemdConfig exposes the host journal and the readiness notification socket to the container.
+func (d *lxc) systemdConfig(cc *liblxc.Container) error {
+	if util.IsTrue(d.expandedConfig["linux.journal.host"]) {
+		if !util.PathExists(lxcHostJournalPath) {
+			return fmt.Errorf("The host doesn't provide the systemd journal sockets in %q", lxcHostJournalPath)
+		}
+
+		err := lxcSetConfigItem(cc, "lxc.mount.entry", fmt.Sprintf("%s %s/journal none bind,create=dir 0 0", lxcHostJournalPath, lxcHostResourcesPath))
+		if err != nil {
+			return err
+		}
+	}
+
+	if util.IsTrue(d.expandedConfig["linux.systemd.notify"]) {
+		err := lxcSetConfigItem(cc, "lxc.mount.entry", fmt.Sprintf("%s %s/notify none bind,create=file 0 0", d.notifySocketPath(), lxcHostResourcesPath))
+		if err != nil {
+			return err
+		}
+
+		err = lxcSetConfigItem(cc, "lxc.environment", fmt.Sprintf("NOTIFY_SOCKET=/%s/notify", lxcHostResourcesPath))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// notifyListen creates the socket receiving the readiness notifications of the container's init.
+func (d *lxc) notifyListen() (*net.UnixConn, error) {
+	path := d.notifySocketPath()
+	_ = os.Remove(path)
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("Failed creating notification socket: %w", err)
+	}
+
+	// The socket is only reachable through the bind-mount in the container.
+	err = os.Chmod(path, 0o666)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// notifyWaitReady waits for the container's init to report that it finished booting.
+func (d *lxc) notifyWaitReady(conn *net.UnixConn) error {
+	timeout := 60 * time.Second
+	if d.expandedConfig["linux.systemd.notify_timeout"] != "" {
+		seconds, err := strconv.Atoi(d.expandedConfig["linux.systemd.notify_timeout"])
+		if err != nil {
+			return err
+		}
+
+		timeout = time.Duration(seconds) * time.Second
+	}
+
+	deadline := time.Now().Add(timeout)
+	buf := make([]byte, 4096)
+
+	for time.Now().Before(deadline) {
+		if !d.IsRunning() {
+			return errors.New("The instance stopped before reporting ready")
+		}
+
+		// Wake up regularly to notice the instance stopping.
+		err := conn.SetReadDeadline(time.Now().Add(time.Second))
+		if err != nil {
+			return err
+		}
+
+		n, err := conn.Read(buf)
+		if err != nil {
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				continue
+			}
+
+			return fmt.Errorf("Failed reading readiness notification: %w", err)
+		}
+
+		for _, line := range strings.Split(string(buf[:n]), "\n") {
+			key, value, _ := strings.Cut(line, "=")
+			switch key {
+			case "READY":
+				if value == "1" {
+					d.logger.Debug("Instance reported ready")
+					return nil
+				}
+
+			case "STATUS":
+				d.logger.Debug("Instance status", logger.Ctx{"status": value})
+			}
+		}
+	}
+
+	return fmt.Errorf("The instance didn't report ready within %s", timeout)
+}
@@ -26,6 +26,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unsafe"
 
@@ -105,6 +106,9 @@ const qemuPCIDeviceIDStart = 4
 // qemuDeviceIDPrefix used as part of the name given QEMU devices generated from user added devices.
 const qemuDeviceIDPrefix = "dev-incus_"
 
+// qemuAudioDevName is the name of the audio backend shared by the sound cards of the VM.
+const qemuAudioDevName = "qemu_audio"
+
 // qemuNetDevIDPrefix used as part of the name given QEMU netdevs generated from user added devices.
 const qemuNetDevIDPrefix = "incus_"
 
@@ -120,6 +124,12 @@ const qemuSparseUSBPorts = 8
 
 var errQemuAgentOffline = fmt.Errorf("VM agent isn't currently running")
 
+// Track the agent watchdogs running for VMs (instance ID to QEMU PID).
+var (
+	qemuAgentWatchdogs   = map[int]int{}
+	muQemuAgentWatchdogs sync.Mutex
+)
+
 type monitorHook func(m *qmp.Monitor) error
 
 // qemuLoad creates a Qemu instance from the supplied InstanceArgs.
@@ -488,6 +498,19 @@ func (d *qemu) getMonitorEventHandler() func(event string, data map[string]any)
 
 		if event == qmp.EventAgentStarted {
 			d.logger.Debug("Instance agent started")
+
+			// Record how long the agent took to start after the VM, unless it restarted since.
+			if d.localConfig["volatile.last_state.agent_duration"] == "" && !d.lastUsedDate.IsZero() {
+				err := d.VolatileSet(map[string]string{"volatile.last_state.agent_duration": strconv.FormatInt(time.Since(d.lastUsedDate).Milliseconds(), 10)})
+				if err != nil {
+					d.logger.Warn("Failed recording agent start duration", logger.Ctx{"err": err})
+				}
+			}
+
+			if d.expandedConfig["restart.watchdog"] == "agent" {
+				d.startAgentWatchdog()
+			}
+
 			err := d.advertiseVsockAddress()
 			if err != nil {
 				d.logger.Warn("Failed to advertise vsock address to instance agent", logger.Ctx{"err": err})
@@ -506,7 +529,8 @@ func (d *qemu) getMonitorEventHandler() func(event string, data map[string]any)
 				d.logger.Debug("Instance stopped", logger.Ctx{"target": target, "reason": data["reason"]})
 			}
 
-			err = d.onStop(target)
+			reason, _ := entry.(string)
+			err = d.onStop(target, reason)
 			if err != nil {
 				d.logger.Error("Failed to cleanly stop instance", logger.Ctx{"err": err})
 				return
@@ -515,6 +539,106 @@ func (d *qemu) getMonitorEventHandler() func(event string, data map[string]any)
 	}
 }
 
+// startAgentWatchdog periodically checks that the agent of the running VM responds and forcefully stops the
+// VM if it doesn't for longer than restart.watchdog.timeout. Only one watchdog runs per QEMU process.
+func (d *qemu) startAgentWatchdog() {
+	pid, _ := d.pid()
+	if pid <= 0 {
+		return
+	}
+
+	muQemuAgentWatchdogs.Lock()
+	if qemuAgentWatchdogs[d.id] == pid {
+		muQemuAgentWatchdogs.Unlock()
+		return
+	}
+
+	qemuAgentWatchdogs[d.id] = pid
+	muQemuAgentWatchdogs.Unlock()
+
+	timeout := 60 * time.Second
+	if d.expandedConfig["restart.watchdog.timeout"] != "" {
+		seconds, err := strconv.Atoi(d.expandedConfig["restart.watchdog.timeout"])
+		if err == nil {
+			timeout = time.Duration(seconds) * time.Second
+		}
+	}
+
+	interval := max(timeout/4, time.Second)
+
+	go func() {
+		defer func() {
+			muQemuAgentWatchdogs.Lock()
+			if qemuAgentWatchdogs[d.id] == pid {
+				delete(qemuAgentWatchdogs, d.id)
+			}
+
+			muQemuAgentWatchdogs.Unlock()
+		}()
+
+		lastSeen := time.Now()
+		for {
+			time.Sleep(interval)
+
+			// Stop watching once the QEMU process is gone.
+			currentPid, _ := d.pid()
+			if currentPid != pid {
+				return
+			}
+
+			// Don't interfere with ongoing operations such as a shutdown.
+			if operationlock.Get(d.Project().Name, d.Name()) != nil {
+				lastSeen = time.Now()
+				continue
+			}
+
+			err := d.pingAgent()
+			if err == nil {
+				lastSeen = time.Now()
+				continue
+			}
+
+			if time.Since(lastSeen) < timeout {
+				continue
+			}
+
+			d.logger.Warn("Agent watchdog fired, stopping instance", logger.Ctx{"timeout": timeout, "err": err})
+
+			err = d.forceStop()
+			if err != nil {
+				d.logger.Error("Failed stopping instance after agent watchdog fired", logger.Ctx{"err": err})
+			}
+
+			return
+		}
+	}()
+}
+
+// pingAgent checks that the agent of the running VM responds.
+func (d *qemu) pingAgent() error {
+	client, err := d.getAgentClient()
+	if err != nil {
+		return err
+	}
+
+	client.Timeout = 10 * time.Second
+
+	agentArgs := &incus.ConnectionArgs{SkipGetServer: true}
+	agent, err := incus.ConnectIncusHTTP(agentArgs, client)
+	if err != nil {
+		return err
+	}
+
+	defer agent.Disconnect()
+
+	_, _, err = agent.RawQuery("GET", "/1.0", nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // mount the instance's config volume if needed.
 func (d *qemu) mount() (*storagePools.MountInfo, error) {
 	var pool storagePools.Pool
@@ -648,9 +772,11 @@ func (d *qemu) pidWait(timeout time.Duration) bool {
 }
 
 // onStop is run when the instance stops.
-func (d *qemu) onStop(target string) error {
-	d.logger.Debug("onStop hook started", logger.Ctx{"target": target})
-	defer d.logger.Debug("onStop hook finished", logger.Ctx{"target": target})
+// The reason is the one reported by QEMU for the shutdown (if any) and is used to tell a clean guest
+// shutdown apart from a crash.
+func (d *qemu) onStop(target string, reason string) error {
+	d.logger.Debug("onStop hook started", logger.Ctx{"target": target, "reason": reason})
+	defer d.logger.Debug("onStop hook finished", logger.Ctx{"target": target, "reason": reason})
 
 	// Create/pick up operation.
 	op, err := d.onStopOperationSetup(target)
@@ -677,11 +803,8 @@ func (d *qemu) onStop(target string) error {
 		d.logger.Error("VM process failed to stop", logger.Ctx{"timeout": waitTimeout})
 	}
 
-	// Record power state.
-	err = d.VolatileSet(map[string]string{
-		"volatile.last_state.power": instance.PowerStateStopped,
-		"volatile.last_state.ready": "false",
-	})
+	// Record power state and uptime.
+	err = d.VolatileSet(d.stoppedConfig())
 	if err != nil {
 		// Don't return an error here as we still want to cleanup the instance even if DB not available.
 		d.logger.Error("Failed recording last power state", logger.Ctx{"err": err})
@@ -709,13 +832,21 @@ func (d *qemu) onStop(target string) error {
 	}
 
 	// Determine if instance should be auto-restarted.
+	failed := reason != "guest-shutdown"
 	var autoRestart bool
-	if target != "reboot" && op.GetInstanceInitiated() && d.shouldAutoRestart() {
+	if target != "reboot" && op.GetInstanceInitiated() && d.shouldAutoRestart(failed) {
 		autoRestart = true
 
 		// Mark current shutdown as complete.
 		op.Done(nil)
 
+		// Back off when the instance keeps stopping.
+		delay := d.autoRestartDelay()
+		if delay > 0 {
+			d.logger.Info("Delaying automatic restart", logger.Ctx{"delay": delay})
+			time.Sleep(delay)
+		}
+
 		// Create a new restart operation.
 		op, err = operationlock.CreateWaitGet(d.Project().Name, d.Name(), d.op, operationlock.ActionRestart, nil, true, false)
 		if err == nil {
@@ -740,7 +871,12 @@ func (d *qemu) onStop(target string) error {
 			return err
 		}
 
-		d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceRestarted.Event(d, nil))
+		var ctx map[string]any
+		if autoRestart {
+			ctx = map[string]any{"policy": d.restartPolicy()}
+		}
+
+		d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceRestarted.Event(d, ctx))
 	} else if d.ephemeral {
 		// Destroy ephemeral virtual machines.
 		err = d.delete(true)
@@ -1240,6 +1376,8 @@ func (d *qemu) startupHook(monitor *qmp.Monitor, stage string) error {
 
 // start starts the instance and can use an existing InstanceOperation lock.
 func (d *qemu) start(stateful bool, op *operationlock.InstanceOperation) error {
+	startTime := time.Now()
+
 	d.logger.Debug("Start started", logger.Ctx{"stateful": stateful})
 	defer d.logger.Debug("Start finished", logger.Ctx{"stateful": stateful})
 
@@ -1569,7 +1707,7 @@ func (d *qemu) start(stateful bool, op *operationlock.InstanceOperation) error {
 	cpuType := "host"
 
 	// Handle CPU flags.
-	if d.state.ServerClustered && util.IsTrue(d.expandedConfig["migration.stateful"]) {
+	if d.state.ServerClustered && util.IsTrue(d.expandedConfig["migration.stateful"]) && d.expandedConfig["limits.cpu.model"] == "" {
 		// Get the cluster group config.
 		var groupConfig map[string]string
 		err = d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
@@ -1634,14 +1772,44 @@ func (d *qemu) start(stateful bool, op *operationlock.InstanceOperation) error {
 		}
 	}
 
+	// Apply the instance CPU model and flags.
+	if d.expandedConfig["limits.cpu.model"] != "" {
+		cpuType = d.expandedConfig["limits.cpu.model"]
+	}
+
+	for _, flag := range util.SplitNTrimSpace(d.expandedConfig["limits.cpu.flags"], ",", -1, true) {
+		name, found := strings.CutPrefix(flag, "-")
+		if found {
+			cpuExtensions = append(cpuExtensions, fmt.Sprintf("%s=off", name))
+		} else {
+			cpuExtensions = append(cpuExtensions, fmt.Sprintf("%s=on", strings.TrimPrefix(flag, "+")))
+		}
+	}
+
 	if len(cpuExtensions) > 0 {
 		cpuType += "," + strings.Join(cpuExtensions, ",")
 	}
 
-	// Provide machine definition when restoring state.
-	var machineDefinition string
-	if stateful {
-		machineDefinition = d.localConfig["volatile.vm.definition"]
+	// Use the recorded machine definition to keep the virtual hardware stable across QEMU upgrades.
+	// Restoring state always requires the original definition, otherwise the machine is moved to the
+	// latest definition when requested or when the recorded one isn't supported by QEMU anymore.
+	machineDefinition := d.localConfig["volatile.vm.definition"]
+	if !stateful && machineDefinition != "" {
+		if util.IsTrue(d.localConfig["volatile.apply_machine_upgrade"]) {
+			d.logger.Info("Upgrading machine definition", logger.Ctx{"definition": machineDefinition})
+			machineDefinition = ""
+		} else {
+			supported, err := d.qemuMachineSupported(qemuPath, machineDefinition)
+			if err != nil {
+				op.Done(err)
+				return err
+			}
+
+			if !supported {
+				d.logger.Warn("Recorded machine definition isn't supported by QEMU, using the latest definition", logger.Ctx{"definition": machineDefinition})
+				machineDefinition = ""
+			}
+		}
 	}
 
 	// Generate the QEMU configuration.
@@ -1670,6 +1838,21 @@ func (d *qemu) start(stateful bool, op *operationlock.InstanceOperation) error {
 		"-D", d.LogFilePath(),
 	}
 
+	// Render the display through the host GPU when 3D acceleration is requested, the result
+	// is then copied to the SPICE display so remote consoles keep working.
+	_, gpuRenderNode := d.gpuVirtioConfig(devConfs)
+	if gpuRenderNode != "" {
+		qemuArgs = append(qemuArgs, "-display", fmt.Sprintf("egl-headless,rendernode=%s", gpuRenderNode))
+	}
+
+	// Route the sound card through the SPICE audio channel.
+	for _, runConf := range devConfs {
+		if len(runConf.AudioDevice) > 0 {
+			qemuArgs = append(qemuArgs, "-audiodev", fmt.Sprintf("spice,id=%s", qemuAudioDevName))
+			break
+		}
+	}
+
 	// If stateful, restore now.
 	if stateful {
 		if d.stateful {
@@ -1913,7 +2096,8 @@ func (d *qemu) start(stateful bool, op *operationlock.InstanceOperation) error {
 		}
 
 		err = d.VolatileSet(map[string]string{
-			"volatile.vm.definition": definition,
+			"volatile.vm.definition":         definition,
+			"volatile.apply_machine_upgrade": "",
 		})
 		if err != nil {
 			op.Done(err)
@@ -1980,6 +2164,15 @@ func (d *qemu) start(stateful bool, op *operationlock.InstanceOperation) error {
 		}
 	}
 
+	// Have QEMU power off the VM when the hardware watchdog fires so it gets handled as a failure.
+	if d.expandedConfig["restart.watchdog"] == "hardware" {
+		err = monitor.SetWatchdogAction("poweroff")
+		if err != nil {
+			op.Done(err)
+			return err
+		}
+	}
+
 	// Run monitor hooks from devices.
 	for _, monHook := range monHooks {
 		err = monHook(monitor)
@@ -2091,6 +2284,7 @@ func (d *qemu) start(stateful bool, op *operationlock.InstanceOperation) error {
 	// The VM started cleanly so now enable the unexpected disconnection event to ensure the onStop hook is
 	// run if QMP unexpectedly disconnects.
 	monitor.SetOnDisconnectEvent(true)
+	d.recordStartDuration(startTime)
 	op.Done(nil)
 	return nil
 }
@@ -2370,6 +2564,23 @@ func (d *qemu) qemuArchConfig(arch int) (string, string, error) {
 	return "", "", fmt.Errorf("Architecture isn't supported for virtual machines")
 }
 
+// qemuMachineSupported checks whether the given machine definition is supported by the QEMU binary.
+func (d *qemu) qemuMachineSupported(qemuPath string, definition string) (bool, error) {
+	out, err := subprocess.RunCommand(qemuPath, "-machine", "help")
+	if err != nil {
+		return false, fmt.Errorf("Failed listing QEMU machine definitions: %w", err)
+	}
+
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[0] == definition {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // RegisterDevices calls the Register() function on all of the instance's devices.
 func (d *qemu) RegisterDevices() {
 	d.devicesRegister(d)
@@ -2564,6 +2775,28 @@ func (d *qemu) deviceAttachPath(deviceName string, configCopy map[string]string,
 		"id":      deviceID,
 	}
 
+	// Add the DAX window if requested.
+	for _, opt := range mount.Opts {
+		daxSize, found := strings.CutPrefix(opt, fmt.Sprintf("%s=", device.DiskVirtiofsDAXMountOpt))
+		if !found {
+			continue
+		}
+
+		info := DriverStatuses()[instancetype.VM].Info
+		_, daxSupported := info.Features["virtiofs_dax"]
+		if !daxSupported {
+			return fmt.Errorf("QEMU doesn't support a DAX window for virtio-fs shares")
+		}
+
+		// QMP expects the size as an integer.
+		cacheSize, err := strconv.ParseInt(daxSize, 10, 64)
+		if err != nil {
+			return fmt.Errorf("Invalid virtio-fs DAX window size %q: %w", daxSize, err)
+		}
+
+		qemuDev["cache-size"] = cacheSize
+	}
+
 	err = monitor.AddDevice(qemuDev)
 	if err != nil {
 		return fmt.Errorf("Failed to add the virtiofs device: %w", err)
@@ -3025,6 +3258,27 @@ func (d *qemu) spiceCmdlineConfig() string {
 	return fmt.Sprintf("unix=on,disable-ticketing=on,addr=%s", d.spicePath())
 }
 
+// gpuVirtioConfig returns the acceleration mode and render node of the virtio GPU device, if any.
+func (d *qemu) gpuVirtioConfig(devConfs []*deviceConfig.RunConfig) (string, string) {
+	for _, runConf := range devConfs {
+		var acceleration, renderNode string
+		for _, item := range runConf.GPUDevice {
+			switch item.Key {
+			case "virtio":
+				acceleration = item.Value
+			case "renderNode":
+				renderNode = item.Value
+			}
+		}
+
+		if acceleration != "" {
+			return acceleration, renderNode
+		}
+	}
+
+	return "", ""
+}
+
 // generateConfigShare generates the config share directory that will be exported to the VM via
 // a 9P share. Due to the unknown size of templates inside the images this directory is created
 // inside the VM's config volume so that it can be restricted by quota.
@@ -3756,6 +4010,9 @@ func (d *qemu) generateQemuConfig(machineDefinition string, cpuInfo *cpuTopology
 		architecture: d.Architecture(),
 	}
 
+	// A virtio GPU device replaces the default display with a 3D accelerated one.
+	gpuOpts.acceleration, _ = d.gpuVirtioConfig(devConfs)
+
 	conf = append(conf, qemuGPU(&gpuOpts)...)
 
 	// Dynamic devices.
@@ -3888,6 +4145,16 @@ func (d *qemu) generateQemuConfig(machineDefinition string, cpuInfo *cpuTopology
 				return nil, err
 			}
 		}
+
+		// Add audio device.
+		if len(runConf.AudioDevice) > 0 {
+			d.addAudioDeviceConfig(&conf, bus, runConf.AudioDevice)
+		}
+
+		// Add input device.
+		if len(runConf.InputDevice) > 0 {
+			d.addInputDeviceConfig(&conf, runConf.InputDevice)
+		}
 	}
 
 	// VM generation ID is only available on x86.
@@ -3898,6 +4165,23 @@ func (d *qemu) generateQemuConfig(machineDefinition string, cpuInfo *cpuTopology
 		}
 	}
 
+	// Add the hardware watchdog (after user devices so it doesn't change their addresses).
+	if d.expandedConfig["restart.watchdog"] == "hardware" {
+		if bus.name != "pci" && bus.name != "pcie" {
+			return nil, fmt.Errorf("Hardware watchdog isn't supported on this architecture")
+		}
+
+		devBus, devAddr, multi := bus.allocate(busFunctionGroupNone)
+		watchdogOpts := qemuDevOpts{
+			busName:       bus.name,
+			devBus:        devBus,
+			devAddr:       devAddr,
+			multifunction: multi,
+		}
+
+		conf = append(conf, qemuWatchdog(&watchdogOpts)...)
+	}
+
 	// Allocate 8 PCI slots for hotplug devices.
 	for i := 0; i < 8; i++ {
 		bus.allocate(busFunctionGroupNone)
@@ -4169,12 +4453,16 @@ func (d *qemu) addDriveDirConfig(conf *[]cfg.Section, bus *qemuBus, fdFiles *[]*
 	// Record the 9p mount for the agent.
 	*agentMounts = append(*agentMounts, agentMount)
 
-	// Check if the disk device has provided a virtiofsd socket path.
+	// Check if the disk device has provided a virtiofsd socket path and DAX window size.
 	var virtiofsdSockPath string
+	var daxSize string
 	for _, opt := range driveConf.Opts {
 		if strings.HasPrefix(opt, fmt.Sprintf("%s=", device.DiskVirtiofsdSockMountOpt)) {
 			parts := strings.SplitN(opt, "=", 2)
 			virtiofsdSockPath = parts[1]
+		} else if strings.HasPrefix(opt, fmt.Sprintf("%s=", device.DiskVirtiofsDAXMountOpt)) {
+			parts := strings.SplitN(opt, "=", 2)
+			daxSize = parts[1]
 		}
 	}
 
@@ -4184,6 +4472,14 @@ func (d *qemu) addDriveDirConfig(conf *[]cfg.Section, bus *qemuBus, fdFiles *[]*
 			return fmt.Errorf("virtiofsd socket path %q doesn't exist", virtiofsdSockPath)
 		}
 
+		if daxSize != "" {
+			info := DriverStatuses()[instancetype.VM].Info
+			_, daxSupported := info.Features["virtiofs_dax"]
+			if !daxSupported {
+				return fmt.Errorf("QEMU doesn't support a DAX window for virtio-fs shares")
+			}
+		}
+
 		devBus, devAddr, multi := bus.allocate(busFunctionGroup9p)
 
 		// Add virtio-fs device as this will be preferred over 9p.
@@ -4194,10 +4490,11 @@ func (d *qemu) addDriveDirConfig(conf *[]cfg.Section, bus *qemuBus, fdFiles *[]*
 				devAddr:       devAddr,
 				multifunction: multi,
 			},
-			devName:  driveConf.DevName,
-			mountTag: mountTag,
-			path:     virtiofsdSockPath,
-			protocol: "virtio-fs",
+			devName:   driveConf.DevName,
+			mountTag:  mountTag,
+			path:      virtiofsdSockPath,
+			protocol:  "virtio-fs",
+			cacheSize: daxSize,
 		}
 		*conf = append(*conf, qemuDriveDir(&driveDirVirtioOpts)...)
 	}
@@ -4930,6 +5227,9 @@ func (d *qemu) addGPUDevConfig(conf *[]cfg.Section, bus *qemuBus, gpuConfig []de
 			pciSlotName = gpuItem.Value
 		} else if gpuItem.Key == "vgpu" {
 			vgpu = gpuItem.Value
+		} else if gpuItem.Key == "virtio" {
+			// Virtio GPUs are set up as the main display.
+			return nil
 		}
 	}
 
@@ -5096,6 +5396,52 @@ func (d *qemu) addTPMDeviceConfig(conf *[]cfg.Section, tpmConfig []deviceConfig.
 	return nil
 }
 
+// addAudioDeviceConfig adds the qemu config required for adding a sound card.
+func (d *qemu) addAudioDeviceConfig(conf *[]cfg.Section, bus *qemuBus, audioConfig []deviceConfig.RunConfigItem) {
+	var devName, model string
+	for _, audioItem := range audioConfig {
+		if audioItem.Key == "devName" {
+			devName = audioItem.Value
+		} else if audioItem.Key == "model" {
+			model = audioItem.Value
+		}
+	}
+
+	devBus, devAddr, multi := bus.allocate(fmt.Sprintf("incus_%s", devName))
+	audioOpts := qemuAudioOpts{
+		dev: qemuDevOpts{
+			busName:       bus.name,
+			devBus:        devBus,
+			devAddr:       devAddr,
+			multifunction: multi,
+		},
+		devName:      devName,
+		model:        model,
+		architecture: d.architecture,
+	}
+
+	*conf = append(*conf, qemuAudio(&audioOpts)...)
+}
+
+// addInputDeviceConfig adds the qemu config required for adding a USB input device.
+func (d *qemu) addInputDeviceConfig(conf *[]cfg.Section, inputConfig []deviceConfig.RunConfigItem) {
+	var devName, model string
+	for _, inputItem := range inputConfig {
+		if inputItem.Key == "devName" {
+			devName = inputItem.Value
+		} else if inputItem.Key == "model" {
+			model = inputItem.Value
+		}
+	}
+
+	inputOpts := qemuInputOpts{
+		devName: devName,
+		model:   model,
+	}
+
+	*conf = append(*conf, qemuInput(&inputOpts)...)
+}
+
 func (d *qemu) addVmgenDeviceConfig(conf *[]cfg.Section, guid string) error {
 	vmgenIDOpts := qemuVmgenIDOpts{
 		guid: guid,
@@ -5213,7 +5559,7 @@ func (d *qemu) Stop(stateful bool) error {
 		}
 
 		// Wait for QEMU process to exit and perform device cleanup.
-		err = d.onStop("stop")
+		err = d.onStop("stop", "")
 		if err != nil {
 			op.Done(err)
 			return err
@@ -5364,8 +5710,43 @@ func (d *qemu) snapshot(name string, expiry time.Time, stateful bool) error {
 		}
 	}
 
+	// Storage drivers without native snapshots copy the root disk, so redirect the guest writes to a
+	// temporary overlay for the duration of the copy rather than pausing the VM.
+	var overlayMerge func() error
+	if !stateful && d.IsRunning() {
+		pool, err := d.getStoragePool()
+		if err != nil {
+			return err
+		}
+
+		if pool.Driver().Info().SnapshotOverlay {
+			monitor, err = qmp.Connect(d.monitorPath(), qemuSerialChardevName, d.getMonitorEventHandler(), d.QMPLogFilePath())
+			if err != nil {
+				return err
+			}
+
+			overlayMerge, err = d.snapshotOverlayStart(monitor, pool)
+			if err != nil {
+				return fmt.Errorf("Failed setting up snapshot overlay: %w", err)
+			}
+		}
+	}
+
 	// Create the snapshot.
 	err = d.snapshotCommon(d, name, expiry, stateful)
+
+	// Merge the writes made during the snapshot back into the root disk.
+	if overlayMerge != nil {
+		mergeErr := overlayMerge()
+		if mergeErr != nil {
+			if err != nil {
+				d.logger.Error("Failed merging snapshot overlay", logger.Ctx{"err": mergeErr})
+			} else {
+				err = fmt.Errorf("Failed merging snapshot overlay: %w", mergeErr)
+			}
+		}
+	}
+
 	if err != nil {
 		return err
 	}
@@ -5387,6 +5768,97 @@ func (d *qemu) snapshot(name string, expiry time.Time, stateful bool) error {
 	return nil
 }
 
+// snapshotOverlayStart redirects the writes of the root disk of the running VM to a temporary qcow2 overlay
+// (using an external blockdev snapshot) so that the storage driver can copy a consistent root disk.
+// It returns a function which merges the overlay back into the root disk and removes it.
+func (d *qemu) snapshotOverlayStart(monitor *qmp.Monitor, pool storagePools.Pool) (func() error, error) {
+	rootDiskName, _, err := d.getRootDiskDevice()
+	if err != nil {
+		return nil, err
+	}
+
+	escapedDeviceName := linux.PathNameEncode(rootDiskName)
+	rootNodeName := d.blockNodeName(escapedDeviceName)
+	overlayNodeName := d.blockNodeName(escapedDeviceName + "_overlay")
+
+	rootDiskSize, err := storagePools.InstanceDiskBlockSize(pool, d, d.op)
+	if err != nil {
+		return nil, err
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	// The overlay is stored on the config volume, the file itself is removed once passed to QEMU so
+	// that it doesn't end up in the snapshot.
+	overlayFile := filepath.Join(d.Path(), "snapshot_overlay.qcow2")
+
+	err = os.Remove(overlayFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	_, err = subprocess.RunCommand("qemu-img", "create", "-f", "qcow2", overlayFile, fmt.Sprintf("%d", rootDiskSize))
+	if err != nil {
+		return nil, fmt.Errorf("Failed creating snapshot overlay %q: %w", overlayFile, err)
+	}
+
+	f, err := os.OpenFile(overlayFile, unix.O_RDWR, 0)
+	_ = os.Remove(overlayFile)
+	if err != nil {
+		return nil, fmt.Errorf("Failed opening snapshot overlay %q: %w", overlayFile, err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	info, err := monitor.SendFileWithFDSet(overlayNodeName, f, false)
+	if err != nil {
+		return nil, fmt.Errorf("Failed sending file descriptor of snapshot overlay: %w", err)
+	}
+
+	reverter.Add(func() { _ = monitor.RemoveFDFromFDSet(overlayNodeName) })
+
+	// Add the overlay as a block device (not visible to the guest OS).
+	err = monitor.AddBlockDevice(map[string]any{
+		"driver":    "qcow2",
+		"node-name": overlayNodeName,
+		"read-only": false,
+		"file": map[string]any{
+			"driver":   "file",
+			"filename": fmt.Sprintf("/dev/fdset/%d", info.ID),
+		},
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed adding snapshot overlay block device: %w", err)
+	}
+
+	reverter.Add(func() { _ = monitor.RemoveBlockDevice(overlayNodeName) })
+
+	// Redirect the writes of the guest to the overlay, leaving the root disk untouched.
+	err = monitor.BlockDevSnapshot(rootNodeName, overlayNodeName)
+	if err != nil {
+		return nil, fmt.Errorf("Failed taking external snapshot of root disk: %w", err)
+	}
+
+	cleanup := reverter.Clone()
+	reverter.Success()
+
+	d.logger.Debug("Redirected root disk writes to snapshot overlay")
+
+	return func() error {
+		defer cleanup.Fail()
+
+		err := monitor.BlockCommit(overlayNodeName)
+		if err != nil {
+			return err
+		}
+
+		d.logger.Debug("Merged snapshot overlay into root disk")
+
+		return nil
+	}, nil
+}
+
 // Snapshot takes a new snapshot.
 func (d *qemu) Snapshot(name string, expiry time.Time, stateful bool) error {
 	return d.snapshot(name, expiry, stateful)
@@ -5944,7 +6416,7 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 	}
 
 	// If apparmor changed, re-validate the apparmor profile (even if not running).
-	if slices.Contains(changedConfig, "raw.apparmor") {
+	if slices.Contains(changedConfig, "raw.apparmor") || slices.Contains(changedConfig, "security.apparmor.extra_rules") {
 		qemuPath, _, err := d.qemuArchConfig(d.architecture)
 		if err != nil {
 			return err
@@ -5964,11 +6436,15 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 		return err
 	}
 
+	// Resources added to the running VM, which the agent has to bring online.
+	hotplugged := []string{}
+
 	if isRunning {
 		// Only certain keys can be changed on a running VM.
 		liveUpdateKeys := []string{
 			"cluster.evacuate",
 			"limits.memory",
+			"restart.policy",
 			"security.agent.metrics",
 			"security.csm",
 			"security.protection.delete",
@@ -5977,6 +6453,7 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 		}
 
 		liveUpdateKeyPrefixes := []string{
+			"agent.metrics.",
 			"boot.",
 			"cloud-init.",
 			"environment.",
@@ -5987,6 +6464,11 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 		}
 
 		isLiveUpdatable := func(key string) bool {
+			// The machine definition of a running VM can't change.
+			if key == "volatile.vm.definition" {
+				return !userRequested
+			}
+
 			// Skip container config keys for VMs
 			_, ok := internalInstance.InstanceConfigKeysContainer[key]
 			if ok {
@@ -6034,6 +6516,10 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 					value = "1"
 				}
 
+				if oldValue == "" {
+					oldValue = "1"
+				}
+
 				limit, err := strconv.Atoi(value)
 				if err != nil {
 					return fmt.Errorf("Cannot change CPU pinning when VM is running")
@@ -6044,6 +6530,11 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 				if err != nil {
 					return fmt.Errorf("Failed updating cpu limit: %w", err)
 				}
+
+				oldLimit, _ := strconv.Atoi(oldValue)
+				if limit > oldLimit {
+					hotplugged = append(hotplugged, "cpu")
+				}
 			} else if key == "limits.memory" {
 				err = d.updateMemoryLimit(value)
 				if err != nil {
@@ -6051,6 +6542,18 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 						return fmt.Errorf("Failed updating memory limit: %w", err)
 					}
 				}
+
+				// Decreases are handled by the balloon, without any memory to bring online.
+				oldValue := oldExpandedConfig["limits.memory"]
+				if oldValue == "" {
+					oldValue = qemudefault.MemSize
+				}
+
+				oldSizeBytes, errOld := ParseMemoryStr(oldValue)
+				newSizeBytes, errNew := ParseMemoryStr(value)
+				if value != "" && errOld == nil && errNew == nil && newSizeBytes > oldSizeBytes {
+					hotplugged = append(hotplugged, "memory")
+				}
 			} else if key == "security.csm" {
 				// Defer rebuilding nvram until next start.
 				d.localConfig["volatile.apply_nvram"] = "true"
@@ -6170,6 +6673,18 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 			}
 		}
 
+		// Let the agent bring the hotplugged CPUs or memory online.
+		for _, resource := range hotplugged {
+			msg := map[string]any{
+				"resource": resource,
+			}
+
+			err = d.devIncusEventSend("hotplug", msg)
+			if err != nil {
+				d.logger.Warn("Failed notifying the agent of hotplugged resources", logger.Ctx{"resource": resource, "err": err})
+			}
+		}
+
 		// Device changes
 		for k, m := range removeDevices {
 			msg := map[string]any{
@@ -6635,7 +7150,11 @@ func (d *qemu) delete(force bool) error {
 }
 
 // Export publishes the instance.
-func (d *qemu) Export(metaWriter io.Writer, rootfsWriter io.Writer, properties map[string]string, expiration time.Time, tracker *ioprogress.ProgressTracker) (*api.ImageMetadata, error) {
+func (d *qemu) Export(metaWriter io.Writer, rootfsWriter io.Writer, properties map[string]string, expiration time.Time, stateful bool, tracker *ioprogress.ProgressTracker) (*api.ImageMetadata, error) {
+	if stateful {
+		return nil, fmt.Errorf("Exporting the state of virtual machines as an image isn't supported")
+	}
+
 	ctxMap := logger.Ctx{
 		"created":   d.creationDate,
 		"ephemeral": d.ephemeral,
@@ -8499,12 +9018,38 @@ func (d *qemu) agentGetState() (*api.InstanceState, error) {
 
 	defer agent.Disconnect()
 
-	status, _, err := agent.GetInstanceState("")
+	resp, _, err := agent.RawQuery("GET", "/1.0/state"+d.agentStateQuery(), nil, "")
 	if err != nil {
 		return nil, err
 	}
 
-	return status, nil
+	status := api.InstanceState{}
+	err = json.Unmarshal(resp.Metadata, &status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// agentStateQuery returns the query string asking the agent for the optional parts of the state and metrics.
+func (d *qemu) agentStateQuery() string {
+	values := url.Values{}
+
+	processes := d.expandedConfig["agent.metrics.processes"]
+	if processes != "" && processes != "0" {
+		values.Set("processes", processes)
+	}
+
+	if util.IsTrue(d.expandedConfig["agent.metrics.services"]) {
+		values.Set("services", "true")
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	return "?" + values.Encode()
 }
 
 // IsRunning returns whether or not the instance is running.
@@ -9284,6 +9829,14 @@ func (d *qemu) checkFeatures(hostArch int, qemuPath string) (map[string]any, err
 		features["cpu_hotplug"] = struct{}{}
 	}
 
+	// Check virtio-fs DAX window support.
+	props, err := monitor.DeviceProperties("vhost-user-fs-pci")
+	if err != nil {
+		logger.Debug("Failed listing virtio-fs device properties during VM feature check", logger.Ctx{"err": err})
+	} else if slices.Contains(props, "cache-size") {
+		features["virtiofs_dax"] = struct{}{}
+	}
+
 	// Check AMD SEV features (only for x86 architecture)
 	if hostArch == osarch.ARCH_64BIT_INTEL_X86 {
 		cmdline, err := os.ReadFile("/proc/cmdline")
@@ -9366,21 +9919,32 @@ func (d *qemu) Metrics(hostInterfaces []net.Interface) (*metrics.MetricSet, erro
 		return nil, ErrInstanceIsStopped
 	}
 
+	var metricSet *metrics.MetricSet
+	var err error
+
 	if d.agentMetricsEnabled() {
-		metrics, err := d.getAgentMetrics()
+		metricSet, err = d.getAgentMetrics()
 		if err != nil {
 			if !errors.Is(err, errQemuAgentOffline) {
 				d.logger.Warn("Could not get VM metrics from agent", logger.Ctx{"err": err})
 			}
 
 			// Fallback data if agent is not reachable.
-			return d.getQemuMetrics()
+			metricSet, err = d.getQemuMetrics()
 		}
+	} else {
+		metricSet, err = d.getQemuMetrics()
+	}
 
-		return metrics, nil
+	if err != nil {
+		return nil, err
 	}
 
-	return d.getQemuMetrics()
+	// The per-device metrics are always collected from the host.
+	metricSet.Merge(d.getQemuDeviceMetrics())
+	metricSet.Merge(d.availabilityMetrics())
+
+	return metricSet, nil
 }
 
 func (d *qemu) getAgentMetrics() (*metrics.MetricSet, error) {
@@ -9398,7 +9962,7 @@ func (d *qemu) getAgentMetrics() (*metrics.MetricSet, error) {
 
 	defer agent.Disconnect()
 
-	resp, _, err := agent.RawQuery("GET", "/1.0/metrics", nil, "")
+	resp, _, err := agent.RawQuery("GET", "/1.0/metrics"+d.agentStateQuery(), nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -9869,6 +10433,38 @@ func (d *qemu) ReloadDevice(devName string) error {
 	return dev.Update(d.expandedDevices, true)
 }
 
+// DebugQMP runs one of the allowed read-only QMP commands on the monitor of the running VM.
+func (d *qemu) DebugQMP(command string, arguments map[string]any) (any, error) {
+	if !slices.Contains(qmp.DebugCommands, command) {
+		return nil, api.StatusErrorf(http.StatusForbidden, "QMP command %q isn't allowed", command)
+	}
+
+	if !d.IsRunning() {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Instance is not running")
+	}
+
+	monitor, err := qmp.Connect(d.monitorPath(), qemuSerialChardevName, d.getMonitorEventHandler(), d.QMPLogFilePath())
+	if err != nil {
+		return nil, err
+	}
+
+	var args any
+	if len(arguments) > 0 {
+		args = arguments
+	}
+
+	var resp struct {
+		Return any `json:"return"`
+	}
+
+	err = monitor.Run(command, args, &resp)
+	if err != nil {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Failed running QMP command %q: %v", command, err)
+	}
+
+	return resp.Return, nil
+}
+
 // DumpGuestMemory dumps the guest memory to a file in the specified format.
 func (d *qemu) DumpGuestMemory(w *os.File, format string) error {
 	if !d.IsRunning() {
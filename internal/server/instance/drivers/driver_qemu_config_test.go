@@ -344,6 +344,23 @@ func TestQemuConfigTemplates(t *testing.T) {
 			`# GPU
 			[device "qemu_gpu"]
 			driver = "virtio-gpu-ccw"`,
+		}, {
+			qemuGpuOpts{dev: qemuDevOpts{"pci", "qemu_pcie3", "00.0", false}, architecture: osarch.ARCH_64BIT_INTEL_X86, acceleration: "virgl"},
+			`# GPU
+			[device "qemu_gpu"]
+			addr = "00.0"
+			bus = "qemu_pcie3"
+			driver = "virtio-vga-gl"`,
+		}, {
+			qemuGpuOpts{dev: qemuDevOpts{"pci", "qemu_pcie3", "00.0", false}, architecture: osarch.ARCH_64BIT_ARMV8_LITTLE_ENDIAN, acceleration: "venus"},
+			`# GPU
+			[device "qemu_gpu"]
+			addr = "00.0"
+			blob = "true"
+			bus = "qemu_pcie3"
+			driver = "virtio-gpu-gl-pci"
+			hostmem = "4G"
+			venus = "true"`,
 		}}
 		for _, tc := range testCases {
 			runTest(tc.expected, qemuGPU(&tc.opts))
@@ -1087,6 +1104,74 @@ func TestQemuConfigTemplates(t *testing.T) {
 		}
 	})
 
+	t.Run("qemu_audio", func(t *testing.T) {
+		testCases := []struct {
+			opts     qemuAudioOpts
+			expected string
+		}{{
+			qemuAudioOpts{
+				dev:          qemuDevOpts{"pcie", "qemu_pcie5", "00.0", false},
+				devName:      "sound",
+				model:        "virtio",
+				architecture: osarch.ARCH_64BIT_INTEL_X86,
+			},
+			`# Audio card ("sound" device)
+			[device "dev-incus_sound"]
+			addr = "00.0"
+			audiodev = "qemu_audio"
+			bus = "qemu_pcie5"
+			driver = "virtio-sound-pci"`,
+		}, {
+			qemuAudioOpts{
+				dev:          qemuDevOpts{"pcie", "qemu_pcie5", "00.0", false},
+				devName:      "sound",
+				model:        "hda",
+				architecture: osarch.ARCH_64BIT_INTEL_X86,
+			},
+			`# Audio card ("sound" device)
+			[device "dev-incus_sound"]
+			addr = "00.0"
+			bus = "qemu_pcie5"
+			driver = "ich9-intel-hda"
+
+			[device "dev-incus_sound-codec"]
+			audiodev = "qemu_audio"
+			bus = "dev-incus_sound.0"
+			driver = "hda-duplex"`,
+		}}
+		for _, tc := range testCases {
+			runTest(tc.expected, qemuAudio(&tc.opts))
+		}
+	})
+
+	t.Run("qemu_input", func(t *testing.T) {
+		testCases := []struct {
+			opts     qemuInputOpts
+			expected string
+		}{{
+			qemuInputOpts{
+				devName: "tablet",
+				model:   "usb-tablet",
+			},
+			`# Input device ("tablet" device)
+			[device "dev-incus_tablet"]
+			bus = "qemu_usb.0"
+			driver = "usb-tablet"`,
+		}, {
+			qemuInputOpts{
+				devName: "kbd",
+				model:   "usb-keyboard",
+			},
+			`# Input device ("kbd" device)
+			[device "dev-incus_kbd"]
+			bus = "qemu_usb.0"
+			driver = "usb-kbd"`,
+		}}
+		for _, tc := range testCases {
+			runTest(tc.expected, qemuInput(&tc.opts))
+		}
+	})
+
 	t.Run("qemu_raw_cfg_override", func(t *testing.T) {
 		conf := []cfg.Section{{
 			Name: "global",
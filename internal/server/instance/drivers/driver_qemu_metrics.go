@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/lxc/incus/v6/internal/linux"
 	"github.com/lxc/incus/v6/internal/server/instance/drivers/qemudefault"
 	"github.com/lxc/incus/v6/internal/server/instance/drivers/qmp"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
@@ -97,6 +98,52 @@ func (d *qemu) getQemuDiskMetrics(monitor *qmp.Monitor) ([]metrics.DiskMetrics,
 	return out, nil
 }
 
+// getQemuDeviceMetrics returns the per-device metrics of the VM, with the I/O statistics of its disk devices
+// taken from QEMU.
+func (d *qemu) getQemuDeviceMetrics() *metrics.MetricSet {
+	var disks []metrics.DiskMetrics
+
+	monitor, err := qmp.Connect(d.monitorPath(), qemuSerialChardevName, d.getMonitorEventHandler(), d.QMPLogFilePath())
+	if err != nil {
+		d.logger.Warn("Failed to get disk device metrics", logger.Ctx{"err": err})
+	} else {
+		stats, err := monitor.GetBlockStats()
+		if err != nil {
+			d.logger.Warn("Failed to get disk device metrics", logger.Ctx{"err": err})
+		}
+
+		for qdev, stat := range stats {
+			deviceName := qemuBlockStatsDeviceName(qdev)
+			if deviceName == "" || d.expandedDevices[deviceName]["type"] != "disk" {
+				continue
+			}
+
+			disks = append(disks, metrics.DiskMetrics{
+				Device:          deviceName,
+				ReadBytes:       uint64(stat.BytesRead),
+				ReadsCompleted:  uint64(stat.ReadsCompleted),
+				WrittenBytes:    uint64(stat.BytesWritten),
+				WritesCompleted: uint64(stat.WritesCompleted),
+			})
+		}
+	}
+
+	return d.deviceMetrics(d, disks)
+}
+
+// qemuBlockStatsDeviceName returns the name of the disk device of a block device reported by QEMU, from either its
+// ID (dev-incus_root) or the QOM path of its backend (/machine/peripheral/dev-incus_root/virtio-backend).
+func qemuBlockStatsDeviceName(qdev string) string {
+	deviceID, _, _ := strings.Cut(strings.TrimPrefix(qdev, "/machine/peripheral/"), "/")
+
+	escapedDeviceName, ok := strings.CutPrefix(deviceID, qemuDeviceIDPrefix)
+	if !ok {
+		return ""
+	}
+
+	return linux.PathNameDecode(escapedDeviceName)
+}
+
 func (d *qemu) getQemuMemoryMetrics(monitor *qmp.Monitor) (metrics.MemoryMetrics, error) {
 	out := metrics.MemoryMetrics{}
 
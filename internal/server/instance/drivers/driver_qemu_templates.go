@@ -356,6 +356,19 @@ func qemuRNG(opts *qemuDevOpts) []cfg.Section {
 	}}
 }
 
+func qemuWatchdog(opts *qemuDevOpts) []cfg.Section {
+	entriesOpts := qemuDevEntriesOpts{
+		dev:     *opts,
+		pciName: "i6300esb",
+	}
+
+	return []cfg.Section{{
+		Name:    `device "qemu_watchdog"`,
+		Comment: "Hardware watchdog",
+		Entries: qemuDeviceEntries(&entriesOpts),
+	}}
+}
+
 func qemuCoreInfo() []cfg.Section {
 	return []cfg.Section{{
 		Name:    `device "qemu_vmcoreinfo"`,
@@ -442,6 +455,7 @@ func qemuVsock(opts *qemuVsockOpts) []cfg.Section {
 type qemuGpuOpts struct {
 	dev          qemuDevOpts
 	architecture int
+	acceleration string
 }
 
 func qemuGPU(opts *qemuGpuOpts) []cfg.Section {
@@ -453,16 +467,34 @@ func qemuGPU(opts *qemuGpuOpts) []cfg.Section {
 		pciName = "virtio-gpu-pci"
 	}
 
+	// Use the OpenGL capable variants for 3D acceleration.
+	if opts.acceleration != "" {
+		if opts.architecture == osarch.ARCH_64BIT_INTEL_X86 {
+			pciName = "virtio-vga-gl"
+		} else {
+			pciName = "virtio-gpu-gl-pci"
+		}
+	}
+
 	entriesOpts := qemuDevEntriesOpts{
 		dev:     opts.dev,
 		pciName: pciName,
 		ccwName: "virtio-gpu-ccw",
 	}
 
+	entries := qemuDeviceEntries(&entriesOpts)
+
+	// Venus (Vulkan) requires blob resources mapped through a host memory window.
+	if opts.acceleration == "venus" {
+		entries["blob"] = "true"
+		entries["venus"] = "true"
+		entries["hostmem"] = "4G"
+	}
+
 	return []cfg.Section{{
 		Name:    `device "qemu_gpu"`,
 		Comment: "GPU",
-		Entries: qemuDeviceEntries(&entriesOpts),
+		Entries: entries,
 	}}
 }
 
@@ -717,6 +749,7 @@ type qemuHostDriveOpts struct {
 	sockFd        string
 	readonly      bool
 	protocol      string
+	cacheSize     string
 }
 
 func qemuHostDrive(opts *qemuHostDriveOpts) []cfg.Section {
@@ -764,6 +797,10 @@ func qemuHostDrive(opts *qemuHostDriveOpts) []cfg.Section {
 		entries = qemuDeviceEntries(&deviceOpts)
 		entries["tag"] = opts.mountTag
 		entries["chardev"] = opts.name
+
+		if opts.cacheSize != "" {
+			entries["cache-size"] = opts.cacheSize
+		}
 	} else {
 		return []cfg.Section{}
 	}
@@ -801,12 +838,13 @@ func qemuDriveConfig(opts *qemuDriveConfigOpts) []cfg.Section {
 }
 
 type qemuDriveDirOpts struct {
-	dev      qemuDevOpts
-	devName  string
-	mountTag string
-	path     string
-	protocol string
-	readonly bool
+	dev       qemuDevOpts
+	devName   string
+	mountTag  string
+	path      string
+	protocol  string
+	readonly  bool
+	cacheSize string
 }
 
 func qemuDriveDir(opts *qemuDriveDirOpts) []cfg.Section {
@@ -820,6 +858,7 @@ func qemuDriveDir(opts *qemuDriveDirOpts) []cfg.Section {
 		readonly:      opts.readonly,
 		path:          opts.path,
 		securityModel: "passthrough",
+		cacheSize:     opts.cacheSize,
 	})
 }
 
@@ -958,6 +997,79 @@ func qemuTPM(opts *qemuTPMOpts) []cfg.Section {
 	}}
 }
 
+type qemuAudioOpts struct {
+	dev          qemuDevOpts
+	devName      string
+	model        string
+	architecture int
+}
+
+func qemuAudio(opts *qemuAudioOpts) []cfg.Section {
+	deviceID := fmt.Sprintf("%s%s", qemuDeviceIDPrefix, opts.devName)
+	comment := fmt.Sprintf(`Audio card ("%s" device)`, opts.devName)
+
+	if opts.model == "hda" {
+		pciName := "intel-hda"
+		if opts.architecture == osarch.ARCH_64BIT_INTEL_X86 {
+			pciName = "ich9-intel-hda"
+		}
+
+		deviceOpts := qemuDevEntriesOpts{
+			dev:     opts.dev,
+			pciName: pciName,
+		}
+
+		return []cfg.Section{{
+			Name:    fmt.Sprintf(`device "%s"`, deviceID),
+			Comment: comment,
+			Entries: qemuDeviceEntries(&deviceOpts),
+		}, {
+			Name: fmt.Sprintf(`device "%s-codec"`, deviceID),
+			Entries: map[string]string{
+				"driver":   "hda-duplex",
+				"bus":      fmt.Sprintf("%s.0", deviceID),
+				"audiodev": qemuAudioDevName,
+			},
+		}}
+	}
+
+	deviceOpts := qemuDevEntriesOpts{
+		dev:     opts.dev,
+		pciName: "virtio-sound-pci",
+	}
+
+	entries := qemuDeviceEntries(&deviceOpts)
+	entries["audiodev"] = qemuAudioDevName
+
+	return []cfg.Section{{
+		Name:    fmt.Sprintf(`device "%s"`, deviceID),
+		Comment: comment,
+		Entries: entries,
+	}}
+}
+
+type qemuInputOpts struct {
+	devName string
+	model   string
+}
+
+func qemuInput(opts *qemuInputOpts) []cfg.Section {
+	drivers := map[string]string{
+		"usb-tablet":   "usb-tablet",
+		"usb-keyboard": "usb-kbd",
+		"usb-mouse":    "usb-mouse",
+	}
+
+	return []cfg.Section{{
+		Name:    fmt.Sprintf(`device "%s%s"`, qemuDeviceIDPrefix, opts.devName),
+		Comment: fmt.Sprintf(`Input device ("%s" device)`, opts.devName),
+		Entries: map[string]string{
+			"driver": drivers[opts.model],
+			"bus":    "qemu_usb.0",
+		},
+	}}
+}
+
 type qemuVmgenIDOpts struct {
 	guid string
 }
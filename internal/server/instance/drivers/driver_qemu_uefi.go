@@ -0,0 +1,307 @@
+package drivers
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/instance/drivers/edk2"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// qemuNVRAMMaxSize is the maximum size of an imported firmware vars file.
+const qemuNVRAMMaxSize = 64 * 1024 * 1024
+
+// UEFI returns the UEFI variables and boot configuration of the VM.
+func (d *qemu) UEFI() (*api.InstanceUEFI, error) {
+	var store *edk2.VarStore
+
+	err := d.withNVRAM(false, func(path string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		store, err = edk2.ParseVarStore(data)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uefi := &api.InstanceUEFI{
+		InstanceUEFIPut: api.InstanceUEFIPut{
+			BootOrder: store.BootOrder(),
+			BootNext:  store.BootNext(),
+		},
+		BootEntries: []api.InstanceUEFIBootEntry{},
+		Variables:   []api.InstanceUEFIVariable{},
+	}
+
+	for _, entry := range store.BootEntries() {
+		uefi.BootEntries = append(uefi.BootEntries, api.InstanceUEFIBootEntry{
+			Name:        entry.Name,
+			Description: entry.Description,
+			Active:      entry.Attributes&edk2.LoadOptionActive != 0,
+		})
+	}
+
+	for _, v := range store.Variables() {
+		uefi.Variables = append(uefi.Variables, api.InstanceUEFIVariable{
+			Name:       v.Name,
+			GUID:       v.GUID,
+			Attributes: v.Attributes,
+			Size:       len(v.Data),
+		})
+	}
+
+	uefi.SecureBootKeys = []api.InstanceUEFISecureBootKey{}
+	for _, name := range edk2.SecureBootVariables {
+		signatures, err := store.SecureBootKeys(name)
+		if err != nil {
+			return nil, fmt.Errorf("Failed parsing Secure Boot variable %q: %w", name, err)
+		}
+
+		for _, sig := range signatures {
+			uefi.SecureBootKeys = append(uefi.SecureBootKeys, qemuSecureBootKey(name, sig))
+		}
+	}
+
+	return uefi, nil
+}
+
+// qemuSecureBootKey returns the API representation of an entry of a Secure Boot variable.
+func qemuSecureBootKey(name string, sig edk2.Signature) api.InstanceUEFISecureBootKey {
+	key := api.InstanceUEFISecureBootKey{
+		Variable: name,
+		Type:     sig.Type,
+		Owner:    sig.Owner,
+	}
+
+	switch sig.Type {
+	case edk2.SignatureTypeX509:
+		key.Type = "x509"
+
+		hash := sha256.Sum256(sig.Data)
+		key.Fingerprint = hex.EncodeToString(hash[:])
+
+		cert, err := x509.ParseCertificate(sig.Data)
+		if err == nil {
+			key.Subject = cert.Subject.String()
+		}
+
+	case edk2.SignatureTypeSHA256:
+		key.Type = "sha256"
+		key.Fingerprint = hex.EncodeToString(sig.Data)
+	}
+
+	return key
+}
+
+// UEFIEnrollSecureBootKeys enrolls certificates into one of the Secure Boot variables of the stopped VM.
+func (d *qemu) UEFIEnrollSecureBootKeys(req api.InstanceUEFISecureBootKeysPost) error {
+	if !slices.Contains(edk2.SecureBootVariables, req.Variable) {
+		return api.StatusErrorf(http.StatusBadRequest, "Invalid Secure Boot variable %q", req.Variable)
+	}
+
+	// Parse the certificates.
+	signatures := []edk2.Signature{}
+	for _, entry := range req.Certificates {
+		rest := []byte(entry)
+		for {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+
+			_, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return api.StatusErrorf(http.StatusBadRequest, "Invalid certificate: %v", err)
+			}
+
+			signatures = append(signatures, edk2.Signature{
+				Type:  edk2.SignatureTypeX509,
+				Owner: d.localConfig["volatile.uuid"],
+				Data:  block.Bytes,
+			})
+		}
+	}
+
+	if len(req.Certificates) > 0 && len(signatures) == 0 {
+		return api.StatusErrorf(http.StatusBadRequest, "No PEM encoded certificate provided")
+	}
+
+	return d.withNVRAM(true, func(path string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		store, err := edk2.ParseVarStore(data)
+		if err != nil {
+			return err
+		}
+
+		if req.Append {
+			existing, err := store.SecureBootKeys(req.Variable)
+			if err != nil {
+				return err
+			}
+
+			signatures = edk2.MergeSignatures(existing, signatures)
+		}
+
+		err = store.SetSecureBootKeys(req.Variable, signatures, time.Now())
+		if err != nil {
+			return api.StatusErrorf(http.StatusBadRequest, "Failed enrolling keys into %q: %v", req.Variable, err)
+		}
+
+		return os.WriteFile(path, store.Bytes(), 0o600)
+	})
+}
+
+// UEFIUpdate updates the UEFI boot configuration of the stopped VM.
+func (d *qemu) UEFIUpdate(req api.InstanceUEFIPut) error {
+	return d.withNVRAM(true, func(path string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		store, err := edk2.ParseVarStore(data)
+		if err != nil {
+			return err
+		}
+
+		err = store.SetBootOrder(req.BootOrder)
+		if err != nil {
+			return api.StatusErrorf(http.StatusBadRequest, "Failed setting boot order: %v", err)
+		}
+
+		err = store.SetBootNext(req.BootNext)
+		if err != nil {
+			return api.StatusErrorf(http.StatusBadRequest, "Failed setting next boot entry: %v", err)
+		}
+
+		return os.WriteFile(path, store.Bytes(), 0o600)
+	})
+}
+
+// UEFIExport writes the firmware vars file of the VM.
+func (d *qemu) UEFIExport(w io.Writer) error {
+	return d.withNVRAM(false, func(path string) error {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = f.Close() }()
+
+		_, err = io.Copy(w, f)
+		if err != nil {
+			return err
+		}
+
+		return f.Close()
+	})
+}
+
+// UEFIImport replaces the firmware vars file of the stopped VM.
+func (d *qemu) UEFIImport(r io.Reader) error {
+	data, err := io.ReadAll(io.LimitReader(r, qemuNVRAMMaxSize+1))
+	if err != nil {
+		return err
+	}
+
+	if len(data) > qemuNVRAMMaxSize {
+		return api.StatusErrorf(http.StatusBadRequest, "UEFI variable store is too large")
+	}
+
+	_, err = edk2.ParseVarStore(data)
+	if err != nil {
+		return api.StatusErrorf(http.StatusBadRequest, "%v", err)
+	}
+
+	return d.withNVRAM(true, func(path string) error {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+
+		// The variable store must match the layout expected by the firmware.
+		if fi.Size() != int64(len(data)) {
+			return api.StatusErrorf(http.StatusBadRequest, "UEFI variable store size (%d) doesn't match the firmware (%d)", len(data), fi.Size())
+		}
+
+		return os.WriteFile(path, data, 0o600)
+	})
+}
+
+// UEFIReset resets the UEFI variables of the stopped VM to the firmware defaults.
+func (d *qemu) UEFIReset() error {
+	if !d.architectureSupportsUEFI(d.architecture) {
+		return api.StatusErrorf(http.StatusBadRequest, "UEFI isn't supported on this architecture")
+	}
+
+	if d.IsRunning() {
+		return api.StatusErrorf(http.StatusBadRequest, "The instance must be stopped to modify its UEFI variables")
+	}
+
+	// setupNvram() requires instance's config volume to be mounted.
+	_, err := d.mount()
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = d.unmount() }()
+
+	return d.setupNvram()
+}
+
+// withNVRAM runs the given function with the path of the firmware vars file of the VM, ensuring the config
+// volume is mounted. Modifications are only allowed while the VM is stopped as QEMU owns the file otherwise.
+func (d *qemu) withNVRAM(write bool, f func(path string) error) error {
+	if !d.architectureSupportsUEFI(d.architecture) {
+		return api.StatusErrorf(http.StatusBadRequest, "UEFI isn't supported on this architecture")
+	}
+
+	isRunning := d.IsRunning()
+	if write && isRunning {
+		return api.StatusErrorf(http.StatusBadRequest, "The instance must be stopped to modify its UEFI variables")
+	}
+
+	if !isRunning {
+		_, err := d.mount()
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = d.unmount() }()
+	}
+
+	if !util.PathExists(d.nvramPath()) {
+		return api.StatusErrorf(http.StatusNotFound, "The instance doesn't have UEFI variables yet")
+	}
+
+	path, err := filepath.EvalSymlinks(d.nvramPath())
+	if err != nil {
+		return fmt.Errorf("Failed resolving UEFI variables file: %w", err)
+	}
+
+	return f(path)
+}
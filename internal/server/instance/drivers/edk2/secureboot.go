@@ -0,0 +1,166 @@
+package edk2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// ImageSecurityDatabaseGUID is the vendor GUID of the Secure Boot signature databases (db and dbx).
+const ImageSecurityDatabaseGUID = "d719b2cb-3d3a-4596-a3bc-dad00e67656f"
+
+// VariableTimeBasedAuthenticatedWriteAccess marks variables which can only be updated with a signed payload.
+const VariableTimeBasedAuthenticatedWriteAccess uint32 = 0x20
+
+// Signature types.
+const (
+	SignatureTypeX509   = "a5c059a1-94e4-4aa7-87b5-ab155c2bf072"
+	SignatureTypeSHA256 = "c1c41626-504c-4092-aca9-41f936934328"
+)
+
+// SecureBootVariables lists the Secure Boot key variables in order of authority.
+var SecureBootVariables = []string{"PK", "KEK", "db", "dbx"}
+
+// Signature represents an entry of a Secure Boot signature database.
+type Signature struct {
+	Type  string
+	Owner string
+	Data  []byte
+}
+
+// secureBootVariableGUID returns the vendor GUID of a Secure Boot key variable.
+func secureBootVariableGUID(name string) (string, error) {
+	switch name {
+	case "PK", "KEK":
+		return GlobalVariableGUID, nil
+	case "db", "dbx":
+		return ImageSecurityDatabaseGUID, nil
+	}
+
+	return "", fmt.Errorf("Invalid Secure Boot variable %q", name)
+}
+
+// SecureBootKeys returns the signatures stored in the given Secure Boot key variable (PK, KEK, db or dbx).
+func (s *VarStore) SecureBootKeys(name string) ([]Signature, error) {
+	guid, err := secureBootVariableGUID(name)
+	if err != nil {
+		return nil, err
+	}
+
+	v := s.Get(guid, name)
+	if v == nil {
+		return []Signature{}, nil
+	}
+
+	return ParseSignatureLists(v.Data)
+}
+
+// SetSecureBootKeys replaces the signatures of the given Secure Boot key variable (PK, KEK, db or dbx).
+// An empty list of signatures removes the variable, which for PK puts the firmware back in setup mode.
+func (s *VarStore) SetSecureBootKeys(name string, signatures []Signature, timestamp time.Time) error {
+	guid, err := secureBootVariableGUID(name)
+	if err != nil {
+		return err
+	}
+
+	if name == "PK" && len(signatures) > 1 {
+		return fmt.Errorf("The platform key can only hold a single certificate")
+	}
+
+	if len(signatures) == 0 {
+		s.Delete(guid, name)
+		return nil
+	}
+
+	attributes := VariableNonVolatile | VariableBootServiceAccess | VariableRuntimeAccess | VariableTimeBasedAuthenticatedWriteAccess
+
+	return s.SetAuthenticated(guid, name, attributes, EncodeSignatureLists(signatures), timestamp)
+}
+
+// ParseSignatureLists parses a list of EFI_SIGNATURE_LIST structures.
+func ParseSignatureLists(data []byte) ([]Signature, error) {
+	signatures := []Signature{}
+
+	for len(data) > 0 {
+		if len(data) < 28 {
+			return nil, fmt.Errorf("Truncated signature list")
+		}
+
+		sigType := guidString(data[0:16])
+		listSize := int(binary.LittleEndian.Uint32(data[16:20]))
+		headerSize := int(binary.LittleEndian.Uint32(data[20:24]))
+		sigSize := int(binary.LittleEndian.Uint32(data[24:28]))
+
+		if listSize < 28+headerSize || listSize > len(data) || sigSize <= 16 || (listSize-28-headerSize)%sigSize != 0 {
+			return nil, fmt.Errorf("Invalid signature list")
+		}
+
+		entries := data[28+headerSize : listSize]
+		for len(entries) > 0 {
+			signatures = append(signatures, Signature{
+				Type:  sigType,
+				Owner: guidString(entries[0:16]),
+				Data:  append([]byte{}, entries[16:sigSize]...),
+			})
+
+			entries = entries[sigSize:]
+		}
+
+		data = data[listSize:]
+	}
+
+	return signatures, nil
+}
+
+// EncodeSignatureLists encodes signatures as a list of EFI_SIGNATURE_LIST structures.
+// Consecutive signatures of the same type and size share a list.
+func EncodeSignatureLists(signatures []Signature) []byte {
+	var out []byte
+
+	for i := 0; i < len(signatures); {
+		// Group signatures of identical type and size.
+		j := i + 1
+		for j < len(signatures) && signatures[j].Type == signatures[i].Type && len(signatures[j].Data) == len(signatures[i].Data) {
+			j++
+		}
+
+		sigSize := 16 + len(signatures[i].Data)
+
+		list := guidBytes(signatures[i].Type)
+		list = binary.LittleEndian.AppendUint32(list, uint32(28+sigSize*(j-i)))
+		list = binary.LittleEndian.AppendUint32(list, 0)
+		list = binary.LittleEndian.AppendUint32(list, uint32(sigSize))
+
+		for _, sig := range signatures[i:j] {
+			list = append(list, guidBytes(sig.Owner)...)
+			list = append(list, sig.Data...)
+		}
+
+		out = append(out, list...)
+		i = j
+	}
+
+	return out
+}
+
+// MergeSignatures appends new signatures to existing ones, skipping duplicates.
+func MergeSignatures(existing []Signature, signatures []Signature) []Signature {
+	merged := append([]Signature{}, existing...)
+
+	for _, sig := range signatures {
+		found := false
+		for _, e := range merged {
+			if e.Type == sig.Type && bytes.Equal(e.Data, sig.Data) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			merged = append(merged, sig)
+		}
+	}
+
+	return merged
+}
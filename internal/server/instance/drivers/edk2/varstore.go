@@ -0,0 +1,526 @@
+package edk2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// GlobalVariableGUID is the vendor GUID of the UEFI global variables (boot entries, boot order, ...).
+const GlobalVariableGUID = "8be4df61-93ca-11d2-aa0d-00e098032b8c"
+
+// Variable attributes.
+const (
+	VariableNonVolatile       uint32 = 0x01
+	VariableBootServiceAccess uint32 = 0x02
+	VariableRuntimeAccess     uint32 = 0x04
+)
+
+// Load option attributes.
+const (
+	LoadOptionActive uint32 = 0x01
+)
+
+const (
+	fvSignature = "_FVH"
+
+	varStoreFormatted = 0x5a
+	varStoreHealthy   = 0xfe
+
+	varStartID             = 0x55aa
+	varAdded               = 0x3f
+	varInDeletedTransition = 0xfe
+	varDeleted             = 0xfd
+
+	varHeaderSize     = 32
+	authVarHeaderSize = 60
+
+	// Offset and size of the monotonic count, timestamp and public key index of authenticated variable headers.
+	authInfoOffset = 8
+	authInfoSize   = 28
+)
+
+var (
+	authenticatedVariableGUID = guidBytes("aaf32c78-947b-439a-a180-2e144ec37792")
+	variableGUID              = guidBytes("ddcf3616-3275-4164-98b6-fe85707ffe7d")
+)
+
+// ErrVarStoreFull is returned when a variable store doesn't have enough space left for a variable.
+var ErrVarStoreFull = errors.New("Not enough space left in the UEFI variable store")
+
+// Variable represents a UEFI variable.
+type Variable struct {
+	Name       string
+	GUID       string
+	Attributes uint32
+	Data       []byte
+
+	offset   int
+	authInfo []byte
+}
+
+// VarStore represents an EDK2 variable store as found in the firmware vars file of a VM.
+type VarStore struct {
+	data          []byte
+	start         int
+	end           int
+	authenticated bool
+	variables     []*Variable
+}
+
+// BootEntry represents a UEFI boot entry (Boot####) of a variable store.
+type BootEntry struct {
+	Name        string
+	Attributes  uint32
+	Description string
+}
+
+// ParseVarStore parses the content of an EDK2 firmware vars file.
+func ParseVarStore(data []byte) (*VarStore, error) {
+	// Parse the firmware volume header.
+	if len(data) < 0x48 || string(data[40:44]) != fvSignature {
+		return nil, fmt.Errorf("Invalid UEFI variable store: Missing firmware volume header")
+	}
+
+	headerLength := int(binary.LittleEndian.Uint16(data[48:50]))
+	if headerLength+28 > len(data) {
+		return nil, fmt.Errorf("Invalid UEFI variable store: Truncated firmware volume")
+	}
+
+	// Parse the variable store header.
+	header := data[headerLength : headerLength+28]
+	s := &VarStore{data: data}
+
+	if bytes.Equal(header[0:16], authenticatedVariableGUID) {
+		s.authenticated = true
+	} else if !bytes.Equal(header[0:16], variableGUID) {
+		return nil, fmt.Errorf("Invalid UEFI variable store: Unknown variable store format")
+	}
+
+	size := int(binary.LittleEndian.Uint32(header[16:20]))
+	if header[20] != varStoreFormatted || header[21] != varStoreHealthy {
+		return nil, fmt.Errorf("Invalid UEFI variable store: Variable store isn't formatted or healthy")
+	}
+
+	if headerLength+size > len(data) {
+		return nil, fmt.Errorf("Invalid UEFI variable store: Truncated variable store")
+	}
+
+	s.start = headerLength + 28
+	s.end = headerLength + size
+
+	// Parse the variables.
+	offset := s.start
+	for {
+		v, next, err := s.parseVariable(offset)
+		if err != nil {
+			return nil, err
+		}
+
+		if next == 0 {
+			break
+		}
+
+		if v != nil {
+			s.variables = append(s.variables, v)
+		}
+
+		offset = next
+	}
+
+	return s, nil
+}
+
+// Bytes returns the content of the firmware vars file.
+func (s *VarStore) Bytes() []byte {
+	return s.data
+}
+
+// Variables returns all the variables of the store.
+func (s *VarStore) Variables() []Variable {
+	variables := make([]Variable, 0, len(s.variables))
+	for _, v := range s.variables {
+		variables = append(variables, *v)
+	}
+
+	return variables
+}
+
+// Get returns the given variable or nil if it doesn't exist.
+func (s *VarStore) Get(guid string, name string) *Variable {
+	for _, v := range s.variables {
+		if v.GUID == guid && v.Name == name {
+			variable := *v
+			return &variable
+		}
+	}
+
+	return nil
+}
+
+// Set creates or replaces the given variable.
+func (s *VarStore) Set(guid string, name string, attributes uint32, data []byte) error {
+	return s.set(&Variable{Name: name, GUID: guid, Attributes: attributes, Data: append([]byte{}, data...)})
+}
+
+// SetAuthenticated creates or replaces the given time based authenticated variable, recording the timestamp
+// used by the firmware to reject older updates. The store itself isn't protected so no signature is needed.
+func (s *VarStore) SetAuthenticated(guid string, name string, attributes uint32, data []byte, timestamp time.Time) error {
+	if !s.authenticated {
+		return fmt.Errorf("The UEFI variable store doesn't support authenticated variables")
+	}
+
+	v := &Variable{Name: name, GUID: guid, Attributes: attributes, Data: append([]byte{}, data...)}
+	v.authInfo = make([]byte, authInfoSize)
+	copy(v.authInfo[8:24], encodeTime(timestamp))
+
+	return s.set(v)
+}
+
+// set creates or replaces a variable, keeping the authentication information of the existing one if none is provided.
+func (s *VarStore) set(nv *Variable) error {
+	for i, v := range s.variables {
+		if v.GUID != nv.GUID || v.Name != nv.Name {
+			continue
+		}
+
+		if nv.authInfo == nil {
+			nv.authInfo = v.authInfo
+		}
+
+		// Update in place if possible.
+		if len(nv.Data) == len(v.Data) && nv.Attributes == v.Attributes && bytes.Equal(nv.authInfo, v.authInfo) {
+			copy(s.data[v.offset+s.headerSize()+len(encodeName(nv.Name)):], nv.Data)
+			v.Data = nv.Data
+			return nil
+		}
+
+		// Otherwise mark the existing variable as deleted before appending the new version.
+		s.data[v.offset+2] &= varDeleted
+		s.variables = append(s.variables[:i], s.variables[i+1:]...)
+		break
+	}
+
+	return s.add(nv)
+}
+
+// Delete removes the given variable.
+func (s *VarStore) Delete(guid string, name string) {
+	for i, v := range s.variables {
+		if v.GUID == guid && v.Name == name {
+			s.data[v.offset+2] &= varDeleted
+			s.variables = append(s.variables[:i], s.variables[i+1:]...)
+			return
+		}
+	}
+}
+
+// BootOrder returns the names of the boot entries (Boot####) in boot order.
+func (s *VarStore) BootOrder() []string {
+	v := s.Get(GlobalVariableGUID, "BootOrder")
+	if v == nil {
+		return []string{}
+	}
+
+	order := make([]string, 0, len(v.Data)/2)
+	for i := 0; i+1 < len(v.Data); i += 2 {
+		order = append(order, fmt.Sprintf("Boot%04X", binary.LittleEndian.Uint16(v.Data[i:i+2])))
+	}
+
+	return order
+}
+
+// SetBootOrder sets the boot order from a list of boot entry names (Boot####).
+func (s *VarStore) SetBootOrder(order []string) error {
+	data := make([]byte, 0, len(order)*2)
+	for _, name := range order {
+		id, err := bootEntryID(name)
+		if err != nil {
+			return err
+		}
+
+		if s.Get(GlobalVariableGUID, name) == nil {
+			return fmt.Errorf("Boot entry %q doesn't exist", name)
+		}
+
+		data = binary.LittleEndian.AppendUint16(data, id)
+	}
+
+	return s.Set(GlobalVariableGUID, "BootOrder", VariableNonVolatile|VariableBootServiceAccess|VariableRuntimeAccess, data)
+}
+
+// BootNext returns the name of the boot entry to use for the next boot only, if any.
+func (s *VarStore) BootNext() string {
+	v := s.Get(GlobalVariableGUID, "BootNext")
+	if v == nil || len(v.Data) != 2 {
+		return ""
+	}
+
+	return fmt.Sprintf("Boot%04X", binary.LittleEndian.Uint16(v.Data))
+}
+
+// SetBootNext sets the boot entry to use for the next boot only, or clears it if empty.
+func (s *VarStore) SetBootNext(name string) error {
+	if name == "" {
+		s.Delete(GlobalVariableGUID, "BootNext")
+		return nil
+	}
+
+	id, err := bootEntryID(name)
+	if err != nil {
+		return err
+	}
+
+	if s.Get(GlobalVariableGUID, name) == nil {
+		return fmt.Errorf("Boot entry %q doesn't exist", name)
+	}
+
+	return s.Set(GlobalVariableGUID, "BootNext", VariableNonVolatile|VariableBootServiceAccess|VariableRuntimeAccess, binary.LittleEndian.AppendUint16(nil, id))
+}
+
+// BootEntries returns the boot entries (Boot####) of the store sorted by name.
+func (s *VarStore) BootEntries() []BootEntry {
+	entries := []BootEntry{}
+	for _, v := range s.variables {
+		if v.GUID != GlobalVariableGUID {
+			continue
+		}
+
+		_, err := bootEntryID(v.Name)
+		if err != nil || len(v.Data) < 6 {
+			continue
+		}
+
+		// Parse the EFI_LOAD_OPTION structure for the description.
+		entry := BootEntry{
+			Name:       v.Name,
+			Attributes: binary.LittleEndian.Uint32(v.Data[0:4]),
+		}
+
+		description, _ := decodeName(v.Data[6:])
+		entry.Description = description
+
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	return entries
+}
+
+// headerSize returns the size of the variable headers of the store.
+func (s *VarStore) headerSize() int {
+	if s.authenticated {
+		return authVarHeaderSize
+	}
+
+	return varHeaderSize
+}
+
+// parseVariable parses the variable at the given offset. It returns a nil variable for deleted variables and
+// a zero next offset once the end of the variables is reached.
+func (s *VarStore) parseVariable(offset int) (*Variable, int, error) {
+	headerSize := s.headerSize()
+	if offset+headerSize > s.end || binary.LittleEndian.Uint16(s.data[offset:offset+2]) != varStartID {
+		return nil, 0, nil
+	}
+
+	header := s.data[offset : offset+headerSize]
+	state := header[2]
+	attributes := binary.LittleEndian.Uint32(header[4:8])
+
+	// The name and data sizes and the vendor GUID are at the end of both header formats.
+	nameSize := int(binary.LittleEndian.Uint32(header[headerSize-24 : headerSize-20]))
+	dataSize := int(binary.LittleEndian.Uint32(header[headerSize-20 : headerSize-16]))
+	guid := header[headerSize-16 : headerSize]
+
+	dataOffset := offset + headerSize + nameSize
+	if nameSize < 0 || dataSize < 0 || dataOffset+dataSize > s.end {
+		return nil, 0, fmt.Errorf("Invalid UEFI variable store: Truncated variable at offset %d", offset)
+	}
+
+	next := alignVariable(dataOffset + dataSize)
+
+	if state != varAdded && state != varAdded&varInDeletedTransition {
+		return nil, next, nil
+	}
+
+	name, err := decodeName(s.data[offset+headerSize : dataOffset])
+	if err != nil {
+		return nil, 0, fmt.Errorf("Invalid UEFI variable store: Bad variable name at offset %d: %w", offset, err)
+	}
+
+	v := &Variable{
+		Name:       name,
+		GUID:       guidString(guid),
+		Attributes: attributes,
+		Data:       append([]byte{}, s.data[dataOffset:dataOffset+dataSize]...),
+		offset:     offset,
+	}
+
+	if s.authenticated {
+		v.authInfo = append([]byte{}, header[authInfoOffset:authInfoOffset+authInfoSize]...)
+	}
+
+	return v, next, nil
+}
+
+// usedEnd returns the offset following the last variable of the store (including deleted ones).
+func (s *VarStore) usedEnd() int {
+	offset := s.start
+	for {
+		_, next, err := s.parseVariable(offset)
+		if err != nil || next == 0 {
+			return offset
+		}
+
+		offset = next
+	}
+}
+
+// add appends a variable to the store, reclaiming the space of deleted variables if needed.
+func (s *VarStore) add(v *Variable) error {
+	record := s.encodeVariable(v)
+
+	offset := s.usedEnd()
+	if offset+len(record) > s.end {
+		// Rewrite the store with only the valid variables.
+		s.reclaim()
+
+		offset = s.usedEnd()
+		if offset+len(record) > s.end {
+			return ErrVarStoreFull
+		}
+	}
+
+	copy(s.data[offset:], record)
+	v.offset = offset
+	s.variables = append(s.variables, v)
+
+	return nil
+}
+
+// reclaim rewrites the variables area with only the valid variables.
+func (s *VarStore) reclaim() {
+	area := bytes.Repeat([]byte{0xff}, s.end-s.start)
+
+	offset := 0
+	for _, v := range s.variables {
+		record := s.encodeVariable(v)
+		copy(area[offset:], record)
+		v.offset = s.start + offset
+		offset += len(record)
+	}
+
+	copy(s.data[s.start:s.end], area)
+}
+
+// encodeVariable returns the on-disk representation of a variable, padded to the variable alignment.
+func (s *VarStore) encodeVariable(v *Variable) []byte {
+	name := encodeName(v.Name)
+	headerSize := s.headerSize()
+
+	header := make([]byte, headerSize)
+	binary.LittleEndian.PutUint16(header[0:2], varStartID)
+	header[2] = varAdded
+	binary.LittleEndian.PutUint32(header[4:8], v.Attributes)
+	if s.authenticated && v.authInfo != nil {
+		copy(header[authInfoOffset:authInfoOffset+authInfoSize], v.authInfo)
+	}
+
+	binary.LittleEndian.PutUint32(header[headerSize-24:headerSize-20], uint32(len(name)))
+	binary.LittleEndian.PutUint32(header[headerSize-20:headerSize-16], uint32(len(v.Data)))
+	copy(header[headerSize-16:], guidBytes(v.GUID))
+
+	record := append(header, name...)
+	record = append(record, v.Data...)
+
+	for len(record)%4 != 0 {
+		record = append(record, 0xff)
+	}
+
+	return record
+}
+
+// alignVariable aligns an offset to the variable header alignment.
+func alignVariable(offset int) int {
+	return (offset + 3) &^ 3
+}
+
+// bootEntryID returns the numeric identifier of a boot entry name (Boot####).
+func bootEntryID(name string) (uint16, error) {
+	hexID, found := strings.CutPrefix(name, "Boot")
+	if !found || len(hexID) != 4 || strings.ToUpper(hexID) != hexID {
+		return 0, fmt.Errorf("Invalid boot entry name %q", name)
+	}
+
+	var id uint16
+	_, err := fmt.Sscanf(hexID, "%04X", &id)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid boot entry name %q", name)
+	}
+
+	return id, nil
+}
+
+// decodeName decodes a NUL terminated UTF-16LE string.
+func decodeName(data []byte) (string, error) {
+	chars := []uint16{}
+	for i := 0; i+1 < len(data); i += 2 {
+		c := binary.LittleEndian.Uint16(data[i : i+2])
+		if c == 0 {
+			return string(utf16.Decode(chars)), nil
+		}
+
+		chars = append(chars, c)
+	}
+
+	return "", fmt.Errorf("Missing string terminator")
+}
+
+// encodeName encodes a string as NUL terminated UTF-16LE.
+func encodeName(name string) []byte {
+	data := []byte{}
+	for _, c := range utf16.Encode([]rune(name)) {
+		data = binary.LittleEndian.AppendUint16(data, c)
+	}
+
+	return append(data, 0, 0)
+}
+
+// encodeTime returns the EFI_TIME representation of a timestamp in UTC.
+func encodeTime(t time.Time) []byte {
+	t = t.UTC()
+
+	data := binary.LittleEndian.AppendUint16(nil, uint16(t.Year()))
+	data = append(data, byte(t.Month()), byte(t.Day()), byte(t.Hour()), byte(t.Minute()), byte(t.Second()), 0)
+
+	// Nanoseconds, time zone, daylight and padding are left empty as required for authenticated variables.
+	return append(data, make([]byte, 8)...)
+}
+
+// guidString formats a binary GUID.
+func guidString(b []byte) string {
+	return fmt.Sprintf("%08x-%04x-%04x-%x-%x", binary.LittleEndian.Uint32(b[0:4]), binary.LittleEndian.Uint16(b[4:6]), binary.LittleEndian.Uint16(b[6:8]), b[8:10], b[10:16])
+}
+
+// guidBytes returns the binary form of a GUID.
+func guidBytes(guid string) []byte {
+	raw, err := hex.DecodeString(strings.ReplaceAll(guid, "-", ""))
+	if err != nil || len(raw) != 16 {
+		return make([]byte, 16)
+	}
+
+	// The first three fields are stored little-endian.
+	out := binary.LittleEndian.AppendUint32(nil, binary.BigEndian.Uint32(raw[0:4]))
+	out = binary.LittleEndian.AppendUint16(out, binary.BigEndian.Uint16(raw[4:6]))
+	out = binary.LittleEndian.AppendUint16(out, binary.BigEndian.Uint16(raw[6:8]))
+
+	return append(out, raw[8:]...)
+}
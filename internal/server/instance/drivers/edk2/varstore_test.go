@@ -0,0 +1,218 @@
+package edk2
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+)
+
+// tVarStore returns an empty authenticated variable store of the given size.
+func tVarStore(size int) []byte {
+	data := make([]byte, 0x48+size)
+	for i := range data {
+		data[i] = 0xff
+	}
+
+	// Firmware volume header.
+	copy(data[0:16], make([]byte, 16))
+	copy(data[40:44], fvSignature)
+	binary.LittleEndian.PutUint16(data[48:50], 0x48)
+
+	// Variable store header.
+	copy(data[0x48:], authenticatedVariableGUID)
+	binary.LittleEndian.PutUint32(data[0x48+16:], uint32(size))
+	data[0x48+20] = varStoreFormatted
+	data[0x48+21] = varStoreHealthy
+	copy(data[0x48+22:0x48+28], make([]byte, 6))
+
+	return data
+}
+
+// tLoadOption returns an EFI_LOAD_OPTION with the given description and an empty device path.
+func tLoadOption(description string) []byte {
+	data := binary.LittleEndian.AppendUint32(nil, LoadOptionActive)
+	data = binary.LittleEndian.AppendUint16(data, 0)
+
+	return append(data, encodeName(description)...)
+}
+
+func TestVarStoreBootEntries(t *testing.T) {
+	s, err := ParseVarStore(tVarStore(4096))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attrs := VariableNonVolatile | VariableBootServiceAccess | VariableRuntimeAccess
+	for name, description := range map[string]string{"Boot0000": "UEFI Misc Device", "Boot0001": "UEFI PXEv4"} {
+		err = s.Set(GlobalVariableGUID, name, attrs, tLoadOption(description))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = s.SetBootOrder([]string{"Boot0000", "Boot0001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.SetBootOrder([]string{"Boot0002"})
+	if err == nil {
+		t.Fatal("Expected an error for a missing boot entry")
+	}
+
+	// Re-order in place and drop an entry which needs a new variable.
+	err = s.SetBootOrder([]string{"Boot0001", "Boot0000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.SetBootOrder([]string{"Boot0001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.SetBootNext("Boot0000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Parse the result again.
+	s, err = ParseVarStore(s.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	order := s.BootOrder()
+	if len(order) != 1 || order[0] != "Boot0001" {
+		t.Fatalf("Unexpected boot order %v", order)
+	}
+
+	if s.BootNext() != "Boot0000" {
+		t.Fatalf("Unexpected boot next %q", s.BootNext())
+	}
+
+	entries := s.BootEntries()
+	if len(entries) != 2 || entries[0].Name != "Boot0000" || entries[0].Description != "UEFI Misc Device" || entries[1].Description != "UEFI PXEv4" {
+		t.Fatalf("Unexpected boot entries %+v", entries)
+	}
+
+	err = s.SetBootNext("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.Variables()) != 3 {
+		t.Fatalf("Unexpected variables %+v", s.Variables())
+	}
+}
+
+func TestVarStoreReclaim(t *testing.T) {
+	s, err := ParseVarStore(tVarStore(256))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each update of a different size leaves a deleted variable behind, requiring the space to be reclaimed.
+	for i := 1; i < 20; i++ {
+		err = s.Set(GlobalVariableGUID, "Test", VariableNonVolatile, make([]byte, i%4+1))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s, err = ParseVarStore(s.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := s.Get(GlobalVariableGUID, "Test")
+	if v == nil || len(v.Data) != 19%4+1 {
+		t.Fatalf("Unexpected variable %+v", v)
+	}
+
+	err = s.Set(GlobalVariableGUID, "Large", VariableNonVolatile, make([]byte, 512))
+	if !errors.Is(err, ErrVarStoreFull) {
+		t.Fatalf("Expected ErrVarStoreFull, got %v", err)
+	}
+}
+
+func TestGUID(t *testing.T) {
+	guid := "8be4df61-93ca-11d2-aa0d-00e098032b8c"
+	b := guidBytes(guid)
+	if b[0] != 0x61 || b[3] != 0x8b || b[4] != 0xca || b[8] != 0xaa {
+		t.Fatalf("Unexpected binary GUID %x", b)
+	}
+
+	if guidString(b) != guid {
+		t.Fatalf("Unexpected GUID %q", guidString(b))
+	}
+}
+
+func TestSecureBootKeys(t *testing.T) {
+	s, err := ParseVarStore(tVarStore(4096))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owner := "11111111-2222-3333-4444-555555555555"
+	certs := []Signature{
+		{Type: SignatureTypeX509, Owner: owner, Data: []byte("first-certificate")},
+		{Type: SignatureTypeX509, Owner: owner, Data: []byte("second-certificate-longer")},
+		{Type: SignatureTypeX509, Owner: owner, Data: []byte("third-certificate")},
+	}
+
+	err = s.SetSecureBootKeys("PK", certs, time.Now())
+	if err == nil {
+		t.Fatal("Expected an error for multiple platform keys")
+	}
+
+	err = s.SetSecureBootKeys("db", certs[0:2], time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	existing, err := s.SecureBootKeys("db")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.SetSecureBootKeys("db", MergeSignatures(existing, certs), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Parse the result again.
+	s, err = ParseVarStore(s.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := s.SecureBootKeys("db")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(keys) != 3 || string(keys[2].Data) != "third-certificate" || keys[1].Owner != owner {
+		t.Fatalf("Unexpected keys %+v", keys)
+	}
+
+	v := s.Get(ImageSecurityDatabaseGUID, "db")
+	if v == nil || v.Attributes&VariableTimeBasedAuthenticatedWriteAccess == 0 || len(v.authInfo) != authInfoSize {
+		t.Fatalf("Unexpected variable %+v", v)
+	}
+
+	err = s.SetSecureBootKeys("db", nil, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Get(ImageSecurityDatabaseGUID, "db") != nil {
+		t.Fatal("Expected db to be removed")
+	}
+
+	_, err = s.SecureBootKeys("PKK")
+	if err == nil {
+		t.Fatal("Expected an error for an invalid variable")
+	}
+}
@@ -1435,3 +1435,43 @@ func (m *Monitor) DumpGuestMemory(path string, format string) error {
 
 	return m.Run("dump-guest-memory", args, &queryResp)
 }
+
+// DeviceProperties returns the names of the properties supported by a device type.
+func (m *Monitor) DeviceProperties(typeName string) ([]string, error) {
+	// Prepare the response.
+	var resp struct {
+		Return []struct {
+			Name string `json:"name"`
+		} `json:"return"`
+	}
+
+	args := map[string]any{
+		"typename": typeName,
+	}
+
+	err := m.Run("device-list-properties", args, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list properties of %q: %w", typeName, err)
+	}
+
+	names := make([]string, 0, len(resp.Return))
+	for _, prop := range resp.Return {
+		names = append(names, prop.Name)
+	}
+
+	return names, nil
+}
+
+// SetWatchdogAction sets the action to perform when the guest watchdog fires.
+func (m *Monitor) SetWatchdogAction(action string) error {
+	args := map[string]any{
+		"action": action,
+	}
+
+	err := m.Run("watchdog-set-action", args, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to set the watchdog action: %w", err)
+	}
+
+	return nil
+}
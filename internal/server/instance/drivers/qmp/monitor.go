@@ -40,6 +40,45 @@ var EventDiskEjected = "DEVICE_TRAY_MOVED"
 // ExcludedCommands is used to filter verbose commands from the QMP logs.
 var ExcludedCommands = []string{"ringbuf-read"}
 
+// DebugCommands lists the read-only QMP commands which can be run through the debug API.
+var DebugCommands = []string{
+	"qom-get",
+	"qom-list",
+	"qom-list-types",
+	"query-acpi-ospm-status",
+	"query-balloon",
+	"query-block",
+	"query-block-jobs",
+	"query-blockstats",
+	"query-chardev",
+	"query-commands",
+	"query-cpu-definitions",
+	"query-cpu-model-expansion",
+	"query-cpus-fast",
+	"query-dump",
+	"query-fdsets",
+	"query-hotpluggable-cpus",
+	"query-iothreads",
+	"query-kvm",
+	"query-memdev",
+	"query-memory-devices",
+	"query-memory-size-summary",
+	"query-migrate",
+	"query-migrate-capabilities",
+	"query-migrate-parameters",
+	"query-name",
+	"query-named-block-nodes",
+	"query-pci",
+	"query-qmp-schema",
+	"query-rx-filter",
+	"query-spice",
+	"query-status",
+	"query-tpm",
+	"query-uuid",
+	"query-version",
+	"query-vm-generation-id",
+}
+
 // Monitor represents a QMP monitor.
 type Monitor struct {
 	path string
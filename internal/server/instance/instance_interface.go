@@ -103,7 +103,7 @@ type Instance interface {
 	Update(newConfig db.InstanceArgs, userRequested bool) error
 
 	Delete(force bool) error
-	Export(meta io.Writer, roofs io.Writer, properties map[string]string, expiration time.Time, tracker *ioprogress.ProgressTracker) (*api.ImageMetadata, error)
+	Export(meta io.Writer, roofs io.Writer, properties map[string]string, expiration time.Time, stateful bool, tracker *ioprogress.ProgressTracker) (*api.ImageMetadata, error)
 
 	// Live configuration.
 	CGroup() (*cgroup.CGroup, error)
@@ -188,6 +188,7 @@ type Container interface {
 	InsertSeccompUnixDevice(prefix string, m deviceConfig.Device, pid int) error
 	DevptsFd() (*os.File, error)
 	IdmappedStorage(path string, fstype string) idmap.StorageType
+	Remap() (bool, error)
 }
 
 // VM interface is for VM specific functions.
@@ -198,6 +199,13 @@ type VM interface {
 	ConsoleLog() (string, error)
 	ConsoleScreenshot(screenshotFile *os.File) error
 	DumpGuestMemory(w *os.File, format string) error
+	DebugQMP(command string, arguments map[string]any) (any, error)
+	UEFI() (*api.InstanceUEFI, error)
+	UEFIUpdate(req api.InstanceUEFIPut) error
+	UEFIExport(w io.Writer) error
+	UEFIImport(r io.Reader) error
+	UEFIReset() error
+	UEFIEnrollSecureBootKeys(req api.InstanceUEFISecureBootKeysPost) error
 }
 
 // CriuMigrationArgs arguments for CRIU migration.
@@ -23,6 +23,7 @@ import (
 	incus "github.com/lxc/incus/v6/client"
 	"github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/migration"
+	"github.com/lxc/incus/v6/internal/server/apparmor"
 	"github.com/lxc/incus/v6/internal/server/backup"
 	"github.com/lxc/incus/v6/internal/server/db"
 	"github.com/lxc/incus/v6/internal/server/db/cluster"
@@ -144,6 +145,13 @@ func ValidConfig(sysOS *sys.OS, config map[string]string, expanded bool, instanc
 		return fmt.Errorf("nvidia.runtime is incompatible with privileged containers")
 	}
 
+	if expanded && slices.Contains([]string{"root", "isolated"}, config["limits.cpu.partition"]) {
+		_, err := strconv.Atoi(config["limits.cpu"])
+		if config["limits.cpu"] == "" || err == nil {
+			return fmt.Errorf("limits.cpu.partition requires limits.cpu to be set to specific CPUs")
+		}
+	}
+
 	return nil
 }
 
@@ -165,6 +173,10 @@ func validConfigKey(os *sys.OS, key string, value string, instanceType instancet
 		return lxcValidConfig(value)
 	}
 
+	if key == "security.apparmor.extra_rules" {
+		return apparmor.ExtraRulesValidate(os, value)
+	}
+
 	if key == "security.syscalls.deny_compat" || key == "security.syscalls.blacklist_compat" {
 		for _, arch := range os.Architectures {
 			if arch == osarch.ARCH_64BIT_INTEL_X86 ||
@@ -572,6 +584,21 @@ func ResolveImage(ctx context.Context, tx *db.ClusterTx, projectName string, sou
 	return "", fmt.Errorf("Must specify one of alias, fingerprint or properties for init from image")
 }
 
+// ArchitectureSupported returns whether instances of the given type and architecture can run on this server.
+// Foreign architectures are only supported for containers, through qemu-user emulation.
+func ArchitectureSupported(os *sys.OS, instanceType instancetype.Type, arch int) bool {
+	if slices.Contains(os.Architectures, arch) {
+		return true
+	}
+
+	return instanceType == instancetype.Container && slices.Contains(os.EmulatedArchitectures, arch)
+}
+
+// ArchitectureEmulated returns whether the architecture is run through emulation on this server.
+func ArchitectureEmulated(os *sys.OS, arch int) bool {
+	return !slices.Contains(os.Architectures, arch) && slices.Contains(os.EmulatedArchitectures, arch)
+}
+
 // SuitableArchitectures returns a slice of architecture ids based on an instance create request.
 //
 // An empty list indicates that the request may be handled by any architecture.
@@ -819,7 +846,7 @@ func CreateInternal(s *state.State, args db.InstanceArgs, op *operations.Operati
 		return nil, nil, nil, err
 	}
 
-	if checkArchitecture && !slices.Contains(s.OS.Architectures, args.Architecture) {
+	if checkArchitecture && !ArchitectureSupported(s.OS, args.Type, args.Architecture) {
 		return nil, nil, nil, fmt.Errorf("Requested architecture isn't supported by this host")
 	}
 
@@ -22,7 +22,10 @@ const (
 	InstanceConsoleReset     = InstanceAction(api.EventLifecycleInstanceConsoleReset)
 	InstanceConsoleRetrieved = InstanceAction(api.EventLifecycleInstanceConsoleRetrieved)
 	InstanceCreated          = InstanceAction(api.EventLifecycleInstanceCreated)
+	InstanceDebugQMP         = InstanceAction(api.EventLifecycleInstanceDebugQMP)
 	InstanceDeleted          = InstanceAction(api.EventLifecycleInstanceDeleted)
+	InstanceDeviceAttached   = InstanceAction(api.EventLifecycleInstanceDeviceAttached)
+	InstanceDeviceDetached   = InstanceAction(api.EventLifecycleInstanceDeviceDetached)
 	InstanceExec             = InstanceAction(api.EventLifecycleInstanceExec)
 	InstanceFileDeleted      = InstanceAction(api.EventLifecycleInstanceFileDeleted)
 	InstanceFilePushed       = InstanceAction(api.EventLifecycleInstanceFilePushed)
@@ -30,6 +33,7 @@ const (
 	InstanceMigrated         = InstanceAction(api.EventLifecycleInstanceMigrated)
 	InstancePaused           = InstanceAction(api.EventLifecycleInstancePaused)
 	InstanceReady            = InstanceAction(api.EventLifecycleInstanceReady)
+	InstanceRebalanced       = InstanceAction(api.EventLifecycleInstanceRebalanced)
 	InstanceRenamed          = InstanceAction(api.EventLifecycleInstanceRenamed)
 	InstanceRestarted        = InstanceAction(api.EventLifecycleInstanceRestarted)
 	InstanceRestored         = InstanceAction(api.EventLifecycleInstanceRestored)
@@ -0,0 +1,29 @@
+package lifecycle
+
+import (
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// ScheduledTaskAction represents a lifecycle event action for scheduled tasks.
+type ScheduledTaskAction string
+
+// All supported lifecycle events for scheduled tasks.
+const (
+	ScheduledTaskCreated = ScheduledTaskAction(api.EventLifecycleScheduledTaskCreated)
+	ScheduledTaskDeleted = ScheduledTaskAction(api.EventLifecycleScheduledTaskDeleted)
+	ScheduledTaskRun     = ScheduledTaskAction(api.EventLifecycleScheduledTaskRun)
+	ScheduledTaskUpdated = ScheduledTaskAction(api.EventLifecycleScheduledTaskUpdated)
+)
+
+// Event creates the lifecycle event for an action on a scheduled task.
+func (a ScheduledTaskAction) Event(name string, requestor *api.EventLifecycleRequestor, ctx map[string]any) api.EventLifecycle {
+	u := api.NewURL().Path(version.APIVersion, "scheduled-tasks", name)
+
+	return api.EventLifecycle{
+		Action:    string(a),
+		Source:    u.String(),
+		Context:   ctx,
+		Requestor: requestor,
+	}
+}
@@ -2,23 +2,41 @@ package logging
 
 import (
 	"encoding/json"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
 	clusterConfig "github.com/lxc/incus/v6/internal/server/cluster/config"
 	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
 )
 
+// eventTypeConsole is the type of the events holding a line of output of the console of an instance.
+// These events are only handed to the loggers, they aren't sent to the event listeners of the API.
+const eventTypeConsole = "console"
+
+// consoleEvent is the metadata of a console event.
+type consoleEvent struct {
+	Project string `json:"project"`
+	Name    string `json:"name"`
+	Line    string `json:"line"`
+}
+
 // Logger is an interface that must be implemented by all loggers.
 type Logger interface {
 	HandleEvent(event api.Event)
 	Start() error
 	Stop()
 	Validate() error
+
+	handlesConsole() bool
 }
 
 // common embeds shared configuration fields for all logger types.
 type common struct {
+	buffer            int
+	drops             *drops
 	lifecycleProjects []string
 	lifecycleTypes    []string
 	loggingLevel      string
@@ -26,11 +44,20 @@ type common struct {
 	types             []string
 }
 
+// drops counts the events dropped by a logger since the last warning about them.
+type drops struct {
+	mu          sync.Mutex
+	count       int
+	lastWarning time.Time
+}
+
 // newCommonLogger instantiates a new common logger.
 func newCommonLogger(name string, cfg *clusterConfig.Config) common {
 	lifecycleProjects, lifecycleTypes, loggingLevel, types := cfg.LoggingCommonConfig(name)
 
 	return common{
+		buffer:            cfg.LoggingBufferSize(name),
+		drops:             &drops{},
 		loggingLevel:      loggingLevel,
 		lifecycleProjects: sliceFromString(lifecycleProjects),
 		lifecycleTypes:    sliceFromString(lifecycleTypes),
@@ -91,7 +118,32 @@ func (c *common) processEvent(event api.Event) bool {
 		}
 
 		return true
+	case api.EventTypeAudit:
+		return contains(c.types, "audit")
+	case eventTypeConsole:
+		return contains(c.types, "console")
 	default:
 		return false
 	}
 }
+
+// handlesConsole returns whether the logger handles the console events.
+func (c *common) handlesConsole() bool {
+	return contains(c.types, "console")
+}
+
+// dropEvent records an event dropped because the buffer of the logger is full, warning about the dropped events
+// at most once a minute.
+func (c *common) dropEvent() {
+	c.drops.mu.Lock()
+	defer c.drops.mu.Unlock()
+
+	c.drops.count++
+	if time.Since(c.drops.lastWarning) < time.Minute {
+		return
+	}
+
+	logger.Warn("Dropping events as the logger target isn't keeping up", logger.Ctx{"logger": c.name, "dropped": c.drops.count})
+	c.drops.count = 0
+	c.drops.lastWarning = time.Now()
+}
@@ -1,10 +1,14 @@
 package logging
 
 import (
+	"encoding/json"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/lxc/incus/v6/internal/server/events"
 	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
 )
 
@@ -12,6 +16,7 @@ import (
 type Controller struct {
 	listener *events.InternalListener
 	loggers  map[string]Logger
+	lock     sync.Mutex
 }
 
 // NewLoggingController instantiates a new LoggerController object.
@@ -29,7 +34,10 @@ func (c *Controller) AddLogger(s *state.State, name string, loggerType string) e
 		return err
 	}
 
+	c.lock.Lock()
 	c.loggers[name] = loggerClient
+	c.lock.Unlock()
+
 	c.listener.AddHandler(name, loggerClient.HandleEvent)
 
 	return nil
@@ -37,11 +45,14 @@ func (c *Controller) AddLogger(s *state.State, name string, loggerType string) e
 
 // RemoveLogger removes a logger from the controller.
 func (c *Controller) RemoveLogger(name string) {
+	c.lock.Lock()
 	loggerClient, ok := c.loggers[name]
+	delete(c.loggers, name)
+	c.lock.Unlock()
+
 	if ok {
-		loggerClient.Stop()
-		delete(c.loggers, name)
 		c.listener.RemoveHandler(name)
+		loggerClient.Stop()
 	}
 }
 
@@ -88,11 +99,52 @@ func (c *Controller) Reconfigure(s *state.State, config map[string]struct{}) err
 
 // Shutdown cleans up loggers.
 func (c *Controller) Shutdown() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	for _, loggerClient := range c.loggers {
 		loggerClient.Stop()
 	}
 }
 
+// HandlesConsole returns whether any of the loggers handles the console events.
+func (c *Controller) HandlesConsole() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, loggerClient := range c.loggers {
+		if loggerClient.handlesConsole() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SendConsole sends the lines of output of the console of an instance to the loggers handling the console events.
+func (c *Controller) SendConsole(location string, projectName string, instanceName string, lines []string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, line := range lines {
+		metadata, err := json.Marshal(consoleEvent{Project: projectName, Name: instanceName, Line: line})
+		if err != nil {
+			continue
+		}
+
+		event := api.Event{
+			Type:      eventTypeConsole,
+			Timestamp: time.Now(),
+			Location:  location,
+			Metadata:  metadata,
+		}
+
+		for _, loggerClient := range c.loggers {
+			loggerClient.HandleEvent(event)
+		}
+	}
+}
+
 // LoggerFromType returns a new logger based on its type.
 func LoggerFromType(s *state.State, loggerName string, loggerType string) (Logger, error) {
 	if loggerType == "" {
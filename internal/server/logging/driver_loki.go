@@ -18,6 +18,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/lxc/incus/v6/internal/server/secrets"
 	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/shared/api"
 	localtls "github.com/lxc/incus/v6/shared/tls"
@@ -67,6 +68,11 @@ type LokiLogger struct {
 func NewLokiLogger(s *state.State, name string) (*LokiLogger, error) {
 	urlStr, username, password, caCert, instance, labels, retry := s.GlobalConfig.LoggingConfigForLoki(name)
 
+	password, err := secrets.Resolve(s.ShutdownCtx, password)
+	if err != nil {
+		return nil, fmt.Errorf("Failed resolving Loki password: %w", err)
+	}
+
 	// Validate the URL.
 	u, err := url.Parse(urlStr)
 	if err != nil {
@@ -89,8 +95,10 @@ func NewLokiLogger(s *state.State, name string) (*LokiLogger, error) {
 		instance = s.ServerName
 	}
 
+	common := newCommonLogger(name, s.GlobalConfig)
+
 	loggerClient := LokiLogger{
-		common: newCommonLogger(name, s.GlobalConfig),
+		common: common,
 		cfg: config{
 			batchSize: 10 * 1024,
 			batchWait: 1 * time.Second,
@@ -104,9 +112,9 @@ func NewLokiLogger(s *state.State, name string) (*LokiLogger, error) {
 			timeout:   10 * time.Second,
 			url:       u,
 		},
-		client:  &http.Client{},
+		client:  &http.Client{Transport: &http.Transport{Proxy: s.Proxy}},
 		ctx:     s.ShutdownCtx,
-		entries: make(chan entry),
+		entries: make(chan entry, common.buffer),
 		quit:    make(chan struct{}),
 	}
 
@@ -117,10 +125,9 @@ func NewLokiLogger(s *state.State, name string) (*LokiLogger, error) {
 		}
 
 		loggerClient.client.Transport = &http.Transport{
+			Proxy:           s.Proxy,
 			TLSClientConfig: tlsConfig,
 		}
-	} else {
-		loggerClient.client = http.DefaultClient
 	}
 
 	return &loggerClient, nil
@@ -206,7 +213,13 @@ func (l *LokiLogger) sendBatch(batch *batch) {
 			}
 
 			// Retry every 10s.
-			time.Sleep(10 * time.Second)
+			select {
+			case <-l.quit:
+				return
+			case <-l.ctx.Done():
+				return
+			case <-time.After(10 * time.Second):
+			}
 		}
 	}
 }
@@ -410,9 +423,37 @@ func (l *LokiLogger) HandleEvent(event api.Event) {
 		message.WriteString(logEvent.Message)
 
 		entry.Line = message.String()
+	case api.EventTypeAudit:
+		auditEntry := api.AuditEntry{}
+
+		err := json.Unmarshal(event.Metadata, &auditEntry)
+		if err != nil {
+			return
+		}
+
+		entry.labels["result"] = auditEntry.Result
+
+		entry.Line = fmt.Sprintf("username=%q protocol=%q address=%q status_code=\"%d\" hash=%q %s %s", auditEntry.Username, auditEntry.Protocol, auditEntry.Address, auditEntry.StatusCode, auditEntry.Hash, auditEntry.Method, auditEntry.URL)
+	case eventTypeConsole:
+		console := consoleEvent{}
+
+		err := json.Unmarshal(event.Metadata, &console)
+		if err != nil {
+			return
+		}
+
+		entry.labels["name"] = console.Name
+		entry.labels["project"] = console.Project
+
+		entry.Line = console.Line
 	}
 
-	l.entries <- entry
+	// Drop the entry rather than blocking when the buffer is full.
+	select {
+	case l.entries <- entry:
+	default:
+		l.dropEvent()
+	}
 }
 
 func buildNestedContext(prefix string, m map[string]any) map[string]string {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/syslog"
 	"strings"
+	"sync"
 
 	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/shared/api"
@@ -33,27 +34,47 @@ type SyslogLogger struct {
 	network  string
 	tag      string
 	writer   *syslog.Writer
+	events   chan api.Event
+	quit     chan struct{}
+	once     sync.Once
+	wg       sync.WaitGroup
 }
 
 // NewSyslogLogger instantiates a new syslog logger.
 func NewSyslogLogger(s *state.State, name string) (*SyslogLogger, error) {
 	addr, facility := s.GlobalConfig.LoggingConfigForSyslog(name)
 	network, address := parseAddress(addr)
+	common := newCommonLogger(name, s.GlobalConfig)
 
 	return &SyslogLogger{
-		common:   newCommonLogger(name, s.GlobalConfig),
+		common:   common,
 		address:  address,
 		facility: parseFacility(facility),
 		network:  network,
 		tag:      "incus",
+		events:   make(chan api.Event, common.buffer),
+		quit:     make(chan struct{}),
 	}, nil
 }
 
 func (c *SyslogLogger) write(event api.Event) error {
 	msg := fmt.Sprintf("type: %s log: %s", event.Type, string(event.Metadata))
+	if event.Location != "" {
+		msg = fmt.Sprintf("type: %s location: %s log: %s", event.Type, event.Location, string(event.Metadata))
+	}
+
 	lvl := "info"
 
-	if event.Type == api.EventTypeLogging {
+	if event.Type == eventTypeConsole {
+		console := consoleEvent{}
+
+		err := json.Unmarshal(event.Metadata, &console)
+		if err != nil {
+			return err
+		}
+
+		msg = fmt.Sprintf("type: %s location: %s project: %s instance: %s log: %s", event.Type, event.Location, console.Project, console.Name, console.Line)
+	} else if event.Type == api.EventTypeLogging {
 		logEvent := api.EventLogging{}
 
 		err := json.Unmarshal(event.Metadata, &logEvent)
@@ -90,7 +111,26 @@ func (c *SyslogLogger) HandleEvent(event api.Event) {
 		return
 	}
 
-	_ = c.write(event)
+	// Drop the event rather than blocking when the buffer is full.
+	select {
+	case c.events <- event:
+	default:
+		c.dropEvent()
+	}
+}
+
+func (c *SyslogLogger) run() {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case <-c.quit:
+			return
+
+		case event := <-c.events:
+			_ = c.write(event)
+		}
+	}
 }
 
 // Start starts the syslog logger.
@@ -101,11 +141,18 @@ func (c *SyslogLogger) Start() error {
 	}
 
 	c.writer = writer
+
+	c.wg.Add(1)
+	go c.run()
+
 	return nil
 }
 
 // Stop cleans up the syslog logger.
 func (c *SyslogLogger) Stop() {
+	c.once.Do(func() { close(c.quit) })
+	c.wg.Wait()
+
 	if c.writer != nil {
 		_ = c.writer.Close()
 	}
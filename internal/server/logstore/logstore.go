@@ -0,0 +1,360 @@
+package logstore
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// Retention is how long the messages are kept in the store.
+const Retention = 7 * 24 * time.Hour
+
+// segmentLayout is the time layout of the names of the segments, each holding the messages of an hour.
+const segmentLayout = "2006-01-02T15"
+
+// segmentSuffix is the file name suffix of the segments.
+const segmentSuffix = ".log"
+
+// indexName is the file name of the index of the segments.
+const indexName = "index.json"
+
+// Filter restricts the messages returned by Read.
+type Filter struct {
+	// Least severe level of the messages.
+	Level logrus.Level
+	Since time.Time
+	Until time.Time
+}
+
+// match returns whether the message matches the filter.
+func (f Filter) match(entry api.ServerLogEntry) bool {
+	level, err := logrus.ParseLevel(entry.Level)
+	if err != nil || level > f.Level {
+		return false
+	}
+
+	if !f.Since.IsZero() && entry.Timestamp.Before(f.Since) {
+		return false
+	}
+
+	if !f.Until.IsZero() && entry.Timestamp.After(f.Until) {
+		return false
+	}
+
+	return true
+}
+
+// Store is an on-disk store of the log messages of the server.
+//
+// The messages are written to a segment file per hour, named after the hour. The index records the most severe
+// level of the messages of each segment, so that reads skip the segments outside of the requested period or
+// without any message of the requested levels.
+type Store struct {
+	mu      sync.Mutex
+	path    string
+	level   logrus.Level
+	file    *os.File
+	segment string
+	index   map[string]string
+}
+
+// NewStore returns a store of the messages of the given level, or more severe, in the given directory.
+// The directory is only created when the first message is recorded.
+func NewStore(path string, level logrus.Level) *Store {
+	return &Store{path: path, level: level}
+}
+
+// HandleEvent records the message of a logging event.
+func (s *Store) HandleEvent(event api.Event) {
+	if event.Type != api.EventTypeLogging {
+		return
+	}
+
+	logEvent := api.EventLogging{}
+
+	err := json.Unmarshal(event.Metadata, &logEvent)
+	if err != nil {
+		return
+	}
+
+	level, err := logrus.ParseLevel(logEvent.Level)
+	if err != nil || level > s.level {
+		return
+	}
+
+	// The events are logged as they're recorded, so a failure is only reported by the returned error.
+	_ = s.Append(api.ServerLogEntry{
+		Timestamp: event.Timestamp,
+		Level:     level.String(),
+		Message:   logEvent.Message,
+		Context:   logEvent.Context,
+	})
+}
+
+// Append records a message in the segment of its hour.
+func (s *Store) Append(entry api.ServerLogEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.loadIndex()
+	if err != nil {
+		return err
+	}
+
+	entry.Timestamp = entry.Timestamp.UTC()
+	segment := entry.Timestamp.Format(segmentLayout)
+
+	if s.file == nil || s.segment != segment {
+		err := s.openSegment(segment)
+		if err != nil {
+			return err
+		}
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.file.Write(append(line, '\n'))
+	if err != nil {
+		return fmt.Errorf("Failed writing log store segment: %w", err)
+	}
+
+	// Record the most severe level of the segment.
+	level, _ := logrus.ParseLevel(entry.Level)
+	previous, err := logrus.ParseLevel(s.index[segment])
+	if err != nil || level < previous {
+		s.index[segment] = level.String()
+
+		return s.saveIndex()
+	}
+
+	return nil
+}
+
+// Read returns the messages matching the filter, ordered by time.
+func (s *Store) Read(filter Filter) ([]api.ServerLogEntry, error) {
+	s.mu.Lock()
+	err := s.loadIndex()
+	index := make(map[string]string, len(s.index))
+	for segment, level := range s.index {
+		index[segment] = level
+	}
+
+	s.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []api.ServerLogEntry{}
+
+	for _, segment := range s.segments() {
+		start, err := time.Parse(segmentLayout, segment)
+		if err != nil {
+			continue
+		}
+
+		if !filter.Since.IsZero() && start.Add(time.Hour).Before(filter.Since) {
+			continue
+		}
+
+		if !filter.Until.IsZero() && start.After(filter.Until) {
+			continue
+		}
+
+		level, err := logrus.ParseLevel(index[segment])
+		if err == nil && level > filter.Level {
+			continue
+		}
+
+		err = s.scan(segment, func(entry api.ServerLogEntry) {
+			if filter.match(entry) {
+				entries = append(entries, entry)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The messages are recorded as they come, which may slightly differ from the order they were logged in.
+	slices.SortStableFunc(entries, func(a api.ServerLogEntry, b api.ServerLogEntry) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	return entries, nil
+}
+
+// Close closes the current segment.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+
+	err := s.file.Close()
+	s.file = nil
+
+	return err
+}
+
+// openSegment opens the segment to append to, and removes the segments past the retention period.
+func (s *Store) openSegment(segment string) error {
+	if s.file != nil {
+		_ = s.file.Close()
+		s.file = nil
+	}
+
+	err := os.MkdirAll(s.path, 0o700)
+	if err != nil {
+		return fmt.Errorf("Failed creating log store: %w", err)
+	}
+
+	s.file, err = os.OpenFile(filepath.Join(s.path, segment+segmentSuffix), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("Failed opening log store segment: %w", err)
+	}
+
+	s.segment = segment
+
+	return s.expire(time.Now().Add(-Retention))
+}
+
+// expire removes the segments of the hours before the given time.
+func (s *Store) expire(before time.Time) error {
+	expired := false
+
+	for _, segment := range s.segments() {
+		start, err := time.Parse(segmentLayout, segment)
+		if err != nil || !start.Add(time.Hour).Before(before) {
+			continue
+		}
+
+		err = os.Remove(filepath.Join(s.path, segment+segmentSuffix))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("Failed removing log store segment: %w", err)
+		}
+
+		delete(s.index, segment)
+		expired = true
+	}
+
+	if expired {
+		return s.saveIndex()
+	}
+
+	return nil
+}
+
+// segments returns the names of the segments, in order.
+func (s *Store) segments() []string {
+	files, err := os.ReadDir(s.path)
+	if err != nil {
+		return nil
+	}
+
+	segments := []string{}
+	for _, file := range files {
+		segment, ok := strings.CutSuffix(file.Name(), segmentSuffix)
+		if ok && !file.IsDir() {
+			segments = append(segments, segment)
+		}
+	}
+
+	// The names sort in time order.
+	slices.Sort(segments)
+
+	return segments
+}
+
+// scan calls the given function for each message of a segment.
+func (s *Store) scan(segment string, f func(entry api.ServerLogEntry)) error {
+	file, err := os.Open(filepath.Join(s.path, segment+segmentSuffix))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("Failed opening log store segment: %w", err)
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		entry := api.ServerLogEntry{}
+
+		// Skip the lines left incomplete by a crash.
+		err := json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			continue
+		}
+
+		f(entry)
+	}
+
+	return scanner.Err()
+}
+
+// loadIndex loads the index, if not already loaded.
+func (s *Store) loadIndex() error {
+	if s.index != nil {
+		return nil
+	}
+
+	index := map[string]string{}
+
+	data, err := os.ReadFile(filepath.Join(s.path, indexName))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("Failed reading log store index: %w", err)
+	}
+
+	// A missing or damaged index only causes all the segments to be read.
+	if err == nil {
+		_ = json.Unmarshal(data, &index)
+	}
+
+	s.index = index
+
+	return nil
+}
+
+// saveIndex atomically writes the index.
+func (s *Store) saveIndex() error {
+	data, err := json.Marshal(s.index)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.path, indexName)
+
+	err = os.WriteFile(path+".tmp", data, 0o600)
+	if err != nil {
+		return fmt.Errorf("Failed writing log store index: %w", err)
+	}
+
+	err = os.Rename(path+".tmp", path)
+	if err != nil {
+		return fmt.Errorf("Failed writing log store index: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package logstore_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/internal/server/logstore"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs")
+	now := time.Now().UTC().Truncate(time.Hour)
+
+	s := logstore.NewStore(path, logrus.InfoLevel)
+	for i, level := range []string{"info", "warning", "error", "info"} {
+		err := s.Append(api.ServerLogEntry{Timestamp: now.Add(-time.Duration(3-i) * time.Hour), Level: level, Message: level})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, s.Close())
+
+	// One segment per hour, plus the index.
+	files, err := os.ReadDir(path)
+	require.NoError(t, err)
+	assert.Len(t, files, 5)
+
+	entries, err := s.Read(logstore.Filter{Level: logrus.InfoLevel})
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	assert.Equal(t, "info", entries[0].Message)
+	assert.Equal(t, "error", entries[2].Message)
+
+	entries, err = s.Read(logstore.Filter{Level: logrus.WarnLevel})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "warning", entries[0].Message)
+
+	entries, err = s.Read(logstore.Filter{Level: logrus.InfoLevel, Since: now.Add(-90 * time.Minute)})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "error", entries[0].Message)
+
+	entries, err = s.Read(logstore.Filter{Level: logrus.InfoLevel, Until: now.Add(-150 * time.Minute)})
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// Segments past the retention period are removed.
+	s = logstore.NewStore(path, logrus.InfoLevel)
+	require.NoError(t, s.Append(api.ServerLogEntry{Timestamp: now.Add(-logstore.Retention - 2*time.Hour), Level: "error", Message: "old"}))
+	require.NoError(t, s.Append(api.ServerLogEntry{Timestamp: now, Level: "error", Message: "new"}))
+	require.NoError(t, s.Close())
+
+	entries, err = s.Read(logstore.Filter{Level: logrus.ErrorLevel})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "error", entries[0].Message)
+	assert.Equal(t, "new", entries[1].Message)
+}
@@ -9,6 +9,8 @@ type Metrics struct {
 	Memory         MemoryMetrics       `json:"memory" yaml:"memory"`
 	Network        []NetworkMetrics    `json:"network" yaml:"network"`
 	ProcessesTotal uint64              `json:"procs_total" yaml:"procs_total"`
+	Processes      []ProcessMetrics    `json:"processes,omitempty" yaml:"processes,omitempty"`
+	Services       *ServicesMetrics    `json:"services,omitempty" yaml:"services,omitempty"`
 }
 
 // CPUMetrics represents CPU metrics for an instance.
@@ -79,3 +81,17 @@ type NetworkMetrics struct {
 	TransmitErrors  uint64 `json:"network_transmit_errs" yaml:"network_transmit_errs"`
 	TransmitPackets uint64 `json:"network_transmit_packets" yaml:"network_transmit_packets"`
 }
+
+// ProcessMetrics represents the metrics of a process of an instance.
+type ProcessMetrics struct {
+	PID         int64   `json:"pid" yaml:"pid"`
+	Name        string  `json:"name" yaml:"name"`
+	CPUSeconds  float64 `json:"cpu_seconds" yaml:"cpu_seconds"`
+	MemoryBytes uint64  `json:"memory_bytes" yaml:"memory_bytes"`
+}
+
+// ServicesMetrics represents the metrics of the systemd units of an instance.
+type ServicesMetrics struct {
+	Units  uint64 `json:"units" yaml:"units"`
+	Failed uint64 `json:"failed" yaml:"failed"`
+}
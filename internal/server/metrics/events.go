@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// eventKey identifies a counter by the lifecycle action, or operation description, and the project.
+type eventKey struct {
+	name    string
+	project string
+}
+
+// EventCounters counts the lifecycle events and the failed operations of the server since it started.
+type EventCounters struct {
+	mu        sync.Mutex
+	lifecycle map[eventKey]uint64
+	failures  map[eventKey]uint64
+}
+
+// NewEventCounters returns a new EventCounters.
+func NewEventCounters() *EventCounters {
+	return &EventCounters{
+		lifecycle: map[eventKey]uint64{},
+		failures:  map[eventKey]uint64{},
+	}
+}
+
+// HandleEvent counts the lifecycle events and the operation events reporting a failure.
+func (c *EventCounters) HandleEvent(event api.Event) {
+	switch event.Type {
+	case api.EventTypeLifecycle:
+		lifecycleEvent := api.EventLifecycle{}
+
+		err := json.Unmarshal(event.Metadata, &lifecycleEvent)
+		if err != nil || lifecycleEvent.Action == "" {
+			return
+		}
+
+		c.mu.Lock()
+		c.lifecycle[eventKey{name: lifecycleEvent.Action, project: lifecycleEvent.Project}]++
+		c.mu.Unlock()
+	case api.EventTypeOperation:
+		op := api.Operation{}
+
+		err := json.Unmarshal(event.Metadata, &op)
+		if err != nil || op.StatusCode != api.Failure {
+			return
+		}
+
+		c.mu.Lock()
+		c.failures[eventKey{name: op.Description, project: event.Project}]++
+		c.mu.Unlock()
+	}
+}
+
+// MetricSet returns the counters as a MetricSet, labelled with the given cluster member.
+func (c *EventCounters) MetricSet(location string) *MetricSet {
+	out := NewMetricSet(map[string]string{"location": location})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, value := range c.lifecycle {
+		out.AddSamples(LifecycleEventsTotal, Sample{Value: float64(value), Labels: map[string]string{"action": key.name, "project": key.project}})
+	}
+
+	for key, value := range c.failures {
+		out.AddSamples(OperationsFailedTotal, Sample{Value: float64(value), Labels: map[string]string{"operation": key.name, "project": key.project}})
+	}
+
+	return out
+}
@@ -9,6 +9,10 @@ import (
 	"github.com/lxc/incus/v6/internal/server/auth"
 )
 
+// labelValueEscaper escapes the label values as specified by OpenMetrics, as some of them come from within the
+// instances (like process names).
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
 // NewMetricSet returns a new MetricSet.
 func NewMetricSet(labels map[string]string) *MetricSet {
 	out := MetricSet{set: make(map[MetricType][]Sample)}
@@ -118,14 +122,19 @@ func (m *MetricSet) String() string {
 		metricTypeName := ""
 
 		// ProcsTotal is a gauge according to the OpenMetrics spec as its value can decrease.
-		if metricType == ProcsTotal || metricType == CPUs || metricType == GoGoroutines || metricType == GoHeapObjects {
+		if metricType == ProcsTotal || metricType == CPUs || metricType == GoGoroutines || metricType == GoHeapObjects || metricType == SystemdUnits || metricType == SystemdUnitsFailed || metricType == StartDurationSeconds || metricType == AgentStartDurationSeconds {
 			metricTypeName = "gauge"
 		} else if strings.HasSuffix(MetricNames[metricType], "_total") || strings.HasSuffix(MetricNames[metricType], "_seconds") {
 			metricTypeName = "counter"
-		} else if strings.HasSuffix(MetricNames[metricType], "_bytes") {
+		} else if strings.HasSuffix(MetricNames[metricType], "_bytes") || strings.HasSuffix(MetricNames[metricType], "_ratio") {
 			metricTypeName = "gauge"
 		}
 
+		// The request durations are written as the bucket, sum and count series of a histogram.
+		if metricType == APIRequestDurationSeconds {
+			metricTypeName = "histogram"
+		}
+
 		// Add TYPE message as specified by OpenMetrics
 		_, err = out.WriteString(fmt.Sprintf("# TYPE %s %s\n", MetricNames[metricType], metricTypeName))
 		if err != nil {
@@ -149,16 +158,16 @@ func (m *MetricSet) String() string {
 					labels += ","
 				}
 
-				labels += fmt.Sprintf(`%s="%s"`, labelName, sample.Labels[labelName])
+				labels += fmt.Sprintf(`%s="%s"`, labelName, labelValueEscaper.Replace(sample.Labels[labelName]))
 				firstLabel = false
 			}
 
 			valueStr := strconv.FormatFloat(sample.Value, 'g', -1, 64)
 
 			if labels != "" {
-				_, err = out.WriteString(fmt.Sprintf("%s{%s} %s\n", MetricNames[metricType], labels, valueStr))
+				_, err = out.WriteString(fmt.Sprintf("%s%s{%s} %s\n", MetricNames[metricType], sample.Suffix, labels, valueStr))
 			} else {
-				_, err = out.WriteString(fmt.Sprintf("%s %s\n", MetricNames[metricType], valueStr))
+				_, err = out.WriteString(fmt.Sprintf("%s%s %s\n", MetricNames[metricType], sample.Suffix, valueStr))
 			}
 
 			if err != nil {
@@ -298,5 +307,19 @@ func MetricSetFromAPI(metrics *Metrics, labels map[string]string) (*MetricSet, e
 	// Procs stats
 	set.AddSamples(ProcsTotal, Sample{Value: float64(metrics.ProcessesTotal)})
 
+	// Process stats
+	for _, stats := range metrics.Processes {
+		labels := map[string]string{"pid": strconv.FormatInt(stats.PID, 10), "process": stats.Name}
+
+		set.AddSamples(ProcessCPUSecondsTotal, Sample{Value: stats.CPUSeconds, Labels: labels})
+		set.AddSamples(ProcessMemoryBytes, Sample{Value: float64(stats.MemoryBytes), Labels: labels})
+	}
+
+	// Services stats
+	if metrics.Services != nil {
+		set.AddSamples(SystemdUnits, Sample{Value: float64(metrics.Services.Units)})
+		set.AddSamples(SystemdUnitsFailed, Sample{Value: float64(metrics.Services.Failed)})
+	}
+
 	return set, nil
 }
@@ -2,10 +2,12 @@ package metrics
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/shared/api"
 )
 
 func TestMetricSet_FilterSamples(t *testing.T) {
@@ -56,3 +58,52 @@ func TestMetricSet_FilterSamples(t *testing.T) {
 		require.Contains(t, hasKeys, "project")
 	}
 }
+
+func TestMetricSetFromAPI_Processes(t *testing.T) {
+	m, err := MetricSetFromAPI(&Metrics{
+		Processes: []ProcessMetrics{{PID: 42, Name: `a"b\c`, CPUSeconds: 1.5, MemoryBytes: 1024}},
+		Services:  &ServicesMetrics{Units: 200, Failed: 2},
+	}, map[string]string{"project": "default", "name": "vm1"})
+	require.NoError(t, err)
+
+	out := m.String()
+
+	// Label values coming from the instance are escaped.
+	require.Contains(t, out, `incus_process_cpu_seconds_total{name="vm1",pid="42",process="a\"b\\c",project="default"} 1.5`)
+	require.Contains(t, out, `incus_process_memory_bytes{name="vm1",pid="42",process="a\"b\\c",project="default"} 1024`)
+	require.Contains(t, out, "# TYPE incus_systemd_units_failed gauge\n")
+	require.Contains(t, out, `incus_systemd_units_failed{name="vm1",project="default"} 2`)
+}
+
+func TestEventCounters(t *testing.T) {
+	c := NewEventCounters()
+
+	for _, action := range []string{"instance-started", "instance-started", "instance-stopped"} {
+		c.HandleEvent(api.Event{Type: api.EventTypeLifecycle, Metadata: []byte(`{"action": "` + action + `", "project": "default"}`)})
+	}
+
+	c.HandleEvent(api.Event{Type: api.EventTypeOperation, Project: "default", Metadata: []byte(`{"description": "Backing up instance", "status_code": 200}`)})
+	c.HandleEvent(api.Event{Type: api.EventTypeOperation, Project: "default", Metadata: []byte(`{"description": "Backing up instance", "status_code": 400}`)})
+
+	m := c.MetricSet("server01")
+	require.ElementsMatch(t, []Sample{
+		{Value: 2, Labels: map[string]string{"action": "instance-started", "project": "default", "location": "server01"}},
+		{Value: 1, Labels: map[string]string{"action": "instance-stopped", "project": "default", "location": "server01"}},
+	}, m.set[LifecycleEventsTotal])
+	require.Equal(t, []Sample{{Value: 1, Labels: map[string]string{"operation": "Backing up instance", "project": "default", "location": "server01"}}}, m.set[OperationsFailedTotal])
+}
+
+func TestRequestMetrics(t *testing.T) {
+	m := NewRequestMetrics()
+	m.Observe("GET", "/1.0/instances", 200, 20*time.Millisecond)
+	m.Observe("GET", "/1.0/instances", 500, 2*time.Second)
+
+	out := m.MetricSet("server01").String()
+	require.Contains(t, out, "# TYPE incus_api_request_duration_seconds histogram\n")
+	require.Contains(t, out, `incus_api_requests_total{code="500",endpoint="/1.0/instances",location="server01",method="GET"} 1`)
+	require.Contains(t, out, `incus_api_request_duration_seconds_bucket{endpoint="/1.0/instances",le="0.025",location="server01",method="GET"} 1`)
+	require.Contains(t, out, `incus_api_request_duration_seconds_bucket{endpoint="/1.0/instances",le="2.5",location="server01",method="GET"} 2`)
+	require.Contains(t, out, `incus_api_request_duration_seconds_bucket{endpoint="/1.0/instances",le="+Inf",location="server01",method="GET"} 2`)
+	require.Contains(t, out, `incus_api_request_duration_seconds_sum{endpoint="/1.0/instances",location="server01",method="GET"} 2.02`)
+	require.Contains(t, out, `incus_api_request_duration_seconds_count{endpoint="/1.0/instances",location="server01",method="GET"} 2`)
+}
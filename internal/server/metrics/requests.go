@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RequestBuckets are the upper bounds, in seconds, of the buckets of the histogram of the API request durations.
+var RequestBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// requestKey identifies the requests of an endpoint.
+type requestKey struct {
+	method   string
+	endpoint string
+}
+
+// requestHistogram records the durations of the requests of an endpoint.
+type requestHistogram struct {
+	buckets []uint64
+	sum     float64
+	count   uint64
+}
+
+// RequestMetrics counts the API requests of the server since it started, and records their durations.
+type RequestMetrics struct {
+	mu        sync.Mutex
+	counts    map[requestKey]map[int]uint64
+	durations map[requestKey]*requestHistogram
+}
+
+// NewRequestMetrics returns a new RequestMetrics.
+func NewRequestMetrics() *RequestMetrics {
+	return &RequestMetrics{
+		counts:    map[requestKey]map[int]uint64{},
+		durations: map[requestKey]*requestHistogram{},
+	}
+}
+
+// Observe records a request to the endpoint with the given path pattern, along with its status code and duration.
+func (m *RequestMetrics) Observe(method string, endpoint string, statusCode int, duration time.Duration) {
+	key := requestKey{method: method, endpoint: endpoint}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counts, ok := m.counts[key]
+	if !ok {
+		counts = map[int]uint64{}
+		m.counts[key] = counts
+	}
+
+	counts[statusCode]++
+
+	histogram, ok := m.durations[key]
+	if !ok {
+		histogram = &requestHistogram{buckets: make([]uint64, len(RequestBuckets))}
+		m.durations[key] = histogram
+	}
+
+	// The buckets are cumulative, each counting the requests up to its bound.
+	seconds := duration.Seconds()
+	for i, bound := range RequestBuckets {
+		if seconds <= bound {
+			histogram.buckets[i]++
+		}
+	}
+
+	histogram.sum += seconds
+	histogram.count++
+}
+
+// MetricSet returns the request counts and durations as a MetricSet, labelled with the given cluster member.
+func (m *RequestMetrics) MetricSet(location string) *MetricSet {
+	out := NewMetricSet(map[string]string{"location": location})
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, counts := range m.counts {
+		for statusCode, value := range counts {
+			out.AddSamples(APIRequestsTotal, Sample{Value: float64(value), Labels: map[string]string{"method": key.method, "endpoint": key.endpoint, "code": strconv.Itoa(statusCode)}})
+		}
+	}
+
+	for key, histogram := range m.durations {
+		labels := func() map[string]string {
+			return map[string]string{"method": key.method, "endpoint": key.endpoint}
+		}
+
+		for i, bound := range RequestBuckets {
+			bucketLabels := labels()
+			bucketLabels["le"] = strconv.FormatFloat(bound, 'g', -1, 64)
+			out.AddSamples(APIRequestDurationSeconds, Sample{Value: float64(histogram.buckets[i]), Labels: bucketLabels, Suffix: "_bucket"})
+		}
+
+		bucketLabels := labels()
+		bucketLabels["le"] = "+Inf"
+		out.AddSamples(APIRequestDurationSeconds, Sample{Value: float64(histogram.count), Labels: bucketLabels, Suffix: "_bucket"})
+		out.AddSamples(APIRequestDurationSeconds, Sample{Value: histogram.sum, Labels: labels(), Suffix: "_sum"})
+		out.AddSamples(APIRequestDurationSeconds, Sample{Value: float64(histogram.count), Labels: labels(), Suffix: "_count"})
+	}
+
+	return out
+}
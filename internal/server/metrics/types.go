@@ -4,6 +4,9 @@ package metrics
 type Sample struct {
 	Labels map[string]string
 	Value  float64
+
+	// Suffix is appended to the metric name, for the bucket, sum and count series of the histograms.
+	Suffix string
 }
 
 // MetricSet represents a set of metrics.
@@ -91,12 +94,64 @@ const (
 	NetworkTransmitErrsTotal
 	// NetworkTransmitPacketsTotal represents the amount of transmitted packets on a given interface.
 	NetworkTransmitPacketsTotal
+	// DeviceDiskReadBytesTotal represents the read bytes for a disk device.
+	DeviceDiskReadBytesTotal
+	// DeviceDiskReadsCompletedTotal represents the completed reads for a disk device.
+	DeviceDiskReadsCompletedTotal
+	// DeviceDiskWrittenBytesTotal represents the written bytes for a disk device.
+	DeviceDiskWrittenBytesTotal
+	// DeviceDiskWritesCompletedTotal represents the completed writes for a disk device.
+	DeviceDiskWritesCompletedTotal
+	// DeviceNetworkReceiveBytesTotal represents the amount of received bytes on a NIC device.
+	DeviceNetworkReceiveBytesTotal
+	// DeviceNetworkReceiveDropTotal represents the amount of received dropped packets on a NIC device.
+	DeviceNetworkReceiveDropTotal
+	// DeviceNetworkReceiveErrsTotal represents the amount of received errors on a NIC device.
+	DeviceNetworkReceiveErrsTotal
+	// DeviceNetworkReceivePacketsTotal represents the amount of received packets on a NIC device.
+	DeviceNetworkReceivePacketsTotal
+	// DeviceNetworkTransmitBytesTotal represents the amount of transmitted bytes on a NIC device.
+	DeviceNetworkTransmitBytesTotal
+	// DeviceNetworkTransmitDropTotal represents the amount of transmitted dropped packets on a NIC device.
+	DeviceNetworkTransmitDropTotal
+	// DeviceNetworkTransmitErrsTotal represents the amount of transmitted errors on a NIC device.
+	DeviceNetworkTransmitErrsTotal
+	// DeviceNetworkTransmitPacketsTotal represents the amount of transmitted packets on a NIC device.
+	DeviceNetworkTransmitPacketsTotal
+	// DeviceGPUUtilizationRatio represents the utilization of the GPU of a GPU device.
+	DeviceGPUUtilizationRatio
+	// DeviceGPUMemoryUsedBytes represents the used memory of the GPU of a GPU device.
+	DeviceGPUMemoryUsedBytes
+	// DeviceGPUMemoryTotalBytes represents the total memory of the GPU of a GPU device.
+	DeviceGPUMemoryTotalBytes
 	// ProcsTotal represents the number of running processes.
 	ProcsTotal
+	// ProcessCPUSecondsTotal represents the CPU seconds used by a process.
+	ProcessCPUSecondsTotal
+	// ProcessMemoryBytes represents the resident memory of a process.
+	ProcessMemoryBytes
+	// SystemdUnits represents the number of loaded systemd units.
+	SystemdUnits
+	// SystemdUnitsFailed represents the number of failed systemd units.
+	SystemdUnitsFailed
+	// StartDurationSeconds represents the time taken by the last start of the instance.
+	StartDurationSeconds
+	// AgentStartDurationSeconds represents the time taken by the agent to start after the virtual machine.
+	AgentStartDurationSeconds
+	// RunningSecondsTotal represents the cumulative time the instance has been running for.
+	RunningSecondsTotal
 	// OperationsTotal represents the number of running operations.
 	OperationsTotal
 	// WarningsTotal represents the number of active warnings.
 	WarningsTotal
+	// LifecycleEventsTotal represents the number of lifecycle events.
+	LifecycleEventsTotal
+	// OperationsFailedTotal represents the number of failed operations.
+	OperationsFailedTotal
+	// APIRequestsTotal represents the number of API requests.
+	APIRequestsTotal
+	// APIRequestDurationSeconds represents the histogram of the durations of the API requests.
+	APIRequestDurationSeconds
 	// UptimeSeconds represents the daemon uptime in seconds.
 	UptimeSeconds
 	// GoGoroutines represents the number of goroutines that currently exist..
@@ -149,136 +204,188 @@ const (
 
 // MetricNames associates a metric type to its name.
 var MetricNames = map[MetricType]string{
-	CPUSecondsTotal:             "incus_cpu_seconds_total",
-	CPUs:                        "incus_cpu_effective_total",
-	DiskReadBytesTotal:          "incus_disk_read_bytes_total",
-	DiskReadsCompletedTotal:     "incus_disk_reads_completed_total",
-	DiskWrittenBytesTotal:       "incus_disk_written_bytes_total",
-	DiskWritesCompletedTotal:    "incus_disk_writes_completed_total",
-	FilesystemAvailBytes:        "incus_filesystem_avail_bytes",
-	FilesystemFreeBytes:         "incus_filesystem_free_bytes",
-	FilesystemSizeBytes:         "incus_filesystem_size_bytes",
-	GoAllocBytes:                "incus_go_alloc_bytes",
-	GoAllocBytesTotal:           "incus_go_alloc_bytes_total",
-	GoBuckHashSysBytes:          "incus_go_buck_hash_sys_bytes",
-	GoFreesTotal:                "incus_go_frees_total",
-	GoGCSysBytes:                "incus_go_gc_sys_bytes",
-	GoGoroutines:                "incus_go_goroutines",
-	GoHeapAllocBytes:            "incus_go_heap_alloc_bytes",
-	GoHeapIdleBytes:             "incus_go_heap_idle_bytes",
-	GoHeapInuseBytes:            "incus_go_heap_inuse_bytes",
-	GoHeapObjects:               "incus_go_heap_objects",
-	GoHeapReleasedBytes:         "incus_go_heap_released_bytes",
-	GoHeapSysBytes:              "incus_go_heap_sys_bytes",
-	GoLookupsTotal:              "incus_go_lookups_total",
-	GoMallocsTotal:              "incus_go_mallocs_total",
-	GoMCacheInuseBytes:          "incus_go_mcache_inuse_bytes",
-	GoMCacheSysBytes:            "incus_go_mcache_sys_bytes",
-	GoMSpanInuseBytes:           "incus_go_mspan_inuse_bytes",
-	GoMSpanSysBytes:             "incus_go_mspan_sys_bytes",
-	GoNextGCBytes:               "incus_go_next_gc_bytes",
-	GoOtherSysBytes:             "incus_go_other_sys_bytes",
-	GoStackInuseBytes:           "incus_go_stack_inuse_bytes",
-	GoStackSysBytes:             "incus_go_stack_sys_bytes",
-	GoSysBytes:                  "incus_go_sys_bytes",
-	MemoryActiveAnonBytes:       "incus_memory_Active_anon_bytes",
-	MemoryActiveFileBytes:       "incus_memory_Active_file_bytes",
-	MemoryActiveBytes:           "incus_memory_Active_bytes",
-	MemoryCachedBytes:           "incus_memory_Cached_bytes",
-	MemoryDirtyBytes:            "incus_memory_Dirty_bytes",
-	MemoryHugePagesFreeBytes:    "incus_memory_HugepagesFree_bytes",
-	MemoryHugePagesTotalBytes:   "incus_memory_HugepagesTotal_bytes",
-	MemoryInactiveAnonBytes:     "incus_memory_Inactive_anon_bytes",
-	MemoryInactiveFileBytes:     "incus_memory_Inactive_file_bytes",
-	MemoryInactiveBytes:         "incus_memory_Inactive_bytes",
-	MemoryMappedBytes:           "incus_memory_Mapped_bytes",
-	MemoryMemAvailableBytes:     "incus_memory_MemAvailable_bytes",
-	MemoryMemFreeBytes:          "incus_memory_MemFree_bytes",
-	MemoryMemTotalBytes:         "incus_memory_MemTotal_bytes",
-	MemoryRSSBytes:              "incus_memory_RSS_bytes",
-	MemoryShmemBytes:            "incus_memory_Shmem_bytes",
-	MemorySwapBytes:             "incus_memory_Swap_bytes",
-	MemoryUnevictableBytes:      "incus_memory_Unevictable_bytes",
-	MemoryWritebackBytes:        "incus_memory_Writeback_bytes",
-	MemoryOOMKillsTotal:         "incus_memory_OOM_kills_total",
-	NetworkReceiveBytesTotal:    "incus_network_receive_bytes_total",
-	NetworkReceiveDropTotal:     "incus_network_receive_drop_total",
-	NetworkReceiveErrsTotal:     "incus_network_receive_errs_total",
-	NetworkReceivePacketsTotal:  "incus_network_receive_packets_total",
-	NetworkTransmitBytesTotal:   "incus_network_transmit_bytes_total",
-	NetworkTransmitDropTotal:    "incus_network_transmit_drop_total",
-	NetworkTransmitErrsTotal:    "incus_network_transmit_errs_total",
-	NetworkTransmitPacketsTotal: "incus_network_transmit_packets_total",
-	OperationsTotal:             "incus_operations_total",
-	ProcsTotal:                  "incus_procs_total",
-	UptimeSeconds:               "incus_uptime_seconds",
-	WarningsTotal:               "incus_warnings_total",
+	APIRequestDurationSeconds:         "incus_api_request_duration_seconds",
+	APIRequestsTotal:                  "incus_api_requests_total",
+	AgentStartDurationSeconds:         "incus_agent_start_duration_seconds",
+	CPUSecondsTotal:                   "incus_cpu_seconds_total",
+	CPUs:                              "incus_cpu_effective_total",
+	DeviceDiskReadBytesTotal:          "incus_device_disk_read_bytes_total",
+	DeviceDiskReadsCompletedTotal:     "incus_device_disk_reads_completed_total",
+	DeviceDiskWrittenBytesTotal:       "incus_device_disk_written_bytes_total",
+	DeviceDiskWritesCompletedTotal:    "incus_device_disk_writes_completed_total",
+	DeviceGPUMemoryTotalBytes:         "incus_device_gpu_memory_total_bytes",
+	DeviceGPUMemoryUsedBytes:          "incus_device_gpu_memory_used_bytes",
+	DeviceGPUUtilizationRatio:         "incus_device_gpu_utilization_ratio",
+	DeviceNetworkReceiveBytesTotal:    "incus_device_network_receive_bytes_total",
+	DeviceNetworkReceiveDropTotal:     "incus_device_network_receive_drop_total",
+	DeviceNetworkReceiveErrsTotal:     "incus_device_network_receive_errs_total",
+	DeviceNetworkReceivePacketsTotal:  "incus_device_network_receive_packets_total",
+	DeviceNetworkTransmitBytesTotal:   "incus_device_network_transmit_bytes_total",
+	DeviceNetworkTransmitDropTotal:    "incus_device_network_transmit_drop_total",
+	DeviceNetworkTransmitErrsTotal:    "incus_device_network_transmit_errs_total",
+	DeviceNetworkTransmitPacketsTotal: "incus_device_network_transmit_packets_total",
+	DiskReadBytesTotal:                "incus_disk_read_bytes_total",
+	DiskReadsCompletedTotal:           "incus_disk_reads_completed_total",
+	DiskWrittenBytesTotal:             "incus_disk_written_bytes_total",
+	DiskWritesCompletedTotal:          "incus_disk_writes_completed_total",
+	FilesystemAvailBytes:              "incus_filesystem_avail_bytes",
+	FilesystemFreeBytes:               "incus_filesystem_free_bytes",
+	FilesystemSizeBytes:               "incus_filesystem_size_bytes",
+	GoAllocBytes:                      "incus_go_alloc_bytes",
+	GoAllocBytesTotal:                 "incus_go_alloc_bytes_total",
+	GoBuckHashSysBytes:                "incus_go_buck_hash_sys_bytes",
+	GoFreesTotal:                      "incus_go_frees_total",
+	GoGCSysBytes:                      "incus_go_gc_sys_bytes",
+	GoGoroutines:                      "incus_go_goroutines",
+	GoHeapAllocBytes:                  "incus_go_heap_alloc_bytes",
+	GoHeapIdleBytes:                   "incus_go_heap_idle_bytes",
+	GoHeapInuseBytes:                  "incus_go_heap_inuse_bytes",
+	GoHeapObjects:                     "incus_go_heap_objects",
+	GoHeapReleasedBytes:               "incus_go_heap_released_bytes",
+	GoHeapSysBytes:                    "incus_go_heap_sys_bytes",
+	GoLookupsTotal:                    "incus_go_lookups_total",
+	GoMallocsTotal:                    "incus_go_mallocs_total",
+	GoMCacheInuseBytes:                "incus_go_mcache_inuse_bytes",
+	GoMCacheSysBytes:                  "incus_go_mcache_sys_bytes",
+	GoMSpanInuseBytes:                 "incus_go_mspan_inuse_bytes",
+	GoMSpanSysBytes:                   "incus_go_mspan_sys_bytes",
+	GoNextGCBytes:                     "incus_go_next_gc_bytes",
+	GoOtherSysBytes:                   "incus_go_other_sys_bytes",
+	GoStackInuseBytes:                 "incus_go_stack_inuse_bytes",
+	GoStackSysBytes:                   "incus_go_stack_sys_bytes",
+	GoSysBytes:                        "incus_go_sys_bytes",
+	LifecycleEventsTotal:              "incus_lifecycle_events_total",
+	MemoryActiveAnonBytes:             "incus_memory_Active_anon_bytes",
+	MemoryActiveFileBytes:             "incus_memory_Active_file_bytes",
+	MemoryActiveBytes:                 "incus_memory_Active_bytes",
+	MemoryCachedBytes:                 "incus_memory_Cached_bytes",
+	MemoryDirtyBytes:                  "incus_memory_Dirty_bytes",
+	MemoryHugePagesFreeBytes:          "incus_memory_HugepagesFree_bytes",
+	MemoryHugePagesTotalBytes:         "incus_memory_HugepagesTotal_bytes",
+	MemoryInactiveAnonBytes:           "incus_memory_Inactive_anon_bytes",
+	MemoryInactiveFileBytes:           "incus_memory_Inactive_file_bytes",
+	MemoryInactiveBytes:               "incus_memory_Inactive_bytes",
+	MemoryMappedBytes:                 "incus_memory_Mapped_bytes",
+	MemoryMemAvailableBytes:           "incus_memory_MemAvailable_bytes",
+	MemoryMemFreeBytes:                "incus_memory_MemFree_bytes",
+	MemoryMemTotalBytes:               "incus_memory_MemTotal_bytes",
+	MemoryRSSBytes:                    "incus_memory_RSS_bytes",
+	MemoryShmemBytes:                  "incus_memory_Shmem_bytes",
+	MemorySwapBytes:                   "incus_memory_Swap_bytes",
+	MemoryUnevictableBytes:            "incus_memory_Unevictable_bytes",
+	MemoryWritebackBytes:              "incus_memory_Writeback_bytes",
+	MemoryOOMKillsTotal:               "incus_memory_OOM_kills_total",
+	NetworkReceiveBytesTotal:          "incus_network_receive_bytes_total",
+	NetworkReceiveDropTotal:           "incus_network_receive_drop_total",
+	NetworkReceiveErrsTotal:           "incus_network_receive_errs_total",
+	NetworkReceivePacketsTotal:        "incus_network_receive_packets_total",
+	NetworkTransmitBytesTotal:         "incus_network_transmit_bytes_total",
+	NetworkTransmitDropTotal:          "incus_network_transmit_drop_total",
+	NetworkTransmitErrsTotal:          "incus_network_transmit_errs_total",
+	NetworkTransmitPacketsTotal:       "incus_network_transmit_packets_total",
+	OperationsFailedTotal:             "incus_operations_failed_total",
+	OperationsTotal:                   "incus_operations_total",
+	ProcsTotal:                        "incus_procs_total",
+	ProcessCPUSecondsTotal:            "incus_process_cpu_seconds_total",
+	ProcessMemoryBytes:                "incus_process_memory_bytes",
+	RunningSecondsTotal:               "incus_running_seconds_total",
+	StartDurationSeconds:              "incus_start_duration_seconds",
+	SystemdUnits:                      "incus_systemd_units",
+	SystemdUnitsFailed:                "incus_systemd_units_failed",
+	UptimeSeconds:                     "incus_uptime_seconds",
+	WarningsTotal:                     "incus_warnings_total",
 }
 
 // MetricHeaders represents the metric headers which contain help messages as specified by OpenMetrics.
 var MetricHeaders = map[MetricType]string{
-	CPUSecondsTotal:             "# HELP incus_cpu_seconds_total The total number of CPU time used in seconds.",
-	CPUs:                        "# HELP incus_cpu_effective_total The total number of effective CPUs.",
-	DiskReadBytesTotal:          "# HELP incus_disk_read_bytes_total The total number of bytes read.",
-	DiskReadsCompletedTotal:     "# HELP incus_disk_reads_completed_total The total number of completed reads.",
-	DiskWrittenBytesTotal:       "# HELP incus_disk_written_bytes_total The total number of bytes written.",
-	DiskWritesCompletedTotal:    "# HELP incus_disk_writes_completed_total The total number of completed writes.",
-	FilesystemAvailBytes:        "# HELP incus_filesystem_avail_bytes The number of available space in bytes.",
-	FilesystemFreeBytes:         "# HELP incus_filesystem_free_bytes The number of free space in bytes.",
-	FilesystemSizeBytes:         "# HELP incus_filesystem_size_bytes The size of the filesystem in bytes.",
-	GoAllocBytes:                "# HELP incus_go_alloc_bytes Number of bytes allocated and still in use.",
-	GoAllocBytesTotal:           "# HELP incus_go_alloc_bytes_total Total number of bytes allocated, even if freed.",
-	GoBuckHashSysBytes:          "# HELP incus_go_buck_hash_sys_bytes Number of bytes used by the profiling bucket hash table.",
-	GoFreesTotal:                "# HELP incus_go_frees_total Total number of frees.",
-	GoGCSysBytes:                "# HELP incus_go_gc_sys_bytes Number of bytes used for garbage collection system metadata.",
-	GoGoroutines:                "# HELP incus_go_goroutines Number of goroutines that currently exist.",
-	GoHeapAllocBytes:            "# HELP incus_go_heap_alloc_bytes Number of heap bytes allocated and still in use.",
-	GoHeapIdleBytes:             "# HELP incus_go_heap_idle_bytes Number of heap bytes waiting to be used.",
-	GoHeapInuseBytes:            "# HELP incus_go_heap_inuse_bytes Number of heap bytes that are in use.",
-	GoHeapObjects:               "# HELP incus_go_heap_objects Number of allocated objects.",
-	GoHeapReleasedBytes:         "# HELP incus_go_heap_released_bytes Number of heap bytes released to OS.",
-	GoHeapSysBytes:              "# HELP incus_go_heap_sys_bytes Number of heap bytes obtained from system.",
-	GoLookupsTotal:              "# HELP incus_go_lookups_total Total number of pointer lookups.",
-	GoMallocsTotal:              "# HELP incus_go_mallocs_total Total number of mallocs.",
-	GoMCacheInuseBytes:          "# HELP incus_go_mcache_inuse_bytes Number of bytes in use by mcache structures.",
-	GoMCacheSysBytes:            "# HELP incus_go_mcache_sys_bytes Number of bytes used for mcache structures obtained from system.",
-	GoMSpanInuseBytes:           "# HELP incus_go_mspan_inuse_bytes Number of bytes in use by mspan structures.",
-	GoMSpanSysBytes:             "# HELP incus_go_mspan_sys_bytes Number of bytes used for mspan structures obtained from system.",
-	GoNextGCBytes:               "# HELP incus_go_next_gc_bytes Number of heap bytes when next garbage collection will take place.",
-	GoOtherSysBytes:             "# HELP incus_go_other_sys_bytes Number of bytes used for other system allocations.",
-	GoStackInuseBytes:           "# HELP incus_go_stack_inuse_bytes Number of bytes in use by the stack allocator.",
-	GoStackSysBytes:             "# HELP incus_go_stack_sys_bytes Number of bytes obtained from system for stack allocator.",
-	GoSysBytes:                  "# HELP incus_go_sys_bytes Number of bytes obtained from system.",
-	MemoryActiveAnonBytes:       "# HELP incus_memory_Active_anon_bytes The amount of anonymous memory on active LRU list.",
-	MemoryActiveFileBytes:       "# HELP incus_memory_Active_file_bytes The amount of file-backed memory on active LRU list.",
-	MemoryActiveBytes:           "# HELP incus_memory_Active_bytes The amount of memory on active LRU list.",
-	MemoryCachedBytes:           "# HELP incus_memory_Cached_bytes The amount of cached memory.",
-	MemoryDirtyBytes:            "# HELP incus_memory_Dirty_bytes The amount of memory waiting to get written back to the disk.",
-	MemoryHugePagesFreeBytes:    "# HELP incus_memory_HugepagesFree_bytes The amount of free memory for hugetlb.",
-	MemoryHugePagesTotalBytes:   "# HELP incus_memory_HugepagesTotal_bytes The amount of used memory for hugetlb.",
-	MemoryInactiveAnonBytes:     "# HELP incus_memory_Inactive_anon_bytes The amount of anonymous memory on inactive LRU list.",
-	MemoryInactiveFileBytes:     "# HELP incus_memory_Inactive_file_bytes The amount of file-backed memory on inactive LRU list.",
-	MemoryInactiveBytes:         "# HELP incus_memory_Inactive_bytes The amount of memory on inactive LRU list.",
-	MemoryMappedBytes:           "# HELP incus_memory_Mapped_bytes The amount of mapped memory.",
-	MemoryMemAvailableBytes:     "# HELP incus_memory_MemAvailable_bytes The amount of available memory.",
-	MemoryMemFreeBytes:          "# HELP incus_memory_MemFree_bytes The amount of free memory.",
-	MemoryMemTotalBytes:         "# HELP incus_memory_MemTotal_bytes The amount of used memory.",
-	MemoryRSSBytes:              "# HELP incus_memory_RSS_bytes The amount of anonymous and swap cache memory.",
-	MemoryShmemBytes:            "# HELP incus_memory_Shmem_bytes The amount of cached filesystem data that is swap-backed.",
-	MemorySwapBytes:             "# HELP incus_memory_Swap_bytes The amount of used swap memory.",
-	MemoryUnevictableBytes:      "# HELP incus_memory_Unevictable_bytes The amount of unevictable memory.",
-	MemoryWritebackBytes:        "# HELP incus_memory_Writeback_bytes The amount of memory queued for syncing to disk.",
-	MemoryOOMKillsTotal:         "# HELP incus_memory_OOM_kills_total The number of out of memory kills.",
-	NetworkReceiveBytesTotal:    "# HELP incus_network_receive_bytes_total The amount of received bytes on a given interface.",
-	NetworkReceiveDropTotal:     "# HELP incus_network_receive_drop_total The amount of received dropped bytes on a given interface.",
-	NetworkReceiveErrsTotal:     "# HELP incus_network_receive_errs_total The amount of received errors on a given interface.",
-	NetworkReceivePacketsTotal:  "# HELP incus_network_receive_packets_total The amount of received packets on a given interface.",
-	NetworkTransmitBytesTotal:   "# HELP incus_network_transmit_bytes_total The amount of transmitted bytes on a given interface.",
-	NetworkTransmitDropTotal:    "# HELP incus_network_transmit_drop_total The amount of transmitted dropped bytes on a given interface.",
-	NetworkTransmitErrsTotal:    "# HELP incus_network_transmit_errs_total The amount of transmitted errors on a given interface.",
-	NetworkTransmitPacketsTotal: "# HELP incus_network_transmit_packets_total The amount of transmitted packets on a given interface.",
-	OperationsTotal:             "# HELP incus_operations_total The number of running operations",
-	ProcsTotal:                  "# HELP incus_procs_total The number of running processes.",
-	UptimeSeconds:               "# HELP incus_uptime_seconds The daemon uptime in seconds.",
-	WarningsTotal:               "# HELP incus_warnings_total The number of active warnings.",
+	APIRequestDurationSeconds:         "# HELP incus_api_request_duration_seconds The durations of the API requests in seconds.",
+	APIRequestsTotal:                  "# HELP incus_api_requests_total The number of API requests since the daemon started.",
+	AgentStartDurationSeconds:         "# HELP incus_agent_start_duration_seconds The time taken by the agent to start after the virtual machine in seconds.",
+	CPUSecondsTotal:                   "# HELP incus_cpu_seconds_total The total number of CPU time used in seconds.",
+	CPUs:                              "# HELP incus_cpu_effective_total The total number of effective CPUs.",
+	DeviceDiskReadBytesTotal:          "# HELP incus_device_disk_read_bytes_total The total number of bytes read by a disk device.",
+	DeviceDiskReadsCompletedTotal:     "# HELP incus_device_disk_reads_completed_total The total number of completed reads of a disk device.",
+	DeviceDiskWrittenBytesTotal:       "# HELP incus_device_disk_written_bytes_total The total number of bytes written by a disk device.",
+	DeviceDiskWritesCompletedTotal:    "# HELP incus_device_disk_writes_completed_total The total number of completed writes of a disk device.",
+	DeviceGPUMemoryTotalBytes:         "# HELP incus_device_gpu_memory_total_bytes The total memory of the GPU of a GPU device in bytes.",
+	DeviceGPUMemoryUsedBytes:          "# HELP incus_device_gpu_memory_used_bytes The used memory of the GPU of a GPU device in bytes.",
+	DeviceGPUUtilizationRatio:         "# HELP incus_device_gpu_utilization_ratio The utilization of the GPU of a GPU device.",
+	DeviceNetworkReceiveBytesTotal:    "# HELP incus_device_network_receive_bytes_total The amount of received bytes on a NIC device.",
+	DeviceNetworkReceiveDropTotal:     "# HELP incus_device_network_receive_drop_total The amount of received dropped packets on a NIC device.",
+	DeviceNetworkReceiveErrsTotal:     "# HELP incus_device_network_receive_errs_total The amount of received errors on a NIC device.",
+	DeviceNetworkReceivePacketsTotal:  "# HELP incus_device_network_receive_packets_total The amount of received packets on a NIC device.",
+	DeviceNetworkTransmitBytesTotal:   "# HELP incus_device_network_transmit_bytes_total The amount of transmitted bytes on a NIC device.",
+	DeviceNetworkTransmitDropTotal:    "# HELP incus_device_network_transmit_drop_total The amount of transmitted dropped packets on a NIC device.",
+	DeviceNetworkTransmitErrsTotal:    "# HELP incus_device_network_transmit_errs_total The amount of transmitted errors on a NIC device.",
+	DeviceNetworkTransmitPacketsTotal: "# HELP incus_device_network_transmit_packets_total The amount of transmitted packets on a NIC device.",
+	DiskReadBytesTotal:                "# HELP incus_disk_read_bytes_total The total number of bytes read.",
+	DiskReadsCompletedTotal:           "# HELP incus_disk_reads_completed_total The total number of completed reads.",
+	DiskWrittenBytesTotal:             "# HELP incus_disk_written_bytes_total The total number of bytes written.",
+	DiskWritesCompletedTotal:          "# HELP incus_disk_writes_completed_total The total number of completed writes.",
+	FilesystemAvailBytes:              "# HELP incus_filesystem_avail_bytes The number of available space in bytes.",
+	FilesystemFreeBytes:               "# HELP incus_filesystem_free_bytes The number of free space in bytes.",
+	FilesystemSizeBytes:               "# HELP incus_filesystem_size_bytes The size of the filesystem in bytes.",
+	GoAllocBytes:                      "# HELP incus_go_alloc_bytes Number of bytes allocated and still in use.",
+	GoAllocBytesTotal:                 "# HELP incus_go_alloc_bytes_total Total number of bytes allocated, even if freed.",
+	GoBuckHashSysBytes:                "# HELP incus_go_buck_hash_sys_bytes Number of bytes used by the profiling bucket hash table.",
+	GoFreesTotal:                      "# HELP incus_go_frees_total Total number of frees.",
+	GoGCSysBytes:                      "# HELP incus_go_gc_sys_bytes Number of bytes used for garbage collection system metadata.",
+	GoGoroutines:                      "# HELP incus_go_goroutines Number of goroutines that currently exist.",
+	GoHeapAllocBytes:                  "# HELP incus_go_heap_alloc_bytes Number of heap bytes allocated and still in use.",
+	GoHeapIdleBytes:                   "# HELP incus_go_heap_idle_bytes Number of heap bytes waiting to be used.",
+	GoHeapInuseBytes:                  "# HELP incus_go_heap_inuse_bytes Number of heap bytes that are in use.",
+	GoHeapObjects:                     "# HELP incus_go_heap_objects Number of allocated objects.",
+	GoHeapReleasedBytes:               "# HELP incus_go_heap_released_bytes Number of heap bytes released to OS.",
+	GoHeapSysBytes:                    "# HELP incus_go_heap_sys_bytes Number of heap bytes obtained from system.",
+	GoLookupsTotal:                    "# HELP incus_go_lookups_total Total number of pointer lookups.",
+	GoMallocsTotal:                    "# HELP incus_go_mallocs_total Total number of mallocs.",
+	GoMCacheInuseBytes:                "# HELP incus_go_mcache_inuse_bytes Number of bytes in use by mcache structures.",
+	GoMCacheSysBytes:                  "# HELP incus_go_mcache_sys_bytes Number of bytes used for mcache structures obtained from system.",
+	GoMSpanInuseBytes:                 "# HELP incus_go_mspan_inuse_bytes Number of bytes in use by mspan structures.",
+	GoMSpanSysBytes:                   "# HELP incus_go_mspan_sys_bytes Number of bytes used for mspan structures obtained from system.",
+	GoNextGCBytes:                     "# HELP incus_go_next_gc_bytes Number of heap bytes when next garbage collection will take place.",
+	GoOtherSysBytes:                   "# HELP incus_go_other_sys_bytes Number of bytes used for other system allocations.",
+	GoStackInuseBytes:                 "# HELP incus_go_stack_inuse_bytes Number of bytes in use by the stack allocator.",
+	GoStackSysBytes:                   "# HELP incus_go_stack_sys_bytes Number of bytes obtained from system for stack allocator.",
+	GoSysBytes:                        "# HELP incus_go_sys_bytes Number of bytes obtained from system.",
+	LifecycleEventsTotal:              "# HELP incus_lifecycle_events_total The number of lifecycle events since the daemon started.",
+	MemoryActiveAnonBytes:             "# HELP incus_memory_Active_anon_bytes The amount of anonymous memory on active LRU list.",
+	MemoryActiveFileBytes:             "# HELP incus_memory_Active_file_bytes The amount of file-backed memory on active LRU list.",
+	MemoryActiveBytes:                 "# HELP incus_memory_Active_bytes The amount of memory on active LRU list.",
+	MemoryCachedBytes:                 "# HELP incus_memory_Cached_bytes The amount of cached memory.",
+	MemoryDirtyBytes:                  "# HELP incus_memory_Dirty_bytes The amount of memory waiting to get written back to the disk.",
+	MemoryHugePagesFreeBytes:          "# HELP incus_memory_HugepagesFree_bytes The amount of free memory for hugetlb.",
+	MemoryHugePagesTotalBytes:         "# HELP incus_memory_HugepagesTotal_bytes The amount of used memory for hugetlb.",
+	MemoryInactiveAnonBytes:           "# HELP incus_memory_Inactive_anon_bytes The amount of anonymous memory on inactive LRU list.",
+	MemoryInactiveFileBytes:           "# HELP incus_memory_Inactive_file_bytes The amount of file-backed memory on inactive LRU list.",
+	MemoryInactiveBytes:               "# HELP incus_memory_Inactive_bytes The amount of memory on inactive LRU list.",
+	MemoryMappedBytes:                 "# HELP incus_memory_Mapped_bytes The amount of mapped memory.",
+	MemoryMemAvailableBytes:           "# HELP incus_memory_MemAvailable_bytes The amount of available memory.",
+	MemoryMemFreeBytes:                "# HELP incus_memory_MemFree_bytes The amount of free memory.",
+	MemoryMemTotalBytes:               "# HELP incus_memory_MemTotal_bytes The amount of used memory.",
+	MemoryRSSBytes:                    "# HELP incus_memory_RSS_bytes The amount of anonymous and swap cache memory.",
+	MemoryShmemBytes:                  "# HELP incus_memory_Shmem_bytes The amount of cached filesystem data that is swap-backed.",
+	MemorySwapBytes:                   "# HELP incus_memory_Swap_bytes The amount of used swap memory.",
+	MemoryUnevictableBytes:            "# HELP incus_memory_Unevictable_bytes The amount of unevictable memory.",
+	MemoryWritebackBytes:              "# HELP incus_memory_Writeback_bytes The amount of memory queued for syncing to disk.",
+	MemoryOOMKillsTotal:               "# HELP incus_memory_OOM_kills_total The number of out of memory kills.",
+	NetworkReceiveBytesTotal:          "# HELP incus_network_receive_bytes_total The amount of received bytes on a given interface.",
+	NetworkReceiveDropTotal:           "# HELP incus_network_receive_drop_total The amount of received dropped bytes on a given interface.",
+	NetworkReceiveErrsTotal:           "# HELP incus_network_receive_errs_total The amount of received errors on a given interface.",
+	NetworkReceivePacketsTotal:        "# HELP incus_network_receive_packets_total The amount of received packets on a given interface.",
+	NetworkTransmitBytesTotal:         "# HELP incus_network_transmit_bytes_total The amount of transmitted bytes on a given interface.",
+	NetworkTransmitDropTotal:          "# HELP incus_network_transmit_drop_total The amount of transmitted dropped bytes on a given interface.",
+	NetworkTransmitErrsTotal:          "# HELP incus_network_transmit_errs_total The amount of transmitted errors on a given interface.",
+	NetworkTransmitPacketsTotal:       "# HELP incus_network_transmit_packets_total The amount of transmitted packets on a given interface.",
+	OperationsFailedTotal:             "# HELP incus_operations_failed_total The number of failed operations since the daemon started.",
+	OperationsTotal:                   "# HELP incus_operations_total The number of running operations",
+	ProcsTotal:                        "# HELP incus_procs_total The number of running processes.",
+	ProcessCPUSecondsTotal:            "# HELP incus_process_cpu_seconds_total The CPU time used by a process in seconds.",
+	ProcessMemoryBytes:                "# HELP incus_process_memory_bytes The resident memory of a process in bytes.",
+	RunningSecondsTotal:               "# HELP incus_running_seconds_total The cumulative time the instance has been running for in seconds.",
+	StartDurationSeconds:              "# HELP incus_start_duration_seconds The time taken by the last start of the instance in seconds.",
+	SystemdUnits:                      "# HELP incus_systemd_units The number of loaded systemd units.",
+	SystemdUnitsFailed:                "# HELP incus_systemd_units_failed The number of failed systemd units.",
+	UptimeSeconds:                     "# HELP incus_uptime_seconds The daemon uptime in seconds.",
+	WarningsTotal:                     "# HELP incus_warnings_total The number of active warnings.",
 }
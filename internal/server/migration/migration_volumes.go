@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"slices"
+	"strings"
 
 	"github.com/lxc/incus/v6/internal/migration"
 	backupConfig "github.com/lxc/incus/v6/internal/server/backup/config"
@@ -260,6 +261,7 @@ func progressWrapperRender(op *operations.Operation, key string, description str
 
 	if meta[key] != progress {
 		meta[key] = progress
+		operations.SetProgressData(meta, strings.TrimSuffix(key, "_progress"), 0, progressInt, speedInt)
 		_ = op.UpdateMetadata(meta)
 	}
 }
@@ -207,13 +207,13 @@ func (n *bridge) Validate(config map[string]string) error {
 		"bridge.hwaddr": validate.Optional(validate.IsNetworkMAC),
 
 		// gendoc:generate(entity=network_bridge, group=common, key=bridge.mtu)
-		//
+		// Set to `auto` to use the MTU of the uplink path (the external interfaces or the default gateway interface), minus any tunnel overhead.
 		// ---
 		//  type: integer
 		//  condition: -
 		//  default: `1500`
 		//  shortdesc: Bridge MTU (default varies if tunnel in use)
-		"bridge.mtu": validate.Optional(validate.IsNetworkMTU),
+		"bridge.mtu": validate.Optional(validate.Or(validate.IsOneOf("auto"), validate.IsNetworkMTU)),
 
 		// gendoc:generate(entity=network_bridge, group=common, key=ipv4.address)
 		//
@@ -266,6 +266,15 @@ func (n *bridge) Validate(config map[string]string) error {
 		//  shortdesc: The source address used for outbound traffic from the bridge
 		"ipv4.nat.address": validate.Optional(validate.IsNetworkAddressV4),
 
+		// gendoc:generate(entity=network_bridge, group=common, key=ipv4.nat.reflection)
+		//
+		// ---
+		//  type: bool
+		//  condition: IPv4 address
+		//  default: `false`
+		//  shortdesc: Whether to make network forwards reachable from instances on the bridge (NAT reflection)
+		"ipv4.nat.reflection": validate.Optional(validate.IsBool),
+
 		// gendoc:generate(entity=network_bridge, group=common, key=ipv4.dhcp)
 		//
 		// ---
@@ -389,6 +398,15 @@ func (n *bridge) Validate(config map[string]string) error {
 		//  shortdesc: The source address used for outbound traffic from the bridge
 		"ipv6.nat.address": validate.Optional(validate.IsNetworkAddressV6),
 
+		// gendoc:generate(entity=network_bridge, group=common, key=ipv6.nat.reflection)
+		//
+		// ---
+		//  type: bool
+		//  condition: IPv6 address
+		//  default: `false`
+		//  shortdesc: Whether to make network forwards reachable from instances on the bridge (NAT reflection)
+		"ipv6.nat.reflection": validate.Optional(validate.IsBool),
+
 		// gendoc:generate(entity=network_bridge, group=common, key=ipv6.dhcp)
 		//
 		// ---
@@ -524,6 +542,33 @@ func (n *bridge) Validate(config map[string]string) error {
 		//  shortdesc: Additional dnsmasq configuration to append to the configuration file
 		"raw.dnsmasq": validate.IsAny,
 
+		// gendoc:generate(entity=network_bridge, group=common, key=raw.nftables.input)
+		//
+		// ---
+		//  type: string
+		//  condition: nftables firewall
+		//  default: -
+		//  shortdesc: Additional nftables rules (one per line) for traffic from the bridge to the host
+		"raw.nftables.input": validate.IsAny,
+
+		// gendoc:generate(entity=network_bridge, group=common, key=raw.nftables.forward)
+		//
+		// ---
+		//  type: string
+		//  condition: nftables firewall
+		//  default: -
+		//  shortdesc: Additional nftables rules (one per line) for traffic forwarded to or from the bridge
+		"raw.nftables.forward": validate.IsAny,
+
+		// gendoc:generate(entity=network_bridge, group=common, key=raw.nftables.output)
+		//
+		// ---
+		//  type: string
+		//  condition: nftables firewall
+		//  default: -
+		//  shortdesc: Additional nftables rules (one per line) for traffic from the host to the bridge
+		"raw.nftables.output": validate.IsAny,
+
 		// gendoc:generate(entity=network_bridge, group=common, key=security.acls)
 		//
 		// ---
@@ -730,7 +775,7 @@ func (n *bridge) Validate(config map[string]string) error {
 	for k, v := range config {
 		key := k
 		// MTU checks
-		if key == "bridge.mtu" && v != "" {
+		if key == "bridge.mtu" && v != "" && v != "auto" {
 			mtu, err := strconv.ParseInt(v, 10, 64)
 			if err != nil {
 				return fmt.Errorf("Invalid value for an integer: %s", v)
@@ -748,6 +793,13 @@ func (n *bridge) Validate(config map[string]string) error {
 		}
 	}
 
+	// User-defined firewall rules are only supported with nftables.
+	if config["raw.nftables.input"] != "" || config["raw.nftables.forward"] != "" || config["raw.nftables.output"] != "" {
+		if n.state != nil && n.state.Firewall != nil && n.state.Firewall.String() != "nftables" {
+			return fmt.Errorf("User-defined firewall rules require the nftables firewall driver")
+		}
+	}
+
 	// Check using same MAC address on every cluster node is safe.
 	if config["bridge.hwaddr"] != "" {
 		err = n.checkClusterWideMACSafe(config)
@@ -977,7 +1029,9 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 	tunnels := n.getTunnels()
 
 	// Decide the MTU for the bridge interface.
-	if n.config["bridge.mtu"] != "" {
+	if n.config["bridge.mtu"] == "auto" {
+		bridge.MTU = n.discoverMTU(tunnels)
+	} else if n.config["bridge.mtu"] != "" {
 		mtuInt, err := strconv.ParseUint(n.config["bridge.mtu"], 10, 32)
 		if err != nil {
 			return fmt.Errorf("Invalid MTU %q: %w", n.config["bridge.mtu"], err)
@@ -1775,6 +1829,14 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		}
 	}
 
+	// Propagate a discovered MTU to the NICs of running instances.
+	if n.config["bridge.mtu"] == "auto" {
+		err = n.applyInstanceMTU(bridge.MTU)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Generate and load apparmor profiles.
 	err = apparmor.NetworkLoad(n.state.OS, n)
 	if err != nil {
@@ -1957,6 +2019,12 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		return err
 	}
 
+	// Setup user-defined firewall rules.
+	err = n.state.Firewall.NetworkApplyUserRules(n.name, n.userFirewallRules())
+	if err != nil {
+		return err
+	}
+
 	// Setup BGP.
 	err = n.bgpSetup(oldConfig)
 	if err != nil {
@@ -2166,6 +2234,76 @@ func (n *bridge) getTunnels() []string {
 	return tunnels
 }
 
+// discoverMTU returns the bridge MTU to use when bridge.mtu is set to "auto".
+// This is the lowest MTU of the external interfaces (or of the default gateway interfaces if there are none)
+// minus the encapsulation overhead of the largest tunnel in use.
+func (n *bridge) discoverMTU(tunnels []string) uint32 {
+	var mtu uint32
+
+	for _, entry := range util.SplitNTrimSpace(n.config["bridge.external_interfaces"], ",", -1, true) {
+		// For VLAN interfaces that may not exist yet, use the parent's MTU.
+		ifName := entry
+		entryParts := strings.Split(entry, "/")
+		if len(entryParts) == 3 {
+			ifName = strings.TrimSpace(entryParts[0])
+			if !InterfaceExists(ifName) {
+				ifName = strings.TrimSpace(entryParts[1])
+			}
+		}
+
+		ifMTU, err := GetDevMTU(ifName)
+		if err != nil {
+			continue
+		}
+
+		if mtu == 0 || ifMTU < mtu {
+			mtu = ifMTU
+		}
+	}
+
+	if mtu == 0 {
+		var err error
+
+		mtu, err = DefaultGatewayMTU()
+		if err != nil {
+			n.logger.Warn("Failed discovering uplink MTU, using default", logger.Ctx{"err": err})
+
+			if len(tunnels) > 0 {
+				return 1400
+			}
+
+			return bridgeMTUDefault
+		}
+	}
+
+	// Account for the encapsulation overhead of the tunnels.
+	var overhead uint32
+	for _, tunnel := range tunnels {
+		getConfig := func(key string) string {
+			return n.config[fmt.Sprintf("tunnel.%s.%s", tunnel, key)]
+		}
+
+		// GRE (with Ethernet header) adds 38 bytes and VXLAN adds 50 bytes over IPv4.
+		tunOverhead := uint32(50)
+		if getConfig("protocol") == "gre" {
+			tunOverhead = 38
+		}
+
+		// IPv6 encapsulation adds another 20 bytes.
+		if strings.Contains(getConfig("local"), ":") || strings.Contains(getConfig("remote"), ":") || strings.Contains(getConfig("group"), ":") {
+			tunOverhead += 20
+		}
+
+		overhead = max(overhead, tunOverhead)
+	}
+
+	if mtu <= overhead+1280 {
+		return 1280
+	}
+
+	return mtu - overhead
+}
+
 // bootRoutesV4 returns a list of IPv4 boot routes on the network's device.
 func (n *bridge) bootRoutesV4() ([]string, error) {
 	r := &ip.Route{
@@ -2295,14 +2433,52 @@ func (n *bridge) DHCPv6Subnet() *net.IPNet {
 	return subnet
 }
 
+// userFirewallRules returns the user-defined firewall rules from the raw.nftables.* config keys.
+func (n *bridge) userFirewallRules() firewallDrivers.UserRules {
+	parseRules := func(value string) []string {
+		var rules []string
+
+		for _, line := range strings.Split(value, "\n") {
+			line = strings.TrimSpace(line)
+
+			// Skip empty lines and comments.
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			rules = append(rules, line)
+		}
+
+		return rules
+	}
+
+	return firewallDrivers.UserRules{
+		Input:   parseRules(n.config["raw.nftables.input"]),
+		Forward: parseRules(n.config["raw.nftables.forward"]),
+		Output:  parseRules(n.config["raw.nftables.output"]),
+	}
+}
+
 // forwardConvertToFirewallForward converts forwards into format compatible with the firewall package.
 func (n *bridge) forwardConvertToFirewallForwards(listenAddress net.IP, defaultTargetAddress net.IP, portMaps []*forwardPortMap) []firewallDrivers.AddressForward {
 	var vips []firewallDrivers.AddressForward
 
+	// Get the bridge subnet to reflect forwarded traffic for if NAT reflection is enabled.
+	reflectionKey := "ipv4"
+	if listenAddress.To4() == nil {
+		reflectionKey = "ipv6"
+	}
+
+	var reflection *net.IPNet
+	if util.IsTrue(n.config[fmt.Sprintf("%s.nat.reflection", reflectionKey)]) {
+		_, reflection, _ = net.ParseCIDR(n.config[fmt.Sprintf("%s.address", reflectionKey)])
+	}
+
 	if defaultTargetAddress != nil {
 		vips = append(vips, firewallDrivers.AddressForward{
 			ListenAddress: listenAddress,
 			TargetAddress: defaultTargetAddress,
+			Reflection:    reflection,
 		})
 	}
 
@@ -2314,6 +2490,7 @@ func (n *bridge) forwardConvertToFirewallForwards(listenAddress net.IP, defaultT
 			ListenPorts:   portMap.listenPorts,
 			TargetPorts:   portMap.target.ports,
 			SNAT:          portMap.snat,
+			Reflection:    reflection,
 		})
 	}
 
@@ -19,7 +19,9 @@ import (
 	"github.com/lxc/incus/v6/internal/server/cluster/request"
 	"github.com/lxc/incus/v6/internal/server/db"
 	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/ip"
 	"github.com/lxc/incus/v6/internal/server/network/acl"
+	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/resources"
 	"github.com/lxc/incus/v6/internal/server/state"
 	internalUtil "github.com/lxc/incus/v6/internal/util"
@@ -1599,3 +1601,50 @@ func (n *common) setAvailable() {
 	delete(unavailableNetworks, pn)
 	unavailableNetworksMu.Unlock()
 }
+
+// applyInstanceMTU sets the MTU of the host side interface of the NICs of local running instances
+// connected to the network. The instances also get the new MTU through DHCP on their next lease renewal.
+func (n *common) applyInstanceMTU(mtu uint32) error {
+	filter := dbCluster.InstanceFilter{Node: &n.state.ServerName}
+
+	return n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.InstanceList(ctx, func(inst db.InstanceArgs, p api.Project) error {
+			if project.NetworkProjectFromRecord(&p) != n.project {
+				return nil
+			}
+
+			devices := db.ExpandInstanceDevices(inst.Devices.Clone(), inst.Profiles)
+
+			for devName, devConfig := range devices {
+				if devConfig["type"] != "nic" || devConfig["mtu"] != "" {
+					continue
+				}
+
+				if !NICUsesNetwork(devConfig, &api.Network{Name: n.name}) {
+					continue
+				}
+
+				hostName := inst.Config[fmt.Sprintf("volatile.%s.host_name", devName)]
+				if hostName == "" || !InterfaceExists(hostName) {
+					continue
+				}
+
+				currentMTU, err := GetDevMTU(hostName)
+				if err != nil || currentMTU == mtu {
+					continue
+				}
+
+				link := &ip.Link{Name: hostName}
+				err = link.SetMTU(mtu)
+				if err != nil {
+					n.logger.Warn("Failed updating NIC MTU", logger.Ctx{"inst": inst.Name, "project": inst.Project, "device": devName, "dev": hostName, "err": err})
+					continue
+				}
+
+				n.logger.Debug("Updated NIC MTU", logger.Ctx{"inst": inst.Name, "project": inst.Project, "device": devName, "dev": hostName, "mtu": mtu})
+			}
+
+			return nil
+		}, filter)
+	})
+}
@@ -44,6 +44,7 @@ import (
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/revert"
+	"github.com/lxc/incus/v6/shared/units"
 	"github.com/lxc/incus/v6/shared/util"
 	"github.com/lxc/incus/v6/shared/validate"
 )
@@ -52,6 +53,7 @@ const (
 	ovnChassisPriorityMax = 32767
 	ovnVolatileUplinkIPv4 = "volatile.network.ipv4.address"
 	ovnVolatileUplinkIPv6 = "volatile.network.ipv6.address"
+	ovnVolatileBridgeMTU  = "volatile.bridge.mtu"
 )
 
 const (
@@ -408,13 +410,13 @@ func (n *ovn) Validate(config map[string]string) error {
 
 		"bridge.hwaddr": validate.Optional(validate.IsNetworkMAC),
 		// gendoc:generate(entity=network_ovn, group=common, key=bridge.mtu)
-		//
+		// Set to `auto` to derive the MTU from the MTU of the OVN underlay interface minus the Geneve overhead whenever the network is created or updated.
 		// ---
 		//  type: integer
 		//  shortdesc: Bridge MTU (default allows host to host Geneve tunnels)
 		//  default: `1442`
 
-		"bridge.mtu": validate.Optional(validate.IsNetworkMTU),
+		"bridge.mtu": validate.Optional(validate.Or(validate.IsOneOf("auto"), validate.IsNetworkMTU)),
 		// gendoc:generate(entity=network_ovn, group=common, key=bridge.external_interfaces)
 		//
 		// ---
@@ -656,6 +658,15 @@ func (n *ovn) Validate(config map[string]string) error {
 		// Volatile keys populated automatically as needed.
 		ovnVolatileUplinkIPv4: validate.Optional(validate.IsNetworkAddressV4),
 		ovnVolatileUplinkIPv6: validate.Optional(validate.IsNetworkAddressV6),
+		ovnVolatileBridgeMTU:  validate.Optional(validate.IsNetworkMTU),
+	}
+
+	// NAT reflection is only configurable on bridges, the OVN load balancers of the forwards being applied to
+	// the internal switch too so that they're always reachable from the network.
+	for _, key := range []string{"ipv4.nat.reflection", "ipv6.nat.reflection"} {
+		if config[key] != "" {
+			return fmt.Errorf("The %q option isn't supported on OVN networks, their forwards are always reachable from the network", key)
+		}
 	}
 
 	err := n.validate(config, rules)
@@ -944,9 +955,15 @@ func (n *ovn) Validate(config map[string]string) error {
 
 // getBridgeMTU returns MTU that should be used for the bridge and instance devices.
 // Will also be used to configure the OVN DHCP and IPv6 RA options. Returns 0 if the bridge.mtu is not set/invalid.
+// When bridge.mtu is "auto", the last discovered MTU is returned.
 func (n *ovn) getBridgeMTU() uint32 {
-	if n.config["bridge.mtu"] != "" {
-		mtu, err := strconv.ParseUint(n.config["bridge.mtu"], 10, 32)
+	mtuValue := n.config["bridge.mtu"]
+	if mtuValue == "auto" {
+		mtuValue = n.config[ovnVolatileBridgeMTU]
+	}
+
+	if mtuValue != "" {
+		mtu, err := strconv.ParseUint(mtuValue, 10, 32)
 		if err != nil {
 			return 0
 		}
@@ -1043,6 +1060,28 @@ func (n *ovn) getOptimalBridgeMTU() (uint32, error) {
 	return 1442, nil
 }
 
+// getDiscoveredBridgeMTU returns the largest MTU that can be used for the bridge and instance devices based on the
+// MTU of the OVN underlay network interface and the geneve tunnel overhead.
+func (n *ovn) getDiscoveredBridgeMTU() (uint32, error) {
+	// Get underlay MTU and encapsulation IP.
+	underlayMTU, encapIP, err := n.getUnderlayInfo()
+	if err != nil {
+		return 0, fmt.Errorf("Failed getting OVN underlay info: %w", err)
+	}
+
+	// The geneve tunnel overhead is 58 bytes with IPv4 encapsulation and 78 bytes with IPv6 encapsulation.
+	overhead := uint32(58)
+	if encapIP.To4() == nil {
+		overhead = 78
+	}
+
+	if underlayMTU < overhead+1280 {
+		return 0, fmt.Errorf("OVN underlay MTU %d is too small for IPv6 traffic", underlayMTU)
+	}
+
+	return underlayMTU - overhead, nil
+}
+
 // getNetworkPrefix returns OVN network prefix to use for object names.
 func (n *ovn) getNetworkPrefix() string {
 	return acl.OVNNetworkPrefix(n.id)
@@ -1599,6 +1638,16 @@ func (n *ovn) startUplinkPortBridgeNative(uplinkNet Network, bridgeDevice string
 		uplinkNetMTU = uplinkNetConfig["mtu"]
 	}
 
+	// Use the MTU discovered by the uplink bridge.
+	if uplinkNetMTU == "auto" {
+		mtu, err := GetDevMTU(uplinkNet.Name())
+		if err != nil {
+			return fmt.Errorf("Failed getting uplink MTU: %w", err)
+		}
+
+		uplinkNetMTU = fmt.Sprintf("%d", mtu)
+	}
+
 	if uplinkNetMTU != "" {
 		mtu, err := strconv.ParseUint(uplinkNetMTU, 10, 32)
 		if err != nil {
@@ -2335,7 +2384,18 @@ func (n *ovn) setup(update bool) error {
 
 	// Get bridge MTU to use.
 	bridgeMTU := n.getBridgeMTU()
-	if bridgeMTU == 0 {
+	if n.config["bridge.mtu"] == "auto" {
+		// Derive the MTU from the current underlay network.
+		bridgeMTU, err = n.getDiscoveredBridgeMTU()
+		if err != nil {
+			return fmt.Errorf("Failed discovering bridge MTU: %w", err)
+		}
+
+		// Save to config so the value can be read by instances connecting to network.
+		if n.config[ovnVolatileBridgeMTU] != fmt.Sprintf("%d", bridgeMTU) {
+			updatedConfig[ovnVolatileBridgeMTU] = fmt.Sprintf("%d", bridgeMTU)
+		}
+	} else if bridgeMTU == 0 {
 		// If no manual bridge MTU specified, derive it from the underlay network.
 		bridgeMTU, err = n.getOptimalBridgeMTU()
 		if err != nil {
@@ -2371,6 +2431,14 @@ func (n *ovn) setup(update bool) error {
 		}
 	}
 
+	// Propagate a discovered MTU to the NICs of local running instances.
+	if n.config["bridge.mtu"] == "auto" && updatedConfig[ovnVolatileBridgeMTU] != "" {
+		err = n.applyInstanceMTU(bridgeMTU)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Get router MAC address.
 	routerMAC, err := n.getRouterMAC()
 	if err != nil {
@@ -4436,6 +4504,25 @@ func (n *ovn) InstanceDevicePortStart(opts *OVNInstanceNICSetupOpts, securityACL
 		_ = n.ovnnb.DeleteLogicalSwitchPortDNS(context.TODO(), n.getIntSwitchName(), dnsUUID, false)
 	})
 
+	// Apply the bandwidth limits of the NIC.
+	limits := map[string]int64{}
+	for _, key := range []string{"limits.ingress", "limits.egress"} {
+		value := opts.DeviceConfig[key]
+		if value == "" {
+			value = opts.DeviceConfig["limits.max"]
+		}
+
+		limits[key], err = units.ParseBitSizeString(value)
+		if err != nil {
+			return "", nil, fmt.Errorf("Invalid %q value %q: %w", key, value, err)
+		}
+	}
+
+	err = n.ovnnb.UpdateLogicalSwitchPortQoS(context.TODO(), n.getIntSwitchName(), instancePortName, limits["limits.ingress"], limits["limits.egress"])
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed setting bandwidth limits: %w", err)
+	}
+
 	// If NIC has static IPv4 address then ensure a DHCPv4 reservation exists.
 	// Do this at start time as well as add time in case an instance was copied (causing a duplicate address
 	// conflict at add time) which is later resolved by deleting the original instance, meaning a reservation needs to
@@ -401,6 +401,115 @@ func DefaultGatewaySubnetV4() (*net.IPNet, string, error) {
 	return subnet, ifaceName, nil
 }
 
+// DefaultGatewayMTU returns the lowest MTU of the interfaces holding the IPv4 and IPv6 default routes.
+func DefaultGatewayMTU() (uint32, error) {
+	ifaceNames := []string{}
+
+	for _, filename := range []string{"route", "ipv6_route"} {
+		file, err := os.Open(fmt.Sprintf("/proc/net/%s", filename))
+		if err != nil {
+			continue
+		}
+
+		scanner := bufio.NewReader(file)
+		for {
+			line, _, err := scanner.ReadLine()
+			if err != nil {
+				break
+			}
+
+			fields := strings.Fields(string(line))
+			if len(fields) < 10 {
+				continue
+			}
+
+			if filename == "ipv6_route" {
+				// Default route has an all-zero destination with a /0 prefix.
+				if fields[0] == "00000000000000000000000000000000" && fields[1] == "00" && fields[9] != "lo" {
+					ifaceNames = append(ifaceNames, fields[9])
+				}
+			} else if fields[1] == "00000000" && fields[7] == "00000000" {
+				ifaceNames = append(ifaceNames, fields[0])
+			}
+		}
+
+		_ = file.Close()
+	}
+
+	var mtu uint32
+	for _, ifaceName := range ifaceNames {
+		ifaceMTU, err := GetDevMTU(ifaceName)
+		if err != nil {
+			continue
+		}
+
+		if mtu == 0 || ifaceMTU < mtu {
+			mtu = ifaceMTU
+		}
+	}
+
+	if mtu == 0 {
+		return 0, fmt.Errorf("No default gateway interface found")
+	}
+
+	return mtu, nil
+}
+
+// GetDefaultGateway returns the default gateway address reachable through the given interface.
+func GetDefaultGateway(devName string, ipv6 bool) (net.IP, error) {
+	filename := "route"
+	if ipv6 {
+		filename = "ipv6_route"
+	}
+
+	file, err := os.Open(fmt.Sprintf("/proc/net/%s", filename))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewReader(file)
+	for {
+		line, _, err := scanner.ReadLine()
+		if err != nil {
+			break
+		}
+
+		fields := strings.Fields(string(line))
+		if len(fields) < 10 {
+			continue
+		}
+
+		if ipv6 {
+			// Default route has an all-zero destination with a /0 prefix and a non-zero next hop.
+			if fields[9] != devName || fields[0] != "00000000000000000000000000000000" || fields[1] != "00" {
+				continue
+			}
+
+			gateway, err := hex.DecodeString(fields[4])
+			if err != nil || net.IP(gateway).IsUnspecified() {
+				continue
+			}
+
+			return net.IP(gateway), nil
+		}
+
+		if fields[0] != devName || fields[1] != "00000000" || fields[7] != "00000000" {
+			continue
+		}
+
+		gateway, err := hex.DecodeString(fields[2])
+		if err != nil || len(gateway) != 4 {
+			continue
+		}
+
+		return net.IPv4(gateway[3], gateway[2], gateway[1], gateway[0]), nil
+	}
+
+	return nil, fmt.Errorf("No default gateway found")
+}
+
 // UpdateDNSMasqStatic rebuilds the DNSMasq static allocations.
 func UpdateDNSMasqStatic(s *state.State, networkName string) error {
 	// We don't want to race with ourselves here.
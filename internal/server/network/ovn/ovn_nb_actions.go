@@ -2176,6 +2176,134 @@ func (o *NB) DeleteLogicalSwitchPortDNS(ctx context.Context, switchName OVNSwitc
 	return nil
 }
 
+// logicalSwitchPortQoSDeleteOperations returns the operations removing the QoS rules of a logical switch port.
+func (o *NB) logicalSwitchPortQoSDeleteOperations(ctx context.Context, switchName OVNSwitch, portName OVNSwitchPort) ([]ovsdb.Operation, error) {
+	operations := []ovsdb.Operation{}
+
+	// Get the QoS rules of the port.
+	qosRules := []ovnNB.QoS{}
+	err := o.client.WhereCache(func(qos *ovnNB.QoS) bool {
+		return qos.ExternalIDs != nil && qos.ExternalIDs[ovnExtIDIncusSwitchPort] == string(portName)
+	}).List(ctx, &qosRules)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(qosRules) == 0 {
+		return operations, nil
+	}
+
+	// Get the logical switch.
+	ls, err := o.GetLogicalSwitch(ctx, switchName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, qos := range qosRules {
+		// Remove from the logical switch.
+		updateOps, err := o.client.Where(ls).Mutate(ls, ovsModel.Mutation{
+			Field:   &ls.QOSRules,
+			Mutator: ovsdb.MutateOperationDelete,
+			Value:   []string{qos.UUID},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		operations = append(operations, updateOps...)
+
+		// Delete the rule itself.
+		deleteOps, err := o.client.Where(&qos).Delete()
+		if err != nil {
+			return nil, err
+		}
+
+		operations = append(operations, deleteOps...)
+	}
+
+	return operations, nil
+}
+
+// UpdateLogicalSwitchPortQoS sets the bandwidth limits in bit/s of the traffic going to (ingress) and
+// coming from (egress) a logical switch port. A limit of 0 means no limit.
+func (o *NB) UpdateLogicalSwitchPortQoS(ctx context.Context, switchName OVNSwitch, portName OVNSwitchPort, ingress int64, egress int64) error {
+	// Remove the existing rules.
+	operations, err := o.logicalSwitchPortQoSDeleteOperations(ctx, switchName, portName)
+	if err != nil {
+		return err
+	}
+
+	// Get the logical switch.
+	ls, err := o.GetLogicalSwitch(ctx, switchName)
+	if err != nil {
+		return err
+	}
+
+	rules := []struct {
+		direction ovnNB.QoSDirection
+		match     string
+		limit     int64
+	}{
+		{direction: ovnNB.QoSDirectionToLport, match: fmt.Sprintf(`outport == "%s"`, portName), limit: ingress},
+		{direction: ovnNB.QoSDirectionFromLport, match: fmt.Sprintf(`inport == "%s"`, portName), limit: egress},
+	}
+
+	for i, rule := range rules {
+		if rule.limit <= 0 {
+			continue
+		}
+
+		// OVN expects the rate in kbit/s.
+		qos := ovnNB.QoS{
+			UUID:      fmt.Sprintf("qos%d", i),
+			Bandwidth: map[string]int{ovnNB.QoSBandwidthRate: int(max(rule.limit/1000, 1))},
+			Direction: rule.direction,
+			Match:     rule.match,
+			Priority:  100,
+			ExternalIDs: map[string]string{
+				ovnExtIDIncusSwitch:     string(switchName),
+				ovnExtIDIncusSwitchPort: string(portName),
+			},
+		}
+
+		createOps, err := o.client.Create(&qos)
+		if err != nil {
+			return err
+		}
+
+		operations = append(operations, createOps...)
+
+		// Add it to the logical switch.
+		updateOps, err := o.client.Where(ls).Mutate(ls, ovsModel.Mutation{
+			Field:   &ls.QOSRules,
+			Mutator: ovsdb.MutateOperationInsert,
+			Value:   []string{qos.UUID},
+		})
+		if err != nil {
+			return err
+		}
+
+		operations = append(operations, updateOps...)
+	}
+
+	if len(operations) == 0 {
+		return nil
+	}
+
+	// Apply the changes.
+	resp, err := o.client.Transact(ctx, operations...)
+	if err != nil {
+		return err
+	}
+
+	_, err = ovsdb.CheckOperationResults(resp, operations)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // logicalSwitchPortDeleteAppendArgs adds the commands to delete the specified logical switch port.
 func (o *NB) logicalSwitchPortDeleteOperations(ctx context.Context, switchName OVNSwitch, portName OVNSwitchPort) ([]ovsdb.Operation, error) {
 	operations := []ovsdb.Operation{}
@@ -2219,6 +2347,14 @@ func (o *NB) logicalSwitchPortDeleteOperations(ctx context.Context, switchName O
 
 	operations = append(operations, deleteOps...)
 
+	// Delete its QoS rules.
+	deleteOps, err = o.logicalSwitchPortQoSDeleteOperations(ctx, switchName, portName)
+	if err != nil {
+		return nil, err
+	}
+
+	operations = append(operations, deleteOps...)
+
 	return operations, nil
 }
 
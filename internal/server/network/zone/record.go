@@ -3,7 +3,10 @@ package zone
 import (
 	"context"
 	"fmt"
+	"net"
 	"slices"
+	"strings"
+	"text/template"
 
 	"github.com/miekg/dns"
 
@@ -174,7 +177,19 @@ func (d *zone) validateEntries(info api.NetworkZoneRecordPut) error {
 			entry.TTL = 300
 		}
 
-		_, err := dns.NewRR(fmt.Sprintf("record %d IN %s %s", entry.TTL, entry.Type, entry.Value))
+		// Expand references to instance addresses using documentation addresses.
+		value, err := renderEntryValue(entry.Value, func(_ string, ipv6 bool) net.IP {
+			if ipv6 {
+				return net.ParseIP("2001:db8::1")
+			}
+
+			return net.ParseIP("192.0.2.1")
+		})
+		if err != nil {
+			return fmt.Errorf("Bad zone record entry: %w", err)
+		}
+
+		_, err = dns.NewRR(fmt.Sprintf("record %d IN %s %s", entry.TTL, entry.Type, value))
 		if err != nil {
 			return fmt.Errorf("Bad zone record entry: %w", err)
 		}
@@ -189,3 +204,54 @@ func (d *zone) validateEntries(info api.NetworkZoneRecordPut) error {
 
 	return nil
 }
+
+// addressLookup returns a function finding the first address of the requested family for a host.
+func addressLookup(addresses map[string][]net.IP) func(hostname string, ipv6 bool) net.IP {
+	return func(hostname string, ipv6 bool) net.IP {
+		for _, ip := range addresses[hostname] {
+			if (ip.To4() == nil) == ipv6 {
+				return ip
+			}
+		}
+
+		return nil
+	}
+}
+
+// renderEntryValue expands the {{ipv4 "<instance>"}} and {{ipv6 "<instance>"}} references in a record value.
+func renderEntryValue(value string, lookup func(hostname string, ipv6 bool) net.IP) (string, error) {
+	if !strings.Contains(value, "{{") {
+		return value, nil
+	}
+
+	getAddress := func(ipv6 bool) func(hostname string) (string, error) {
+		return func(hostname string) (string, error) {
+			ip := lookup(hostname, ipv6)
+			if ip == nil {
+				if ipv6 {
+					return "", fmt.Errorf("No IPv6 address found for %q", hostname)
+				}
+
+				return "", fmt.Errorf("No IPv4 address found for %q", hostname)
+			}
+
+			return ip.String(), nil
+		}
+	}
+
+	tpl, err := template.New("").Funcs(template.FuncMap{
+		"ipv4": getAddress(false),
+		"ipv6": getAddress(true),
+	}).Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("Invalid record template: %w", err)
+	}
+
+	sb := &strings.Builder{}
+	err = tpl.Execute(sb, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
@@ -375,6 +375,21 @@ func (d *zone) Content() (*strings.Builder, error) {
 		return nil, err
 	}
 
+	// Check if dealing with a reverse zone.
+	isReverse4 := strings.HasSuffix(d.info.Name, ip4Arpa)
+	isReverse6 := strings.HasSuffix(d.info.Name, ip6Arpa)
+	isReverse := isReverse4 || isReverse6
+
+	// Track instance addresses for use in record templates, keyed by forward zone name.
+	zoneAddresses := map[string]map[string][]net.IP{}
+	addAddress := func(zoneName string, hostname string, ip net.IP) {
+		if zoneAddresses[zoneName] == nil {
+			zoneAddresses[zoneName] = map[string][]net.IP{}
+		}
+
+		zoneAddresses[zoneName][hostname] = append(zoneAddresses[zoneName][hostname], ip)
+	}
+
 	for netProjectName, networks := range projectNetworks {
 		for _, netInfo := range networks {
 			if !d.networkUsesZone(netInfo.Config) {
@@ -392,11 +407,6 @@ func (d *zone) Content() (*strings.Builder, error) {
 			includeV4 := includeNAT || util.IsFalseOrEmpty(netConfig["ipv4.nat"])
 			includeV6 := includeNAT || util.IsFalseOrEmpty(netConfig["ipv6.nat"])
 
-			// Check if dealing with a reverse zone.
-			isReverse4 := strings.HasSuffix(d.info.Name, ip4Arpa)
-			isReverse6 := strings.HasSuffix(d.info.Name, ip6Arpa)
-			isReverse := isReverse4 || isReverse6
-
 			genRecord := func(name string, ip net.IP) map[string]string {
 				isV4 := ip.To4() != nil
 
@@ -421,24 +431,7 @@ func (d *zone) Content() (*strings.Builder, error) {
 					record["name"] = name
 					record["value"] = ip.String()
 				} else {
-					// Skip PTR records for wrong family.
-					if isV4 && !isReverse4 {
-						return nil
-					}
-
-					if !isV4 && !isReverse6 {
-						return nil
-					}
-
-					// Get the ARPA record.
-					reverseAddr := reverse(ip)
-					if reverseAddr == "" {
-						return nil
-					}
-
-					record["type"] = "PTR"
-					record["name"] = strings.TrimSuffix(reverseAddr, "."+d.info.Name+".")
-					record["value"] = name + "."
+					return d.ptrRecord(name, ip)
 				}
 
 				return record
@@ -462,6 +455,7 @@ func (d *zone) Content() (*strings.Builder, error) {
 					// Convert leases to usable PTR records.
 					for _, lease := range leases {
 						ip := net.ParseIP(lease.Address)
+						addAddress(forwardZoneName, lease.Hostname, ip)
 
 						// Get the record.
 						record := genRecord(fmt.Sprintf("%s.%s", lease.Hostname, forwardZoneName), ip)
@@ -482,6 +476,7 @@ func (d *zone) Content() (*strings.Builder, error) {
 				// Convert leases to usable records.
 				for _, lease := range leases {
 					ip := net.ParseIP(lease.Address)
+					addAddress(d.info.Name, lease.Hostname, ip)
 
 					// Get the record.
 					record := genRecord(lease.Hostname, ip)
@@ -495,6 +490,46 @@ func (d *zone) Content() (*strings.Builder, error) {
 		}
 	}
 
+	// Add PTR records for the A and AAAA extra records of the forward zones.
+	if isReverse {
+		for forwardZoneName, addresses := range zoneAddresses {
+			forwardZone, err := LoadByNameAndProject(d.state, zoneProjects[forwardZoneName], forwardZoneName)
+			if err != nil {
+				return nil, err
+			}
+
+			forwardRecords, err := forwardZone.GetRecords()
+			if err != nil {
+				return nil, err
+			}
+
+			for _, forwardRecord := range forwardRecords {
+				for _, entry := range forwardRecord.Entries {
+					if entry.Type != "A" && entry.Type != "AAAA" {
+						continue
+					}
+
+					value, err := renderEntryValue(entry.Value, addressLookup(addresses))
+					if err != nil {
+						continue
+					}
+
+					name := forwardZoneName
+					if forwardRecord.Name != "@" {
+						name = fmt.Sprintf("%s.%s", forwardRecord.Name, forwardZoneName)
+					}
+
+					record := d.ptrRecord(name, net.ParseIP(value))
+					if record == nil {
+						continue
+					}
+
+					records = append(records, record)
+				}
+			}
+		}
+	}
+
 	// Add the extra records.
 	extraRecords, err := d.GetRecords()
 	if err != nil {
@@ -503,6 +538,13 @@ func (d *zone) Content() (*strings.Builder, error) {
 
 	for _, extraRecord := range extraRecords {
 		for _, entry := range extraRecord.Entries {
+			// Expand any references to instance addresses, skipping entries that can't be resolved.
+			value, err := renderEntryValue(entry.Value, addressLookup(zoneAddresses[d.info.Name]))
+			if err != nil {
+				d.logger.Debug("Skipping zone record entry", logger.Ctx{"name": extraRecord.Name, "type": entry.Type, "err": err})
+				continue
+			}
+
 			record := map[string]string{}
 			if entry.TTL > 0 {
 				record["ttl"] = fmt.Sprintf("%d", entry.TTL)
@@ -512,7 +554,7 @@ func (d *zone) Content() (*strings.Builder, error) {
 
 			record["type"] = entry.Type
 			record["name"] = extraRecord.Name
-			record["value"] = entry.Value
+			record["value"] = value
 
 			records = append(records, record)
 		}
@@ -550,6 +592,42 @@ func (d *zone) Content() (*strings.Builder, error) {
 	return sb, nil
 }
 
+// ptrRecord returns the PTR record pointing the address to the name or nil if the address isn't covered by the zone.
+func (d *zone) ptrRecord(name string, ip net.IP) map[string]string {
+	if ip == nil {
+		return nil
+	}
+
+	// Skip PTR records for wrong family.
+	isV4 := ip.To4() != nil
+	if isV4 && !strings.HasSuffix(d.info.Name, ip4Arpa) {
+		return nil
+	}
+
+	if !isV4 && !strings.HasSuffix(d.info.Name, ip6Arpa) {
+		return nil
+	}
+
+	// Get the ARPA record.
+	reverseAddr := reverse(ip)
+	if reverseAddr == "" {
+		return nil
+	}
+
+	// Skip addresses outside of the (possibly delegated) range handled by the zone.
+	suffix := "." + d.info.Name + "."
+	if !strings.HasSuffix(reverseAddr, suffix) {
+		return nil
+	}
+
+	return map[string]string{
+		"ttl":   "300",
+		"type":  "PTR",
+		"name":  strings.TrimSuffix(reverseAddr, suffix),
+		"value": name + ".",
+	}
+}
+
 // SOA returns just the DNS zone SOA record.
 func (d *zone) SOA() (*strings.Builder, error) {
 	// Get the nameservers.
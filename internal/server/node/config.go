@@ -5,9 +5,11 @@ import (
 	"fmt"
 
 	"github.com/lxc/incus/v6/internal/ports"
+	"github.com/lxc/incus/v6/internal/server/binfmt"
 	"github.com/lxc/incus/v6/internal/server/config"
 	"github.com/lxc/incus/v6/internal/server/db"
 	internalUtil "github.com/lxc/incus/v6/internal/util"
+	"github.com/lxc/incus/v6/shared/util"
 	"github.com/lxc/incus/v6/shared/validate"
 )
 
@@ -90,6 +92,11 @@ func (c *Config) MetricsAddress() string {
 	return metricsAddress
 }
 
+// InstancesEmulationArchitectures returns the architectures to emulate through qemu-user.
+func (c *Config) InstancesEmulationArchitectures() []string {
+	return util.SplitNTrimSpace(c.m.GetString("instances.emulation.architectures"), ",", -1, true)
+}
+
 // NetworkOVSConnection returns the OVS socket path.
 func (c *Config) NetworkOVSConnection() string {
 	return c.m.GetString("network.ovs.connection")
@@ -253,6 +260,18 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Whether to enable the syslog unixgram socket listener
 	"core.syslog_socket": {Validator: validate.Optional(validate.IsBool), Type: config.Bool},
 
+	// Foreign architectures
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.emulation.architectures)
+	// Specify a comma-separated list of architectures, for example `aarch64,riscv64`.
+	// Incus registers the matching `qemu-user` static emulator with `binfmt_misc`, which allows running containers of those architectures on this server.
+	// See {ref}`containers-foreign-architectures`.
+	// ---
+	//  type: string
+	//  scope: local
+	//  shortdesc: Foreign architectures to run containers of through emulation
+	"instances.emulation.architectures": {Validator: validate.Optional(validate.IsListOf(binfmt.ValidArchitecture))},
+
 	// gendoc:generate(entity=server, group=miscellaneous, key=network.ovs.connection)
 	//
 	// ---
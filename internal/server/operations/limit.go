@@ -0,0 +1,53 @@
+package operations
+
+import (
+	"context"
+	"sync"
+)
+
+// expensiveOperations restricts the number of expensive operations running at once.
+var expensiveOperations = newOperationLimiter()
+
+// operationLimiter is a counting semaphore whose limit is given on each acquisition,
+// so that it follows the changes of the configuration.
+type operationLimiter struct {
+	mu      sync.Mutex
+	running int
+	changed chan struct{}
+}
+
+func newOperationLimiter() *operationLimiter {
+	return &operationLimiter{changed: make(chan struct{})}
+}
+
+// acquire waits until less than limit operations are running, limit being 0 for unlimited.
+func (l *operationLimiter) acquire(ctx context.Context, limit int) error {
+	for {
+		l.mu.Lock()
+		if limit <= 0 || l.running < limit {
+			l.running++
+			l.mu.Unlock()
+
+			return nil
+		}
+
+		changed := l.changed
+		l.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// release marks an operation as done and wakes up the queued ones.
+func (l *operationLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.running--
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
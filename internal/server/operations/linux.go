@@ -4,6 +4,7 @@ package operations
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/lxc/incus/v6/internal/server/db"
@@ -33,6 +34,29 @@ func registerDBOperation(op *Operation, opType operationtype.Type) error {
 			opInfo.ProjectID = &projectID
 		}
 
+		// Record the affected resources so that they can be rolled back if the server crashes.
+		if len(op.resources) > 0 || len(op.created) > 0 {
+			resources := make(map[string][]string, len(op.resources)+len(op.created))
+			for key, value := range op.resources {
+				for _, u := range value {
+					resources[key] = append(resources[key], u.String())
+				}
+			}
+
+			for key, value := range op.created {
+				for _, u := range value {
+					resources[CreatedResourcePrefix+key] = append(resources[CreatedResourcePrefix+key], u.String())
+				}
+			}
+
+			data, err := json.Marshal(resources)
+			if err != nil {
+				return err
+			}
+
+			opInfo.Resources = string(data)
+		}
+
 		_, err := cluster.CreateOrReplaceOperation(ctx, tx.Tx(), opInfo)
 		return err
 	})
@@ -62,3 +86,36 @@ func (op *Operation) sendEvent(eventMessage any) {
 
 	_ = op.events.Send(op.projectName, api.EventTypeOperation, eventMessage)
 }
+
+// queue waits for an expensive operation to be allowed to run and returns the function to call once it's done.
+func (op *Operation) queue() (func(), error) {
+	if op.class != OperationClassTask || !op.dbOpType.Expensive() || op.state == nil || op.state.GlobalConfig == nil {
+		return func() {}, nil
+	}
+
+	// Let Cancel abort the wait while the operation is queued.
+	ctx, dequeue := context.WithCancel(op.state.ShutdownCtx)
+	defer dequeue()
+
+	op.lock.Lock()
+	op.dequeue = dequeue
+	op.lock.Unlock()
+
+	err := expensiveOperations.acquire(ctx, op.state.GlobalConfig.MaxConcurrentOperations())
+
+	op.lock.Lock()
+	op.dequeue = nil
+	if err == nil && ctx.Err() != nil {
+		// Cancelled right as a slot was freed.
+		expensiveOperations.release()
+		err = ctx.Err()
+	}
+
+	op.lock.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return expensiveOperations.release, nil
+}
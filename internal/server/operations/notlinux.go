@@ -32,3 +32,8 @@ func (op *Operation) sendEvent(eventMessage any) {
 
 	op.events.Send(op.projectName, api.EventTypeOperation, eventMessage)
 }
+
+// queue doesn't limit the operations outside of the daemon, returning a no-op release function.
+func (op *Operation) queue() (func(), error) {
+	return func() {}, nil
+}
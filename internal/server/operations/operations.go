@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/lxc/incus/v6/internal/server/auth"
 	"github.com/lxc/incus/v6/internal/server/db/operationtype"
@@ -16,6 +18,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
 	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/internal/server/tracing"
 	"github.com/lxc/incus/v6/internal/version"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/cancel"
@@ -97,7 +100,10 @@ type Operation struct {
 	status      api.StatusCode
 	url         string
 	resources   map[string][]api.URL
+	created     map[string][]api.URL
 	metadata    map[string]any
+	progress    *api.OperationProgress
+	timings     []*operationTiming
 	err         error
 	readonly    bool
 	canceler    *cancel.HTTPRequestCanceller
@@ -107,6 +113,8 @@ type Operation struct {
 	dbOpType    operationtype.Type
 	requestor   *api.EventLifecycleRequestor
 	logger      logger.Logger
+	traceCtx    context.Context
+	span        trace.Span
 
 	// Those functions are called at various points in the Operation lifecycle
 	onRun     func(*Operation) error
@@ -116,6 +124,9 @@ type Operation struct {
 	// Indicates if operation has finished.
 	finished *cancel.Canceller
 
+	// Aborts the wait of an expensive operation that is queued.
+	dequeue context.CancelFunc
+
 	// Locking for concurrent access to the Operation
 	lock sync.Mutex
 
@@ -186,12 +197,21 @@ func OperationCreate(s *state.State, projectName string, opClass OperationClass,
 		op.SetRequestor(r)
 	}
 
+	// Trace the operation as part of the request that created it, if any.
+	parentCtx := context.Background()
+	if r != nil {
+		parentCtx = tracing.Detach(r.Context())
+	}
+
+	op.traceCtx, op.span = tracing.Start(parentCtx, op.description, attribute.String("incus.operation", op.id), attribute.String("incus.project", op.projectName), attribute.String("incus.operation_class", op.class.String()))
+
 	operationsLock.Lock()
 	operations[op.id] = &op
 	operationsLock.Unlock()
 
 	err = registerDBOperation(&op, opType)
 	if err != nil {
+		tracing.End(op.span, err)
 		return nil, err
 	}
 
@@ -220,6 +240,15 @@ func (op *Operation) CopyRequestor(otherOp *Operation) {
 	op.requestor = otherOp.requestor
 }
 
+// TraceContext returns a context carrying the span of the operation, to trace the work done as part of it.
+func (op *Operation) TraceContext() context.Context {
+	if op == nil || op.traceCtx == nil {
+		return context.Background()
+	}
+
+	return op.traceCtx
+}
+
 // Requestor returns the initial requestor for this operation.
 func (op *Operation) Requestor() *api.EventLifecycleRequestor {
 	return op.requestor
@@ -236,6 +265,7 @@ func (op *Operation) done() {
 	op.onCancel = nil
 	op.onConnect = nil
 	op.finished.Cancel()
+	tracing.End(op.span, op.err)
 	op.lock.Unlock()
 
 	go func() {
@@ -286,9 +316,31 @@ func (op *Operation) Start() error {
 
 	if op.onRun != nil {
 		go func(op *Operation) {
-			err := op.onRun(op)
+			// Expensive operations may have to wait for others to complete.
+			release, err := op.queue()
+			if err == nil {
+				err = op.onRun(op)
+				release()
+			}
+
 			if err != nil {
 				op.lock.Lock()
+				if op.status == api.Cancelling {
+					// Cancelled while queued.
+					op.status = api.Cancelled
+					op.lock.Unlock()
+					op.done()
+
+					op.logger.Debug("Cancelled queued operation")
+					_, md, _ := op.Render()
+
+					op.lock.Lock()
+					op.sendEvent(md)
+					op.lock.Unlock()
+
+					return
+				}
+
 				op.status = api.Failure
 				op.err = err
 				op.lock.Unlock()
@@ -339,13 +391,31 @@ func (op *Operation) Cancel() (chan error, error) {
 		return nil, fmt.Errorf("Only running operations can be cancelled")
 	}
 
+	chanCancel := make(chan error, 1)
+
+	if op.dequeue != nil {
+		// Queued operations haven't started yet, so they can always be cancelled.
+		op.status = api.Cancelling
+		op.dequeue()
+		op.lock.Unlock()
+
+		op.logger.Debug("Cancelling queued operation")
+		_, md, _ := op.Render()
+
+		op.lock.Lock()
+		op.sendEvent(md)
+		op.lock.Unlock()
+
+		chanCancel <- nil
+
+		return chanCancel, nil
+	}
+
 	if !op.mayCancel() {
 		op.lock.Unlock()
 		return nil, fmt.Errorf("This operation can't be cancelled")
 	}
 
-	chanCancel := make(chan error, 1)
-
 	oldStatus := op.status
 	op.status = api.Cancelling
 	op.lock.Unlock()
@@ -503,6 +573,13 @@ func (op *Operation) Render() (string, *api.Operation, error) {
 		MayCancel:   op.mayCancel(),
 	}
 
+	if op.progress != nil {
+		progress := *op.progress
+		retOp.Progress = &progress
+	}
+
+	retOp.Timings = op.renderTimings()
+
 	if op.state != nil {
 		retOp.Location = op.state.ServerName
 	}
@@ -527,6 +604,20 @@ func (op *Operation) Wait(ctx context.Context) error {
 	}
 }
 
+// CreatedResourcePrefix prefixes the keys of the resources created by the operation, in the resources recorded
+// in the database.
+const CreatedResourcePrefix = "created:"
+
+// SetCreated records the resources which the operation creates from scratch, so that they get deleted if the
+// server goes down before the operation completes. Those aren't part of the resources shown to the clients.
+func (op *Operation) SetCreated(opResources map[string][]api.URL) error {
+	op.lock.Lock()
+	op.created = opResources
+	op.lock.Unlock()
+
+	return registerDBOperation(op, op.dbOpType)
+}
+
 // UpdateResources updates the resources of the operation. It returns an error
 // if the operation is not pending or running, or the operation is read-only.
 func (op *Operation) UpdateResources(opResources map[string][]api.URL) error {
@@ -576,6 +667,7 @@ func (op *Operation) UpdateMetadata(opMetadata any) error {
 
 	op.updatedAt = time.Now()
 	op.metadata = newMetadata
+	op.updateProgress()
 	op.lock.Unlock()
 
 	op.logger.Debug("Updated metadata for operation")
@@ -627,6 +719,7 @@ func (op *Operation) ExtendMetadata(metadata any) error {
 	op.lock.Lock()
 	op.updatedAt = time.Now()
 	op.metadata = newMetadata
+	op.updateProgress()
 	op.lock.Unlock()
 
 	op.logger.Debug("Updated metadata for operation")
@@ -639,6 +732,30 @@ func (op *Operation) ExtendMetadata(metadata any) error {
 	return nil
 }
 
+// updateProgress refreshes the progress of the operation from its metadata, estimating the
+// remaining time of the current stage from its elapsed time and completion percentage.
+// The caller must hold the operation lock.
+func (op *Operation) updateProgress() {
+	progress := parseProgress(op.metadata)
+	if progress == nil {
+		op.progress = nil
+		return
+	}
+
+	if op.progress != nil && op.progress.Stage == progress.Stage {
+		progress.StartedAt = op.progress.StartedAt
+	} else {
+		progress.StartedAt = time.Now()
+	}
+
+	if progress.Percent > 0 && progress.Percent < 100 {
+		elapsed := time.Since(progress.StartedAt)
+		progress.ETA = int64(elapsed.Seconds() * float64(100-progress.Percent) / float64(progress.Percent))
+	}
+
+	op.progress = progress
+}
+
 // ID returns the operation ID.
 func (op *Operation) ID() string {
 	return op.id
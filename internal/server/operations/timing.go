@@ -0,0 +1,82 @@
+package operations
+
+import (
+	"time"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// operationTiming records the time spent by an operation in one of its phases.
+// Overlapping runs of a phase, like the two ends of a local migration, are only counted once.
+type operationTiming struct {
+	phase     string
+	startedAt time.Time
+	duration  time.Duration
+	active    int
+	since     time.Time
+}
+
+// StartPhase records the start of a phase of the operation, returning the function recording its end.
+func (op *Operation) StartPhase(phase string) func() {
+	if op == nil {
+		return func() {}
+	}
+
+	op.lock.Lock()
+	defer op.lock.Unlock()
+
+	var timing *operationTiming
+	for _, entry := range op.timings {
+		if entry.phase == phase {
+			timing = entry
+			break
+		}
+	}
+
+	now := time.Now()
+
+	if timing == nil {
+		timing = &operationTiming{phase: phase, startedAt: now}
+		op.timings = append(op.timings, timing)
+	}
+
+	if timing.active == 0 {
+		timing.since = now
+	}
+
+	timing.active++
+
+	return func() {
+		op.lock.Lock()
+		defer op.lock.Unlock()
+
+		timing.active--
+		if timing.active == 0 {
+			timing.duration += time.Since(timing.since)
+		}
+	}
+}
+
+// renderTimings returns the time spent in each phase of the operation, including the running ones.
+// Must be called with the operation lock held.
+func (op *Operation) renderTimings() []api.OperationTiming {
+	if len(op.timings) == 0 {
+		return nil
+	}
+
+	timings := make([]api.OperationTiming, 0, len(op.timings))
+	for _, timing := range op.timings {
+		duration := timing.duration
+		if timing.active > 0 {
+			duration += time.Since(timing.since)
+		}
+
+		timings = append(timings, api.OperationTiming{
+			Phase:     timing.phase,
+			StartedAt: timing.startedAt,
+			Duration:  duration.Seconds(),
+		})
+	}
+
+	return timings
+}
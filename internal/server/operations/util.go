@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strconv"
 
+	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/units"
 )
 
@@ -32,8 +33,8 @@ func parseMetadata(metadata any) (map[string]any, error) {
 	return newMetadata, nil
 }
 
-// SetProgressMetadata updates an operation metadata map with the provided progress data.
-func SetProgressMetadata(metadata map[string]any, stage, displayPrefix string, percent, processed, speed int64) {
+// SetProgressData updates an operation metadata map with the machine readable progress data.
+func SetProgressData(metadata map[string]any, stage string, percent, processed, speed int64) {
 	progress := make(map[string]string)
 	// stage, percent, speed sent for API callers.
 	progress["stage"] = stage
@@ -47,6 +48,11 @@ func SetProgressMetadata(metadata map[string]any, stage, displayPrefix string, p
 
 	progress["speed"] = strconv.FormatInt(speed, 10)
 	metadata["progress"] = progress
+}
+
+// SetProgressMetadata updates an operation metadata map with the provided progress data.
+func SetProgressMetadata(metadata map[string]any, stage, displayPrefix string, percent, processed, speed int64) {
+	SetProgressData(metadata, stage, percent, processed, speed)
 
 	// <stage>_progress with formatted text.
 	if percent > 0 {
@@ -61,3 +67,30 @@ func SetProgressMetadata(metadata map[string]any, stage, displayPrefix string, p
 		metadata[stage+"_progress"] = fmt.Sprintf("%s: %s/s", displayPrefix, units.GetByteSizeString(speed, 2))
 	}
 }
+
+// parseProgress returns the progress data recorded in an operation metadata map.
+func parseProgress(metadata map[string]any) *api.OperationProgress {
+	data := map[string]string{}
+
+	switch value := metadata["progress"].(type) {
+	case map[string]string:
+		data = value
+	case map[string]any:
+		for k, v := range value {
+			str, ok := v.(string)
+			if ok {
+				data[k] = str
+			}
+		}
+
+	default:
+		return nil
+	}
+
+	progress := api.OperationProgress{Stage: data["stage"]}
+	progress.Percent, _ = strconv.ParseInt(data["percent"], 10, 64)
+	progress.Processed, _ = strconv.ParseInt(data["processed"], 10, 64)
+	progress.Speed, _ = strconv.ParseInt(data["speed"], 10, 64)
+
+	return &progress
+}
@@ -0,0 +1,54 @@
+package project
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// AuditRestrictions returns the configuration keys and devices of the instances and profiles of a project which
+// violate its current restrictions, for example because they were set before the restrictions were tightened.
+func AuditRestrictions(tx *db.ClusterTx, projectName string) ([]api.ProjectAuditViolation, error) {
+	info, err := fetchProject(tx, projectName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []api.ProjectAuditViolation{}
+
+	if util.IsFalseOrEmpty(info.Project.Config["restricted"]) {
+		return result, nil
+	}
+
+	// Only audit the profiles of the project itself, not the ones of the default project.
+	profiles := []api.Profile{}
+	for _, profile := range info.Profiles {
+		if profile.Project == projectName {
+			profiles = append(profiles, profile)
+		}
+	}
+
+	violations, err := getRestrictionViolations(info.Project, info.Instances, profiles)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, violation := range violations {
+		result = append(result, api.ProjectAuditViolation{
+			EntityType:  violation.entityType,
+			EntityName:  violation.entityName,
+			Key:         violation.key,
+			Device:      violation.device,
+			Description: violation.err.Error(),
+		})
+	}
+
+	slices.SortFunc(result, func(a api.ProjectAuditViolation, b api.ProjectAuditViolation) int {
+		return cmp.Or(cmp.Compare(a.EntityType, b.EntityType), cmp.Compare(a.EntityName, b.EntityName), cmp.Compare(a.Key, b.Key), cmp.Compare(a.Device, b.Device))
+	})
+
+	return result, nil
+}
@@ -0,0 +1,178 @@
+package project
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// restrictionLevels ranks the values of the restricted.* options, from the most restrictive to the least one.
+var restrictionLevels = map[string]int{
+	"block":        0,
+	"isolated":     1,
+	"managed":      1,
+	"unprivileged": 2,
+	"allow":        3,
+	"full":         4,
+}
+
+// restrictionListKeys are the restricted.* options holding a list of allowed entries.
+var restrictionListKeys = []string{
+	"restricted.cluster.groups",
+	"restricted.devices.disk.paths",
+	"restricted.idmap.gid",
+	"restricted.idmap.uid",
+	"restricted.networks.access",
+	"restricted.networks.integrations",
+	"restricted.networks.subnets",
+	"restricted.networks.uplinks",
+	"restricted.networks.zones",
+}
+
+// restrictionDefaults are the restricted.* options which don't default to block.
+var restrictionDefaults = map[string]string{
+	"restricted.containers.privilege": "unprivileged",
+	"restricted.devices.disk":         "managed",
+	"restricted.devices.nic":          "managed",
+}
+
+// IsInheritedConfigKey returns whether a project configuration key is inherited by the child projects.
+func IsInheritedConfigKey(key string) bool {
+	return key == "restricted" || strings.HasPrefix(key, "restricted.") || strings.HasPrefix(key, "limits.")
+}
+
+// InheritConfig adds the inherited configuration of the parent project which isn't set in the given configuration.
+func InheritConfig(parentConfig map[string]string, config map[string]string) {
+	for key, value := range parentConfig {
+		_, ok := config[key]
+		if !ok && IsInheritedConfigKey(key) {
+			config[key] = value
+		}
+	}
+}
+
+// CheckInheritedConfig checks that the configuration of a child project is at least as restrictive as the
+// one of its parent project.
+func CheckInheritedConfig(parentConfig map[string]string, config map[string]string) error {
+	restricted := util.IsTrue(parentConfig["restricted"])
+	if restricted && !util.IsTrue(config["restricted"]) {
+		return fmt.Errorf(`The "restricted" configuration can't be disabled as the parent project is restricted`)
+	}
+
+	// Get all the inherited keys set on either side.
+	keys := []string{}
+	for _, cfg := range []map[string]string{parentConfig, config} {
+		for key := range cfg {
+			if key != "restricted" && IsInheritedConfigKey(key) && !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		parentValue, parentOk := parentConfig[key]
+		value, ok := config[key]
+
+		if strings.HasPrefix(key, "limits.") {
+			if !parentOk || parentValue == "" {
+				continue
+			}
+
+			if !ok || value == "" {
+				return fmt.Errorf("The %q configuration must be set as the parent project limits it to %q", key, parentValue)
+			}
+
+			looser, err := isLimitLooser(key, parentValue, value)
+			if err != nil {
+				return err
+			}
+
+			if looser {
+				return fmt.Errorf("The %q configuration can't exceed %q as set on the parent project", key, parentValue)
+			}
+
+			continue
+		}
+
+		// Restrictions only matter if the parent project is restricted.
+		if !restricted {
+			continue
+		}
+
+		if slices.Contains(restrictionListKeys, key) {
+			if !parentOk || parentValue == "" {
+				continue
+			}
+
+			parentEntries := util.SplitNTrimSpace(parentValue, ",", -1, true)
+			entries := util.SplitNTrimSpace(value, ",", -1, true)
+			if len(entries) == 0 {
+				return fmt.Errorf("The %q configuration must be set as the parent project limits it to %q", key, parentValue)
+			}
+
+			for _, entry := range entries {
+				if !slices.Contains(parentEntries, entry) {
+					return fmt.Errorf("The %q configuration can't include %q as it isn't allowed by the parent project", key, entry)
+				}
+			}
+
+			continue
+		}
+
+		if parentValue == "" {
+			parentValue = restrictionDefaults[key]
+			if parentValue == "" {
+				parentValue = "block"
+			}
+		}
+
+		if value == "" {
+			value = restrictionDefaults[key]
+			if value == "" {
+				value = "block"
+			}
+		}
+
+		parentLevel, parentOk := restrictionLevels[parentValue]
+		level, ok := restrictionLevels[value]
+		if parentOk && ok && level > parentLevel {
+			return fmt.Errorf("The %q configuration can't be set to %q as the parent project sets it to %q", key, value, parentValue)
+		}
+	}
+
+	return nil
+}
+
+// isLimitLooser returns whether the value of a limit exceeds the one of the parent project.
+func isLimitLooser(key string, parentValue string, value string) (bool, error) {
+	parse := func(value string) (int64, error) {
+		return strconv.ParseInt(value, 10, 64)
+	}
+
+	keyName := key
+	if strings.HasPrefix(key, projectLimitDiskPool) {
+		keyName = "limits.disk"
+	}
+
+	parser, ok := aggregateLimitConfigValueParsers[keyName]
+	if ok {
+		parse = parser
+	}
+
+	parentLimit, err := parse(parentValue)
+	if err != nil {
+		return false, fmt.Errorf("Invalid value %q for %q on the parent project: %w", parentValue, key, err)
+	}
+
+	limit, err := parse(value)
+	if err != nil {
+		return false, fmt.Errorf("Invalid value %q for %q: %w", value, key, err)
+	}
+
+	return limit > parentLimit, nil
+}
@@ -0,0 +1,85 @@
+package project_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/internal/server/project"
+)
+
+func TestInheritConfig(t *testing.T) {
+	config := map[string]string{"limits.cpu": "4", "features.images": "false"}
+	project.InheritConfig(map[string]string{"limits.cpu": "8", "limits.memory": "8GiB", "restricted": "true", "features.images": "true", "user.foo": "bar"}, config)
+
+	assert.Equal(t, map[string]string{"limits.cpu": "4", "limits.memory": "8GiB", "restricted": "true", "features.images": "false"}, config)
+}
+
+func TestCheckInheritedConfig(t *testing.T) {
+	parentConfig := map[string]string{
+		"restricted":                    "true",
+		"restricted.devices.disk":       "allow",
+		"restricted.containers.nesting": "allow",
+		"restricted.networks.access":    "net1,net2",
+		"limits.cpu":                    "8",
+		"limits.memory":                 "8GiB",
+	}
+
+	tests := []struct {
+		name   string
+		config map[string]string
+		err    string
+	}{
+		{
+			name:   "Same configuration",
+			config: parentConfig,
+		},
+		{
+			name: "Tighter configuration",
+			config: map[string]string{
+				"restricted":                    "true",
+				"restricted.devices.disk":       "managed",
+				"restricted.containers.nesting": "block",
+				"restricted.networks.access":    "net2",
+				"limits.cpu":                    "4",
+				"limits.memory":                 "4GiB",
+			},
+		},
+		{
+			name:   "Unrestricted",
+			config: map[string]string{"limits.cpu": "8", "limits.memory": "8GiB"},
+			err:    `The "restricted" configuration can't be disabled as the parent project is restricted`,
+		},
+		{
+			name:   "Missing limit",
+			config: map[string]string{"restricted": "true", "restricted.networks.access": "net1", "limits.cpu": "8"},
+			err:    `The "limits.memory" configuration must be set as the parent project limits it to "8GiB"`,
+		},
+		{
+			name:   "Higher limit",
+			config: map[string]string{"restricted": "true", "restricted.networks.access": "net1", "limits.cpu": "16", "limits.memory": "8GiB"},
+			err:    `The "limits.cpu" configuration can't exceed "8" as set on the parent project`,
+		},
+		{
+			name:   "Looser restriction",
+			config: map[string]string{"restricted": "true", "restricted.networks.access": "net1", "restricted.devices.gpu": "allow", "limits.cpu": "8", "limits.memory": "8GiB"},
+			err:    `The "restricted.devices.gpu" configuration can't be set to "allow" as the parent project sets it to "block"`,
+		},
+		{
+			name:   "Additional network",
+			config: map[string]string{"restricted": "true", "restricted.networks.access": "net1,net3", "limits.cpu": "8", "limits.memory": "8GiB"},
+			err:    `The "restricted.networks.access" configuration can't include "net3" as it isn't allowed by the parent project`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := project.CheckInheritedConfig(parentConfig, tt.config)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// maintenanceWindowDays maps the day names used in maintenance windows to week days.
+var maintenanceWindowDays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// maintenanceWindow is a daily time range during which background tasks are allowed.
+type maintenanceWindow struct {
+	days  [7]bool
+	start int
+	end   int
+}
+
+// contains returns whether the given time falls within the window.
+func (w maintenanceWindow) contains(t time.Time) bool {
+	day := t.Weekday()
+	minute := t.Hour()*60 + t.Minute()
+
+	if w.start < w.end {
+		return w.days[day] && minute >= w.start && minute < w.end
+	}
+
+	// The window crosses midnight, the days apply to its start.
+	return (w.days[day] && minute >= w.start) || (w.days[(day+6)%7] && minute < w.end)
+}
+
+// parseMaintenanceWindows parses a comma separated list of "[<day>[-<day>]] <HH:MM>-<HH:MM>" time ranges.
+func parseMaintenanceWindows(value string) ([]maintenanceWindow, error) {
+	parseDay := func(name string) (time.Weekday, error) {
+		day, ok := maintenanceWindowDays[strings.ToLower(name)]
+		if !ok {
+			return 0, fmt.Errorf("Invalid day %q", name)
+		}
+
+		return day, nil
+	}
+
+	parseTime := func(value string) (int, error) {
+		hours, minutes, ok := strings.Cut(value, ":")
+		if !ok {
+			return -1, fmt.Errorf("Invalid time %q", value)
+		}
+
+		h, err := strconv.Atoi(hours)
+		if err != nil || h < 0 || h > 24 {
+			return -1, fmt.Errorf("Invalid time %q", value)
+		}
+
+		m, err := strconv.Atoi(minutes)
+		if err != nil || m < 0 || m > 59 || (h == 24 && m != 0) {
+			return -1, fmt.Errorf("Invalid time %q", value)
+		}
+
+		return h*60 + m, nil
+	}
+
+	windows := []maintenanceWindow{}
+	for _, entry := range util.SplitNTrimSpace(value, ",", -1, true) {
+		window := maintenanceWindow{}
+
+		fields := strings.Fields(entry)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, fmt.Errorf("Invalid maintenance window %q", entry)
+		}
+
+		// Get the days, all of them by default.
+		if len(fields) == 2 {
+			first, last, ok := strings.Cut(fields[0], "-")
+			if !ok {
+				last = first
+			}
+
+			firstDay, err := parseDay(first)
+			if err != nil {
+				return nil, err
+			}
+
+			lastDay, err := parseDay(last)
+			if err != nil {
+				return nil, err
+			}
+
+			for day := firstDay; ; day = (day + 1) % 7 {
+				window.days[day] = true
+
+				if day == lastDay {
+					break
+				}
+			}
+
+			fields = fields[1:]
+		} else {
+			for day := range window.days {
+				window.days[day] = true
+			}
+		}
+
+		// Get the time range.
+		start, end, ok := strings.Cut(fields[0], "-")
+		if !ok {
+			return nil, fmt.Errorf("Invalid time range %q", fields[0])
+		}
+
+		var err error
+
+		window.start, err = parseTime(start)
+		if err != nil {
+			return nil, err
+		}
+
+		window.end, err = parseTime(end)
+		if err != nil {
+			return nil, err
+		}
+
+		if window.start == window.end {
+			return nil, fmt.Errorf("Empty time range %q", fields[0])
+		}
+
+		windows = append(windows, window)
+	}
+
+	return windows, nil
+}
+
+// ValidateMaintenanceWindow validates the value of the "maintenance.window" project configuration.
+func ValidateMaintenanceWindow(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	_, err := parseMaintenanceWindows(value)
+
+	return err
+}
+
+// InMaintenanceWindow returns whether the background tasks of the project are allowed at the given time.
+// Projects without a maintenance window allow them at any time.
+func InMaintenanceWindow(p api.Project, t time.Time) bool {
+	value := p.Config["maintenance.window"]
+	if value == "" {
+		return true
+	}
+
+	windows, err := parseMaintenanceWindows(value)
+	if err != nil {
+		return true
+	}
+
+	for _, window := range windows {
+		if window.contains(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OutsideMaintenanceWindow returns the names of the projects whose background tasks aren't allowed at the
+// given time.
+func OutsideMaintenanceWindow(ctx context.Context, tx *db.ClusterTx, t time.Time) ([]string, error) {
+	dbProjects, err := cluster.GetProjects(ctx, tx.Tx())
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading projects: %w", err)
+	}
+
+	names := []string{}
+	for _, dbProject := range dbProjects {
+		p, err := dbProject.ToAPI(ctx, tx.Tx())
+		if err != nil {
+			return nil, err
+		}
+
+		if !InMaintenanceWindow(*p, t) {
+			names = append(names, p.Name)
+		}
+	}
+
+	return names, nil
+}
+
+// DeferredTasks tracks the scheduled tasks which were due outside of the maintenance window of their project,
+// so that they run once the window opens instead of being skipped.
+type DeferredTasks struct {
+	mu      sync.Mutex
+	pending map[string]struct{}
+}
+
+// NewDeferredTasks returns a new, empty, DeferredTasks.
+func NewDeferredTasks() *DeferredTasks {
+	return &DeferredTasks{pending: map[string]struct{}{}}
+}
+
+// Due returns whether the task identified by key should run at the given time, which is the case within the
+// maintenance window of the project if it's scheduled then or was deferred earlier. The tasks scheduled
+// outside of the window are deferred.
+func (d *DeferredTasks) Due(p api.Project, key string, scheduled bool, t time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !InMaintenanceWindow(p, t) {
+		if scheduled {
+			d.pending[key] = struct{}{}
+		}
+
+		return false
+	}
+
+	_, deferred := d.pending[key]
+	if !scheduled && !deferred {
+		return false
+	}
+
+	delete(d.pending, key)
+
+	return true
+}
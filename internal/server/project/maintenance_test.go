@@ -0,0 +1,77 @@
+package project_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/internal/server/project"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func TestValidateMaintenanceWindow(t *testing.T) {
+	for _, value := range []string{"", "22:00-06:00", "mon-fri 22:00-06:00, sat-sun 00:00-24:00", "fri-mon 12:30-13:30"} {
+		assert.NoError(t, project.ValidateMaintenanceWindow(value), value)
+	}
+
+	for _, value := range []string{"22:00", "22:00-22:00", "25:00-06:00", "12:60-13:00", "foo 22:00-06:00", "mon fri 22:00-06:00"} {
+		assert.Error(t, project.ValidateMaintenanceWindow(value), value)
+	}
+}
+
+func TestInMaintenanceWindow(t *testing.T) {
+	p := api.Project{}
+	p.Config = map[string]string{"maintenance.window": "mon-fri 22:00-06:00, sat-sun 00:00-24:00"}
+
+	tests := []struct {
+		time    string
+		allowed bool
+	}{
+		{"2024-01-01T21:59:00Z", false}, // Monday evening.
+		{"2024-01-01T22:00:00Z", true},  // Monday night.
+		{"2024-01-02T05:59:00Z", true},  // Tuesday early morning, in the window opened on Monday.
+		{"2024-01-02T12:00:00Z", false}, // Tuesday afternoon.
+		{"2024-01-06T12:00:00Z", true},  // Saturday afternoon.
+		{"2024-01-08T03:00:00Z", false}, // Monday early morning, as no window opens on Sunday night.
+		{"2024-01-08T07:00:00Z", false}, // Monday morning.
+	}
+
+	for _, test := range tests {
+		at, err := time.Parse(time.RFC3339, test.time)
+		assert.NoError(t, err)
+
+		assert.Equal(t, test.allowed, project.InMaintenanceWindow(p, at), test.time)
+	}
+
+	assert.True(t, project.InMaintenanceWindow(api.Project{}, time.Now()))
+}
+
+func TestDeferredTasksDue(t *testing.T) {
+	p := api.Project{}
+	p.Config = map[string]string{"maintenance.window": "22:00-06:00"}
+
+	at := func(value string) time.Time {
+		parsed, err := time.Parse(time.RFC3339, value)
+		assert.NoError(t, err)
+
+		return parsed
+	}
+
+	tasks := project.NewDeferredTasks()
+
+	// Scheduled within the window.
+	assert.True(t, tasks.Due(p, "a", true, at("2024-01-01T23:00:00Z")))
+	assert.False(t, tasks.Due(p, "a", false, at("2024-01-01T23:05:00Z")))
+
+	// Scheduled outside of the window, deferred to its opening.
+	assert.False(t, tasks.Due(p, "a", true, at("2024-01-02T12:00:00Z")))
+	assert.False(t, tasks.Due(p, "a", false, at("2024-01-02T21:59:00Z")))
+	assert.False(t, tasks.Due(p, "b", false, at("2024-01-02T22:00:00Z")))
+	assert.True(t, tasks.Due(p, "a", false, at("2024-01-02T22:00:00Z")))
+	assert.False(t, tasks.Due(p, "a", false, at("2024-01-02T22:01:00Z")))
+
+	// Projects without a window run the tasks when scheduled.
+	assert.True(t, tasks.Due(api.Project{}, "c", true, at("2024-01-02T12:00:00Z")))
+	assert.False(t, tasks.Due(api.Project{}, "c", false, at("2024-01-02T12:01:00Z")))
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/idmap"
 )
 
@@ -75,3 +76,28 @@ func TestParseHostIDMapRange(t *testing.T) {
 		assert.Equal(t, idmaps, expected)
 	}
 }
+
+func TestGetInstanceNICsBandwidth(t *testing.T) {
+	inst := api.Instance{
+		Name:    "c1",
+		Project: "p1",
+		Devices: map[string]map[string]string{
+			"eth0": {"type": "nic", "limits.ingress": "100Mbit", "limits.egress": "50Mbit"},
+			"eth1": {"type": "nic", "limits.max": "10Mbit"},
+			"root": {"type": "disk", "path": "/", "pool": "default"},
+		},
+	}
+
+	bandwidth, err := getInstanceNICsBandwidth(inst, false)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(110_000_000), bandwidth)
+
+	inst.Devices["eth2"] = map[string]string{"type": "nic", "limits.ingress": "1Gbit"}
+
+	_, err = getInstanceNICsBandwidth(inst, false)
+	assert.EqualError(t, err, `Instance "c1" in project "p1" has no "limits.egress" set on NIC "eth2", either directly or via a profile`)
+
+	bandwidth, err = getInstanceNICsBandwidth(inst, true)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1_110_000_000), bandwidth)
+}
@@ -3,6 +3,7 @@ package project
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/http"
 	"path/filepath"
 	"slices"
@@ -369,15 +370,35 @@ func checkRestrictionsAndAggregateLimits(tx *db.ClusterTx, info *projectInfo) er
 	}
 
 	if isRestricted {
-		err = checkRestrictions(info.Project, info.Instances, info.Profiles)
+		violations, err := getRestrictionViolations(info.Project, info.Instances, info.Profiles)
 		if err != nil {
 			return err
 		}
+
+		for _, violation := range violations {
+			if !slices.ContainsFunc(info.tolerated, violation.equal) {
+				return violation.err
+			}
+		}
 	}
 
 	return nil
 }
 
+// Return the restriction violations of the instances and profiles of a restricted project.
+func getProjectRestrictionViolations(info *projectInfo) ([]restrictionViolation, error) {
+	if util.IsFalseOrEmpty(info.Project.Config["restricted"]) {
+		return nil, nil
+	}
+
+	instances, err := expandInstancesConfigAndDevices(info.Instances, info.Profiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return getRestrictionViolations(info.Project, instances, info.Profiles)
+}
+
 func getAggregateLimits(info *projectInfo, aggregateKeys []string) (map[string]api.ProjectStateResource, error) {
 	result := map[string]api.ProjectStateResource{}
 
@@ -444,7 +465,7 @@ func checkAggregateLimits(info *projectInfo, aggregateKeys []string) error {
 		}
 
 		if totals[key] > max {
-			return fmt.Errorf("Reached maximum aggregate value %q for %q in project %q", info.Project.Config[key], key, info.Project.Name)
+			return api.StatusErrorf(http.StatusForbidden, "Reached maximum aggregate value %q for %q in project %q (usage would be %s)", info.Project.Config[key], key, info.Project.Name, aggregateLimitConfigValuePrinters[keyName](totals[key]))
 		}
 	}
 
@@ -476,6 +497,40 @@ func parseHostIDMapRange(isUID bool, isGID bool, listValue string) ([]idmap.Entr
 // Check that the project's restrictions are not violated across the given
 // instances and profiles.
 func checkRestrictions(project api.Project, instances []api.Instance, profiles []api.Profile) error {
+	violations, err := getRestrictionViolations(project, instances, profiles)
+	if err != nil {
+		return err
+	}
+
+	if len(violations) > 0 {
+		return violations[0].err
+	}
+
+	return nil
+}
+
+// restrictionViolation is a configuration key or device of an instance or profile violating the project
+// restrictions.
+type restrictionViolation struct {
+	entityType   string
+	entityName   string
+	key          string
+	value        string
+	device       string
+	deviceConfig map[string]string
+	err          error
+}
+
+// equal returns whether two violations are caused by the same configuration.
+func (v restrictionViolation) equal(other restrictionViolation) bool {
+	return v.entityType == other.entityType && v.entityName == other.entityName && v.key == other.key && v.value == other.value && v.device == other.device && maps.Equal(v.deviceConfig, other.deviceConfig) && v.err.Error() == other.err.Error()
+}
+
+// Return the configuration keys and devices of the given instances and profiles which violate the project's
+// restrictions.
+func getRestrictionViolations(project api.Project, instances []api.Instance, profiles []api.Profile) ([]restrictionViolation, error) {
+	violations := []restrictionViolation{}
+
 	containerConfigChecks := map[string]func(value string) error{}
 	devicesChecks := map[string]func(value map[string]string) error{}
 
@@ -530,7 +585,7 @@ func checkRestrictions(project api.Project, instances []api.Instance, profiles [
 			}
 
 			containerConfigChecks["security.idmap.isolated"] = func(instanceValue string) error {
-				if restrictionValue == "isolated" && util.IsFalseOrEmpty(instanceValue) {
+				if restrictionValue == "isolated" && !IdmapIsolated(&project, map[string]string{"security.idmap.isolated": instanceValue}) {
 					return fmt.Errorf("Non-isolated containers are forbidden")
 				}
 
@@ -682,14 +737,14 @@ func checkRestrictions(project api.Project, instances []api.Instance, profiles [
 			var err error
 			allowedIDMapHostUIDs, err = parseHostIDMapRange(true, false, restrictionValue)
 			if err != nil {
-				return fmt.Errorf("Failed parsing %q: %w", "restricted.idmap.uid", err)
+				return nil, fmt.Errorf("Failed parsing %q: %w", "restricted.idmap.uid", err)
 			}
 
 		case "restricted.idmap.gid":
 			var err error
 			allowedIDMapHostGIDs, err = parseHostIDMapRange(false, true, restrictionValue)
 			if err != nil {
-				return fmt.Errorf("Failed parsing %q: %w", "restricted.idmap.uid", err)
+				return nil, fmt.Errorf("Failed parsing %q: %w", "restricted.idmap.uid", err)
 			}
 		}
 	}
@@ -697,8 +752,14 @@ func checkRestrictions(project api.Project, instances []api.Instance, profiles [
 	// Common config check logic between instances and profiles.
 	entityConfigChecker := func(instType instancetype.Type, entityName string, config map[string]string) error {
 		entityTypeLabel := instType.String()
+		entityType := "instance"
 		if instType == instancetype.Any {
 			entityTypeLabel = "profile"
+			entityType = "profile"
+		}
+
+		addViolation := func(key string, value string, err error) {
+			violations = append(violations, restrictionViolation{entityType: entityType, entityName: entityName, key: key, value: value, err: err})
 		}
 
 		isContainerOrProfile := instType == instancetype.Container || instType == instancetype.Any
@@ -715,7 +776,8 @@ func checkRestrictions(project api.Project, instances []api.Instance, profiles [
 
 				for i, entry := range idmaps.Entries {
 					if !entry.HostIDsCoveredBy(allowedIDMapHostUIDs, allowedIDMapHostGIDs) {
-						return fmt.Errorf(`Use of low-level "raw.idmap" element %d on %s %q of project %q is forbidden`, i, entityTypeLabel, entityName, project.Name)
+						addViolation(key, value, fmt.Errorf(`Use of low-level "raw.idmap" element %d on %s %q of project %q is forbidden`, i, entityTypeLabel, entityName, project.Name))
+						break
 					}
 				}
 
@@ -724,11 +786,13 @@ func checkRestrictions(project api.Project, instances []api.Instance, profiles [
 			}
 
 			if isContainerOrProfile && !allowContainerLowLevel && isContainerLowLevelOptionForbidden(key) {
-				return fmt.Errorf("Use of low-level config %q on %s %q of project %q is forbidden", key, entityTypeLabel, entityName, project.Name)
+				addViolation(key, value, fmt.Errorf("Use of low-level config %q on %s %q of project %q is forbidden", key, entityTypeLabel, entityName, project.Name))
+				continue
 			}
 
 			if isVMOrProfile && !allowVMLowLevel && isVMLowLevelOptionForbidden(key) {
-				return fmt.Errorf("Use of low-level config %q on %s %q of project %q is forbidden", key, entityTypeLabel, entityName, project.Name)
+				addViolation(key, value, fmt.Errorf("Use of low-level config %q on %s %q of project %q is forbidden", key, entityTypeLabel, entityName, project.Name))
+				continue
 			}
 
 			var checker func(value string) error
@@ -742,7 +806,7 @@ func checkRestrictions(project api.Project, instances []api.Instance, profiles [
 
 			err := checker(value)
 			if err != nil {
-				return fmt.Errorf("Invalid value %q for config %q on %s %q of project %q: %w", value, key, instType, entityName, project.Name, err)
+				addViolation(key, value, fmt.Errorf("Invalid value %q for config %q on %s %q of project %q: %w", value, key, instType, entityName, project.Name, err))
 			}
 		}
 
@@ -750,10 +814,12 @@ func checkRestrictions(project api.Project, instances []api.Instance, profiles [
 	}
 
 	// Common devices check logic between instances and profiles.
-	entityDevicesChecker := func(instType instancetype.Type, entityName string, devices map[string]map[string]string) error {
+	entityDevicesChecker := func(instType instancetype.Type, entityName string, devices map[string]map[string]string) {
 		entityTypeLabel := instType.String()
+		entityType := "instance"
 		if instType == instancetype.Any {
 			entityTypeLabel = "profile"
+			entityType = "profile"
 		}
 
 		for name, device := range devices {
@@ -764,42 +830,35 @@ func checkRestrictions(project api.Project, instances []api.Instance, profiles [
 
 			err := check(device)
 			if err != nil {
-				return fmt.Errorf("Invalid device %q on %s %q of project %q: %w", name, entityTypeLabel, entityName, project.Name, err)
+				violations = append(violations, restrictionViolation{entityType: entityType, entityName: entityName, device: name, deviceConfig: device, err: fmt.Errorf("Invalid device %q on %s %q of project %q: %w", name, entityTypeLabel, entityName, project.Name, err)})
 			}
 		}
-		return nil
 	}
 
 	for _, instance := range instances {
 		instType, err := instancetype.New(instance.Type)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		err = entityConfigChecker(instType, instance.Name, instance.Config)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		err = entityDevicesChecker(instType, instance.Name, instance.Devices)
-		if err != nil {
-			return err
-		}
+		entityDevicesChecker(instType, instance.Name, instance.Devices)
 	}
 
 	for _, profile := range profiles {
 		err := entityConfigChecker(instancetype.Any, profile.Name, profile.Config)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		err = entityDevicesChecker(instancetype.Any, profile.Name, profile.Devices)
-		if err != nil {
-			return err
-		}
+		entityDevicesChecker(instancetype.Any, profile.Name, profile.Devices)
 	}
 
-	return nil
+	return violations, nil
 }
 
 // CheckRestrictedDevicesDiskPaths checks whether the disk's source path is within the allowed paths specified in
@@ -829,6 +888,7 @@ var allAggregateLimits = []string{
 	"limits.cpu",
 	"limits.disk",
 	"limits.memory",
+	"limits.networks.bandwidth",
 	"limits.processes",
 }
 
@@ -876,15 +936,23 @@ func isContainerLowLevelOptionForbidden(key string) bool {
 		return true
 	}
 
+	if strings.HasPrefix(key, "security.syscalls.policy.") {
+		return true
+	}
+
 	if slices.Contains([]string{
 		"boot.host_shutdown_action",
 		"boot.host_shutdown_timeout",
 		"linux.kernel_modules",
 		"limits.memory.swap",
+		"linux.hooks.mount",
+		"linux.hooks.post_stop",
+		"linux.hooks.pre_start",
 		"raw.apparmor",
 		"raw.idmap",
 		"raw.lxc",
 		"raw.seccomp",
+		"security.apparmor.extra_rules",
 		"security.guestapi.images",
 		"security.idmap.base",
 		"security.idmap.size",
@@ -910,6 +978,7 @@ func isVMLowLevelOptionForbidden(key string) bool {
 		"raw.qemu.qmp.post-start",
 		"raw.qemu.qmp.pre-start",
 		"raw.qemu.scriptlet",
+		"security.apparmor.extra_rules",
 	},
 		key)
 }
@@ -927,6 +996,13 @@ func AllowInstanceUpdate(tx *db.ClusterTx, projectName, instanceName string, req
 		return nil
 	}
 
+	// Violations of the restrictions which predate the update don't block it, so that they can be fixed one
+	// at a time.
+	info.tolerated, err = getProjectRestrictionViolations(info)
+	if err != nil {
+		return err
+	}
+
 	// Change the instance being updated.
 	for i, instance := range info.Instances {
 		if instance.Name != instanceName {
@@ -960,6 +1036,74 @@ func AllowInstanceUpdate(tx *db.ClusterTx, projectName, instanceName string, req
 	return nil
 }
 
+// CheckInstanceUpdate returns how updating an existing instance would change the aggregate resource usage of
+// its project, along with the reason the update would be refused, if any.
+func CheckInstanceUpdate(tx *db.ClusterTx, projectName, instanceName string, req api.InstancePut, currentConfig map[string]string) (*api.InstanceUpdateCheck, error) {
+	getUsage := func(update bool) (map[string]api.ProjectStateResource, error) {
+		info, err := fetchProject(tx, projectName, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if update {
+			for i, instance := range info.Instances {
+				if instance.Name != instanceName {
+					continue
+				}
+
+				info.Instances[i].Profiles = req.Profiles
+				info.Instances[i].Config = req.Config
+				info.Instances[i].Devices = req.Devices
+			}
+		}
+
+		info.Instances, err = expandInstancesConfigAndDevices(info.Instances, info.Profiles)
+		if err != nil {
+			return nil, err
+		}
+
+		keys := slices.Clone(allAggregateLimits)
+		for key := range info.Project.Config {
+			if strings.HasPrefix(key, projectLimitDiskPool) {
+				keys = append(keys, key)
+			}
+		}
+
+		return getAggregateLimits(info, keys)
+	}
+
+	usage, err := getUsage(false)
+	if err != nil {
+		return nil, err
+	}
+
+	newUsage, err := getUsage(true)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &api.InstanceUpdateCheck{Resources: map[string]api.InstanceUpdateCheckResource{}}
+	for key, resource := range newUsage {
+		name := strings.TrimPrefix(key, "limits.")
+		if strings.HasPrefix(key, projectLimitDiskPool) {
+			name = "disk." + strings.TrimPrefix(key, projectLimitDiskPool)
+		}
+
+		check.Resources[name] = api.InstanceUpdateCheckResource{
+			Limit:    resource.Limit,
+			Usage:    usage[key].Usage,
+			NewUsage: resource.Usage,
+		}
+	}
+
+	err = AllowInstanceUpdate(tx, projectName, instanceName, req, currentConfig)
+	if err != nil {
+		check.Error = err.Error()
+	}
+
+	return check, nil
+}
+
 // AllowVolumeUpdate returns an error if any project-specific limit or
 // restriction is violated when updating an existing custom volume.
 func AllowVolumeUpdate(tx *db.ClusterTx, projectName, volumeName string, req api.StorageVolumePut, currentConfig map[string]string) error {
@@ -1006,6 +1150,13 @@ func AllowProfileUpdate(tx *db.ClusterTx, projectName, profileName string, req a
 		return nil
 	}
 
+	// Violations of the restrictions which predate the update don't block it, so that they can be fixed one
+	// at a time.
+	info.tolerated, err = getProjectRestrictionViolations(info)
+	if err != nil {
+		return err
+	}
+
 	// Change the profile being updated.
 	for i, profile := range info.Profiles {
 		if profile.Name != profileName {
@@ -1014,6 +1165,30 @@ func AllowProfileUpdate(tx *db.ClusterTx, projectName, profileName string, req a
 
 		info.Profiles[i].Config = req.Config
 		info.Profiles[i].Devices = req.Devices
+		info.Profiles[i].Extends = req.Extends
+	}
+
+	// Expand the profiles extending others again to account for the change.
+	for i, profile := range info.Profiles {
+		info.Profiles[i].ExpandedConfig = nil
+		info.Profiles[i].ExpandedDevices = nil
+
+		if profile.Extends == "" {
+			continue
+		}
+
+		info.Profiles[i].ExpandedConfig, info.Profiles[i].ExpandedDevices, err = cluster.ExpandProfile(profile, func(name string) (*api.Profile, error) {
+			for _, base := range info.Profiles {
+				if base.Name == name {
+					return &base, nil
+				}
+			}
+
+			return nil, fmt.Errorf("Profile %q doesn't exist", name)
+		})
+		if err != nil {
+			return err
+		}
 	}
 
 	err = checkRestrictionsAndAggregateLimits(tx, info)
@@ -1078,6 +1253,8 @@ func AllowProjectUpdate(tx *db.ClusterTx, projectName string, config map[string]
 			fallthrough
 		case "limits.memory":
 			fallthrough
+		case "limits.networks.bandwidth":
+			fallthrough
 		case "limits.disk":
 			aggregateKeys = append(aggregateKeys, key)
 		}
@@ -1216,6 +1393,9 @@ type projectInfo struct {
 	Profiles  []api.Profile
 	Instances []api.Instance
 	Volumes   []db.StorageVolumeArgs
+
+	// Restriction violations which predate the change being checked and so don't block it.
+	tolerated []restrictionViolation
 }
 
 // Fetch the given project from the database along with its profiles, instances
@@ -1461,6 +1641,11 @@ func getInstanceLimits(inst api.Instance, keys []string, skipUnset bool) (map[st
 
 				limit += sizeStateLimit
 			}
+		} else if key == "limits.networks.bandwidth" {
+			limit, err = getInstanceNICsBandwidth(inst, skipUnset)
+			if err != nil {
+				return nil, err
+			}
 		} else {
 			value, ok := inst.Config[key]
 			if !ok || value == "" {
@@ -1487,6 +1672,45 @@ func getInstanceLimits(inst api.Instance, keys []string, skipUnset bool) (map[st
 	return limits, nil
 }
 
+// Return the bandwidth accounted for the NICs of an instance in bit/s, which is the sum of the highest of
+// the ingress and egress limits of each NIC.
+func getInstanceNICsBandwidth(inst api.Instance, skipUnset bool) (int64, error) {
+	var total int64
+
+	for devName, device := range inst.Devices {
+		if device["type"] != "nic" {
+			continue
+		}
+
+		var limit int64
+		for _, key := range []string{"limits.ingress", "limits.egress"} {
+			value := device[key]
+			if value == "" {
+				value = device["limits.max"]
+			}
+
+			if value == "" {
+				if skipUnset {
+					continue
+				}
+
+				return -1, fmt.Errorf(`Instance %q in project %q has no %q set on NIC %q, either directly or via a profile`, inst.Name, inst.Project, key, devName)
+			}
+
+			bandwidth, err := units.ParseBitSizeString(value)
+			if err != nil {
+				return -1, fmt.Errorf("Failed parsing %q of NIC %q for instance %q in project %q: %w", key, devName, inst.Name, inst.Project, err)
+			}
+
+			limit = max(limit, bandwidth)
+		}
+
+		total += limit
+	}
+
+	return total, nil
+}
+
 var aggregateLimitConfigValueParsers = map[string]func(string) (int64, error){
 	"limits.memory": func(value string) (int64, error) {
 		if strings.HasSuffix(value, "%") {
@@ -1518,6 +1742,9 @@ var aggregateLimitConfigValueParsers = map[string]func(string) (int64, error){
 	"limits.disk": func(value string) (int64, error) {
 		return units.ParseByteSizeString(value)
 	},
+	"limits.networks.bandwidth": func(value string) (int64, error) {
+		return units.ParseBitSizeString(value)
+	},
 }
 
 var aggregateLimitConfigValuePrinters = map[string]func(int64) string{
@@ -1533,6 +1760,9 @@ var aggregateLimitConfigValuePrinters = map[string]func(int64) string{
 	"limits.disk": func(limit int64) string {
 		return units.GetByteSizeStringIEC(limit, 1)
 	},
+	"limits.networks.bandwidth": func(limit int64) string {
+		return units.GetBitSizeString(limit, 1)
+	},
 }
 
 // FilterUsedBy filters a UsedBy list based on project access.
@@ -1691,6 +1921,11 @@ func CheckTargetMember(p *api.Project, targetMemberName string, allMembers []db.
 				return nil, api.StatusErrorf(http.StatusForbidden, err.Error())
 			}
 
+			// Witnesses don't host instances or storage volumes.
+			if slices.Contains(potentialMember.Roles, db.ClusterRoleWitness) {
+				return nil, api.StatusErrorf(http.StatusBadRequest, "Cluster member %q is a witness and can't be targeted", targetMemberName)
+			}
+
 			return &potentialMember, nil
 		}
 	}
@@ -157,6 +157,43 @@ func TestAllowInstanceCreation_AboveInstances(t *testing.T) {
 	assert.EqualError(t, err, `Reached maximum number of instances in project "p1"`)
 }
 
+// If an update exceeds an aggregate limit, the check reports the new usage along with the reason the update
+// would be refused.
+func TestCheckInstanceUpdate_Above(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	id, err := cluster.CreateProject(ctx, tx.Tx(), cluster.Project{Name: "p1"})
+	require.NoError(t, err)
+
+	err = cluster.CreateProjectConfig(ctx, tx.Tx(), id, map[string]string{"limits.cpu": "4"})
+	require.NoError(t, err)
+
+	instanceID, err := cluster.CreateInstance(ctx, tx.Tx(), cluster.Instance{
+		Project:      "p1",
+		Name:         "c1",
+		Type:         instancetype.Container,
+		Architecture: 1,
+		Node:         "none",
+	})
+	require.NoError(t, err)
+
+	err = cluster.CreateInstanceConfig(ctx, tx.Tx(), instanceID, map[string]string{"limits.cpu": "2"})
+	require.NoError(t, err)
+
+	req := api.InstancePut{
+		Config:  map[string]string{"limits.cpu": "6"},
+		Devices: map[string]map[string]string{},
+	}
+
+	check, err := project.CheckInstanceUpdate(tx, "p1", "c1", req, map[string]string{"limits.cpu": "2"})
+	require.NoError(t, err)
+
+	assert.Equal(t, api.InstanceUpdateCheckResource{Limit: 4, Usage: 2, NewUsage: 6}, check.Resources["cpu"])
+	assert.Contains(t, check.Error, `Reached maximum aggregate value "4" for "limits.cpu" in project "p1" (usage would be 6)`)
+}
+
 // If a direct targeting is blocked, the check fails.
 func TestCheckClusterTargetRestriction_RestrictedTrue(t *testing.T) {
 	tx, cleanup := db.NewTestClusterTx(t)
@@ -249,3 +286,53 @@ func TestCheckClusterTargetRestriction_RestrictedFalse(t *testing.T) {
 	err = project.CheckClusterTargetRestriction(authorizer, req, p, "n1")
 	assert.NoError(t, err)
 }
+
+// Instances created before a project was restricted are reported by the audit, without blocking the updates of
+// the other instances.
+func TestAuditRestrictions(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	id, err := cluster.CreateProject(ctx, tx.Tx(), cluster.Project{Name: "p1"})
+	require.NoError(t, err)
+
+	err = cluster.CreateProjectConfig(ctx, tx.Tx(), id, map[string]string{"restricted": "true"})
+	require.NoError(t, err)
+
+	for _, name := range []string{"c1", "c2"} {
+		instanceID, err := cluster.CreateInstance(ctx, tx.Tx(), cluster.Instance{
+			Project:      "p1",
+			Name:         name,
+			Type:         instancetype.Container,
+			Architecture: 1,
+			Node:         "none",
+		})
+		require.NoError(t, err)
+
+		if name == "c1" {
+			err = cluster.CreateInstanceConfig(ctx, tx.Tx(), instanceID, map[string]string{"security.privileged": "true"})
+			require.NoError(t, err)
+		}
+	}
+
+	violations, err := project.AuditRestrictions(tx, "p1")
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+
+	assert.Equal(t, "instance", violations[0].EntityType)
+	assert.Equal(t, "c1", violations[0].EntityName)
+	assert.Equal(t, "security.privileged", violations[0].Key)
+
+	req := api.InstancePut{
+		Config:  map[string]string{"user.foo": "bar"},
+		Devices: map[string]map[string]string{},
+	}
+
+	err = project.AllowInstanceUpdate(tx, "p1", "c2", req, map[string]string{})
+	assert.NoError(t, err)
+
+	req.Config = map[string]string{"security.privileged": "true"}
+	err = project.AllowInstanceUpdate(tx, "p1", "c2", req, map[string]string{})
+	assert.Error(t, err)
+}
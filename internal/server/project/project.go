@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/lxc/incus/v6/internal/server/db"
@@ -350,3 +351,99 @@ func NetworkZoneProjectFromRecord(p *api.Project) string {
 
 	return api.ProjectDefaultName
 }
+
+// IdmapIsolated returns whether a container should use an isolated idmap, falling back to the
+// project's default when the instance doesn't set security.idmap.isolated. Unless configured otherwise,
+// the containers of all projects but the default one are isolated.
+func IdmapIsolated(p *api.Project, instanceConfig map[string]string) bool {
+	value, ok := instanceConfig["security.idmap.isolated"]
+	if ok && value != "" {
+		return util.IsTrue(value)
+	}
+
+	if p == nil {
+		return false
+	}
+
+	value = p.Config["security.idmap.isolated"]
+	if value == "" {
+		return p.Name != api.ProjectDefaultName
+	}
+
+	return util.IsTrue(value)
+}
+
+// IdmapRange parses a host ID range in the `<first>-<last>` format used by security.idmap.range.
+// It returns the first host ID and the size of the range.
+func IdmapRange(value string) (int64, int64, error) {
+	first, last, ok := strings.Cut(value, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("Invalid ID range %q, expected <first>-<last>", value)
+	}
+
+	firstID, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || firstID < 0 {
+		return 0, 0, fmt.Errorf("Invalid first ID %q", first)
+	}
+
+	lastID, err := strconv.ParseInt(last, 10, 64)
+	if err != nil || lastID < firstID {
+		return 0, 0, fmt.Errorf("Invalid last ID %q", last)
+	}
+
+	return firstID, lastID - firstID + 1, nil
+}
+
+// IdmapRanges returns the first host ID and the size of the ranges set through security.idmap.range, indexed by project name.
+func IdmapRanges(ctx context.Context, tx *db.ClusterTx) (map[string][2]int64, error) {
+	dbProjects, err := cluster.GetProjects(ctx, tx.Tx())
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading projects: %w", err)
+	}
+
+	ranges := map[string][2]int64{}
+	for _, dbProject := range dbProjects {
+		config, err := cluster.GetProjectConfig(ctx, tx.Tx(), dbProject.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if config["security.idmap.range"] == "" {
+			continue
+		}
+
+		base, size, err := IdmapRange(config["security.idmap.range"])
+		if err != nil {
+			return nil, err
+		}
+
+		ranges[dbProject.Name] = [2]int64{base, size}
+	}
+
+	return ranges, nil
+}
+
+// CheckIdmapRange checks that the security.idmap.range of a project doesn't overlap with the range of another project.
+func CheckIdmapRange(ctx context.Context, tx *db.ClusterTx, projectName string, config map[string]string) error {
+	if config["security.idmap.range"] == "" {
+		return nil
+	}
+
+	base, size, err := IdmapRange(config["security.idmap.range"])
+	if err != nil {
+		return err
+	}
+
+	ranges, err := IdmapRanges(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	for name, other := range ranges {
+		if name != projectName && base < other[0]+other[1] && other[0] < base+size {
+			return fmt.Errorf("ID range %q overlaps with the one of project %q", config["security.idmap.range"], name)
+		}
+	}
+
+	return nil
+}
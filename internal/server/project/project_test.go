@@ -45,3 +45,30 @@ func ExampleStorageVolume() {
 	// Output: default_test
 	// project_name_test1
 }
+
+func ExampleIdmapRange() {
+	base, size, err := project.IdmapRange("1000000-1065535")
+	fmt.Println(base, size, err)
+
+	_, _, err = project.IdmapRange("1065535-1000000")
+	fmt.Println(err)
+	// Output: 1000000 65536 <nil>
+	// Invalid last ID "1000000"
+}
+
+func ExampleIdmapIsolated() {
+	defaultProject := &api.Project{Name: api.ProjectDefaultName, ProjectPut: api.ProjectPut{Config: map[string]string{}}}
+	otherProject := &api.Project{Name: "foo", ProjectPut: api.ProjectPut{Config: map[string]string{}}}
+	sharedProject := &api.Project{Name: "bar", ProjectPut: api.ProjectPut{Config: map[string]string{"security.idmap.isolated": "false"}}}
+
+	fmt.Println(project.IdmapIsolated(defaultProject, nil))
+	fmt.Println(project.IdmapIsolated(otherProject, nil))
+	fmt.Println(project.IdmapIsolated(sharedProject, nil))
+	fmt.Println(project.IdmapIsolated(otherProject, map[string]string{"security.idmap.isolated": "false"}))
+	fmt.Println(project.IdmapIsolated(defaultProject, map[string]string{"security.idmap.isolated": "true"}))
+	// Output: false
+	// true
+	// false
+	// false
+	// true
+}
@@ -3,6 +3,7 @@ package project
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -38,6 +39,27 @@ func GetCurrentAllocations(ctx context.Context, tx *db.ClusterTx, projectName st
 		}
 	}
 
+	// Also report the usage of the pools used by the project without a limit.
+	usedPools := []string{}
+	for _, volume := range info.Volumes {
+		usedPools = append(usedPools, volume.PoolName)
+	}
+
+	for _, inst := range info.Instances {
+		for _, dev := range inst.Devices {
+			if dev["type"] == "disk" && dev["pool"] != "" {
+				usedPools = append(usedPools, dev["pool"])
+			}
+		}
+	}
+
+	for _, pool := range usedPools {
+		key := projectLimitDiskPool + pool
+		if !slices.Contains(poolLimits, key) {
+			poolLimits = append(poolLimits, key)
+		}
+	}
+
 	allAggregateLimits := append(allAggregateLimits, poolLimits...)
 
 	// Get the instance aggregated values.
@@ -50,11 +72,12 @@ func GetCurrentAllocations(ctx context.Context, tx *db.ClusterTx, projectName st
 	result["disk"] = raw["limits.disk"]
 	result["memory"] = raw["limits.memory"]
 	result["networks"] = raw["limits.networks"]
+	result["networks.bandwidth"] = raw["limits.networks.bandwidth"]
 	result["processes"] = raw["limits.processes"]
 
-	// Add the pool-specific disk limits.
+	// Add the pool-specific disk usage, skipping the pools which the project isn't allowed to use.
 	for k, v := range raw {
-		if strings.HasPrefix(k, projectLimitDiskPool) && v.Limit > 0 {
+		if strings.HasPrefix(k, projectLimitDiskPool) && v.Limit != 0 {
 			result[fmt.Sprintf("disk.%s", strings.SplitN(k, ".", 4)[3])] = v
 		}
 	}
@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// maxIdentities is the number of tracked identities above which the idle ones get forgotten.
+const maxIdentities = 1024
+
+// Limiter restricts the rate of the API requests, both from all clients and from each identity.
+type Limiter struct {
+	mu sync.Mutex
+
+	globalRate   int64
+	identityRate int64
+
+	global     *rate.Limiter
+	identities map[string]*rate.Limiter
+}
+
+// NewLimiter returns a new Limiter, with no limits set.
+func NewLimiter() *Limiter {
+	return &Limiter{identities: map[string]*rate.Limiter{}}
+}
+
+// newLimiter returns a token bucket allowing the given number of requests per second,
+// with bursts of up to the same number of requests.
+func newLimiter(limit int64) *rate.Limiter {
+	return rate.NewLimiter(rate.Limit(limit), int(limit))
+}
+
+// Allow returns whether a request from the given identity can be handled now.
+// The limits are in requests per second, 0 meaning unlimited. An empty identity is only subject to the global limit.
+func (l *Limiter) Allow(identity string, globalRate int64, identityRate int64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Reset the buckets when the limits change.
+	if globalRate != l.globalRate {
+		l.globalRate = globalRate
+		l.global = nil
+		if globalRate > 0 {
+			l.global = newLimiter(globalRate)
+		}
+	}
+
+	if identityRate != l.identityRate {
+		l.identityRate = identityRate
+		l.identities = map[string]*rate.Limiter{}
+	}
+
+	if identity != "" && l.identityRate > 0 {
+		limiter, found := l.identities[identity]
+		if !found {
+			l.prune()
+
+			limiter = newLimiter(l.identityRate)
+			l.identities[identity] = limiter
+		}
+
+		if !limiter.Allow() {
+			return false
+		}
+	}
+
+	if l.global != nil && !l.global.Allow() {
+		return false
+	}
+
+	return true
+}
+
+// prune forgets the identities whose buckets are full, as they haven't made requests for a while.
+func (l *Limiter) prune() {
+	if len(l.identities) < maxIdentities {
+		return
+	}
+
+	now := time.Now()
+	for identity, limiter := range l.identities {
+		if limiter.TokensAt(now) >= float64(limiter.Burst()) {
+			delete(l.identities, identity)
+		}
+	}
+}
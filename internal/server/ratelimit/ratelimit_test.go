@@ -0,0 +1,38 @@
+package ratelimit_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/internal/server/ratelimit"
+)
+
+func TestLimiter(t *testing.T) {
+	l := ratelimit.NewLimiter()
+
+	// No limits.
+	for range 100 {
+		assert.True(t, l.Allow("foo", 0, 0))
+	}
+
+	// Per-identity limit.
+	for range 2 {
+		assert.True(t, l.Allow("foo", 0, 2))
+	}
+
+	assert.False(t, l.Allow("foo", 0, 2))
+	assert.True(t, l.Allow("bar", 0, 2))
+	assert.True(t, l.Allow("", 0, 2))
+
+	// Changing the limits resets the buckets.
+	assert.True(t, l.Allow("foo", 0, 3))
+
+	// Global limit.
+	for range 3 {
+		assert.True(t, l.Allow("", 3, 0))
+	}
+
+	assert.False(t, l.Allow("", 3, 0))
+	assert.False(t, l.Allow("baz", 3, 0))
+}
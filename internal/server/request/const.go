@@ -28,6 +28,9 @@ const (
 
 	// CtxForwardedProtocol is the forwarded protocol field in request context.
 	CtxForwardedProtocol CtxKey = "forwarded_protocol"
+
+	// CtxImpersonator is the username of the administrator impersonating the requestor in request context.
+	CtxImpersonator CtxKey = "impersonator"
 )
 
 // Headers.
@@ -40,4 +43,7 @@ const (
 
 	// HeaderForwardedProtocol is the forwarded protocol field in request header.
 	HeaderForwardedProtocol = "X-Incus-forwarded-protocol"
+
+	// HeaderImpersonate is the identity to run the request as in request header.
+	HeaderImpersonate = "X-Incus-impersonate"
 )
@@ -0,0 +1,115 @@
+package response
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// notModifiedWriter holds back the successful responses having an ETag, so that they can be replaced by
+// 304 Not Modified if their body is the one the client already has.
+type notModifiedWriter struct {
+	http.ResponseWriter
+
+	match       string
+	code        int
+	wroteHeader bool
+	buffered    bool
+	body        bytes.Buffer
+}
+
+// NotModifiedWriter wraps the writer of a GET request with a If-None-Match header so that the client gets a
+// 304 Not Modified response, without body, if the body of the response matches one of the tags it sent.
+//
+// The ETag of the objects only covers the fields which can be modified (used with If-Match), not their state,
+// so the tags are compared with bodyETag instead. The returned function must be called once the response is
+// rendered, to send the held back response.
+func NotModifiedWriter(w http.ResponseWriter, r *http.Request) (http.ResponseWriter, func() error) {
+	match := r.Header.Get("If-None-Match")
+	if r.Method != http.MethodGet || match == "" || r.Header.Get("Upgrade") != "" {
+		return w, func() error { return nil }
+	}
+
+	nw := &notModifiedWriter{ResponseWriter: w, match: match}
+
+	return nw, nw.finish
+}
+
+// bodyETag returns the weak ETag identifying a response body, which is its SHA-256 hash.
+func bodyETag(body []byte) string {
+	hash := sha256.Sum256(body)
+
+	return "W/\"" + hex.EncodeToString(hash[:]) + "\""
+}
+
+// WriteHeader sends the status code, or holds it back along with the body for the successful responses having an ETag.
+func (w *notModifiedWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+
+	w.wroteHeader = true
+
+	if code == http.StatusOK && w.Header().Get("ETag") != "" {
+		w.code = code
+		w.buffered = true
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write sends the body of the response, or holds it back.
+func (w *notModifiedWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.buffered {
+		return w.body.Write(data)
+	}
+
+	return w.ResponseWriter.Write(data)
+}
+
+// finish sends the held back response, switched to 304 if its body matches.
+func (w *notModifiedWriter) finish() error {
+	if !w.buffered {
+		return nil
+	}
+
+	if etagMatches(w.match, bodyETag(w.body.Bytes())) {
+		w.Header().Del("Content-Type")
+		w.Header().Del("Content-Encoding")
+		w.ResponseWriter.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	w.ResponseWriter.WriteHeader(w.code)
+
+	_, err := w.ResponseWriter.Write(w.body.Bytes())
+
+	return err
+}
+
+// Unwrap returns the wrapped writer, for use by http.ResponseController.
+func (w *notModifiedWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// etagMatches returns whether the ETag is part of the comma separated list of the If-None-Match header.
+// As If-None-Match uses the weak comparison, the weak indicators are ignored.
+func etagMatches(match string, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+
+	for _, value := range strings.Split(match, ",") {
+		value = strings.TrimPrefix(strings.TrimSpace(value), "W/")
+		if value == "*" || value == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,131 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotModifiedWriter(t *testing.T) {
+	body := `{"type":"sync","status":"Success","status_code":200,"metadata":{"status":"Running"}}`
+
+	tests := []struct {
+		name     string
+		method   string
+		match    string
+		etag     string
+		code     int
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "no If-None-Match",
+			method:   http.MethodGet,
+			etag:     `"config"`,
+			code:     http.StatusOK,
+			wantCode: http.StatusOK,
+			wantBody: body,
+		},
+		{
+			name:     "matching body",
+			method:   http.MethodGet,
+			match:    bodyETag([]byte(body)),
+			etag:     `"config"`,
+			code:     http.StatusOK,
+			wantCode: http.StatusNotModified,
+		},
+		{
+			name:     "matching body in list",
+			method:   http.MethodGet,
+			match:    `W/"other", ` + bodyETag([]byte(body)),
+			etag:     `"config"`,
+			code:     http.StatusOK,
+			wantCode: http.StatusNotModified,
+		},
+		{
+			name:     "changed body",
+			method:   http.MethodGet,
+			match:    bodyETag([]byte(`{"metadata":{"status":"Stopped"}}`)),
+			etag:     `"config"`,
+			code:     http.StatusOK,
+			wantCode: http.StatusOK,
+			wantBody: body,
+		},
+		{
+			name:     "matching object ETag only",
+			method:   http.MethodGet,
+			match:    `"config"`,
+			etag:     `"config"`,
+			code:     http.StatusOK,
+			wantCode: http.StatusOK,
+			wantBody: body,
+		},
+		{
+			name:     "no ETag",
+			method:   http.MethodGet,
+			match:    bodyETag([]byte(body)),
+			code:     http.StatusOK,
+			wantCode: http.StatusOK,
+			wantBody: body,
+		},
+		{
+			name:     "error",
+			method:   http.MethodGet,
+			match:    bodyETag([]byte(body)),
+			etag:     `"config"`,
+			code:     http.StatusNotFound,
+			wantCode: http.StatusNotFound,
+			wantBody: body,
+		},
+		{
+			name:     "not a GET",
+			method:   http.MethodPut,
+			match:    bodyETag([]byte(body)),
+			etag:     `"config"`,
+			code:     http.StatusOK,
+			wantCode: http.StatusOK,
+			wantBody: body,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(test.method, "/1.0/instances/c1", nil)
+			if test.match != "" {
+				r.Header.Set("If-None-Match", test.match)
+			}
+
+			recorder := httptest.NewRecorder()
+			w, finish := NotModifiedWriter(recorder, r)
+
+			if test.etag != "" {
+				w.Header().Set("ETag", test.etag)
+			}
+
+			w.WriteHeader(test.code)
+			_, err := w.Write([]byte(body))
+			require.NoError(t, err)
+
+			require.NoError(t, finish())
+			assert.Equal(t, test.wantCode, recorder.Code)
+			assert.Equal(t, test.wantBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestBodyETag(t *testing.T) {
+	assert.Equal(t, `W/"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"`, bodyETag(nil))
+}
+
+func TestEtagMatches(t *testing.T) {
+	assert.True(t, etagMatches(`"a"`, `"a"`))
+	assert.True(t, etagMatches(`W/"a"`, `"a"`))
+	assert.True(t, etagMatches(`"a"`, `W/"a"`))
+	assert.True(t, etagMatches(`"b", "a"`, `"a"`))
+	assert.True(t, etagMatches(`*`, `"a"`))
+	assert.False(t, etagMatches(`"b"`, `"a"`))
+	assert.False(t, etagMatches(`"a-suffix"`, `"a"`))
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/scriptlet/log"
 	"github.com/lxc/incus/v6/internal/server/scriptlet/marshal"
 	"github.com/lxc/incus/v6/internal/server/state"
+	storagePools "github.com/lxc/incus/v6/internal/server/storage"
 	"github.com/lxc/incus/v6/shared/api"
 	apiScriptlet "github.com/lxc/incus/v6/shared/api/scriptlet"
 	"github.com/lxc/incus/v6/shared/logger"
@@ -153,10 +154,49 @@ func InstancePlacementRun(ctx context.Context, l logger.Logger, s *state.State,
 	}
 
 	getInstanceResourcesFunc := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
-		var err error
+		var projectName string
+		var instanceName string
+
+		err := starlark.UnpackArgs(b.Name(), args, kwargs, "project??", &projectName, "name??", &instanceName)
+		if err != nil {
+			return nil, err
+		}
+
+		// Default to the instance being placed.
+		instConfig := req.Config
+		instDevices := req.Devices
+		instType := req.Type
+
+		// Otherwise use the expanded configuration of an existing instance.
+		if instanceName != "" {
+			if projectName == "" {
+				projectName = req.Project
+			}
+
+			var inst *api.Instance
+
+			err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+				dbInst, err := dbCluster.GetInstance(ctx, tx.Tx(), projectName, instanceName)
+				if err != nil {
+					return err
+				}
+
+				inst, err = dbInst.ToAPI(ctx, tx.Tx(), nil, nil, nil)
+
+				return err
+			})
+			if err != nil {
+				return nil, fmt.Errorf("Failed loading instance %q in project %q: %w", instanceName, projectName, err)
+			}
+
+			instConfig = inst.ExpandedConfig
+			instDevices = inst.ExpandedDevices
+			instType = api.InstanceType(inst.Type)
+		}
+
 		var res apiScriptlet.InstanceResources
 
-		usageCPU, usageMemory, usageDisk, err := internalInstance.ResourceUsage(req.Config, req.Devices, req.Type)
+		usageCPU, usageMemory, usageDisk, err := internalInstance.ResourceUsage(instConfig, instDevices, instType)
 		if err != nil {
 			return nil, fmt.Errorf("Failed to calculate instance resource usage: %w", err)
 		}
@@ -173,6 +213,61 @@ func InstancePlacementRun(ctx context.Context, l logger.Logger, s *state.State,
 		return rv, nil
 	}
 
+	getStoragePoolResourcesFunc := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var memberName string
+		var poolName string
+
+		err := starlark.UnpackArgs(b.Name(), args, kwargs, "member_name", &memberName, "pool_name", &poolName)
+		if err != nil {
+			return nil, err
+		}
+
+		var res *api.ResourcesStoragePool
+
+		// Get the local storage pool usage.
+		if memberName == s.ServerName {
+			pool, err := storagePools.LoadByName(s, poolName)
+			if err != nil {
+				return nil, err
+			}
+
+			res, err = pool.GetResources()
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			// Get remote member storage pool usage.
+			var targetMember *db.NodeInfo
+			for i := range candidateMembers {
+				if candidateMembers[i].Name == memberName {
+					targetMember = &candidateMembers[i]
+					break
+				}
+			}
+
+			if targetMember == nil {
+				return nil, fmt.Errorf("Invalid member name: %s", memberName)
+			}
+
+			client, err := cluster.Connect(targetMember.Address, s.Endpoints.NetworkCert(), s.ServerCert(), nil, true)
+			if err != nil {
+				return nil, err
+			}
+
+			res, err = client.GetStoragePoolResources(poolName)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		rv, err := marshal.StarlarkMarshal(res)
+		if err != nil {
+			return nil, fmt.Errorf("Marshalling storage pool resources for %q on %q failed: %w", poolName, memberName, err)
+		}
+
+		return rv, nil
+	}
+
 	getInstancesFunc := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
 		var project string
 		var location string
@@ -450,6 +545,7 @@ func InstancePlacementRun(ctx context.Context, l logger.Logger, s *state.State,
 	// Remember to match the entries in scriptletLoad.InstancePlacementCompile() with this list so Starlark can
 	// perform compile time validation of functions used.
 	env := starlark.StringDict{
+		"log_debug":                    starlark.NewBuiltin("log_debug", logFunc),
 		"log_info":                     starlark.NewBuiltin("log_info", logFunc),
 		"log_warn":                     starlark.NewBuiltin("log_warn", logFunc),
 		"log_error":                    starlark.NewBuiltin("log_error", logFunc),
@@ -457,6 +553,7 @@ func InstancePlacementRun(ctx context.Context, l logger.Logger, s *state.State,
 		"get_cluster_member_resources": starlark.NewBuiltin("get_cluster_member_resources", getClusterMemberResourcesFunc),
 		"get_cluster_member_state":     starlark.NewBuiltin("get_cluster_member_state", getClusterMemberStateFunc),
 		"get_instance_resources":       starlark.NewBuiltin("get_instance_resources", getInstanceResourcesFunc),
+		"get_storage_pool_resources":   starlark.NewBuiltin("get_storage_pool_resources", getStoragePoolResourcesFunc),
 		"get_instances":                starlark.NewBuiltin("get_instances", getInstancesFunc),
 		"get_instances_count":          starlark.NewBuiltin("get_instances_count", getInstancesCountFunc),
 		"get_cluster_members":          starlark.NewBuiltin("get_cluster_members", getClusterMembersFunc),
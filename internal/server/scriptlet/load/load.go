@@ -23,6 +23,7 @@ var (
 // InstancePlacementCompile compiles the instance placement scriptlet.
 func InstancePlacementCompile(name string, src string) (*starlark.Program, error) {
 	return compile(name, src, []string{
+		"log_debug",
 		"log_info",
 		"log_warn",
 		"log_error",
@@ -30,6 +31,7 @@ func InstancePlacementCompile(name string, src string) (*starlark.Program, error
 		"get_cluster_member_resources",
 		"get_cluster_member_state",
 		"get_instance_resources",
+		"get_storage_pool_resources",
 		"get_instances",
 		"get_instances_count",
 		"get_cluster_members",
@@ -24,6 +24,8 @@ func CreateLogger(l logger.Logger, name string) func(*starlark.Thread, *starlark
 		}
 
 		switch b.Name() {
+		case "log_debug":
+			l.Debug(fmt.Sprintf("%s: %s", name, sb.String()))
 		case "log_info":
 			l.Info(fmt.Sprintf("%s: %s", name, sb.String()))
 		case "log_warn":
@@ -454,11 +454,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"net"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -658,6 +660,12 @@ func InstanceNeedsPolicy(c Instance) bool {
 		}
 	}
 
+	for k := range config {
+		if strings.HasPrefix(k, "security.syscalls.policy.") {
+			return true
+		}
+	}
+
 	// Check for boolean keys that default to false
 	keys = []string{
 		"security.syscalls.deny_compat",
@@ -752,6 +760,12 @@ func seccompGetPolicyContent(s *state.State, c Instance) (string, error) {
 		return raw, nil
 	}
 
+	// Custom system call policies
+	customPolicies, err := seccompCustomPolicies(config)
+	if err != nil {
+		return "", err
+	}
+
 	// Policy header
 	policy := seccompHeader
 	allowlist := config["security.syscalls.allow"]
@@ -780,7 +794,7 @@ func seccompGetPolicyContent(s *state.State, c Instance) (string, error) {
 		}
 
 		if !ok || util.IsTrue(defaultFlag) {
-			policy += defaultSeccompPolicy
+			policy += seccompFilterPolicy(defaultSeccompPolicy, customPolicies)
 		}
 	}
 
@@ -825,6 +839,10 @@ func seccompGetPolicyContent(s *state.State, c Instance) (string, error) {
 		}
 	}
 
+	for _, syscall := range slices.Sorted(maps.Keys(customPolicies)) {
+		policy += fmt.Sprintf("%s %s\n", syscall, customPolicies[syscall])
+	}
+
 	if allowlist != "" {
 		return policy, nil
 	}
@@ -856,6 +874,73 @@ func seccompGetPolicyContent(s *state.State, c Instance) (string, error) {
 	return policy, nil
 }
 
+// seccompInterceptedSyscalls maps the system calls handled by the built-in interception to their configuration key.
+var seccompInterceptedSyscalls = map[string]string{
+	"mknod":              "security.syscalls.intercept.mknod",
+	"mknodat":            "security.syscalls.intercept.mknod",
+	"setxattr":           "security.syscalls.intercept.setxattr",
+	"sched_setscheduler": "security.syscalls.intercept.sched_setscheduler",
+	"sysinfo":            "security.syscalls.intercept.sysinfo",
+	"mount":              "security.syscalls.intercept.mount",
+	"bpf":                "security.syscalls.intercept.bpf",
+	"seccomp":            "",
+}
+
+// seccompCustomPolicies returns the seccomp policy actions for the security.syscalls.policy.* keys, indexed by system call.
+func seccompCustomPolicies(config map[string]string) (map[string]string, error) {
+	policies := map[string]string{}
+
+	for key, value := range config {
+		syscall, ok := strings.CutPrefix(key, "security.syscalls.policy.")
+		if !ok || value == "" {
+			continue
+		}
+
+		// The built-in interception can't be combined with a custom policy.
+		interceptKey, ok := seccompInterceptedSyscalls[syscall]
+		if ok && (interceptKey == "" || util.IsTrue(config[interceptKey])) {
+			return nil, fmt.Errorf("System call %q is already handled by Incus and can't have a custom policy", syscall)
+		}
+
+		action, errno, hasErrno := strings.Cut(value, ":")
+		switch action {
+		case "allow":
+			policies[syscall] = "allow"
+		case "deny":
+			if !hasErrno {
+				errno = "1"
+			}
+
+			policies[syscall] = "errno " + errno
+		case "emulate":
+			policies[syscall] = "errno 0"
+		default:
+			return nil, fmt.Errorf("Invalid policy %q for system call %q", value, syscall)
+		}
+	}
+
+	return policies, nil
+}
+
+// seccompFilterPolicy removes the rules of a policy which apply to system calls with a custom policy.
+func seccompFilterPolicy(policy string, customPolicies map[string]string) string {
+	if len(customPolicies) == 0 {
+		return policy
+	}
+
+	lines := []string{}
+	for _, line := range strings.Split(policy, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && customPolicies[fields[0]] != "" {
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 // CreateProfile creates a seccomp profile.
 func CreateProfile(s *state.State, c Instance) error {
 	/* Unlike apparmor, there is no way to "cache" profiles, and profiles
@@ -4,6 +4,7 @@ package seccomp
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -18,3 +19,32 @@ func TestMountFlagsToOpts(t *testing.T) {
 		t.Fatal(fmt.Errorf("Mount options parsing failed with invalid option string: %s", opts))
 	}
 }
+
+func TestSeccompCustomPolicies(t *testing.T) {
+	policies, err := seccompCustomPolicies(map[string]string{
+		"security.syscalls.policy.kexec_load": "allow",
+		"security.syscalls.policy.keyctl":     "deny:38",
+		"security.syscalls.policy.syslog":     "deny",
+		"security.syscalls.policy.sync":       "emulate",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if policies["kexec_load"] != "allow" || policies["keyctl"] != "errno 38" || policies["syslog"] != "errno 1" || policies["sync"] != "errno 0" {
+		t.Fatal(fmt.Errorf("Unexpected custom policies: %v", policies))
+	}
+
+	filtered := seccompFilterPolicy(defaultSeccompPolicy, policies)
+	if strings.Contains(filtered, "kexec_load") || !strings.Contains(filtered, "init_module errno 38") {
+		t.Fatal(fmt.Errorf("Unexpected filtered policy: %s", filtered))
+	}
+
+	_, err = seccompCustomPolicies(map[string]string{
+		"security.syscalls.intercept.mknod": "true",
+		"security.syscalls.policy.mknod":    "allow",
+	})
+	if err == nil {
+		t.Fatal("Expected a conflict with the built-in mknod interception")
+	}
+}
@@ -0,0 +1,201 @@
+package secrets
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	internalUtil "github.com/lxc/incus/v6/internal/util"
+)
+
+// prefix is the prefix of the configuration values referencing an external secret.
+const prefix = "secret:"
+
+// IsReference returns whether the value references an external secret.
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// parseReference splits a "secret:<backend>:<path>[#<key>]" reference into its components.
+func parseReference(value string) (string, string, string, error) {
+	backend, reference, ok := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+	if !ok || reference == "" {
+		return "", "", "", fmt.Errorf("Invalid secret reference %q, expected \"secret:<backend>:<path>\"", value)
+	}
+
+	path, key, _ := strings.Cut(reference, "#")
+
+	switch backend {
+	case "vault":
+		path = strings.Trim(path, "/")
+		if path == "" || key == "" {
+			return "", "", "", fmt.Errorf("Invalid Vault secret reference %q, expected \"secret:vault:<path>#<key>\"", value)
+		}
+
+	case "file":
+		if !strings.HasPrefix(path, "/") || key != "" {
+			return "", "", "", fmt.Errorf("Invalid file secret reference %q, expected \"secret:file:<absolute path>\"", value)
+		}
+
+		path = filepath.Clean(path)
+		if !strings.HasPrefix(path, fileDir()+"/") {
+			return "", "", "", fmt.Errorf("Invalid file secret reference %q, the file must be in %q", value, fileDir())
+		}
+
+	default:
+		return "", "", "", fmt.Errorf("Unknown secret backend %q", backend)
+	}
+
+	return backend, path, key, nil
+}
+
+// ValidateValue checks the syntax of values referencing an external secret.
+// Other values are accepted as-is.
+func ValidateValue(value string) error {
+	if !IsReference(value) {
+		return nil
+	}
+
+	_, _, _, err := parseReference(value)
+
+	return err
+}
+
+// Resolve returns the value of the referenced external secret.
+// Values which aren't references are returned unchanged.
+func Resolve(ctx context.Context, value string) (string, error) {
+	if !IsReference(value) {
+		return value, nil
+	}
+
+	backend, path, key, err := parseReference(value)
+	if err != nil {
+		return "", err
+	}
+
+	switch backend {
+	case "vault":
+		return resolveVault(ctx, path, key)
+	case "file":
+		return resolveFile(path)
+	}
+
+	return "", fmt.Errorf("Unknown secret backend %q", backend)
+}
+
+// fileDir returns the directory holding the secrets of the file backend, set in the INCUS_SECRETS_DIR
+// environment variable of the daemon and defaulting to the secrets directory of the daemon.
+func fileDir() string {
+	dir := os.Getenv("INCUS_SECRETS_DIR")
+	if dir == "" {
+		dir = internalUtil.VarPath("secrets")
+	}
+
+	return filepath.Clean(dir)
+}
+
+// resolveFile returns the content of a local file, without surrounding whitespace.
+// The file must be in the secrets directory once its symlinks are resolved.
+func resolveFile(path string) (string, error) {
+	dir, err := filepath.EvalSymlinks(fileDir())
+	if err != nil {
+		return "", fmt.Errorf("Failed resolving secrets directory: %w", err)
+	}
+
+	realPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", fmt.Errorf("Failed reading secret file %q: %w", path, err)
+	}
+
+	if !strings.HasPrefix(realPath, dir+"/") {
+		return "", fmt.Errorf("Secret file %q isn't in %q", path, fileDir())
+	}
+
+	content, err := os.ReadFile(realPath)
+	if err != nil {
+		return "", fmt.Errorf("Failed reading secret file %q: %w", path, err)
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+// resolveVault retrieves a key from a Vault KV secret, using the address and token set in the
+// VAULT_ADDR and VAULT_TOKEN environment variables of the daemon.
+func resolveVault(ctx context.Context, path string, key string) (string, error) {
+	addr := os.Getenv("VAULT_ADDR")
+	token := os.Getenv("VAULT_TOKEN")
+	if addr == "" || token == "" {
+		return "", fmt.Errorf("Vault secrets require the VAULT_ADDR and VAULT_TOKEN environment variables to be set")
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	caPath := os.Getenv("VAULT_CACERT")
+	if caPath != "" {
+		caCert, err := os.ReadFile(caPath)
+		if err != nil {
+			return "", fmt.Errorf("Failed reading Vault CA certificate: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return "", fmt.Errorf("Invalid Vault CA certificate %q", caPath)
+		}
+
+		client.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(addr, "/")+"/v1/"+path, nil)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("X-Vault-Token", token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Failed querying Vault: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Failed retrieving Vault secret %q: %s", path, resp.Status)
+	}
+
+	var secret struct {
+		Data map[string]any `json:"data"`
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&secret)
+	if err != nil {
+		return "", fmt.Errorf("Failed parsing Vault response: %w", err)
+	}
+
+	// KV version 2 secrets are nested in a second "data" field along with their metadata.
+	data := secret.Data
+	nested, ok := data["data"].(map[string]any)
+	if ok {
+		_, hasMetadata := data["metadata"]
+		if hasMetadata {
+			data = nested
+		}
+	}
+
+	value, ok := data[key].(string)
+	if !ok {
+		return "", fmt.Errorf("Vault secret %q has no %q key", path, key)
+	}
+
+	return value, nil
+}
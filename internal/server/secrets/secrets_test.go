@@ -0,0 +1,87 @@
+package secrets_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/internal/server/secrets"
+)
+
+func TestValidateValue(t *testing.T) {
+	t.Setenv("INCUS_SECRETS_DIR", "/etc/incus/secrets")
+
+	for _, value := range []string{"", "plain", "secret:vault:secret/data/incus#token", "secret:file:/etc/incus/secrets/token"} {
+		assert.NoError(t, secrets.ValidateValue(value), value)
+	}
+
+	for _, value := range []string{"secret:", "secret:vault:secret/data/incus", "secret:vault:#token", "secret:file:token", "secret:file:/etc/incus/secrets/token#key", "secret:file:/etc/shadow", "secret:file:/etc/incus/secrets/../token", "secret:foo:bar"} {
+		assert.Error(t, secrets.ValidateValue(value), value)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	value, err := secrets.Resolve(context.Background(), "plain")
+	assert.NoError(t, err)
+	assert.Equal(t, "plain", value)
+
+	dir := t.TempDir()
+	t.Setenv("INCUS_SECRETS_DIR", dir)
+
+	path := filepath.Join(dir, "token")
+	assert.NoError(t, os.WriteFile(path, []byte("foo\n"), 0o600))
+
+	value, err = secrets.Resolve(context.Background(), "secret:file:"+path)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+
+	// Files outside of the secrets directory can't be reached through symlinks either.
+	outside := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(outside, []byte("bar\n"), 0o600))
+	assert.NoError(t, os.Symlink(outside, filepath.Join(dir, "link")))
+
+	_, err = secrets.Resolve(context.Background(), "secret:file:"+outside)
+	assert.Error(t, err)
+
+	_, err = secrets.Resolve(context.Background(), "secret:file:"+filepath.Join(dir, "link"))
+	assert.Error(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/data/incus":
+			_, _ = w.Write([]byte(`{"data": {"data": {"token": "v2"}, "metadata": {"version": 1}}}`))
+		case "/v1/kv/incus":
+			_, _ = w.Write([]byte(`{"data": {"token": "v1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("VAULT_ADDR", server.URL)
+	t.Setenv("VAULT_TOKEN", "root")
+
+	value, err = secrets.Resolve(context.Background(), "secret:vault:secret/data/incus#token")
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", value)
+
+	value, err = secrets.Resolve(context.Background(), "secret:vault:kv/incus#token")
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", value)
+
+	_, err = secrets.Resolve(context.Background(), "secret:vault:kv/incus#missing")
+	assert.Error(t, err)
+
+	_, err = secrets.Resolve(context.Background(), "secret:vault:kv/other#token")
+	assert.Error(t, err)
+}
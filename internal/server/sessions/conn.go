@@ -0,0 +1,67 @@
+package sessions
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// responseWriter records the connection a tracked request gets upgraded to.
+type responseWriter struct {
+	http.ResponseWriter
+
+	manager *Manager
+	session *session
+}
+
+// Hijack takes over the connection and attaches it to the session.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("Response writer doesn't support hijacking")
+	}
+
+	netConn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := &conn{Conn: netConn, manager: w.manager, session: w.session}
+
+	w.manager.mu.Lock()
+	w.session.conn = c
+	w.manager.mu.Unlock()
+
+	return c, rw, nil
+}
+
+// Flush sends any buffered data to the client.
+func (w *responseWriter) Flush() {
+	flusher, ok := w.ResponseWriter.(http.Flusher)
+	if ok {
+		flusher.Flush()
+	}
+}
+
+// conn is the connection of a session, which ends the session when closed.
+type conn struct {
+	net.Conn
+
+	manager *Manager
+	session *session
+	once    sync.Once
+}
+
+// Close closes the connection and ends the session.
+func (c *conn) Close() error {
+	c.once.Do(func() { c.manager.closed(c.session) })
+
+	return c.Conn.Close()
+}
+
+// NetConn returns the underlying connection.
+func (c *conn) NetConn() net.Conn {
+	return c.Conn
+}
@@ -0,0 +1,188 @@
+package sessions
+
+import (
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// session is an active client connection.
+type session struct {
+	info api.Session
+	conn net.Conn
+}
+
+// Manager keeps track of the active client connections and of the blocked identities.
+type Manager struct {
+	mu       sync.Mutex
+	sessions map[string]*session
+	blocks   map[string]time.Time
+}
+
+// NewManager returns a new session manager.
+func NewManager() *Manager {
+	return &Manager{
+		sessions: map[string]*session{},
+		blocks:   map[string]time.Time{},
+	}
+}
+
+// Track registers a new session for the given request and returns a response writer recording the
+// connection it gets upgraded to.
+// The returned function must be called once the request has been handled, the session then either
+// lasts until its connection gets closed or is discarded if the request didn't upgrade it.
+func (m *Manager) Track(w http.ResponseWriter, info api.Session) (http.ResponseWriter, func()) {
+	info.ID = uuid.New().String()
+	info.CreatedAt = time.Now()
+
+	s := &session{info: info}
+
+	m.mu.Lock()
+	m.sessions[info.ID] = s
+	m.mu.Unlock()
+
+	writer := &responseWriter{ResponseWriter: w, manager: m, session: s}
+
+	return writer, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		if s.conn == nil {
+			delete(m.sessions, info.ID)
+		}
+	}
+}
+
+// List returns the active sessions.
+func (m *Manager) List() []api.Session {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sessions := make([]api.Session, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		if s.conn == nil {
+			continue
+		}
+
+		sessions = append(sessions, s.info)
+	}
+
+	slices.SortFunc(sessions, func(a api.Session, b api.Session) int { return a.CreatedAt.Compare(b.CreatedAt) })
+
+	return sessions
+}
+
+// Get returns the active session with the given ID.
+func (m *Manager) Get(id string) (*api.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.sessions[id]
+	if !ok || s.conn == nil {
+		return nil, api.StatusErrorf(http.StatusNotFound, "Session not found")
+	}
+
+	info := s.info
+
+	return &info, nil
+}
+
+// Terminate closes the connection of the active session with the given ID.
+func (m *Manager) Terminate(id string) error {
+	m.mu.Lock()
+	s, ok := m.sessions[id]
+	m.mu.Unlock()
+
+	if !ok || s.conn == nil {
+		return api.StatusErrorf(http.StatusNotFound, "Session not found")
+	}
+
+	return s.conn.Close()
+}
+
+// Block rejects the requests of the given identity until the block expires, and terminates its
+// active sessions.
+func (m *Manager) Block(username string, expiresAt time.Time) {
+	m.mu.Lock()
+	m.blocks[username] = expiresAt
+
+	conns := []net.Conn{}
+	for _, s := range m.sessions {
+		if s.conn != nil && s.info.Username == username {
+			conns = append(conns, s.conn)
+		}
+	}
+
+	m.mu.Unlock()
+
+	for _, conn := range conns {
+		_ = conn.Close()
+	}
+}
+
+// Unblock removes the block of the given identity.
+func (m *Manager) Unblock(username string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expiresAt, ok := m.blocks[username]
+	if !ok || time.Now().After(expiresAt) {
+		return api.StatusErrorf(http.StatusNotFound, "Identity isn't blocked")
+	}
+
+	delete(m.blocks, username)
+
+	return nil
+}
+
+// IsBlocked returns whether the requests of the given identity are currently rejected.
+func (m *Manager) IsBlocked(username string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expiresAt, ok := m.blocks[username]
+	if !ok {
+		return false
+	}
+
+	if time.Now().After(expiresAt) {
+		delete(m.blocks, username)
+		return false
+	}
+
+	return true
+}
+
+// Blocks returns the identities which are currently blocked.
+func (m *Manager) Blocks() []api.SessionBlock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	blocks := []api.SessionBlock{}
+	for username, expiresAt := range m.blocks {
+		if time.Now().After(expiresAt) {
+			delete(m.blocks, username)
+			continue
+		}
+
+		blocks = append(blocks, api.SessionBlock{Username: username, ExpiresAt: expiresAt})
+	}
+
+	slices.SortFunc(blocks, func(a api.SessionBlock, b api.SessionBlock) int { return a.ExpiresAt.Compare(b.ExpiresAt) })
+
+	return blocks
+}
+
+// closed removes a session once its connection has been closed.
+func (m *Manager) closed(s *session) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.sessions, s.info.ID)
+}
@@ -0,0 +1,88 @@
+package sessions_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/internal/server/sessions"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func TestManager(t *testing.T) {
+	m := sessions.NewManager()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w, done := m.Track(w, api.Session{Username: r.URL.Query().Get("username")})
+		defer done()
+
+		if r.URL.Query().Get("hijack") == "" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+
+		_, _ = conn.Write([]byte("HTTP/1.1 101 Switching Protocols\r\n\r\n"))
+	}))
+	defer server.Close()
+
+	// Requests which aren't upgraded don't open sessions.
+	resp, err := http.Get(server.URL + "?username=foo")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Empty(t, m.List())
+
+	// Upgraded requests last until their connection gets closed.
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("GET /?username=foo&hijack=1 HTTP/1.1\r\nHost: localhost\r\n\r\n"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 64)
+	_, err = conn.Read(buf)
+	require.NoError(t, err)
+
+	list := m.List()
+	require.Len(t, list, 1)
+	assert.Equal(t, "foo", list[0].Username)
+
+	session, err := m.Get(list[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, list[0], *session)
+
+	// Blocking the identity terminates its sessions.
+	m.Block("foo", time.Now().Add(time.Hour))
+	assert.True(t, m.IsBlocked("foo"))
+	assert.False(t, m.IsBlocked("bar"))
+	assert.Empty(t, m.List())
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = conn.Read(buf)
+	assert.Error(t, err)
+
+	assert.Error(t, m.Terminate(list[0].ID))
+
+	blocks := m.Blocks()
+	require.Len(t, blocks, 1)
+	assert.Equal(t, "foo", blocks[0].Username)
+
+	require.NoError(t, m.Unblock("foo"))
+	assert.False(t, m.IsBlocked("foo"))
+	assert.Error(t, m.Unblock("foo"))
+
+	// Expired blocks are ignored.
+	m.Block("bar", time.Now().Add(-time.Second))
+	assert.False(t, m.IsBlocked("bar"))
+	assert.Empty(t, m.Blocks())
+}
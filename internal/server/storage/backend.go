@@ -44,6 +44,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/secrets"
 	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/internal/server/storage/drivers"
 	"github.com/lxc/incus/v6/internal/server/storage/memorypipe"
@@ -3078,7 +3079,9 @@ func (b *backend) CreateInstanceSnapshot(inst instance.Instance, src instance.In
 	reverter.Add(func() { _ = VolumeDBDelete(b, inst.Project().Name, inst.Name(), volType) })
 
 	// Some driver backing stores require that running instances be frozen during snapshot.
-	if b.driver.Info().RunningCopyFreeze && src.IsRunning() && !src.IsFrozen() {
+	// Running VMs on drivers supporting snapshot overlays have already had their writes redirected.
+	snapshotOverlay := b.driver.Info().SnapshotOverlay && src.Type() == instancetype.VM
+	if b.driver.Info().RunningCopyFreeze && !snapshotOverlay && src.IsRunning() && !src.IsFrozen() {
 		// Freeze the processes.
 		err = src.Freeze()
 		if err != nil {
@@ -4331,6 +4334,17 @@ func (b *backend) CreateBucketKey(projectName string, bucketName string, key api
 		SecretKey: key.SecretKey,
 	}
 
+	// Secret keys referencing an external secret are recorded as such, only their value being given to the storage.
+	secretRef := ""
+	if secrets.IsReference(key.SecretKey) {
+		secretRef = key.SecretKey
+
+		creds.SecretKey, err = secrets.Resolve(ctx, secretRef)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = b.driver.ValidateBucketKey(key.Name, creds, key.Role)
 	if err != nil {
 		return nil, err
@@ -4357,7 +4371,7 @@ func (b *backend) CreateBucketKey(projectName string, bucketName string, key api
 			return nil, err
 		}
 
-		adminCreds, err := adminClient.AddServiceAccount(ctx, minioProc.AdminUser(), key.AccessKey, key.SecretKey, bucketPolicy)
+		adminCreds, err := adminClient.AddServiceAccount(ctx, minioProc.AdminUser(), creds.AccessKey, creds.SecretKey, bucketPolicy)
 		if err != nil {
 			return nil, err
 		}
@@ -4381,6 +4395,10 @@ func (b *backend) CreateBucketKey(projectName string, bucketName string, key api
 	key.AccessKey = newCreds.AccessKey
 	key.SecretKey = newCreds.SecretKey
 
+	if secretRef != "" {
+		key.SecretKey = secretRef
+	}
+
 	newKey := api.StorageBucketKey{
 		Name: key.Name,
 		StorageBucketKeyPut: api.StorageBucketKeyPut{
@@ -4471,6 +4489,17 @@ func (b *backend) UpdateBucketKey(projectName string, bucketName string, keyName
 		SecretKey: newBucketKey.SecretKey,
 	}
 
+	// Secret keys referencing an external secret are recorded as such, only their value being given to the storage.
+	secretRef := ""
+	if secrets.IsReference(key.SecretKey) {
+		secretRef = key.SecretKey
+
+		creds.SecretKey, err = secrets.Resolve(ctx, secretRef)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = b.driver.ValidateBucketKey(keyName, creds, key.Role)
 	if err != nil {
 		return err
@@ -4529,6 +4558,10 @@ func (b *backend) UpdateBucketKey(projectName string, bucketName string, keyName
 		key.SecretKey = newCreds.SecretKey
 	}
 
+	if secretRef != "" {
+		key.SecretKey = secretRef
+	}
+
 	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 		// Update the database record.
 		return tx.UpdateStoragePoolBucketKey(ctx, bucket.ID, curBucketKey.ID, &key)
@@ -7495,7 +7528,7 @@ func (b *backend) getFirstReadStorageBucketPoolKey(bucketID int64) (*db.StorageB
 		return nil, err
 	}
 
-	return backupKey, nil
+	return b.resolveBucketKeySecret(backupKey)
 }
 
 func (b *backend) getFirstAdminStorageBucketPoolKey(projectName string, bucketName string) (*db.StorageBucketKey, error) {
@@ -7539,5 +7572,18 @@ func (b *backend) getFirstAdminStorageBucketPoolKey(projectName string, bucketNa
 		return nil, err
 	}
 
-	return bucketKey, nil
+	return b.resolveBucketKeySecret(bucketKey)
+}
+
+// resolveBucketKeySecret returns a copy of the bucket key whose secret key is resolved, if it references an external secret.
+func (b *backend) resolveBucketKeySecret(key *db.StorageBucketKey) (*db.StorageBucketKey, error) {
+	secretKey, err := secrets.Resolve(b.state.ShutdownCtx, key.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := *key
+	resolved.SecretKey = secretKey
+
+	return &resolved, nil
 }
@@ -45,6 +45,7 @@ func (d *dir) Info() Info {
 		VolumeMultiNode:              d.isRemote(),
 		BlockBacking:                 false,
 		RunningCopyFreeze:            true,
+		SnapshotOverlay:              true, // Snapshots are full copies of the volume.
 		DirectIO:                     true,
 		IOUring:                      true,
 		MountedRoot:                  true,
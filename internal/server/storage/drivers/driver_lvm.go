@@ -142,6 +142,7 @@ func (d *lvm) Info() Info {
 		VolumeMultiNode:              d.isRemote(),
 		BlockBacking:                 true,
 		RunningCopyFreeze:            true,
+		SnapshotOverlay:              !d.usesThinpool(), // Only thinpool pools support efficient snapshots.
 		DirectIO:                     true,
 		IOUring:                      true,
 		MountedRoot:                  false,
@@ -0,0 +1,210 @@
+package drivers
+
+import (
+	"io"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/lxc/incus/v6/internal/instancewriter"
+	"github.com/lxc/incus/v6/internal/server/backup"
+	"github.com/lxc/incus/v6/internal/server/migration"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/tracing"
+	"github.com/lxc/incus/v6/shared/revert"
+)
+
+// tracedDriver wraps a driver to trace its long running calls as part of the operation they are made for,
+// and to record the time the operation spends snapshotting, transferring and unpacking the volumes.
+type tracedDriver struct {
+	Driver
+}
+
+// start starts the span of a driver call, as a child of the span of the operation.
+func (d *tracedDriver) start(op *operations.Operation, name string, vol *Volume) trace.Span {
+	attrs := []attribute.KeyValue{
+		attribute.String("incus.storage.pool", d.Name()),
+		attribute.String("incus.storage.driver", d.Info().Name),
+	}
+
+	if vol != nil {
+		attrs = append(attrs,
+			attribute.String("incus.storage.volume", vol.Name()),
+			attribute.String("incus.storage.volume_type", string(vol.Type())),
+			attribute.String("incus.storage.content_type", string(vol.ContentType())),
+		)
+	}
+
+	_, span := tracing.Start(op.TraceContext(), "storage."+name, attrs...)
+
+	return span
+}
+
+// Delete traces the deletion of the storage pool.
+func (d *tracedDriver) Delete(op *operations.Operation) error {
+	span := d.start(op, "Delete", nil)
+	err := d.Driver.Delete(op)
+	tracing.End(span, err)
+
+	return err
+}
+
+// CreateBucket traces the creation of a bucket.
+func (d *tracedDriver) CreateBucket(bucket Volume, op *operations.Operation) error {
+	span := d.start(op, "CreateBucket", &bucket)
+	err := d.Driver.CreateBucket(bucket, op)
+	tracing.End(span, err)
+
+	return err
+}
+
+// DeleteBucket traces the deletion of a bucket.
+func (d *tracedDriver) DeleteBucket(bucket Volume, op *operations.Operation) error {
+	span := d.start(op, "DeleteBucket", &bucket)
+	err := d.Driver.DeleteBucket(bucket, op)
+	tracing.End(span, err)
+
+	return err
+}
+
+// CreateVolume traces the creation of a volume.
+func (d *tracedDriver) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.Operation) error {
+	if filler != nil && filler.Fill != nil {
+		defer op.StartPhase("unpack")()
+	}
+
+	span := d.start(op, "CreateVolume", &vol)
+	err := d.Driver.CreateVolume(vol, filler, op)
+	tracing.End(span, err)
+
+	return err
+}
+
+// CreateVolumeFromCopy traces the copy of a volume.
+func (d *tracedDriver) CreateVolumeFromCopy(vol Volume, srcVol Volume, copySnapshots bool, allowInconsistent bool, op *operations.Operation) error {
+	defer op.StartPhase("transfer")()
+
+	span := d.start(op, "CreateVolumeFromCopy", &vol)
+	err := d.Driver.CreateVolumeFromCopy(vol, srcVol, copySnapshots, allowInconsistent, op)
+	tracing.End(span, err)
+
+	return err
+}
+
+// RefreshVolume traces the refresh of a volume.
+func (d *tracedDriver) RefreshVolume(vol Volume, srcVol Volume, srcSnapshots []Volume, allowInconsistent bool, op *operations.Operation) error {
+	defer op.StartPhase("transfer")()
+
+	span := d.start(op, "RefreshVolume", &vol)
+	err := d.Driver.RefreshVolume(vol, srcVol, srcSnapshots, allowInconsistent, op)
+	tracing.End(span, err)
+
+	return err
+}
+
+// DeleteVolume traces the deletion of a volume.
+func (d *tracedDriver) DeleteVolume(vol Volume, op *operations.Operation) error {
+	span := d.start(op, "DeleteVolume", &vol)
+	err := d.Driver.DeleteVolume(vol, op)
+	tracing.End(span, err)
+
+	return err
+}
+
+// RenameVolume traces the renaming of a volume.
+func (d *tracedDriver) RenameVolume(vol Volume, newName string, op *operations.Operation) error {
+	span := d.start(op, "RenameVolume", &vol)
+	err := d.Driver.RenameVolume(vol, newName, op)
+	tracing.End(span, err)
+
+	return err
+}
+
+// SetVolumeQuota traces the resizing of a volume.
+func (d *tracedDriver) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op *operations.Operation) error {
+	span := d.start(op, "SetVolumeQuota", &vol)
+	err := d.Driver.SetVolumeQuota(vol, size, allowUnsafeResize, op)
+	tracing.End(span, err)
+
+	return err
+}
+
+// CreateVolumeSnapshot traces the creation of a volume snapshot.
+func (d *tracedDriver) CreateVolumeSnapshot(snapVol Volume, op *operations.Operation) error {
+	defer op.StartPhase("snapshot")()
+
+	span := d.start(op, "CreateVolumeSnapshot", &snapVol)
+	err := d.Driver.CreateVolumeSnapshot(snapVol, op)
+	tracing.End(span, err)
+
+	return err
+}
+
+// DeleteVolumeSnapshot traces the deletion of a volume snapshot.
+func (d *tracedDriver) DeleteVolumeSnapshot(snapVol Volume, op *operations.Operation) error {
+	span := d.start(op, "DeleteVolumeSnapshot", &snapVol)
+	err := d.Driver.DeleteVolumeSnapshot(snapVol, op)
+	tracing.End(span, err)
+
+	return err
+}
+
+// RestoreVolume traces the restoration of a volume from one of its snapshots.
+func (d *tracedDriver) RestoreVolume(vol Volume, snapshotName string, op *operations.Operation) error {
+	span := d.start(op, "RestoreVolume", &vol)
+	err := d.Driver.RestoreVolume(vol, snapshotName, op)
+	tracing.End(span, err)
+
+	return err
+}
+
+// MigrateVolume traces the sending of a volume to a migration target.
+func (d *tracedDriver) MigrateVolume(vol Volume, conn io.ReadWriteCloser, volSrcArgs *migration.VolumeSourceArgs, op *operations.Operation) error {
+	defer op.StartPhase("transfer")()
+
+	span := d.start(op, "MigrateVolume", &vol)
+	err := d.Driver.MigrateVolume(vol, conn, volSrcArgs, op)
+	tracing.End(span, err)
+
+	return err
+}
+
+// CreateVolumeFromMigration traces the reception of a volume from a migration source.
+func (d *tracedDriver) CreateVolumeFromMigration(vol Volume, conn io.ReadWriteCloser, volTargetArgs migration.VolumeTargetArgs, preFiller *VolumeFiller, op *operations.Operation) error {
+	defer op.StartPhase("transfer")()
+
+	span := d.start(op, "CreateVolumeFromMigration", &vol)
+	err := d.Driver.CreateVolumeFromMigration(vol, conn, volTargetArgs, preFiller, op)
+	tracing.End(span, err)
+
+	return err
+}
+
+// BackupVolume traces the backup of a volume.
+func (d *tracedDriver) BackupVolume(vol Volume, tarWriter *instancewriter.InstanceTarWriter, optimized bool, snapshots []string, op *operations.Operation) error {
+	span := d.start(op, "BackupVolume", &vol)
+	err := d.Driver.BackupVolume(vol, tarWriter, optimized, snapshots, op)
+	tracing.End(span, err)
+
+	return err
+}
+
+// CreateVolumeFromBackup traces the restoration of a volume from a backup.
+func (d *tracedDriver) CreateVolumeFromBackup(vol Volume, srcBackup backup.Info, srcData io.ReadSeeker, op *operations.Operation) (VolumePostHook, revert.Hook, error) {
+	defer op.StartPhase("unpack")()
+
+	span := d.start(op, "CreateVolumeFromBackup", &vol)
+	postHook, revertHook, err := d.Driver.CreateVolumeFromBackup(vol, srcBackup, srcData, op)
+	tracing.End(span, err)
+
+	if postHook != nil {
+		driverPostHook := postHook
+		postHook = func(vol Volume) error {
+			defer op.StartPhase("post_processing")()
+
+			return driverPostHook(vol)
+		}
+	}
+
+	return postHook, revertHook, err
+}
@@ -48,16 +48,18 @@ type OS struct {
 	VarDir   string // Data directory (e.g. /var/lib/incus/).
 
 	// Daemon environment
-	Architectures   []int      // Cache of detected system architectures
-	BackingFS       string     // Backing filesystem of $INCUS_DIR/containers
-	ExecPath        string     // Absolute path to the daemon
-	IdmapSet        *idmap.Set // Information about user/group ID mapping
-	InotifyWatch    InotifyInfo
-	LxcPath         string // Path to the $INCUS_DIR/containers directory
-	MockMode        bool   // If true some APIs will be mocked (for testing)
-	Nodev           bool
-	RunningInUserNS bool
-	Hostname        string
+	Architectures         []int      // Cache of detected system architectures
+	EmulatedArchitectures []int      // Foreign architectures run through qemu-user
+	BackingFS             string     // Backing filesystem of $INCUS_DIR/containers
+	ExecPath              string     // Absolute path to the daemon
+	IdmapSet              *idmap.Set // Information about user/group ID mapping
+	InotifyWatch          InotifyInfo
+	LxcPath               string // Path to the $INCUS_DIR/containers directory
+	MockMode              bool   // If true some APIs will be mocked (for testing)
+	Nodev                 bool
+	RunningInUserNS       bool
+	Rootless              bool // If true the daemon runs unprivileged for a single user
+	Hostname              string
 
 	// Privilege dropping
 	UnprivUser  string
@@ -174,6 +176,7 @@ func (s *OS) Init() ([]cluster.Warning, error) {
 	s.IdmapSet = getIdmapset()
 	s.ExecPath = localUtil.GetExecPath()
 	s.RunningInUserNS = linux.RunningInUserNS()
+	s.Rootless = os.Getenv("INCUS_ROOTLESS") == "1"
 	s.Hostname, err = os.Hostname()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,82 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/lxc/incus/v6/internal/server/tracing"
+)
+
+func TestExport(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	spans := map[string]map[string]any{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []map[string]any `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		paths = append(paths, r.URL.Path)
+		for _, resourceSpans := range body.ResourceSpans {
+			for _, scopeSpans := range resourceSpans.ScopeSpans {
+				for _, span := range scopeSpans.Spans {
+					spans[span["name"].(string)] = span
+				}
+			}
+		}
+	}))
+	defer server.Close()
+
+	require.NoError(t, tracing.Configure(server.URL, "", 100, "server01"))
+
+	ctx, parent := tracing.Start(context.Background(), "parent", attribute.String("incus.instance", "c1"))
+	_, child := tracing.Start(ctx, "child")
+	tracing.End(child, errors.New("Failed"))
+	tracing.End(parent, nil)
+
+	require.NoError(t, tracing.Shutdown(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	require.Contains(t, spans, "parent")
+	require.Contains(t, spans, "child")
+	assert.Contains(t, paths, "/v1/traces")
+
+	// The child span is linked to its parent and carries the error.
+	assert.Equal(t, spans["parent"]["traceId"], spans["child"]["traceId"])
+	assert.Equal(t, spans["parent"]["spanId"], spans["child"]["parentSpanId"])
+	assert.Equal(t, map[string]any{"code": float64(2), "message": "Failed"}, spans["child"]["status"])
+	assert.Equal(t, []any{map[string]any{"key": "incus.instance", "value": map[string]any{"stringValue": "c1"}}}, spans["parent"]["attributes"])
+}
+
+func TestDisabled(t *testing.T) {
+	require.NoError(t, tracing.Configure("", "", 100, "server01"))
+
+	_, span := tracing.Start(context.Background(), "span")
+	assert.False(t, span.IsRecording())
+	span.End()
+}
@@ -0,0 +1,157 @@
+// Package tracing records OpenTelemetry spans of the daemon and exports them to an OTLP collector.
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"runtime"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// tracerName is the name of the instrumentation scope of the spans.
+const tracerName = "incus"
+
+var (
+	tracer     trace.Tracer = noop.NewTracerProvider().Tracer(tracerName)
+	provider   *sdktrace.TracerProvider
+	tracerMu   sync.RWMutex
+	propagator = propagation.TraceContext{}
+)
+
+// Configure sets up the export of the spans to the OTLP/HTTP collector at the given URL, replacing any previous
+// configuration. Only the given percentage of the traces started by this server is recorded.
+// An empty URL disables tracing.
+func Configure(endpoint string, caCert string, samplePercentage int64, location string) error {
+	var newProvider *sdktrace.TracerProvider
+
+	if endpoint != "" {
+		exporter, err := newExporter(endpoint, caCert)
+		if err != nil {
+			return err
+		}
+
+		res := resource.NewSchemaless(
+			attribute.String("service.name", "incus"),
+			attribute.String("service.version", version.Version),
+			attribute.String("service.instance.id", location),
+		)
+
+		newProvider = sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(res),
+			sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(float64(samplePercentage)/100))),
+		)
+	}
+
+	tracerMu.Lock()
+	oldProvider := provider
+	provider = newProvider
+
+	if newProvider != nil {
+		tracer = newProvider.Tracer(tracerName)
+	} else {
+		tracer = noop.NewTracerProvider().Tracer(tracerName)
+	}
+
+	tracerMu.Unlock()
+
+	// Flush the spans of the previous configuration in the background.
+	if oldProvider != nil {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			err := oldProvider.Shutdown(ctx)
+			if err != nil {
+				logger.Warn("Failed flushing traces", logger.Ctx{"err": err})
+			}
+		}()
+	}
+
+	return nil
+}
+
+// Shutdown flushes the pending spans and disables tracing.
+func Shutdown(ctx context.Context) error {
+	tracerMu.Lock()
+	oldProvider := provider
+	provider = nil
+	tracer = noop.NewTracerProvider().Tracer(tracerName)
+	tracerMu.Unlock()
+
+	if oldProvider == nil {
+		return nil
+	}
+
+	return oldProvider.Shutdown(ctx)
+}
+
+// Start starts a new span, child of the span of the given context if any.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	tracerMu.RLock()
+	t := tracer
+	tracerMu.RUnlock()
+
+	return t.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartRequest starts the span of an API request, continuing the trace of the client when the request carries
+// a W3C trace context.
+func StartRequest(r *http.Request, route string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	tracerMu.RLock()
+	t := tracer
+	tracerMu.RUnlock()
+
+	ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+	attrs = append(attrs,
+		attribute.String("http.request.method", r.Method),
+		attribute.String("http.route", route),
+		attribute.String("url.path", r.URL.Path),
+		attribute.String("client.address", r.RemoteAddr),
+	)
+
+	return t.Start(ctx, r.Method+" "+route, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+}
+
+// Inject adds the trace context of the span of the given context to the headers of an outgoing request.
+func Inject(ctx context.Context, header http.Header) {
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// Detach returns a background context carrying the span of the given context, for work outliving the latter.
+func Detach(ctx context.Context) context.Context {
+	return trace.ContextWithSpanContext(context.Background(), trace.SpanContextFromContext(ctx))
+}
+
+// End records the error, if any, and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// Caller returns an attribute holding the name of the function skip frames above the caller of Caller.
+func Caller(skip int) attribute.KeyValue {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return attribute.String("code.function", "unknown")
+	}
+
+	return attribute.String("code.function", runtime.FuncForPC(pc).Name())
+}
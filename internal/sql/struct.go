@@ -1,5 +1,9 @@
 package sql
 
+import (
+	"time"
+)
+
 // SQLDump represents a full database dump.
 type SQLDump struct {
 	Text string `json:"text" yaml:"text"`
@@ -23,3 +27,12 @@ type SQLResult struct {
 	Rows         [][]any  `json:"rows"          yaml:"rows"`
 	RowsAffected int64    `json:"rows_affected" yaml:"rows_affected"`
 }
+
+// SQLSlowQuery represents a query of the cluster database which took longer than the threshold.
+type SQLSlowQuery struct {
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+	Duration  float64   `json:"duration"  yaml:"duration"`
+	Query     string    `json:"query"     yaml:"query"`
+	Args      []string  `json:"args"      yaml:"args"`
+	Caller    string    `json:"caller"    yaml:"caller"`
+}
@@ -484,6 +484,79 @@ var APIExtensions = []string{
 	"instance_publish_split",
 	"init_preseed_certificates",
 	"custom_volume_sftp",
+	"network_nat_reflection",
+	"network_firewall_user_rules",
+	"network_zones_record_templates",
+	"network_bridge_mtu_auto",
+	"network_ipvlan_l3_isolation",
+	"gpu_mdev_placement",
+	"usb_hotplug_filters",
+	"disk_io_virtiofs",
+	"pci_iommu_group",
+	"agent_hotplug_online",
+	"instance_limits_cgroup2",
+	"instance_restart_policy",
+	"instance_boot_depends_on",
+	"instance_uefi",
+	"instance_machine_upgrade",
+	"gpu_virtio",
+	"instance_uefi_secureboot_keys",
+	"instance_cpu_model",
+	"instance_audio_input_devices",
+	"instance_snapshot_overlay",
+	"instance_debug_qmp",
+	"instance_oci_application",
+	"project_idmap_isolated",
+	"instance_syscalls_policy",
+	"instance_apparmor_extra_rules",
+	"instance_systemd_integration",
+	"instance_architecture_emulation",
+	"instance_lxc_hooks",
+	"image_publish_live",
+	"instance_network_namespace",
+	"instance_placement_rules",
+	"cluster_rebalance_control",
+	"clustering_drain",
+	"clustering_witness",
+	"images_replication",
+	"instances_placement_scriptlet_metrics",
+	"clustering_heal",
+	"clustering_groups_overrides",
+	"projects_usage_report",
+	"projects_limits_networks_bandwidth",
+	"projects_templates",
+	"projects_hierarchy",
+	"profiles_extends",
+	"instances_config_origin",
+	"instances_limits_check",
+	"projects_maintenance_window",
+	"projects_audit",
+	"oidc_groups_claim",
+	"acme_cluster_members",
+	"config_secrets",
+	"sessions",
+	"audit_log",
+	"identity_allowed_sources",
+	"impersonation",
+	"api_rate_limits",
+	"operation_progress",
+	"scheduled_tasks",
+	"health_endpoints",
+	"tracing",
+	"batch",
+	"collection_filtering",
+	"etag_network_forwards_load_balancers_peers_buckets",
+	"warnings_notifications",
+	"metrics_devices",
+	"agent_process_service_metrics",
+	"logging_console",
+	"server_logs",
+	"metrics_lifecycle_events",
+	"database_slow_query_log",
+	"metrics_api_requests",
+	"instances_start_uptime_metrics",
+	"operation_timings",
+	"etag_if_none_match",
 }
 
 // APIExtensionsCount returns the number of available API extensions.
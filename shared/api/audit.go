@@ -0,0 +1,68 @@
+package api
+
+import (
+	"time"
+)
+
+// AuditEntry represents an entry of the audit log of a server.
+//
+// swagger:model
+//
+// API extension: audit_log.
+type AuditEntry struct {
+	// Time at which the request was handled
+	// Example: 2021-03-23T17:38:37.753398689Z
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+
+	// Cluster member which handled the request
+	// Example: server01
+	Location string `json:"location" yaml:"location"`
+
+	// Identity of the client (certificate fingerprint, OIDC user or local user)
+	// Example: jdoe@example.com
+	Username string `json:"username" yaml:"username"`
+
+	// Identity of the server administrator who ran the request as the client
+	// Example: 7d5f1b1c0bd2e0e2a4ddd0a0dcb1f48a7f3e8437041ef0cd29c82bd7e30fbc4b
+	//
+	// API extension: impersonation
+	Impersonator string `json:"impersonator,omitempty" yaml:"impersonator,omitempty"`
+
+	// Authentication method of the client
+	// Example: oidc
+	Protocol string `json:"protocol" yaml:"protocol"`
+
+	// Address of the client
+	// Example: 10.0.2.15:45378
+	Address string `json:"address" yaml:"address"`
+
+	// HTTP method of the request
+	// Example: DELETE
+	Method string `json:"method" yaml:"method"`
+
+	// Requested object
+	// Example: /1.0/instances/c1?project=default
+	URL string `json:"url" yaml:"url"`
+
+	// HTTP status code of the response
+	// Example: 202
+	StatusCode int `json:"status_code" yaml:"status_code"`
+
+	// Outcome of the request (success or failure)
+	// Example: success
+	Result string `json:"result" yaml:"result"`
+
+	// Why the server rejected the request before handling it
+	// Example: Source address not allowed for this identity
+	//
+	// API extension: identity_allowed_sources
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+
+	// Hash of the previous entry of the audit log
+	// Example: 5d41402abc4b2a76b9719d911017c592b7d6e3e6b1b4fbb9a9b2e1e0a8b3f1c2
+	PreviousHash string `json:"previous_hash" yaml:"previous_hash"`
+
+	// Hash of this entry, chained with the previous one
+	// Example: 7c211433f02071597741e6ff5a8ea34789abbf43b1d0c6d50e1b4e0a9b0c5a11
+	Hash string `json:"hash" yaml:"hash"`
+}
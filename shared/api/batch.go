@@ -0,0 +1,76 @@
+package api
+
+// BatchPost represents a list of API requests to run server-side as a single operation
+//
+// swagger:model
+//
+// API extension: batch.
+type BatchPost struct {
+	// List of requests to run, in order
+	Requests []BatchRequest `json:"requests" yaml:"requests"`
+
+	// Number of requests to run at the same time (defaults to 1)
+	// Example: 4
+	Parallel int `json:"parallel" yaml:"parallel"`
+
+	// Whether to skip the remaining requests after a failure
+	// Example: false
+	StopOnError bool `json:"stop_on_error" yaml:"stop_on_error"`
+}
+
+// BatchRequest represents a single API request of a batch
+//
+// swagger:model
+//
+// API extension: batch.
+type BatchRequest struct {
+	// HTTP method of the request
+	// Example: PUT
+	Method string `json:"method" yaml:"method"`
+
+	// Path and query string of the request
+	// Example: /1.0/instances/c1/state?project=default
+	URL string `json:"url" yaml:"url"`
+
+	// Body of the request
+	// Example: {"action": "start"}
+	Body any `json:"body" yaml:"body"`
+}
+
+// BatchResult represents the result of a single API request of a batch
+//
+// swagger:model
+//
+// API extension: batch.
+type BatchResult struct {
+	// HTTP method of the request
+	// Example: PUT
+	Method string `json:"method" yaml:"method"`
+
+	// Path and query string of the request
+	// Example: /1.0/instances/c1/state?project=default
+	URL string `json:"url" yaml:"url"`
+
+	// Status of the request (Pending, Running, Success, Failure, Cancelled)
+	// Example: Success
+	Status string `json:"status" yaml:"status"`
+
+	// Status code of the request
+	// Example: 200
+	StatusCode StatusCode `json:"status_code" yaml:"status_code"`
+
+	// HTTP status code of the error, when the request was rejected
+	// Example: 404
+	ErrorCode int `json:"error_code" yaml:"error_code"`
+
+	// Error message of the failed request
+	// Example: Instance not found
+	Error string `json:"error" yaml:"error"`
+
+	// Response metadata, or the metadata of the background operation of the request in its final state
+	Metadata any `json:"metadata" yaml:"metadata"`
+
+	// URL of the background operation of the request, if any
+	// Example: /1.0/operations/b8d84888-1dc2-44fd-b386-7f679e171ba5
+	Operation string `json:"operation" yaml:"operation"`
+}
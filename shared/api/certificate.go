@@ -72,6 +72,12 @@ type CertificatePut struct {
 	//
 	// API extension: certificate_description
 	Description string `json:"description" yaml:"description"`
+
+	// List of networks the certificate may be used from (empty for any)
+	// Example: ["10.0.0.0/24", "2001:db8::/64"]
+	//
+	// API extension: identity_allowed_sources
+	AllowedSources []string `json:"allowed_sources" yaml:"allowed_sources"`
 }
 
 // Certificate represents a certificate
@@ -245,7 +245,7 @@ type ClusterCertificatePut struct {
 //
 // API extension: clustering_evacuation.
 type ClusterMemberStatePost struct {
-	// The action to be performed. Valid actions are "evacuate" and "restore".
+	// The action to be performed. Valid actions are "evacuate", "drain" and "restore".
 	// Example: evacuate
 	Action string `json:"action" yaml:"action"`
 
@@ -256,6 +256,34 @@ type ClusterMemberStatePost struct {
 	Mode string `json:"mode" yaml:"mode"`
 }
 
+// ClusterMemberDrainEntry represents what draining a cluster member did to one of its instances
+// and how restoring the cluster member undoes it.
+//
+// swagger:model
+//
+// API extension: clustering_drain.
+type ClusterMemberDrainEntry struct {
+	// Project of the instance
+	// Example: default
+	Project string `json:"project" yaml:"project"`
+
+	// Name of the instance
+	// Example: c1
+	Instance string `json:"instance" yaml:"instance"`
+
+	// Action performed on the instance ("live-migrate", "migrate", "stop", "stateful-stop", "force-stop" or "none")
+	// Example: live-migrate
+	Action string `json:"action" yaml:"action"`
+
+	// Cluster member the instance was moved to
+	// Example: server02
+	Location string `json:"location" yaml:"location"`
+
+	// Action performed on restore ("migrate", "start" or "none")
+	// Example: migrate
+	Restore string `json:"restore" yaml:"restore"`
+}
+
 // ClusterGroupsPost represents the fields available for a new cluster group.
 //
 // swagger:model
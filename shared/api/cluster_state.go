@@ -15,6 +15,14 @@ type ClusterMemberSysInfo struct {
 	TotalSwap    uint64    `json:"total_swap" yaml:"total_swap"`
 	FreeSwap     uint64    `json:"free_swap" yaml:"free_swap"`
 	Processes    uint16    `json:"processes" yaml:"processes"`
+
+	// Percentage of the last 10 seconds during which some tasks were stalled on CPU, memory or IO
+	// Example: 1.5
+	//
+	// API extension: instances_placement_scriptlet_metrics.
+	CPUPressure    float64 `json:"cpu_pressure" yaml:"cpu_pressure"`
+	MemoryPressure float64 `json:"memory_pressure" yaml:"memory_pressure"`
+	IOPressure     float64 `json:"io_pressure" yaml:"io_pressure"`
 }
 
 // ClusterMemberState represents the state of a cluster member.
@@ -12,6 +12,7 @@ const (
 	EventTypeLogging    = "logging"
 	EventTypeOperation  = "operation"
 	EventTypeNetworkACL = "network-acl"
+	EventTypeAudit      = "audit"
 )
 
 // Event represents an event entry (over websocket)
@@ -39,7 +39,10 @@ const (
 	EventLifecycleInstanceConsoleReset              = "instance-console-reset"
 	EventLifecycleInstanceConsoleRetrieved          = "instance-console-retrieved"
 	EventLifecycleInstanceCreated                   = "instance-created"
+	EventLifecycleInstanceDebugQMP                  = "instance-debug-qmp"
 	EventLifecycleInstanceDeleted                   = "instance-deleted"
+	EventLifecycleInstanceDeviceAttached            = "instance-device-attached"
+	EventLifecycleInstanceDeviceDetached            = "instance-device-detached"
 	EventLifecycleInstanceExec                      = "instance-exec"
 	EventLifecycleInstanceFileDeleted               = "instance-file-deleted"
 	EventLifecycleInstanceFilePushed                = "instance-file-pushed"
@@ -54,6 +57,7 @@ const (
 	EventLifecycleInstanceMigrated                  = "instance-migrated"
 	EventLifecycleInstancePaused                    = "instance-paused"
 	EventLifecycleInstanceReady                     = "instance-ready"
+	EventLifecycleInstanceRebalanced                = "instance-rebalanced"
 	EventLifecycleInstanceRenamed                   = "instance-renamed"
 	EventLifecycleInstanceRestarted                 = "instance-restarted"
 	EventLifecycleInstanceRestored                  = "instance-restored"
@@ -106,6 +110,10 @@ const (
 	EventLifecycleProjectDeleted                    = "project-deleted"
 	EventLifecycleProjectRenamed                    = "project-renamed"
 	EventLifecycleProjectUpdated                    = "project-updated"
+	EventLifecycleScheduledTaskCreated              = "scheduled-task-created"
+	EventLifecycleScheduledTaskDeleted              = "scheduled-task-deleted"
+	EventLifecycleScheduledTaskRun                  = "scheduled-task-run"
+	EventLifecycleScheduledTaskUpdated              = "scheduled-task-updated"
 	EventLifecycleStorageBucketBackupCreated        = "storage-bucket-backup-created"
 	EventLifecycleStorageBucketBackupDeleted        = "storage-bucket-backup-deleted"
 	EventLifecycleStorageBucketBackupRenamed        = "storage-bucket-backup-renamed"
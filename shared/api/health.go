@@ -0,0 +1,37 @@
+package api
+
+// HealthStatusOK represents a healthy server or component.
+const HealthStatusOK = "ok"
+
+// HealthStatusError represents an unhealthy server or component.
+const HealthStatusError = "error"
+
+// Health represents the health of the server, as reported by the /healthz and /readyz endpoints
+//
+// swagger:model
+//
+// API extension: health_endpoints.
+type Health struct {
+	// Overall status of the server (ok or error)
+	// Example: ok
+	Status string `json:"status" yaml:"status"`
+
+	// Status of the individual components
+	// Example: {"database": {"status": "ok"}}
+	Components map[string]HealthComponent `json:"components" yaml:"components"`
+}
+
+// HealthComponent represents the health of a server component
+//
+// swagger:model
+//
+// API extension: health_endpoints.
+type HealthComponent struct {
+	// Status of the component (ok or error)
+	// Example: error
+	Status string `json:"status" yaml:"status"`
+
+	// Reason the component is unhealthy
+	// Example: Storage pool "local" is unavailable
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
@@ -105,6 +105,12 @@ type ImagesPostSource struct {
 	//
 	// API extension: image_source_project
 	Project string `json:"project" yaml:"project"`
+
+	// Whether to checkpoint the running instance and include its state in the image (for type "instance")
+	// Example: false
+	//
+	// API extension: image_publish_live
+	Live bool `json:"live" yaml:"live"`
 }
 
 // ImagePut represents the modifiable fields of an image
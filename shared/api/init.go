@@ -101,4 +101,10 @@ type InitClusterPreseed struct {
 	// The path to the cluster certificate
 	// Example: /tmp/cluster.crt
 	ClusterCertificatePath string `json:"cluster_certificate_path" yaml:"cluster_certificate_path"`
+
+	// List of roles to assign to the member once it joined the cluster
+	// Example: ["witness"]
+	//
+	// API extension: clustering_witness
+	Roles []string `json:"roles" yaml:"roles"`
 }
@@ -187,6 +187,40 @@ type InstancePut struct {
 	Description string `json:"description" yaml:"description"`
 }
 
+// InstanceUpdateCheck represents the effect an instance update would have on the resources of its project.
+//
+// swagger:model
+//
+// API extension: instances_limits_check.
+type InstanceUpdateCheck struct {
+	// Project resources affected by the update
+	// Example: {"cpu": {"limit": 20, "usage": 16, "new_usage": 18}}
+	Resources map[string]InstanceUpdateCheckResource `json:"resources" yaml:"resources"`
+
+	// Reason the update would be refused (empty if it would be allowed)
+	// Example: Reached maximum aggregate value "20" for "limits.cpu" in project "foo" (usage would be 22)
+	Error string `json:"error" yaml:"error"`
+}
+
+// InstanceUpdateCheckResource represents the effect of an instance update on a particular resource of its project.
+//
+// swagger:model
+//
+// API extension: instances_limits_check.
+type InstanceUpdateCheckResource struct {
+	// Limit for the resource (-1 if none)
+	// Example: 20
+	Limit int64 `json:"limit" yaml:"limit"`
+
+	// Current usage for the resource
+	// Example: 16
+	Usage int64 `json:"usage" yaml:"usage"`
+
+	// Usage for the resource after the update
+	// Example: 18
+	NewUsage int64 `json:"new_usage" yaml:"new_usage"`
+}
+
 // InstanceRebuildPost indicates how to rebuild an instance.
 //
 // swagger:model
@@ -197,6 +231,25 @@ type InstanceRebuildPost struct {
 	Source InstanceSource `json:"source" yaml:"source"`
 }
 
+// InstanceConfigOrigin represents where an expanded instance configuration key or device comes from.
+//
+// swagger:model
+//
+// API extension: instances_config_origin.
+type InstanceConfigOrigin struct {
+	// Origin type (local or profile)
+	// Example: profile
+	Type string `json:"type" yaml:"type"`
+
+	// Name of the profile setting the value
+	// Example: default
+	Profile string `json:"profile,omitempty" yaml:"profile,omitempty"`
+
+	// Project of the profile setting the value
+	// Example: default
+	Project string `json:"project,omitempty" yaml:"project,omitempty"`
+}
+
 // Instance represents an instance.
 //
 // swagger:model
@@ -217,6 +270,18 @@ type Instance struct {
 	// Example: {"root": {"type": "disk", "pool": "default", "path": "/"}}
 	ExpandedDevices map[string]map[string]string `json:"expanded_devices,omitempty" yaml:"expanded_devices,omitempty"`
 
+	// Origin of each expanded configuration key (only set when requested)
+	// Example: {"security.nesting": {"type": "profile", "profile": "default", "project": "default"}}
+	//
+	// API extension: instances_config_origin
+	ExpandedConfigOrigins map[string]InstanceConfigOrigin `json:"expanded_config_origins,omitempty" yaml:"expanded_config_origins,omitempty"`
+
+	// Origin of each expanded device (only set when requested)
+	// Example: {"root": {"type": "local"}}
+	//
+	// API extension: instances_config_origin
+	ExpandedDevicesOrigins map[string]InstanceConfigOrigin `json:"expanded_devices_origins,omitempty" yaml:"expanded_devices_origins,omitempty"`
+
 	// Instance name
 	// Example: foo
 	Name string `json:"name" yaml:"name"`
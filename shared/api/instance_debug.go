@@ -0,0 +1,16 @@
+package api
+
+// InstanceDebugQMPPost represents a QMP command to run on the monitor of a virtual machine.
+//
+// swagger:model
+//
+// API extension: instance_debug_qmp.
+type InstanceDebugQMPPost struct {
+	// Name of the QMP command
+	// Example: query-status
+	Execute string `json:"execute" yaml:"execute"`
+
+	// Arguments of the QMP command
+	// Example: {"path": "/machine", "property": "type"}
+	Arguments map[string]any `json:"arguments,omitempty" yaml:"arguments,omitempty"`
+}
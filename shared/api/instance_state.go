@@ -70,6 +70,16 @@ type InstanceState struct {
 	//
 	// API extension: instances_state_os_info.
 	OSInfo *InstanceStateOSInfo `json:"os_info" yaml:"os_info"`
+
+	// Processes using the most CPU time, as reported by the VM agent when enabled
+	//
+	// API extension: agent_process_service_metrics
+	TopProcesses []InstanceStateProcess `json:"top_processes,omitempty" yaml:"top_processes,omitempty"`
+
+	// State of the systemd units, as reported by the VM agent when enabled
+	//
+	// API extension: agent_process_service_metrics
+	Services *InstanceStateServices `json:"services,omitempty" yaml:"services,omitempty"`
 }
 
 // InstanceStateDisk represents the disk information section of an instance's state.
@@ -258,3 +268,41 @@ type InstanceStateOSInfo struct {
 	// Example: myhost.mydomain.local
 	FQDN string `json:"fqdn" yaml:"fqdn"`
 }
+
+// InstanceStateProcess represents a process running in the instance.
+//
+// swagger:model
+//
+// API extension: agent_process_service_metrics.
+type InstanceStateProcess struct {
+	// PID of the process
+	// Example: 1234
+	PID int64 `json:"pid" yaml:"pid"`
+
+	// Name of the process
+	// Example: nginx
+	Name string `json:"name" yaml:"name"`
+
+	// CPU time used by the process since it started, in nanoseconds
+	// Example: 3637691016
+	CPUUsage int64 `json:"cpu_usage" yaml:"cpu_usage"`
+
+	// Resident memory of the process, in bytes
+	// Example: 73400320
+	MemoryUsage int64 `json:"memory_usage" yaml:"memory_usage"`
+}
+
+// InstanceStateServices represents the state of the systemd units of the instance.
+//
+// swagger:model
+//
+// API extension: agent_process_service_metrics.
+type InstanceStateServices struct {
+	// Number of loaded units
+	// Example: 214
+	Units int64 `json:"units" yaml:"units"`
+
+	// Names of the failed units
+	// Example: ["nginx.service"]
+	Failed []string `json:"failed" yaml:"failed"`
+}
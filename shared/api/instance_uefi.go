@@ -0,0 +1,124 @@
+package api
+
+// InstanceUEFI represents the UEFI variables and boot configuration of a virtual machine.
+//
+// swagger:model
+//
+// API extension: instance_uefi.
+type InstanceUEFI struct {
+	InstanceUEFIPut `yaml:",inline"`
+
+	// Boot entries defined in the UEFI variables
+	BootEntries []InstanceUEFIBootEntry `json:"boot_entries" yaml:"boot_entries"`
+
+	// List of UEFI variables
+	Variables []InstanceUEFIVariable `json:"variables" yaml:"variables"`
+
+	// Keys enrolled in the Secure Boot variables
+	//
+	// API extension: instance_uefi_secureboot_keys
+	SecureBootKeys []InstanceUEFISecureBootKey `json:"secureboot_keys" yaml:"secureboot_keys"`
+}
+
+// InstanceUEFIPut represents the modifiable UEFI boot configuration of a virtual machine.
+//
+// swagger:model
+//
+// API extension: instance_uefi.
+type InstanceUEFIPut struct {
+	// Boot entries in the order they're attempted
+	// Example: ["Boot0001", "Boot0000"]
+	BootOrder []string `json:"boot_order" yaml:"boot_order"`
+
+	// Boot entry to use for the next boot only
+	// Example: Boot0002
+	BootNext string `json:"boot_next" yaml:"boot_next"`
+}
+
+// InstanceUEFIBootEntry represents a UEFI boot entry.
+//
+// swagger:model
+//
+// API extension: instance_uefi.
+type InstanceUEFIBootEntry struct {
+	// Name of the boot entry variable
+	// Example: Boot0001
+	Name string `json:"name" yaml:"name"`
+
+	// Description of the boot entry
+	// Example: UEFI QEMU QEMU HARDDISK
+	Description string `json:"description" yaml:"description"`
+
+	// Whether the boot entry is active
+	// Example: true
+	Active bool `json:"active" yaml:"active"`
+}
+
+// InstanceUEFIVariable represents a UEFI variable.
+//
+// swagger:model
+//
+// API extension: instance_uefi.
+type InstanceUEFIVariable struct {
+	// Name of the variable
+	// Example: BootOrder
+	Name string `json:"name" yaml:"name"`
+
+	// Vendor GUID of the variable
+	// Example: 8be4df61-93ca-11d2-aa0d-00e098032b8c
+	GUID string `json:"guid" yaml:"guid"`
+
+	// Variable attributes
+	// Example: 7
+	Attributes uint32 `json:"attributes" yaml:"attributes"`
+
+	// Size of the variable data in bytes
+	// Example: 4
+	Size int `json:"size" yaml:"size"`
+}
+
+// InstanceUEFISecureBootKey represents an entry of one of the Secure Boot variables.
+//
+// swagger:model
+//
+// API extension: instance_uefi_secureboot_keys.
+type InstanceUEFISecureBootKey struct {
+	// Secure Boot variable holding the key (PK, KEK, db or dbx)
+	// Example: db
+	Variable string `json:"variable" yaml:"variable"`
+
+	// Type of entry (x509, sha256 or the GUID of the signature type)
+	// Example: x509
+	Type string `json:"type" yaml:"type"`
+
+	// GUID of the owner of the entry
+	// Example: 77fa9abd-0359-4d32-bd60-28f4e78f784b
+	Owner string `json:"owner" yaml:"owner"`
+
+	// Subject of the certificate (x509 only)
+	// Example: CN=Microsoft Corporation UEFI CA 2011
+	Subject string `json:"subject" yaml:"subject"`
+
+	// SHA256 fingerprint of the certificate or hash
+	// Example: 48e99b991f57fc52f76149599bff0a58c47154229b9f8d603ac40d3500248507
+	Fingerprint string `json:"fingerprint" yaml:"fingerprint"`
+}
+
+// InstanceUEFISecureBootKeysPost represents a request to enroll keys into one of the Secure Boot variables.
+//
+// swagger:model
+//
+// API extension: instance_uefi_secureboot_keys.
+type InstanceUEFISecureBootKeysPost struct {
+	// Secure Boot variable to enroll the keys into (PK, KEK, db or dbx)
+	// Example: db
+	Variable string `json:"variable" yaml:"variable"`
+
+	// PEM encoded certificates to enroll (an empty list removes all keys from the variable)
+	// Example: ["-----BEGIN CERTIFICATE-----\n...\n-----END CERTIFICATE-----"]
+	Certificates []string `json:"certificates" yaml:"certificates"`
+
+	// Whether to keep the existing keys of the variable
+	// Example: true
+	Append bool `json:"append" yaml:"append"`
+}
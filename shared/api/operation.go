@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -67,6 +68,66 @@ type Operation struct {
 	//
 	// API extension: operation_location
 	Location string `json:"location" yaml:"location"`
+
+	// Progress of the operation, if reported
+	//
+	// API extension: operation_progress
+	Progress *OperationProgress `json:"progress,omitempty" yaml:"progress,omitempty"`
+
+	// Time spent in each phase of the operation, if recorded
+	//
+	// API extension: operation_timings
+	Timings []OperationTiming `json:"timings,omitempty" yaml:"timings,omitempty"`
+}
+
+// OperationProgress represents the progress of a background operation
+//
+// swagger:model
+//
+// API extension: operation_progress.
+type OperationProgress struct {
+	// Current stage of the operation
+	// Example: create_backup
+	Stage string `json:"stage" yaml:"stage"`
+
+	// Time at which the current stage started
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	StartedAt time.Time `json:"started_at" yaml:"started_at"`
+
+	// Completion percentage of the current stage, if known
+	// Example: 42
+	Percent int64 `json:"percent" yaml:"percent"`
+
+	// Number of bytes processed by the current stage
+	// Example: 1073741824
+	Processed int64 `json:"processed" yaml:"processed"`
+
+	// Processing speed in bytes per second
+	// Example: 104857600
+	Speed int64 `json:"speed" yaml:"speed"`
+
+	// Estimated number of seconds until the current stage completes, if known
+	// Example: 30
+	ETA int64 `json:"eta" yaml:"eta"`
+}
+
+// OperationTiming represents the time spent by a background operation in one of its phases
+//
+// swagger:model
+//
+// API extension: operation_timings.
+type OperationTiming struct {
+	// Name of the phase (snapshot, transfer, unpack or post_processing)
+	// Example: transfer
+	Phase string `json:"phase" yaml:"phase"`
+
+	// Time at which the phase first started
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	StartedAt time.Time `json:"started_at" yaml:"started_at"`
+
+	// Number of seconds spent in the phase so far
+	// Example: 12.5
+	Duration float64 `json:"duration" yaml:"duration"`
 }
 
 // ToCertificateAddToken creates a certificate add token from the operation metadata.
@@ -176,3 +237,26 @@ func (op *Operation) ToClusterJoinToken() (*ClusterMemberJoinToken, error) {
 
 	return &joinToken, nil
 }
+
+// ToClusterMemberRestorePlan converts the metadata of a cluster member drain operation to its restore plan.
+//
+// API extension: clustering_drain.
+func (op *Operation) ToClusterMemberRestorePlan() ([]ClusterMemberDrainEntry, error) {
+	plan, ok := op.Metadata["restore_plan"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("Operation restore_plan is type %T not []any", op.Metadata["restore_plan"])
+	}
+
+	data, err := json.Marshal(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []ClusterMemberDrainEntry{}
+	err = json.Unmarshal(data, &entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -35,6 +35,12 @@ type ProfilePut struct {
 	// List of devices
 	// Example: {"root": {"type": "disk", "pool": "default", "path": "/"}, "eth0": {"type": "nic", "network": "mybr0", "name": "eth0"}}
 	Devices map[string]map[string]string `json:"devices" yaml:"devices"`
+
+	// Name of the profile whose configuration and devices this profile extends
+	// Example: base
+	//
+	// API extension: profiles_extends
+	Extends string `json:"extends,omitempty" yaml:"extends,omitempty"`
 }
 
 // Profile represents a profile
@@ -60,6 +66,20 @@ type Profile struct {
 	//
 	// API extension: profiles_all_projects
 	Project string `json:"project" yaml:"project"`
+
+	// Expanded configuration (merged with the profiles this profile extends)
+	// Read only: true
+	// Example: {"limits.cpu": "4", "limits.memory": "4GiB", "security.nesting": "true"}
+	//
+	// API extension: profiles_extends
+	ExpandedConfig map[string]string `json:"expanded_config,omitempty" yaml:"expanded_config,omitempty"`
+
+	// Expanded devices (merged with the profiles this profile extends)
+	// Read only: true
+	// Example: {"root": {"type": "disk", "pool": "default", "path": "/"}, "eth0": {"type": "nic", "network": "mybr0", "name": "eth0"}}
+	//
+	// API extension: profiles_extends
+	ExpandedDevices map[string]map[string]string `json:"expanded_devices,omitempty" yaml:"expanded_devices,omitempty"`
 }
 
 // Writable converts a full Profile struct into a ProfilePut struct (filters read-only fields).
@@ -14,6 +14,12 @@ type ProjectsPost struct {
 	// The name of the new project
 	// Example: foo
 	Name string `json:"name" yaml:"name"`
+
+	// Name of a template project to copy the configuration and profiles from
+	// Example: tenant-template
+	//
+	// API extension: projects_templates
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
 }
 
 // ProjectPost represents the fields required to rename a project
@@ -73,6 +79,18 @@ func (project *Project) URL(apiVersion string) *URL {
 	return NewURL().Path(apiVersion, "projects", project.Name)
 }
 
+// ProjectFull is a combination of Project and ProjectState.
+//
+// swagger:model
+//
+// API extension: projects_usage_report.
+type ProjectFull struct {
+	Project `yaml:",inline"`
+
+	// Current resource usage and limits.
+	State *ProjectState `json:"state" yaml:"state"`
+}
+
 // ProjectState represents the current running state of a project
 //
 // swagger:model
@@ -85,6 +103,34 @@ type ProjectState struct {
 	Resources map[string]ProjectStateResource `json:"resources" yaml:"resources"`
 }
 
+// ProjectAuditViolation represents a configuration key or device of an instance or profile violating the
+// restrictions of its project.
+//
+// swagger:model
+//
+// API extension: projects_audit.
+type ProjectAuditViolation struct {
+	// Type of the entity (instance or profile)
+	// Example: instance
+	EntityType string `json:"entity_type" yaml:"entity_type"`
+
+	// Name of the instance or profile
+	// Example: c1
+	EntityName string `json:"entity_name" yaml:"entity_name"`
+
+	// Configuration key violating the restrictions (empty for devices)
+	// Example: security.privileged
+	Key string `json:"key" yaml:"key"`
+
+	// Device violating the restrictions (empty for configuration keys)
+	// Example: gpu0
+	Device string `json:"device" yaml:"device"`
+
+	// Description of the violation
+	// Example: Invalid value "true" for config "security.privileged" on container "c1" of project "foo": Privileged containers are forbidden
+	Description string `json:"description" yaml:"description"`
+}
+
 // ProjectStateResource represents the state of a particular resource in a project
 //
 // swagger:model
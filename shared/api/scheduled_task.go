@@ -0,0 +1,96 @@
+package api
+
+import (
+	"time"
+)
+
+// ScheduledTaskStatusSuccess represents a successful scheduled task run.
+const ScheduledTaskStatusSuccess = "success"
+
+// ScheduledTaskStatusFailure represents a failed scheduled task run.
+const ScheduledTaskStatusFailure = "failure"
+
+// ScheduledTasksPost represents the fields of a new scheduled task
+//
+// swagger:model
+//
+// API extension: scheduled_tasks.
+type ScheduledTasksPost struct {
+	ScheduledTaskPut `yaml:",inline"`
+
+	// The name of the scheduled task
+	// Example: nightly-snapshots
+	Name string `json:"name" yaml:"name"`
+}
+
+// ScheduledTaskPut represents the modifiable fields of a scheduled task
+//
+// swagger:model
+//
+// API extension: scheduled_tasks.
+type ScheduledTaskPut struct {
+	// Description of the scheduled task
+	// Example: Nightly snapshots of the production instances
+	Description string `json:"description" yaml:"description"`
+
+	// Cron expression or alias (@hourly, @daily, ...) of the schedule
+	// Example: 0 3 * * *
+	Schedule string `json:"schedule" yaml:"schedule"`
+
+	// Action to run (snapshot, backup, image-refresh, exec or webhook)
+	// Example: snapshot
+	Action string `json:"action" yaml:"action"`
+
+	// Comma separated list of key=value filters selecting the instances the action applies to
+	// Example: project=prod,status=running
+	Filter string `json:"filter" yaml:"filter"`
+
+	// Action configuration map (refer to doc/scheduled-tasks.md)
+	// Example: {"snapshot.expiry": "7d"}
+	Config map[string]string `json:"config" yaml:"config"`
+}
+
+// ScheduledTask represents a scheduled task.
+//
+// swagger:model
+//
+// API extension: scheduled_tasks.
+type ScheduledTask struct {
+	ScheduledTaskPut `yaml:",inline"`
+
+	// The name of the scheduled task
+	// Example: nightly-snapshots
+	Name string `json:"name" yaml:"name"`
+
+	// Most recent runs of the task, newest first
+	// Read only: true
+	Runs []ScheduledTaskRun `json:"runs" yaml:"runs"`
+}
+
+// Writable converts a full ScheduledTask struct into a ScheduledTaskPut struct (filters read-only fields).
+func (t *ScheduledTask) Writable() ScheduledTaskPut {
+	return t.ScheduledTaskPut
+}
+
+// ScheduledTaskRun represents a run of a scheduled task on a server.
+//
+// swagger:model
+//
+// API extension: scheduled_tasks.
+type ScheduledTaskRun struct {
+	// When the task was run
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	Date time.Time `json:"date" yaml:"date"`
+
+	// What cluster member the task was run on
+	// Example: server01
+	Location string `json:"location" yaml:"location"`
+
+	// Result of the run (success or failure)
+	// Example: success
+	Status string `json:"status" yaml:"status"`
+
+	// Details about the run, like the error it failed with
+	// Example: Created 3 snapshots
+	Message string `json:"message" yaml:"message"`
+}
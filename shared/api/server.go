@@ -10,6 +10,12 @@ type ServerEnvironment struct {
 	// Example: ["x86_64", "i686"]
 	Architectures []string `json:"architectures" yaml:"architectures"`
 
+	// List of foreign architectures run through emulation by the server
+	// Example: ["aarch64", "riscv64"]
+	//
+	// API extension: instance_architecture_emulation
+	EmulatedArchitectures []string `json:"emulated_architectures" yaml:"emulated_architectures"`
+
 	// Server certificate as PEM encoded X509
 	// Example: X509 PEM certificate
 	Certificate string `json:"certificate" yaml:"certificate"`
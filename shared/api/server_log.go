@@ -0,0 +1,32 @@
+package api
+
+import (
+	"time"
+)
+
+// ServerLogEntry represents a message of the log of a server.
+//
+// swagger:model
+//
+// API extension: server_logs.
+type ServerLogEntry struct {
+	// Time at which the message was logged
+	// Example: 2021-03-23T17:38:37.753398689Z
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+
+	// Cluster member which logged the message
+	// Example: server01
+	Location string `json:"location" yaml:"location"`
+
+	// Level of the message
+	// Example: warning
+	Level string `json:"level" yaml:"level"`
+
+	// Message
+	// Example: Failed to start instance
+	Message string `json:"message" yaml:"message"`
+
+	// Context of the message
+	// Example: {"instance": "c1", "project": "default"}
+	Context map[string]string `json:"context" yaml:"context"`
+}
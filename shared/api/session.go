@@ -0,0 +1,63 @@
+package api
+
+import (
+	"time"
+)
+
+// Session represents an active client connection to a server.
+//
+// swagger:model
+//
+// API extension: sessions.
+type Session struct {
+	// Session identifier
+	// Example: 0cc1d7b6-51b1-4dd9-9b6c-0b4a40b3a450
+	ID string `json:"id" yaml:"id"`
+
+	// Identity of the client (certificate fingerprint or OIDC user)
+	// Example: jdoe@example.com
+	Username string `json:"username" yaml:"username"`
+
+	// Authentication method of the client
+	// Example: oidc
+	Protocol string `json:"protocol" yaml:"protocol"`
+
+	// Address of the client
+	// Example: 10.0.2.15:45378
+	Address string `json:"address" yaml:"address"`
+
+	// Type of connection (events or operation)
+	// Example: operation
+	Type string `json:"type" yaml:"type"`
+
+	// Operation the connection is attached to
+	// Example: /1.0/operations/c1a0dc1f-a8f6-4e36-8fe6-1d5e3d5a2a88
+	Operation string `json:"operation" yaml:"operation"`
+
+	// Description of the connection
+	// Example: Executing command
+	Description string `json:"description" yaml:"description"`
+
+	// What cluster member the client is connected to
+	// Example: server01
+	Location string `json:"location" yaml:"location"`
+
+	// When the connection was established
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+}
+
+// SessionBlock represents an identity whose requests are temporarily rejected.
+//
+// swagger:model
+//
+// API extension: sessions.
+type SessionBlock struct {
+	// Identity of the client (certificate fingerprint or OIDC user)
+	// Example: jdoe@example.com
+	Username string `json:"username" yaml:"username"`
+
+	// When the block expires
+	// Example: 2021-03-23T18:38:37.753398689-04:00
+	ExpiresAt time.Time `json:"expires_at" yaml:"expires_at"`
+}
@@ -62,4 +62,10 @@ type WarningPut struct {
 	// Status of the warning (new, acknowledged, or resolved)
 	// Example: new
 	Status string `json:"status" yaml:"status"`
+
+	// When the acknowledgment of the warning expires, making it new again (acknowledged warnings only)
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	//
+	// API extension: warnings_notifications
+	AcknowledgedUntil *time.Time `json:"acknowledged_until,omitempty" yaml:"acknowledged_until,omitempty"`
 }
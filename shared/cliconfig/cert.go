@@ -20,11 +20,12 @@ func (c *Config) HasClientCertificate() bool {
 	return true
 }
 
-// HasRemoteClientCertificate will return true if a remote-specific client certificate is present.
+// HasRemoteClientCertificate will return true if a remote-specific client certificate is present,
+// its key being either next to it or in the credential helper of the remote.
 func (c *Config) HasRemoteClientCertificate(name string) bool {
 	certf := c.ConfigPath("clientcerts", fmt.Sprintf("%s.crt", name))
 	keyf := c.ConfigPath("clientcerts", fmt.Sprintf("%s.key", name))
-	if !util.PathExists(certf) || !util.PathExists(keyf) {
+	if !util.PathExists(certf) || (!util.PathExists(keyf) && c.Remotes[name].CredentialHelper == "") {
 		return false
 	}
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 
+	incus "github.com/lxc/incus/v6/client"
 	"github.com/lxc/incus/v6/shared/util"
 )
 
@@ -21,6 +22,10 @@ type Config struct {
 	// communication with the named daemon
 	Remotes map[string]Remote `yaml:"remotes"`
 
+	// RemoteGroups defines a map of remote group names to the names of
+	// the remotes they contain, so commands can target all of them at once
+	RemoteGroups map[string][]string `yaml:"remote-groups,omitempty"`
+
 	// Command line aliases for `incus`
 	Aliases map[string]string `yaml:"aliases"`
 
@@ -39,9 +44,15 @@ type Config struct {
 	// ProjectOverride allows overriding the default project
 	ProjectOverride string `yaml:"-"`
 
+	// Impersonate allows running the requests as another identity
+	Impersonate string `yaml:"-"`
+
 	// OIDC tokens
 	oidcTokens map[string]*oidc.Tokens[*oidc.IDTokenClaims]
 
+	// SSH jump hosts
+	sshJumps map[string]*incus.SSHJump
+
 	// Defaults holds default settings for a client or daemon
 	Defaults DefaultSettings `yaml:"defaults"`
 }
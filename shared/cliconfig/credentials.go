@@ -0,0 +1,355 @@
+package cliconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	localtls "github.com/lxc/incus/v6/shared/tls"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// Credential helpers store the secrets of the remotes (TLS client key, OIDC tokens) outside of the
+// configuration directory, following the protocol of the Docker credential helpers: the helper is run
+// with "get", "store" or "erase" as argument and exchanges JSON documents on its standard input
+// and output. This makes the existing helpers for the OS keyrings usable as is.
+
+// credentialHelperPrefixes are the prefixes of the executables of the credential helpers, by order of preference.
+var credentialHelperPrefixes = []string{"incus-credential-", "docker-credential-"}
+
+// credentialNotFound is the message of the credential helpers for missing credentials.
+const credentialNotFound = "credentials not found in native keychain"
+
+// Kinds of the credentials stored for a remote.
+const (
+	credentialClientKey  = "client-key"
+	credentialOIDCTokens = "oidc-tokens"
+)
+
+// errCredentialNotFound is returned when the credential helper doesn't hold the credentials.
+var errCredentialNotFound = errors.New("Credentials not found")
+
+// credentials is the document exchanged with the credential helpers.
+type credentials struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+// credentialHelper runs a credential helper.
+type credentialHelper struct {
+	name string
+}
+
+// command returns the path of the executable of the helper, either given as an absolute path
+// or found in PATH as "incus-credential-<name>" or "docker-credential-<name>".
+func (h *credentialHelper) command() (string, error) {
+	if filepath.IsAbs(h.name) {
+		return h.name, nil
+	}
+
+	for _, prefix := range credentialHelperPrefixes {
+		path, err := exec.LookPath(prefix + h.name)
+		if err == nil {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("Credential helper %q not found in PATH", h.name)
+}
+
+// run runs the helper with the given action and input, returning its output.
+func (h *credentialHelper) run(action string, input string) ([]byte, error) {
+	path, err := h.command()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout := bytes.Buffer{}
+	stderr := bytes.Buffer{}
+
+	cmd := exec.Command(path, action)
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	if err != nil {
+		// The helpers report their errors on the standard output.
+		message := strings.TrimSpace(stdout.String())
+		if message == credentialNotFound {
+			return nil, errCredentialNotFound
+		}
+
+		if message == "" {
+			message = strings.TrimSpace(stderr.String())
+		}
+
+		return nil, fmt.Errorf("Failed running credential helper %q %s: %w (%s)", h.name, action, err, message)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// get returns the secret stored under the label.
+func (h *credentialHelper) get(label string) (string, error) {
+	output, err := h.run("get", label)
+	if err != nil {
+		return "", err
+	}
+
+	creds := credentials{}
+
+	err = json.Unmarshal(output, &creds)
+	if err != nil {
+		return "", fmt.Errorf("Failed parsing the output of credential helper %q: %w", h.name, err)
+	}
+
+	return creds.Secret, nil
+}
+
+// store stores the secret under the label.
+func (h *credentialHelper) store(label string, secret string) error {
+	input, err := json.Marshal(credentials{ServerURL: label, Username: "incus", Secret: secret})
+	if err != nil {
+		return err
+	}
+
+	_, err = h.run("store", string(input))
+
+	return err
+}
+
+// erase removes the secret stored under the label, if any.
+func (h *credentialHelper) erase(label string) error {
+	_, err := h.run("erase", label)
+	if err != nil && !errors.Is(err, errCredentialNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// credentialLabel returns the label of the credentials of the given kind of a remote in its credential helper.
+func credentialLabel(remote string, kind string) string {
+	return fmt.Sprintf("incus://%s/%s", remote, kind)
+}
+
+// credentialHelper returns the credential helper of the remote, or nil if it doesn't use one.
+func (c *Config) credentialHelper(remote string) *credentialHelper {
+	name := c.Remotes[remote].CredentialHelper
+	if name == "" {
+		return nil
+	}
+
+	return &credentialHelper{name: name}
+}
+
+// credentialTokenCache is an incus.OIDCTokenCache storing the OIDC tokens of a remote in its credential helper.
+type credentialTokenCache struct {
+	helper *credentialHelper
+	label  string
+}
+
+// Load gets the tokens from the credential helper, returning nil if it doesn't hold them.
+func (t *credentialTokenCache) Load() (*oidc.Tokens[*oidc.IDTokenClaims], error) {
+	secret, err := t.helper.get(t.label)
+	if err != nil {
+		if errors.Is(err, errCredentialNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	tokens := &oidc.Tokens[*oidc.IDTokenClaims]{}
+
+	err = json.Unmarshal([]byte(secret), tokens)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing OIDC tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// Save stores the tokens in the credential helper.
+func (t *credentialTokenCache) Save(tokens *oidc.Tokens[*oidc.IDTokenClaims]) error {
+	content, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+
+	return t.helper.store(t.label, string(content))
+}
+
+// remoteClientCertPath returns the path of a file of the remote-specific client certificate.
+func (c *Config) remoteClientCertPath(remote string, extension string) string {
+	return c.ConfigPath("clientcerts", fmt.Sprintf("%s.%s", remote, extension))
+}
+
+// GenerateRemoteClientCertificate generates a client certificate for the remote, storing its key
+// in the credential helper of the remote.
+func (c *Config) GenerateRemoteClientCertificate(remote string) error {
+	helper := c.credentialHelper(remote)
+	if helper == nil {
+		return fmt.Errorf("Remote %q doesn't use a credential helper", remote)
+	}
+
+	cert, key, err := localtls.GenerateMemCert(true, false)
+	if err != nil {
+		return err
+	}
+
+	err = helper.store(credentialLabel(remote, credentialClientKey), string(key))
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(c.ConfigPath("clientcerts"), 0o750)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.remoteClientCertPath(remote, "crt"), cert, 0o644)
+}
+
+// StoreRemoteCredentials moves the client key and the OIDC tokens of the remote to its credential helper.
+//
+// The plaintext files of the remote are removed once stored, except for the client key shared
+// by the remotes which don't have their own certificate: the remote then gets a copy of the shared certificate.
+func (c *Config) StoreRemoteCredentials(remote string) error {
+	helper := c.credentialHelper(remote)
+	if helper == nil {
+		return fmt.Errorf("Remote %q doesn't use a credential helper", remote)
+	}
+
+	// OIDC tokens.
+	tokenPath := c.OIDCTokenPath(remote)
+	if util.PathExists(tokenPath) {
+		content, err := os.ReadFile(tokenPath)
+		if err != nil {
+			return err
+		}
+
+		err = helper.store(credentialLabel(remote, credentialOIDCTokens), string(content))
+		if err != nil {
+			return err
+		}
+
+		err = os.Remove(tokenPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Client key.
+	keyPath := c.remoteClientCertPath(remote, "key")
+	if !util.PathExists(keyPath) {
+		if !c.HasClientCertificate() {
+			return nil
+		}
+
+		content, err := os.ReadFile(c.ConfigPath("client.crt"))
+		if err != nil {
+			return err
+		}
+
+		err = os.MkdirAll(c.ConfigPath("clientcerts"), 0o750)
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(c.remoteClientCertPath(remote, "crt"), content, 0o644)
+		if err != nil {
+			return err
+		}
+
+		keyPath = c.ConfigPath("client.key")
+	}
+
+	content, err := os.ReadFile(keyPath)
+	if err != nil {
+		return err
+	}
+
+	err = helper.store(credentialLabel(remote, credentialClientKey), string(content))
+	if err != nil {
+		return err
+	}
+
+	if keyPath == c.ConfigPath("client.key") {
+		return nil
+	}
+
+	return os.Remove(keyPath)
+}
+
+// RenameRemoteCredentials moves the credentials of the remote stored in its credential helper to the new name.
+func (c *Config) RenameRemoteCredentials(remote string, newName string) error {
+	helper := c.credentialHelper(remote)
+	if helper == nil {
+		return nil
+	}
+
+	for _, kind := range []string{credentialClientKey, credentialOIDCTokens} {
+		secret, err := helper.get(credentialLabel(remote, kind))
+		if err != nil {
+			if errors.Is(err, errCredentialNotFound) {
+				continue
+			}
+
+			return err
+		}
+
+		err = helper.store(credentialLabel(newName, kind), secret)
+		if err != nil {
+			return err
+		}
+
+		err = helper.erase(credentialLabel(remote, kind))
+		if err != nil {
+			return err
+		}
+	}
+
+	// The certificate goes along with its key.
+	for _, extension := range []string{"crt", "ca"} {
+		path := c.remoteClientCertPath(remote, extension)
+		if util.PathExists(path) {
+			err := os.Rename(path, c.remoteClientCertPath(newName, extension))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// DeleteRemoteCredentials removes the credentials of the remote stored in its credential helper, along with its certificate.
+func (c *Config) DeleteRemoteCredentials(remote string) error {
+	helper := c.credentialHelper(remote)
+	if helper == nil {
+		return nil
+	}
+
+	for _, kind := range []string{credentialClientKey, credentialOIDCTokens} {
+		err := helper.erase(credentialLabel(remote, kind))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, extension := range []string{"crt", "ca"} {
+		_ = os.Remove(c.remoteClientCertPath(remote, extension))
+	}
+
+	return nil
+}
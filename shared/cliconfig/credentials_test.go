@@ -0,0 +1,261 @@
+package cliconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// credentialHelperStub is a credential helper keeping the documents it's given in a directory.
+const credentialHelperStub = `#!/bin/sh
+input=$(cat)
+label=$input
+if [ "$1" = "store" ]; then
+	label=$(printf '%%s' "$input" | sed 's/.*"ServerURL":"\([^"]*\)".*/\1/')
+fi
+
+file="%s/$(printf '%%s' "$label" | tr '/:' '__')"
+
+case "$1" in
+get|erase)
+	if [ ! -e "$file" ]; then
+		echo "credentials not found in native keychain"
+		exit 1
+	fi
+
+	if [ "$1" = "get" ]; then
+		cat "$file"
+	else
+		rm "$file"
+	fi
+	;;
+store)
+	printf '%%s' "$input" > "$file"
+	;;
+*)
+	echo "unknown action $1" >&2
+	exit 1
+	;;
+esac
+`
+
+// newCredentialHelperStub writes the stub credential helper under the given name in a new directory,
+// returning its path.
+func newCredentialHelperStub(t *testing.T, name string) string {
+	dir := t.TempDir()
+	store := filepath.Join(dir, "store")
+
+	err := os.Mkdir(store, 0o700)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, name)
+
+	err = os.WriteFile(path, fmt.Appendf(nil, credentialHelperStub, store), 0o700)
+	require.NoError(t, err)
+
+	return path
+}
+
+// newCredentialConfig returns a configuration whose remote "foo" uses the stub credential helper.
+func newCredentialConfig(t *testing.T) (*Config, *credentialHelper) {
+	helper := &credentialHelper{name: newCredentialHelperStub(t, "helper")}
+
+	conf := &Config{
+		ConfigDir: t.TempDir(),
+		Remotes:   map[string]Remote{"foo": {Addr: "https://foo:8443", CredentialHelper: helper.name}},
+	}
+
+	return conf, helper
+}
+
+// writeConfigFile writes a file of the configuration directory.
+func writeConfigFile(t *testing.T, conf *Config, content string, paths ...string) {
+	path := conf.ConfigPath(paths...)
+
+	err := os.MkdirAll(filepath.Dir(path), 0o700)
+	require.NoError(t, err)
+
+	err = os.WriteFile(path, []byte(content), 0o600)
+	require.NoError(t, err)
+}
+
+func TestCredentialHelper(t *testing.T) {
+	helper := &credentialHelper{name: newCredentialHelperStub(t, "helper")}
+
+	// Missing credentials.
+	_, err := helper.get("incus://foo/client-key")
+	assert.ErrorIs(t, err, errCredentialNotFound)
+
+	// Stored credentials.
+	err = helper.store("incus://foo/client-key", "secret\nkey")
+	require.NoError(t, err)
+
+	secret, err := helper.get("incus://foo/client-key")
+	require.NoError(t, err)
+	assert.Equal(t, "secret\nkey", secret)
+
+	_, err = helper.get("incus://bar/client-key")
+	assert.ErrorIs(t, err, errCredentialNotFound)
+
+	// Erased credentials, erasing missing ones being fine.
+	err = helper.erase("incus://foo/client-key")
+	require.NoError(t, err)
+
+	_, err = helper.get("incus://foo/client-key")
+	assert.ErrorIs(t, err, errCredentialNotFound)
+
+	err = helper.erase("incus://foo/client-key")
+	assert.NoError(t, err)
+}
+
+func TestCredentialHelperErrors(t *testing.T) {
+	helper := &credentialHelper{name: newCredentialHelperStub(t, "helper")}
+
+	// The errors of the helper are reported.
+	_, err := helper.run("list", "")
+	assert.ErrorContains(t, err, "unknown action list")
+
+	// Executables other than the absolute paths are looked up in PATH.
+	dir := filepath.Dir(helper.name)
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	helper = &credentialHelper{name: "helper"}
+	_, err = helper.command()
+	assert.Error(t, err)
+
+	err = os.Rename(filepath.Join(dir, "helper"), filepath.Join(dir, "docker-credential-helper"))
+	require.NoError(t, err)
+
+	path, err := helper.command()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "docker-credential-helper"), path)
+
+	err = helper.store("incus://foo/client-key", "secret")
+	assert.NoError(t, err)
+
+	// The Incus helpers are preferred to the Docker ones.
+	err = os.WriteFile(filepath.Join(dir, "incus-credential-helper"), []byte("#!/bin/sh\n"), 0o700)
+	require.NoError(t, err)
+
+	path, err = helper.command()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "incus-credential-helper"), path)
+}
+
+func TestStoreRemoteCredentials(t *testing.T) {
+	conf, helper := newCredentialConfig(t)
+
+	// Remotes without a credential helper are rejected.
+	conf.Remotes["bar"] = Remote{Addr: "https://bar:8443"}
+
+	err := conf.StoreRemoteCredentials("bar")
+	assert.Error(t, err)
+
+	// The files of the remote are moved to the helper.
+	writeConfigFile(t, conf, `{"access_token":"token"}`, "oidctokens", "foo.json")
+	writeConfigFile(t, conf, "foo key", "clientcerts", "foo.key")
+
+	err = conf.StoreRemoteCredentials("foo")
+	require.NoError(t, err)
+
+	assert.NoFileExists(t, conf.OIDCTokenPath("foo"))
+	assert.NoFileExists(t, conf.ConfigPath("clientcerts", "foo.key"))
+
+	secret, err := helper.get(credentialLabel("foo", credentialOIDCTokens))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"access_token":"token"}`, secret)
+
+	secret, err = helper.get(credentialLabel("foo", credentialClientKey))
+	require.NoError(t, err)
+	assert.Equal(t, "foo key", secret)
+}
+
+func TestStoreRemoteCredentialsShared(t *testing.T) {
+	conf, helper := newCredentialConfig(t)
+
+	// Without any client certificate, there's nothing to store.
+	err := conf.StoreRemoteCredentials("foo")
+	require.NoError(t, err)
+
+	_, err = helper.get(credentialLabel("foo", credentialClientKey))
+	assert.ErrorIs(t, err, errCredentialNotFound)
+
+	// The shared key is copied to the helper and kept, the remote getting a copy of the shared certificate.
+	writeConfigFile(t, conf, "shared cert", "client.crt")
+	writeConfigFile(t, conf, "shared key", "client.key")
+
+	err = conf.StoreRemoteCredentials("foo")
+	require.NoError(t, err)
+
+	assert.FileExists(t, conf.ConfigPath("client.key"))
+
+	content, err := os.ReadFile(conf.ConfigPath("clientcerts", "foo.crt"))
+	require.NoError(t, err)
+	assert.Equal(t, "shared cert", string(content))
+
+	secret, err := helper.get(credentialLabel("foo", credentialClientKey))
+	require.NoError(t, err)
+	assert.Equal(t, "shared key", secret)
+}
+
+func TestRenameRemoteCredentials(t *testing.T) {
+	conf, helper := newCredentialConfig(t)
+
+	err := helper.store(credentialLabel("foo", credentialClientKey), "foo key")
+	require.NoError(t, err)
+
+	writeConfigFile(t, conf, "foo cert", "clientcerts", "foo.crt")
+
+	// Only the stored credentials are moved, the missing OIDC tokens being skipped.
+	err = conf.RenameRemoteCredentials("foo", "baz")
+	require.NoError(t, err)
+
+	_, err = helper.get(credentialLabel("foo", credentialClientKey))
+	assert.ErrorIs(t, err, errCredentialNotFound)
+
+	secret, err := helper.get(credentialLabel("baz", credentialClientKey))
+	require.NoError(t, err)
+	assert.Equal(t, "foo key", secret)
+
+	_, err = helper.get(credentialLabel("baz", credentialOIDCTokens))
+	assert.ErrorIs(t, err, errCredentialNotFound)
+
+	assert.NoFileExists(t, conf.ConfigPath("clientcerts", "foo.crt"))
+	assert.FileExists(t, conf.ConfigPath("clientcerts", "baz.crt"))
+
+	// Remotes without a credential helper have nothing to move.
+	conf.Remotes["bar"] = Remote{Addr: "https://bar:8443"}
+
+	err = conf.RenameRemoteCredentials("bar", "qux")
+	assert.NoError(t, err)
+}
+
+func TestDeleteRemoteCredentials(t *testing.T) {
+	conf, helper := newCredentialConfig(t)
+
+	err := helper.store(credentialLabel("foo", credentialClientKey), "foo key")
+	require.NoError(t, err)
+
+	err = helper.store(credentialLabel("foo", credentialOIDCTokens), "{}")
+	require.NoError(t, err)
+
+	writeConfigFile(t, conf, "foo cert", "clientcerts", "foo.crt")
+
+	err = conf.DeleteRemoteCredentials("foo")
+	require.NoError(t, err)
+
+	for _, kind := range []string{credentialClientKey, credentialOIDCTokens} {
+		_, err = helper.get(credentialLabel("foo", kind))
+		assert.ErrorIs(t, err, errCredentialNotFound)
+	}
+
+	assert.NoFileExists(t, conf.ConfigPath("clientcerts", "foo.crt"))
+
+	// Deleting again is fine.
+	err = conf.DeleteRemoteCredentials("foo")
+	assert.NoError(t, err)
+}
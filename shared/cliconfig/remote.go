@@ -12,6 +12,7 @@ import (
 	"os"
 	"runtime"
 	"slices"
+	"sort"
 	"strings"
 	"time"
 
@@ -25,14 +26,16 @@ import (
 
 // Remote holds details for communication with a remote daemon.
 type Remote struct {
-	Addr      string `yaml:"addr"`
-	AuthType  string `yaml:"auth_type,omitempty"`
-	KeepAlive int    `yaml:"keepalive,omitempty"`
-	Project   string `yaml:"project,omitempty"`
-	Protocol  string `yaml:"protocol,omitempty"`
-	Public    bool   `yaml:"public"`
-	Global    bool   `yaml:"-"`
-	Static    bool   `yaml:"-"`
+	Addr             string `yaml:"addr"`
+	AuthType         string `yaml:"auth_type,omitempty"`
+	KeepAlive        int    `yaml:"keepalive,omitempty"`
+	Project          string `yaml:"project,omitempty"`
+	Protocol         string `yaml:"protocol,omitempty"`
+	Public           bool   `yaml:"public"`
+	SSHJump          string `yaml:"ssh_jump,omitempty"`
+	CredentialHelper string `yaml:"credential_helper,omitempty"`
+	Global           bool   `yaml:"-"`
+	Static           bool   `yaml:"-"`
 }
 
 // ParseRemote splits remote and object.
@@ -43,7 +46,7 @@ func (c *Config) ParseRemote(raw string) (string, string, error) {
 	}
 
 	_, ok := c.Remotes[result[0]]
-	if !ok {
+	if !ok && !c.IsRemoteGroup(result[0]) {
 		// Attempt to play nice with snapshots containing ":"
 		if strings.Contains(raw, "/") && strings.Contains(result[0], "/") {
 			return c.DefaultRemote, raw, nil
@@ -55,6 +58,93 @@ func (c *Config) ParseRemote(raw string) (string, string, error) {
 	return result[0], result[1], nil
 }
 
+// RemoteGroupPrefix is the prefix of the remote group names used in place of a remote name ("@group:").
+const RemoteGroupPrefix = "@"
+
+// RemoteGroupAll is the name of the implicit remote group containing all the remotes ("@all:").
+const RemoteGroupAll = "all"
+
+// IsRemoteGroup returns whether the name refers to a remote group ("@group").
+func (c *Config) IsRemoteGroup(name string) bool {
+	group, prefixed := strings.CutPrefix(name, RemoteGroupPrefix)
+	if !prefixed {
+		return false
+	}
+
+	if group == RemoteGroupAll {
+		return true
+	}
+
+	_, ok := c.RemoteGroups[group]
+	return ok
+}
+
+// GetInstanceServers returns an InstanceServer struct for each remote of a remote group, keyed by remote name.
+// A remote name is also accepted, in which case a single server is returned.
+// Remotes which can't be connected to are left out of the returned servers and reported through the returned error.
+func (c *Config) GetInstanceServers(name string) (map[string]incus.InstanceServer, error) {
+	servers := map[string]incus.InstanceServer{}
+	errs := []error{}
+
+	for _, remote := range c.remoteGroupMembers(name) {
+		// Skip the image servers of the implicit group of all remotes.
+		if name == RemoteGroupPrefix+RemoteGroupAll && (c.Remotes[remote].Public || c.Remotes[remote].Protocol != "incus") {
+			continue
+		}
+
+		server, err := c.GetInstanceServer(remote)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Failed connecting to remote %q: %w", remote, err))
+			continue
+		}
+
+		servers[remote] = server
+	}
+
+	return servers, errors.Join(errs...)
+}
+
+// GetImageServers returns an ImageServer struct for each remote of a remote group, keyed by remote name.
+// A remote name is also accepted, in which case a single server is returned.
+// Remotes which can't be connected to are left out of the returned servers and reported through the returned error.
+func (c *Config) GetImageServers(name string) (map[string]incus.ImageServer, error) {
+	servers := map[string]incus.ImageServer{}
+	errs := []error{}
+
+	for _, remote := range c.remoteGroupMembers(name) {
+		server, err := c.GetImageServer(remote)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Failed connecting to remote %q: %w", remote, err))
+			continue
+		}
+
+		servers[remote] = server
+	}
+
+	return servers, errors.Join(errs...)
+}
+
+// remoteGroupMembers returns the remotes referred to by a remote or remote group name.
+func (c *Config) remoteGroupMembers(name string) []string {
+	if !c.IsRemoteGroup(name) {
+		return []string{name}
+	}
+
+	group := strings.TrimPrefix(name, RemoteGroupPrefix)
+	if name == RemoteGroupPrefix+RemoteGroupAll {
+		remotes := make([]string, 0, len(c.Remotes))
+		for remote := range c.Remotes {
+			remotes = append(remotes, remote)
+		}
+
+		sort.Strings(remotes)
+
+		return remotes
+	}
+
+	return c.RemoteGroups[group]
+}
+
 // GetInstanceServer returns a InstanceServer struct for the remote.
 func (c *Config) GetInstanceServer(name string) (incus.InstanceServer, error) {
 	// Handle "local" on non-Linux
@@ -65,6 +155,10 @@ func (c *Config) GetInstanceServer(name string) (incus.InstanceServer, error) {
 	// Get the remote
 	remote, ok := c.Remotes[name]
 	if !ok {
+		if c.IsRemoteGroup(name) {
+			return nil, fmt.Errorf("\"%s\" is a remote group, which isn't supported by this command", name)
+		}
+
 		return nil, fmt.Errorf("The remote \"%s\" doesn't exist", name)
 	}
 
@@ -112,6 +206,24 @@ func (c *Config) GetInstanceServer(name string) (incus.InstanceServer, error) {
 		return d, nil
 	}
 
+	// Unix socket over SSH
+	if strings.HasPrefix(remote.Addr, "ssh:") {
+		d, err := incus.ConnectIncusSSH(remote.Addr, args)
+		if err != nil {
+			return nil, err
+		}
+
+		if remote.Project != "" && remote.Project != "default" {
+			d = d.UseProject(remote.Project)
+		}
+
+		if c.ProjectOverride != "" {
+			d = d.UseProject(c.ProjectOverride)
+		}
+
+		return d, nil
+	}
+
 	// HTTPs
 	if !slices.Contains([]string{api.AuthenticationMethodOIDC}, remote.AuthType) && (args.TLSClientCert == "" || args.TLSClientKey == "") {
 		return nil, fmt.Errorf("Missing TLS client certificate and key")
@@ -155,6 +267,10 @@ func (c *Config) GetImageServer(name string) (incus.ImageServer, error) {
 	// Get the remote
 	remote, ok := c.Remotes[name]
 	if !ok {
+		if c.IsRemoteGroup(name) {
+			return nil, fmt.Errorf("\"%s\" is a remote group, which isn't supported by this command", name)
+		}
+
 		return nil, fmt.Errorf("The remote \"%s\" doesn't exist", name)
 	}
 
@@ -188,6 +304,24 @@ func (c *Config) GetImageServer(name string) (incus.ImageServer, error) {
 		return d, nil
 	}
 
+	// Unix socket over SSH
+	if strings.HasPrefix(remote.Addr, "ssh:") {
+		d, err := incus.ConnectIncusSSH(remote.Addr, args)
+		if err != nil {
+			return nil, err
+		}
+
+		if remote.Project != "" && remote.Project != "default" {
+			d = d.UseProject(remote.Project)
+		}
+
+		if c.ProjectOverride != "" {
+			d = d.UseProject(c.ProjectOverride)
+		}
+
+		return d, nil
+	}
+
 	// HTTPs (simplestreams)
 	if remote.Protocol == "simplestreams" {
 		d, err := incus.ConnectSimpleStreams(remote.Addr, args)
@@ -242,11 +376,16 @@ func (c *Config) GetImageServer(name string) (incus.ImageServer, error) {
 func (c *Config) getConnectionArgs(name string) (*incus.ConnectionArgs, error) {
 	remote := c.Remotes[name]
 	args := incus.ConnectionArgs{
-		UserAgent: c.UserAgent,
-		AuthType:  remote.AuthType,
+		UserAgent:   c.UserAgent,
+		AuthType:    remote.AuthType,
+		Impersonate: c.Impersonate,
 	}
 
-	if args.AuthType == api.AuthenticationMethodOIDC {
+	helper := c.credentialHelper(name)
+
+	if args.AuthType == api.AuthenticationMethodOIDC && helper != nil {
+		args.OIDCTokenCache = &credentialTokenCache{helper: helper, label: credentialLabel(name, credentialOIDCTokens)}
+	} else if args.AuthType == api.AuthenticationMethodOIDC {
 		if c.oidcTokens == nil {
 			c.oidcTokens = map[string]*oidc.Tokens[*oidc.IDTokenClaims]{}
 		}
@@ -277,10 +416,20 @@ func (c *Config) getConnectionArgs(name string) (*incus.ConnectionArgs, error) {
 	}
 
 	// Stop here if no TLS involved
-	if strings.HasPrefix(remote.Addr, "unix:") {
+	if strings.HasPrefix(remote.Addr, "unix:") || strings.HasPrefix(remote.Addr, "ssh:") {
 		return &args, nil
 	}
 
+	// SSH jump host
+	if remote.SSHJump != "" {
+		jump, err := c.SSHJump(remote.SSHJump)
+		if err != nil {
+			return nil, err
+		}
+
+		args.SSHJump = jump
+	}
+
 	// Server certificate
 	if util.PathExists(c.ServerCertPath(name)) {
 		content, err := os.ReadFile(c.ServerCertPath(name))
@@ -330,6 +479,17 @@ func (c *Config) getConnectionArgs(name string) (*incus.ConnectionArgs, error) {
 		args.TLSCA = string(content)
 	}
 
+	// Client key from the credential helper
+	if helper != nil {
+		key, err := helper.get(credentialLabel(name, credentialClientKey))
+		if err == nil {
+			args.TLSClientKey = key
+			return &args, nil
+		} else if !errors.Is(err, errCredentialNotFound) {
+			return nil, err
+		}
+	}
+
 	// Client key
 	if util.PathExists(pathClientKey) {
 		content, err := os.ReadFile(pathClientKey)
@@ -389,3 +549,25 @@ func (c *Config) getConnectionArgs(name string) (*incus.ConnectionArgs, error) {
 
 	return &args, nil
 }
+
+// SSHJump returns the tunnel through the SSH jump host at the given address ("[user@]host[:port]"),
+// shared by the remotes using the same jump host.
+func (c *Config) SSHJump(address string) (*incus.SSHJump, error) {
+	jump, ok := c.sshJumps[address]
+	if ok {
+		return jump, nil
+	}
+
+	jump, err := incus.NewSSHJump(address, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.sshJumps == nil {
+		c.sshJumps = map[string]*incus.SSHJump{}
+	}
+
+	c.sshJumps[address] = jump
+
+	return jump, nil
+}
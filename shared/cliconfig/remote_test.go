@@ -0,0 +1,25 @@
+package cliconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRemoteGroup(t *testing.T) {
+	conf := &Config{
+		Remotes:      map[string]Remote{"foo": {Addr: "https://foo:8443"}, "prod": {Addr: "https://prod:8443"}},
+		RemoteGroups: map[string][]string{"prod": {"foo"}},
+	}
+
+	// Remote groups require the prefix.
+	assert.True(t, conf.IsRemoteGroup("@prod"))
+	assert.True(t, conf.IsRemoteGroup("@all"))
+	assert.False(t, conf.IsRemoteGroup("prod"))
+	assert.False(t, conf.IsRemoteGroup("all"))
+	assert.False(t, conf.IsRemoteGroup("@foo"))
+	assert.False(t, conf.IsRemoteGroup("foo"))
+
+	assert.Equal(t, []string{"foo"}, conf.remoteGroupMembers("@prod"))
+	assert.Equal(t, []string{"prod"}, conf.remoteGroupMembers("prod"))
+}
@@ -1,5 +1,29 @@
 package ioprogress
 
+// Stages of the transfers reported by the client.
+const (
+	// StageImageDownload is the download of an image file from a server.
+	StageImageDownload = "image_download"
+
+	// StageImageUnpack is the conversion of a downloaded image into the Incus format.
+	StageImageUnpack = "image_unpack"
+
+	// StageImageUpload is the upload of an image file to a server.
+	StageImageUpload = "image_upload"
+
+	// StageBackupDownload is the download of an exported backup from a server.
+	StageBackupDownload = "backup_download"
+
+	// StageBackupUpload is the upload of a backup to import on a server.
+	StageBackupUpload = "backup_upload"
+
+	// StageFilePush is the upload of a file to an instance or volume.
+	StageFilePush = "file_push"
+
+	// StageFilePull is the download of a file from an instance or volume.
+	StageFilePull = "file_pull"
+)
+
 // The ProgressData struct represents new progress information on an operation.
 type ProgressData struct {
 	// Preferred string representation of progress (always set)
@@ -13,4 +37,10 @@ type ProgressData struct {
 
 	// Total number of bytes (for files)
 	TotalBytes int64
+
+	// Transfer rate in bytes per second
+	Speed int64
+
+	// Stage of the transfer or operation the progress applies to (one of the Stage constants for transfers)
+	Stage string
 }
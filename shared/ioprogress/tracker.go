@@ -1,7 +1,10 @@
 package ioprogress
 
 import (
+	"fmt"
 	"time"
+
+	"github.com/lxc/incus/v6/shared/units"
 )
 
 // ProgressTracker provides the stream information needed for tracking.
@@ -75,3 +78,29 @@ func (pt *ProgressTracker) update(n int) {
 
 	pt.Handler(progressInt, speedInt)
 }
+
+// NewProgressTracker returns a tracker for a transfer of the given length, 0 if unknown, reporting the
+// transferred bytes, percentage and rate of the transfer to the handler under the given stage.
+func NewProgressTracker(stage string, length int64, handler func(ProgressData)) *ProgressTracker {
+	tracker := &ProgressTracker{Length: length}
+
+	tracker.Handler = func(value int64, speed int64) {
+		data := ProgressData{
+			TransferredBytes: tracker.total,
+			TotalBytes:       max(length, 0),
+			Speed:            speed,
+			Stage:            stage,
+		}
+
+		if length > 0 {
+			data.Percentage = int(value)
+			data.Text = fmt.Sprintf("%d%% (%s/s)", value, units.GetByteSizeString(speed, 2))
+		} else {
+			data.Text = fmt.Sprintf("%s (%s/s)", units.GetByteSizeString(tracker.total, 2), units.GetByteSizeString(speed, 2))
+		}
+
+		handler(data)
+	}
+
+	return tracker
+}
@@ -0,0 +1,41 @@
+package ioprogress
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The trackers report the bytes transferred, total size, percentage and stage of the transfer.
+func TestNewProgressTracker(t *testing.T) {
+	updates := []ProgressData{}
+
+	reader := &ProgressReader{
+		Reader: bytes.NewReader(make([]byte, 100)),
+		Tracker: NewProgressTracker(StageFilePush, 100, func(data ProgressData) {
+			updates = append(updates, data)
+		}),
+	}
+
+	buf := make([]byte, 10)
+	for {
+		_, err := reader.Read(buf)
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+	}
+
+	require.NotEmpty(t, updates)
+
+	last := updates[len(updates)-1]
+	assert.Equal(t, StageFilePush, last.Stage)
+	assert.Equal(t, int64(100), last.TransferredBytes)
+	assert.Equal(t, int64(100), last.TotalBytes)
+	assert.Equal(t, 100, last.Percentage)
+	assert.NotEmpty(t, last.Text)
+}
@@ -13,6 +13,17 @@ import (
 func ExtractConn(conn net.Conn) (*net.TCPConn, error) {
 	var tcpConn *net.TCPConn
 
+	// Unwrap connections wrapping another one, such as the ones of tracked client sessions.
+	for {
+		_, isTLS := conn.(*tls.Conn)
+		wrapper, ok := conn.(interface{ NetConn() net.Conn })
+		if isTLS || !ok {
+			break
+		}
+
+		conn = wrapper.NetConn()
+	}
+
 	// Go doesn't currently expose the underlying TCP connection of a TLS connection, but we need it in order
 	// to set timeout properties on the connection. We use some reflect/unsafe magic to extract the private
 	// remote.conn field, which is indeed the underlying TCP connection.
@@ -6,6 +6,7 @@
 package tls
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
@@ -407,6 +408,12 @@ func CertFingerprintStr(c string) (string, error) {
 
 // GetRemoteCertificate gets the x509 certificate from a remote HTTPS server.
 func GetRemoteCertificate(address string, useragent string) (*x509.Certificate, error) {
+	return GetRemoteCertificateWithDialer(address, useragent, nil)
+}
+
+// GetRemoteCertificateWithDialer gets the x509 certificate from a remote HTTPS server, connecting to it with the
+// dialer (like an SSH tunnel) instead of directly or through the proxy of the environment if not nil.
+func GetRemoteCertificateWithDialer(address string, useragent string, dialer func(ctx context.Context, network string, address string) (net.Conn, error)) (*x509.Certificate, error) {
 	// Setup a permissive TLS config
 	tlsConfig, err := GetTLSConfig(nil)
 	if err != nil {
@@ -424,6 +431,11 @@ func GetRemoteCertificate(address string, useragent string) (*x509.Certificate,
 		TLSHandshakeTimeout:   time.Second * 5,
 	}
 
+	if dialer != nil {
+		tr.DialContext = dialer
+		tr.Proxy = nil
+	}
+
 	// Connect
 	req, err := http.NewRequest("GET", address, nil)
 	if err != nil {
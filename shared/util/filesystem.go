@@ -4,6 +4,7 @@ import (
 	"errors"
 	"io/fs"
 	"os"
+	"path/filepath"
 )
 
 // PathExists checks if the provided path exists.
@@ -15,3 +16,19 @@ func PathExists(name string) bool {
 
 	return true
 }
+
+// RootlessDir returns the data directory of a rootless daemon for the current user,
+// $XDG_DATA_HOME/incus or ~/.local/share/incus.
+func RootlessDir() (string, error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+
+	return filepath.Join(dataHome, "incus"), nil
+}
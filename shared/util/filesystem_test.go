@@ -0,0 +1,31 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRootlessDir(t *testing.T) {
+	// From $XDG_DATA_HOME.
+	t.Setenv("XDG_DATA_HOME", "/data")
+	t.Setenv("HOME", "/home/user")
+
+	dir, err := RootlessDir()
+	require.NoError(t, err)
+	assert.Equal(t, "/data/incus", dir)
+
+	// Falling back to the home directory.
+	t.Setenv("XDG_DATA_HOME", "")
+
+	dir, err = RootlessDir()
+	require.NoError(t, err)
+	assert.Equal(t, "/home/user/.local/share/incus", dir)
+
+	// Without a home directory.
+	t.Setenv("HOME", "")
+
+	_, err = RootlessDir()
+	assert.Error(t, err)
+}
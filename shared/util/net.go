@@ -10,7 +10,6 @@ import (
 
 	"github.com/lxc/incus/v6/shared/cancel"
 	"github.com/lxc/incus/v6/shared/ioprogress"
-	"github.com/lxc/incus/v6/shared/units"
 )
 
 // ErrNotFound is used to explicitly signal error cases, where a resource
@@ -62,16 +61,13 @@ func DownloadFileHash(ctx context.Context, httpClient *http.Client, useragent st
 	if progress != nil {
 		body = &ioprogress.ProgressReader{
 			ReadCloser: r.Body,
-			Tracker: &ioprogress.ProgressTracker{
-				Length: r.ContentLength,
-				Handler: func(percent int64, speed int64) {
-					if filename != "" {
-						progress(ioprogress.ProgressData{Text: fmt.Sprintf("%s: %d%% (%s/s)", filename, percent, units.GetByteSizeString(speed, 2))})
-					} else {
-						progress(ioprogress.ProgressData{Text: fmt.Sprintf("%d%% (%s/s)", percent, units.GetByteSizeString(speed, 2))})
-					}
-				},
-			},
+			Tracker: ioprogress.NewProgressTracker(ioprogress.StageImageDownload, r.ContentLength, func(data ioprogress.ProgressData) {
+				if filename != "" {
+					data.Text = fmt.Sprintf("%s: %s", filename, data.Text)
+				}
+
+				progress(data)
+			}),
 		}
 	}
 
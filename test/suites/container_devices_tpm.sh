@@ -22,6 +22,29 @@ test_container_devices_tpm() {
   ! incus exec "${ctName}" -- stat /dev/tpm0
   ! incus exec "${ctName}" -- stat /dev/tpmrm0
 
+  # Check that the TPM state follows the instance through snapshots, copies and exports.
+  # The state is checked while the instances are stopped, as starting them creates an empty one if it is missing.
+  incus config device add "${ctName}" test-dev2 tpm path=/dev/tpm0 pathrm=/dev/tpmrm0
+  [ -n "$(ls -A "${INCUS_DIR}/containers/${ctName}/tpm.test-dev2")" ]
+  incus snapshot create "${ctName}" snap0
+  incus copy "${ctName}" "${ctName}-copy"
+  incus export "${ctName}" "${INCUS_DIR}/${ctName}.tar.gz"
+  incus import "${INCUS_DIR}/${ctName}.tar.gz" "${ctName}-import"
+  rm "${INCUS_DIR}/${ctName}.tar.gz"
+
+  incus stop -f "${ctName}"
+  for inst in "${ctName}-copy" "${ctName}-import"; do
+    [ -n "$(ls -A "${INCUS_DIR}/containers/${inst}/tpm.test-dev2")" ]
+    incus start "${inst}"
+    incus exec "${inst}" -- stat /dev/tpm0
+    incus rm -f "${inst}"
+  done
+
+  incus snapshot restore "${ctName}" snap0
+  [ -n "$(ls -A "${INCUS_DIR}/containers/${ctName}/tpm.test-dev2")" ]
+  incus start "${ctName}"
+  incus exec "${ctName}" -- stat /dev/tpm0
+
   # Clean up
   incus rm -f "${ctName}"
 }